@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/internal/fork"
+	"github.com/ava-labs/subnet-cli/internal/metrics"
+)
+
+// avaxDenomination is the number of decimals of AVAX (i.e., nAVAX).
+const avaxDenomination = 9
+
+var ErrNotElastic = errors.New("subnet is not elastic")
+
+// Asset is the description of an X-Chain asset.
+type Asset struct {
+	ID     ids.ID
+	Name   string
+	Symbol string
+	// Denomination is the number of decimals of the asset amounts.
+	Denomination uint8
+}
+
+// notElasticErrors are the substrings of the errors returned by the node
+// for the subnets without any staking asset (ref. "platformvm.Service").
+var notElasticErrors = []string{
+	"doesn't have a valid staking token",
+	"subnet transformation",
+}
+
+func (pc *p) StakingAsset(ctx context.Context, subnetID ids.ID) (*Asset, error) {
+	if subnetID == ids.Empty {
+		return nil, ErrEmptyID
+	}
+	if subnetID != constants.PrimaryNetworkID {
+		// no subnet is elastic before Banff
+		if c, err := pc.Capabilities(ctx); err == nil && !c.PermissionlessValidators {
+			return nil, fmt.Errorf("%w: %s is not transformed (%s is not activated)", ErrNotElastic, subnetID, fork.Banff.Name)
+		}
+	}
+
+	reqStart := time.Now()
+	assetID, err := pc.cli.GetStakingAssetID(ctx, subnetID)
+	metrics.ObserveAPI("platform.getStakingAssetID", reqStart, err)
+	if err != nil {
+		msg := strings.ToLower(err.Error())
+		for _, s := range notElasticErrors {
+			if strings.Contains(msg, s) {
+				return nil, fmt.Errorf("%w: %v", ErrNotElastic, err)
+			}
+		}
+		return nil, err
+	}
+	return pc.Asset(ctx, assetID)
+}
+
+func (pc *p) Asset(ctx context.Context, assetID ids.ID) (*Asset, error) {
+	if assetID == pc.assetID {
+		return &Asset{ID: assetID, Name: "Avalanche", Symbol: "AVAX", Denomination: avaxDenomination}, nil
+	}
+
+	zap.L().Info("fetching asset", zap.String("assetId", assetID.String()))
+	reqStart := time.Now()
+	desc, err := pc.x.GetAssetDescription(ctx, assetID.String())
+	metrics.ObserveAPI("avm.getAssetDescription", reqStart, err)
+	if err != nil {
+		return nil, err
+	}
+	return &Asset{
+		ID:           assetID,
+		Name:         desc.Name,
+		Symbol:       desc.Symbol,
+		Denomination: uint8(desc.Denomination),
+	}, nil
+}
@@ -15,6 +15,8 @@ import (
 	avago_constants "github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
+	internal_avax "github.com/ava-labs/subnet-cli/internal/avax"
+	"github.com/ava-labs/subnet-cli/internal/fees"
 	internal_platformvm "github.com/ava-labs/subnet-cli/internal/platformvm"
 	"github.com/ava-labs/subnet-cli/internal/poll"
 	"go.uber.org/zap"
@@ -30,14 +32,126 @@ type Config struct {
 	URI          string
 	u            *url.URL
 	PollInterval time.Duration
+	// UTXOs are the raw UTXOs to spend instead of fetching them from
+	// the network, for offline tx building. Nil to query the network.
+	// The UTXOs spent by the committed txs are dropped.
+	UTXOs [][]byte
+	// Offline is the network of "UTXOs", used instead of querying the
+	// network for its IDs (e.g., on an air-gapped machine). Nil to query
+	// the network.
+	Offline *Offline
+	// OnIssued is called once a tx is issued by the client, before it is
+	// committed. Nil to skip.
+	OnIssued func(Issued)
+	// OnCommitted is called once a tx issued by the client is committed,
+	// e.g., to record a receipt. Nil to skip.
+	OnCommitted func(Committed)
+	// CheckTx is called with the addresses of the signing keys before a
+	// built tx is signed or proposed, to reject it (e.g., on the address
+	// allowlist). Nil to skip.
+	CheckTx func(utx platformvm.UnsignedTx, self []ids.ShortID) error
+	// MaxTxRetries is the number of times a dropped tx is retried: issued
+	// again as is if the node may accept it later, or built again with the
+	// refetched UTXOs (and a later start time) if its UTXOs were consumed
+	// or its start time passed. Zero to not retry.
+	MaxTxRetries int
+	// Deterministic builds the same txs from the same UTXOs and parameters:
+	// the UTXOs are spent in the order of their IDs, and the change of each
+	// owner is merged into a single output.
+	Deterministic bool
+	// LocktimeAt is the time to check the locktimes of the UTXOs and of the
+	// subnet owners at (e.g., the fetch time of "UTXOs"). Zero for now.
+	LocktimeAt time.Time
+	// OnSigning is called with the canonical digest of each tx before it
+	// is signed or proposed. Nil to skip.
+	OnSigning func(Signing)
+	// Cache caches the subnets, the blockchains, the validators, and the
+	// fee config across the runs. Nil to always query the network.
+	Cache Cache
+	// FeeOverride overrides the fees of the network (ref. "info.getTxFee"),
+	// e.g., on the custom networks of another fee config. Nil to use the
+	// fees of the network.
+	FeeOverride fees.Override
+	// LockDir is the directory of the wallet locks, held while the UTXOs
+	// of a wallet are spent by the txs of the client, for the processes
+	// sharing a key to spend its UTXOs one after the other. Empty to not
+	// lock.
+	LockDir string
 }
 
+// Cache is the store of the network objects, whose entries may expire.
+type Cache interface {
+	// Get decodes the entry of [key] into [v], and returns false on a miss.
+	Get(key string, v interface{}) bool
+	// Put stores [v] as the entry of [key].
+	Put(key string, v interface{}) error
+}
+
+// cached decodes the entry of [name] into [v] from "Config.Cache" on a hit.
+// Otherwise, it calls [fetch] to fill [v], and caches [v].
+func (cfg Config) cached(name string, v interface{}, fetch func() error) error {
+	if cfg.Cache == nil {
+		return fetch()
+	}
+	// the same names on different endpoints are different entries
+	key := cfg.u.Host + "/" + name
+	if cfg.Cache.Get(key, v) {
+		zap.L().Debug("cache hit", zap.String("key", key))
+		return nil
+	}
+	if err := fetch(); err != nil {
+		return err
+	}
+	if err := cfg.Cache.Put(key, v); err != nil {
+		zap.L().Warn("failed to cache", zap.String("key", key), zap.Error(err))
+	}
+	return nil
+}
+
+// Offline is the network information saved along with the UTXOs
+// (ref. "utxos export").
+type Offline struct {
+	NetworkID uint32
+	XChainID  ids.ID
+	AssetID   ids.ID
+}
+
+// Issued is a tx issued by the client, not yet committed.
+type Issued struct {
+	TxID ids.ID
+	// TxType is the tx type as recorded by the metrics (e.g., "create_subnet").
+	TxType string
+}
+
+// Signing is a tx built by the client, about to be signed.
+type Signing struct {
+	// TxType is the tx type (e.g., "CreateSubnetTx").
+	TxType string
+	// Digest is the canonical digest of the unsigned tx (ref. "txs.Digest").
+	Digest string
+}
+
+// Committed is a tx issued by the client, once committed.
+type Committed struct {
+	TxID ids.ID
+	// TxType is the tx type as recorded by the metrics (e.g., "create_subnet").
+	TxType string
+	// Fee is the AVAX burned by the tx.
+	Fee  uint64
+	Took time.Duration
+}
+
+//go:generate mockgen -copyright_file=../LICENSE.header -destination=mocks/mocks.go -package=mocks github.com/ava-labs/subnet-cli/client Client,P,Info,Health,KeyStore
+
 var _ Client = &client{}
 
 type Client interface {
 	NetworkID() uint32
+	// AssetID returns the ID of AVAX on the network.
+	AssetID() ids.ID
 	Config() Config
 	Info() Info
+	Health() Health
 	KeyStore() KeyStore
 	P() P
 }
@@ -53,6 +167,7 @@ type client struct {
 	pChainID    ids.ID
 
 	i *info
+	h *health
 	k *keyStore
 	p *p
 }
@@ -75,48 +190,24 @@ func New(cfg Config) (Client, error) {
 		cfg:      cfg,
 		pChainID: avago_constants.PlatformChainID,
 		i:        newInfo(cfg),
+		h:        newHealth(cfg),
 		k:        newKeyStore(cfg),
 	}
 
-	zap.L().Info("fetching X-Chain id")
-	xChainID, err := cli.i.Client().GetBlockchainID(context.TODO(), "X")
-	if err != nil {
+	if cfg.Offline != nil {
+		cli.networkID = cfg.Offline.NetworkID
+		cli.networkName = avago_constants.NetworkName(cli.networkID)
+		cli.xChainID = cfg.Offline.XChainID
+		cli.assetID = cfg.Offline.AssetID
+		zap.L().Info("using offline network information",
+			zap.Uint32("networkId", cli.networkID),
+			zap.String("xChainId", cli.xChainID.String()),
+			zap.String("assetId", cli.assetID.String()),
+		)
+	} else if err := cli.fetchNetwork(); err != nil {
 		return nil, err
 	}
-	cli.xChainID = xChainID
-	zap.L().Info("fetched X-Chain id", zap.String("id", cli.xChainID.String()))
-
-	uriX := u.Scheme + "://" + u.Host
-	xChainName := cli.xChainID.String()
-	if u.Port() == "" {
-		// ref. https://docs.avax.network/build/avalanchego-apis/x-chain
-		// e.g., https://api.avax-test.network
-		xChainName = "X"
-	}
-	zap.L().Info("fetching AVAX asset id",
-		zap.String("uri", uriX),
-	)
-	xc := avm.NewClient(uriX, xChainName)
-	avaxDesc, err := xc.GetAssetDescription(context.TODO(), "AVAX")
-	if err != nil {
-		return nil, err
-	}
-	cli.assetID = avaxDesc.AssetID
-	zap.L().Info("fetched AVAX asset id", zap.String("id", cli.assetID.String()))
-
-	zap.L().Info("fetching network information")
-	cli.networkName, err = cli.i.Client().GetNetworkName(context.TODO())
-	if err != nil {
-		return nil, err
-	}
-	cli.networkID, err = avago_constants.NetworkID(cli.networkName)
-	if err != nil {
-		return nil, err
-	}
-	zap.L().Info("fetched network information",
-		zap.Uint32("networkId", cli.networkID),
-		zap.String("networkName", cli.networkName),
-	)
+	xc := newXClient(u, cli.xChainID)
 
 	// "NewClient" already appends "/ext/P"
 	// e.g., https://api.avax-test.network
@@ -130,21 +221,76 @@ func New(cfg Config) (Client, error) {
 		networkID:   cli.networkID,
 		assetID:     cli.assetID,
 		pChainID:    cli.pChainID,
+		xChainID:    cli.xChainID,
 
 		cli:  pc,
+		x:    xc,
 		info: cli.i.Client(),
 		checker: internal_platformvm.NewChecker(
 			poll.New(cfg.PollInterval),
 			pc,
 		),
+		utxos: internal_avax.NewReservations(),
+		locks: newWalletLocks(),
 	}
 	return cli, nil
 }
 
+// fetchNetwork fetches the X-Chain ID, the AVAX asset ID, and the network
+// ID of the endpoint.
+func (cc *client) fetchNetwork() error {
+	zap.L().Info("fetching X-Chain id")
+	xChainID, err := cc.i.Client().GetBlockchainID(context.TODO(), "X")
+	if err != nil {
+		return err
+	}
+	cc.xChainID = xChainID
+	zap.L().Info("fetched X-Chain id", zap.String("id", cc.xChainID.String()))
+
+	zap.L().Info("fetching AVAX asset id",
+		zap.String("uri", cc.cfg.u.Scheme+"://"+cc.cfg.u.Host),
+	)
+	avaxDesc, err := newXClient(cc.cfg.u, cc.xChainID).GetAssetDescription(context.TODO(), "AVAX")
+	if err != nil {
+		return err
+	}
+	cc.assetID = avaxDesc.AssetID
+	zap.L().Info("fetched AVAX asset id", zap.String("id", cc.assetID.String()))
+
+	zap.L().Info("fetching network information")
+	cc.networkName, err = cc.i.Client().GetNetworkName(context.TODO())
+	if err != nil {
+		return err
+	}
+	cc.networkID, err = avago_constants.NetworkID(cc.networkName)
+	if err != nil {
+		return err
+	}
+	zap.L().Info("fetched network information",
+		zap.Uint32("networkId", cc.networkID),
+		zap.String("networkName", cc.networkName),
+	)
+	return nil
+}
+
+// newXClient returns the X-Chain client of the endpoint [u].
+func newXClient(u *url.URL, xChainID ids.ID) avm.Client {
+	uriX := u.Scheme + "://" + u.Host
+	xChainName := xChainID.String()
+	if u.Port() == "" {
+		// ref. https://docs.avax.network/build/avalanchego-apis/x-chain
+		// e.g., https://api.avax-test.network
+		xChainName = "X"
+	}
+	return avm.NewClient(uriX, xChainName)
+}
+
 func (cc *client) NetworkID() uint32 { return cc.networkID }
+func (cc *client) AssetID() ids.ID   { return cc.assetID }
 func (cc *client) Config() Config    { return cc.cfg }
 
 func (cc *client) Info() Info         { return cc.i }
+func (cc *client) Health() Health     { return cc.h }
 func (cc *client) KeyStore() KeyStore { return cc.k }
 
 func (cc *client) P() P { return cc.p }
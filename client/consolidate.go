@@ -0,0 +1,222 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"go.uber.org/zap"
+
+	internal_avax "github.com/ava-labs/subnet-cli/internal/avax"
+	"github.com/ava-labs/subnet-cli/internal/codec"
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/internal/txs"
+)
+
+// consolidateOverhead is the size reserved for the rest of the
+// consolidation tx (i.e., the change and the exported outputs).
+const consolidateOverhead = 1024
+
+// exportedAmount is the amount exported by each consolidation tx,
+// as the P-Chain has no tx to only move funds within the chain.
+const exportedAmount = 1
+
+var ErrNothingToConsolidate = errors.New("nothing to consolidate")
+
+// Consolidation is a tx merging the UTXOs of the key into a single one.
+type Consolidation struct {
+	TxID ids.ID
+	// Inputs is the number of UTXOs merged.
+	Inputs int
+	// Amount is the merged amount in nAVAX, after the fee and the export.
+	Amount uint64
+	// Size is the size of the signed tx in bytes.
+	Size int
+	Took time.Duration
+}
+
+// Consolidate merges the UTXOs with an "ExportTx" of 1 nAVAX to the X-Chain
+// per batch, owned by the key (which can import it back). Each batch is
+// issued once the previous one is committed.
+func (pc *p) Consolidate(
+	ctx context.Context,
+	k key.Key,
+	maxInputs int,
+	progress func(done int, total int, c Consolidation),
+	opts ...OpOption,
+) ([]Consolidation, error) {
+	ret := &Op{}
+	ret.applyOpts(opts)
+	if ret.changeAddr == ids.ShortEmpty {
+		ret.changeAddr = k.Addresses()[0]
+	}
+
+	fi, err := pc.txFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	txFee := uint64(fi.TxFee)
+
+	// all the batches spend the UTXOs selected upfront
+	wallet := k.Addresses()[0]
+	if err := pc.lockWallet(ctx, wallet); err != nil {
+		return nil, err
+	}
+	defer pc.unlockWallet(wallet)
+
+	ins, signers, err := pc.spendables(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+	sigs := make([]int, len(signers))
+	for i, inputSigners := range signers {
+		sigs[i] = len(inputSigners)
+	}
+	batches := txs.Batches(sigs, consolidateOverhead, maxInputs)
+	if len(batches) == 0 || batches[0] < 2 {
+		return nil, ErrNothingToConsolidate
+	}
+	if batches[len(batches)-1] < 2 {
+		// a single UTXO is already consolidated
+		batches = batches[:len(batches)-1]
+	}
+	zap.L().Info("consolidating UTXOs",
+		zap.Bool("dryMode", ret.dryMode),
+		zap.Int("utxos", len(ins)),
+		zap.Int("txs", len(batches)),
+		zap.Uint64("txFee", txFee),
+	)
+
+	cs := make([]Consolidation, 0, len(batches))
+	for i, n := range batches {
+		c, err := pc.consolidate(ctx, k, ins[:n], signers[:n], txFee, ret)
+		if err != nil {
+			return cs, fmt.Errorf("failed to consolidate batch %d/%d: %w", i+1, len(batches), err)
+		}
+		ins, signers = ins[n:], signers[n:]
+		cs = append(cs, c)
+		if progress != nil {
+			progress(i+1, len(batches), c)
+		}
+	}
+	return cs, nil
+}
+
+// spendables returns the inputs of the unlocked AVAX UTXOs of the key,
+// skipping the UTXOs spent by the in-flight txs.
+func (pc *p) spendables(ctx context.Context, k key.Key) ([]*avax.TransferableInput, [][]ids.ShortID, error) {
+	ubs, err := pc.UTXOs(ctx, k.P())
+	if err != nil {
+		return nil, nil, err
+	}
+	now := pc.now()
+	utxos := make([]*avax.UTXO, 0, len(ubs))
+	for _, ub := range ubs {
+		utxo, err := internal_avax.ParseUTXO(ub, codec.PCodecManager)
+		if err != nil {
+			return nil, nil, err
+		}
+		if pc.utxos.Reserved(utxo.InputID()) {
+			continue
+		}
+		utxos = append(utxos, utxo)
+	}
+	pc.sortUTXOs(utxos)
+	_, ins, signers := k.Spends(utxos, key.WithTime(now), key.WithAssetID(pc.assetID))
+	return ins, signers, nil
+}
+
+// consolidate merges [ins] into one change output.
+func (pc *p) consolidate(
+	ctx context.Context,
+	k key.Key,
+	ins []*avax.TransferableInput,
+	signers [][]ids.ShortID,
+	txFee uint64,
+	ret *Op,
+) (Consolidation, error) {
+	if !pc.utxos.Reserve(inputIDs(ins)...) {
+		return Consolidation{}, ErrUTXOsInFlight
+	}
+	defer pc.release(ins)
+
+	amount := uint64(0)
+	for _, in := range ins {
+		var err error
+		amount, err = math.Add64(amount, in.In.Amount())
+		if err != nil {
+			return Consolidation{}, err
+		}
+	}
+	if amount <= txFee+exportedAmount {
+		return Consolidation{}, &ErrInsufficientFunds{
+			Needed: txFee + exportedAmount + 1,
+			Have:   amount,
+			kind:   ErrInsufficientBalanceForGasFee,
+		}
+	}
+	c := Consolidation{
+		Inputs: len(ins),
+		Amount: amount - txFee - exportedAmount,
+	}
+
+	utx := &platformvm.UnsignedExportTx{
+		BaseTx: platformvm.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    pc.networkID,
+			BlockchainID: pc.pChainID,
+			Ins:          ins,
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: pc.assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          c.Amount,
+					OutputOwners: ret.changeOwners(),
+				},
+			}},
+			Memo: ret.memo,
+		}},
+		DestinationChain: pc.xChainID,
+		ExportedOutputs: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: pc.assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: exportedAmount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{k.Addresses()[0]},
+				},
+			},
+		}},
+	}
+	pTx := &platformvm.Tx{
+		UnsignedTx: utx,
+	}
+	if err := pc.sign(pTx, signers, k); err != nil {
+		return c, err
+	}
+	if err := utx.SyntacticVerify(&snow.Context{
+		NetworkID: pc.networkID,
+		ChainID:   pc.pChainID,
+	}); err != nil {
+		return c, err
+	}
+	c.TxID, c.Size = pTx.ID(), len(pTx.Bytes())
+	if ret.dryMode {
+		return c, nil
+	}
+
+	if _, err := pc.issueTx(ctx, "consolidate", pTx.Bytes()); err != nil {
+		return c, fmt.Errorf("failed to issue tx: %w", err)
+	}
+	var err error
+	c.Took, err = pc.waitTx(ctx, "consolidate", pTx)
+	return c, err
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// now returns the time to check the locktimes of the UTXOs and of the
+// subnet owners at, "Config.LocktimeAt" if set.
+func (pc *p) now() uint64 {
+	if !pc.cfg.LocktimeAt.IsZero() {
+		return uint64(pc.cfg.LocktimeAt.Unix())
+	}
+	return uint64(time.Now().Unix())
+}
+
+// sortUTXOs sorts the UTXOs by their input IDs in "Config.Deterministic"
+// mode, so the inputs selected do not depend on the order returned by the
+// node (or of the UTXOs file).
+func (pc *p) sortUTXOs(utxos []*avax.UTXO) {
+	if !pc.cfg.Deterministic {
+		return
+	}
+	sort.SliceStable(utxos, func(i, j int) bool {
+		a, b := utxos[i].InputID(), utxos[j].InputID()
+		return bytes.Compare(a[:], b[:]) < 0
+	})
+}
+
+// mergeChange merges the change outputs of the same asset, owners, and
+// stakeable locktime into one in "Config.Deterministic" mode, so the change
+// is a single output per owner regardless of the number of inputs.
+func (pc *p) mergeChange(outs []*avax.TransferableOutput) []*avax.TransferableOutput {
+	if !pc.cfg.Deterministic {
+		return outs
+	}
+	merged := make([]*avax.TransferableOutput, 0, len(outs))
+	byKey := make(map[string]*secp256k1fx.TransferOutput)
+	for _, out := range outs {
+		locktime := uint64(0)
+		to := out.Out
+		if lout, ok := to.(*platformvm.StakeableLockOut); ok {
+			locktime, to = lout.Locktime, lout.TransferableOut
+		}
+		tout, ok := to.(*secp256k1fx.TransferOutput)
+		if !ok {
+			merged = append(merged, out)
+			continue
+		}
+		k := changeKey(out.AssetID(), locktime, &tout.OutputOwners)
+		if prev, ok := byKey[k]; ok {
+			prev.Amt += tout.Amt
+			continue
+		}
+		// copied not to modify the outputs of the caller
+		c := *tout
+		byKey[k] = &c
+		var o avax.TransferableOut = &c
+		if locktime > 0 {
+			o = &platformvm.StakeableLockOut{Locktime: locktime, TransferableOut: &c}
+		}
+		merged = append(merged, &avax.TransferableOutput{Asset: out.Asset, Out: o})
+	}
+	return merged
+}
+
+func changeKey(assetID ids.ID, locktime uint64, owners *secp256k1fx.OutputOwners) string {
+	return fmt.Sprintf("%s/%d/%d/%d/%v", assetID, locktime, owners.Locktime, owners.Threshold, owners.Addrs)
+}
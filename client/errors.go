@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+// ErrTxRejected is returned when the node rejects the tx on issuance for
+// a reason not typed below (e.g., an invalid signature).
+var ErrTxRejected = errors.New("tx rejected")
+
+// ErrInsufficientFunds is returned when the key cannot cover the fee or
+// the stake amount of the tx. When built from the UTXOs, it matches
+// "ErrInsufficientBalanceForGasFee" or "ErrInsufficientBalanceForStakeAmount"
+// with "errors.Is". When parsed from the node, the amounts are unknown (zero).
+type ErrInsufficientFunds struct {
+	// Needed is the amount required in nAVAX.
+	Needed uint64
+	// Have is the amount available in nAVAX.
+	Have uint64
+
+	kind error
+	msg  string
+}
+
+func (e *ErrInsufficientFunds) Error() string {
+	if e.msg != "" {
+		return "insufficient funds: " + e.msg
+	}
+	s := "insufficient funds"
+	if e.kind != nil {
+		s = e.kind.Error()
+	}
+	return fmt.Sprintf("%s (needed %.9f AVAX, have %.9f AVAX)",
+		s,
+		float64(e.Needed)/float64(units.Avax),
+		float64(e.Have)/float64(units.Avax),
+	)
+}
+
+func (e *ErrInsufficientFunds) Unwrap() error { return e.kind }
+
+// ErrNotAuthorized is returned when the keys cannot satisfy the threshold
+// of the owners (e.g., the subnet control keys). It matches "ErrCantSign"
+// with "errors.Is".
+type ErrNotAuthorized struct {
+	// MissingKeys is the P-Chain addresses of the owners not held by the keys.
+	MissingKeys []string
+	// Have is the number of owners held by the keys.
+	Have uint32
+	// Threshold is the number of signatures required.
+	Threshold uint32
+	// Locktime is non-zero if the owners are locked until then.
+	Locktime time.Time
+
+	msg string
+}
+
+func (e *ErrNotAuthorized) Error() string {
+	switch {
+	case e.msg != "":
+		return fmt.Sprintf("%v: %s", ErrCantSign, e.msg)
+	case !e.Locktime.IsZero():
+		return fmt.Sprintf("%v: control keys are locked until %v", ErrCantSign, e.Locktime)
+	case e.Threshold == 0:
+		return fmt.Sprintf("%v: no key holds %v", ErrCantSign, e.MissingKeys)
+	}
+	s := fmt.Sprintf("%v: have %d of %d required control keys", ErrCantSign, e.Have, e.Threshold)
+	if len(e.MissingKeys) > 0 {
+		s += fmt.Sprintf(" (missing %v)", e.MissingKeys)
+	}
+	return s
+}
+
+func (e *ErrNotAuthorized) Unwrap() error { return ErrCantSign }
+
+// ErrStartTimeTooSoon is returned when the validation start time is not
+// far enough in the future for the tx to be accepted.
+type ErrStartTimeTooSoon struct {
+	// Start is the requested start time, zero if parsed from the node.
+	Start time.Time
+	// Min is the earliest accepted start time, zero if parsed from the node.
+	Min time.Time
+
+	msg string
+}
+
+func (e *ErrStartTimeTooSoon) Error() string {
+	if e.msg != "" {
+		return "validate start time is too soon: " + e.msg
+	}
+	return fmt.Sprintf("validate start time is too soon: %s must be at or after %s",
+		e.Start.Format(time.RFC3339),
+		e.Min.Format(time.RFC3339),
+	)
+}
+
+// NewStartTimeTooSoon returns the error of the validation [start] time
+// that is before [min].
+func NewStartTimeTooSoon(start time.Time, min time.Time) error {
+	return &ErrStartTimeTooSoon{Start: start, Min: min}
+}
+
+// nodeErrors maps the substrings of the errors returned by the node
+// (ref. "vms.platformvm", "vms.secp256k1fx") to the typed errors.
+var nodeErrors = []struct {
+	substrs []string
+	typed   func(msg string) error
+}{
+	{
+		substrs: []string{"insufficient funds"},
+		typed:   func(msg string) error { return &ErrInsufficientFunds{msg: msg} },
+	},
+	{
+		substrs: []string{"can't sign", "signers", "expected signature from"},
+		typed:   func(msg string) error { return &ErrNotAuthorized{msg: msg} },
+	},
+	{
+		substrs: []string{"start time must be at least", "start time is before the current chain time"},
+		typed:   func(msg string) error { return &ErrStartTimeTooSoon{msg: msg} },
+	},
+}
+
+// parseNodeError converts the error message returned by the node into
+// a typed error, if known. Otherwise, it wraps "ErrTxRejected".
+func parseNodeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var (
+		funds *ErrInsufficientFunds
+		auth  *ErrNotAuthorized
+		soon  *ErrStartTimeTooSoon
+	)
+	if errors.As(err, &funds) || errors.As(err, &auth) || errors.As(err, &soon) {
+		return err
+	}
+	msg := err.Error()
+	lower := strings.ToLower(msg)
+	for _, ne := range nodeErrors {
+		for _, s := range ne.substrs {
+			if strings.Contains(lower, s) {
+				return ne.typed(msg)
+			}
+		}
+	}
+	return fmt.Errorf("%w: %v", ErrTxRejected, err)
+}
+
+// IsNetworkError returns true if [err] is a failure to reach the node
+// (e.g., connection refused, timeout, HTTP status), rather than an error
+// returned by the node.
+func IsNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		// ref. "utils/rpc.SendJSONRequest"
+		strings.Contains(err.Error(), "received status code")
+}
@@ -0,0 +1,399 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	api_info "github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	"github.com/ava-labs/subnet-cli/internal/fork"
+	"github.com/ava-labs/subnet-cli/internal/key"
+	internal_platformvm "github.com/ava-labs/subnet-cli/internal/platformvm"
+)
+
+// ErrNotSupported is returned by the methods of "Fake" beyond the
+// provisioning flows (e.g., "Transfer", "UTXOs").
+var ErrNotSupported = errors.New("not supported by the fake client")
+
+var _ Client = &Fake{}
+
+// Fake is an in-memory "Client", to test the provisioning flows of
+// "pkg/subnet" without a network: the subnet and blockchain creation, and
+// the validators. Each tx is committed once issued: the subnets, the
+// blockchains, and the validators are kept in memory, and the fees are
+// burned from the balance of the first address of the payer. The
+// validators are pending until their start time. The other methods return
+// "ErrNotSupported", and "Info", "Health", and "KeyStore" are nil.
+type Fake struct {
+	// Fees are the fees burned by the txs.
+	Fees api_info.GetTxFeeResponse
+
+	networkID uint32
+	assetID   ids.ID
+
+	mu          sync.Mutex
+	txs         uint64
+	balances    map[ids.ShortID]uint64
+	subnets     map[ids.ID]*secp256k1fx.OutputOwners
+	blockchains map[ids.ID]*platformvm.UnsignedCreateChainTx
+	validators  map[ids.ID]map[ids.ShortID]Validator
+}
+
+// NewFake returns an empty network of [networkID], with the default fees
+// of the local network.
+func NewFake(networkID uint32) *Fake {
+	return &Fake{
+		Fees: api_info.GetTxFeeResponse{
+			TxFee:                 json.Uint64(units.MilliAvax),
+			CreationTxFee:         json.Uint64(units.MilliAvax),
+			CreateAssetTxFee:      json.Uint64(units.MilliAvax),
+			CreateSubnetTxFee:     json.Uint64(100 * units.MilliAvax),
+			CreateBlockchainTxFee: json.Uint64(100 * units.MilliAvax),
+		},
+		networkID:   networkID,
+		assetID:     ids.Empty.Prefix(uint64(networkID)),
+		balances:    make(map[ids.ShortID]uint64),
+		subnets:     make(map[ids.ID]*secp256k1fx.OutputOwners),
+		blockchains: make(map[ids.ID]*platformvm.UnsignedCreateChainTx),
+		validators:  make(map[ids.ID]map[ids.ShortID]Validator),
+	}
+}
+
+// Fund credits [amount] nAVAX to [addr].
+func (f *Fake) Fund(addr ids.ShortID, amount uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.balances[addr] += amount
+}
+
+// SetValidator sets [v] in the validators of the subnet (the primary
+// network, if [subnetID] is empty) without any tx.
+func (f *Fake) SetValidator(subnetID ids.ID, v Validator) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.validatorsOf(subnetID)[v.NodeID] = v
+}
+
+func (f *Fake) NetworkID() uint32  { return f.networkID }
+func (f *Fake) AssetID() ids.ID    { return f.assetID }
+func (f *Fake) Config() Config     { return Config{} }
+func (f *Fake) Info() Info         { return nil }
+func (f *Fake) Health() Health     { return nil }
+func (f *Fake) KeyStore() KeyStore { return nil }
+func (f *Fake) P() P               { return (*fakeP)(f) }
+
+// nextID returns the ID of the next tx. The caller must hold the lock.
+func (f *Fake) nextID() ids.ID {
+	f.txs++
+	return ids.Empty.Prefix(uint64(f.networkID), f.txs)
+}
+
+// validatorsOf returns the validators of [subnetID]. The caller must hold
+// the lock.
+func (f *Fake) validatorsOf(subnetID ids.ID) map[ids.ShortID]Validator {
+	if subnetID == ids.Empty {
+		subnetID = constants.PrimaryNetworkID
+	}
+	vs, ok := f.validators[subnetID]
+	if !ok {
+		vs = make(map[ids.ShortID]Validator)
+		f.validators[subnetID] = vs
+	}
+	return vs
+}
+
+// burn burns [amount] from the balance of [k]. The caller must hold the
+// lock.
+func (f *Fake) burn(k key.Key, amount uint64, kind error) error {
+	addr := k.Addresses()[0]
+	if have := f.balances[addr]; have < amount {
+		return &ErrInsufficientFunds{Needed: amount, Have: have, kind: kind}
+	}
+	f.balances[addr] -= amount
+	return nil
+}
+
+// authorize returns an error unless [k] holds the control keys of
+// [subnetID]. The caller must hold the lock.
+func (f *Fake) authorize(k key.Key, subnetID ids.ID) error {
+	owners, err := f.owners(subnetID)
+	if err != nil {
+		return err
+	}
+	now := uint64(time.Now().Unix())
+	if _, _, ok := k.Match(owners, now); ok {
+		return nil
+	}
+	if now < owners.Locktime {
+		return &ErrNotAuthorized{Threshold: owners.Threshold, Locktime: time.Unix(int64(owners.Locktime), 0)}
+	}
+	e := &ErrNotAuthorized{Threshold: owners.Threshold}
+	held := make(map[ids.ShortID]struct{})
+	for _, addr := range k.Addresses() {
+		held[addr] = struct{}{}
+	}
+	hrp := constants.GetHRP(f.networkID)
+	for _, addr := range owners.Addrs {
+		if _, ok := held[addr]; ok {
+			e.Have++
+			continue
+		}
+		paddr, err := formatting.FormatAddress("P", hrp, addr.Bytes())
+		if err != nil {
+			return err
+		}
+		e.MissingKeys = append(e.MissingKeys, paddr)
+	}
+	return e
+}
+
+// owners returns the control keys of [subnetID]. The caller must hold the
+// lock.
+func (f *Fake) owners(subnetID ids.ID) (*secp256k1fx.OutputOwners, error) {
+	if subnetID == ids.Empty {
+		return nil, ErrEmptyID
+	}
+	owners, ok := f.subnets[subnetID]
+	if !ok {
+		return nil, fmt.Errorf("subnet %s not found", subnetID)
+	}
+	return owners, nil
+}
+
+// fakeOp parses [opts], and returns "ErrNotSupported" for the proposals
+// and the subnet signers.
+func fakeOp(opts []OpOption) (*Op, error) {
+	op := &Op{}
+	op.applyOpts(opts)
+	if op.proposal != nil {
+		return nil, fmt.Errorf("%w: proposal", ErrNotSupported)
+	}
+	if len(op.subnetSigners) > 0 {
+		return nil, fmt.Errorf("%w: subnet signers", ErrNotSupported)
+	}
+	return op, nil
+}
+
+type fakeP Fake
+
+func (pc *fakeP) Client() platformvm.Client            { return nil }
+func (pc *fakeP) Checker() internal_platformvm.Checker { return nil }
+
+func (pc *fakeP) Balance(ctx context.Context, k key.Key) (uint64, error) {
+	f := (*Fake)(pc)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.balances[k.Addresses()[0]], nil
+}
+
+func (pc *fakeP) CreateSubnet(ctx context.Context, k key.Key, opts ...OpOption) (subnetID ids.ID, took time.Duration, err error) {
+	f := (*Fake)(pc)
+	op, err := fakeOp(opts)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if op.dryMode {
+		return ids.Empty.Prefix(uint64(f.networkID), f.txs+1), 0, nil
+	}
+	if err := f.burn(op.payer(k), uint64(f.Fees.CreateSubnetTxFee), ErrInsufficientBalanceForGasFee); err != nil {
+		return ids.Empty, 0, err
+	}
+	subnetID = f.nextID()
+	f.subnets[subnetID] = &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{k.Addresses()[0]},
+	}
+	return subnetID, 0, nil
+}
+
+func (pc *fakeP) AddSubnetValidator(ctx context.Context, k key.Key, subnetID ids.ID, nodeID ids.ShortID, start time.Time, end time.Time, weight uint64, opts ...OpOption) (took time.Duration, err error) {
+	f := (*Fake)(pc)
+	op, err := fakeOp(opts)
+	if err != nil {
+		return 0, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.authorize(k, subnetID); err != nil {
+		return 0, err
+	}
+	primary, ok := f.validatorsOf(ids.Empty)[nodeID]
+	if !ok {
+		return 0, ErrNotValidatingPrimaryNetwork
+	}
+	if start.Before(primary.Start) || end.After(primary.End) {
+		return 0, ErrInvalidSubnetValidatePeriod
+	}
+	vs := f.validatorsOf(subnetID)
+	if _, ok := vs[nodeID]; ok {
+		return 0, ErrAlreadySubnetValidator
+	}
+	if op.dryMode {
+		return 0, nil
+	}
+	if err := f.burn(op.payer(k), uint64(f.Fees.TxFee), ErrInsufficientBalanceForGasFee); err != nil {
+		return 0, err
+	}
+	f.nextID()
+	vs[nodeID] = Validator{NodeID: nodeID, Start: start, End: end, Weight: weight}
+	return 0, nil
+}
+
+func (pc *fakeP) CreateBlockchain(ctx context.Context, k key.Key, subnetID ids.ID, chainName string, vmID ids.ID, vmGenesis []byte, opts ...OpOption) (blkChainID ids.ID, took time.Duration, err error) {
+	f := (*Fake)(pc)
+	op, err := fakeOp(opts)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.authorize(k, subnetID); err != nil {
+		return ids.Empty, 0, err
+	}
+	if op.dryMode {
+		return ids.Empty.Prefix(uint64(f.networkID), f.txs+1), 0, nil
+	}
+	if err := f.burn(op.payer(k), uint64(f.Fees.CreateBlockchainTxFee), ErrInsufficientBalanceForGasFee); err != nil {
+		return ids.Empty, 0, err
+	}
+	blkChainID = f.nextID()
+	f.blockchains[blkChainID] = &platformvm.UnsignedCreateChainTx{
+		SubnetID:    subnetID,
+		ChainName:   chainName,
+		VMID:        vmID,
+		FxIDs:       op.fxIDs,
+		GenesisData: vmGenesis,
+	}
+	return blkChainID, 0, nil
+}
+
+func (pc *fakeP) GetValidator(ctx context.Context, rsubnetID ids.ID, nodeID ids.ShortID) (start time.Time, end time.Time, err error) {
+	f := (*Fake)(pc)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v, ok := f.validatorsOf(rsubnetID)[nodeID]
+	if !ok || v.Start.After(time.Now()) {
+		return time.Time{}, time.Time{}, ErrValidatorNotFound
+	}
+	return v.Start, v.End, nil
+}
+
+func (pc *fakeP) GetPendingValidators(ctx context.Context, rsubnetID ids.ID) ([]Validator, error) {
+	f := (*Fake)(pc)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	vs := f.validatorsOf(rsubnetID)
+	validators := make([]Validator, 0, len(vs))
+	for _, v := range vs {
+		if v.Start.After(now) {
+			validators = append(validators, v)
+		}
+	}
+	sort.Slice(validators, func(i, j int) bool {
+		return validators[i].NodeID.String() < validators[j].NodeID.String()
+	})
+	return validators, nil
+}
+
+func (pc *fakeP) BlockchainTx(ctx context.Context, blockchainID ids.ID) (*platformvm.UnsignedCreateChainTx, error) {
+	if blockchainID == ids.Empty {
+		return nil, ErrEmptyID
+	}
+	f := (*Fake)(pc)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tx, ok := f.blockchains[blockchainID]
+	if !ok {
+		return nil, fmt.Errorf("blockchain %s not found", blockchainID)
+	}
+	return tx, nil
+}
+
+func (pc *fakeP) Pools(ctx context.Context, k key.Key) (Pools, error) {
+	return Pools{}, fmt.Errorf("%w: Pools", ErrNotSupported)
+}
+
+func (pc *fakeP) AssetBalances(ctx context.Context, addrs []string) (map[ids.ID]Pools, error) {
+	return nil, fmt.Errorf("%w: AssetBalances", ErrNotSupported)
+}
+
+func (pc *fakeP) Asset(ctx context.Context, assetID ids.ID) (*Asset, error) {
+	return nil, fmt.Errorf("%w: Asset", ErrNotSupported)
+}
+
+func (pc *fakeP) Transfer(ctx context.Context, k key.Key, to ids.ShortID, amount uint64, opts ...OpOption) (Transfer, error) {
+	return Transfer{}, fmt.Errorf("%w: Transfer", ErrNotSupported)
+}
+
+func (pc *fakeP) AddValidator(ctx context.Context, k key.Key, nodeID ids.ShortID, start time.Time, end time.Time, opts ...OpOption) (took time.Duration, err error) {
+	return 0, fmt.Errorf("%w: AddValidator", ErrNotSupported)
+}
+
+func (pc *fakeP) RemoveSubnetValidator(ctx context.Context, k key.Key, subnetID ids.ID, nodeID ids.ShortID, opts ...OpOption) (took time.Duration, err error) {
+	return 0, fmt.Errorf("%w: RemoveSubnetValidator", ErrNotSupported)
+}
+
+func (pc *fakeP) TransferSubnetOwnership(ctx context.Context, k key.Key, subnetID ids.ID, owner *secp256k1fx.OutputOwners, opts ...OpOption) (took time.Duration, err error) {
+	return 0, fmt.Errorf("%w: TransferSubnetOwnership", ErrNotSupported)
+}
+
+func (pc *fakeP) GetValidators(ctx context.Context, rsubnetID ids.ID) ([]Validator, error) {
+	return nil, fmt.Errorf("%w: GetValidators", ErrNotSupported)
+}
+
+func (pc *fakeP) SubnetOwners(ctx context.Context, subnetID ids.ID) (*secp256k1fx.OutputOwners, error) {
+	return nil, fmt.Errorf("%w: SubnetOwners", ErrNotSupported)
+}
+
+func (pc *fakeP) CheckSubnetAuth(ctx context.Context, k key.Key, subnetID ids.ID) error {
+	return fmt.Errorf("%w: CheckSubnetAuth", ErrNotSupported)
+}
+
+func (pc *fakeP) IssueSignedTx(ctx context.Context, pTx *platformvm.Tx, txType string) (txID ids.ID, took time.Duration, err error) {
+	return ids.Empty, 0, fmt.Errorf("%w: IssueSignedTx", ErrNotSupported)
+}
+
+func (pc *fakeP) GetSubnets(ctx context.Context) ([]Subnet, error) {
+	return nil, fmt.Errorf("%w: GetSubnets", ErrNotSupported)
+}
+
+func (pc *fakeP) GetBlockchains(ctx context.Context) ([]platformvm.APIBlockchain, error) {
+	return nil, fmt.Errorf("%w: GetBlockchains", ErrNotSupported)
+}
+
+func (pc *fakeP) UTXOs(ctx context.Context, addrs []string) ([][]byte, error) {
+	return nil, fmt.Errorf("%w: UTXOs", ErrNotSupported)
+}
+
+func (pc *fakeP) Balances(ctx context.Context, addrs []ids.ShortID) (map[ids.ShortID]uint64, error) {
+	return nil, fmt.Errorf("%w: Balances", ErrNotSupported)
+}
+
+func (pc *fakeP) Capabilities(ctx context.Context) (*fork.Capabilities, error) {
+	return nil, fmt.Errorf("%w: Capabilities", ErrNotSupported)
+}
+
+func (pc *fakeP) StakingAsset(ctx context.Context, subnetID ids.ID) (*Asset, error) {
+	return nil, fmt.Errorf("%w: StakingAsset", ErrNotSupported)
+}
+
+func (pc *fakeP) Consolidate(ctx context.Context, k key.Key, maxInputs int, progress func(done int, total int, c Consolidation), opts ...OpOption) ([]Consolidation, error) {
+	return nil, fmt.Errorf("%w: Consolidate", ErrNotSupported)
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	api_health "github.com/ava-labs/avalanchego/api/health"
+
+	"github.com/ava-labs/subnet-cli/internal/metrics"
+)
+
+type Health interface {
+	Client() api_health.Client
+	// Healthy returns true if the node reports all its health checks as
+	// passing (ref. "health.health").
+	Healthy(ctx context.Context) (bool, error)
+}
+
+type health struct {
+	cli api_health.Client
+}
+
+func newHealth(cfg Config) *health {
+	// "NewClient" already appends "/ext/health"
+	uri := cfg.u.Scheme + "://" + cfg.u.Host
+	return &health{cli: api_health.NewClient(uri)}
+}
+
+func (h *health) Client() api_health.Client { return h.cli }
+
+func (h *health) Healthy(ctx context.Context) (bool, error) {
+	reqStart := time.Now()
+	reply, err := h.cli.Health(ctx)
+	metrics.ObserveAPI("health.health", reqStart, err)
+	if err != nil {
+		return false, err
+	}
+	return reply.Healthy, nil
+}
@@ -4,11 +4,16 @@
 package client
 
 import (
+	"context"
+
 	api_info "github.com/ava-labs/avalanchego/api/info"
 )
 
 type Info interface {
 	Client() api_info.Client
+	// TxFee returns the fee config of the network, from "Config.Cache" if
+	// available, with the fees of "Config.FeeOverride".
+	TxFee(ctx context.Context) (*api_info.GetTxFeeResponse, error)
 }
 
 type info struct {
@@ -29,3 +34,18 @@ func newInfo(cfg Config) *info {
 }
 
 func (i *info) Client() api_info.Client { return i.cli }
+
+func (i *info) TxFee(ctx context.Context) (*api_info.GetTxFeeResponse, error) {
+	if i.cfg.FeeOverride.Complete() {
+		return i.cfg.FeeOverride.Apply(nil), nil
+	}
+	var fee *api_info.GetTxFeeResponse
+	err := i.cfg.cached("txFee", &fee, func() (err error) {
+		fee, err = i.cli.GetTxFee(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.cfg.FeeOverride.Apply(fee), nil
+}
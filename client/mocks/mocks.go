@@ -0,0 +1,757 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ava-labs/subnet-cli/client (interfaces: Client,P,Info,Health,KeyStore)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	health "github.com/ava-labs/avalanchego/api/health"
+	info "github.com/ava-labs/avalanchego/api/info"
+	keystore "github.com/ava-labs/avalanchego/api/keystore"
+	ids "github.com/ava-labs/avalanchego/ids"
+	platformvm "github.com/ava-labs/avalanchego/vms/platformvm"
+	secp256k1fx "github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	client "github.com/ava-labs/subnet-cli/client"
+	fork "github.com/ava-labs/subnet-cli/internal/fork"
+	key "github.com/ava-labs/subnet-cli/internal/key"
+	platformvm0 "github.com/ava-labs/subnet-cli/internal/platformvm"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// AssetID mocks base method.
+func (m *MockClient) AssetID() ids.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssetID")
+	ret0, _ := ret[0].(ids.ID)
+	return ret0
+}
+
+// AssetID indicates an expected call of AssetID.
+func (mr *MockClientMockRecorder) AssetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssetID", reflect.TypeOf((*MockClient)(nil).AssetID))
+}
+
+// Config mocks base method.
+func (m *MockClient) Config() client.Config {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Config")
+	ret0, _ := ret[0].(client.Config)
+	return ret0
+}
+
+// Config indicates an expected call of Config.
+func (mr *MockClientMockRecorder) Config() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Config", reflect.TypeOf((*MockClient)(nil).Config))
+}
+
+// Health mocks base method.
+func (m *MockClient) Health() client.Health {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health")
+	ret0, _ := ret[0].(client.Health)
+	return ret0
+}
+
+// Health indicates an expected call of Health.
+func (mr *MockClientMockRecorder) Health() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockClient)(nil).Health))
+}
+
+// Info mocks base method.
+func (m *MockClient) Info() client.Info {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Info")
+	ret0, _ := ret[0].(client.Info)
+	return ret0
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockClientMockRecorder) Info() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockClient)(nil).Info))
+}
+
+// KeyStore mocks base method.
+func (m *MockClient) KeyStore() client.KeyStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeyStore")
+	ret0, _ := ret[0].(client.KeyStore)
+	return ret0
+}
+
+// KeyStore indicates an expected call of KeyStore.
+func (mr *MockClientMockRecorder) KeyStore() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeyStore", reflect.TypeOf((*MockClient)(nil).KeyStore))
+}
+
+// NetworkID mocks base method.
+func (m *MockClient) NetworkID() uint32 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkID")
+	ret0, _ := ret[0].(uint32)
+	return ret0
+}
+
+// NetworkID indicates an expected call of NetworkID.
+func (mr *MockClientMockRecorder) NetworkID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkID", reflect.TypeOf((*MockClient)(nil).NetworkID))
+}
+
+// P mocks base method.
+func (m *MockClient) P() client.P {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "P")
+	ret0, _ := ret[0].(client.P)
+	return ret0
+}
+
+// P indicates an expected call of P.
+func (mr *MockClientMockRecorder) P() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "P", reflect.TypeOf((*MockClient)(nil).P))
+}
+
+// MockP is a mock of P interface.
+type MockP struct {
+	ctrl     *gomock.Controller
+	recorder *MockPMockRecorder
+}
+
+// MockPMockRecorder is the mock recorder for MockP.
+type MockPMockRecorder struct {
+	mock *MockP
+}
+
+// NewMockP creates a new mock instance.
+func NewMockP(ctrl *gomock.Controller) *MockP {
+	mock := &MockP{ctrl: ctrl}
+	mock.recorder = &MockPMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockP) EXPECT() *MockPMockRecorder {
+	return m.recorder
+}
+
+// AddSubnetValidator mocks base method.
+func (m *MockP) AddSubnetValidator(arg0 context.Context, arg1 key.Key, arg2 ids.ID, arg3 ids.ShortID, arg4, arg5 time.Time, arg6 uint64, arg7 ...client.OpOption) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3, arg4, arg5, arg6}
+	for _, a := range arg7 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddSubnetValidator", varargs...)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSubnetValidator indicates an expected call of AddSubnetValidator.
+func (mr *MockPMockRecorder) AddSubnetValidator(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}, arg7 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3, arg4, arg5, arg6}, arg7...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubnetValidator", reflect.TypeOf((*MockP)(nil).AddSubnetValidator), varargs...)
+}
+
+// AddValidator mocks base method.
+func (m *MockP) AddValidator(arg0 context.Context, arg1 key.Key, arg2 ids.ShortID, arg3, arg4 time.Time, arg5 ...client.OpOption) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3, arg4}
+	for _, a := range arg5 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddValidator", varargs...)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddValidator indicates an expected call of AddValidator.
+func (mr *MockPMockRecorder) AddValidator(arg0, arg1, arg2, arg3, arg4 interface{}, arg5 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3, arg4}, arg5...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddValidator", reflect.TypeOf((*MockP)(nil).AddValidator), varargs...)
+}
+
+// Asset mocks base method.
+func (m *MockP) Asset(arg0 context.Context, arg1 ids.ID) (*client.Asset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Asset", arg0, arg1)
+	ret0, _ := ret[0].(*client.Asset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Asset indicates an expected call of Asset.
+func (mr *MockPMockRecorder) Asset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Asset", reflect.TypeOf((*MockP)(nil).Asset), arg0, arg1)
+}
+
+// AssetBalances mocks base method.
+func (m *MockP) AssetBalances(arg0 context.Context, arg1 []string) (map[ids.ID]client.Pools, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssetBalances", arg0, arg1)
+	ret0, _ := ret[0].(map[ids.ID]client.Pools)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssetBalances indicates an expected call of AssetBalances.
+func (mr *MockPMockRecorder) AssetBalances(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssetBalances", reflect.TypeOf((*MockP)(nil).AssetBalances), arg0, arg1)
+}
+
+// Balance mocks base method.
+func (m *MockP) Balance(arg0 context.Context, arg1 key.Key) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balance", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balance indicates an expected call of Balance.
+func (mr *MockPMockRecorder) Balance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balance", reflect.TypeOf((*MockP)(nil).Balance), arg0, arg1)
+}
+
+// Balances mocks base method.
+func (m *MockP) Balances(arg0 context.Context, arg1 []ids.ShortID) (map[ids.ShortID]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balances", arg0, arg1)
+	ret0, _ := ret[0].(map[ids.ShortID]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balances indicates an expected call of Balances.
+func (mr *MockPMockRecorder) Balances(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balances", reflect.TypeOf((*MockP)(nil).Balances), arg0, arg1)
+}
+
+// BlockchainTx mocks base method.
+func (m *MockP) BlockchainTx(arg0 context.Context, arg1 ids.ID) (*platformvm.UnsignedCreateChainTx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockchainTx", arg0, arg1)
+	ret0, _ := ret[0].(*platformvm.UnsignedCreateChainTx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockchainTx indicates an expected call of BlockchainTx.
+func (mr *MockPMockRecorder) BlockchainTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockchainTx", reflect.TypeOf((*MockP)(nil).BlockchainTx), arg0, arg1)
+}
+
+// Capabilities mocks base method.
+func (m *MockP) Capabilities(arg0 context.Context) (*fork.Capabilities, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capabilities", arg0)
+	ret0, _ := ret[0].(*fork.Capabilities)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Capabilities indicates an expected call of Capabilities.
+func (mr *MockPMockRecorder) Capabilities(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capabilities", reflect.TypeOf((*MockP)(nil).Capabilities), arg0)
+}
+
+// CheckSubnetAuth mocks base method.
+func (m *MockP) CheckSubnetAuth(arg0 context.Context, arg1 key.Key, arg2 ids.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSubnetAuth", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckSubnetAuth indicates an expected call of CheckSubnetAuth.
+func (mr *MockPMockRecorder) CheckSubnetAuth(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSubnetAuth", reflect.TypeOf((*MockP)(nil).CheckSubnetAuth), arg0, arg1, arg2)
+}
+
+// Checker mocks base method.
+func (m *MockP) Checker() platformvm0.Checker {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checker")
+	ret0, _ := ret[0].(platformvm0.Checker)
+	return ret0
+}
+
+// Checker indicates an expected call of Checker.
+func (mr *MockPMockRecorder) Checker() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checker", reflect.TypeOf((*MockP)(nil).Checker))
+}
+
+// Client mocks base method.
+func (m *MockP) Client() platformvm.Client {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Client")
+	ret0, _ := ret[0].(platformvm.Client)
+	return ret0
+}
+
+// Client indicates an expected call of Client.
+func (mr *MockPMockRecorder) Client() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Client", reflect.TypeOf((*MockP)(nil).Client))
+}
+
+// Consolidate mocks base method.
+func (m *MockP) Consolidate(arg0 context.Context, arg1 key.Key, arg2 int, arg3 func(int, int, client.Consolidation), arg4 ...client.OpOption) ([]client.Consolidation, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Consolidate", varargs...)
+	ret0, _ := ret[0].([]client.Consolidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consolidate indicates an expected call of Consolidate.
+func (mr *MockPMockRecorder) Consolidate(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consolidate", reflect.TypeOf((*MockP)(nil).Consolidate), varargs...)
+}
+
+// CreateBlockchain mocks base method.
+func (m *MockP) CreateBlockchain(arg0 context.Context, arg1 key.Key, arg2 ids.ID, arg3 string, arg4 ids.ID, arg5 []byte, arg6 ...client.OpOption) (ids.ID, time.Duration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3, arg4, arg5}
+	for _, a := range arg6 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateBlockchain", varargs...)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateBlockchain indicates an expected call of CreateBlockchain.
+func (mr *MockPMockRecorder) CreateBlockchain(arg0, arg1, arg2, arg3, arg4, arg5 interface{}, arg6 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3, arg4, arg5}, arg6...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlockchain", reflect.TypeOf((*MockP)(nil).CreateBlockchain), varargs...)
+}
+
+// CreateSubnet mocks base method.
+func (m *MockP) CreateSubnet(arg0 context.Context, arg1 key.Key, arg2 ...client.OpOption) (ids.ID, time.Duration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateSubnet", varargs...)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateSubnet indicates an expected call of CreateSubnet.
+func (mr *MockPMockRecorder) CreateSubnet(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubnet", reflect.TypeOf((*MockP)(nil).CreateSubnet), varargs...)
+}
+
+// GetBlockchains mocks base method.
+func (m *MockP) GetBlockchains(arg0 context.Context) ([]platformvm.APIBlockchain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockchains", arg0)
+	ret0, _ := ret[0].([]platformvm.APIBlockchain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockchains indicates an expected call of GetBlockchains.
+func (mr *MockPMockRecorder) GetBlockchains(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockchains", reflect.TypeOf((*MockP)(nil).GetBlockchains), arg0)
+}
+
+// GetPendingValidators mocks base method.
+func (m *MockP) GetPendingValidators(arg0 context.Context, arg1 ids.ID) ([]client.Validator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingValidators", arg0, arg1)
+	ret0, _ := ret[0].([]client.Validator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingValidators indicates an expected call of GetPendingValidators.
+func (mr *MockPMockRecorder) GetPendingValidators(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidators", reflect.TypeOf((*MockP)(nil).GetPendingValidators), arg0, arg1)
+}
+
+// GetSubnets mocks base method.
+func (m *MockP) GetSubnets(arg0 context.Context) ([]client.Subnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnets", arg0)
+	ret0, _ := ret[0].([]client.Subnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnets indicates an expected call of GetSubnets.
+func (mr *MockPMockRecorder) GetSubnets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnets", reflect.TypeOf((*MockP)(nil).GetSubnets), arg0)
+}
+
+// GetValidator mocks base method.
+func (m *MockP) GetValidator(arg0 context.Context, arg1 ids.ID, arg2 ids.ShortID) (time.Time, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidator", arg0, arg1, arg2)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetValidator indicates an expected call of GetValidator.
+func (mr *MockPMockRecorder) GetValidator(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidator", reflect.TypeOf((*MockP)(nil).GetValidator), arg0, arg1, arg2)
+}
+
+// GetValidators mocks base method.
+func (m *MockP) GetValidators(arg0 context.Context, arg1 ids.ID) ([]client.Validator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidators", arg0, arg1)
+	ret0, _ := ret[0].([]client.Validator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidators indicates an expected call of GetValidators.
+func (mr *MockPMockRecorder) GetValidators(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidators", reflect.TypeOf((*MockP)(nil).GetValidators), arg0, arg1)
+}
+
+// IssueSignedTx mocks base method.
+func (m *MockP) IssueSignedTx(arg0 context.Context, arg1 *platformvm.Tx, arg2 string) (ids.ID, time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueSignedTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// IssueSignedTx indicates an expected call of IssueSignedTx.
+func (mr *MockPMockRecorder) IssueSignedTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueSignedTx", reflect.TypeOf((*MockP)(nil).IssueSignedTx), arg0, arg1, arg2)
+}
+
+// Pools mocks base method.
+func (m *MockP) Pools(arg0 context.Context, arg1 key.Key) (client.Pools, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pools", arg0, arg1)
+	ret0, _ := ret[0].(client.Pools)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pools indicates an expected call of Pools.
+func (mr *MockPMockRecorder) Pools(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pools", reflect.TypeOf((*MockP)(nil).Pools), arg0, arg1)
+}
+
+// RemoveSubnetValidator mocks base method.
+func (m *MockP) RemoveSubnetValidator(arg0 context.Context, arg1 key.Key, arg2 ids.ID, arg3 ids.ShortID, arg4 ...client.OpOption) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveSubnetValidator", varargs...)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveSubnetValidator indicates an expected call of RemoveSubnetValidator.
+func (mr *MockPMockRecorder) RemoveSubnetValidator(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubnetValidator", reflect.TypeOf((*MockP)(nil).RemoveSubnetValidator), varargs...)
+}
+
+// StakingAsset mocks base method.
+func (m *MockP) StakingAsset(arg0 context.Context, arg1 ids.ID) (*client.Asset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StakingAsset", arg0, arg1)
+	ret0, _ := ret[0].(*client.Asset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StakingAsset indicates an expected call of StakingAsset.
+func (mr *MockPMockRecorder) StakingAsset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StakingAsset", reflect.TypeOf((*MockP)(nil).StakingAsset), arg0, arg1)
+}
+
+// SubnetOwners mocks base method.
+func (m *MockP) SubnetOwners(arg0 context.Context, arg1 ids.ID) (*secp256k1fx.OutputOwners, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubnetOwners", arg0, arg1)
+	ret0, _ := ret[0].(*secp256k1fx.OutputOwners)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubnetOwners indicates an expected call of SubnetOwners.
+func (mr *MockPMockRecorder) SubnetOwners(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubnetOwners", reflect.TypeOf((*MockP)(nil).SubnetOwners), arg0, arg1)
+}
+
+// Transfer mocks base method.
+func (m *MockP) Transfer(arg0 context.Context, arg1 key.Key, arg2 ids.ShortID, arg3 uint64, arg4 ...client.OpOption) (client.Transfer, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Transfer", varargs...)
+	ret0, _ := ret[0].(client.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockPMockRecorder) Transfer(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockP)(nil).Transfer), varargs...)
+}
+
+// TransferSubnetOwnership mocks base method.
+func (m *MockP) TransferSubnetOwnership(arg0 context.Context, arg1 key.Key, arg2 ids.ID, arg3 *secp256k1fx.OutputOwners, arg4 ...client.OpOption) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TransferSubnetOwnership", varargs...)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferSubnetOwnership indicates an expected call of TransferSubnetOwnership.
+func (mr *MockPMockRecorder) TransferSubnetOwnership(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferSubnetOwnership", reflect.TypeOf((*MockP)(nil).TransferSubnetOwnership), varargs...)
+}
+
+// UTXOs mocks base method.
+func (m *MockP) UTXOs(arg0 context.Context, arg1 []string) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UTXOs", arg0, arg1)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UTXOs indicates an expected call of UTXOs.
+func (mr *MockPMockRecorder) UTXOs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UTXOs", reflect.TypeOf((*MockP)(nil).UTXOs), arg0, arg1)
+}
+
+// MockInfo is a mock of Info interface.
+type MockInfo struct {
+	ctrl     *gomock.Controller
+	recorder *MockInfoMockRecorder
+}
+
+// MockInfoMockRecorder is the mock recorder for MockInfo.
+type MockInfoMockRecorder struct {
+	mock *MockInfo
+}
+
+// NewMockInfo creates a new mock instance.
+func NewMockInfo(ctrl *gomock.Controller) *MockInfo {
+	mock := &MockInfo{ctrl: ctrl}
+	mock.recorder = &MockInfoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInfo) EXPECT() *MockInfoMockRecorder {
+	return m.recorder
+}
+
+// Client mocks base method.
+func (m *MockInfo) Client() info.Client {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Client")
+	ret0, _ := ret[0].(info.Client)
+	return ret0
+}
+
+// Client indicates an expected call of Client.
+func (mr *MockInfoMockRecorder) Client() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Client", reflect.TypeOf((*MockInfo)(nil).Client))
+}
+
+// TxFee mocks base method.
+func (m *MockInfo) TxFee(arg0 context.Context) (*info.GetTxFeeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxFee", arg0)
+	ret0, _ := ret[0].(*info.GetTxFeeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TxFee indicates an expected call of TxFee.
+func (mr *MockInfoMockRecorder) TxFee(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxFee", reflect.TypeOf((*MockInfo)(nil).TxFee), arg0)
+}
+
+// MockHealth is a mock of Health interface.
+type MockHealth struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthMockRecorder
+}
+
+// MockHealthMockRecorder is the mock recorder for MockHealth.
+type MockHealthMockRecorder struct {
+	mock *MockHealth
+}
+
+// NewMockHealth creates a new mock instance.
+func NewMockHealth(ctrl *gomock.Controller) *MockHealth {
+	mock := &MockHealth{ctrl: ctrl}
+	mock.recorder = &MockHealthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealth) EXPECT() *MockHealthMockRecorder {
+	return m.recorder
+}
+
+// Client mocks base method.
+func (m *MockHealth) Client() health.Client {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Client")
+	ret0, _ := ret[0].(health.Client)
+	return ret0
+}
+
+// Client indicates an expected call of Client.
+func (mr *MockHealthMockRecorder) Client() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Client", reflect.TypeOf((*MockHealth)(nil).Client))
+}
+
+// Healthy mocks base method.
+func (m *MockHealth) Healthy(arg0 context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Healthy", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Healthy indicates an expected call of Healthy.
+func (mr *MockHealthMockRecorder) Healthy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Healthy", reflect.TypeOf((*MockHealth)(nil).Healthy), arg0)
+}
+
+// MockKeyStore is a mock of KeyStore interface.
+type MockKeyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyStoreMockRecorder
+}
+
+// MockKeyStoreMockRecorder is the mock recorder for MockKeyStore.
+type MockKeyStoreMockRecorder struct {
+	mock *MockKeyStore
+}
+
+// NewMockKeyStore creates a new mock instance.
+func NewMockKeyStore(ctrl *gomock.Controller) *MockKeyStore {
+	mock := &MockKeyStore{ctrl: ctrl}
+	mock.recorder = &MockKeyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyStore) EXPECT() *MockKeyStoreMockRecorder {
+	return m.recorder
+}
+
+// Client mocks base method.
+func (m *MockKeyStore) Client() keystore.Client {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Client")
+	ret0, _ := ret[0].(keystore.Client)
+	return ret0
+}
+
+// Client indicates an expected call of Client.
+func (mr *MockKeyStoreMockRecorder) Client() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Client", reflect.TypeOf((*MockKeyStore)(nil).Client))
+}
@@ -0,0 +1,34 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var ErrInvalidOutputOwners = errors.New("invalid output owners")
+
+// NewOutputOwners returns the owners of the outputs spendable after
+// [locktime] (unix seconds, 0 for no lock) by any [threshold] of [addrs].
+func NewOutputOwners(locktime uint64, threshold uint32, addrs []ids.ShortID) (*secp256k1fx.OutputOwners, error) {
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("%w: no address", ErrInvalidOutputOwners)
+	}
+	sorted := make([]ids.ShortID, len(addrs))
+	copy(sorted, addrs)
+	ids.SortShortIDs(sorted)
+	owners := &secp256k1fx.OutputOwners{
+		Locktime:  locktime,
+		Threshold: threshold,
+		Addrs:     sorted,
+	}
+	if err := owners.Verify(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOutputOwners, err)
+	}
+	return owners, nil
+}
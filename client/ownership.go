@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/internal/metrics"
+)
+
+func (pc *p) TransferSubnetOwnership(
+	ctx context.Context,
+	k key.Key,
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	opts ...OpOption,
+) (took time.Duration, err error) {
+	err = pc.rebuilding("transfer_subnet_ownership", func() error {
+		took, err = pc.transferSubnetOwnership(ctx, k, subnetID, owner, opts...)
+		return err
+	}, nil)
+	return took, err
+}
+
+// ref. "platformvm.wallet.IssueTransferSubnetOwnershipTx".
+func (pc *p) transferSubnetOwnership(
+	ctx context.Context,
+	k key.Key,
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	opts ...OpOption,
+) (took time.Duration, err error) {
+	ret := &Op{}
+	ret.applyOpts(opts)
+
+	if subnetID == ids.Empty {
+		return 0, ErrEmptyID
+	}
+	if err := owner.Verify(); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidOutputOwners, err)
+	}
+	if err := pc.checkTxType(ctx, "TransferSubnetOwnershipTx"); err != nil {
+		return 0, err
+	}
+
+	fi, err := pc.txFees(ctx)
+	if err != nil {
+		return 0, err
+	}
+	txFee := uint64(fi.TxFee)
+
+	zap.L().Info("transferring subnet ownership",
+		zap.String("subnetId", subnetID.String()),
+		zap.Uint32("threshold", owner.Threshold),
+		zap.Int("controlKeys", len(owner.Addrs)),
+		zap.Uint64("txFee", txFee),
+	)
+	ins, returnedOuts, _, signers, err := pc.stake(ctx, ret.payer(k), txFee, WithOutputOwners(ret.outputOwners))
+	if err != nil {
+		return 0, err
+	}
+	defer pc.release(ins)
+	subnetAuth, subnetSigners, err := pc.authorize(ctx, k, subnetID, ret.subnetSigners)
+	if err != nil {
+		return 0, err
+	}
+	signers = append(signers, subnetSigners)
+
+	utx := &codec.TransferSubnetOwnershipTx{
+		BaseTx: platformvm.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    pc.networkID,
+			BlockchainID: pc.pChainID,
+			Ins:          ins,
+			Outs:         returnedOuts,
+			Memo:         ret.memo,
+		}},
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+		Owner:      owner,
+	}
+	pTx := &platformvm.Tx{
+		UnsignedTx: utx,
+	}
+	if ret.proposal != nil {
+		if err := pc.checkTx(utx, k, ret.payer(k)); err != nil {
+			return 0, err
+		}
+		ret.proposal.Tx, ret.proposal.Signers = pTx, signers
+		return 0, nil
+	}
+	if err := pc.sign(pTx, signers, k, ret.payer(k)); err != nil {
+		return 0, err
+	}
+	if err := utx.SyntacticVerify(&snow.Context{
+		NetworkID: pc.networkID,
+		ChainID:   pc.pChainID,
+	}); err != nil {
+		return 0, err
+	}
+	if _, err := pc.issueTx(ctx, "transfer_subnet_ownership", pTx.Bytes()); err != nil {
+		return 0, fmt.Errorf("failed to issue tx: %w", err)
+	}
+	return pc.waitTx(ctx, "transfer_subnet_ownership", pTx)
+}
+
+// currentOwners returns the control keys and the threshold of the subnet
+// reported by "platform.getSubnets", which follow the ownership transfers
+// (ref. "TransferSubnetOwnershipTx"), or nil if the subnet is not found.
+func (pc *p) currentOwners(ctx context.Context, subnetID ids.ID) (*secp256k1fx.OutputOwners, error) {
+	reqStart := time.Now()
+	ss, err := pc.cli.GetSubnets(ctx, []ids.ID{subnetID})
+	metrics.ObserveAPI("platform.getSubnets", reqStart, err)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range ss {
+		if s.ID != subnetID {
+			continue
+		}
+		addrs := make([]ids.ShortID, len(s.ControlKeys))
+		for i, ck := range s.ControlKeys {
+			_, _, b, err := formatting.ParseAddress(ck)
+			if err != nil {
+				return nil, err
+			}
+			addrs[i], err = ids.ToShortID(b)
+			if err != nil {
+				return nil, err
+			}
+		}
+		ids.SortShortIDs(addrs)
+		return &secp256k1fx.OutputOwners{Threshold: uint32(s.Threshold), Addrs: addrs}, nil
+	}
+	return nil, nil
+}
@@ -8,14 +8,19 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	api_info "github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
@@ -23,15 +28,22 @@ import (
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	internal_avax "github.com/ava-labs/subnet-cli/internal/avax"
 	"github.com/ava-labs/subnet-cli/internal/codec"
+	"github.com/ava-labs/subnet-cli/internal/fork"
 	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/internal/metrics"
 	internal_platformvm "github.com/ava-labs/subnet-cli/internal/platformvm"
+	"github.com/ava-labs/subnet-cli/internal/txs"
 	"go.uber.org/zap"
 )
 
 var (
 	ErrInsufficientBalanceForGasFee      = errors.New("insufficient balance for gas")
 	ErrInsufficientBalanceForStakeAmount = errors.New("insufficient balance for stake amount")
+	ErrUTXOsInFlight                     = errors.New("UTXOs are spent by in-flight txs")
 	ErrUnexpectedSubnetID                = errors.New("unexpected subnet ID")
+	// ErrTxTooLarge is returned when the signed tx would exceed the max
+	// tx size accepted by the node (e.g., too many inputs).
+	ErrTxTooLarge = txs.ErrTooLarge
 
 	ErrEmptyValidator              = errors.New("empty validator set")
 	ErrAlreadyValidator            = errors.New("already validator")
@@ -51,6 +63,19 @@ type P interface {
 	Client() platformvm.Client
 	Checker() internal_platformvm.Checker
 	Balance(ctx context.Context, key key.Key) (uint64, error)
+	// Pools returns the AVAX of the key split by what it can pay for,
+	// as the locked stakeable AVAX can be staked but cannot pay the fees.
+	Pools(ctx context.Context, key key.Key) (Pools, error)
+	// AssetBalances returns the amounts of each asset (e.g., AVAX and the
+	// staking assets of the elastic subnets) owned by [addrs], split by
+	// what they can pay for.
+	AssetBalances(ctx context.Context, addrs []string) (map[ids.ID]Pools, error)
+	// Asset returns the description of the asset from the X-Chain.
+	Asset(ctx context.Context, assetID ids.ID) (*Asset, error)
+	// Transfer sends [amount] of AVAX from the key to the P-Chain address
+	// [to] with a "BaseTx", and waits until it is committed. The change
+	// is returned to the change address.
+	Transfer(ctx context.Context, k key.Key, to ids.ShortID, amount uint64, opts ...OpOption) (Transfer, error)
 	CreateSubnet(
 		ctx context.Context,
 		key key.Key,
@@ -74,6 +99,26 @@ type P interface {
 		weight uint64,
 		opts ...OpOption,
 	) (took time.Duration, err error)
+	// RemoveSubnetValidator removes the current or pending validator from
+	// the subnet before its end time (requires Banff), and waits until it is
+	// committed. The key must hold enough control keys of the subnet.
+	RemoveSubnetValidator(
+		ctx context.Context,
+		k key.Key,
+		subnetID ids.ID,
+		nodeID ids.ShortID,
+		opts ...OpOption,
+	) (took time.Duration, err error)
+	// TransferSubnetOwnership replaces the owners (i.e., the control keys
+	// and the threshold) of the subnet (requires Durango), and waits until it
+	// is committed. The key must hold enough current control keys.
+	TransferSubnetOwnership(
+		ctx context.Context,
+		k key.Key,
+		subnetID ids.ID,
+		owner *secp256k1fx.OutputOwners,
+		opts ...OpOption,
+	) (took time.Duration, err error)
 	CreateBlockchain(
 		ctx context.Context,
 		key key.Key,
@@ -88,6 +133,84 @@ type P interface {
 		rsubnetID ids.ID,
 		nodeID ids.ShortID,
 	) (start time.Time, end time.Time, err error)
+	// GetValidators returns all current validators of the subnet.
+	// If no [rsubnetID] is provided, it returns the primary network validators.
+	GetValidators(ctx context.Context, rsubnetID ids.ID) ([]Validator, error)
+	// GetPendingValidators returns the validators of the subnet that are
+	// added, but whose validation has not started yet. If no [rsubnetID]
+	// is provided, it returns the pending primary network validators.
+	GetPendingValidators(ctx context.Context, rsubnetID ids.ID) ([]Validator, error)
+	// SubnetOwners returns the control keys and the threshold of the subnet,
+	// as specified in its "CreateSubnetTx", or in its last ownership
+	// transfer.
+	SubnetOwners(ctx context.Context, subnetID ids.ID) (*secp256k1fx.OutputOwners, error)
+	// CheckSubnetAuth verifies that the key holds enough control keys
+	// of the subnet to satisfy its threshold. If not, it returns an error
+	// of type "*ErrNotAuthorized" with the list of missing control keys.
+	CheckSubnetAuth(ctx context.Context, k key.Key, subnetID ids.ID) error
+	// IssueSignedTx issues the fully signed transaction, and waits
+	// until it is committed.
+	IssueSignedTx(ctx context.Context, pTx *platformvm.Tx, txType string) (txID ids.ID, took time.Duration, err error)
+	// BlockchainTx returns the "CreateChainTx" of the blockchain, which
+	// holds its name, VM ID, feature extension IDs, and genesis.
+	BlockchainTx(ctx context.Context, blockchainID ids.ID) (*platformvm.UnsignedCreateChainTx, error)
+	// GetSubnets returns all subnets with their control keys and threshold.
+	GetSubnets(ctx context.Context) ([]Subnet, error)
+	// GetBlockchains returns all blockchains with their subnet and VM IDs.
+	GetBlockchains(ctx context.Context) ([]platformvm.APIBlockchain, error)
+	// UTXOs returns the raw P-Chain UTXOs of the addresses, fetching all
+	// pages. If "Config.UTXOs" is set, it returns those instead.
+	UTXOs(ctx context.Context, addrs []string) ([][]byte, error)
+	// Balances returns the AVAX (including the locked one) owned by each of
+	// [addrs], omitting the addresses without any UTXO. A UTXO owned by
+	// several of the addresses counts for each of them.
+	Balances(ctx context.Context, addrs []ids.ShortID) (map[ids.ShortID]uint64, error)
+	// Capabilities returns the tx formats accepted by the network, based on
+	// the version of the node and the activated network upgrades.
+	Capabilities(ctx context.Context) (*fork.Capabilities, error)
+	// StakingAsset returns the asset staked by the validators of the
+	// elastic subnet, described from the X-Chain. It returns an error
+	// matching "ErrNotElastic" if the subnet has no staking asset.
+	StakingAsset(ctx context.Context, subnetID ids.ID) (*Asset, error)
+	// Consolidate merges the spendable AVAX UTXOs of the key, split into as
+	// many txs as needed to fit the max tx size (and at most [maxInputs]
+	// inputs per tx, if non-zero). [progress] is called once each tx is
+	// committed (or signed, in dry mode), if not nil.
+	Consolidate(
+		ctx context.Context,
+		k key.Key,
+		maxInputs int,
+		progress func(done int, total int, c Consolidation),
+		opts ...OpOption,
+	) ([]Consolidation, error)
+}
+
+// Subnet is the parsed record of a subnet.
+type Subnet struct {
+	ID          ids.ID
+	ControlKeys []ids.ShortID
+	Threshold   uint32
+}
+
+// Proposal is an unsigned transaction, along with the addresses
+// required to sign each of its credentials.
+type Proposal struct {
+	Tx      *platformvm.Tx
+	Signers [][]ids.ShortID
+}
+
+// Validator is the parsed record of a validator.
+type Validator struct {
+	NodeID ids.ShortID
+	Start  time.Time
+	End    time.Time
+	// Weight is the subnet validation weight, or the stake amount for
+	// the primary network validators.
+	Weight uint64
+	// Uptime is the observed uptime ratio in [0, 1] as reported by the
+	// queried node. Only set for the primary network validators.
+	Uptime    float64
+	Connected bool
 }
 
 type p struct {
@@ -96,25 +219,395 @@ type p struct {
 	networkID   uint32
 	assetID     ids.ID
 	pChainID    ids.ID
+	xChainID    ids.ID
 
 	cli     platformvm.Client
+	x       avm.Client
 	info    api_info.Client
 	checker internal_platformvm.Checker
+
+	// UTXOs spent by the in-flight txs, shared by the concurrent txs
+	utxos *internal_avax.Reservations
+	// guards "cfg.UTXOs", which drops the UTXOs of the committed txs
+	offlineMu sync.Mutex
+	// file locks of the wallets spent from, shared with the other processes
+	locks *walletLocks
 }
 
 func (pc *p) Client() platformvm.Client            { return pc.cli }
 func (pc *p) Checker() internal_platformvm.Checker { return pc.checker }
 
 func (pc *p) Balance(ctx context.Context, key key.Key) (uint64, error) {
+	if pc.cfg.UTXOs != nil {
+		return pc.offlineBalance()
+	}
+	reqStart := time.Now()
 	pb, err := pc.cli.GetBalance(ctx, key.P())
+	metrics.ObserveAPI("platform.getBalance", reqStart, err)
 	if err != nil {
 		return 0, err
 	}
+	metrics.Balance.WithLabelValues(key.P()[0]).Set(float64(pb.Balance))
 	return uint64(pb.Balance), nil
 }
 
+// offlineBalance sums the AVAX of the UTXOs set in the config.
+func (pc *p) offlineBalance() (uint64, error) {
+	ps, err := pc.offlinePools()
+	if err != nil {
+		return 0, err
+	}
+	return ps.Total(), nil
+}
+
+// Pools is the AVAX balance split by spendability
+// (ref. "platform.getBalance").
+type Pools struct {
+	// Unlocked can pay the fees and the stake.
+	Unlocked uint64
+	// LockedStakeable can only be staked until its locktime.
+	LockedStakeable uint64
+	// LockedNotStakeable cannot be spent until its locktime.
+	LockedNotStakeable uint64
+}
+
+// Total returns the sum of the pools.
+func (ps Pools) Total() uint64 {
+	return ps.Unlocked + ps.LockedStakeable + ps.LockedNotStakeable
+}
+
+func (pc *p) Pools(ctx context.Context, key key.Key) (Pools, error) {
+	if pc.cfg.UTXOs != nil {
+		return pc.offlinePools()
+	}
+	reqStart := time.Now()
+	pb, err := pc.cli.GetBalance(ctx, key.P())
+	metrics.ObserveAPI("platform.getBalance", reqStart, err)
+	if err != nil {
+		return Pools{}, err
+	}
+	metrics.Balance.WithLabelValues(key.P()[0]).Set(float64(pb.Balance))
+	return Pools{
+		Unlocked:           uint64(pb.Unlocked),
+		LockedStakeable:    uint64(pb.LockedStakeable),
+		LockedNotStakeable: uint64(pb.LockedNotStakeable),
+	}, nil
+}
+
+// offlinePools splits the AVAX of the UTXOs set in the config
+// by their locktime.
+func (pc *p) offlinePools() (Pools, error) {
+	aps, err := assetPools(pc.offlineUTXOs(), uint64(time.Now().Unix()))
+	if err != nil {
+		return Pools{}, err
+	}
+	return aps[pc.assetID], nil
+}
+
+func (pc *p) AssetBalances(ctx context.Context, addrs []string) (map[ids.ID]Pools, error) {
+	ubs, err := pc.UTXOs(ctx, addrs)
+	if err != nil {
+		return nil, err
+	}
+	return assetPools(ubs, uint64(time.Now().Unix()))
+}
+
+// assetPools splits the amounts of the UTXOs by asset, and by their
+// locktime at [now].
+func assetPools(ubs [][]byte, now uint64) (map[ids.ID]Pools, error) {
+	aps := make(map[ids.ID]Pools)
+	for _, ub := range ubs {
+		utxo, err := internal_avax.ParseUTXO(ub, codec.PCodecManager)
+		if err != nil {
+			return nil, err
+		}
+		out, ok := utxo.Out.(avax.TransferableOut)
+		if !ok {
+			continue
+		}
+		ps := aps[utxo.AssetID()]
+		pool := &ps.Unlocked
+		switch o := out.(type) {
+		case *platformvm.StakeableLockOut:
+			if o.Locktime > now {
+				pool = &ps.LockedStakeable
+			}
+		case *secp256k1fx.TransferOutput:
+			if o.Locktime > now {
+				pool = &ps.LockedNotStakeable
+			}
+		}
+		*pool, err = math.Add64(*pool, out.Amount())
+		if err != nil {
+			return nil, err
+		}
+		aps[utxo.AssetID()] = ps
+	}
+	return aps, nil
+}
+
+// utxosPageSize is the max number of UTXOs returned by "platform.getUTXOs".
+const utxosPageSize = 1024
+
+func (pc *p) UTXOs(ctx context.Context, addrs []string) ([][]byte, error) {
+	if pc.cfg.UTXOs != nil {
+		return pc.offlineUTXOs(), nil
+	}
+	utxos := make([][]byte, 0)
+	startAddr, startUTXOID := "", ""
+	for {
+		reqStart := time.Now()
+		ubs, idx, err := pc.cli.GetAtomicUTXOs(ctx, addrs, "", utxosPageSize, startAddr, startUTXOID)
+		metrics.ObserveAPI("platform.getUTXOs", reqStart, err)
+		if err != nil {
+			return nil, err
+		}
+		utxos = append(utxos, ubs...)
+		if len(ubs) < utxosPageSize {
+			return utxos, nil
+		}
+		startAddr, startUTXOID = idx.Address, idx.UTXO
+	}
+}
+
+// offlineUTXOs returns the UTXOs of "Config.UTXOs" not spent yet.
+func (pc *p) offlineUTXOs() [][]byte {
+	pc.offlineMu.Lock()
+	defer pc.offlineMu.Unlock()
+	return pc.cfg.UTXOs
+}
+
+// spentOffline drops the UTXOs consumed by [pTx] from "Config.UTXOs", not
+// to spend them again in the next txs (e.g., of "wizard").
+func (pc *p) spentOffline(pTx *platformvm.Tx) error {
+	if pc.cfg.UTXOs == nil {
+		return nil
+	}
+	spent := pTx.UnsignedTx.InputIDs()
+	pc.offlineMu.Lock()
+	defer pc.offlineMu.Unlock()
+	// a new slice (non-nil, even once all spent), as the returned ones
+	// may still be read
+	utxos := make([][]byte, 0, len(pc.cfg.UTXOs))
+	for _, ub := range pc.cfg.UTXOs {
+		utxo, err := internal_avax.ParseUTXO(ub, codec.PCodecManager)
+		if err != nil {
+			return err
+		}
+		if !spent.Contains(utxo.InputID()) {
+			utxos = append(utxos, ub)
+		}
+	}
+	pc.cfg.UTXOs = utxos
+	return nil
+}
+
+func (pc *p) Balances(ctx context.Context, addrs []ids.ShortID) (map[ids.ShortID]uint64, error) {
+	hrp := constants.GetHRP(pc.networkID)
+	paddrs := make([]string, len(addrs))
+	queried := make(map[ids.ShortID]struct{}, len(addrs))
+	for i, addr := range addrs {
+		paddr, err := formatting.FormatAddress("P", hrp, addr.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		paddrs[i] = paddr
+		queried[addr] = struct{}{}
+	}
+	ubs, err := pc.UTXOs(ctx, paddrs)
+	if err != nil {
+		return nil, err
+	}
+	balances := make(map[ids.ShortID]uint64)
+	for _, ub := range ubs {
+		utxo, err := internal_avax.ParseUTXO(ub, codec.PCodecManager)
+		if err != nil {
+			return nil, err
+		}
+		if utxo.AssetID() != pc.assetID {
+			continue
+		}
+		out := utxo.Out
+		if lockedOut, ok := out.(*platformvm.StakeableLockOut); ok {
+			out = lockedOut.TransferableOut
+		}
+		transferOut, ok := out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			continue
+		}
+		for _, addr := range transferOut.Addrs {
+			if _, ok := queried[addr]; !ok {
+				continue
+			}
+			balances[addr], err = math.Add64(balances[addr], transferOut.Amt)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return balances, nil
+}
+
+// checkTx calls "Config.CheckTx" with the addresses of the keys.
+func (pc *p) checkTx(utx platformvm.UnsignedTx, keys ...key.Key) error {
+	if pc.cfg.OnSigning != nil {
+		d, err := txs.Digest(utx)
+		if err != nil {
+			return err
+		}
+		pc.cfg.OnSigning(Signing{TxType: txs.TypeName(utx), Digest: d})
+	}
+	if pc.cfg.CheckTx == nil {
+		return nil
+	}
+	var self []ids.ShortID
+	for _, k := range keys {
+		self = append(self, k.Addresses()...)
+	}
+	return pc.cfg.CheckTx(utx, self)
+}
+
+// sign signs the tx with the keys, once verified it is for the network of
+// the client, it passes "Config.CheckTx", and it fits the max tx size of
+// the node. The signers are split across the keys if more than one (e.g.,
+// the fee payer and the subnet control key).
+func (pc *p) sign(pTx *platformvm.Tx, signers [][]ids.ShortID, keys ...key.Key) error {
+	if err := txs.CheckChain(pTx.UnsignedTx, pc.networkID, pc.pChainID); err != nil {
+		return err
+	}
+	if err := pc.checkTx(pTx.UnsignedTx, keys...); err != nil {
+		return err
+	}
+	c, err := txs.Measure(pTx.UnsignedTx, signers)
+	if err != nil {
+		return err
+	}
+	zap.L().Debug("measured tx",
+		zap.Int("size", c.Size),
+		zap.Int("inputs", c.Inputs),
+		zap.Int("outputs", c.Outputs),
+		zap.Int("signatures", c.Signatures),
+	)
+	if err := c.Check(); err != nil {
+		return err
+	}
+	if len(keys) == 1 || keys[1] == keys[0] {
+		return keys[0].Sign(pTx, signers)
+	}
+
+	// ref. "platformvm.Tx.Sign"
+	unsignedBytes, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, &pTx.UnsignedTx)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
+	}
+	hash := hashing.ComputeHash256(unsignedBytes)
+	addrs := make([]ids.ShortID, 0, len(signers))
+	for _, inputSigners := range signers {
+		addrs = append(addrs, inputSigners...)
+	}
+	sigs := make(map[ids.ShortID][]byte)
+	for _, k := range keys {
+		ksigs, err := k.SignHash(hash, addrs)
+		if err != nil {
+			return err
+		}
+		for addr, sig := range ksigs {
+			sigs[addr] = sig
+		}
+	}
+	for _, inputSigners := range signers {
+		cred := &secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(inputSigners)),
+		}
+		for i, signer := range inputSigners {
+			sig, ok := sigs[signer]
+			if !ok {
+				paddr, err := formatting.FormatAddress("P", constants.GetHRP(pc.networkID), signer.Bytes())
+				if err != nil {
+					return err
+				}
+				return &ErrNotAuthorized{MissingKeys: []string{paddr}}
+			}
+			copy(cred.Sigs[i][:], sig)
+		}
+		pTx.Creds = append(pTx.Creds, cred)
+	}
+	signedBytes, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, pTx)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal signed tx: %w", err)
+	}
+	pTx.Initialize(unsignedBytes, signedBytes)
+	return nil
+}
+
+// issueTx issues the signed tx bytes, recording the issuance by tx type.
+func (pc *p) issueTx(ctx context.Context, txType string, b []byte) (ids.ID, error) {
+	reqStart := time.Now()
+	txID, err := pc.cli.IssueTx(ctx, b)
+	metrics.ObserveAPI("platform.issueTx", reqStart, err)
+	if err != nil {
+		metrics.TxFailures.WithLabelValues(txType).Inc()
+		if IsNetworkError(err) {
+			return ids.Empty, err
+		}
+		return ids.Empty, parseNodeError(err)
+	}
+	metrics.TxIssued.WithLabelValues(txType).Inc()
+	pc.recordIssued(txID)
+	if pc.cfg.OnIssued != nil {
+		pc.cfg.OnIssued(Issued{TxID: txID, TxType: txType})
+	}
+	return txID, nil
+}
+
+// txFees returns the fee config of the network, with the fees of
+// "Config.FeeOverride".
+func (pc *p) txFees(ctx context.Context) (*api_info.GetTxFeeResponse, error) {
+	if pc.cfg.FeeOverride.Complete() {
+		return pc.cfg.FeeOverride.Apply(nil), nil
+	}
+	reqStart := time.Now()
+	fi, err := pc.info.GetTxFee(ctx)
+	metrics.ObserveAPI("info.getTxFee", reqStart, err)
+	if err != nil {
+		return nil, err
+	}
+	return pc.cfg.FeeOverride.Apply(fi), nil
+}
+
+func (pc *p) Capabilities(ctx context.Context) (*fork.Capabilities, error) {
+	reqStart := time.Now()
+	v, err := pc.info.GetNodeVersion(ctx)
+	metrics.ObserveAPI("info.getNodeVersion", reqStart, err)
+	if err != nil {
+		return nil, err
+	}
+	return fork.Detect(pc.networkID, v.Version, time.Now())
+}
+
+// checkTxType returns an error if the network no longer accepts the tx
+// type. The check is skipped if the node version cannot be queried.
+func (pc *p) checkTxType(ctx context.Context, txType string) error {
+	c, err := pc.Capabilities(ctx)
+	if err != nil {
+		zap.L().Warn("failed to detect the network upgrades", zap.Error(err))
+		return nil
+	}
+	return c.Check(txType)
+}
+
+// decodeError explains the failure to decode a tx returned by the node,
+// if the node uses the tx formats of a later network upgrade.
+func (pc *p) decodeError(ctx context.Context, err error) error {
+	c, cerr := pc.Capabilities(ctx)
+	if cerr != nil {
+		return err
+	}
+	return c.WrapDecodeError(err)
+}
+
 // ref. "platformvm.VM.newCreateSubnetTx".
-func (pc *p) CreateSubnet(
+func (pc *p) createSubnet(
 	ctx context.Context,
 	k key.Key,
 	opts ...OpOption,
@@ -122,7 +615,7 @@ func (pc *p) CreateSubnet(
 	ret := &Op{}
 	ret.applyOpts(opts)
 
-	fi, err := pc.info.GetTxFee(ctx)
+	fi, err := pc.txFees(ctx)
 	if err != nil {
 		return ids.Empty, 0, err
 	}
@@ -133,10 +626,11 @@ func (pc *p) CreateSubnet(
 		zap.String("assetId", pc.assetID.String()),
 		zap.Uint64("createSubnetTxFee", createSubnetTxFee),
 	)
-	ins, returnedOuts, _, signers, err := pc.stake(ctx, k, createSubnetTxFee)
+	ins, returnedOuts, _, signers, err := pc.stake(ctx, ret.payer(k), createSubnetTxFee, WithOutputOwners(ret.outputOwners))
 	if err != nil {
 		return ids.Empty, 0, err
 	}
+	defer pc.release(ins)
 
 	utx := &platformvm.UnsignedCreateSubnetTx{
 		BaseTx: platformvm.BaseTx{BaseTx: avax.BaseTx{
@@ -144,6 +638,7 @@ func (pc *p) CreateSubnet(
 			BlockchainID: pc.pChainID,
 			Ins:          ins,
 			Outs:         returnedOuts,
+			Memo:         ret.memo,
 		}},
 		Owner: &secp256k1fx.OutputOwners{
 			// [threshold] of [ownerAddrs] needed to manage this subnet
@@ -157,7 +652,15 @@ func (pc *p) CreateSubnet(
 	pTx := &platformvm.Tx{
 		UnsignedTx: utx,
 	}
-	if err := k.Sign(pTx, signers); err != nil {
+	if ret.proposal != nil {
+		if err := pc.checkTx(utx, ret.payer(k)); err != nil {
+			return ids.Empty, 0, err
+		}
+		// subnet ID is only known once signed
+		ret.proposal.Tx, ret.proposal.Signers = pTx, signers
+		return ids.Empty, 0, nil
+	}
+	if err := pc.sign(pTx, signers, ret.payer(k)); err != nil {
 		return ids.Empty, 0, err
 	}
 	if err := utx.SyntacticVerify(&snow.Context{
@@ -173,7 +676,7 @@ func (pc *p) CreateSubnet(
 		return subnetID, 0, nil
 	}
 
-	txID, err := pc.cli.IssueTx(ctx, pTx.Bytes())
+	txID, err := pc.issueTx(ctx, "create_subnet", pTx.Bytes())
 	if err != nil {
 		return subnetID, 0, fmt.Errorf("failed to issue tx: %w", err)
 	}
@@ -181,8 +684,13 @@ func (pc *p) CreateSubnet(
 		return subnetID, 0, ErrUnexpectedSubnetID
 	}
 
+	took, err = pc.waitTx(ctx, "create_subnet", pTx)
+	if err != nil {
+		return txID, took, err
+	}
+	prev := took
 	took, err = pc.checker.PollSubnet(ctx, txID)
-	return txID, took, err
+	return txID, prev + took, err
 }
 
 func (pc *p) GetValidator(ctx context.Context, rsubnetID ids.ID, nodeID ids.ShortID) (start time.Time, end time.Time, err error) {
@@ -193,7 +701,9 @@ func (pc *p) GetValidator(ctx context.Context, rsubnetID ids.ID, nodeID ids.Shor
 	}
 
 	// Find validator data associated with [nodeID]
+	reqStart := time.Now()
 	vs, err := pc.Client().GetCurrentValidators(ctx, subnetID, []ids.ShortID{nodeID})
+	metrics.ObserveAPI("platform.getCurrentValidators", reqStart, err)
 	if err != nil {
 		return time.Time{}, time.Time{}, err
 	}
@@ -203,51 +713,137 @@ func (pc *p) GetValidator(ctx context.Context, rsubnetID ids.ID, nodeID ids.Shor
 	if len(vs) < 1 {
 		return time.Time{}, time.Time{}, ErrValidatorNotFound
 	}
-	var validator map[string]interface{}
 	for _, v := range vs {
-		va, ok := v.(map[string]interface{})
-		if !ok {
-			return time.Time{}, time.Time{}, fmt.Errorf("%w: %T %+v", ErrInvalidValidatorData, v, v)
+		validator, err := parseValidator(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
 		}
-		nodeIDs, ok := va["nodeID"].(string)
-		if !ok {
-			return time.Time{}, time.Time{}, ErrInvalidValidatorData
+		if validator.NodeID == nodeID {
+			return validator.Start, validator.End, nil
 		}
-		if nodeIDs == nodeID.PrefixedString(constants.NodeIDPrefix) {
-			validator = va
-			break
+	}
+
+	// This should never happen if the length of [vs] > 1, however,
+	// we defend against it in case.
+	return time.Time{}, time.Time{}, ErrValidatorNotFound
+}
+
+func (pc *p) GetValidators(ctx context.Context, rsubnetID ids.ID) ([]Validator, error) {
+	subnetID := constants.PrimaryNetworkID
+	if rsubnetID != ids.Empty {
+		subnetID = rsubnetID
+	}
+	var validators []Validator
+	err := pc.cfg.cached("validators/"+subnetID.String(), &validators, func() error {
+		reqStart := time.Now()
+		vs, err := pc.Client().GetCurrentValidators(ctx, subnetID, nil)
+		metrics.ObserveAPI("platform.getCurrentValidators", reqStart, err)
+		if err != nil {
+			return err
+		}
+		validators = make([]Validator, 0, len(vs))
+		for _, v := range vs {
+			validator, err := parseValidator(v)
+			if err != nil {
+				return err
+			}
+			validators = append(validators, validator)
 		}
+		return nil
+	})
+	return validators, err
+}
+
+func (pc *p) GetPendingValidators(ctx context.Context, rsubnetID ids.ID) ([]Validator, error) {
+	subnetID := constants.PrimaryNetworkID
+	if rsubnetID != ids.Empty {
+		subnetID = rsubnetID
 	}
-	if validator == nil {
-		// This should never happen if the length of [vs] > 1, however,
-		// we defend against it in case.
-		return time.Time{}, time.Time{}, ErrValidatorNotFound
+	var validators []Validator
+	err := pc.cfg.cached("pendingValidators/"+subnetID.String(), &validators, func() error {
+		reqStart := time.Now()
+		vs, _, err := pc.Client().GetPendingValidators(ctx, subnetID, nil)
+		metrics.ObserveAPI("platform.getPendingValidators", reqStart, err)
+		if err != nil {
+			return err
+		}
+		validators = make([]Validator, 0, len(vs))
+		for _, v := range vs {
+			validator, err := parseValidator(v)
+			if err != nil {
+				return err
+			}
+			validators = append(validators, validator)
+		}
+		return nil
+	})
+	return validators, err
+}
+
+// parseValidator parses the validator record returned by the
+// "platform.getCurrentValidators" and "platform.getPendingValidators" APIs
+// (of format "platformvm.APIStaker").
+func parseValidator(v interface{}) (Validator, error) {
+	va, ok := v.(map[string]interface{})
+	if !ok {
+		return Validator{}, fmt.Errorf("%w: %T %+v", ErrInvalidValidatorData, v, v)
 	}
+	nodeIDs, ok := va["nodeID"].(string)
+	if !ok {
+		return Validator{}, ErrInvalidValidatorData
+	}
+	nodeID, err := ids.ShortFromPrefixedString(nodeIDs, constants.NodeIDPrefix)
+	if err != nil {
+		return Validator{}, err
+	}
+	validator := Validator{NodeID: nodeID}
+
 	// Parse start/end time once the validator data is found (of format
 	// `json.Uint64`)
-	d, ok := validator["startTime"].(string)
-	if !ok {
-		return time.Time{}, time.Time{}, ErrInvalidValidatorData
+	start, err := parseJSONUint64(va, "startTime")
+	if err != nil {
+		return Validator{}, err
 	}
-	dv, err := strconv.ParseInt(d, 10, 64)
+	validator.Start = time.Unix(int64(start), 0)
+	end, err := parseJSONUint64(va, "endTime")
 	if err != nil {
-		return time.Time{}, time.Time{}, err
+		return Validator{}, err
 	}
-	start = time.Unix(dv, 0)
-	d, ok = validator["endTime"].(string)
-	if !ok {
-		return time.Time{}, time.Time{}, ErrInvalidValidatorData
+	validator.End = time.Unix(int64(end), 0)
+
+	// subnet validators have "weight" while the primary network
+	// validators have "stakeAmount"
+	switch {
+	case va["weight"] != nil:
+		validator.Weight, err = parseJSONUint64(va, "weight")
+	case va["stakeAmount"] != nil:
+		validator.Weight, err = parseJSONUint64(va, "stakeAmount")
 	}
-	dv, err = strconv.ParseInt(d, 10, 64)
 	if err != nil {
-		return time.Time{}, time.Time{}, err
+		return Validator{}, err
+	}
+
+	// only reported for the primary network validators (of format `json.Float32`)
+	if d, ok := va["uptime"].(string); ok {
+		validator.Uptime, err = strconv.ParseFloat(d, 64)
+		if err != nil {
+			return Validator{}, err
+		}
 	}
-	end = time.Unix(dv, 0)
-	return start, end, nil
+	validator.Connected, _ = va["connected"].(bool)
+	return validator, nil
+}
+
+func parseJSONUint64(va map[string]interface{}, field string) (uint64, error) {
+	d, ok := va[field].(string)
+	if !ok {
+		return 0, ErrInvalidValidatorData
+	}
+	return strconv.ParseUint(d, 10, 64)
 }
 
 // ref. "platformvm.VM.newAddSubnetValidatorTx".
-func (pc *p) AddSubnetValidator(
+func (pc *p) addSubnetValidator(
 	ctx context.Context,
 	k key.Key,
 	subnetID ids.ID,
@@ -290,7 +886,7 @@ func (pc *p) AddSubnetValidator(
 		return 0, fmt.Errorf("%w (validate end %v expected <%v)", ErrInvalidSubnetValidatePeriod, end, validateEnd)
 	}
 
-	fi, err := pc.info.GetTxFee(ctx)
+	fi, err := pc.txFees(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -303,11 +899,12 @@ func (pc *p) AddSubnetValidator(
 		zap.Time("end", end),
 		zap.Uint64("weight", weight),
 	)
-	ins, returnedOuts, _, signers, err := pc.stake(ctx, k, txFee)
+	ins, returnedOuts, _, signers, err := pc.stake(ctx, ret.payer(k), txFee, WithOutputOwners(ret.outputOwners))
 	if err != nil {
 		return 0, err
 	}
-	subnetAuth, subnetSigners, err := pc.authorize(ctx, k, subnetID)
+	defer pc.release(ins)
+	subnetAuth, subnetSigners, err := pc.authorize(ctx, k, subnetID, ret.subnetSigners)
 	if err != nil {
 		return 0, err
 	}
@@ -319,6 +916,7 @@ func (pc *p) AddSubnetValidator(
 			BlockchainID: pc.pChainID,
 			Ins:          ins,
 			Outs:         returnedOuts,
+			Memo:         ret.memo,
 		}},
 		Validator: platformvm.SubnetValidator{
 			Validator: platformvm.Validator{
@@ -334,7 +932,14 @@ func (pc *p) AddSubnetValidator(
 	pTx := &platformvm.Tx{
 		UnsignedTx: utx,
 	}
-	if err := k.Sign(pTx, signers); err != nil {
+	if ret.proposal != nil {
+		if err := pc.checkTx(utx, k, ret.payer(k)); err != nil {
+			return 0, err
+		}
+		ret.proposal.Tx, ret.proposal.Signers = pTx, signers
+		return 0, nil
+	}
+	if err := pc.sign(pTx, signers, k, ret.payer(k)); err != nil {
 		return 0, err
 	}
 	if err := utx.SyntacticVerify(&snow.Context{
@@ -343,16 +948,16 @@ func (pc *p) AddSubnetValidator(
 	}); err != nil {
 		return 0, err
 	}
-	txID, err := pc.cli.IssueTx(ctx, pTx.Bytes())
+	_, err = pc.issueTx(ctx, "add_subnet_validator", pTx.Bytes())
 	if err != nil {
 		return 0, fmt.Errorf("failed to issue tx: %w", err)
 	}
 
-	return pc.checker.PollTx(ctx, txID, pstatus.Committed)
+	return pc.waitTx(ctx, "add_subnet_validator", pTx)
 }
 
 // ref. "platformvm.VM.newAddValidatorTx".
-func (pc *p) AddValidator(
+func (pc *p) addValidator(
 	ctx context.Context,
 	k key.Key,
 	nodeID ids.ShortID,
@@ -366,6 +971,9 @@ func (pc *p) AddValidator(
 	if nodeID == ids.ShortEmpty {
 		return 0, ErrEmptyID
 	}
+	if err := pc.checkTxType(ctx, "AddValidatorTx"); err != nil {
+		return 0, err
+	}
 
 	_, _, err = pc.GetValidator(ctx, ids.ID{}, nodeID)
 	if err == nil {
@@ -421,10 +1029,12 @@ func (pc *p) AddValidator(
 		WithRewardAddress(ret.rewardAddr),
 		WithRewardShares(ret.rewardShares),
 		WithChangeAddress(ret.changeAddr),
+		WithOutputOwners(ret.outputOwners),
 	)
 	if err != nil {
 		return 0, err
 	}
+	defer pc.release(ins)
 
 	utx := &platformvm.UnsignedAddValidatorTx{
 		BaseTx: platformvm.BaseTx{BaseTx: avax.BaseTx{
@@ -432,6 +1042,7 @@ func (pc *p) AddValidator(
 			BlockchainID: pc.pChainID,
 			Ins:          ins,
 			Outs:         returnedOuts,
+			Memo:         ret.memo,
 		}},
 		Validator: platformvm.Validator{
 			NodeID: nodeID,
@@ -439,18 +1050,14 @@ func (pc *p) AddValidator(
 			End:    uint64(end.Unix()),
 			Wght:   ret.stakeAmt,
 		},
-		Stake: stakedOuts,
-		RewardsOwner: &secp256k1fx.OutputOwners{
-			Locktime:  0,
-			Threshold: 1,
-			Addrs:     []ids.ShortID{ret.rewardAddr},
-		},
-		Shares: ret.rewardShares,
+		Stake:        stakedOuts,
+		RewardsOwner: ret.rewardOwners(),
+		Shares:       ret.rewardShares,
 	}
 	pTx := &platformvm.Tx{
 		UnsignedTx: utx,
 	}
-	if err := k.Sign(pTx, signers); err != nil {
+	if err := pc.sign(pTx, signers, k); err != nil {
 		return 0, err
 	}
 	if err := utx.SyntacticVerify(&snow.Context{
@@ -459,16 +1066,16 @@ func (pc *p) AddValidator(
 	}); err != nil {
 		return 0, err
 	}
-	txID, err := pc.cli.IssueTx(ctx, pTx.Bytes())
+	_, err = pc.issueTx(ctx, "add_validator", pTx.Bytes())
 	if err != nil {
 		return 0, fmt.Errorf("failed to issue tx: %w", err)
 	}
 
-	return pc.checker.PollTx(ctx, txID, pstatus.Committed)
+	return pc.waitTx(ctx, "add_validator", pTx)
 }
 
 // ref. "platformvm.VM.newCreateChainTx".
-func (pc *p) CreateBlockchain(
+func (pc *p) createBlockchain(
 	ctx context.Context,
 	k key.Key,
 	subnetID ids.ID,
@@ -487,7 +1094,7 @@ func (pc *p) CreateBlockchain(
 		return ids.Empty, 0, ErrEmptyID
 	}
 
-	fi, err := pc.info.GetTxFee(ctx)
+	fi, err := pc.txFees(ctx)
 	if err != nil {
 		return ids.Empty, 0, err
 	}
@@ -500,11 +1107,12 @@ func (pc *p) CreateBlockchain(
 		zap.String("vmId", vmID.String()),
 		zap.Uint64("createBlockchainTxFee", createBlkChainTxFee),
 	)
-	ins, returnedOuts, _, signers, err := pc.stake(ctx, k, createBlkChainTxFee)
+	ins, returnedOuts, _, signers, err := pc.stake(ctx, ret.payer(k), createBlkChainTxFee, WithOutputOwners(ret.outputOwners))
 	if err != nil {
 		return ids.Empty, 0, err
 	}
-	subnetAuth, subnetSigners, err := pc.authorize(ctx, k, subnetID)
+	defer pc.release(ins)
+	subnetAuth, subnetSigners, err := pc.authorize(ctx, k, subnetID, ret.subnetSigners)
 	if err != nil {
 		return ids.Empty, 0, err
 	}
@@ -516,18 +1124,27 @@ func (pc *p) CreateBlockchain(
 			BlockchainID: pc.pChainID,
 			Ins:          ins,
 			Outs:         returnedOuts,
+			Memo:         ret.memo,
 		}},
 		SubnetID:    subnetID,
 		ChainName:   chainName,
 		VMID:        vmID,
-		FxIDs:       nil,
+		FxIDs:       ret.fxIDs,
 		GenesisData: vmGenesis,
 		SubnetAuth:  subnetAuth,
 	}
 	pTx := &platformvm.Tx{
 		UnsignedTx: utx,
 	}
-	if err := k.Sign(pTx, signers); err != nil {
+	if ret.proposal != nil {
+		if err := pc.checkTx(utx, k, ret.payer(k)); err != nil {
+			return ids.Empty, 0, err
+		}
+		// blockchain ID is only known once signed
+		ret.proposal.Tx, ret.proposal.Signers = pTx, signers
+		return ids.Empty, 0, nil
+	}
+	if err := pc.sign(pTx, signers, k, ret.payer(k)); err != nil {
 		return ids.Empty, 0, err
 	}
 	if err := utx.SyntacticVerify(&snow.Context{
@@ -536,7 +1153,7 @@ func (pc *p) CreateBlockchain(
 	}); err != nil {
 		return ids.Empty, 0, err
 	}
-	blkChainID, err = pc.cli.IssueTx(ctx, pTx.Bytes())
+	blkChainID, err = pc.issueTx(ctx, "create_blockchain", pTx.Bytes())
 	if err != nil {
 		return ids.Empty, 0, fmt.Errorf("failed to issue tx: %w", err)
 	}
@@ -552,18 +1169,120 @@ func (pc *p) CreateBlockchain(
 			internal_platformvm.WithCheckBlockchainBootstrapped(pc.info),
 		)
 		took += bTook
+		if err == nil {
+			pc.committed("create_blockchain", pTx, took)
+		}
 	}
 	return blkChainID, took, err
 }
 
+func (pc *p) IssueSignedTx(ctx context.Context, pTx *platformvm.Tx, txType string) (ids.ID, time.Duration, error) {
+	if err := pTx.UnsignedTx.SyntacticVerify(&snow.Context{
+		NetworkID: pc.networkID,
+		ChainID:   pc.pChainID,
+	}); err != nil {
+		return ids.Empty, 0, err
+	}
+	now := time.Now()
+	txID, err := pc.issueTx(ctx, txType, pTx.Bytes())
+	if err != nil {
+		return ids.Empty, 0, fmt.Errorf("failed to issue tx: %w", err)
+	}
+	_, err = pc.checker.PollTx(ctx, txID, pstatus.Committed)
+	took := time.Since(now)
+	if err == nil {
+		pc.committed(txType, pTx, took)
+	}
+	return txID, took, err
+}
+
+// waitTx polls the tx status at "Config.PollInterval" until the tx is
+// committed. The tx dropped by the node is issued again as is, up to
+// "Config.MaxTxRetries" times, if it may be accepted later
+// (ref. "RetryResubmit").
+func (pc *p) waitTx(ctx context.Context, txType string, pTx *platformvm.Tx) (took time.Duration, err error) {
+	defer func() {
+		if err == nil {
+			pc.committed(txType, pTx, took)
+		}
+	}()
+
+	for i := 0; ; i++ {
+		var prev time.Duration
+		prev, err = pc.checker.PollTx(ctx, pTx.ID(), pstatus.Committed)
+		took += prev
+		var txErr *internal_platformvm.TxError
+		if err == nil || i >= pc.cfg.MaxTxRetries ||
+			!errors.As(err, &txErr) || txErr.Retry() != internal_platformvm.RetryResubmit {
+			return took, err
+		}
+		metrics.Retries.WithLabelValues("resubmit").Inc()
+		zap.L().Warn("re-submitting dropped tx as is",
+			zap.String("txType", txType),
+			zap.String("txId", pTx.ID().String()),
+			zap.String("reason", txErr.Reason),
+			zap.Int("retry", i+1),
+			zap.Int("maxRetries", pc.cfg.MaxTxRetries),
+		)
+		if _, err := pc.issueTx(ctx, txType, pTx.Bytes()); err != nil {
+			return took, fmt.Errorf("failed to re-submit tx: %w", err)
+		}
+	}
+}
+
+// committed notifies "Config.OnCommitted" of the committed tx, if set,
+// and drops the UTXOs it spent from "Config.UTXOs".
+func (pc *p) committed(txType string, pTx *platformvm.Tx, took time.Duration) {
+	if err := pc.spentOffline(pTx); err != nil {
+		zap.L().Warn("failed to drop the spent UTXOs", zap.Error(err))
+	}
+	if pc.cfg.OnCommitted == nil {
+		return
+	}
+	fee := uint64(0)
+	if s, err := txs.Decode(pTx.Bytes()); err != nil {
+		zap.L().Warn("failed to decode the committed tx", zap.Error(err))
+	} else {
+		fee = s.Fee[pc.assetID]
+	}
+	pc.cfg.OnCommitted(Committed{
+		TxID:   pTx.ID(),
+		TxType: txType,
+		Fee:    fee,
+		Took:   took,
+	})
+}
+
 type Op struct {
 	stakeAmt     uint64
 	rewardShares uint32
 	rewardAddr   ids.ShortID
 	changeAddr   ids.ShortID
+	// non-nil to own the change, the returned stake, and the rewards
+	// instead of the change and the reward addresses
+	outputOwners *secp256k1fx.OutputOwners
+
+	fxIDs []ids.ID
+
+	// control keys to authorize the subnet operation with,
+	// instead of the ones held by the key
+	subnetSigners []ids.ShortID
+	// non-nil to only build the unsigned tx
+	proposal *Proposal
+	// non-nil to pay the fee with, instead of the key
+	feeKey key.Key
 
 	dryMode bool
 	poll    bool
+	// true to stake the locked stakeable outputs (e.g., vested AVAX)
+	stakeableLocked bool
+	// non-empty to stake another asset than AVAX
+	stakeAssetID ids.ID
+
+	// arbitrary bytes attached to the tx
+	memo []byte
+	// true to pay the transfer fee out of the sent amount
+	deductFee bool
 }
 
 type OpOption func(*Op)
@@ -598,6 +1317,111 @@ func WithChangeAddress(v ids.ShortID) OpOption {
 	}
 }
 
+// WithFeeKey sets the key to pay the tx fee with, while the key provides
+// the subnet auth. Ignored by "AddValidator", whose stake is paid by the key.
+func WithFeeKey(k key.Key) OpOption {
+	return func(op *Op) {
+		op.feeKey = k
+	}
+}
+
+// payer returns the key to pay the fee with.
+func (op *Op) payer(k key.Key) key.Key {
+	if op.feeKey != nil {
+		return op.feeKey
+	}
+	return k
+}
+
+// WithStakeableLocked set to true stakes the locked stakeable outputs of
+// the key first, which cannot pay the fee. Otherwise, only the unlocked
+// outputs are spent.
+func WithStakeableLocked(v bool) OpOption {
+	return func(op *Op) {
+		op.stakeableLocked = v
+	}
+}
+
+// WithStakeAsset sets the asset to stake, such as the staking asset of an
+// elastic subnet. The fee is still paid in AVAX. Default to AVAX.
+func WithStakeAsset(assetID ids.ID) OpOption {
+	return func(op *Op) {
+		op.stakeAssetID = assetID
+	}
+}
+
+// WithOutputOwners sets the locktime and the multisig owners of the
+// outputs created by the tx. Nil to use the change and reward addresses.
+func WithOutputOwners(v *secp256k1fx.OutputOwners) OpOption {
+	return func(op *Op) {
+		op.outputOwners = v
+	}
+}
+
+// changeOwners returns the owners of the change and the returned stake.
+func (op *Op) changeOwners() secp256k1fx.OutputOwners {
+	if op.outputOwners != nil {
+		return *op.outputOwners
+	}
+	return secp256k1fx.OutputOwners{
+		Locktime:  0,
+		Threshold: 1,
+		Addrs:     []ids.ShortID{op.changeAddr},
+	}
+}
+
+// rewardOwners returns the owners of the validation rewards.
+func (op *Op) rewardOwners() *secp256k1fx.OutputOwners {
+	if op.outputOwners != nil {
+		owners := *op.outputOwners
+		return &owners
+	}
+	return &secp256k1fx.OutputOwners{
+		Locktime:  0,
+		Threshold: 1,
+		Addrs:     []ids.ShortID{op.rewardAddr},
+	}
+}
+
+func WithFxIDs(v []ids.ID) OpOption {
+	return func(op *Op) {
+		op.fxIDs = v
+	}
+}
+
+// WithSubnetSigners sets the subnet control keys expected to sign the
+// subnet operation, which may not be held by the key (e.g., multisig).
+func WithSubnetSigners(v []ids.ShortID) OpOption {
+	return func(op *Op) {
+		op.subnetSigners = v
+	}
+}
+
+// WithProposal only builds the unsigned tx into [p], without signing
+// nor issuing it. The key must still be able to pay the fee.
+func WithProposal(p *Proposal) OpOption {
+	return func(op *Op) {
+		op.proposal = p
+	}
+}
+
+// WithMemo attaches [memo] to the tx for bookkeeping, up to 256 bytes
+// (ref. "avax.MaxMemoSize").
+func WithMemo(memo []byte) OpOption {
+	return func(op *Op) {
+		op.memo = memo
+	}
+}
+
+// WithDeductFee set to true pays the fee of the transfer out of the sent
+// amount, the recipient receiving the amount minus the fee. Otherwise,
+// the fee is paid on top of the amount.
+func WithDeductFee(b bool) OpOption {
+	return func(op *Op) {
+		op.deductFee = b
+	}
+}
+
 func WithDryMode(b bool) OpOption {
 	return func(op *Op) {
 		op.dryMode = b
@@ -610,13 +1434,72 @@ func WithPoll(b bool) OpOption {
 	}
 }
 
-// ref. "platformvm.VM.stake".
+// stake selects and reserves the inputs to burn [fee] and stake, skipping
+// the UTXOs spent by the in-flight txs of the same client. If those UTXOs
+// are needed, it waits for the in-flight txs to return their change.
+// The wallet of the key is locked until then, if "Config.LockDir" is set,
+// for the other processes spending its UTXOs to wait for the tx.
+// The caller must release the inputs once the tx is accepted or failed.
 func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption) (
 	ins []*avax.TransferableInput,
 	returnedOuts []*avax.TransferableOutput,
 	stakedOuts []*avax.TransferableOutput,
 	signers [][]ids.ShortID,
 	err error,
+) {
+	wallet := k.Addresses()[0]
+	if err := pc.lockWallet(ctx, wallet); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	defer func() {
+		if err != nil || len(ins) == 0 {
+			pc.unlockWallet(wallet)
+			return
+		}
+		pc.lockInputs(wallet, ins)
+	}()
+	for {
+		// register before selecting, not to miss a release in between
+		released := pc.utxos.Released()
+		ins, returnedOuts, stakedOuts, signers, err = pc.selectInputs(ctx, k, fee, opts...)
+		switch {
+		case errors.Is(err, ErrUTXOsInFlight):
+			zap.L().Info("waiting for in-flight txs to release UTXOs")
+			select {
+			case <-ctx.Done():
+				return nil, nil, nil, nil, fmt.Errorf("%w: %v", err, ctx.Err())
+			case <-released:
+			}
+		case err != nil:
+			return nil, nil, nil, nil, err
+		case pc.utxos.Reserve(inputIDs(ins)...):
+			return ins, returnedOuts, stakedOuts, signers, nil
+		}
+		// selected concurrently with another tx, retry
+	}
+}
+
+// release releases the inputs reserved by [stake], and the wallet lock.
+func (pc *p) release(ins []*avax.TransferableInput) {
+	pc.utxos.Release(inputIDs(ins)...)
+	pc.unlockInputs(ins)
+}
+
+func inputIDs(ins []*avax.TransferableInput) []ids.ID {
+	utxoIDs := make([]ids.ID, len(ins))
+	for i, in := range ins {
+		utxoIDs[i] = in.InputID()
+	}
+	return utxoIDs
+}
+
+// ref. "platformvm.VM.stake".
+func (pc *p) selectInputs(ctx context.Context, k key.Key, fee uint64, opts ...OpOption) (
+	ins []*avax.TransferableInput,
+	returnedOuts []*avax.TransferableOutput,
+	stakedOuts []*avax.TransferableOutput,
+	signers [][]ids.ShortID,
+	err error,
 ) {
 	ret := &Op{}
 	ret.applyOpts(opts)
@@ -627,35 +1510,47 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 		ret.changeAddr = k.Addresses()[0]
 	}
 
-	ubs, _, err := pc.cli.GetAtomicUTXOs(ctx, k.P(), "", 100, "", "")
+	ubs, err := pc.UTXOs(ctx, k.P())
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
 
-	now := uint64(time.Now().Unix())
+	now := pc.now()
+	// the fee is always burned in AVAX
+	stakeAssetID := ret.stakeAssetID
+	if stakeAssetID == ids.Empty {
+		stakeAssetID = pc.assetID
+	}
 
 	ins = make([]*avax.TransferableInput, 0)
 	returnedOuts = make([]*avax.TransferableOutput, 0)
 	stakedOuts = make([]*avax.TransferableOutput, 0)
 
-	utxos := make([]*avax.UTXO, len(ubs))
-	for i, ub := range ubs {
-		utxos[i], err = internal_avax.ParseUTXO(ub, codec.PCodecManager)
+	utxos := make([]*avax.UTXO, 0, len(ubs))
+	inFlight := 0
+	for _, ub := range ubs {
+		utxo, err := internal_avax.ParseUTXO(ub, codec.PCodecManager)
 		if err != nil {
 			return nil, nil, nil, nil, err
 		}
+		if pc.utxos.Reserved(utxo.InputID()) {
+			inFlight++
+			continue
+		}
+		utxos = append(utxos, utxo)
 	}
+	pc.sortUTXOs(utxos)
 
 	// amount of AVAX that has been staked
 	amountStaked := uint64(0)
 	for _, utxo := range utxos {
+		// locked stakeable AVAX is only spent if opted in
 		// have staked more AVAX then we need to
 		// no need to consume more AVAX
-		if amountStaked >= ret.stakeAmt {
+		if !ret.stakeableLocked || amountStaked >= ret.stakeAmt {
 			break
 		}
-		// assume "AssetID" is set to "AVAX" asset ID
-		if utxo.AssetID() != pc.assetID {
+		if utxo.AssetID() != stakeAssetID {
 			continue
 		}
 
@@ -677,7 +1572,12 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 			continue
 		}
 
-		_, inputs, inputSigners := k.Spends([]*avax.UTXO{utxo}, key.WithTime(now))
+		_, inputs, inputSigners := k.Spends(
+			[]*avax.UTXO{utxo},
+			key.WithTime(now),
+			key.WithAssetID(stakeAssetID),
+			key.WithStakeableLocked(),
+		)
 		if len(inputs) == 0 {
 			// cannot spend this UTXO, skip to try next one
 			continue
@@ -697,7 +1597,7 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 
 		// Add the output to the staked outputs
 		stakedOuts = append(stakedOuts, &avax.TransferableOutput{
-			Asset: avax.Asset{ID: pc.assetID},
+			Asset: avax.Asset{ID: stakeAssetID},
 			Out: &platformvm.StakeableLockOut{
 				Locktime: out.Locktime,
 				TransferableOut: &secp256k1fx.TransferOutput{
@@ -708,17 +1608,15 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 		})
 
 		if remainingValue > 0 {
-			// input had extra value, so some of it must be returned
+			// input had extra value, so some of it must be returned,
+			// still locked until the same time
 			returnedOuts = append(returnedOuts, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: pc.assetID},
-				Out: &secp256k1fx.TransferOutput{
-					Amt: remainingValue,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-
-						// address to send change to, if there is any
-						Addrs: []ids.ShortID{ret.changeAddr},
+				Asset: avax.Asset{ID: stakeAssetID},
+				Out: &platformvm.StakeableLockOut{
+					Locktime: out.Locktime,
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt:          remainingValue,
+						OutputOwners: inner.OutputOwners,
 					},
 				},
 			})
@@ -738,22 +1636,17 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 		if amountStaked >= ret.stakeAmt && amountBurned >= fee {
 			break
 		}
-		// assume "AssetID" is set to "AVAX" asset ID
-		if utxo.AssetID() != pc.assetID {
+		// only AVAX burns, and only the stake asset stakes,
+		// never consume an asset no longer needed
+		assetID := utxo.AssetID()
+		burns := assetID == pc.assetID && amountBurned < fee
+		stakes := assetID == stakeAssetID && amountStaked < ret.stakeAmt
+		if !burns && !stakes {
 			continue
 		}
 
-		out := utxo.Out
-		inner, ok := out.(*platformvm.StakeableLockOut)
-		if ok {
-			if inner.Locktime > now {
-				// output currently locked, can't be burned
-				// skip for next UTXO
-				continue
-			}
-			utxo.Out = inner.TransferableOut
-		}
-		_, inputs, inputSigners := k.Spends([]*avax.UTXO{utxo}, key.WithTime(now))
+		// outputs currently locked can't be burned, thus skipped
+		_, inputs, inputSigners := k.Spends([]*avax.UTXO{utxo}, key.WithTime(now), key.WithAssetID(assetID))
 		if len(inputs) == 0 {
 			// cannot spend this UTXO, skip to try next one
 			continue
@@ -763,33 +1656,34 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 		// initially the full value of the input
 		remainingValue := in.In.Amount()
 
-		// burn any value that should be burned
-		amountToBurn := math.Min64(
-			fee-amountBurned, // amount we still need to burn
-			remainingValue,   // amount available to burn
-		)
-		amountBurned += amountToBurn
-		remainingValue -= amountToBurn
+		if assetID == pc.assetID {
+			// burn any value that should be burned
+			amountToBurn := math.Min64(
+				fee-amountBurned, // amount we still need to burn
+				remainingValue,   // amount available to burn
+			)
+			amountBurned += amountToBurn
+			remainingValue -= amountToBurn
+		}
 
-		// stake any value that should be staked
-		amountToStake := math.Min64(
-			ret.stakeAmt-amountStaked, // Amount we still need to stake
-			remainingValue,            // Amount available to stake
-		)
-		amountStaked += amountToStake
-		remainingValue -= amountToStake
+		amountToStake := uint64(0)
+		if assetID == stakeAssetID {
+			// stake any value that should be staked
+			amountToStake = math.Min64(
+				ret.stakeAmt-amountStaked, // Amount we still need to stake
+				remainingValue,            // Amount available to stake
+			)
+			amountStaked += amountToStake
+			remainingValue -= amountToStake
+		}
 
 		if amountToStake > 0 {
 			// Some of this input was put for staking
 			stakedOuts = append(stakedOuts, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: pc.assetID},
+				Asset: avax.Asset{ID: assetID},
 				Out: &secp256k1fx.TransferOutput{
-					Amt: amountToStake,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-						Addrs:     []ids.ShortID{ret.changeAddr},
-					},
+					Amt:          amountToStake,
+					OutputOwners: ret.changeOwners(),
 				},
 			})
 		}
@@ -797,16 +1691,11 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 		if remainingValue > 0 {
 			// input had extra value, so some of it must be returned
 			returnedOuts = append(returnedOuts, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: pc.assetID},
+				Asset: avax.Asset{ID: assetID},
 				Out: &secp256k1fx.TransferOutput{
 					Amt: remainingValue,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-
-						// address to send change to, if there is any
-						Addrs: []ids.ShortID{ret.changeAddr},
-					},
+					// owners to send change to, if there is any
+					OutputOwners: ret.changeOwners(),
 				},
 			})
 		}
@@ -816,13 +1705,25 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 		signers = append(signers, inputSigners...)
 	}
 
+	if inFlight > 0 && (amountStaked < ret.stakeAmt || amountBurned < fee) {
+		return nil, nil, nil, nil, ErrUTXOsInFlight
+	}
 	if amountStaked > 0 && amountStaked < ret.stakeAmt {
-		return nil, nil, nil, nil, ErrInsufficientBalanceForStakeAmount
+		return nil, nil, nil, nil, &ErrInsufficientFunds{
+			Needed: ret.stakeAmt,
+			Have:   amountStaked,
+			kind:   ErrInsufficientBalanceForStakeAmount,
+		}
 	}
 	if amountBurned > 0 && amountBurned < fee {
-		return nil, nil, nil, nil, ErrInsufficientBalanceForGasFee
+		return nil, nil, nil, nil, &ErrInsufficientFunds{
+			Needed: fee,
+			Have:   amountBurned,
+			kind:   ErrInsufficientBalanceForGasFee,
+		}
 	}
 
+	returnedOuts = pc.mergeChange(returnedOuts)
 	key.SortTransferableInputsWithSigners(ins, signers)             // sort inputs
 	avax.SortTransferableOutputs(returnedOuts, codec.PCodecManager) // sort outputs
 	avax.SortTransferableOutputs(stakedOuts, codec.PCodecManager)   // sort outputs
@@ -830,35 +1731,200 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 	return ins, returnedOuts, stakedOuts, signers, nil
 }
 
-// ref. "platformvm.VM.authorize".
-func (pc *p) authorize(ctx context.Context, k key.Key, subnetID ids.ID) (
-	auth verify.Verifiable, // input that names owners
-	signers []ids.ShortID,
-	err error,
-) {
+func (pc *p) SubnetOwners(ctx context.Context, subnetID ids.ID) (*secp256k1fx.OutputOwners, error) {
+	if subnetID == ids.Empty {
+		return nil, ErrEmptyID
+	}
+	reqStart := time.Now()
 	tb, err := pc.cli.GetTx(ctx, subnetID)
+	metrics.ObserveAPI("platform.getTx", reqStart, err)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	tx := new(platformvm.Tx)
 	if _, err = codec.PCodecManager.Unmarshal(tb, tx); err != nil {
-		return nil, nil, err
+		return nil, pc.decodeError(ctx, err)
 	}
 
 	subnetTx, ok := tx.UnsignedTx.(*platformvm.UnsignedCreateSubnetTx)
 	if !ok {
-		return nil, nil, ErrWrongTxType
+		return nil, ErrWrongTxType
 	}
 
 	owner, ok := subnetTx.Owner.(*secp256k1fx.OutputOwners)
 	if !ok {
-		return nil, nil, ErrUnknownOwners
+		return nil, ErrUnknownOwners
+	}
+	current, err := pc.currentOwners(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && !current.Equals(&secp256k1fx.OutputOwners{Threshold: owner.Threshold, Addrs: owner.Addrs}) {
+		// transferred since its creation, without the locktime of the
+		// "CreateSubnetTx" owners
+		return current, nil
+	}
+	return owner, nil
+}
+
+func (pc *p) GetSubnets(ctx context.Context) ([]Subnet, error) {
+	var subnets []Subnet
+	err := pc.cfg.cached("subnets", &subnets, func() error {
+		reqStart := time.Now()
+		ss, err := pc.cli.GetSubnets(ctx, nil)
+		metrics.ObserveAPI("platform.getSubnets", reqStart, err)
+		if err != nil {
+			return err
+		}
+		subnets = make([]Subnet, len(ss))
+		for i, s := range ss {
+			controlKeys := make([]ids.ShortID, len(s.ControlKeys))
+			for j, ck := range s.ControlKeys {
+				_, _, b, err := formatting.ParseAddress(ck)
+				if err != nil {
+					return err
+				}
+				controlKeys[j], err = ids.ToShortID(b)
+				if err != nil {
+					return err
+				}
+			}
+			subnets[i] = Subnet{
+				ID:          s.ID,
+				ControlKeys: controlKeys,
+				Threshold:   uint32(s.Threshold),
+			}
+		}
+		return nil
+	})
+	return subnets, err
+}
+
+func (pc *p) GetBlockchains(ctx context.Context) ([]platformvm.APIBlockchain, error) {
+	var bcs []platformvm.APIBlockchain
+	err := pc.cfg.cached("blockchains", &bcs, func() (err error) {
+		reqStart := time.Now()
+		bcs, err = pc.cli.GetBlockchains(ctx)
+		metrics.ObserveAPI("platform.getBlockchains", reqStart, err)
+		return err
+	})
+	return bcs, err
+}
+
+func (pc *p) BlockchainTx(ctx context.Context, blockchainID ids.ID) (*platformvm.UnsignedCreateChainTx, error) {
+	if blockchainID == ids.Empty {
+		return nil, ErrEmptyID
+	}
+	// blockchain ID is the ID of its "CreateChainTx"
+	reqStart := time.Now()
+	tb, err := pc.cli.GetTx(ctx, blockchainID)
+	metrics.ObserveAPI("platform.getTx", reqStart, err)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := new(platformvm.Tx)
+	if _, err = codec.PCodecManager.Unmarshal(tb, tx); err != nil {
+		return nil, pc.decodeError(ctx, err)
+	}
+
+	chainTx, ok := tx.UnsignedTx.(*platformvm.UnsignedCreateChainTx)
+	if !ok {
+		return nil, ErrWrongTxType
+	}
+	return chainTx, nil
+}
+
+// ref. "platformvm.VM.authorize".
+//
+// If [expected] is not empty, the subnet operation is authorized with those
+// control keys instead of the ones held by the key.
+func (pc *p) authorize(ctx context.Context, k key.Key, subnetID ids.ID, expected []ids.ShortID) (
+	auth verify.Verifiable, // input that names owners
+	signers []ids.ShortID,
+	err error,
+) {
+	owner, err := pc.SubnetOwners(ctx, subnetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	now := pc.now()
+	if len(expected) > 0 {
+		indices, signers, err := matchSigners(owner, expected, now)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &secp256k1fx.Input{SigIndices: indices}, signers, nil
 	}
-	now := uint64(time.Now().Unix())
 	indices, signers, ok := k.Match(owner, now)
 	if !ok {
-		return nil, nil, ErrCantSign
+		return nil, nil, pc.cantSignError(k, owner)
 	}
 	return &secp256k1fx.Input{SigIndices: indices}, signers, nil
 }
+
+// matchSigners returns the sorted signature indices of the first
+// [owner.Threshold] control keys found in [expected].
+func matchSigners(owner *secp256k1fx.OutputOwners, expected []ids.ShortID, now uint64) ([]uint32, []ids.ShortID, error) {
+	if now < owner.Locktime {
+		return nil, nil, &ErrNotAuthorized{Threshold: owner.Threshold, Locktime: time.Unix(int64(owner.Locktime), 0)}
+	}
+	want := make(map[ids.ShortID]struct{}, len(expected))
+	for _, addr := range expected {
+		want[addr] = struct{}{}
+	}
+	indices := make([]uint32, 0, owner.Threshold)
+	signers := make([]ids.ShortID, 0, owner.Threshold)
+	for i, addr := range owner.Addrs {
+		if uint32(len(indices)) == owner.Threshold {
+			break
+		}
+		if _, ok := want[addr]; ok {
+			indices = append(indices, uint32(i))
+			signers = append(signers, addr)
+		}
+	}
+	if uint32(len(indices)) < owner.Threshold {
+		return nil, nil, &ErrNotAuthorized{Have: uint32(len(indices)), Threshold: owner.Threshold}
+	}
+	return indices, signers, nil
+}
+
+func (pc *p) CheckSubnetAuth(ctx context.Context, k key.Key, subnetID ids.ID) error {
+	owner, err := pc.SubnetOwners(ctx, subnetID)
+	if err != nil {
+		return err
+	}
+	now := uint64(time.Now().Unix())
+	if _, _, ok := k.Match(owner, now); !ok {
+		return pc.cantSignError(k, owner)
+	}
+	return nil
+}
+
+// cantSignError lists the subnet control keys that are not held by the key.
+func (pc *p) cantSignError(k key.Key, owner *secp256k1fx.OutputOwners) error {
+	if uint64(time.Now().Unix()) < owner.Locktime {
+		return &ErrNotAuthorized{Threshold: owner.Threshold, Locktime: time.Unix(int64(owner.Locktime), 0)}
+	}
+	held := make(map[ids.ShortID]struct{})
+	for _, addr := range k.Addresses() {
+		held[addr] = struct{}{}
+	}
+	hrp := constants.GetHRP(pc.networkID)
+	found := uint32(0)
+	missing := make([]string, 0, len(owner.Addrs))
+	for _, addr := range owner.Addrs {
+		if _, ok := held[addr]; ok {
+			found++
+			continue
+		}
+		paddr, err := formatting.FormatAddress("P", hrp, addr.Bytes())
+		if err != nil {
+			return err
+		}
+		missing = append(missing, paddr)
+	}
+	return &ErrNotAuthorized{MissingKeys: missing, Have: found, Threshold: owner.Threshold}
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+	"github.com/ava-labs/subnet-cli/internal/key"
+)
+
+func (pc *p) RemoveSubnetValidator(
+	ctx context.Context,
+	k key.Key,
+	subnetID ids.ID,
+	nodeID ids.ShortID,
+	opts ...OpOption,
+) (took time.Duration, err error) {
+	err = pc.rebuilding("remove_subnet_validator", func() error {
+		took, err = pc.removeSubnetValidator(ctx, k, subnetID, nodeID, opts...)
+		return err
+	}, nil)
+	return took, err
+}
+
+// ref. "platformvm.wallet.IssueRemoveSubnetValidatorTx".
+func (pc *p) removeSubnetValidator(
+	ctx context.Context,
+	k key.Key,
+	subnetID ids.ID,
+	nodeID ids.ShortID,
+	opts ...OpOption,
+) (took time.Duration, err error) {
+	ret := &Op{}
+	ret.applyOpts(opts)
+
+	if subnetID == ids.Empty || nodeID == ids.ShortEmpty {
+		return 0, ErrEmptyID
+	}
+	if err := pc.checkTxType(ctx, "RemoveSubnetValidatorTx"); err != nil {
+		return 0, err
+	}
+
+	fi, err := pc.txFees(ctx)
+	if err != nil {
+		return 0, err
+	}
+	txFee := uint64(fi.TxFee)
+
+	zap.L().Info("removing subnet validator",
+		zap.String("subnetId", subnetID.String()),
+		zap.String("nodeId", nodeID.String()),
+		zap.Uint64("txFee", txFee),
+	)
+	ins, returnedOuts, _, signers, err := pc.stake(ctx, ret.payer(k), txFee, WithOutputOwners(ret.outputOwners))
+	if err != nil {
+		return 0, err
+	}
+	defer pc.release(ins)
+	subnetAuth, subnetSigners, err := pc.authorize(ctx, k, subnetID, ret.subnetSigners)
+	if err != nil {
+		return 0, err
+	}
+	signers = append(signers, subnetSigners)
+
+	utx := &codec.RemoveSubnetValidatorTx{
+		BaseTx: platformvm.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    pc.networkID,
+			BlockchainID: pc.pChainID,
+			Ins:          ins,
+			Outs:         returnedOuts,
+			Memo:         ret.memo,
+		}},
+		NodeID:     nodeID,
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+	}
+	pTx := &platformvm.Tx{
+		UnsignedTx: utx,
+	}
+	if ret.proposal != nil {
+		if err := pc.checkTx(utx, k, ret.payer(k)); err != nil {
+			return 0, err
+		}
+		ret.proposal.Tx, ret.proposal.Signers = pTx, signers
+		return 0, nil
+	}
+	if err := pc.sign(pTx, signers, k, ret.payer(k)); err != nil {
+		return 0, err
+	}
+	if err := utx.SyntacticVerify(&snow.Context{
+		NetworkID: pc.networkID,
+		ChainID:   pc.pChainID,
+	}); err != nil {
+		return 0, err
+	}
+	if _, err := pc.issueTx(ctx, "remove_subnet_validator", pTx.Bytes()); err != nil {
+		return 0, fmt.Errorf("failed to issue tx: %w", err)
+	}
+	return pc.waitTx(ctx, "remove_subnet_validator", pTx)
+}
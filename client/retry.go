@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/internal/metrics"
+	internal_platformvm "github.com/ava-labs/subnet-cli/internal/platformvm"
+)
+
+// rebuilding calls [issue] again while the tx it issued is dropped for
+// its consumed UTXOs or its passed start time (ref. "RetryRebuild"), up
+// to "Config.MaxTxRetries" times. Each call builds the tx from the UTXOs
+// fetched again, after [restart] (if not nil) moves the start time.
+func (pc *p) rebuilding(txType string, issue func() error, restart func()) error {
+	for i := 0; ; i++ {
+		err := issue()
+		var txErr *internal_platformvm.TxError
+		if err == nil || i >= pc.cfg.MaxTxRetries ||
+			!errors.As(err, &txErr) || txErr.Retry() != internal_platformvm.RetryRebuild {
+			return err
+		}
+		if pc.cfg.UTXOs != nil {
+			// the same UTXOs would be spent again
+			zap.L().Warn("not rebuilding dropped tx with the UTXOs of the file", zap.String("txType", txType))
+			return err
+		}
+		if restart != nil {
+			restart()
+		}
+		metrics.Retries.WithLabelValues("rebuild").Inc()
+		zap.L().Warn("rebuilding dropped tx with the refetched UTXOs",
+			zap.String("txType", txType),
+			zap.String("txId", txErr.TxID.String()),
+			zap.String("reason", txErr.Reason),
+			zap.Int("retry", i+1),
+			zap.Int("maxRetries", pc.cfg.MaxTxRetries),
+		)
+	}
+}
+
+// laterStart returns [start], or [buffer] from now if later, so that the
+// rebuilt staker tx is not dropped again for its passed start time.
+func laterStart(start time.Time, buffer time.Duration) time.Time {
+	if s := time.Now().Add(buffer); s.After(start) {
+		return s
+	}
+	return start
+}
+
+func (pc *p) CreateSubnet(
+	ctx context.Context,
+	k key.Key,
+	opts ...OpOption,
+) (subnetID ids.ID, took time.Duration, err error) {
+	err = pc.rebuilding("create_subnet", func() error {
+		subnetID, took, err = pc.createSubnet(ctx, k, opts...)
+		return err
+	}, nil)
+	return subnetID, took, err
+}
+
+func (pc *p) AddSubnetValidator(
+	ctx context.Context,
+	k key.Key,
+	subnetID ids.ID,
+	nodeID ids.ShortID,
+	start time.Time,
+	end time.Time,
+	weight uint64,
+	opts ...OpOption,
+) (took time.Duration, err error) {
+	buffer := time.Until(start)
+	err = pc.rebuilding("add_subnet_validator", func() error {
+		took, err = pc.addSubnetValidator(ctx, k, subnetID, nodeID, start, end, weight, opts...)
+		return err
+	}, func() { start = laterStart(start, buffer) })
+	return took, err
+}
+
+func (pc *p) AddValidator(
+	ctx context.Context,
+	k key.Key,
+	nodeID ids.ShortID,
+	start time.Time,
+	end time.Time,
+	opts ...OpOption,
+) (took time.Duration, err error) {
+	buffer := time.Until(start)
+	err = pc.rebuilding("add_validator", func() error {
+		took, err = pc.addValidator(ctx, k, nodeID, start, end, opts...)
+		return err
+	}, func() { start = laterStart(start, buffer) })
+	return took, err
+}
+
+func (pc *p) CreateBlockchain(
+	ctx context.Context,
+	k key.Key,
+	subnetID ids.ID,
+	chainName string,
+	vmID ids.ID,
+	vmGenesis []byte,
+	opts ...OpOption,
+) (blkChainID ids.ID, took time.Duration, err error) {
+	err = pc.rebuilding("create_blockchain", func() error {
+		blkChainID, took, err = pc.createBlockchain(ctx, k, subnetID, chainName, vmID, vmGenesis, opts...)
+		return err
+	}, nil)
+	return blkChainID, took, err
+}
+
+func (pc *p) Transfer(
+	ctx context.Context,
+	k key.Key,
+	to ids.ShortID,
+	amount uint64,
+	opts ...OpOption,
+) (t Transfer, err error) {
+	err = pc.rebuilding("transfer", func() error {
+		t, err = pc.transfer(ctx, k, to, amount, opts...)
+		return err
+	}, nil)
+	return t, err
+}
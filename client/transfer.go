@@ -0,0 +1,131 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+	"github.com/ava-labs/subnet-cli/internal/key"
+)
+
+var ErrAmountBelowFee = errors.New("transfer amount does not cover the fee")
+
+// Transfer is a committed "BaseTx" sending AVAX to a P-Chain address.
+type Transfer struct {
+	TxID ids.ID
+	// Amount is the AVAX received by the recipient in nAVAX.
+	Amount uint64
+	// Fee is the AVAX burned in nAVAX.
+	Fee  uint64
+	Took time.Duration
+}
+
+// ref. "platformvm.wallet.IssueBaseTx".
+func (pc *p) transfer(
+	ctx context.Context,
+	k key.Key,
+	to ids.ShortID,
+	amount uint64,
+	opts ...OpOption,
+) (Transfer, error) {
+	ret := &Op{}
+	ret.applyOpts(opts)
+	if ret.changeAddr == ids.ShortEmpty {
+		ret.changeAddr = k.Addresses()[0]
+	}
+
+	if to == ids.ShortEmpty {
+		return Transfer{}, ErrEmptyID
+	}
+	if err := pc.checkTxType(ctx, "BaseTx"); err != nil {
+		return Transfer{}, err
+	}
+
+	fi, err := pc.txFees(ctx)
+	if err != nil {
+		return Transfer{}, err
+	}
+	t := Transfer{Amount: amount, Fee: uint64(fi.TxFee)}
+	if ret.deductFee {
+		if amount <= t.Fee {
+			return t, fmt.Errorf("%w: %d nAVAX <= %d nAVAX", ErrAmountBelowFee, amount, t.Fee)
+		}
+		t.Amount = amount - t.Fee
+	}
+
+	zap.L().Info("transferring",
+		zap.Bool("dryMode", ret.dryMode),
+		zap.String("to", to.String()),
+		zap.Uint64("amount", t.Amount),
+		zap.Uint64("txFee", t.Fee),
+		zap.Bool("deductFee", ret.deductFee),
+	)
+	// the sent amount is selected as a stake, then paid to the recipient
+	ins, returnedOuts, _, signers, err := pc.stake(
+		ctx,
+		k,
+		t.Fee,
+		WithStakeAmount(t.Amount),
+		WithChangeAddress(ret.changeAddr),
+		WithOutputOwners(ret.outputOwners),
+	)
+	if err != nil {
+		return t, err
+	}
+	defer pc.release(ins)
+
+	outs := append(returnedOuts, &avax.TransferableOutput{
+		Asset: avax.Asset{ID: pc.assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: t.Amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{to},
+			},
+		},
+	})
+	avax.SortTransferableOutputs(outs, codec.PCodecManager)
+	utx := &codec.BaseTx{
+		BaseTx: platformvm.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    pc.networkID,
+			BlockchainID: pc.pChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         ret.memo,
+		}},
+	}
+	pTx := &platformvm.Tx{
+		UnsignedTx: utx,
+	}
+	if err := pc.sign(pTx, signers, k); err != nil {
+		return t, err
+	}
+	if err := utx.SyntacticVerify(&snow.Context{
+		NetworkID: pc.networkID,
+		ChainID:   pc.pChainID,
+	}); err != nil {
+		return t, err
+	}
+	t.TxID = pTx.ID()
+	if ret.dryMode {
+		return t, nil
+	}
+
+	if _, err := pc.issueTx(ctx, "transfer", pTx.Bytes()); err != nil {
+		return t, fmt.Errorf("failed to issue tx: %w", err)
+	}
+	t.Took, err = pc.waitTx(ctx, "transfer", pTx)
+	return t, err
+}
@@ -0,0 +1,213 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	pstatus "github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/internal/metrics"
+	"github.com/ava-labs/subnet-cli/internal/walletlock"
+)
+
+// walletLocks are the file locks of the wallets held by the client, shared
+// by its concurrent txs spending the UTXOs of the same wallet.
+type walletLocks struct {
+	mu   sync.Mutex
+	held map[ids.ShortID]*heldLock
+	// wallet of each input reserved by "stake", to unlock on release
+	owners map[ids.ID]ids.ShortID
+}
+
+type heldLock struct {
+	// closed once the lock is acquired, or failed to
+	ready chan struct{}
+	lock  *walletlock.Lock
+	err   error
+	refs  int
+	// txs issued while held
+	pending []ids.ID
+}
+
+func newWalletLocks() *walletLocks {
+	return &walletLocks{
+		held:   make(map[ids.ShortID]*heldLock),
+		owners: make(map[ids.ID]ids.ShortID),
+	}
+}
+
+// lockWallet acquires the file lock of the wallet [addr] until
+// "unlockWallet", waiting for the other processes to release it. It is a
+// no-op without "Config.LockDir", or when spending the UTXOs of the config.
+func (pc *p) lockWallet(ctx context.Context, addr ids.ShortID) error {
+	if pc.cfg.LockDir == "" || pc.cfg.UTXOs != nil {
+		return nil
+	}
+	wl := pc.locks
+	wl.mu.Lock()
+	h, ok := wl.held[addr]
+	if ok {
+		h.refs++
+		wl.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			pc.unlockWallet(addr)
+			return ctx.Err()
+		case <-h.ready:
+		}
+		if h.err != nil {
+			pc.unlockWallet(addr)
+			return h.err
+		}
+		return nil
+	}
+	h = &heldLock{ready: make(chan struct{}), refs: 1}
+	wl.held[addr] = h
+	wl.mu.Unlock()
+
+	h.lock, h.err = pc.acquireWallet(ctx, addr)
+	close(h.ready)
+	if h.err != nil {
+		pc.unlockWallet(addr)
+		return h.err
+	}
+	return nil
+}
+
+// acquireWallet acquires the file lock of the wallet [addr], and waits for
+// the txs issued by the previous holders to leave the processing status,
+// for the UTXOs fetched next to reflect them.
+func (pc *p) acquireWallet(ctx context.Context, addr ids.ShortID) (*walletlock.Lock, error) {
+	name, err := formatting.FormatAddress("P", constants.GetHRP(pc.networkID), addr[:])
+	if err != nil {
+		return nil, err
+	}
+	zap.L().Debug("acquiring wallet lock", zap.String("wallet", name), zap.String("dir", pc.cfg.LockDir))
+	l, err := walletlock.Acquire(ctx, pc.cfg.LockDir, name, pc.cfg.PollInterval)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := l.Pending()
+	if err != nil {
+		// the record is advisory, the UTXOs are re-verified on issuance anyway
+		zap.L().Warn("failed to read the issued txs of the wallet lock", zap.String("wallet", name), zap.Error(err))
+	}
+	for _, txID := range pending {
+		reqStart := time.Now()
+		s, err := pc.cli.GetTxStatus(ctx, txID, false)
+		metrics.ObserveAPI("platform.getTxStatus", reqStart, err)
+		if err != nil {
+			zap.L().Warn("failed to get the status of a tx of the wallet lock", zap.Stringer("txId", txID), zap.Error(err))
+			continue
+		}
+		if s.Status != pstatus.Processing {
+			continue
+		}
+		zap.L().Info("waiting for a tx issued by another process to be decided",
+			zap.String("wallet", name),
+			zap.Stringer("txId", txID),
+		)
+		if _, err := pc.checker.PollTx(ctx, txID, pstatus.Committed); err != nil {
+			if ctx.Err() != nil {
+				l.Release()
+				return nil, fmt.Errorf("%w (waiting for tx %s): %v", walletlock.ErrLocked, txID, ctx.Err())
+			}
+			// aborted or dropped, its UTXOs are spendable again
+			zap.L().Debug("tx of the wallet lock not committed", zap.Stringer("txId", txID), zap.Error(err))
+		}
+	}
+	if err := l.SetPending(nil); err != nil {
+		l.Release()
+		return nil, err
+	}
+	return l, nil
+}
+
+// unlockWallet releases the lock of the wallet [addr] acquired by
+// "lockWallet", once no other tx of the client holds it.
+func (pc *p) unlockWallet(addr ids.ShortID) {
+	wl := pc.locks
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+	h, ok := wl.held[addr]
+	if !ok {
+		return
+	}
+	h.refs--
+	if h.refs > 0 {
+		return
+	}
+	delete(wl.held, addr)
+	if h.lock == nil {
+		return
+	}
+	if err := h.lock.Release(); err != nil {
+		zap.L().Warn("failed to release the wallet lock", zap.Error(err))
+	}
+}
+
+// lockInputs records [ins] as spent from the wallet [addr], to unlock it
+// once released.
+func (pc *p) lockInputs(addr ids.ShortID, ins []*avax.TransferableInput) {
+	wl := pc.locks
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+	if _, ok := wl.held[addr]; !ok {
+		return
+	}
+	for _, in := range ins {
+		wl.owners[in.InputID()] = addr
+	}
+}
+
+// unlockInputs unlocks the wallet [ins] were spent from, if locked by
+// "stake".
+func (pc *p) unlockInputs(ins []*avax.TransferableInput) {
+	wl := pc.locks
+	wl.mu.Lock()
+	addrs := make(map[ids.ShortID]struct{})
+	for _, in := range ins {
+		utxoID := in.InputID()
+		if addr, ok := wl.owners[utxoID]; ok {
+			addrs[addr] = struct{}{}
+			delete(wl.owners, utxoID)
+		}
+	}
+	wl.mu.Unlock()
+	for addr := range addrs {
+		pc.unlockWallet(addr)
+	}
+}
+
+// recordIssued records [txID] in the locks held by the client, for the next
+// holders to wait for it if this process exits before it is decided.
+func (pc *p) recordIssued(txID ids.ID) {
+	wl := pc.locks
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+	for _, h := range wl.held {
+		select {
+		case <-h.ready:
+		default:
+			// not acquired yet, so not spent from
+			continue
+		}
+		if h.lock == nil {
+			continue
+		}
+		h.pending = append(h.pending, txID)
+		if err := h.lock.SetPending(h.pending); err != nil {
+			zap.L().Warn("failed to record the issued tx in the wallet lock", zap.Stringer("txId", txID), zap.Error(err))
+		}
+	}
+}
@@ -23,8 +23,11 @@ func AddCommand() *cobra.Command {
 		newAddSubnetValidatorCommand(),
 	)
 	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
-	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path, or key store URI (e.g., 'keychain://subnet-cli/ops', 'env://OPS_KEY')")
 	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	addStakeDurationFlags(cmd)
+	addOutputFlags(cmd)
+	addMemoFlag(cmd)
 	return cmd
 }
 
@@ -32,7 +35,7 @@ func CreateAddTable(i *Info) string {
 	buf, tb := BaseTableSetup(i)
 	tb.Append([]string{formatter.F("{{orange}}NODE IDs{{/}}"), formatter.F("{{light-gray}}{{bold}}%v{{/}}", i.nodeIDs)})
 	if i.subnetID != ids.Empty {
-		tb.Append([]string{formatter.F("{{blue}}SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.subnetID)})
+		tb.Append([]string{formatter.F("{{blue}}SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", labeled(i.subnetID.String()))})
 	}
 	if !i.validateStart.IsZero() {
 		tb.Append([]string{formatter.F("{{magenta}}VALIDATE START{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.validateStart.Format(time.RFC3339))})
@@ -4,21 +4,29 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"os"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/rebalance"
 	"github.com/ava-labs/subnet-cli/pkg/color"
-	"github.com/manifoldco/promptui"
+	"github.com/ava-labs/subnet-cli/pkg/subnet"
+	"github.com/dustin/go-humanize"
+	"github.com/olekukonko/tablewriter"
 	"github.com/onsi/ginkgo/v2/formatter"
 	"github.com/spf13/cobra"
 )
 
 const (
-	defaultValidateWeight = 1000
+	defaultValidateWeight = subnet.DefaultWeight
 )
 
 func newAddSubnetValidatorCommand() *cobra.Command {
@@ -35,29 +43,91 @@ $ subnet-cli add subnet-validator \
 --node-ids="NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH" \
 --validate-weight=1000
 
+With --validate-weight=auto, each node gets a weight proportional to its
+primary network stake: the weight per staked AVAX of the current subnet
+validators, or one per AVAX without current validators.
+
+With --copy-from-subnet (instead of --node-ids), adds the current validators
+of another subnet with their weights there, e.g., to launch a sister chain
+validated by the same fleet. --validate-weight, if set, overrides the
+copied weights.
+
+$ subnet-cli add subnet-validator \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--copy-from-subnet="2bRCr6B4MiEfSjidDwxDpdCyviwnfUVqB2HGwhm947w9YYqb7r"
+
 `,
 		RunE: createSubnetValidatorFunc,
 	}
 
 	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
 	cmd.PersistentFlags().StringSliceVar(&nodeIDs, "node-ids", nil, "a list of node IDs (must be formatted in ids.ID)")
-	cmd.PersistentFlags().Uint64Var(&validateWeight, "validate-weight", defaultValidateWeight, "validate weight")
+	cmd.PersistentFlags().StringVar(&copyFromSubnet, "copy-from-subnet", "", "subnet ID to copy the current validators and their weights from, instead of --node-ids")
+	cmd.PersistentFlags().Var(newWeightValue(defaultValidateWeight, &validateWeight, &validateWeightAuto), "validate-weight", "validate weight, or 'auto' for the weights proportional to the primary network stakes")
+	addFeeKeyFlags(cmd)
 
 	return cmd
 }
 
-var errZeroValidateWeight = errors.New("zero validate weight")
+var (
+	errZeroValidateWeight = errors.New("zero validate weight")
+	errCopyNodeIDs        = errors.New("--copy-from-subnet and --node-ids are mutually exclusive")
+	errCopySameSubnet     = errors.New("--copy-from-subnet is the subnet to add the validators to")
+)
+
+// weightValue is the "--validate-weight" flag value, a weight or "auto".
+type weightValue struct {
+	p    *uint64
+	auto *bool
+}
+
+// newWeightValue sets the default [v] to [p], and returns the flag value
+// that parses into [p], or sets [auto] on "auto".
+func newWeightValue(v uint64, p *uint64, auto *bool) *weightValue {
+	*p = v
+	return &weightValue{p: p, auto: auto}
+}
+
+func (v *weightValue) String() string {
+	if *v.auto {
+		return "auto"
+	}
+	return strconv.FormatUint(*v.p, 10)
+}
+
+func (v *weightValue) Set(s string) error {
+	if s == "auto" {
+		*v.auto = true
+		return nil
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*v.p, *v.auto = n, false
+	return nil
+}
+
+func (v *weightValue) Type() string { return "weight" }
 
 func createSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 	cli, info, err := InitClient(publicURI, true)
 	if err != nil {
 		return err
 	}
+	defer info.key.Close()
 	info.subnetID, err = ids.FromString(subnetIDs)
 	if err != nil {
 		return err
 	}
 	info.txFee = uint64(info.feeData.TxFee)
+	var copied map[ids.ShortID]uint64
+	if copyFromSubnet != "" {
+		copied, err = copySubnetValidators(cli, info.subnetID)
+		if err != nil {
+			return err
+		}
+	}
 	if err := ParseNodeIDs(cli, info); err != nil {
 		return err
 	}
@@ -71,6 +141,26 @@ func createSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 	if info.validateWeight == 0 {
 		return errZeroValidateWeight
 	}
+	var stakes map[ids.ShortID]uint64
+	weights := make(map[ids.ShortID]uint64, len(info.nodeIDs))
+	for _, nodeID := range info.nodeIDs {
+		weights[nodeID] = validateWeight
+	}
+	perNode := false
+	switch {
+	case validateWeightAuto:
+		weights, stakes, err = stakeWeights(cli, info.subnetID, info.nodeIDs)
+		if err != nil {
+			return err
+		}
+		perNode = true
+	case copied != nil && !cmd.Flags().Changed("validate-weight"):
+		weights, perNode = copied, true
+	}
+	if perNode {
+		// the weights are listed per node instead
+		info.validateWeight = 0
+	}
 
 	info.rewardAddr = ids.ShortEmpty
 	info.changeAddr = ids.ShortEmpty
@@ -80,28 +170,23 @@ func createSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 	if err := info.CheckBalance(); err != nil {
 		return err
 	}
+	if err := info.CheckSubnetAuth(cli); err != nil {
+		return err
+	}
+	if err := CheckClockSkew(publicURI); err != nil {
+		return err
+	}
 	msg := CreateAddTable(info)
 	if enablePrompt {
 		msg = formatter.F("\n{{blue}}{{bold}}Ready to add subnet validator, should we continue?{{/}}\n") + msg
 	}
 	fmt.Fprint(formatter.ColorableStdOut, msg)
+	if perNode {
+		fmt.Fprint(formatter.ColorableStdOut, makeValidateWeightsTable(info.nodeIDs, weights, stakes))
+	}
 
-	if enablePrompt {
-		prompt := promptui.Select{
-			Label:  "\n",
-			Stdout: os.Stdout,
-			Items: []string{
-				formatter.F("{{green}}Yes, let's create! {{bold}}{{underline}}I agree to pay the fee{{/}}{{green}}!{{/}}"),
-				formatter.F("{{red}}No, stop it!{{/}}"),
-			},
-		}
-		idx, _, err := prompt.Run()
-		if err != nil {
-			return nil //nolint:nilerr
-		}
-		if idx == 1 {
-			return nil
-		}
+	if !info.confirmSpend() {
+		return errTxDeclined
 	}
 
 	println()
@@ -117,8 +202,12 @@ func createSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		info.validateStart = time.Now().Add(30 * time.Second)
+		now := time.Now()
+		info.validateStart = now.Add(validateStartBuffer)
 		info.validateEnd = end
+		if err := CheckStakeDuration(cli.NetworkID(), now, info.validateStart, info.validateEnd); err != nil {
+			return fmt.Errorf("%s: %w", nodeID, err)
+		}
 		ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
 		took, err := cli.P().AddSubnetValidator(
 			ctx,
@@ -127,7 +216,10 @@ func createSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 			nodeID,
 			info.validateStart,
 			info.validateEnd,
-			validateWeight,
+			weights[nodeID],
+			client.WithOutputOwners(info.outputOwners),
+			client.WithMemo(info.memo),
+			client.WithFeeKey(info.feeKey),
 		)
 		cancel()
 		if err != nil {
@@ -148,3 +240,86 @@ func createSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 	fmt.Fprint(formatter.ColorableStdOut, CreateAddTable(info))
 	return nil
 }
+
+// stakeWeights returns the weights of the [nodeIDs] proportional to their
+// primary network stakes, at the weight per stake of the current
+// validators of the subnet, and the stakes.
+func stakeWeights(cli client.Client, subnetID ids.ID, nodeIDs []ids.ShortID) (weights map[ids.ShortID]uint64, stakes map[ids.ShortID]uint64, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	primary, err := cli.P().GetValidators(ctx, ids.Empty)
+	if err != nil {
+		return nil, nil, err
+	}
+	stakes = make(map[ids.ShortID]uint64, len(primary))
+	for _, v := range primary {
+		stakes[v.NodeID] = v.Weight
+	}
+	vs, err := cli.P().GetValidators(ctx, subnetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	current := make(map[ids.ShortID]uint64, len(vs))
+	for _, v := range vs {
+		current[v.NodeID] = v.Weight
+	}
+	weights, err = rebalance.StakeWeights(stakes, current, nodeIDs, units.Avax)
+	return weights, stakes, err
+}
+
+// copySubnetValidators sets "--node-ids" to the current validators of
+// "--copy-from-subnet", and returns their weights there.
+func copySubnetValidators(cli client.Client, subnetID ids.ID) (map[ids.ShortID]uint64, error) {
+	if len(nodeIDs) > 0 {
+		return nil, errCopyNodeIDs
+	}
+	srcID, err := ids.FromString(copyFromSubnet)
+	if err != nil {
+		return nil, err
+	}
+	if srcID == subnetID {
+		return nil, errCopySameSubnet
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	vs, err := cli.P().GetValidators(ctx, srcID)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	weights := make(map[ids.ShortID]uint64, len(vs))
+	for _, v := range vs {
+		weights[v.NodeID] = v.Weight
+		nodeIDs = append(nodeIDs, v.NodeID.PrefixedString(constants.NodeIDPrefix))
+	}
+	sort.Strings(nodeIDs)
+	color.Outf("{{blue}}copying %d validator(s) of subnet %s{{/}}\n", len(vs), srcID)
+	return weights, nil
+}
+
+// makeValidateWeightsTable renders the weights of the nodes, with their
+// primary network stakes if not nil.
+func makeValidateWeightsTable(nodeIDs []ids.ShortID, weights map[ids.ShortID]uint64, stakes map[ids.ShortID]uint64) string {
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+	header := []string{"node ID", "validate weight"}
+	if stakes != nil {
+		header = append(header, "primary stake ($AVAX)")
+	}
+	tb.SetHeader(header)
+	for _, nodeID := range nodeIDs {
+		row := []string{
+			labeled(nodeID.PrefixedString(constants.NodeIDPrefix)),
+			humanize.Comma(int64(weights[nodeID])),
+		}
+		if stakes != nil {
+			row = append(row, humanize.FormatFloat("#,###.#########", float64(stakes[nodeID])/float64(units.Avax)))
+		}
+		tb.Append(row)
+	}
+	tb.Render()
+	return buf.String()
+}
@@ -4,18 +4,21 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/amount"
+	"github.com/ava-labs/subnet-cli/internal/manifest"
 	"github.com/ava-labs/subnet-cli/pkg/color"
-	"github.com/manifoldco/promptui"
+	"github.com/dustin/go-humanize"
+	"github.com/olekukonko/tablewriter"
 	"github.com/onsi/ginkgo/v2/formatter"
 	"github.com/spf13/cobra"
 )
@@ -38,18 +41,32 @@ $ subnet-cli add validator \
 --private-key-path=.insecure.ewoq.key \
 --public-uri=http://localhost:52250 \
 --node-ids="NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH" \
---stake-amount=2000000000000 \
+--stake-amount=2000avax \
 --validate-reward-fee-percent=2
 
+To add validators in batch, with per-node stake amounts and durations:
+
+$ subnet-cli add validator \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--nodes-file=nodes.yaml
+
+# nodes.yaml
+nodes:
+  - node-id: NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH
+    weight: 2000avax
+    duration: 336h
+
 `,
 		RunE: createValidatorFunc,
 	}
 
 	cmd.PersistentFlags().StringSliceVar(&nodeIDs, "node-ids", nil, "a list of node IDs (must be formatted in ids.ID)")
-	cmd.PersistentFlags().Uint64Var(&stakeAmount, "stake-amount", defaultStakeAmount, "stake amount denominated in nano AVAX (minimum amount that a validator must stake is 2,000 AVAX)")
+	cmd.PersistentFlags().StringVar(&nodesFile, "nodes-file", "", "YAML/JSON file listing the node IDs with their stake amounts and durations (overrides --node-ids)")
+	cmd.PersistentFlags().Var(amount.NewValue(defaultStakeAmount, &stakeAmount), "stake-amount", "stake amount in nano AVAX, or with a denomination (e.g., '2000avax', '500milliavax') (minimum amount that a validator must stake is 2,000 AVAX)")
+	cmd.PersistentFlags().BoolVar(&stakeLocked, "stake-locked", false, "'true' to stake the locked stakeable AVAX first (e.g., vested), which cannot pay the fees")
 
-	end := time.Now().Add(defaultValDuration)
-	cmd.PersistentFlags().StringVar(&validateEnds, "validate-end", end.Format(time.RFC3339), "validate start timestamp in RFC3339 format")
+	addValidateEndFlags(cmd, fmt.Sprintf("+%dd", defaultValDuration/(24*time.Hour)), "validate end time")
 	cmd.PersistentFlags().Uint32Var(&validateRewardFeePercent, "validate-reward-fee-percent", defaultValFeePercent, "percentage of fee that the validator will take rewards from its delegators")
 	cmd.PersistentFlags().StringVar(&rewardAddrs, "reward-address", "", "node address to send rewards to (default to key owner)")
 	cmd.PersistentFlags().StringVar(&changeAddrs, "change-address", "", "node address to send changes to (default to key owner)")
@@ -64,8 +81,27 @@ func createValidatorFunc(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	defer info.key.Close()
+	if err := checkTxType(cli, "AddValidatorTx"); err != nil {
+		return err
+	}
 	info.stakeAmount = stakeAmount
 
+	// nil unless in batch mode
+	var nodes map[ids.ShortID]manifest.Node
+	if nodesFile != "" {
+		ns, err := manifest.LoadNodes(nodesFile)
+		if err != nil {
+			return err
+		}
+		nodeIDs = make([]string, len(ns.Nodes))
+		nodes = make(map[ids.ShortID]manifest.Node, len(ns.Nodes))
+		for i, n := range ns.Nodes {
+			nodeIDs[i] = n.NodeID
+			nodes[n.ID] = n
+		}
+	}
+
 	info.subnetID = ids.Empty
 	if err := ParseNodeIDs(cli, info); err != nil {
 		return err
@@ -74,7 +110,7 @@ func createValidatorFunc(cmd *cobra.Command, args []string) error {
 		color.Outf("{{magenta}}no primary network validators to add{{/}}\n")
 		return nil
 	}
-	info.validateEnd, err = time.Parse(time.RFC3339, validateEnds)
+	info.validateEnd, err = parseValidateEnd(time.Now().Add(validateStartBuffer))
 	if err != nil {
 		return err
 	}
@@ -101,7 +137,28 @@ func createValidatorFunc(cmd *cobra.Command, args []string) error {
 	} else {
 		info.changeAddr = info.key.Addresses()[0]
 	}
-	info.requiredBalance = info.stakeAmount * uint64(len(info.nodeIDs))
+	// fail fast on the validation periods, before any tx is issued
+	if err := CheckClockSkew(publicURI); err != nil {
+		return err
+	}
+	now, end := time.Now(), info.validateEnd
+	for i, nodeID := range info.nodeIDs {
+		start, nodeEnd := now.Add(validateStartBuffer), end
+		if n, ok := nodes[nodeID]; ok && n.ParsedDuration > 0 {
+			nodeEnd = start.Add(n.ParsedDuration)
+		}
+		if err := CheckStakeDuration(cli.NetworkID(), now, start, nodeEnd); err != nil {
+			return fmt.Errorf("%s: %w", nodeID, err)
+		}
+		if i < len(info.nodeIDs)-1 {
+			end = end.Add(defaultStagger)
+		}
+	}
+
+	info.requiredBalance = 0
+	for _, nodeID := range info.nodeIDs {
+		info.requiredBalance += nodeStake(nodes, nodeID, info.stakeAmount)
+	}
 	if err := info.CheckBalance(); err != nil {
 		return err
 	}
@@ -111,51 +168,53 @@ func createValidatorFunc(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Fprint(formatter.ColorableStdOut, msg)
 
-	if enablePrompt {
-		prompt := promptui.Select{
-			Label:  "\n",
-			Stdout: os.Stdout,
-			Items: []string{
-				formatter.F("{{green}}Yes, let's create! {{bold}}{{underline}}I agree to pay the fee{{/}}{{green}}!{{/}}"),
-				formatter.F("{{red}}No, stop it!{{/}}"),
-			},
-		}
-		idx, _, err := prompt.Run()
-		if err != nil {
-			return nil //nolint:nilerr
-		}
-		if idx == 1 {
-			return nil
-		}
+	if !info.confirmSpend() {
+		return errTxDeclined
 	}
 
 	println()
 	println()
 	println()
+	results := make([]batchResult, 0, len(info.nodeIDs))
+	added := make([]ids.ShortID, 0, len(info.nodeIDs))
 	for i, nodeID := range info.nodeIDs {
 		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
-		info.validateStart = time.Now().Add(30 * time.Second)
+		info.validateStart = time.Now().Add(validateStartBuffer)
+		stake, end := nodeStake(nodes, nodeID, info.stakeAmount), info.validateEnd
+		if n, ok := nodes[nodeID]; ok && n.ParsedDuration > 0 {
+			end = info.validateStart.Add(n.ParsedDuration)
+		}
 		took, err := cli.P().AddValidator(
 			ctx,
 			info.key,
 			nodeID,
 			info.validateStart,
-			info.validateEnd,
-			client.WithStakeAmount(info.stakeAmount),
+			end,
+			client.WithStakeAmount(stake),
+			client.WithStakeableLocked(stakeLocked),
 			client.WithRewardShares(info.validateRewardFeePercent*10000),
 			client.WithRewardAddress(info.rewardAddr),
 			client.WithChangeAddress(info.changeAddr),
+			client.WithOutputOwners(info.outputOwners),
+			client.WithMemo(info.memo),
 		)
 		cancel()
+		results = append(results, batchResult{nodeID: nodeID, weight: stake, end: end, took: took, err: err})
 		if err != nil {
-			return err
+			if nodes == nil {
+				return err
+			}
+			// keep going with the rest of the batch
+			color.Outf("{{red}}failed to add %s to primary network validator set: %v{{/}}\n\n", nodeID, err)
+			continue
 		}
+		added = append(added, nodeID)
 		color.Outf("{{magenta}}added %s to primary network validator set{{/}} {{light-gray}}(took %v){{/}}\n\n", nodeID, took)
 		if i < len(info.nodeIDs)-1 {
 			info.validateEnd = info.validateEnd.Add(defaultStagger)
 		}
 	}
-	WaitValidator(cli, info.nodeIDs, info)
+	WaitValidator(cli, added, info)
 	info.requiredBalance = 0
 	info.stakeAmount = 0
 	info.txFee = 0
@@ -166,5 +225,54 @@ func createValidatorFunc(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	fmt.Fprint(formatter.ColorableStdOut, CreateAddTable(info))
+	if nodes != nil {
+		fmt.Fprint(formatter.ColorableStdOut, makeBatchTable(results))
+		if failed := len(results) - len(added); failed > 0 {
+			return fmt.Errorf("%w: %d of %d validator(s) failed", errBatchFailed, failed, len(results))
+		}
+	}
 	return nil
 }
+
+var errBatchFailed = errors.New("batch failed")
+
+// nodeStake returns the stake amount of the node in the batch,
+// or the default one.
+func nodeStake(nodes map[ids.ShortID]manifest.Node, nodeID ids.ShortID, defaultStake uint64) uint64 {
+	if n, ok := nodes[nodeID]; ok && n.Weight > 0 {
+		return uint64(n.Weight)
+	}
+	return defaultStake
+}
+
+type batchResult struct {
+	nodeID ids.ShortID
+	weight uint64
+	end    time.Time
+	took   time.Duration
+	err    error
+}
+
+func makeBatchTable(results []batchResult) string {
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+	tb.SetHeader([]string{"node ID", "weight", "end", "result"})
+	for _, r := range results {
+		result := formatter.F("{{green}}added{{/}} (took %v)", r.took)
+		if r.err != nil {
+			result = formatter.F("{{red}}failed: %v{{/}}", r.err)
+		}
+		tb.Append([]string{
+			r.nodeID.PrefixedString(constants.NodeIDPrefix),
+			humanize.Comma(int64(r.weight)),
+			r.end.Format(time.RFC3339),
+			result,
+		})
+	}
+	tb.Render()
+	return buf.String()
+}
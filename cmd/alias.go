@@ -0,0 +1,21 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// AliasCommand implements "subnet-cli alias" command.
+func AliasCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Sub-commands for setting aliases on nodes",
+	}
+	cmd.AddCommand(
+		newAliasChainCommand(),
+	)
+	cmd.PersistentFlags().StringSliceVar(&nodeURLs, "node-url", nil, "URIs of the nodes to update (requires --api-admin-enabled on the nodes)")
+	return cmd
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/node"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newAliasChainCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chain",
+		Short: "Sets a human-readable alias for a blockchain",
+		Long: `
+Sets the blockchain alias on each node through its admin API, and verifies
+the alias resolves to the blockchain (e.g., "/ext/bc/myevm/rpc"). The admin
+API must be enabled on the nodes ("--api-admin-enabled=true").
+
+Aliases set through the admin API are lost when the node restarts. To keep
+them, also add them to the node's "--chain-aliases-file".
+
+$ subnet-cli alias chain \
+--node-url=http://localhost:9650 \
+--node-url=http://localhost:9652 \
+--chain-id=[BLOCKCHAIN ID] \
+--alias=myevm
+
+`,
+		RunE: aliasChainFunc,
+	}
+	cmd.PersistentFlags().StringVar(&blockchainID, "chain-id", "", "blockchain ID to alias")
+	cmd.PersistentFlags().StringVar(&chainAlias, "alias", "", "alias of the blockchain")
+	return cmd
+}
+
+var (
+	errNoNodeURL    = errors.New("no --node-url provided")
+	errInvalidAlias = errors.New("invalid alias")
+	errAliasFailed  = errors.New("failed to alias the chain")
+)
+
+func aliasChainFunc(cmd *cobra.Command, args []string) error {
+	if len(nodeURLs) == 0 {
+		return errNoNodeURL
+	}
+	chainID, err := ids.FromString(blockchainID)
+	if err != nil {
+		return err
+	}
+	// an ID-like alias would shadow the blockchain IDs
+	if _, err := ids.FromString(chainAlias); err == nil || strings.TrimSpace(chainAlias) != chainAlias || chainAlias == "" {
+		return fmt.Errorf("%w %q", errInvalidAlias, chainAlias)
+	}
+
+	failed := 0
+	for _, u := range nodeURLs {
+		cli := node.New(u)
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		err := cli.AliasChain(ctx, chainID.String(), chainAlias)
+		if err == nil {
+			err = node.VerifyAlias(ctx, cli, chainID.String(), chainAlias)
+		}
+		cancel()
+		if err != nil {
+			failed++
+			color.Outf("{{red}}failed to alias %s as %q on %s: %v{{/}}\n", chainID, chainAlias, u, err)
+			continue
+		}
+		color.Outf("{{green}}aliased %s as %q on %s{{/}} {{light-gray}}(%s/ext/bc/%s){{/}}\n", chainID, chainAlias, u, strings.TrimSuffix(u, "/"), chainAlias)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w on %d of %d node(s)", errAliasFailed, failed, len(nodeURLs))
+	}
+	return nil
+}
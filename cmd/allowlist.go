@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/internal/allowlist"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// loadAllowlist loads the allowlist of "--allowlist" (or of the config
+// file), nil if not set.
+func loadAllowlist() (*allowlist.List, error) {
+	if allowlistPath == "" {
+		return nil, nil
+	}
+	l, err := allowlist.Load(allowlistPath)
+	if err != nil {
+		return nil, err
+	}
+	zap.L().Info("loaded allowlist", zap.String("path", allowlistPath), zap.Int("addresses", l.Len()))
+	return l, nil
+}
+
+// checkAllowlist rejects the tx sending funds outside of the allowlist
+// and of the [self] addresses, unless "--override-allowlist" is set.
+func checkAllowlist(l *allowlist.List, utx platformvm.UnsignedTx, networkID uint32, self []ids.ShortID) error {
+	err := l.Check(utx, networkID, self)
+	if err == nil || !overrideAllowlist {
+		return err
+	}
+	color.Outf("{{red}}{{bold}}overriding the allowlist:{{/}} {{red}}%v{{/}}\n", err)
+	return nil
+}
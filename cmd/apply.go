@@ -0,0 +1,478 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/dustin/go-humanize"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/amount"
+	"github.com/ava-labs/subnet-cli/internal/apply"
+	"github.com/ava-labs/subnet-cli/internal/manifest"
+	"github.com/ava-labs/subnet-cli/internal/progress"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// ApplyCommand implements "subnet-cli apply" command.
+func ApplyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply <spec.yaml>",
+		Short: "Converges a subnet to its spec",
+		Long: `
+Compares the subnet spec (as of "wizard --spec") to the subnet on chain, and
+issues only the transactions to converge: the missing primary network
+validators, the subnet if it has no ID yet, the missing subnet validators,
+and the missing chains. The spec is written back with the created IDs, so
+that re-running it is a no-op.
+
+Validators on the subnet but not in the spec are left untouched, unless
+--prune removes them. The P-Chain has no transaction to change a weight:
+such changes are shown in the plan, and must wait for the end of the
+current validation period.
+
+Use "plan spec" to preview the changes without any key.
+
+$ subnet-cli plan spec subnet.yaml
+$ subnet-cli apply subnet.yaml
+
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: applyFunc,
+	}
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints (overridden by the spec 'public-uri')")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path (overridden by the spec 'private-key-path')")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().BoolVar(&applyPrune, "prune", false, "'true' to remove the subnet validators missing from the spec")
+	cmd.PersistentFlags().Var(amount.NewValue(defaultStakeAmount, &stakeAmount), "stake-amount", "stake amount of the new primary network validators (overridden by the spec 'subnet.stake-amount')")
+	addValidateEndFlags(cmd, fmt.Sprintf("+%dd", defaultValDuration/(24*time.Hour)), "validate end time of the new primary network validators (overridden by the spec 'subnet.validate-end')")
+	addStakeDurationFlags(cmd)
+	addOutputFlags(cmd)
+	addMemoFlag(cmd)
+	addFeeKeyFlags(cmd)
+	return cmd
+}
+
+func newPlanSpecCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "spec <spec.yaml>",
+		Short: "Shows the changes to converge a subnet to its spec",
+		Long: `
+Compares the subnet spec to the subnet on chain, and shows the changes
+"apply" would issue, without loading any key.
+
+$ subnet-cli plan spec subnet.yaml --prune
+
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: planSpecFunc,
+	}
+	cmd.PersistentFlags().BoolVar(&applyPrune, "prune", false, "'true' to plan the removal of the subnet validators missing from the spec")
+	return cmd
+}
+
+// loadApplySpec loads the spec, which overrides the flags it sets.
+func loadApplySpec(p string) (*manifest.Spec, error) {
+	s, err := manifest.LoadSpec(p)
+	if err != nil {
+		return nil, err
+	}
+	if s.PublicURI != "" {
+		publicURI = s.PublicURI
+	}
+	if s.PrivateKeyPath != "" {
+		privKeyPath = s.PrivateKeyPath
+	}
+	if s.Subnet.ValidateEnd != "" {
+		validateEnds, validateDurations = s.Subnet.ValidateEnd, ""
+	}
+	if s.Subnet.StakeAmount != "" {
+		stakeAmount, err = amount.Parse(s.Subnet.StakeAmount)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// fetchApplyState returns the subnet of the spec on chain, with the end of
+// the subnet validations.
+func fetchApplyState(cli client.Client, s *manifest.Spec) (*apply.State, map[ids.ShortID]time.Time, error) {
+	primary, err := subnetValidators(cli, ids.Empty)
+	if err != nil {
+		return nil, nil, err
+	}
+	st := &apply.State{
+		Primary:    primary,
+		Validators: make(map[ids.ShortID]uint64),
+		Chains:     make(map[string]apply.Chain),
+	}
+	ends := make(map[ids.ShortID]time.Time)
+	if s.Subnet.ID == "" {
+		return st, ends, nil
+	}
+	st.SubnetID, err = ids.FromString(s.Subnet.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	current, err := cli.P().GetValidators(ctx, st.SubnetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, v := range current {
+		st.Validators[v.NodeID] = v.Weight
+		ends[v.NodeID] = v.End
+	}
+	pending, err := cli.P().GetPendingValidators(ctx, st.SubnetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, v := range pending {
+		st.Validators[v.NodeID] = v.Weight
+		ends[v.NodeID] = v.End
+	}
+
+	bcs, err := cli.P().GetBlockchains(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, bc := range bcs {
+		if bc.SubnetID == st.SubnetID {
+			st.Chains[bc.Name] = apply.Chain{ID: bc.ID, VMID: bc.VMID}
+		}
+	}
+	return st, ends, nil
+}
+
+func planSpecFunc(cmd *cobra.Command, args []string) error {
+	s, err := loadApplySpec(args[0])
+	if err != nil {
+		return err
+	}
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+	st, ends, err := fetchApplyState(cli, s)
+	if err != nil {
+		return err
+	}
+	changes := apply.Plan(s, st, applyPrune, defaultValidateWeight)
+	fmt.Fprint(formatter.ColorableStdOut, makeApplyTable(s, st, changes, ends))
+	color.Outf("{{green}}%d change(s) to apply{{/}}\n", countIssued(changes))
+	return nil
+}
+
+func applyFunc(cmd *cobra.Command, args []string) error {
+	specPath := args[0]
+	s, err := loadApplySpec(specPath)
+	if err != nil {
+		return err
+	}
+	cli, info, err := InitClient(publicURI, true)
+	if err != nil {
+		return err
+	}
+	defer info.key.Close()
+
+	st, ends, err := fetchApplyState(cli, s)
+	if err != nil {
+		return err
+	}
+	changes := apply.Plan(s, st, applyPrune, defaultValidateWeight)
+	msg := makeApplyTable(s, st, changes, ends)
+	if blocked := apply.Count(changes, apply.Reweight); blocked > 0 {
+		msg += formatter.F("{{yellow}}%d change(s) must wait for the current validation periods to end{{/}}\n", blocked)
+	}
+	if countIssued(changes) == 0 {
+		fmt.Fprint(formatter.ColorableStdOut, msg)
+		color.Outf("{{magenta}}subnet %s matches the spec, no changes to apply{{/}}\n", st.SubnetID)
+		return nil
+	}
+
+	adds := apply.Count(changes, apply.AddValidator)
+	info.stakeAmount = stakeAmount
+	info.validateEnd, err = parseValidateEnd(time.Now().Add(validateStartBuffer))
+	if err != nil {
+		return err
+	}
+	info.validateRewardFeePercent = defaultValFeePercent
+	info.rewardAddr = info.key.Addresses()[0]
+	info.changeAddr = info.key.Addresses()[0]
+	info.totalStakeAmount = uint64(adds) * info.stakeAmount
+	info.txFee = uint64(info.feeData.TxFee) * uint64(adds+apply.Count(changes, apply.AddSubnetValidator)+apply.Count(changes, apply.RemoveSubnetValidator))
+	if st.SubnetID == ids.Empty {
+		info.txFee += uint64(info.feeData.CreateSubnetTxFee)
+	}
+	info.txFee += uint64(info.feeData.CreateBlockchainTxFee) * uint64(apply.Count(changes, apply.CreateBlockchain))
+	info.requiredBalance = info.totalStakeAmount + info.txFee
+	if err := info.CheckBalance(); err != nil {
+		return err
+	}
+	if st.SubnetID != ids.Empty {
+		info.subnetID = st.SubnetID
+		if err := info.CheckSubnetAuth(cli); err != nil {
+			return err
+		}
+	}
+	if apply.Count(changes, apply.RemoveSubnetValidator) > 0 {
+		if err := checkTxType(cli, "RemoveSubnetValidatorTx"); err != nil {
+			return err
+		}
+	}
+	if err := CheckClockSkew(publicURI); err != nil {
+		return err
+	}
+	if adds > 0 {
+		now := time.Now()
+		if err := CheckStakeDuration(cli.NetworkID(), now, now.Add(validateStartBuffer), info.validateEnd); err != nil {
+			return err
+		}
+	}
+
+	if enablePrompt {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to apply the spec, should we continue?{{/}}\n") + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+	if !info.confirmSpend() {
+		return errTxDeclined
+	}
+	println()
+	println()
+
+	// primary network validators, waited for before validating the subnet
+	info.subnetID = ids.Empty
+	added := make([]ids.ShortID, 0, adds)
+	for _, c := range changes {
+		if c.Kind != apply.AddValidator {
+			continue
+		}
+		done := progressEvents.Start(progress.Event{Step: "add_validator", NodeID: c.NodeID.PrefixedString(constants.NodeIDPrefix)})
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		took, err := cli.P().AddValidator(
+			ctx,
+			info.key,
+			c.NodeID,
+			time.Now().Add(validateStartBuffer),
+			info.validateEnd,
+			client.WithStakeAmount(info.stakeAmount),
+			client.WithRewardShares(info.validateRewardFeePercent*10000),
+			client.WithRewardAddress(info.rewardAddr),
+			client.WithChangeAddress(info.changeAddr),
+			client.WithOutputOwners(info.outputOwners),
+			client.WithMemo(info.memo),
+			client.WithFeeKey(info.feeKey),
+		)
+		cancel()
+		done(err)
+		if err != nil {
+			return err
+		}
+		added = append(added, c.NodeID)
+		color.Outf("{{magenta}}added %s to primary network validator set{{/}} {{light-gray}}(took %v){{/}}\n\n", c.NodeID, took)
+	}
+	WaitValidator(cli, added, info)
+
+	if st.SubnetID == ids.Empty {
+		done := progressEvents.Start(progress.Event{Step: "create_subnet"})
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		subnetID, took, err := cli.P().CreateSubnet(ctx, info.key, client.WithOutputOwners(info.outputOwners), client.WithMemo(info.memo), client.WithFeeKey(info.feeKey))
+		cancel()
+		done(err)
+		if err != nil {
+			return err
+		}
+		st.SubnetID = subnetID
+		color.Outf("{{magenta}}created subnet{{/}} %q {{light-gray}}(took %v){{/}}\n", subnetID, took)
+	}
+	info.subnetID = st.SubnetID
+	s.Subnet.ID = st.SubnetID.String()
+	// write the subnet ID right away, so that a failure below resumes on it
+	if err := s.Save(specPath); err != nil {
+		return err
+	}
+
+	added = added[:0]
+	for _, c := range changes {
+		if c.Kind != apply.AddSubnetValidator {
+			continue
+		}
+		// subnet validation period must be within the primary network one
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		_, end, err := cli.P().GetValidator(ctx, ids.Empty, c.NodeID)
+		cancel()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		start := now.Add(validateStartBuffer)
+		if err := CheckStakeDuration(cli.NetworkID(), now, start, end); err != nil {
+			return fmt.Errorf("%s: %w", c.NodeID, err)
+		}
+		done := progressEvents.Start(progress.Event{
+			Step:     "add_subnet_validator",
+			NodeID:   c.NodeID.PrefixedString(constants.NodeIDPrefix),
+			SubnetID: info.subnetID.String(),
+		})
+		ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+		took, err := cli.P().AddSubnetValidator(
+			ctx,
+			info.key,
+			info.subnetID,
+			c.NodeID,
+			start,
+			end,
+			c.To,
+			client.WithOutputOwners(info.outputOwners),
+			client.WithMemo(info.memo),
+			client.WithFeeKey(info.feeKey),
+		)
+		cancel()
+		done(err)
+		if err != nil {
+			return err
+		}
+		added = append(added, c.NodeID)
+		color.Outf("{{magenta}}added %s to subnet %s validator set with weight %d{{/}} {{light-gray}}(took %v){{/}}\n\n", c.NodeID, info.subnetID, c.To, took)
+	}
+	WaitValidator(cli, added, info)
+
+	for _, c := range changes {
+		if c.Kind != apply.RemoveSubnetValidator {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		took, err := cli.P().RemoveSubnetValidator(
+			ctx,
+			info.key,
+			info.subnetID,
+			c.NodeID,
+			client.WithMemo(info.memo),
+			client.WithFeeKey(info.feeKey),
+		)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to remove %s: %w", c.NodeID, err)
+		}
+		color.Outf("{{magenta}}removed %s from subnet %s validator set{{/}} {{light-gray}}(took %v){{/}}\n", c.NodeID, info.subnetID, took)
+	}
+
+	for i := range s.Chains {
+		c := &s.Chains[i]
+		if existing, ok := st.Chains[c.Name]; ok && existing.VMID.String() == c.VMID {
+			c.ID = existing.ID.String()
+			continue
+		}
+		vmID, err := ids.FromString(c.VMID)
+		if err != nil {
+			return err
+		}
+		genesis, err := ioutil.ReadFile(c.GenesisPath(specPath))
+		if err != nil {
+			return err
+		}
+		done := progressEvents.Start(progress.Event{Step: "create_blockchain", SubnetID: info.subnetID.String()})
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		blockchainID, took, err := cli.P().CreateBlockchain(
+			ctx,
+			info.key,
+			info.subnetID,
+			c.Name,
+			vmID,
+			genesis,
+			client.WithOutputOwners(info.outputOwners),
+			client.WithMemo(info.memo),
+			client.WithFeeKey(info.feeKey),
+		)
+		cancel()
+		done(err)
+		if err != nil {
+			return err
+		}
+		c.ID = blockchainID.String()
+		color.Outf("{{magenta}}created blockchain{{/}} %q {{light-gray}}(%s, took %v){{/}}\n\n", blockchainID, c.Name, took)
+	}
+
+	if err := s.Save(specPath); err != nil {
+		return err
+	}
+	color.Outf("{{green}}wrote the spec with the subnet and blockchain IDs to %q{{/}}\n", specPath)
+	checkSpecEndpoints(s, info.subnetID)
+	return nil
+}
+
+func countIssued(changes []apply.Change) (n int) {
+	for _, c := range changes {
+		if c.Issued() {
+			n++
+		}
+	}
+	return n
+}
+
+func makeApplyTable(s *manifest.Spec, st *apply.State, changes []apply.Change, ends map[ids.ShortID]time.Time) string {
+	vmIDs := make(map[string]string, len(s.Chains))
+	for _, c := range s.Chains {
+		vmIDs[c.Name] = c.VMID
+	}
+	buf := bytes.NewBuffer(nil)
+	tb := newTxTable(buf, []string{"resource", "action", "detail"})
+	for _, c := range changes {
+		var resource, detail string
+		switch {
+		case c.Kind == apply.CreateSubnet:
+			resource = "subnet"
+		case c.Chain != "":
+			resource = fmt.Sprintf("chain %q", c.Chain)
+			detail = fmt.Sprintf("VM %s", vmIDs[c.Chain])
+			if c.Kind == apply.Unmanaged {
+				detail = fmt.Sprintf("VM %s", st.Chains[c.Chain].VMID)
+			}
+		default:
+			resource = labeled(c.NodeID.PrefixedString(constants.NodeIDPrefix))
+			switch c.Kind {
+			case apply.AddValidator:
+				detail = fmt.Sprintf("stake %s $AVAX", humanize.FormatFloat("#,###.#########", float64(stakeAmount)/float64(units.Avax)))
+			case apply.AddSubnetValidator:
+				detail = fmt.Sprintf("weight %s", humanize.Comma(int64(c.To)))
+			case apply.Reweight:
+				detail = fmt.Sprintf("weight %s -> %s after %s (%s)",
+					humanize.Comma(int64(c.From)),
+					humanize.Comma(int64(c.To)),
+					ends[c.NodeID].Format(time.RFC3339),
+					humanize.Time(ends[c.NodeID]),
+				)
+			default:
+				detail = fmt.Sprintf("weight %s", humanize.Comma(int64(c.From)))
+			}
+		}
+		var action string
+		switch c.Kind {
+		case apply.Keep, apply.Unmanaged:
+			action = formatter.F("{{light-gray}}%s{{/}}", c.Kind)
+		case apply.Reweight:
+			action = formatter.F("{{yellow}}%s (blocked){{/}}", c.Kind)
+		case apply.RemoveSubnetValidator:
+			action = formatter.F("{{red}}%s{{/}}", c.Kind)
+		default:
+			action = formatter.F("{{green}}%s{{/}}", c.Kind)
+		}
+		tb.Append([]string{resource, action, detail})
+	}
+	tb.Render()
+	return buf.String()
+}
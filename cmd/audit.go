@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// AuditCommand implements "subnet-cli audit" command.
+func AuditCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Sub-commands for the signing audit log",
+	}
+	cmd.AddCommand(
+		newAuditVerifyCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/audit"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var errEmptyAuditLog = errors.New("empty --audit-log")
+
+func newAuditVerifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verifies the hash chain of the audit log",
+		Long: `
+Verifies the hash chain of the audit log recorded with "--audit-log", and
+fails if any entry was modified, inserted, or removed. The removal of the
+last entries is only detected by comparing the last hash with a copy.
+
+$ subnet-cli audit verify --audit-log=signing-audit.log
+
+`,
+		RunE: auditVerifyFunc,
+	}
+	return cmd
+}
+
+func auditVerifyFunc(cmd *cobra.Command, args []string) error {
+	if auditLogPath == "" {
+		return errEmptyAuditLog
+	}
+	last, n, err := audit.Verify(auditLogPath)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		color.Outf("{{green}}%q is empty{{/}}\n", auditLogPath)
+		return nil
+	}
+	color.Outf("{{green}}verified %d entries of %q{{/}}\n", n, auditLogPath)
+	color.Outf("{{light-gray}}last entry #%d at %s, hash %s{{/}}\n", last.Seq, last.Time.Format(time.RFC3339), last.Hash)
+	return nil
+}
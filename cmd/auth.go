@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/subnet-cli/internal/auth"
+	"github.com/ava-labs/subnet-cli/internal/config"
+)
+
+// authTokenEnv is the environment variable of the bearer token,
+// to not expose it in the process list.
+const authTokenEnv = "SUBNET_CLI_AUTH_TOKEN"
+
+// endpointFlags lists the flags of the endpoints authenticated with
+// "--auth-token" and "--tls-*".
+var endpointFlags = []string{"public-uri", "private-uri", "endpoints", "source-uri", "node-url", "node-urls", "chain-rpc"}
+
+// loadCLIConfig loads the config file of "--cli-config".
+func loadCLIConfig() (*config.Config, error) {
+	p := cliConfigPath
+	if p == "" {
+		var err error
+		p, err = config.DefaultPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return config.Load(p)
+}
+
+// installAuth authenticates the requests to the endpoints of [cfg],
+// and to the endpoints set by the flags of [cmd] with the
+// authentication flags.
+func installAuth(cmd *cobra.Command, cfg *config.Config) error {
+	eps := cfg.Endpoints
+
+	token := authToken
+	if token == "" {
+		token = os.Getenv(authTokenEnv)
+	}
+	if token != "" || tlsCert != "" || tlsKey != "" || tlsCACert != "" {
+		for _, uri := range flagValues(cmd, endpointFlags) {
+			eps = append(eps, auth.Endpoint{
+				URI:    uri,
+				Token:  token,
+				Cert:   tlsCert,
+				Key:    tlsKey,
+				CACert: tlsCACert,
+			})
+		}
+	}
+	if len(eps) == 0 {
+		return nil
+	}
+	t, err := auth.NewTransport(http.DefaultTransport.(*http.Transport), eps)
+	if err != nil {
+		return err
+	}
+	auth.Install(t)
+	return nil
+}
+
+// flagValues returns the non-empty values of the [names] flags
+// defined by [cmd].
+func flagValues(cmd *cobra.Command, names []string) []string {
+	var vs []string
+	for _, name := range names {
+		f := cmd.Flag(name)
+		if f == nil {
+			continue
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			vs = append(vs, sv.GetSlice()...)
+			continue
+		}
+		if v := f.Value.String(); v != "" {
+			vs = append(vs, v)
+		}
+	}
+	return vs
+}
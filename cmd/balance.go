@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/dustin/go-humanize"
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/amount"
+)
+
+// BalanceCommand implements "subnet-cli balance" command.
+func BalanceCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "balance",
+		Short: "Shows the P-Chain balance",
+		Long: `
+Shows the P-Chain balance of the key, or of any address without loading a key,
+along with the other assets held on the P-Chain (e.g., the staking assets of
+the elastic subnets).
+
+$ subnet-cli balance \
+--public-uri=http://localhost:52250 \
+--address=P-custom18jma8ppw3nhx5r4ap8clazz0dps7rv5u9xde7p
+
+$ subnet-cli balance \
+--public-uri=http://localhost:52250 \
+--private-key-path=.insecure.ewoq.key
+
+$ subnet-cli balance \
+--public-uri=https://api.avax-test.network \
+--xpub=xpub6C...
+
+`,
+		RunE: balanceFunc,
+	}
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path (ignored if --address is set)")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to derive the addresses (ignored if --address is set)")
+	cmd.PersistentFlags().StringVar(&pAddress, "address", "", "P-Chain address to check the balance of, without loading any key")
+	addXPubFlags(cmd)
+	return cmd
+}
+
+func balanceFunc(cmd *cobra.Command, args []string) error {
+	loadKey := pAddress == ""
+	cli, info, err := InitClient(publicURI, loadKey)
+	if err != nil {
+		return err
+	}
+
+	addrs := []string{pAddress}
+	if loadKey {
+		defer info.key.Close()
+		addrs = info.key.P()
+	} else if _, err := ParsePAddress(pAddress); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	resp, err := cli.P().Client().GetBalance(ctx, addrs)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	buf, tb := BaseTableSetup(info)
+	if !loadKey {
+		tb.Append([]string{formatter.F("{{cyan}}{{bold}}P-CHAIN ADDRESS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", pAddress)})
+	}
+	for _, row := range []struct {
+		name string
+		v    uint64
+	}{
+		{"TOTAL P-CHAIN BALANCE", uint64(resp.Balance)},
+		{"UNLOCKED", uint64(resp.Unlocked)},
+		{"LOCKED STAKEABLE", uint64(resp.LockedStakeable)},
+		{"LOCKED NOT STAKEABLE", uint64(resp.LockedNotStakeable)},
+	} {
+		if loadKey && row.name == "TOTAL P-CHAIN BALANCE" {
+			// already displayed by the base table
+			continue
+		}
+		amount := humanize.FormatFloat("#,###.#######", float64(row.v)/float64(units.Avax))
+		tb.Append([]string{formatter.F("{{coral}}{{bold}}%s{{/}}", row.name), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} $AVAX", amount)})
+	}
+	if err := appendAssetBalances(tb, cli, addrs); err != nil {
+		return err
+	}
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	return nil
+}
+
+// appendAssetBalances appends the balances of the assets other than AVAX
+// (e.g., the staking assets of the elastic subnets) held on the P-Chain.
+func appendAssetBalances(tb *tablewriter.Table, cli client.Client, addrs []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	aps, err := cli.P().AssetBalances(ctx, addrs)
+	if err != nil {
+		return err
+	}
+	assetIDs := make([]ids.ID, 0, len(aps))
+	for assetID := range aps {
+		if assetID != cli.AssetID() {
+			assetIDs = append(assetIDs, assetID)
+		}
+	}
+	ids.SortIDs(assetIDs)
+	for _, assetID := range assetIDs {
+		ps := aps[assetID]
+		asset, err := cli.P().Asset(ctx, assetID)
+		if err != nil {
+			// still show the raw amount of an unknown asset
+			zap.L().Warn("failed to describe asset", zap.Stringer("assetId", assetID), zap.Error(err))
+			asset = &client.Asset{ID: assetID, Symbol: assetID.String()}
+		}
+		v := amount.FormatDenomination(ps.Total(), asset.Denomination)
+		if locked := ps.LockedStakeable + ps.LockedNotStakeable; locked > 0 {
+			v += fmt.Sprintf(" (%s locked)", amount.FormatDenomination(locked, asset.Denomination))
+		}
+		tb.Append([]string{formatter.F("{{coral}}{{bold}}%s BALANCE{{/}}", asset.Symbol), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} $%s", v, asset.Symbol)})
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/chainlist"
+	"github.com/ava-labs/subnet-cli/internal/genesis"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// checkChainCollisions warns of the chains colliding with the new chain
+// [name] of the genesis [genesisBytes]: the chains of the same name on
+// [subnetID] (ids.Empty for a new subnet), and the EVM chains of the same
+// chain ID on [subnetID] or known to the wallets (ref. "--chainlist").
+// The P-Chain accepts them, but the wallets key the networks by chain ID,
+// and the names are ambiguous in the aliases and explorers. It only fails
+// on an invalid "--chainlist".
+func checkChainCollisions(cli client.Client, subnetID ids.ID, name string, genesisBytes []byte) error {
+	known := chainlist.New()
+	if chainlistPath != "" {
+		var err error
+		known, err = chainlist.Load(chainlistPath)
+		if err != nil {
+			return err
+		}
+	}
+	// not an error here, as the genesis of any VM is accepted
+	chainID, err := genesis.ChainID(genesisBytes)
+	if err != nil {
+		zap.L().Debug("no EVM chain ID in the genesis", zap.Error(err))
+	}
+	for _, c := range known.Lookup(chainID) {
+		color.Outf("{{yellow}}warning: chain ID %v is already used by %q, which the wallets will confuse with the new chain{{/}}\n", chainID, c.Name)
+	}
+	if subnetID == ids.Empty {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	bcs, err := cli.P().GetBlockchains(ctx)
+	if err != nil {
+		zap.L().Warn("failed to get the blockchains to check the collisions", zap.Error(err))
+		return nil
+	}
+	for _, bc := range bcs {
+		if bc.SubnetID != subnetID {
+			continue
+		}
+		if strings.EqualFold(bc.Name, name) {
+			color.Outf("{{yellow}}warning: chain name %q is already used by blockchain %s (%q) on subnet %s{{/}}\n", name, bc.ID, bc.Name, subnetID)
+		}
+		if chainID == nil {
+			continue
+		}
+		tx, err := cli.P().BlockchainTx(ctx, bc.ID)
+		if err != nil {
+			zap.L().Warn("failed to get the genesis to check the chain ID", zap.Stringer("blockchainID", bc.ID), zap.Error(err))
+			continue
+		}
+		if other, err := genesis.ChainID(tx.GenesisData); err == nil && other != nil && other.Cmp(chainID) == 0 {
+			color.Outf("{{yellow}}warning: chain ID %v is already used by blockchain %s (%q) on subnet %s{{/}}\n", chainID, bc.ID, bc.Name, subnetID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// CloneCommand implements "subnet-cli clone" command.
+func CloneCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clone",
+		Short: "Sub-commands for cloning resources",
+	}
+	cmd.AddCommand(
+		newCloneBlockchainCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path, or key store URI (e.g., 'keychain://subnet-cli/ops', 'env://OPS_KEY')")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	addOutputFlags(cmd)
+	addMemoFlag(cmd)
+	addFeeKeyFlags(cmd)
+	return cmd
+}
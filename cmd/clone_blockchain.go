@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newCloneBlockchainCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "blockchain [options]",
+		Short: "Creates a copy of an existing blockchain on another subnet",
+		Long: `
+Fetches the name, VM ID, feature extensions, and genesis of an existing
+blockchain, and creates an identical blockchain on the given subnet. The
+source blockchain may be on another network (e.g., Fuji to Mainnet).
+
+$ subnet-cli clone blockchain \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=https://api.avax.network \
+--source-uri=https://api.avax-test.network \
+--source-chain-id="2ebCneCbwthjQ1rYT41nhd7M76Hc6YmosMAQrTFhBq8qeqh6tt" \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1"
+
+`,
+		RunE: cloneBlockchainFunc,
+	}
+
+	cmd.PersistentFlags().StringVar(&sourceURI, "source-uri", "", "URI of the network of the source blockchain (default to --public-uri)")
+	cmd.PersistentFlags().StringVar(&sourceChainID, "source-chain-id", "", "source blockchain ID (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID to create the blockchain on (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringVar(&chainName, "chain-name", "", "chain name (default to the source chain name)")
+	cmd.PersistentFlags().StringVar(&vmGenesisPath, "vm-genesis-output-path", "", "file path to save the source genesis to (optional)")
+
+	return cmd
+}
+
+func cloneBlockchainFunc(cmd *cobra.Command, args []string) error {
+	srcID, err := ids.FromString(sourceChainID)
+	if err != nil {
+		return err
+	}
+	if sourceURI == "" {
+		sourceURI = publicURI
+	}
+	srcCli, err := client.New(client.Config{
+		URI:          sourceURI,
+		PollInterval: pollInterval,
+	})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	src, err := srcCli.P().BlockchainTx(ctx, srcID)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to fetch source blockchain %s: %w", srcID, err)
+	}
+	zap.L().Info("fetched source blockchain",
+		zap.String("chainName", src.ChainName),
+		zap.String("vmId", src.VMID.String()),
+		zap.Int("fxIds", len(src.FxIDs)),
+		zap.Int("genesisBytes", len(src.GenesisData)),
+	)
+	if vmGenesisPath != "" {
+		if err := ioutil.WriteFile(vmGenesisPath, src.GenesisData, 0o644); err != nil {
+			return err
+		}
+	}
+
+	cli, info, err := InitClient(publicURI, true)
+	if err != nil {
+		return err
+	}
+	defer info.key.Close()
+	info.subnetIDType = "SUBNET ID"
+	info.subnetID, err = ids.FromString(subnetIDs)
+	if err != nil {
+		return err
+	}
+	info.vmID = src.VMID
+	info.chainName = src.ChainName
+	if chainName != "" {
+		info.chainName = chainName
+	}
+	info.vmGenesisPath = fmt.Sprintf("(cloned from %s, %d bytes)", srcID, len(src.GenesisData))
+	if vmGenesisPath != "" {
+		info.vmGenesisPath = vmGenesisPath
+	}
+	info.txFee = uint64(info.feeData.CreateBlockchainTxFee)
+	info.requiredBalance = info.txFee
+	if err := info.CheckBalance(); err != nil {
+		return err
+	}
+	if err := info.CheckSubnetAuth(cli); err != nil {
+		return err
+	}
+
+	msg := MakeCreateTable(info)
+	if enablePrompt {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to clone blockchain %s, should we continue?{{/}}\n", srcID) + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+
+	if !info.confirmSpend() {
+		return errTxDeclined
+	}
+	println()
+	println()
+	println()
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	blockchainID, took, err := cli.P().CreateBlockchain(
+		ctx,
+		info.key,
+		info.subnetID,
+		info.chainName,
+		info.vmID,
+		src.GenesisData,
+		client.WithFxIDs(src.FxIDs),
+		client.WithOutputOwners(info.outputOwners),
+		client.WithMemo(info.memo),
+		client.WithFeeKey(info.feeKey),
+	)
+	cancel()
+	if err != nil {
+		return err
+	}
+	info.blockchainID = blockchainID
+	color.Outf("{{magenta}}cloned blockchain %s to{{/}} %q {{light-gray}}(took %v){{/}}\n\n", srcID, info.blockchainID, took)
+
+	info.requiredBalance = 0
+	info.stakeAmount = 0
+	info.txFee = 0
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	info.balance, err = cli.P().Balance(ctx, info.key)
+	cancel()
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(formatter.ColorableStdOut, MakeCreateTable(info))
+	return nil
+}
@@ -8,21 +8,41 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/dustin/go-humanize"
 	"github.com/olekukonko/tablewriter"
 	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/allowlist"
+	"github.com/ava-labs/subnet-cli/internal/audit"
+	"github.com/ava-labs/subnet-cli/internal/cache"
+	"github.com/ava-labs/subnet-cli/internal/clock"
+	"github.com/ava-labs/subnet-cli/internal/fees"
+	"github.com/ava-labs/subnet-cli/internal/fork"
 	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/internal/passphrase"
+	"github.com/ava-labs/subnet-cli/internal/progress"
+	"github.com/ava-labs/subnet-cli/internal/staking"
+	"github.com/ava-labs/subnet-cli/internal/utxofile"
+	"github.com/ava-labs/subnet-cli/internal/validate"
+	"github.com/ava-labs/subnet-cli/internal/walletlock"
 	"github.com/ava-labs/subnet-cli/pkg/color"
 	"github.com/ava-labs/subnet-cli/pkg/logutil"
+	"github.com/ava-labs/subnet-cli/pkg/prompt"
+	"github.com/ava-labs/subnet-cli/pkg/subnet"
 )
 
 type ValInfo struct {
@@ -35,6 +55,8 @@ type Info struct {
 
 	feeData *info.GetTxFeeResponse
 	balance uint64
+	// the balance split by what it can pay for
+	pools client.Pools
 
 	txFee            uint64
 	stakeAmount      uint64
@@ -42,7 +64,10 @@ type Info struct {
 	requiredBalance  uint64
 
 	key key.Key
+	// nil to pay the fees with the key
+	feeKey key.Key
 
+	networkID   uint32
 	networkName string
 
 	subnetIDType string
@@ -56,6 +81,8 @@ type Info struct {
 	chainName     string
 	vmID          ids.ID
 	vmGenesisPath string
+	// nil unless the subnet is elastic
+	stakingAsset *client.Asset
 
 	validateStart            time.Time
 	validateEnd              time.Time
@@ -64,53 +91,324 @@ type Info struct {
 
 	rewardAddr ids.ShortID
 	changeAddr ids.ShortID
+
+	// nil to send the change and rewards to the change and reward addresses
+	outputOwners *secp256k1fx.OutputOwners
+	// attached to the built txs, nil if not set
+	memo []byte
+	// nil if the destination addresses are not restricted
+	allowlist *allowlist.List
 }
 
 func InitClient(uri string, loadKey bool) (client.Client, *Info, error) {
-	cli, err := client.New(client.Config{
-		URI:          uri,
-		PollInterval: pollInterval,
-	})
+	var (
+		cli   client.Client
+		utxos *utxofile.File
+	)
+	cfg := client.Config{
+		URI:           uri,
+		PollInterval:  pollInterval,
+		MaxTxRetries:  maxTxRetries,
+		Deterministic: deterministic,
+		OnIssued: func(i client.Issued) {
+			progressEvents.Emit(progress.Event{Type: progress.TxIssued, TxType: i.TxType, TxID: i.TxID.String()})
+		},
+		OnCommitted: func(c client.Committed) {
+			progressEvents.Emit(progress.Event{
+				Type:   progress.TxAccepted,
+				TxType: c.TxType,
+				TxID:   c.TxID.String(),
+				Fee:    c.Fee,
+				TookMs: c.Took.Milliseconds(),
+			})
+			recordReceipt(cli.NetworkID(), c)
+			recordHistory(cli.NetworkID(), c)
+		},
+	}
+	// never cache when signing, not to build txs from stale state
+	if !loadKey && !noCache {
+		c, err := openCache()
+		if err != nil {
+			return nil, nil, err
+		}
+		cfg.Cache = c
+	}
+	if loadKey && !noLock {
+		dir, err := walletLockDir()
+		if err != nil {
+			return nil, nil, err
+		}
+		cfg.LockDir = dir
+	}
+	if utxosFilePath != "" {
+		var err error
+		utxos, err = utxofile.Load(utxosFilePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		cfg.UTXOs, err = utxos.Bytes()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if deterministic {
+		if utxos == nil {
+			return nil, nil, errDeterministicUTXOs
+		}
+		// the same locktimes are unlocked on every run
+		cfg.LocktimeAt = utxos.FetchedAt
+		cfg.OnSigning = func(s client.Signing) {
+			color.Outf("{{cyan}}%s digest{{/}} {{bold}}%s{{/}}\n", s.TxType, s.Digest)
+		}
+	}
+	list, err := loadAllowlist()
+	if err != nil {
+		return nil, nil, err
+	}
+	userFees, err := fees.Parse(feeOverride)
 	if err != nil {
 		return nil, nil, err
 	}
-	txFee, err := cli.Info().Client().GetTxFee(context.TODO())
+	cfg.FeeOverride = userFees
+	if utxos != nil && utxos.Offline() {
+		cfg.Offline = &client.Offline{
+			NetworkID: utxos.NetworkID,
+			XChainID:  *utxos.XChainID,
+			AssetID:   *utxos.AssetID,
+		}
+		// the fees of the file, and the ones of "--fee-override"
+		cfg.FeeOverride = fees.Override{}
+		for _, o := range []fees.Override{utxos.Fees, userFees} {
+			for name, v := range o {
+				cfg.FeeOverride[name] = v
+			}
+		}
+	}
+	// with prompts, each tx is summarized and confirmed before it is signed
+	var reviewer *txReviewer
+	if loadKey && enablePrompt {
+		reviewer = newTxReviewer()
+	}
+	if list != nil || reviewer != nil {
+		cfg.CheckTx = func(utx platformvm.UnsignedTx, self []ids.ShortID) error {
+			if list != nil {
+				if err := checkAllowlist(list, utx, cli.NetworkID(), self); err != nil {
+					return err
+				}
+			}
+			if reviewer != nil {
+				return reviewer.review(cli, utx, self)
+			}
+			return nil
+		}
+	}
+	cli, err = client.New(cfg)
 	if err != nil {
 		return nil, nil, err
 	}
-	networkName, err := cli.Info().Client().GetNetworkName(context.TODO())
+	if err := checkNetwork(cli); err != nil {
+		return nil, nil, err
+	}
+	if loadKey && userFees != nil {
+		if err := checkFeeOverride(cli, userFees); err != nil {
+			return nil, nil, err
+		}
+	}
+	if utxos != nil {
+		if err := utxos.CheckNetwork(cli.NetworkID()); err != nil {
+			return nil, nil, err
+		}
+		color.Outf("{{yellow}}spending %d UTXOs from %q (fetched at %s){{/}}\n", len(utxos.UTXOs), utxosFilePath, utxos.FetchedAt.Format(time.RFC3339))
+	}
+	txFee, err := cli.Info().TxFee(context.TODO())
 	if err != nil {
 		return nil, nil, err
 	}
+	networkName := constants.NetworkName(cli.NetworkID())
+	if cfg.Offline == nil {
+		networkName, err = cli.Info().Client().GetNetworkName(context.TODO())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 	info := &Info{
 		uri:         uri,
 		feeData:     txFee,
+		networkID:   cli.NetworkID(),
 		networkName: networkName,
 		valInfos:    map[ids.ShortID]*ValInfo{},
+		allowlist:   list,
+	}
+	info.outputOwners, err = parseOutputOwners()
+	if err != nil {
+		return nil, nil, err
+	}
+	info.memo, err = validate.Memo(memo)
+	if err != nil {
+		return nil, nil, err
 	}
 	if !loadKey {
 		return cli, info, nil
 	}
+	if cfg.Offline == nil {
+		warnUpgrades(cli)
+	}
+
+	switch {
+	case xpub != "":
+		info.key, err = key.NewWatch(cli.NetworkID(), xpub, watchAddresses)
+		if err != nil {
+			return nil, nil, err
+		}
+	case !useLedger:
+		info.key, err = loadSoftKey(cli.NetworkID())
+		if err != nil {
+			return nil, nil, err
+		}
+	default:
+		info.key, err = key.NewHard(cli.NetworkID(), ledgerPrompter())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 
-	if !useLedger {
-		info.key, err = key.LoadSoft(cli.NetworkID(), privKeyPath)
+	payer := info.key
+	if feeKeyPath != "" {
+		info.feeKey, err = key.LoadSoft(cli.NetworkID(), feeKeyPath)
 		if err != nil {
+			info.key.Close()
 			return nil, nil, err
 		}
-	} else {
-		info.key, err = key.NewHard(cli.NetworkID())
+		payer = info.feeKey
+		// closes both keys with the deferred "info.key.Close"
+		info.key = &withFeeKey{Key: info.key, feeKey: info.feeKey}
+	}
+	if auditLogPath != "" {
+		log, err := audit.Open(auditLogPath)
 		if err != nil {
+			info.key.Close()
 			return nil, nil, err
 		}
+		info.key = key.WithAudit(info.key, log)
+		if info.feeKey != nil {
+			info.feeKey = key.WithAudit(info.feeKey, log)
+			payer = info.feeKey
+		}
 	}
 
-	info.balance, err = cli.P().Balance(context.TODO(), info.key)
+	// the balance to pay the fees with
+	info.pools, err = cli.P().Pools(context.TODO(), payer)
 	if err != nil {
+		info.key.Close()
 		return nil, nil, err
 	}
+	info.balance = info.pools.Total()
 	return cli, info, nil
 }
 
+// withFeeKey closes the fee key along with the key.
+type withFeeKey struct {
+	key.Key
+	feeKey key.Key
+}
+
+func (k *withFeeKey) Close() error {
+	err := k.Key.Close()
+	if ferr := k.feeKey.Close(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+func addFeeKeyFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&feeKeyPath, "fee-key-path", "", "private key file path to pay the tx fees with, while the key provides the subnet auth (default to the key)")
+}
+
+var (
+	errStdinKeyPrompt     = errors.New("--private-key-path=- reads the key from stdin, which the prompts read from: pass --yes or --enable-prompt=false")
+	errWrongNetwork       = errors.New("endpoint is for another network")
+	errDeterministicUTXOs = errors.New("--deterministic requires --utxos-file, to build from the same UTXOs")
+)
+
+// checkNetwork returns an error if "--network" is set, and the endpoint
+// reports another network.
+func checkNetwork(cli client.Client) error {
+	if expectedNetwork == "" {
+		return nil
+	}
+	networkID, err := constants.NetworkID(expectedNetwork)
+	if err != nil {
+		return err
+	}
+	if cli.NetworkID() != networkID {
+		return fmt.Errorf("%w: %q reports network ID %d, expected %d (%s)",
+			errWrongNetwork, cli.Config().URI, cli.NetworkID(), networkID, expectedNetwork)
+	}
+	return nil
+}
+
+// warnUpgrades warns of the activated network upgrades that change the
+// txs built by subnet-cli, if the node version can be queried.
+func warnUpgrades(cli client.Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	c, err := cli.P().Capabilities(ctx)
+	cancel()
+	if err != nil {
+		zap.L().Debug("failed to detect the network upgrades", zap.Error(err))
+		return
+	}
+	if c.DynamicFees {
+		color.Outf("{{yellow}}%s is activated (node %s), the displayed fees are a lower bound{{/}}\n", fork.Etna.Name, c.NodeVersion)
+	}
+}
+
+// checkTxType returns an error if the network no longer accepts the tx type,
+// not to prompt for a tx that would be rejected.
+func checkTxType(cli client.Client, txType string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	c, err := cli.P().Capabilities(ctx)
+	cancel()
+	if err != nil {
+		zap.L().Debug("failed to detect the network upgrades", zap.Error(err))
+		return nil
+	}
+	return c.Check(txType)
+}
+
+const feeConfirmation = "{{green}}Yes, let's sign! {{bold}}{{underline}}I agree to pay the fee{{/}}{{green}}!{{/}}"
+
+// prompter is used for all confirmations, and is replaced with
+// an auto-confirming one when prompts are disabled (e.g., "--yes").
+var prompter = prompt.New()
+
+// SetPrompter overrides the prompter used for all confirmations.
+func SetPrompter(p prompt.Prompter) {
+	prompter = p
+}
+
+// ledgerPrompter returns the prompter confirming the retries of the failed
+// ledger actions, nil with the prompts disabled not to retry forever.
+func ledgerPrompter() prompt.Prompter {
+	if !enablePrompt {
+		return nil
+	}
+	return prompter
+}
+
+// confirm asks the prompter to confirm with the [yes] option,
+// and returns false if declined or the prompt fails.
+func confirm(yes string) bool {
+	ok, err := prompter.Confirm(
+		formatter.F(yes),
+		formatter.F("{{red}}No, stop it!{{/}}"),
+	)
+	if err != nil {
+		zap.L().Warn("prompt failed", zap.Error(err))
+		return false
+	}
+	return ok
+}
+
 func CreateLogger() error {
 	lcfg := logutil.GetDefaultZapLoggerConfig()
 	lcfg.Level = zap.NewAtomicLevelAt(logutil.ConvertToZapLevel(logLevel))
@@ -122,14 +420,185 @@ func CreateLogger() error {
 	return nil
 }
 
+// openCache opens the cache of "--cache-dir", "~/.subnet-cli/cache" by default.
+func openCache() (*cache.Cache, error) {
+	dir := cacheDir
+	if dir == "" {
+		var err error
+		dir, err = cache.DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cache.New(dir, cacheTTL)
+}
+
+// walletLockDir returns the directory of "--lock-dir",
+// "~/.subnet-cli/locks" by default.
+func walletLockDir() (string, error) {
+	if lockDir != "" {
+		return lockDir, nil
+	}
+	return walletlock.DefaultDir()
+}
+
+// passphraseReader reads the passphrases from "--passphrase-file" or
+// "--passphrase-fd", or prompts for them without echoing them.
+func passphraseReader() *passphrase.Reader {
+	return passphrase.New(passphraseFile, passphraseFD, enablePrompt)
+}
+
+// CheckBalance verifies the unlocked balance covers the required balance,
+// along with the locked stakeable one if "--stake-locked" is set. The fees
+// can only be paid with the unlocked balance.
 func (i *Info) CheckBalance() error {
-	if i.balance < i.requiredBalance {
-		color.Outf("{{red}}insufficient funds to perform operation. get more at https://faucet.avax-test.network{{/}}\n")
-		return fmt.Errorf("%w: on %s (expected=%d, have=%d)", ErrInsufficientFunds, i.key.P(), i.requiredBalance, i.balance)
+	have := i.pools.Unlocked
+	if stakeLocked {
+		have += i.pools.LockedStakeable
+	}
+	needed := i.requiredBalance
+	if fee := math.Min64(i.txFee, i.requiredBalance); i.pools.Unlocked < fee {
+		have, needed = i.pools.Unlocked, fee
+	}
+	if have < needed {
+		color.Outf("{{red}}insufficient funds to perform operation{{/}}\n")
+		addrs := i.key.P()
+		if i.feeKey != nil {
+			addrs = i.feeKey.P()
+		}
+		return fmt.Errorf("%w: on %s", &client.ErrInsufficientFunds{Needed: needed, Have: have}, addrs)
+	}
+	return i.CheckSpend()
+}
+
+// CheckSubnetAuth fails fast when the loaded key cannot satisfy the
+// subnet control keys threshold, before any transaction is built.
+func (i *Info) CheckSubnetAuth(cli client.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	err := cli.P().CheckSubnetAuth(ctx, i.key, i.subnetID)
+	cancel()
+	if err != nil {
+		color.Outf("{{red}}loaded key is not authorized to sign transactions for subnet %s{{/}}\n", i.subnetID)
+		return err
 	}
 	return nil
 }
 
+// CheckStakeDuration fails fast when the validation period violates the
+// network staking duration limits, which the node would reject anyway.
+func CheckStakeDuration(networkID uint32, now time.Time, start time.Time, end time.Time) error {
+	limits, err := staking.DefaultLimits(networkID).Override(minStakeDuration, maxStakeDuration)
+	if err != nil {
+		return err
+	}
+	if err := limits.Check(start, end, now, validateStartBuffer); err != nil {
+		color.Outf("{{red}}invalid validation period (adjust --validate-end or --validate-start-buffer){{/}}\n")
+		if errors.Is(err, staking.ErrStartTooSoon) {
+			return client.NewStartTimeTooSoon(start, now.Add(validateStartBuffer))
+		}
+		return err
+	}
+	return nil
+}
+
+var errClockSkew = errors.New("local clock is out of sync with the node")
+
+// CheckClockSkew compares the local clock against the node's, since the
+// validate start time is computed locally but verified by the node.
+func CheckClockSkew(uri string) error {
+	if maxClockSkew <= 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	skew, err := clock.Skew(ctx, uri)
+	cancel()
+	if err != nil {
+		color.Outf("{{yellow}}failed to check the clock skew against %s: %v{{/}}\n", uri, err)
+		return nil
+	}
+	direction := "behind"
+	if skew < 0 {
+		direction = "ahead of"
+	}
+	if clock.Abs(skew) > maxClockSkew {
+		color.Outf("{{red}}local clock is %v %s the node; sync it (e.g., with NTP) or raise --max-clock-skew{{/}}\n", clock.Abs(skew), direction)
+		return fmt.Errorf("%w: skew %v exceeds %v", errClockSkew, skew, maxClockSkew)
+	}
+	// "Date" header is only accurate to a second
+	if clock.Abs(skew) > time.Second {
+		color.Outf("{{yellow}}local clock is %v %s the node{{/}}\n", clock.Abs(skew), direction)
+	}
+	return nil
+}
+
+// addValidateEndFlags adds "--validate-end" of the default [end], and
+// "--validate-duration" to set the end from the start time instead.
+func addValidateEndFlags(cmd *cobra.Command, end string, usage string) {
+	cmd.PersistentFlags().StringVar(&validateEnds, "validate-end", end, usage+" (RFC3339 timestamp, or relative to now, e.g., '+300d')")
+	cmd.PersistentFlags().StringVar(&validateDurations, "validate-duration", "", "validation duration from the validate start time (e.g., '336h', '14d'), instead of --validate-end")
+}
+
+// parseValidateEnd returns the validate end time of "--validate-end",
+// or of "--validate-duration" from [start] if set.
+func parseValidateEnd(start time.Time) (time.Time, error) {
+	if validateDurations != "" {
+		d, err := validate.Duration(validateDurations)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return start.Add(d), nil
+	}
+	return validate.Time(validateEnds, time.Now())
+}
+
+func addStakeDurationFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().DurationVar(&validateStartBuffer, "validate-start-buffer", 30*time.Second, "minimum time between now and the validate start time")
+	cmd.PersistentFlags().DurationVar(&minStakeDuration, "min-stake-duration", 0, "minimum staking duration of the network (0 to use the network default)")
+	cmd.PersistentFlags().DurationVar(&maxStakeDuration, "max-stake-duration", 0, "maximum staking duration of the network (0 to use the network default)")
+	cmd.PersistentFlags().DurationVar(&maxClockSkew, "max-clock-skew", 10*time.Second, "maximum allowed difference between the local and the node clocks (0 to disable the check)")
+}
+
+func addOutputFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringSliceVar(&outputOwnerAddrs, "output-owners", nil, "P-Chain addresses to own the change, the returned stake, and the rewards (default to the change and reward addresses)")
+	cmd.PersistentFlags().Uint32Var(&outputThreshold, "output-threshold", 1, "number of --output-owners required to spend the outputs")
+	cmd.PersistentFlags().StringVar(&outputLocktime, "output-locktime", "", "RFC3339 timestamp until which the outputs cannot be spent (requires --output-owners)")
+}
+
+// addMemoFlag adds the "--memo" flag to the commands building txs.
+func addMemoFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&memo, "memo", "", "memo to attach to the txs for bookkeeping, UTF-8 or hex-encoded with the 0x prefix (up to 256 bytes)")
+}
+
+var errOutputOwnersRequired = errors.New("--output-locktime and --output-threshold require --output-owners")
+
+// parseOutputOwners returns the owners set by "addOutputFlags",
+// or nil if not set.
+func parseOutputOwners() (*secp256k1fx.OutputOwners, error) {
+	if len(outputOwnerAddrs) == 0 {
+		if outputLocktime != "" || outputThreshold > 1 {
+			return nil, errOutputOwnersRequired
+		}
+		return nil, nil
+	}
+	locktime := uint64(0)
+	if outputLocktime != "" {
+		t, err := time.Parse(time.RFC3339, outputLocktime)
+		if err != nil {
+			return nil, err
+		}
+		locktime = uint64(t.Unix())
+	}
+	addrs := make([]ids.ShortID, len(outputOwnerAddrs))
+	for i, addr := range outputOwnerAddrs {
+		var err error
+		addrs[i], err = ParsePAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return client.NewOutputOwners(locktime, outputThreshold, addrs)
+}
+
 func BaseTableSetup(i *Info) (*bytes.Buffer, *tablewriter.Table) {
 	// P-Chain balance is denominated by units.Avax or 10^9 nano-Avax
 	curPChainDenominatedP := float64(i.balance) / float64(units.Avax)
@@ -145,8 +614,14 @@ func BaseTableSetup(i *Info) (*bytes.Buffer, *tablewriter.Table) {
 	tb.SetRowLine(true)
 	tb.SetAlignment(tablewriter.ALIGN_LEFT)
 
-	tb.Append([]string{formatter.F("{{cyan}}{{bold}}PRIMARY P-CHAIN ADDRESS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.key.P()[0])})
-	tb.Append([]string{formatter.F("{{coral}}{{bold}}TOTAL P-CHAIN BALANCE{{/}} "), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} $AVAX", curPChainDenominatedBalanceP)})
+	// no key is loaded in read-only mode
+	if i.key != nil {
+		tb.Append([]string{formatter.F("{{cyan}}{{bold}}PRIMARY P-CHAIN ADDRESS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.key.P()[0])})
+		if i.feeKey != nil {
+			tb.Append([]string{formatter.F("{{cyan}}{{bold}}FEE PAYER P-CHAIN ADDRESS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.feeKey.P()[0])})
+		}
+		tb.Append([]string{formatter.F("{{coral}}{{bold}}TOTAL P-CHAIN BALANCE{{/}} "), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} $AVAX", curPChainDenominatedBalanceP)})
+	}
 	if i.txFee > 0 {
 		txFee := float64(i.txFee) / float64(units.Avax)
 		txFees := humanize.FormatFloat("#,###.###", txFee)
@@ -168,16 +643,43 @@ func BaseTableSetup(i *Info) (*bytes.Buffer, *tablewriter.Table) {
 		tb.Append([]string{formatter.F("{{red}}{{bold}}REQUIRED BALANCE{{/}}"), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} $AVAX", requiredBalances)})
 	}
 
+	if i.outputOwners != nil {
+		owners := fmt.Sprintf("%d of %s", i.outputOwners.Threshold, strings.Join(outputOwnerAddrs, ", "))
+		if i.outputOwners.Locktime > 0 {
+			owners += fmt.Sprintf(" (locked until %s)", time.Unix(int64(i.outputOwners.Locktime), 0).UTC().Format(time.RFC3339))
+		}
+		tb.Append([]string{formatter.F("{{red}}{{bold}}OUTPUT OWNERS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", owners)})
+	}
+	if len(i.memo) > 0 {
+		tb.Append([]string{formatter.F("{{dark-green}}MEMO{{/}}"), formatter.F("{{light-gray}}%s{{/}}", memo)})
+	}
+
 	tb.Append([]string{formatter.F("{{orange}}URI{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.uri)})
 	tb.Append([]string{formatter.F("{{orange}}NETWORK NAME{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.networkName)})
 	return buf, tb
 }
 
+var errInvalidPAddress = errors.New("invalid P-Chain address")
+
+// ParsePAddress parses the formatted P-Chain address (e.g., "P-fuji1...").
+func ParsePAddress(addr string) (ids.ShortID, error) {
+	chainID, _, b, err := formatting.ParseAddress(addr)
+	if err != nil {
+		return ids.ShortEmpty, err
+	}
+	if chainID != "P" {
+		return ids.ShortEmpty, fmt.Errorf("%w %q", errInvalidPAddress, addr)
+	}
+	return ids.ToShortID(b)
+}
+
 func ParseNodeIDs(cli client.Client, i *Info) error {
 	// TODO: make this parsing logic more explicit (+ store per subnetID, not
 	// just whatever was called last)
 	i.nodeIDs = []ids.ShortID{}
 	i.allNodeIDs = make([]ids.ShortID, len(nodeIDs))
+	// fetched on the first node not validating yet
+	var pending map[ids.ShortID]client.Validator
 	for idx, rnodeID := range nodeIDs {
 		nodeID, err := ids.ShortFromPrefixedString(rnodeID, constants.NodeIDPrefix)
 		if err != nil {
@@ -189,6 +691,18 @@ func ParseNodeIDs(cli client.Client, i *Info) error {
 		i.valInfos[nodeID] = &ValInfo{start, end}
 		switch {
 		case errors.Is(err, client.ErrValidatorNotFound):
+			if pending == nil {
+				pending, err = pendingValidators(cli, i.subnetID)
+				if err != nil {
+					return err
+				}
+			}
+			if v, ok := pending[nodeID]; ok {
+				// added but not started yet, so it must not be added again
+				i.valInfos[nodeID] = &ValInfo{v.Start, v.End}
+				color.Outf("\n{{yellow}}%s is already a pending validator on %s, starting at %s{{/}}\n", nodeID, i.subnetID, formatPendingStart(v.Start))
+				continue
+			}
 			i.nodeIDs = append(i.nodeIDs, nodeID)
 		case err != nil:
 			return err
@@ -199,18 +713,57 @@ func ParseNodeIDs(cli client.Client, i *Info) error {
 	return nil
 }
 
+// pendingValidators returns the validators of the subnet (the primary
+// network, if [subnetID] is empty) whose validation has not started yet.
+func pendingValidators(cli client.Client, subnetID ids.ID) (map[ids.ShortID]client.Validator, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	vs, err := cli.P().GetPendingValidators(ctx, subnetID)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[ids.ShortID]client.Validator, len(vs))
+	for _, v := range vs {
+		pending[v.NodeID] = v
+	}
+	return pending, nil
+}
+
+// formatPendingStart formats the start time of a pending validator, with
+// the countdown to it.
+func formatPendingStart(start time.Time) string {
+	return fmt.Sprintf("%s (in %v)", start.Format(time.RFC3339), time.Until(start).Round(time.Second))
+}
+
 func WaitValidator(cli client.Client, nodeIDs []ids.ShortID, i *Info) {
 	for _, nodeID := range nodeIDs {
 		color.Outf("{{yellow}}waiting for validator %s to start validating %s...(could take a few minutes){{/}}\n", nodeID, i.subnetID)
-		for {
-			start, end, err := cli.P().GetValidator(context.Background(), i.subnetID, nodeID)
-			if err == nil {
-				if i.subnetID == ids.Empty {
-					i.valInfos[nodeID] = &ValInfo{start, end}
-				}
-				break
-			}
-			time.Sleep(10 * time.Second)
+		// never fails without a deadline
+		start, end, _ := subnet.WaitValidator(context.Background(), cli, i.subnetID, nodeID, subnet.DefaultPollInterval)
+		if i.subnetID == ids.Empty {
+			i.valInfos[nodeID] = &ValInfo{start, end}
 		}
+		progressEvents.Emit(progress.Event{
+			Type:     progress.ValidatorConfirmed,
+			NodeID:   nodeID.PrefixedString(constants.NodeIDPrefix),
+			SubnetID: i.subnetID.String(),
+		})
+	}
+}
+
+// privKeyFromEnv is true to load the key of the SUBNET_CLI_PRIVATE_KEY
+// environment variable, when "--private-key-path" is not set.
+var privKeyFromEnv bool
+
+// loadSoftKey loads the key of "--private-key-path", or of the environment
+// if "privKeyFromEnv". The key is only read from stdin without the prompts,
+// as they read their answers from stdin too.
+func loadSoftKey(networkID uint32) (*key.SoftKey, error) {
+	if privKeyFromEnv {
+		return key.LoadSoftEnv(networkID)
+	}
+	if privKeyPath == key.StdinPath && enablePrompt {
+		return nil, errStdinKeyPrompt
 	}
+	return key.LoadSoft(networkID, privKeyPath)
 }
@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// completionTimeout bounds the network queries of the dynamic completion,
+// not to hang the shell on an unreachable endpoint.
+const completionTimeout = 5 * time.Second
+
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// flagCompletions maps the flag names to their dynamic value completion.
+var flagCompletions = map[string]completionFunc{
+	"subnet-id":        completeSubnetIDs,
+	"blockchain-id":    completeBlockchainIDs,
+	"chain-id":         completeBlockchainIDs,
+	"private-key-path": completeKeyFiles,
+	"fee-key-path":     completeKeyFiles,
+}
+
+// registerCompletions registers the dynamic value completion of the
+// flags defined by [c] and all of its sub-commands.
+func registerCompletions(c *cobra.Command) {
+	for name, fn := range flagCompletions {
+		if c.LocalFlags().Lookup(name) == nil {
+			continue
+		}
+		if err := c.RegisterFlagCompletionFunc(name, fn); err != nil {
+			zap.L().Debug("failed to register flag completion", zap.String("flag", name), zap.Error(err))
+		}
+	}
+	for _, sub := range c.Commands() {
+		registerCompletions(sub)
+	}
+}
+
+// completeSubnetIDs completes the IDs of the subnets on the network
+// of "--public-uri", described by their control keys threshold.
+func completeSubnetIDs(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cli := completionClient()
+	if cli == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	ss, err := cli.GetSubnets(ctx, nil)
+	cancel()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	comps := make([]string, 0, len(ss))
+	for _, s := range ss {
+		if s.ID == constants.PrimaryNetworkID || !strings.HasPrefix(s.ID.String(), toComplete) {
+			continue
+		}
+		comps = append(comps, fmt.Sprintf("%s\t%d-of-%d control keys", s.ID, s.Threshold, len(s.ControlKeys)))
+	}
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeBlockchainIDs completes the IDs of the blockchains on the network
+// of "--public-uri", described by their names. If "--subnet-id" is set,
+// only the blockchains of the subnet are completed.
+func completeBlockchainIDs(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cli := completionClient()
+	if cli == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var subnetID ids.ID
+	if f := cmd.Flag("subnet-id"); f != nil && f.Value.String() != "" {
+		var err error
+		subnetID, err = ids.FromString(f.Value.String())
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	bcs, err := cli.GetBlockchains(ctx)
+	cancel()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	comps := make([]string, 0, len(bcs))
+	for _, bc := range bcs {
+		if subnetID != ids.Empty && bc.SubnetID != subnetID {
+			continue
+		}
+		if !strings.HasPrefix(bc.ID.String(), toComplete) {
+			continue
+		}
+		comps = append(comps, fmt.Sprintf("%s\t%s", bc.ID, bc.Name))
+	}
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeKeyFiles completes the private key files (e.g., ".subnet-cli.pk").
+func completeKeyFiles(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return []string{"pk"}, cobra.ShellCompDirectiveFilterFileExt
+}
+
+// completionClient returns the P-Chain client of "--public-uri",
+// or nil if the command has no endpoint to query.
+func completionClient() platformvm.Client {
+	if publicURI == "" {
+		return nil
+	}
+	return platformvm.NewClient(strings.TrimSuffix(publicURI, "/"))
+}
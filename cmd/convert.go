@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ConvertCommand implements "subnet-cli convert" command.
+func ConvertCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "convert",
+		Short: "Sub-commands for converting between formats",
+	}
+	cmd.AddCommand(
+		newConvertIDCommand(),
+	)
+	return cmd
+}
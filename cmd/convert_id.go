@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/idconv"
+)
+
+var errInvalidConvertTo = errors.New("--to must be 'cb58', 'hex', or 'node-id'")
+
+func newConvertIDCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "id <ID>...",
+		Short: "Converts the IDs between CB58 and hex",
+		Long: `
+Detects the kind of each ID (a 32-byte subnet, blockchain, tx, or VM ID, a
+node ID, a 20-byte short ID, or a chain address) and its format (CB58,
+hex with or without the avalanche checksum, or bech32), verifies its
+checksum, and shows it in CB58 and in hex. The hex has no checksum, as
+expected by the EVM tooling (e.g., a blockchain ID as a bytes32).
+
+With --to, only the converted IDs are printed, one per line, for the
+scripts.
+
+$ subnet-cli convert id 24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1
+$ subnet-cli convert id --to=cb58 0x8c86d07cd60218661863e0116552dccd5bd84c564bd29d7181dbddd5ec616104
+$ subnet-cli convert id --to=node-id 0x22cfc1533359faecf8d235e5c51de26d53e0ac3f
+
+`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: convertIDFunc,
+	}
+	cmd.PersistentFlags().StringVar(&convertTo, "to", "", "format to print the IDs in alone ('cb58', 'hex', or 'node-id' for the 20-byte IDs), empty to show all")
+	return cmd
+}
+
+func convertIDFunc(cmd *cobra.Command, args []string) error {
+	ps := make([]*idconv.Parsed, len(args))
+	for i, s := range args {
+		p, err := idconv.Parse(s)
+		if err != nil {
+			return fmt.Errorf("%q: %w", s, err)
+		}
+		ps[i] = p
+	}
+
+	if convertTo != "" {
+		for i, p := range ps {
+			var s string
+			switch convertTo {
+			case "cb58":
+				s = p.CB58()
+			case "hex":
+				s = p.Hex()
+			case "node-id":
+				s = p.NodeID()
+				if s == "" {
+					return fmt.Errorf("%q: %w: not a node ID", args[i], idconv.ErrInvalidLength)
+				}
+			default:
+				return errInvalidConvertTo
+			}
+			fmt.Println(s)
+		}
+		return nil
+	}
+
+	buf := bytes.NewBuffer(nil)
+	tb := newTxTable(buf, []string{"input", "kind", "CB58", "hex", "node ID"})
+	for i, p := range ps {
+		kind := fmt.Sprintf("%s (%s)", p.Kind, p.Encoding)
+		if p.Checksum {
+			kind = fmt.Sprintf("%s (%s, checksum ok)", p.Kind, p.Encoding)
+		}
+		if p.Kind == idconv.Address {
+			kind = fmt.Sprintf("%s (%s-Chain, %s)", p.Kind, p.Chain, p.HRP)
+		}
+		tb.Append([]string{args[i], kind, p.CB58(), p.Hex(), p.NodeID()})
+	}
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	return nil
+}
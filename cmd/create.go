@@ -22,15 +22,21 @@ func CreateCommand() *cobra.Command {
 		newCreateVMIDCommand(),
 	)
 	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
-	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path, or key store URI (e.g., 'keychain://subnet-cli/ops', 'env://OPS_KEY')")
 	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	addOutputFlags(cmd)
+	addMemoFlag(cmd)
+	addFeeKeyFlags(cmd)
 	return cmd
 }
 
 func MakeCreateTable(i *Info) string {
 	buf, tb := BaseTableSetup(i)
 	if i.subnetID != ids.Empty {
-		tb.Append([]string{formatter.F("{{blue}}%s{{/}}", i.subnetIDType), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.subnetID)})
+		tb.Append([]string{formatter.F("{{blue}}%s{{/}}", i.subnetIDType), formatter.F("{{light-gray}}{{bold}}%s{{/}}", labeled(i.subnetID.String()))})
+	}
+	if i.stakingAsset != nil {
+		tb.Append([]string{formatter.F("{{blue}}STAKING ASSET{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}} (%s, %d decimals)", i.stakingAsset.Symbol, i.stakingAsset.ID, i.stakingAsset.Denomination)})
 	}
 	if i.blockchainID != ids.Empty {
 		tb.Append([]string{formatter.F("{{blue}}CREATED BLOCKCHAIN ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.blockchainID)})
@@ -5,15 +5,19 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
-	"os"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/genesis"
+	"github.com/ava-labs/subnet-cli/internal/node"
 	"github.com/ava-labs/subnet-cli/pkg/color"
-	"github.com/manifoldco/promptui"
 	"github.com/onsi/ginkgo/v2/formatter"
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 )
 
 func newCreateBlockchainCommand() *cobra.Command {
@@ -30,6 +34,47 @@ $ subnet-cli create blockchain \
 --vm-id=tGas3T58KzdjLHhBDMnH2TvrddhqTji5iZAMZ3RXs2NLpSnhH \
 --vm-genesis-path=.my-custom-vm.genesis
 
+To render the genesis from a Go template (e.g., {{.chainId}}) instead:
+
+$ subnet-cli create blockchain \
+--private-key-path=.insecure.ewoq.key \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--chain-name=my-custom-chain \
+--vm-id=srEXiWaHuhNyGwPUi444Tu47ZEDwxTWrbQiuD7FmgSAQ6X7Dy \
+--genesis-template=genesis.tmpl.json \
+--set chainId=43214 \
+--set airdropAddr=0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC
+
+To configure the Subnet-EVM stateful precompiles (ContractDeployerAllowList,
+TxAllowList, FeeConfigManager, NativeMinter, RewardManager) of the genesis,
+interactively or from a JSON file:
+
+$ subnet-cli create blockchain \
+--private-key-path=.insecure.ewoq.key \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--chain-name=my-custom-chain \
+--vm-id=srEXiWaHuhNyGwPUi444Tu47ZEDwxTWrbQiuD7FmgSAQ6X7Dy \
+--vm-genesis-path=.my-custom-vm.genesis \
+--configure-precompiles
+
+The chain name is checked against the chains of the subnet, and the EVM
+chain ID of the genesis against the chains of the subnet and the known EVM
+chains (with --chainlist, e.g., https://chainid.network/chains.json). The
+collisions are warned of, as they break the wallets keying the networks
+by chain ID.
+
+To block until the validators bootstrapped the new chain (e.g., for the
+automation to know when the chain is usable):
+
+$ subnet-cli create blockchain \
+--private-key-path=.insecure.ewoq.key \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--chain-name=my-custom-chain \
+--vm-id=tGas3T58KzdjLHhBDMnH2TvrddhqTji5iZAMZ3RXs2NLpSnhH \
+--vm-genesis-path=.my-custom-vm.genesis \
+--wait-bootstrapped \
+--node-urls=http://10.0.0.1:9650,http://10.0.0.2:9650
+
 `,
 		RunE: createBlockchainFunc,
 	}
@@ -38,15 +83,89 @@ $ subnet-cli create blockchain \
 	cmd.PersistentFlags().StringVar(&chainName, "chain-name", "", "chain name")
 	cmd.PersistentFlags().StringVar(&vmIDs, "vm-id", "", "VM ID (must be formatted in ids.ID)")
 	cmd.PersistentFlags().StringVar(&vmGenesisPath, "vm-genesis-path", "", "VM genesis file path")
+	cmd.PersistentFlags().StringVar(&genesisTemplatePath, "genesis-template", "", "VM genesis Go template file path (overrides --vm-genesis-path)")
+	cmd.PersistentFlags().StringArrayVar(&genesisVars, "set", nil, "genesis template variable in 'key=value' format (can be repeated)")
+	cmd.PersistentFlags().StringVar(&precompilesPath, "precompiles", "", "JSON file of the Subnet-EVM precompile configs to set in the genesis, keyed by precompile (e.g., 'txAllowList')")
+	cmd.PersistentFlags().BoolVar(&configurePrecompiles, "configure-precompiles", false, "'true' to configure the Subnet-EVM precompiles of the genesis interactively")
+	cmd.PersistentFlags().StringVar(&chainlistPath, "chainlist", "", "JSON file of the known EVM chains (e.g., https://chainid.network/chains.json) to check the chain ID of the genesis against, besides the built-in ones")
+	cmd.PersistentFlags().StringVar(&stakingAssetIDs, "staking-asset-id", "", "expected staking asset ID of the elastic subnet, to refuse to create the chain on another subnet")
+	cmd.PersistentFlags().BoolVar(&waitBootstrapped, "wait-bootstrapped", false, "'true' to wait for the nodes of --node-urls to bootstrap the new chain")
+	cmd.PersistentFlags().StringSliceVar(&nodeURLs, "node-urls", nil, "URIs of the nodes to wait for (e.g., the subnet validators)")
+	cmd.PersistentFlags().DurationVar(&bootstrapTimeout, "bootstrap-timeout", time.Hour, "maximum time to wait for the nodes to bootstrap the new chain")
 
 	return cmd
 }
 
+// readGenesis reads the genesis file, or renders the genesis template,
+// with the precompile configs set if any.
+func readGenesis() (b []byte, src string, err error) {
+	if genesisTemplatePath == "" {
+		b, err = ioutil.ReadFile(vmGenesisPath)
+		if err != nil {
+			return nil, "", err
+		}
+		src = vmGenesisPath
+	} else {
+		vars, err := genesis.ParseVars(genesisVars)
+		if err != nil {
+			return nil, "", err
+		}
+		b, err = genesis.RenderFile(genesisTemplatePath, vars)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to render %q: %w", genesisTemplatePath, err)
+		}
+		src = fmt.Sprintf("%s (rendered)", genesisTemplatePath)
+	}
+	b, set, err := applyPrecompiles(b)
+	if err != nil {
+		return nil, "", err
+	}
+	if set {
+		src += " (with precompiles)"
+	}
+	return b, src, nil
+}
+
+var errStakingAssetMismatch = errors.New("unexpected staking asset")
+
+// checkStakingAsset returns the staking asset of the subnet if elastic,
+// verified against "--staking-asset-id" if set. The asset is not required
+// unless "--staking-asset-id" is set.
+func checkStakingAsset(cli client.Client, subnetID ids.ID) (*client.Asset, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	asset, err := cli.P().StakingAsset(ctx, subnetID)
+	cancel()
+	if err != nil {
+		if stakingAssetIDs != "" {
+			return nil, err
+		}
+		if !errors.Is(err, client.ErrNotElastic) {
+			zap.L().Warn("failed to get the staking asset", zap.Error(err))
+		}
+		return nil, nil
+	}
+	if stakingAssetIDs == "" {
+		return asset, nil
+	}
+	expected, err := ids.FromString(stakingAssetIDs)
+	if err != nil {
+		return nil, err
+	}
+	if asset.ID != expected {
+		return nil, fmt.Errorf("%w: subnet %s stakes %s (%s), expected %s", errStakingAssetMismatch, subnetID, asset.Symbol, asset.ID, expected)
+	}
+	return asset, nil
+}
+
 func createBlockchainFunc(cmd *cobra.Command, args []string) error {
+	if waitBootstrapped && len(nodeURLs) == 0 {
+		return errNoNodeURLs
+	}
 	cli, info, err := InitClient(publicURI, true)
 	if err != nil {
 		return err
 	}
+	defer info.key.Close()
 	info.subnetIDType = "SUBNET ID"
 	info.subnetID, err = ids.FromString(subnetIDs)
 	if err != nil {
@@ -56,17 +175,27 @@ func createBlockchainFunc(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	vmGenesisBytes, err := ioutil.ReadFile(vmGenesisPath)
+	info.stakingAsset, err = checkStakingAsset(cli, info.subnetID)
+	if err != nil {
+		return err
+	}
+	vmGenesisBytes, genesisSrc, err := readGenesis()
 	if err != nil {
 		return err
 	}
+	if err := checkChainCollisions(cli, info.subnetID, chainName, vmGenesisBytes); err != nil {
+		return err
+	}
 	info.txFee = uint64(info.feeData.CreateBlockchainTxFee)
 	info.requiredBalance = info.txFee
 	if err := info.CheckBalance(); err != nil {
 		return err
 	}
+	if err := info.CheckSubnetAuth(cli); err != nil {
+		return err
+	}
 	info.chainName = chainName
-	info.vmGenesisPath = vmGenesisPath
+	info.vmGenesisPath = genesisSrc
 
 	msg := MakeCreateTable(info)
 	if enablePrompt {
@@ -74,22 +203,8 @@ func createBlockchainFunc(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Fprint(formatter.ColorableStdOut, msg)
 
-	if enablePrompt {
-		prompt := promptui.Select{
-			Label:  "\n",
-			Stdout: os.Stdout,
-			Items: []string{
-				formatter.F("{{green}}Yes, let's create! {{bold}}{{underline}}I agree to pay the fee{{/}}{{green}}!{{/}}"),
-				formatter.F("{{red}}No, stop it!{{/}}"),
-			},
-		}
-		idx, _, err := prompt.Run()
-		if err != nil {
-			return nil //nolint:nilerr
-		}
-		if idx == 1 {
-			return nil
-		}
+	if !info.confirmSpend() {
+		return errTxDeclined
 	}
 	println()
 	println()
@@ -102,6 +217,9 @@ func createBlockchainFunc(cmd *cobra.Command, args []string) error {
 		info.chainName,
 		info.vmID,
 		vmGenesisBytes,
+		client.WithOutputOwners(info.outputOwners),
+		client.WithMemo(info.memo),
+		client.WithFeeKey(info.feeKey),
 	)
 	cancel()
 	if err != nil {
@@ -120,5 +238,33 @@ func createBlockchainFunc(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	fmt.Fprint(formatter.ColorableStdOut, MakeCreateTable(info))
+	if waitBootstrapped {
+		return waitChainBootstrapped(info.blockchainID)
+	}
+	return nil
+}
+
+var errBootstrapTimeout = errors.New("nodes did not bootstrap the chain in time")
+
+// waitChainBootstrapped blocks until the nodes of "--node-urls" bootstrapped
+// the blockchain, or "--bootstrap-timeout" expires.
+func waitChainBootstrapped(blockchainID ids.ID) error {
+	color.Outf("\n{{blue}}waiting for %d node(s) to bootstrap %s...{{/}} {{light-gray}}(the nodes must track the subnet){{/}}\n", len(nodeURLs), blockchainID)
+	cs := make([]node.Client, len(nodeURLs))
+	for i, u := range nodeURLs {
+		cs[i] = node.New(u)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), bootstrapTimeout)
+	defer cancel()
+	left, err := node.WaitBootstrapped(ctx, cs, blockchainID.String(), pollInterval, func(i int, done int, took time.Duration) {
+		color.Outf("{{green}}[%d/%d] %s bootstrapped the chain{{/}} {{light-gray}}(took %v){{/}}\n", done, len(cs), nodeURLs[i], took.Round(time.Second))
+	})
+	if err != nil {
+		for _, i := range left {
+			color.Outf("{{red}}%s did not bootstrap the chain{{/}}\n", nodeURLs[i])
+		}
+		return fmt.Errorf("%w: %v", errBootstrapTimeout, err)
+	}
+	color.Outf("{{green}}all %d node(s) bootstrapped %s{{/}}\n", len(cs), blockchainID)
 	return nil
 }
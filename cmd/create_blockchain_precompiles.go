@@ -0,0 +1,132 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+
+	"github.com/ava-labs/subnet-cli/internal/genesis"
+	"github.com/ava-labs/subnet-cli/internal/validate"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+	"github.com/ava-labs/subnet-cli/pkg/prompt"
+)
+
+var errPrecompilesNonInteractive = errors.New("--configure-precompiles requires the prompts (use --precompiles with a file instead)")
+
+// applyPrecompiles sets the precompile configs of "--precompiles" and of
+// "--configure-precompiles" in the Subnet-EVM genesis, if any.
+func applyPrecompiles(b []byte) ([]byte, bool, error) {
+	cfgs := make(map[genesis.Precompile]genesis.PrecompileConfig)
+	if precompilesPath != "" {
+		pb, err := ioutil.ReadFile(precompilesPath)
+		if err != nil {
+			return nil, false, err
+		}
+		cfgs, err = genesis.LoadPrecompiles(pb)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to load %q: %w", precompilesPath, err)
+		}
+	}
+	if configurePrecompiles {
+		if err := promptPrecompiles(cfgs); err != nil {
+			return nil, false, err
+		}
+	}
+	if len(cfgs) == 0 {
+		return b, false, nil
+	}
+	b, err := genesis.SetPrecompiles(b, cfgs)
+	return b, err == nil, err
+}
+
+// promptPrecompiles prompts for the config of each precompile, starting
+// from the ones of [cfgs] (e.g., loaded from "--precompiles").
+func promptPrecompiles(cfgs map[genesis.Precompile]genesis.PrecompileConfig) error {
+	for _, p := range genesis.Precompiles {
+		action := "Enable"
+		if _, ok := cfgs[p]; ok {
+			action = "Reconfigure"
+		}
+		yes, err := prompter.Confirm(
+			formatter.F("{{green}}%s %s{{/}}", action, p.Name()),
+			formatter.F("{{red}}Skip %s{{/}}", p.Name()),
+		)
+		if errors.Is(err, prompt.ErrNonInteractive) {
+			return errPrecompilesNonInteractive
+		}
+		if err != nil {
+			return err
+		}
+		if !yes {
+			continue
+		}
+		var c genesis.PrecompileConfig
+		for {
+			c, err = promptPrecompile(p)
+			if err == nil {
+				err = c.Validate(p)
+			}
+			if err == nil {
+				break
+			}
+			if errors.Is(err, prompt.ErrNonInteractive) {
+				return errPrecompilesNonInteractive
+			}
+			color.Outf("{{red}}%v, try again{{/}}\n", err)
+		}
+		cfgs[p] = c
+	}
+	return nil
+}
+
+func promptPrecompile(p genesis.Precompile) (genesis.PrecompileConfig, error) {
+	var c genesis.PrecompileConfig
+	s, err := prompter.Input(p.Name() + " admin addresses (comma-separated)")
+	if err != nil {
+		return c, err
+	}
+	if c.AdminAddresses, err = genesis.ParseAddresses(s); err != nil {
+		return c, err
+	}
+	if p.IsAllowList() {
+		s, err := prompter.Input(p.Name() + " enabled addresses (comma-separated, empty for none)")
+		if err != nil {
+			return c, err
+		}
+		if c.EnabledAddresses, err = genesis.ParseAddresses(s); err != nil {
+			return c, err
+		}
+	}
+	if p == genesis.RewardManager {
+		s, err := prompter.Input("Initial rewards ('burn', 'fee-recipients', or the reward address; empty to burn)")
+		if err != nil {
+			return c, err
+		}
+		switch s = strings.TrimSpace(s); s {
+		case "", "burn":
+		case "fee-recipients":
+			c.InitialRewardConfig = &genesis.RewardConfig{AllowFeeRecipients: true}
+		default:
+			c.InitialRewardConfig = &genesis.RewardConfig{RewardAddress: s}
+		}
+	}
+	s, err = prompter.Input(p.Name() + " activation time (RFC3339, or relative to now, e.g., '+24h'; empty for genesis)")
+	if err != nil {
+		return c, err
+	}
+	if s = strings.TrimSpace(s); s != "" {
+		t, err := validate.Time(s, time.Now())
+		if err != nil {
+			return c, err
+		}
+		c.BlockTimestamp = t.Unix()
+	}
+	return c, nil
+}
@@ -4,8 +4,6 @@
 package cmd
 
 import (
-	"os"
-
 	"github.com/ava-labs/subnet-cli/internal/key"
 	"github.com/ava-labs/subnet-cli/pkg/color"
 	"github.com/spf13/cobra"
@@ -27,9 +25,8 @@ $ subnet-cli create key --private-key-path=.insecure.test.key
 }
 
 func createKeyFunc(cmd *cobra.Command, args []string) error {
-	if _, err := os.Stat(privKeyPath); err == nil {
-		color.Outf("{{red}}key already found at %q{{/}}\n", privKeyPath)
-		return os.ErrExist
+	if err := checkNoKey(privKeyPath); err != nil {
+		return err
 	}
 	k, err := key.NewSoft(0)
 	if err != nil {
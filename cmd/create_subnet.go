@@ -6,11 +6,9 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/ava-labs/subnet-cli/client"
 	"github.com/ava-labs/subnet-cli/pkg/color"
-	"github.com/manifoldco/promptui"
 	"github.com/onsi/ginkgo/v2/formatter"
 	"github.com/spf13/cobra"
 )
@@ -38,8 +36,9 @@ func createSubnetFunc(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	defer info.key.Close()
 	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
-	sid, _, err := cli.P().CreateSubnet(ctx, info.key, client.WithDryMode(true))
+	sid, _, err := cli.P().CreateSubnet(ctx, info.key, client.WithDryMode(true), client.WithOutputOwners(info.outputOwners), client.WithMemo(info.memo), client.WithFeeKey(info.feeKey))
 	cancel()
 	if err != nil {
 		return err
@@ -57,29 +56,15 @@ func createSubnetFunc(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Fprint(formatter.ColorableStdOut, msg)
 
-	if enablePrompt {
-		prompt := promptui.Select{
-			Label:  "\n",
-			Stdout: os.Stdout,
-			Items: []string{
-				formatter.F("{{red}}No, stop it!{{/}}"),
-				formatter.F("{{green}}Yes, let's create! {{bold}}{{underline}}I agree to pay the fee{{/}}{{green}}!{{/}}"),
-			},
-		}
-		idx, _, err := prompt.Run()
-		if err != nil {
-			panic(err)
-		}
-		if idx == 0 {
-			return nil
-		}
+	if !info.confirmSpend() {
+		return errTxDeclined
 	}
 
 	println()
 	println()
 	println()
 	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
-	subnetID, took, err := cli.P().CreateSubnet(ctx, info.key)
+	subnetID, took, err := cli.P().CreateSubnet(ctx, info.key, client.WithOutputOwners(info.outputOwners), client.WithMemo(info.memo), client.WithFeeKey(info.feeKey))
 	cancel()
 	if err != nil {
 		return err
@@ -0,0 +1,23 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// DecommissionCommand implements "subnet-cli decommission" command.
+func DecommissionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decommission",
+		Short: "Sub-commands for tearing down subnet resources",
+	}
+	cmd.AddCommand(
+		newDecommissionSubnetCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path, or key store URI (e.g., 'keychain://subnet-cli/ops', 'env://OPS_KEY')")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	return cmd
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/state"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var errValidatorsRemain = errors.New("subnet validators remain after the decommission")
+
+func newDecommissionSubnetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subnet",
+		Short: "Removes all validators of a subnet, and records it as retired",
+		Long: `
+Removes every current and pending validator of the subnet, with one
+"RemoveSubnetValidatorTx" per node (requires Banff), verifies that no
+validator remains, and records the subnet as retired in the local state
+file. The P-Chain has no transaction to delete a subnet or its blockchains:
+they remain, without validators. The key must hold enough control keys.
+
+$ subnet-cli decommission subnet \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1"
+
+`,
+		RunE: decommissionSubnetFunc,
+	}
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringVar(&stateFilePath, "state-file", "", "local state file to record the retired subnet in (default ~/.subnet-cli/state.json)")
+	addMemoFlag(cmd)
+	addFeeKeyFlags(cmd)
+	return cmd
+}
+
+func decommissionSubnetFunc(cmd *cobra.Command, args []string) error {
+	statePath := stateFilePath
+	if statePath == "" {
+		var err error
+		statePath, err = state.DefaultPath()
+		if err != nil {
+			return err
+		}
+	}
+	st, err := state.Load(statePath)
+	if err != nil {
+		return err
+	}
+
+	cli, info, err := InitClient(publicURI, true)
+	if err != nil {
+		return err
+	}
+	defer info.key.Close()
+	info.subnetID, err = ids.FromString(subnetIDs)
+	if err != nil {
+		return err
+	}
+	if r, ok := st.Retired(info.networkID, info.subnetID); ok {
+		color.Outf("{{yellow}}subnet %s was retired at %s, removing any validator added since{{/}}\n", info.subnetID, r.RetiredAt.Format(time.RFC3339))
+	}
+
+	vs, err := subnetValidators(cli, info.subnetID)
+	if err != nil {
+		return err
+	}
+	nodeIDs := make([]ids.ShortID, 0, len(vs))
+	for nodeID := range vs {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Slice(nodeIDs, func(i, j int) bool { return nodeIDs[i].String() < nodeIDs[j].String() })
+
+	info.txFee = uint64(info.feeData.TxFee) * uint64(len(nodeIDs))
+	info.requiredBalance = info.txFee
+	if err := info.CheckBalance(); err != nil {
+		return err
+	}
+	if err := info.CheckSubnetAuth(cli); err != nil {
+		return err
+	}
+
+	msg := makeDecommissionTable(info, nodeIDs)
+	if enablePrompt {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to decommission subnet, should we continue?{{/}}\n") + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+	if !info.confirmSpend() {
+		return errTxDeclined
+	}
+	println()
+
+	removed := make([]ids.ShortID, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		took, err := cli.P().RemoveSubnetValidator(
+			ctx,
+			info.key,
+			info.subnetID,
+			nodeID,
+			client.WithMemo(info.memo),
+			client.WithFeeKey(info.feeKey),
+		)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to remove %s: %w", nodeID, err)
+		}
+		removed = append(removed, nodeID)
+		color.Outf("{{magenta}}removed %s from subnet %s validator set{{/}} {{light-gray}}(took %v){{/}}\n", nodeID, info.subnetID, took)
+	}
+
+	remain, err := subnetValidators(cli, info.subnetID)
+	if err != nil {
+		return err
+	}
+	if len(remain) > 0 {
+		left := make([]string, 0, len(remain))
+		for nodeID := range remain {
+			left = append(left, nodeID.PrefixedString(constants.NodeIDPrefix))
+		}
+		sort.Strings(left)
+		return fmt.Errorf("%w: %v", errValidatorsRemain, left)
+	}
+
+	st.Retire(state.RetiredSubnet{
+		NetworkID: info.networkID,
+		SubnetID:  info.subnetID,
+		RetiredAt: time.Now().UTC(),
+		Removed:   removed,
+	})
+	if err := st.Save(statePath); err != nil {
+		return err
+	}
+	color.Outf("\n{{green}}decommissioned subnet %s{{/}} {{light-gray}}(recorded in %s){{/}}\n", info.subnetID, statePath)
+	return nil
+}
+
+func makeDecommissionTable(i *Info, nodeIDs []ids.ShortID) string {
+	buf, tb := BaseTableSetup(i)
+	tb.Append([]string{formatter.F("{{blue}}SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.subnetID)})
+	tb.Append([]string{formatter.F("{{red}}VALIDATORS TO REMOVE{{/}}"), formatter.F("{{light-gray}}{{bold}}%v{{/}}", nodeIDs)})
+	tb.Render()
+	return buf.String()
+}
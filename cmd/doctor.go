@@ -0,0 +1,291 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	api_info "github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/amount"
+	"github.com/ava-labs/subnet-cli/internal/clock"
+	"github.com/ava-labs/subnet-cli/internal/doctor"
+	"github.com/ava-labs/subnet-cli/internal/node"
+)
+
+var errUnknownOperation = errors.New("unknown operation")
+
+// doctorOperation is an operation planned with "--operation",
+// whose tx type and balance are checked.
+type doctorOperation struct {
+	txType string
+	fee    func(*api_info.GetTxFeeResponse) uint64
+	// true if the operation stakes "--stake-amount"
+	stake bool
+}
+
+// doctorOperationNames are the keys of "doctorOperations", in the order
+// of the wizard steps.
+var doctorOperationNames = []string{"create subnet", "create blockchain", "add validator", "add subnet-validator"}
+
+var doctorOperations = map[string]doctorOperation{
+	"create subnet": {
+		txType: "CreateSubnetTx",
+		fee:    func(f *api_info.GetTxFeeResponse) uint64 { return uint64(f.CreateSubnetTxFee) },
+	},
+	"create blockchain": {
+		txType: "CreateChainTx",
+		fee:    func(f *api_info.GetTxFeeResponse) uint64 { return uint64(f.CreateBlockchainTxFee) },
+	},
+	"add validator": {
+		txType: "AddValidatorTx",
+		fee:    func(f *api_info.GetTxFeeResponse) uint64 { return uint64(f.TxFee) },
+		stake:  true,
+	},
+	"add subnet-validator": {
+		txType: "AddSubnetValidatorTx",
+		fee:    func(f *api_info.GetTxFeeResponse) uint64 { return uint64(f.TxFee) },
+	},
+}
+
+// DoctorCommand implements "subnet-cli doctor" command.
+func DoctorCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Runs the pre-flight checks of an operation",
+		Long: `
+Checks the endpoint reachability, the node health, the compatibility of
+the node version with the txs built by subnet-cli, the local clock skew,
+the key file, the balance for the operation planned with --operation, and
+the subnet tracked by each of --node-urls, and prints a pass/fail checklist. Fails
+if any check fails, e.g., to run before a maintenance window.
+
+$ subnet-cli doctor \
+--public-uri=https://api.avax-test.network \
+--private-key-path=.insecure.ewoq.key \
+--operation="add subnet-validator" \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--node-urls=http://10.0.0.1:9650,http://10.0.0.2:9650
+
+`,
+		RunE: doctorFunc,
+	}
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", "", "private key file path, or key store URI, to check (empty to skip the key and balance checks)")
+	cmd.PersistentFlags().StringVar(&doctorOperationName, "operation", "", fmt.Sprintf("operation to check the tx type and the balance of (%s)", strings.Join(doctorOperationNames, ", ")))
+	cmd.PersistentFlags().Var(amount.NewValue(defaultStakeAmount, &stakeAmount), "stake-amount", "stake amount of the 'add validator' operation in nano AVAX, or with a denomination (e.g., '2000avax')")
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID the --node-urls must track")
+	cmd.PersistentFlags().StringSliceVar(&nodeURLs, "node-urls", nil, "URIs of the nodes to check")
+	cmd.PersistentFlags().DurationVar(&maxClockSkew, "max-clock-skew", 10*time.Second, "maximum allowed difference between the local and the node clocks")
+	return cmd
+}
+
+func doctorFunc(cmd *cobra.Command, args []string) error {
+	var op *doctorOperation
+	if doctorOperationName != "" {
+		o, ok := doctorOperations[doctorOperationName]
+		if !ok {
+			return fmt.Errorf("%w %q (expected one of %s)", errUnknownOperation, doctorOperationName, strings.Join(doctorOperationNames, ", "))
+		}
+		op = &o
+	}
+	var subnetID ids.ID
+	if subnetIDs != "" {
+		var err error
+		subnetID, err = ids.FromString(subnetIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	c := new(doctor.Checklist)
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		c.Fail("endpoint", "%s: %v", publicURI, err)
+		for _, check := range []string{"node health", "node version", "clock skew", "key", "balance"} {
+			c.Skip(check, "endpoint unreachable")
+		}
+	} else {
+		c.Pass("endpoint", "%s (network ID %d)", publicURI, cli.NetworkID())
+		checkNodeHealth(c, cli)
+		checkNodeVersion(c, cli, op)
+		checkDoctorClockSkew(c)
+		checkKeyBalance(c, cli, op)
+	}
+	checkTrackedSubnets(c, subnetID)
+
+	fmt.Fprint(formatter.ColorableStdOut, makeDoctorTable(c))
+	return c.Err()
+}
+
+// checkNodeHealth warns if the node of the endpoint reports a failing
+// health check, or does not expose its health API (e.g., public APIs).
+func checkNodeHealth(c *doctor.Checklist, cli client.Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	healthy, err := cli.Health().Healthy(ctx)
+	cancel()
+	switch {
+	case err != nil:
+		c.Warn("node health", "unavailable: %v", err)
+	case !healthy:
+		c.Warn("node health", "the node reports a failing health check")
+	default:
+		c.Pass("node health", "healthy")
+	}
+}
+
+// checkNodeVersion checks the node accepts the tx type of the operation,
+// and warns of the upgrades changing the txs built by subnet-cli.
+func checkNodeVersion(c *doctor.Checklist, cli client.Client, op *doctorOperation) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	caps, err := cli.P().Capabilities(ctx)
+	cancel()
+	if err != nil {
+		c.Fail("node version", "%v", err)
+		return
+	}
+	if op != nil {
+		if err := caps.Check(op.txType); err != nil {
+			c.Fail("node version", "%v", err)
+			return
+		}
+	}
+	active := make([]string, 0, len(caps.Active))
+	for _, u := range caps.Active {
+		active = append(active, u.Name)
+	}
+	switch {
+	case caps.DynamicFees:
+		c.Warn("node version", "%s (upgrades %v): the displayed fees are a lower bound", caps.NodeVersion, active)
+	case caps.PermissionlessValidators:
+		c.Warn("node version", "%s (upgrades %v): the txs of the node may not be decoded", caps.NodeVersion, active)
+	default:
+		c.Pass("node version", "%s", caps.NodeVersion)
+	}
+}
+
+func checkDoctorClockSkew(c *doctor.Checklist) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	skew, err := clock.Skew(ctx, publicURI)
+	cancel()
+	switch {
+	case err != nil:
+		c.Warn("clock skew", "%v", err)
+	case maxClockSkew > 0 && clock.Abs(skew) > maxClockSkew:
+		c.Fail("clock skew", "%v exceeds %v; sync the local clock (e.g., with NTP)", skew, maxClockSkew)
+	case clock.Abs(skew) > time.Second:
+		c.Warn("clock skew", "%v", skew)
+	default:
+		c.Pass("clock skew", "%v", skew)
+	}
+}
+
+// checkKeyBalance checks the key loads, and covers the fee and the stake
+// of the operation.
+func checkKeyBalance(c *doctor.Checklist, cli client.Client, op *doctorOperation) {
+	if privKeyPath == "" {
+		c.Skip("key", "no --private-key-path")
+		c.Skip("balance", "no --private-key-path")
+		return
+	}
+	k, err := loadSoftKey(cli.NetworkID())
+	if err != nil {
+		c.Fail("key", "%q: %v", privKeyPath, err)
+		c.Skip("balance", "no key")
+		return
+	}
+	defer k.Close()
+	c.Pass("key", "%s", k.P()[0])
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	pools, err := cli.P().Pools(ctx, k)
+	cancel()
+	if err != nil {
+		c.Fail("balance", "%v", err)
+		return
+	}
+	if op == nil {
+		c.Pass("balance", "%s AVAX unlocked (no --operation to check)", formatAVAX(pools.Unlocked))
+		return
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	fees, err := cli.Info().TxFee(ctx)
+	cancel()
+	if err != nil {
+		c.Fail("balance", "%v", err)
+		return
+	}
+	needed := op.fee(fees)
+	if op.stake {
+		needed += stakeAmount
+	}
+	if pools.Unlocked < needed {
+		c.Fail("balance", "%s AVAX unlocked, %q needs %s AVAX", formatAVAX(pools.Unlocked), doctorOperationName, formatAVAX(needed))
+		return
+	}
+	c.Pass("balance", "%s AVAX unlocked, %q needs %s AVAX", formatAVAX(pools.Unlocked), doctorOperationName, formatAVAX(needed))
+}
+
+// checkTrackedSubnets checks each node is ready, and tracks the subnet.
+func checkTrackedSubnets(c *doctor.Checklist, subnetID ids.ID) {
+	results := make([]pingResult, len(nodeURLs))
+	runPool(len(nodeURLs), len(nodeURLs), func(i int) {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		s, err := node.Ping(ctx, node.New(nodeURLs[i]))
+		results[i] = pingResult{uri: nodeURLs[i], status: s, err: err}
+	})
+	for _, r := range results {
+		check := "node " + r.uri
+		switch {
+		case r.err != nil:
+			c.Fail(check, "%v", r.err)
+		case !r.status.Healthy || !r.status.Bootstrapped:
+			c.Fail(check, "%s (%s) is not ready (healthy %v, bootstrapped %v)", r.status.NodeID, r.status.Version, r.status.Healthy, r.status.Bootstrapped)
+		case subnetID != ids.Empty && !containsString(r.status.TrackedSubnets, subnetID.String()):
+			c.Fail(check, "%s (%s) does not track %s (tracked %v)", r.status.NodeID, r.status.Version, subnetID, r.status.TrackedSubnets)
+		default:
+			c.Pass(check, "%s (%s) tracks %v", r.status.NodeID, r.status.Version, r.status.TrackedSubnets)
+		}
+	}
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func makeDoctorTable(c *doctor.Checklist) string {
+	buf := bytes.NewBuffer(nil)
+	tb := newTxTable(buf, []string{"check", "status", "detail"})
+	for _, r := range c.Results {
+		status := r.Status.String()
+		switch r.Status {
+		case doctor.Pass:
+			status = formatter.F("{{green}}%s{{/}}", status)
+		case doctor.Warn:
+			status = formatter.F("{{yellow}}%s{{/}}", status)
+		case doctor.Fail:
+			status = formatter.F("{{red}}%s{{/}}", status)
+		case doctor.Skip:
+			status = formatter.F("{{light-gray}}%s{{/}}", status)
+		}
+		tb.Append([]string{r.Check, status, r.Detail})
+	}
+	tb.Render()
+	return buf.String()
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/dustin/go-humanize"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/state"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// DrainCommand implements "subnet-cli drain" command.
+func DrainCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drain",
+		Short: "Sub-commands for scheduling the removal of subnet validators",
+	}
+	cmd.AddCommand(
+		newDrainValidatorCommand(),
+		newDrainListCommand(),
+		newDrainCancelCommand(),
+		newDrainRunCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringVar(&stateFilePath, "state-file", "", "local state file to record the scheduled removals in (default ~/.subnet-cli/state.json)")
+	return cmd
+}
+
+func newDrainListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists the scheduled removals of the network",
+		RunE:  drainListFunc,
+	}
+}
+
+func newDrainCancelCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "Cancels the scheduled removal of a subnet validator",
+		RunE:  drainCancelFunc,
+	}
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringVar(&drainNodeID, "node-id", "", "node ID of the subnet validator")
+	return cmd
+}
+
+// loadState loads the local state file of "--state-file", and returns
+// its path to save it.
+func loadState() (*state.State, string, error) {
+	p := stateFilePath
+	if p == "" {
+		var err error
+		p, err = state.DefaultPath()
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	st, err := state.Load(p)
+	if err != nil {
+		return nil, "", err
+	}
+	return st, p, nil
+}
+
+// parseDrainTarget parses "--subnet-id" and "--node-id".
+func parseDrainTarget() (ids.ID, ids.ShortID, error) {
+	subnetID, err := ids.FromString(subnetIDs)
+	if err != nil {
+		return ids.Empty, ids.ShortEmpty, err
+	}
+	nodeID, err := ids.ShortFromPrefixedString(drainNodeID, constants.NodeIDPrefix)
+	if err != nil {
+		return ids.Empty, ids.ShortEmpty, fmt.Errorf("invalid node ID %q: %w", drainNodeID, err)
+	}
+	return subnetID, nodeID, nil
+}
+
+func drainListFunc(cmd *cobra.Command, args []string) error {
+	st, _, err := loadState()
+	if err != nil {
+		return err
+	}
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+	ds := st.Scheduled(cli.NetworkID())
+	if len(ds) == 0 {
+		color.Outf("{{magenta}}no scheduled removals on network %d{{/}}\n", cli.NetworkID())
+		return nil
+	}
+	buf := bytes.NewBuffer(nil)
+	tb := newTxTable(buf, []string{"node ID", "subnet ID", "at", "min validators"})
+	for _, d := range ds {
+		tb.Append([]string{
+			labeled(d.NodeID.PrefixedString(constants.NodeIDPrefix)),
+			labeled(d.SubnetID.String()),
+			fmt.Sprintf("%s (%s)", d.At.Format(time.RFC3339), humanize.Time(d.At)),
+			fmt.Sprint(d.MinValidators),
+		})
+	}
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	return nil
+}
+
+func drainCancelFunc(cmd *cobra.Command, args []string) error {
+	subnetID, nodeID, err := parseDrainTarget()
+	if err != nil {
+		return err
+	}
+	st, p, err := loadState()
+	if err != nil {
+		return err
+	}
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+	if !st.Unschedule(cli.NetworkID(), subnetID, nodeID) {
+		return fmt.Errorf("%w: %s on subnet %s", errNoDrain, nodeID.PrefixedString(constants.NodeIDPrefix), subnetID)
+	}
+	if err := st.Save(p); err != nil {
+		return err
+	}
+	color.Outf("{{green}}canceled the removal of %s from subnet %s{{/}}\n", nodeID.PrefixedString(constants.NodeIDPrefix), subnetID)
+	return nil
+}
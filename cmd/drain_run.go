@@ -0,0 +1,140 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/poll"
+	"github.com/ava-labs/subnet-cli/internal/state"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newDrainRunCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Issues the scheduled removals once due",
+		Long: `
+Polls the P-Chain timestamp, and removes the subnet validators whose
+scheduled time is reached, until no removal remains scheduled on the
+network (e.g., run in the background, or as a service). With --once, it
+issues the due removals and exits (e.g., from cron).
+
+A removal is postponed while it would leave the subnet with fewer current
+validators than its --min-validators, and dropped if the node no longer
+validates the subnet.
+
+$ subnet-cli drain run \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--interval=5m
+
+`,
+		RunE: drainRunFunc,
+	}
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path, or key store URI (e.g., 'keychain://subnet-cli/ops', 'env://OPS_KEY')")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().DurationVar(&drainInterval, "interval", time.Minute, "interval to poll the P-Chain timestamp at")
+	cmd.PersistentFlags().BoolVar(&drainOnce, "once", false, "'true' to issue the due removals and exit, instead of waiting for the scheduled ones")
+	addMemoFlag(cmd)
+	addFeeKeyFlags(cmd)
+	return cmd
+}
+
+func drainRunFunc(cmd *cobra.Command, args []string) error {
+	st, p, err := loadState()
+	if err != nil {
+		return err
+	}
+	cli, info, err := InitClient(publicURI, true)
+	if err != nil {
+		return err
+	}
+	defer info.key.Close()
+	if err := checkTxType(cli, "RemoveSubnetValidatorTx"); err != nil {
+		return err
+	}
+
+	scheduled := len(st.Scheduled(info.networkID))
+	if scheduled == 0 {
+		color.Outf("{{magenta}}no scheduled removals on network %d{{/}}\n", info.networkID)
+		return nil
+	}
+	color.Outf("{{blue}}%d removal(s) scheduled on network %d{{/}}\n", scheduled, info.networkID)
+
+	check := func() (bool, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		now, err := cli.P().Client().GetTimestamp(ctx)
+		cancel()
+		if err != nil {
+			return false, err
+		}
+		for _, d := range st.Due(info.networkID, now) {
+			if err := drainDue(cli, info, st, d); err != nil {
+				return false, err
+			}
+			if err := st.Save(p); err != nil {
+				return false, err
+			}
+		}
+		return len(st.Scheduled(info.networkID)) == 0, nil
+	}
+	if drainOnce {
+		_, err := check()
+		return err
+	}
+	_, err = poll.New(drainInterval).Poll(context.Background(), check)
+	return err
+}
+
+// drainDue removes the validator of the due drain, and unschedules it,
+// unless the removal would drop the subnet below its minimum validators.
+func drainDue(cli client.Client, info *Info, st *state.State, d state.Drain) error {
+	nodeID := d.NodeID.PrefixedString(constants.NodeIDPrefix)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	vs, err := cli.P().GetValidators(ctx, d.SubnetID)
+	cancel()
+	if err != nil {
+		return err
+	}
+	validating, remain := false, 0
+	for _, v := range vs {
+		if v.NodeID == d.NodeID {
+			validating = true
+			continue
+		}
+		remain++
+	}
+	if !validating {
+		st.Unschedule(d.NetworkID, d.SubnetID, d.NodeID)
+		color.Outf("{{yellow}}%s no longer validates subnet %s, dropping its removal{{/}}\n", nodeID, d.SubnetID)
+		return nil
+	}
+	if remain < d.MinValidators {
+		color.Outf("{{yellow}}postponing the removal of %s: subnet %s would be left with %d of %d current validators{{/}}\n", nodeID, d.SubnetID, remain, d.MinValidators)
+		return nil
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	took, err := cli.P().RemoveSubnetValidator(
+		ctx,
+		info.key,
+		d.SubnetID,
+		d.NodeID,
+		client.WithMemo(info.memo),
+		client.WithFeeKey(info.feeKey),
+	)
+	cancel()
+	if err != nil {
+		return err
+	}
+	st.Unschedule(d.NetworkID, d.SubnetID, d.NodeID)
+	color.Outf("{{magenta}}removed %s from subnet %s validator set{{/}} {{light-gray}}(took %v){{/}}\n", nodeID, d.SubnetID, took)
+	return nil
+}
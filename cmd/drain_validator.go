@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/state"
+	"github.com/ava-labs/subnet-cli/internal/validate"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var (
+	errNoDrain       = errors.New("no scheduled removal")
+	errDrainInPast   = errors.New("--at is not after the P-Chain time")
+	errDrainAfterEnd = errors.New("validation ends before --at")
+)
+
+func newDrainValidatorCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator",
+		Short: "Schedules the removal of a subnet validator",
+		Long: `
+Schedules the removal of a current subnet validator at a future time, and
+records it in the local state file. "drain run" issues the removal once the
+P-Chain timestamp (not the local clock) reaches --at, so the validator keeps
+validating until the chain itself gets there. A relative --at is from the
+P-Chain timestamp too.
+
+The removal is postponed while it would leave the subnet with fewer than
+--min-validators current validators (e.g., until a replacement validator
+starts validating). Scheduling the same validator again reschedules it.
+
+$ subnet-cli drain validator \
+--public-uri=http://localhost:52250 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--node-id="NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH" \
+--at=+2d \
+--min-validators=3
+$ subnet-cli drain run \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250
+
+`,
+		RunE: drainValidatorFunc,
+	}
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringVar(&drainNodeID, "node-id", "", "node ID of the subnet validator to remove")
+	cmd.PersistentFlags().StringVar(&drainAt, "at", "", "P-Chain time to remove the validator at (RFC3339 timestamp, or relative to the P-Chain time, e.g., '+2d')")
+	cmd.PersistentFlags().IntVar(&minValidators, "min-validators", 1, "minimum number of current validators to keep on the subnet, postponing the removal until then")
+	return cmd
+}
+
+func drainValidatorFunc(cmd *cobra.Command, args []string) error {
+	subnetID, nodeID, err := parseDrainTarget()
+	if err != nil {
+		return err
+	}
+	st, p, err := loadState()
+	if err != nil {
+		return err
+	}
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	now, err := cli.P().Client().GetTimestamp(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	at, err := validate.Time(drainAt, now)
+	if err != nil {
+		return err
+	}
+	if !at.After(now) {
+		return fmt.Errorf("%w: %s <= %s", errDrainInPast, at.Format(time.RFC3339), now.Format(time.RFC3339))
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	_, end, err := cli.P().GetValidator(ctx, subnetID, nodeID)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("%s on subnet %s: %w", nodeID.PrefixedString(constants.NodeIDPrefix), subnetID, err)
+	}
+	if !at.Before(end) {
+		return fmt.Errorf("%w: %s ends at %s", errDrainAfterEnd, nodeID.PrefixedString(constants.NodeIDPrefix), end.Format(time.RFC3339))
+	}
+
+	st.Schedule(state.Drain{
+		NetworkID:     cli.NetworkID(),
+		SubnetID:      subnetID,
+		NodeID:        nodeID,
+		At:            at,
+		MinValidators: minValidators,
+	})
+	if err := st.Save(p); err != nil {
+		return err
+	}
+	color.Outf("{{green}}scheduled the removal of %s from subnet %s at %s{{/}} {{light-gray}}(in %v of P-Chain time){{/}}\n",
+		nodeID.PrefixedString(constants.NodeIDPrefix),
+		subnetID,
+		at.Format(time.RFC3339),
+		at.Sub(now).Round(time.Minute),
+	)
+	color.Outf("{{light-gray}}issue it with: subnet-cli drain run --public-uri=%s{{/}}\n", publicURI)
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/config"
+	"github.com/ava-labs/subnet-cli/internal/endpoint"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var (
+	errInvalidEndpoint  = errors.New("invalid --endpoint (must be 'auto')")
+	errEndpointConflict = errors.New("--endpoint conflicts with --public-uri and --private-uri")
+)
+
+// endpointURIFlags lists the flags set to the endpoint selected with
+// "--endpoint auto".
+var endpointURIFlags = []string{"public-uri", "private-uri"}
+
+// resolveEndpoint probes the known endpoints of "--network" (default
+// to fuji) and of the config file, and sets the endpoint flags of [cmd]
+// to the healthy one of the lowest latency.
+func resolveEndpoint(cmd *cobra.Command, cfg *config.Config) error {
+	if endpointMode != endpoint.Auto {
+		return fmt.Errorf("%w: %q", errInvalidEndpoint, endpointMode)
+	}
+	var flags []string
+	for _, name := range endpointURIFlags {
+		f := cmd.Flag(name)
+		if f == nil {
+			continue
+		}
+		if f.Changed {
+			return errEndpointConflict
+		}
+		flags = append(flags, name)
+	}
+	if len(flags) == 0 {
+		// the command has no endpoint
+		return nil
+	}
+
+	network := expectedNetwork
+	if network == "" {
+		network = constants.FujiName
+	}
+	networkID, err := constants.NetworkID(network)
+	if err != nil {
+		return err
+	}
+	extra := cfg.Registry[constants.NetworkName(networkID)]
+	extra = append(extra, cfg.Registry[strconv.FormatUint(uint64(networkID), 10)]...)
+	uris := endpoint.Candidates(networkID, extra)
+
+	color.Outf("{{blue}}probing %d endpoints of %s...{{/}}\n", len(uris), constants.NetworkName(networkID))
+	ps := endpoint.ProbeAll(context.Background(), uris, networkID, endpoint.ProbeInfo)
+	for _, p := range ps {
+		if p.Err != nil {
+			color.Outf("{{light-gray}}  %s: unhealthy (%v){{/}}\n", p.URI, p.Err)
+			continue
+		}
+		color.Outf("{{light-gray}}  %s: %v{{/}}\n", p.URI, p.Latency.Round(time.Millisecond))
+	}
+	best, err := endpoint.Best(ps)
+	if err != nil {
+		return fmt.Errorf("%w for %s", err, constants.NetworkName(networkID))
+	}
+	color.Outf("{{green}}using %s{{/}}\n", best.URI)
+	for _, name := range flags {
+		if err := cmd.Flags().Set(name, best.URI); err != nil {
+			return err
+		}
+	}
+	return nil
+}
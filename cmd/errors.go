@@ -5,6 +5,174 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/allowlist"
+	"github.com/ava-labs/subnet-cli/internal/audit"
+	"github.com/ava-labs/subnet-cli/internal/doctor"
+	"github.com/ava-labs/subnet-cli/internal/fork"
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/internal/passphrase"
+	"github.com/ava-labs/subnet-cli/internal/platformvm"
+	"github.com/ava-labs/subnet-cli/internal/spend"
+	"github.com/ava-labs/subnet-cli/internal/validate"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// Exit codes of subnet-cli by failure class, for the scripts to branch on
+// instead of matching the error messages. They are stable across the
+// releases: a new class gets a new code (ref. "Exit codes" in README).
+const (
+	ExitOK                = 0
+	ExitFailure           = 1 // any other failure
+	ExitUsage             = 2 // invalid flags or arguments
+	ExitInsufficientFunds = 3 // the key cannot cover the fees or the stake
+	ExitUnauthorized      = 4 // the keys cannot sign (e.g., not the control keys)
+	ExitNetwork           = 5 // the endpoint is unreachable, or timed out
+	ExitTxRejected        = 6 // the node rejected or dropped the tx
+	ExitRefused           = 7 // declined at a prompt, or refused by a local policy
+	ExitCheckFailed       = 8 // a check command found a problem (e.g., "doctor")
 )
 
-var ErrInsufficientFunds = errors.New("insufficient funds")
+var errUsage = errors.New("invalid usage")
+
+// ExitCode returns the exit code of the failure class of [err], "ExitOK"
+// for no error.
+func ExitCode(err error) int {
+	var (
+		funds *client.ErrInsufficientFunds
+		auth  *client.ErrNotAuthorized
+		soon  *client.ErrStartTimeTooSoon
+	)
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, errUsage), errors.Is(err, validate.ErrInvalid):
+		return ExitUsage
+	case errors.As(err, &funds), errors.Is(err, client.ErrAmountBelowFee):
+		return ExitInsufficientFunds
+	case errors.As(err, &auth), errors.Is(err, client.ErrCantSign),
+		errors.Is(err, key.ErrWatchOnly),
+		errors.Is(err, passphrase.ErrWrong), errors.Is(err, passphrase.ErrTooManyAttempts):
+		return ExitUnauthorized
+	case errors.Is(err, errTxDeclined), errors.Is(err, errAborted),
+		errors.Is(err, allowlist.ErrNotAllowed),
+		errors.Is(err, spend.ErrSoftLimit), errors.Is(err, spend.ErrHardLimit),
+		errors.Is(err, errLargeFeeOverride),
+		errors.Is(err, errWrongNetwork), errors.Is(err, errMainnetNotExpected):
+		return ExitRefused
+	case errors.Is(err, client.ErrTxRejected), errors.Is(err, platformvm.ErrAbortedDropped),
+		errors.As(err, &soon),
+		errors.Is(err, client.ErrTxTooLarge), errors.Is(err, fork.ErrUnsupportedTx):
+		return ExitTxRejected
+	case errors.Is(err, doctor.ErrFailed), errors.Is(err, errUnhealthy),
+		errors.Is(err, errNodesNotReady), errors.Is(err, errSnapshotDrift),
+		errors.Is(err, errGenesisMismatch), errors.Is(err, audit.ErrTampered),
+		errors.Is(err, errReceiptNetwork), errors.Is(err, errReceiptNotCommitted):
+		return ExitCheckFailed
+	case client.IsNetworkError(err):
+		return ExitNetwork
+	default:
+		return ExitFailure
+	}
+}
+
+// markUsageErrors wraps the flag and argument errors of [cmd] and its
+// subcommands with "errUsage", for "ExitUsage".
+func markUsageErrors(cmd *cobra.Command) {
+	if !cmd.HasParent() {
+		// inherited by the subcommands
+		cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+			return fmt.Errorf("%w: %v", errUsage, err)
+		})
+	}
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(c *cobra.Command, a []string) error {
+			if err := args(c, a); err != nil {
+				return fmt.Errorf("%w: %v", errUsage, err)
+			}
+			return nil
+		}
+	}
+	for _, c := range cmd.Commands() {
+		markUsageErrors(c)
+	}
+}
+
+// errorCategory returns the category of the typed error for the usage
+// stats (e.g., "insufficient_funds"), "other" for the untyped ones, and
+// empty for no error. Never the message, which may hold addresses.
+func errorCategory(err error) string {
+	var (
+		funds *client.ErrInsufficientFunds
+		auth  *client.ErrNotAuthorized
+		soon  *client.ErrStartTimeTooSoon
+	)
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &funds):
+		return "insufficient_funds"
+	case errors.As(err, &auth):
+		return "not_authorized"
+	case errors.As(err, &soon):
+		return "start_time_too_soon"
+	case errors.Is(err, fork.ErrUnsupportedTx):
+		return "unsupported_tx"
+	case errors.Is(err, fork.ErrUnsupportedFormat):
+		return "unsupported_format"
+	case errors.Is(err, allowlist.ErrNotAllowed):
+		return "not_allowed"
+	case errors.Is(err, client.ErrTxTooLarge):
+		return "tx_too_large"
+	case errors.Is(err, key.ErrWatchOnly):
+		return "watch_only"
+	default:
+		return "other"
+	}
+}
+
+// printHint prints the actionable remediation of the typed error, if any.
+func printHint(err error) {
+	var (
+		funds *client.ErrInsufficientFunds
+		auth  *client.ErrNotAuthorized
+		soon  *client.ErrStartTimeTooSoon
+	)
+	switch {
+	case errors.As(err, &funds):
+		if funds.Needed > funds.Have {
+			color.Outf("{{yellow}}hint: fund the key with at least %.9f more AVAX{{/}}\n", float64(funds.Needed-funds.Have)/float64(units.Avax))
+		} else {
+			color.Outf("{{yellow}}hint: fund the key, or pay the fees with another key with --fee-key-path{{/}}\n")
+		}
+		color.Outf("{{yellow}}hint: on Fuji, get test funds at https://faucet.avax-test.network{{/}}\n")
+	case errors.As(err, &auth):
+		switch {
+		case !auth.Locktime.IsZero():
+			color.Outf("{{yellow}}hint: retry after %s, once the control keys are unlocked{{/}}\n", auth.Locktime.Format(time.RFC3339))
+		case len(auth.MissingKeys) > 0:
+			color.Outf("{{yellow}}hint: sign with the keys of %s (e.g., via \"subnet-cli multisig\"){{/}}\n", strings.Join(auth.MissingKeys, ", "))
+		default:
+			color.Outf("{{yellow}}hint: check that --private-key-path or --ledger holds the subnet control keys{{/}}\n")
+		}
+	case errors.As(err, &soon):
+		color.Outf("{{yellow}}hint: increase --validate-start-buffer, or check the local clock{{/}}\n")
+	case errors.Is(err, fork.ErrUnsupportedTx):
+		color.Outf("{{yellow}}hint: add the validator with a BLS key via a tool supporting the network upgrades (e.g., avalanche-cli){{/}}\n")
+	case errors.Is(err, fork.ErrUnsupportedFormat):
+		color.Outf("{{yellow}}hint: the node is newer than the tx formats known to subnet-cli; upgrade subnet-cli (e.g., \"subnet-cli update\"), or query an older node{{/}}\n")
+	case errors.Is(err, allowlist.ErrNotAllowed):
+		color.Outf("{{yellow}}hint: add the addresses to %q, or set --override-allowlist{{/}}\n", allowlistPath)
+	case errors.Is(err, client.ErrTxTooLarge):
+		color.Outf("{{yellow}}hint: merge the UTXOs of the key with \"subnet-cli utxos consolidate\"{{/}}\n")
+	case errors.Is(err, key.ErrWatchOnly):
+		color.Outf("{{yellow}}hint: build the unsigned tx with \"subnet-cli multisig propose --xpub\", and sign it with \"subnet-cli multisig sign\" where the key is{{/}}\n")
+	}
+}
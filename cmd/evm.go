@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// EVMCommand implements "subnet-cli evm" command.
+func EVMCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "evm",
+		Short: "Sub-commands for the Subnet-EVM chains",
+	}
+	cmd.AddCommand(
+		newEVMDeployCommand(),
+	)
+	return cmd
+}
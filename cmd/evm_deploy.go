@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/evm"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var (
+	errEmptyChainRPC = errors.New("empty --chain-rpc")
+	errEmptyBytecode = errors.New("empty --bytecode")
+)
+
+func newEVMDeployCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deploy",
+		Short: "Deploys a contract to an EVM chain",
+		Long: `
+Deploys a contract (e.g., a multicall or a teleporter contract) to an EVM
+chain, as a post-provisioning step of a freshly created Subnet-EVM chain.
+The key of "--private-key-path" signs and pays for the deployment on the
+EVM chain, from its EVM address (the same secp256k1 key as the P-Chain one).
+
+"--bytecode" is the contract creation bytecode in hex, or the compiled
+artifact JSON of Hardhat or Foundry. The ABI-encoded constructor arguments,
+if any, are appended with "--constructor-args".
+
+$ subnet-cli evm deploy \
+--chain-rpc=http://localhost:9650/ext/bc/[BLOCKCHAIN ID]/rpc \
+--bytecode=artifacts/Multicall.json \
+--private-key-path=.subnet-cli.pk
+
+`,
+		RunE: evmDeployFunc,
+	}
+	cmd.PersistentFlags().StringVar(&evmChainRPC, "chain-rpc", "", "JSON-RPC endpoint of the EVM chain")
+	cmd.PersistentFlags().StringVar(&bytecodePath, "bytecode", "", "file of the contract creation bytecode (hex, or Hardhat/Foundry artifact JSON)")
+	cmd.PersistentFlags().StringVar(&constructorArgs, "constructor-args", "", "ABI-encoded constructor arguments in hex, appended to the bytecode")
+	cmd.PersistentFlags().Uint64Var(&evmGasLimit, "gas-limit", 0, "gas limit of the deployment tx, zero to estimate")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path, or key store URI (e.g., 'keychain://subnet-cli/ops', 'env://OPS_KEY')")
+	return cmd
+}
+
+func evmDeployFunc(cmd *cobra.Command, args []string) error {
+	if evmChainRPC == "" {
+		return errEmptyChainRPC
+	}
+	if bytecodePath == "" {
+		return errEmptyBytecode
+	}
+	b, err := ioutil.ReadFile(bytecodePath)
+	if err != nil {
+		return err
+	}
+	code, err := evm.ParseBytecode(b)
+	if err != nil {
+		return err
+	}
+	if constructorArgs != "" {
+		cargs, err := hex.DecodeString(strings.TrimPrefix(constructorArgs, "0x"))
+		if err != nil {
+			return fmt.Errorf("invalid --constructor-args: %w", err)
+		}
+		code = append(code, cargs...)
+	}
+
+	// the network ID only formats the (unused) P-Chain address
+	k, err := loadSoftKey(constants.LocalID)
+	if err != nil {
+		return err
+	}
+	defer k.Close()
+	privKey := k.Key()
+	pub := &privKey.ToECDSA().PublicKey
+
+	cli := evm.Dial(evmChainRPC)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	chainID, err := cli.ChainID(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	tb := newTxTable(buf, nil)
+	tb.Append([]string{formatter.F("{{cyan}}{{bold}}CHAIN RPC{{/}}"), formatter.F("{{light-gray}}%s{{/}}", evmChainRPC)})
+	tb.Append([]string{formatter.F("{{cyan}}{{bold}}CHAIN ID{{/}}"), formatter.F("{{light-gray}}%s{{/}}", chainID)})
+	tb.Append([]string{formatter.F("{{blue}}DEPLOYER{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", evm.EncodeHex(evm.Address(pub)))})
+	tb.Append([]string{formatter.F("{{blue}}BYTECODE{{/}}"), formatter.F("{{light-gray}}%d bytes{{/}}", len(code))})
+	tb.Render()
+	msg := buf.String()
+	if enablePrompt {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to deploy the contract, should we continue?{{/}}\n") + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+	if !confirm("{{green}}Yes, let's deploy!{{/}}") {
+		return errTxDeclined
+	}
+
+	color.Outf("\n{{blue}}Deploying the contract...{{/}}\n")
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	d, err := evm.Deploy(ctx, cli, pub, privKey.SignHash, code, evmGasLimit, pollInterval)
+	cancel()
+	if err != nil {
+		return err
+	}
+	color.Outf("{{green}}deployed the contract %s{{/}} (tx %s, block %d, gas used %d)\n",
+		evm.EncodeHex(d.Contract),
+		d.Receipt.TxHash,
+		d.Receipt.BlockNumber,
+		d.Receipt.GasUsed,
+	)
+	return nil
+}
@@ -0,0 +1,23 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ExportCommand implements "subnet-cli export" command.
+func ExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Sub-commands for exporting resources",
+	}
+	cmd.AddCommand(
+		newExportValidatorsCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringVar(&exportFormat, "format", "csv", "output format ('csv' or 'json')")
+	cmd.PersistentFlags().StringVar(&exportOutputPath, "output-path", "", "file path to write to (default to stdout)")
+	return cmd
+}
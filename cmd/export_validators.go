@@ -0,0 +1,167 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newExportValidatorsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validators [options]",
+		Short: "Exports the current validator set",
+		Long: `
+Exports the current validator set of a subnet (or the primary network
+if no subnet ID is given) with node IDs, weights, validation periods,
+and uptimes.
+
+$ subnet-cli export validators \
+--public-uri=http://localhost:52250 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--format=csv \
+--output-path=validators.csv
+
+`,
+		RunE: exportValidatorsFunc,
+	}
+
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID, default to primary network)")
+	return cmd
+}
+
+var errInvalidExportFormat = errors.New("invalid export format")
+
+type exportedValidator struct {
+	NodeID string    `json:"nodeId"`
+	Weight uint64    `json:"weight"`
+	Start  time.Time `json:"startTime"`
+	End    time.Time `json:"endTime"`
+	// Uptime is in percent, reported by the queried node
+	// for the node's primary network validation.
+	Uptime    float64 `json:"uptimePercent"`
+	Connected bool    `json:"connected"`
+}
+
+func exportValidatorsFunc(cmd *cobra.Command, args []string) error {
+	if exportFormat != "csv" && exportFormat != "json" {
+		return fmt.Errorf("%w %q", errInvalidExportFormat, exportFormat)
+	}
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+	subnetID := ids.Empty
+	if subnetIDs != "" {
+		subnetID, err = ids.FromString(subnetIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	evs, err := getExportedValidators(cli, subnetID)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if exportOutputPath != "" {
+		f, err := os.Create(exportOutputPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	switch exportFormat {
+	case "csv":
+		err = writeValidatorsCSV(w, evs)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(evs)
+	}
+	if err != nil {
+		return err
+	}
+	if exportOutputPath != "" {
+		color.Outf("{{green}}exported %d validator(s) to %q{{/}}\n", len(evs), exportOutputPath)
+	}
+	return nil
+}
+
+func getExportedValidators(cli client.Client, subnetID ids.ID) ([]exportedValidator, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	vs, err := cli.P().GetValidators(ctx, subnetID)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	// uptimes are only tracked for the primary network validation
+	primary := vs
+	if subnetID != ids.Empty {
+		ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+		primary, err = cli.P().GetValidators(ctx, ids.Empty)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+	}
+	uptimes := make(map[ids.ShortID]client.Validator, len(primary))
+	for _, v := range primary {
+		uptimes[v.NodeID] = v
+	}
+
+	evs := make([]exportedValidator, 0, len(vs))
+	for _, v := range vs {
+		pv := uptimes[v.NodeID]
+		evs = append(evs, exportedValidator{
+			NodeID:    v.NodeID.PrefixedString(constants.NodeIDPrefix),
+			Weight:    v.Weight,
+			Start:     v.Start.UTC(),
+			End:       v.End.UTC(),
+			Uptime:    pv.Uptime * 100,
+			Connected: pv.Connected,
+		})
+	}
+	sort.Slice(evs, func(i, j int) bool { return evs[i].NodeID < evs[j].NodeID })
+	return evs, nil
+}
+
+func writeValidatorsCSV(w io.Writer, evs []exportedValidator) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"node_id", "weight", "start_time", "end_time", "uptime_percent", "connected"}); err != nil {
+		return err
+	}
+	for _, ev := range evs {
+		if err := cw.Write([]string{
+			ev.NodeID,
+			strconv.FormatUint(ev.Weight, 10),
+			ev.Start.Format(time.RFC3339),
+			ev.End.Format(time.RFC3339),
+			strconv.FormatFloat(ev.Uptime, 'f', 4, 64),
+			strconv.FormatBool(ev.Connected),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
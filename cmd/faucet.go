@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/faucet"
+	"github.com/ava-labs/subnet-cli/internal/poll"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// FaucetCommand implements "subnet-cli faucet" command.
+func FaucetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "faucet",
+		Short: "Requests test AVAX from the Fuji faucet",
+		Long: `
+Requests test AVAX from the public Fuji faucet, and waits until the
+funds are visible. Funds the P-Chain address of the key by default.
+The faucet may require a captcha token (solve the captcha on the
+faucet website, and copy the response token).
+
+$ subnet-cli faucet \
+--private-key-path=.subnet-cli.pk \
+--captcha-token=[TOKEN]
+
+$ subnet-cli faucet \
+--chain=C \
+--address=0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC \
+--captcha-token=[TOKEN]
+
+`,
+		RunE: faucetFunc,
+	}
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path (ignored if --address is set)")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to derive the addresses (ignored if --address is set)")
+	cmd.PersistentFlags().StringVar(&pAddress, "address", "", "address to fund, without loading any key (required for the C-Chain)")
+	cmd.PersistentFlags().StringVar(&faucetURL, "faucet-url", faucet.DefaultURL, "URL of the faucet")
+	cmd.PersistentFlags().StringVar(&faucetChain, "chain", "P", "chain to fund ('P' or 'C')")
+	cmd.PersistentFlags().StringVar(&captchaToken, "captcha-token", "", "captcha response token for the faucet")
+	cmd.PersistentFlags().BoolVar(&faucetWait, "wait", true, "'true' to wait until the funds are visible")
+	return cmd
+}
+
+var (
+	errNotFuji           = errors.New("faucet only funds the Fuji network")
+	errInvalidChain      = errors.New("invalid chain")
+	errEmptyEVMAddress   = errors.New("--address is required for the C-Chain")
+	errInvalidEVMAddress = errors.New("invalid C-Chain address")
+)
+
+func faucetFunc(cmd *cobra.Command, args []string) error {
+	chain := strings.ToUpper(faucetChain)
+	if chain != "P" && chain != "C" {
+		return fmt.Errorf("%w %q", errInvalidChain, faucetChain)
+	}
+	if chain == "C" {
+		if pAddress == "" {
+			return errEmptyEVMAddress
+		}
+		if !strings.HasPrefix(pAddress, "0x") || len(pAddress) != 42 {
+			return fmt.Errorf("%w %q", errInvalidEVMAddress, pAddress)
+		}
+	}
+	loadKey := pAddress == ""
+	cli, info, err := InitClient(publicURI, loadKey)
+	if err != nil {
+		return err
+	}
+	if loadKey {
+		defer info.key.Close()
+	}
+	if cli.NetworkID() != constants.FujiID && faucetURL == faucet.DefaultURL {
+		return fmt.Errorf("%w (connected to %q)", errNotFuji, info.networkName)
+	}
+
+	addr := pAddress
+	if loadKey {
+		addr = info.key.P()[0]
+	} else if chain == "P" {
+		if _, err := ParsePAddress(addr); err != nil {
+			return err
+		}
+	}
+
+	// snapshot the balance before the request to detect the funding
+	var (
+		pBalance uint64
+		cBalance *big.Int
+		rpcURL   = strings.TrimSuffix(publicURI, "/") + "/ext/bc/C/rpc"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	if chain == "P" {
+		pBalance, err = pChainBalance(ctx, cli, addr)
+	} else {
+		cBalance, err = faucet.EVMBalance(ctx, rpcURL, addr)
+	}
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	color.Outf("\n{{blue}}Requesting test AVAX for %s from %s...{{/}}\n", addr, faucetURL)
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	resp, err := faucet.Send(ctx, faucetURL, faucet.Request{
+		Address: addr,
+		Chain:   chain,
+		Token:   captchaToken,
+	})
+	cancel()
+	if err != nil {
+		return err
+	}
+	color.Outf("{{magenta}}%s{{/}} {{light-gray}}(tx %s){{/}}\n", resp.Message, resp.TxHash)
+	if !faucetWait {
+		return nil
+	}
+
+	color.Outf("{{blue}}Waiting for the funds to be visible...{{/}}\n")
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	took, err := poll.New(pollInterval).Poll(ctx, func() (bool, error) {
+		if chain == "P" {
+			b, err := pChainBalance(ctx, cli, addr)
+			if err != nil {
+				return false, err
+			}
+			return b > pBalance, nil
+		}
+		b, err := faucet.EVMBalance(ctx, rpcURL, addr)
+		if err != nil {
+			return false, err
+		}
+		return b.Cmp(cBalance) > 0, nil
+	})
+	cancel()
+	if err != nil {
+		return err
+	}
+	color.Outf("{{green}}funds received on %s{{/}} {{light-gray}}(took %v){{/}}\n", addr, took.Round(time.Second))
+	return nil
+}
+
+func pChainBalance(ctx context.Context, cli client.Client, addr string) (uint64, error) {
+	resp, err := cli.P().Client().GetBalance(ctx, []string{addr})
+	if err != nil {
+		return 0, err
+	}
+	return uint64(resp.Balance), nil
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/amount"
+	"github.com/ava-labs/subnet-cli/internal/fees"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var errLargeFeeOverride = errors.New("fee override above --fee-override-limit")
+
+// checkFeeOverride prints the overridden fees along with the ones reported
+// by the network, and asks to confirm twice the fees above
+// "--fee-override-limit", refused with the prompts disabled.
+func checkFeeOverride(cli client.Client, o fees.Override) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	fi, err := cli.Info().Client().GetTxFee(ctx)
+	cancel()
+	if err != nil {
+		// e.g., a custom network not reporting its fee config
+		zap.L().Debug("failed to get the fee config of the network", zap.Error(err))
+		fi = nil
+	}
+	network := fees.Override{}.Apply(fi)
+	for _, f := range []struct {
+		name    string
+		network uint64
+	}{
+		{fees.Tx, uint64(network.TxFee)},
+		{fees.CreateSubnet, uint64(network.CreateSubnetTxFee)},
+		{fees.CreateBlockchain, uint64(network.CreateBlockchainTxFee)},
+	} {
+		v, ok := o[f.name]
+		if !ok {
+			continue
+		}
+		reported := "unknown"
+		if fi != nil {
+			reported = amount.Format(f.network)
+		}
+		color.Outf("{{yellow}}%s fee overridden to %s (the network reports %s){{/}}\n", f.name, amount.Format(v), reported)
+	}
+
+	above := o.Above(feeOverrideLimit)
+	if len(above) == 0 {
+		return nil
+	}
+	max := uint64(0)
+	for _, name := range above {
+		if o[name] > max {
+			max = o[name]
+		}
+	}
+	if !enablePrompt {
+		return fmt.Errorf("%w (%s): confirm them with the prompts enabled, or raise the limit", errLargeFeeOverride, strings.Join(above, ", "))
+	}
+	color.Outf("{{red}}{{bold}}The %s fee override is above %s.{{/}}\n", strings.Join(above, ", "), amount.Format(feeOverrideLimit))
+	if !confirm("{{green}}Yes, {{bold}}{{underline}}I want to pay up to " + amount.Format(max) + " per tx{{/}}{{green}}!{{/}}") {
+		return errLargeFeeOverride
+	}
+	phrase := amount.Format(max)
+	s, err := prompter.Input(`Type "` + phrase + `" to confirm the fee override`)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(s) != phrase {
+		return fmt.Errorf("%w: confirmation mismatch", errLargeFeeOverride)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// HealthCommand implements "subnet-cli health" command.
+func HealthCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "health",
+		Short: "Sub-commands for checking the health of resources",
+	}
+	cmd.AddCommand(
+		newHealthValidatorsCommand(),
+	)
+	return cmd
+}
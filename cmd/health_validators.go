@@ -0,0 +1,183 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/health"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// minimum uptime (in percent) for the primary network validators to be
+// rewarded
+const defaultMinUptime = 80
+
+func newHealthValidatorsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validators",
+		Short: "Checks the uptime and connectivity of the validators",
+		Long: `
+Queries the uptime and connectivity of each validator of the subnet
+(or the primary network if no subnet ID is given), as observed by each of
+the endpoints. Fails if any validator is below the minimum uptime on
+average, or not connected to the majority of the endpoints, so that it can
+be used for cron alerts. The pending validators (added, but not started
+yet) are listed with the countdown to their start, and not checked.
+
+$ subnet-cli health validators \
+--endpoints=https://api.avax-test.network,http://localhost:9650 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--min-uptime=80
+
+`,
+		RunE: healthValidatorsFunc,
+	}
+	cmd.PersistentFlags().StringSliceVar(&endpoints, "endpoints", []string{"https://api.avax-test.network"}, "URIs of the avalanche network endpoints to observe from")
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID, default to primary network)")
+	cmd.PersistentFlags().Float64Var(&minUptime, "min-uptime", defaultMinUptime, "minimum uptime in percent (80 is the reward threshold)")
+	return cmd
+}
+
+var (
+	errNoEndpoint = errors.New("no endpoint could be queried")
+	errUnhealthy  = errors.New("unhealthy validators")
+)
+
+func healthValidatorsFunc(cmd *cobra.Command, args []string) error {
+	subnetID := ids.Empty
+	if subnetIDs != "" {
+		var err error
+		subnetID, err = ids.FromString(subnetIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	color.Outf("\n{{blue}}Checking validators from %d endpoint(s)...{{/}}\n", len(endpoints))
+	var obs []health.Observation
+	pending := make(map[ids.ShortID]client.Validator)
+	queried := 0
+	for _, ep := range endpoints {
+		o, p, err := observe(ep, subnetID)
+		if err != nil {
+			// an endpoint being down should not hide the others
+			zap.L().Warn("failed to query endpoint", zap.String("endpoint", ep), zap.Error(err))
+			color.Outf("{{yellow}}failed to query %s: %v{{/}}\n", ep, err)
+			continue
+		}
+		queried++
+		obs = append(obs, o...)
+		for _, v := range p {
+			pending[v.NodeID] = v
+		}
+	}
+	if queried == 0 {
+		return errNoEndpoint
+	}
+
+	statuses := health.Evaluate(obs, minUptime/100)
+	fmt.Fprint(formatter.ColorableStdOut, makeHealthTable(statuses))
+	sorted := make([]client.Validator, 0, len(pending))
+	for _, v := range pending {
+		sorted = append(sorted, v)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+	for _, v := range sorted {
+		color.Outf("{{yellow}}%s is pending, starting at %s (not checked){{/}}\n", v.NodeID.PrefixedString(constants.NodeIDPrefix), formatPendingStart(v.Start))
+	}
+	if n := health.Unhealthy(statuses); n > 0 {
+		return fmt.Errorf("%w: %d of %d below %.0f%% uptime or disconnected", errUnhealthy, n, len(statuses), minUptime)
+	}
+	color.Outf("{{green}}all %d validator(s) healthy{{/}}\n", len(statuses))
+	return nil
+}
+
+// observe returns the uptime and connectivity of the subnet validators as
+// reported by the endpoint, and the pending validators. Only the primary
+// network validators report them, so the subnet validators are looked up
+// in the primary network.
+func observe(ep string, subnetID ids.ID) ([]health.Observation, []client.Validator, error) {
+	cli, err := client.New(client.Config{
+		URI:          ep,
+		PollInterval: pollInterval,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	primary, err := cli.P().GetValidators(ctx, ids.Empty)
+	cancel()
+	if err != nil {
+		return nil, nil, err
+	}
+	vs := primary
+	if subnetID != ids.Empty {
+		ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+		vs, err = cli.P().GetValidators(ctx, subnetID)
+		cancel()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	pending, err := cli.P().GetPendingValidators(ctx, subnetID)
+	cancel()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byNode := make(map[ids.ShortID]client.Validator, len(primary))
+	for _, v := range primary {
+		byNode[v.NodeID] = v
+	}
+	obs := make([]health.Observation, 0, len(vs))
+	for _, v := range vs {
+		// zero uptime if not validating the primary network anymore
+		pv := byNode[v.NodeID]
+		obs = append(obs, health.Observation{
+			NodeID:    v.NodeID.PrefixedString(constants.NodeIDPrefix),
+			Endpoint:  ep,
+			Uptime:    pv.Uptime,
+			Connected: pv.Connected,
+		})
+	}
+	return obs, pending, nil
+}
+
+func makeHealthTable(statuses []health.Status) string {
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+	tb.SetHeader([]string{"node ID", "uptime", "connected", "health"})
+	for _, s := range statuses {
+		status := formatter.F("{{green}}healthy{{/}}")
+		if !s.Healthy {
+			status = formatter.F("{{red}}unhealthy{{/}}")
+		}
+		tb.Append([]string{
+			labeled(s.NodeID),
+			fmt.Sprintf("%.2f%%", s.Uptime*100),
+			fmt.Sprintf("%d/%d", s.Connected, s.Reported),
+			status,
+		})
+	}
+	tb.Render()
+	return buf.String()
+}
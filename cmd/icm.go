@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ICMCommand implements "subnet-cli icm" command.
+func ICMCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "icm",
+		Short: "Sub-commands for the Interchain Messaging (ICM) between the EVM chains",
+	}
+	cmd.AddCommand(
+		newICMDeployCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,269 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/evm"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var (
+	errICMChains         = errors.New("--chains requires at least two chains")
+	errEmptyMessengerTx  = errors.New("empty --messenger-tx")
+	errMessengerMismatch = errors.New("messenger tx does not deploy the expected messenger")
+	errICMPrerequisites  = errors.New("cross-chain relay prerequisites not met")
+	errDuplicateEVMChain = errors.New("duplicate EVM chain ID")
+)
+
+func newICMDeployCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deploy",
+		Short: "Deploys the ICM (Teleporter) messenger to Subnet-EVM chains",
+		Long: `
+Deploys the ICM (Teleporter) messenger contract at its canonical address on
+each of the Subnet-EVM chains, as a post-wizard integration step, and
+verifies the cross-chain message relay prerequisites: the messenger is
+deployed, and the Warp precompile is enabled on every chain.
+
+"--messenger-tx" is the keyless deployment tx of the messenger release
+(e.g., "TeleporterMessenger_Deployment_Transaction_v1.0.0.txt"), which
+creates the messenger at the same address on every chain. The key of
+"--private-key-path" funds its deployer with the gas of the tx. The chains
+already having the messenger are skipped.
+
+If "--registry-bytecode" is set, the "TeleporterRegistry" contract is also
+deployed by the key (at an address of the key, not a canonical one), with
+the messenger as the protocol version 1.
+
+"--chains" is the blockchain IDs of the chains on "--public-uri", or their
+JSON-RPC endpoints.
+
+$ subnet-cli icm deploy \
+--public-uri=http://localhost:9650 \
+--chains=[BLOCKCHAIN ID A],[BLOCKCHAIN ID B] \
+--messenger-tx=TeleporterMessenger_Deployment_Transaction_v1.0.0.txt \
+--registry-bytecode=artifacts/TeleporterRegistry.json \
+--private-key-path=.subnet-cli.pk
+
+`,
+		RunE: icmDeployFunc,
+	}
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringSliceVar(&icmChains, "chains", nil, "comma-separated blockchain IDs (or JSON-RPC endpoints) of the Subnet-EVM chains")
+	cmd.PersistentFlags().StringVar(&messengerTxPath, "messenger-tx", "", "file of the keyless deployment tx of the messenger (hex)")
+	cmd.PersistentFlags().StringVar(&messengerAddress, "messenger-address", evm.TeleporterMessengerAddress, "expected address of the messenger, empty to not check")
+	cmd.PersistentFlags().StringVar(&registryBytecodePath, "registry-bytecode", "", "file of the registry creation bytecode (hex, or Hardhat/Foundry artifact JSON), empty to not deploy the registry")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path, or key store URI (e.g., 'keychain://subnet-cli/ops', 'env://OPS_KEY')")
+	return cmd
+}
+
+// icmChain is the state of a chain to deploy the messenger to.
+type icmChain struct {
+	name    string
+	cli     evm.Client
+	chainID *big.Int
+	warp    bool
+	// true if the messenger is already deployed
+	deployed bool
+}
+
+func icmDeployFunc(cmd *cobra.Command, args []string) error {
+	if len(icmChains) < 2 {
+		return errICMChains
+	}
+	if messengerTxPath == "" {
+		return errEmptyMessengerTx
+	}
+	b, err := ioutil.ReadFile(messengerTxPath)
+	if err != nil {
+		return err
+	}
+	d, err := evm.ParseKeylessDeployment(b)
+	if err != nil {
+		return fmt.Errorf("%s: %w", messengerTxPath, err)
+	}
+	if messengerAddress != "" {
+		addr, err := evm.ParseAddress(messengerAddress)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(addr, d.Contract) {
+			return fmt.Errorf("%w: creates %s, expected %s", errMessengerMismatch, evm.EncodeHex(d.Contract), messengerAddress)
+		}
+	}
+	var registryCode []byte
+	if registryBytecodePath != "" {
+		b, err := ioutil.ReadFile(registryBytecodePath)
+		if err != nil {
+			return err
+		}
+		registryCode, err = evm.ParseBytecode(b)
+		if err != nil {
+			return err
+		}
+		registryCode = append(registryCode, evm.RegistryArgs(d.Contract)...)
+	}
+
+	// the network ID only formats the (unused) P-Chain address
+	k, err := loadSoftKey(constants.LocalID)
+	if err != nil {
+		return err
+	}
+	defer k.Close()
+	privKey := k.Key()
+	pub := &privKey.ToECDSA().PublicKey
+
+	chains := make([]*icmChain, 0, len(icmChains))
+	for _, s := range icmChains {
+		c, err := getICMChain(s, d.Contract)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s, err)
+		}
+		chains = append(chains, c)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	tb := newTxTable(buf, []string{"chain", "chain ID", "messenger", "warp"})
+	for _, c := range chains {
+		messenger := formatter.F("{{yellow}}to deploy{{/}}")
+		if c.deployed {
+			messenger = formatter.F("{{green}}deployed{{/}}")
+		}
+		warp := formatter.F("{{green}}enabled{{/}}")
+		if !c.warp {
+			warp = formatter.F("{{red}}disabled{{/}}")
+		}
+		tb.Append([]string{c.name, c.chainID.String(), messenger, warp})
+	}
+	tb.Render()
+	msg := formatter.F("{{blue}}MESSENGER{{/}} {{light-gray}}{{bold}}%s{{/}} {{light-gray}}(deployer %s, gas cost %s wei){{/}}\n",
+		evm.EncodeHex(d.Contract), evm.EncodeHex(d.Deployer), d.Cost())
+	msg += formatter.F("{{blue}}FUNDER{{/}} {{light-gray}}{{bold}}%s{{/}}\n", evm.EncodeHex(evm.Address(pub)))
+	msg += buf.String()
+	if enablePrompt {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to deploy the messenger, should we continue?{{/}}\n") + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+	if !confirm("{{green}}Yes, let's deploy!{{/}}") {
+		return errTxDeclined
+	}
+
+	for _, c := range chains {
+		if !c.deployed {
+			color.Outf("\n{{blue}}Deploying the messenger to %s...{{/}}\n", c.name)
+			ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			dep, err := evm.DeployKeyless(ctx, c.cli, pub, privKey.SignHash, d, pollInterval)
+			cancel()
+			if err != nil {
+				return fmt.Errorf("%s: %w", c.name, err)
+			}
+			if dep.Receipt == nil {
+				color.Outf("{{yellow}}messenger already deployed to %s{{/}}\n", c.name)
+			} else {
+				color.Outf("{{green}}deployed the messenger %s{{/}} (tx %s, block %d)\n",
+					evm.EncodeHex(dep.Contract),
+					dep.Receipt.TxHash,
+					dep.Receipt.BlockNumber,
+				)
+			}
+		}
+		if registryCode == nil {
+			continue
+		}
+		color.Outf("\n{{blue}}Deploying the registry to %s...{{/}}\n", c.name)
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		dep, err := evm.Deploy(ctx, c.cli, pub, privKey.SignHash, registryCode, 0, pollInterval)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("%s: %w", c.name, err)
+		}
+		color.Outf("{{green}}deployed the registry %s{{/}} (tx %s, block %d)\n",
+			evm.EncodeHex(dep.Contract),
+			dep.Receipt.TxHash,
+			dep.Receipt.BlockNumber,
+		)
+	}
+
+	return checkICMPrerequisites(chains, d.Contract)
+}
+
+// getICMChain dials the chain of the blockchain ID or the JSON-RPC
+// endpoint [s], and checks whether [messenger] is deployed.
+func getICMChain(s string, messenger []byte) (*icmChain, error) {
+	c := &icmChain{name: s}
+	if strings.Contains(s, "://") {
+		c.cli = evm.Dial(s)
+	} else {
+		c.cli = evm.New(publicURI, s)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	var err error
+	c.chainID, err = c.cli.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := c.cli.ChainConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range evm.Precompiles(cfg) {
+		if p == evm.WarpConfig {
+			c.warp = true
+		}
+	}
+	code, err := c.cli.Code(ctx, messenger)
+	if err != nil {
+		return nil, err
+	}
+	c.deployed = len(code) > 0
+	return c, nil
+}
+
+// checkICMPrerequisites checks that the messages can be relayed between
+// the chains: the messenger is deployed and the Warp precompile is
+// enabled on each chain, of distinct EVM chain IDs.
+func checkICMPrerequisites(chains []*icmChain, messenger []byte) error {
+	color.Outf("\n{{blue}}Checking the cross-chain relay prerequisites...{{/}}\n")
+	problems := 0
+	chainIDs := make(map[string]string)
+	for _, c := range chains {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		code, err := c.cli.Code(ctx, messenger)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("%s: %w", c.name, err)
+		}
+		if len(code) == 0 {
+			color.Outf("{{red}}%s: no messenger at %s{{/}}\n", c.name, evm.EncodeHex(messenger))
+			problems++
+		}
+		if !c.warp {
+			color.Outf("{{red}}%s: the Warp precompile (%q) is not enabled in the chain config{{/}}\n", c.name, evm.WarpConfig)
+			problems++
+		}
+		if other, ok := chainIDs[c.chainID.String()]; ok {
+			color.Outf("{{red}}%s: %v %s (same as %s){{/}}\n", c.name, errDuplicateEVMChain, c.chainID, other)
+			problems++
+		}
+		chainIDs[c.chainID.String()] = c.name
+	}
+	if problems > 0 {
+		return fmt.Errorf("%w: %d problem(s)", errICMPrerequisites, problems)
+	}
+	color.Outf("{{green}}the messages can be relayed between the %d chains{{/}}\n", len(chains))
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/keystore"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// KeyCommand implements "subnet-cli key" command.
+func KeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "key",
+		Short: "Sub-commands for managing keys",
+	}
+	cmd.AddCommand(
+		newKeyCreateCommand(),
+		newKeyImportWalletCommand(),
+		newKeyScanCommand(),
+		newKeySignMessageCommand(),
+		newKeyVerifyMessageCommand(),
+		newKeyWatchCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path, or key store URI (e.g., 'keychain://subnet-cli/ops', 'env://OPS_KEY')")
+	return cmd
+}
+
+// checkNoKey fails if a key is already stored at the key path [p] (e.g.,
+// a file, or "keychain://subnet-cli/ops"), not to overwrite it.
+func checkNoKey(p string) error {
+	b, err := keystore.Open(p)
+	if err != nil {
+		return err
+	}
+	ok, err := b.Exists()
+	if err != nil {
+		return err
+	}
+	if ok {
+		color.Outf("{{red}}key already found at %q{{/}}\n", p)
+		return os.ErrExist
+	}
+	return nil
+}
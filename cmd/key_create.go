@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newKeyCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Generates a private key",
+		Long: `
+Generates a random private key, or with --seed, the same key for the same
+seed, for reproducible integration tests and local networks. Anyone who
+knows the seed can spend the funds of a seeded key, so --seed requires the
+--insecure acknowledgment. The key file is marked as seeded, and the key is
+refused on Mainnet.
+
+$ subnet-cli key create --private-key-path=.subnet-cli.pk
+
+$ subnet-cli key create \
+--private-key-path=.insecure.test.key \
+--seed=e2e-validator-1 \
+--insecure
+
+`,
+		RunE: keyCreateFunc,
+	}
+	cmd.PersistentFlags().StringVar(&keySeed, "seed", "", "seed to deterministically derive the key from (requires --insecure)")
+	cmd.PersistentFlags().BoolVar(&insecure, "insecure", false, "acknowledge that the seeded key is insecure and only meant for tests")
+	return cmd
+}
+
+var errInsecureSeed = errors.New("--seed creates an insecure key, acknowledge with --insecure")
+
+func keyCreateFunc(cmd *cobra.Command, args []string) error {
+	if keySeed != "" && !insecure {
+		return errInsecureSeed
+	}
+	if err := checkNoKey(privKeyPath); err != nil {
+		return err
+	}
+
+	var (
+		k   *key.SoftKey
+		err error
+	)
+	if keySeed != "" {
+		k, err = key.NewWithSeed(0, []byte(keySeed))
+	} else {
+		k, err = key.NewSoft(0)
+	}
+	if err != nil {
+		return err
+	}
+	defer k.Close()
+	if err := k.Save(privKeyPath); err != nil {
+		return err
+	}
+	if keySeed != "" {
+		color.Outf("{{yellow}}{{bold}}created an INSECURE seeded key %q, never use it on Mainnet{{/}}\n", privKeyPath)
+		return nil
+	}
+	color.Outf("{{green}}created a new key %q{{/}}\n", privKeyPath)
+	return nil
+}
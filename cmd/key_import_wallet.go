@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/internal/passphrase"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// WalletPassphraseEnvVar is the environment variable to read the wallet
+// export passphrase from, instead of prompting for it.
+const WalletPassphraseEnvVar = "SUBNET_CLI_WALLET_PASSPHRASE"
+
+func newKeyImportWalletCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import-wallet [WALLET EXPORT FILE]",
+		Short: "Imports the keys of an Avalanche web wallet export",
+		Long: `
+Decrypts the JSON file exported by the Avalanche web wallet ("Export Wallet"),
+and saves its keys as private key files. The passphrase is prompted for
+without being echoed (up to 3 attempts), or read from --passphrase-file,
+--passphrase-fd, or the SUBNET_CLI_WALLET_PASSPHRASE environment variable.
+
+The first key is saved at --private-key-path, and the following ones with
+the ".1", ".2", ... suffixes. Mnemonic keys are imported as their first
+address key ("m/44'/9000'/0'/0/0").
+
+$ subnet-cli key import-wallet wallet.json \
+--private-key-path=.subnet-cli.pk
+
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: keyImportWalletFunc,
+	}
+	cmd.PersistentFlags().Uint32Var(&walletNetworkID, "network-id", constants.FujiID, "network ID to format the P-Chain addresses with")
+	return cmd
+}
+
+func keyImportWalletFunc(cmd *cobra.Command, args []string) error {
+	b, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	var wks []key.WalletKey
+	decrypt := func(p string) (err error) {
+		wks, err = key.DecryptWallet(b, p)
+		if errors.Is(err, key.ErrWrongPassphrase) {
+			return fmt.Errorf("%w of %q", passphrase.ErrWrong, args[0])
+		}
+		return err
+	}
+	if p := os.Getenv(WalletPassphraseEnvVar); p != "" && passphraseFile == "" && passphraseFD == 0 {
+		err = decrypt(p)
+	} else {
+		_, err = passphraseReader().Read("Wallet passphrase", decrypt)
+	}
+	if errors.Is(err, passphrase.ErrNoTerminal) {
+		return fmt.Errorf("%w: set --passphrase-file, --passphrase-fd, or $%s", err, WalletPassphraseEnvVar)
+	}
+	if err != nil {
+		return err
+	}
+	if len(wks) == 0 {
+		color.Outf("{{yellow}}no key found in %q{{/}}\n", args[0])
+		return nil
+	}
+
+	paths := make([]string, len(wks))
+	for i := range wks {
+		paths[i] = privKeyPath
+		if i > 0 {
+			paths[i] = fmt.Sprintf("%s.%d", privKeyPath, i)
+		}
+		if err := checkNoKey(paths[i]); err != nil {
+			return err
+		}
+	}
+
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+	tb.SetHeader([]string{"#", "type", "P-Chain address", "private key path"})
+	for i, wk := range wks {
+		k, err := key.NewSoft(walletNetworkID, key.WithPrivateKey(wk.PrivKey))
+		if err != nil {
+			return err
+		}
+		err = k.Save(paths[i])
+		pAddr := k.P()[0]
+		k.Close()
+		if err != nil {
+			return err
+		}
+		tb.Append([]string{fmt.Sprintf("%d", i), wk.Type, pAddr, paths[i]})
+	}
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	color.Outf("{{green}}imported %d key(s) from %q{{/}}\n", len(wks), args[0])
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var (
+	errEmptyMessage   = errors.New("empty --message and --message-file")
+	errBothMessages   = errors.New("--message and --message-file are mutually exclusive")
+	errEmptySignature = errors.New("empty --signature")
+)
+
+func newKeySignMessageCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-message",
+		Short: "Signs a message with the P-Chain key",
+		Long: `
+Signs an arbitrary message with the P-Chain address of the key, to prove the
+control of the address (e.g., a subnet control key) to a third party without
+issuing any tx. The message is prefixed and hashed as by the Avalanche wallet
+(SHA-256 of "\x1AAvalanche Signed Message:\n", the message length as a
+big-endian uint32, and the message), and the signature is CB58 encoded.
+
+The address is formatted for --network (mainnet if not set).
+
+$ subnet-cli key sign-message \
+--private-key-path=.insecure.ewoq.key \
+--message="I control P-avax1..."
+
+`,
+		RunE: keySignMessageFunc,
+	}
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign the message")
+	addMessageFlags(cmd)
+	return cmd
+}
+
+func newKeyVerifyMessageCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-message",
+		Short: "Verifies a message signed by a P-Chain address",
+		Long: `
+Verifies the signature of a message produced by "key sign-message" (or by
+the Avalanche wallet), and fails unless it was signed by the address.
+No key nor network access is required.
+
+$ subnet-cli key verify-message \
+--address=P-avax1... \
+--message="I control P-avax1..." \
+--signature=...
+
+`,
+		RunE: keyVerifyMessageFunc,
+	}
+	cmd.PersistentFlags().StringVar(&messageAddress, "address", "", "P-Chain address expected to have signed the message")
+	cmd.PersistentFlags().StringVar(&messageSignature, "signature", "", "CB58 encoded signature")
+	addMessageFlags(cmd)
+	return cmd
+}
+
+func addMessageFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&messageText, "message", "", "message to sign or verify")
+	cmd.PersistentFlags().StringVar(&messageFile, "message-file", "", "file of the message to sign or verify (instead of --message)")
+}
+
+// readMessage returns the message of "--message" or "--message-file".
+func readMessage() ([]byte, error) {
+	switch {
+	case messageText != "" && messageFile != "":
+		return nil, errBothMessages
+	case messageFile != "":
+		return os.ReadFile(messageFile)
+	case messageText != "":
+		return []byte(messageText), nil
+	}
+	return nil, errEmptyMessage
+}
+
+func keySignMessageFunc(cmd *cobra.Command, args []string) error {
+	msg, err := readMessage()
+	if err != nil {
+		return err
+	}
+	networkID := constants.MainnetID
+	if expectedNetwork != "" {
+		networkID, err = constants.NetworkID(expectedNetwork)
+		if err != nil {
+			return err
+		}
+	}
+
+	var k key.Key
+	if useLedger {
+		k, err = key.NewHard(networkID, ledgerPrompter())
+	} else {
+		k, err = loadSoftKey(networkID)
+	}
+	if err != nil {
+		return err
+	}
+	defer k.Close()
+
+	sig, err := key.SignMessage(k, k.Addresses()[0], msg)
+	if err != nil {
+		return err
+	}
+	encoded, err := formatting.EncodeWithChecksum(formatting.CB58, sig)
+	if err != nil {
+		return err
+	}
+	color.Outf("{{blue}}address:{{/}}   %s\n", k.P()[0])
+	color.Outf("{{blue}}signature:{{/}} %s\n", encoded)
+	return nil
+}
+
+func keyVerifyMessageFunc(cmd *cobra.Command, args []string) error {
+	msg, err := readMessage()
+	if err != nil {
+		return err
+	}
+	if messageSignature == "" {
+		return errEmptySignature
+	}
+	addr, err := ParsePAddress(messageAddress)
+	if err != nil {
+		return err
+	}
+	sig, err := formatting.Decode(formatting.CB58, messageSignature)
+	if err != nil {
+		return err
+	}
+	if err := key.VerifyMessage(addr, msg, sig); err != nil {
+		return err
+	}
+	color.Outf("{{green}}valid signature by %s{{/}}\n", messageAddress)
+	return nil
+}
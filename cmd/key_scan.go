@@ -0,0 +1,138 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/dustin/go-humanize"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// MnemonicEnvVar is the environment variable to read the mnemonic from,
+// instead of prompting for it.
+const MnemonicEnvVar = "SUBNET_CLI_MNEMONIC"
+
+func newKeyScanCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scan",
+		Short: "Finds the funded addresses of a mnemonic",
+		Long: `
+Derives the addresses of the successive accounts of a BIP-39 mnemonic
+("m/44'/9000'/account'/0/index"), and reports the ones that own P-Chain
+UTXOs, to locate the funds of a restored seed without guessing the indices.
+The scan of an account stops after --gap-limit consecutive unused addresses,
+and the scan stops at the first account without any used address.
+
+The mnemonic is prompted for, or read from the SUBNET_CLI_MNEMONIC environment
+variable. With --save, the keys of the funded addresses are saved at
+--private-key-path with the ".[account].[index]" suffixes.
+
+$ subnet-cli key scan \
+--public-uri=https://api.avax-test.network \
+--gap-limit=20
+
+`,
+		RunE: keyScanFunc,
+	}
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().Uint32Var(&gapLimit, "gap-limit", key.DefaultGapLimit, "number of consecutive unused addresses to stop scanning an account after")
+	cmd.PersistentFlags().BoolVar(&saveScanned, "save", false, "'true' to save the keys of the funded addresses")
+	return cmd
+}
+
+func keyScanFunc(cmd *cobra.Command, args []string) error {
+	mnemonic := os.Getenv(MnemonicEnvVar)
+	if mnemonic == "" {
+		var err error
+		mnemonic, err = prompter.Password("Mnemonic")
+		if err != nil {
+			return err
+		}
+	}
+	seed, err := key.SeedFromMnemonic(mnemonic, "")
+	if err != nil {
+		return err
+	}
+
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+	hrp := constants.GetHRP(cli.NetworkID())
+
+	color.Outf("\n{{blue}}Scanning the accounts (gap limit %d)...{{/}}\n", gapLimit)
+	results, err := key.Scan(seed, gapLimit, func(addrs []ids.ShortID) (map[ids.ShortID]uint64, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		return cli.P().Balances(ctx, addrs)
+	})
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		color.Outf("{{yellow}}no funded address found{{/}}\n")
+		return nil
+	}
+
+	buf := bytes.NewBuffer(nil)
+	tb := newTxTable(buf, []string{"path", "P-Chain address", "balance ($AVAX)", "private key path"})
+	total := uint64(0)
+	for _, r := range results {
+		paddr, err := formatting.FormatAddress("P", hrp, r.Address.Bytes())
+		if err != nil {
+			return err
+		}
+		p := ""
+		if saveScanned {
+			p, err = saveScannedKey(seed, r)
+			if err != nil {
+				return err
+			}
+		}
+		total += r.Balance
+		tb.Append([]string{
+			r.Path(),
+			paddr,
+			humanize.FormatFloat("#,###.#########", float64(r.Balance)/float64(units.Avax)),
+			p,
+		})
+	}
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	color.Outf("{{green}}found %d funded address(es) with %s $AVAX{{/}}\n",
+		len(results),
+		humanize.FormatFloat("#,###.#########", float64(total)/float64(units.Avax)),
+	)
+	return nil
+}
+
+// saveScannedKey saves the key of the scanned address, and returns its path.
+func saveScannedKey(seed []byte, r key.ScanResult) (string, error) {
+	p := fmt.Sprintf("%s.%d.%d", privKeyPath, r.Account, r.Index)
+	if err := checkNoKey(p); err != nil {
+		return "", err
+	}
+	privKey, err := key.DeriveKey(seed, key.AvalanchePath(r.Account, r.Index))
+	if err != nil {
+		return "", err
+	}
+	k, err := key.NewSoft(0, key.WithPrivateKey(privKey))
+	if err != nil {
+		return "", err
+	}
+	defer k.Close()
+	return p, k.Save(p)
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/dustin/go-humanize"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// defaultWatchAddresses is the number of addresses derived from --xpub,
+// as the gap limit of the wallets.
+const defaultWatchAddresses = 20
+
+var errEmptyXPub = errors.New("empty --xpub")
+
+func newKeyWatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Shows the addresses and balances of an account extended public key",
+		Long: `
+Derives the external addresses ("m/44'/9000'/account'/0/index") of an
+account extended public key (xpub), as exported by a wallet or read from the
+ledger, and shows their P-Chain balances, without any private key.
+
+The same --xpub loads a watch-only key in "balance", "utxos export", and
+"multisig propose", to monitor the funds and build the unsigned txs on a
+machine without the private key. The txs are then signed with "multisig
+sign" where the key is.
+
+$ subnet-cli key watch \
+--public-uri=https://api.avax-test.network \
+--xpub=xpub6C...
+
+`,
+		RunE: keyWatchFunc,
+	}
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringVar(&xpub, "xpub", "", "account extended public key to watch (e.g., 'xpub6C...' of \"m/44'/9000'/0'\")")
+	cmd.PersistentFlags().IntVar(&watchAddresses, "watch-addresses", defaultWatchAddresses, "number of external addresses to derive from --xpub")
+	cmd.PersistentFlags().BoolVar(&showUnfunded, "all", false, "'true' to also list the addresses without any balance")
+	return cmd
+}
+
+// addXPubFlags adds the flags to load a watch-only key from an account
+// extended public key, instead of --private-key-path or --ledger.
+func addXPubFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&xpub, "xpub", "", "account extended public key to load a watch-only key from, instead of --private-key-path or --ledger (can't sign)")
+	cmd.PersistentFlags().IntVar(&watchAddresses, "watch-addresses", defaultWatchAddresses, "number of external addresses to derive from --xpub")
+}
+
+func keyWatchFunc(cmd *cobra.Command, args []string) error {
+	if xpub == "" {
+		return errEmptyXPub
+	}
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+	w, err := key.NewWatch(cli.NetworkID(), xpub, watchAddresses)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	balances, err := cli.P().Balances(ctx, w.Addresses())
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	color.Outf("\n{{blue}}account %d{{/}} {{light-gray}}(m/44'/%d'/%d'){{/}}\n", w.Account(), key.AvalancheCoinType, w.Account())
+	buf := bytes.NewBuffer(nil)
+	tb := newTxTable(buf, []string{"index", "path", "P-Chain address", "balance ($AVAX)"})
+	total, funded := uint64(0), 0
+	for i, addr := range w.Addresses() {
+		balance, ok := balances[addr]
+		if ok {
+			total += balance
+			funded++
+		}
+		if !ok && !showUnfunded {
+			continue
+		}
+		tb.Append([]string{
+			strconv.Itoa(i),
+			w.Path(uint32(i)),
+			w.P()[i],
+			humanize.FormatFloat("#,###.#########", float64(balance)/float64(units.Avax)),
+		})
+	}
+	if funded > 0 || showUnfunded {
+		tb.Render()
+		fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	}
+	color.Outf("{{green}}%d of %d address(es) funded with %s $AVAX{{/}}\n",
+		funded,
+		len(w.Addresses()),
+		humanize.FormatFloat("#,###.#########", float64(total)/float64(units.Avax)),
+	)
+	return nil
+}
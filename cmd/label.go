@@ -0,0 +1,274 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/internal/idconv"
+	"github.com/ava-labs/subnet-cli/internal/labels"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var (
+	errLabelKind = errors.New("only the subnet, blockchain, and node IDs can be labeled")
+	errNoLabel   = errors.New("no label")
+)
+
+// LabelCommand implements "subnet-cli label" command.
+func LabelCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "label",
+		Short: "Sub-commands for naming the subnets, blockchains, and nodes locally",
+		Long: `
+Manages the local names and tags of the subnets, blockchains, and nodes,
+as the P-Chain has no human names for them. The names decorate the IDs in
+the command output (e.g., "list subnets", "status subnet"). The store is
+shared within a team with "label export" and "label import".
+
+$ subnet-cli label set 24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1 prod-subnet --tags=team=infra
+$ subnet-cli label set NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH validator-1
+$ subnet-cli label list
+
+`,
+	}
+	cmd.AddCommand(
+		newLabelSetCommand(),
+		newLabelRemoveCommand(),
+		newLabelListCommand(),
+		newLabelExportCommand(),
+		newLabelImportCommand(),
+	)
+	return cmd
+}
+
+func newLabelSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <ID> <name>",
+		Short: "Names a subnet, a blockchain, or a node",
+		Args:  cobra.ExactArgs(2),
+		RunE:  labelSetFunc,
+	}
+	cmd.PersistentFlags().StringSliceVar(&labelTags, "tags", nil, "tags of the ID (e.g., 'env=prod,team=infra')")
+	return cmd
+}
+
+func newLabelRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rm <ID or name>",
+		Short: "Removes the label of an ID",
+		Args:  cobra.ExactArgs(1),
+		RunE:  labelRemoveFunc,
+	}
+}
+
+func newLabelListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the labels",
+		RunE:  labelListFunc,
+	}
+	cmd.PersistentFlags().StringSliceVar(&labelTags, "tags", nil, "only list the labels with all of the tags")
+	return cmd
+}
+
+func newLabelExportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export <file>",
+		Short: "Exports the labels to share them",
+		Args:  cobra.ExactArgs(1),
+		RunE:  labelExportFunc,
+	}
+}
+
+func newLabelImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Imports the labels exported by another user",
+		Long: `
+Merges the labels of the file exported by "label export". The IDs already
+labeled differently keep their labels, unless --overwrite. The labels of a
+name already used by another ID are skipped.
+
+$ subnet-cli label import team-labels.json
+
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: labelImportFunc,
+	}
+	cmd.PersistentFlags().BoolVar(&labelOverwrite, "overwrite", false, "'true' to replace the labels of the IDs already labeled differently")
+	return cmd
+}
+
+func labelsFile() (string, error) {
+	if labelsPath != "" {
+		return labelsPath, nil
+	}
+	return labels.DefaultPath()
+}
+
+func loadLabels() (*labels.Store, string, error) {
+	p, err := labelsFile()
+	if err != nil {
+		return nil, "", err
+	}
+	s, err := labels.Load(p)
+	if err != nil {
+		return nil, "", err
+	}
+	return s, p, nil
+}
+
+// normalizeLabelID returns the ID as printed by the commands
+// (e.g., the hex of a blockchain ID in CB58).
+func normalizeLabelID(s string) (string, error) {
+	p, err := idconv.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	switch p.Kind {
+	case idconv.ID:
+		return p.CB58(), nil
+	case idconv.NodeID:
+		return p.NodeID(), nil
+	default:
+		return "", fmt.Errorf("%w: %q is a %s", errLabelKind, s, p.Kind)
+	}
+}
+
+func labelSetFunc(cmd *cobra.Command, args []string) error {
+	id, err := normalizeLabelID(args[0])
+	if err != nil {
+		return err
+	}
+	s, p, err := loadLabels()
+	if err != nil {
+		return err
+	}
+	if err := s.Set(id, labels.Label{Name: args[1], Tags: labelTags}); err != nil {
+		return err
+	}
+	if err := s.Save(p); err != nil {
+		return err
+	}
+	color.Outf("{{green}}labeled %s as %q{{/}}\n", id, strings.TrimSpace(args[1]))
+	return nil
+}
+
+func labelRemoveFunc(cmd *cobra.Command, args []string) error {
+	s, p, err := loadLabels()
+	if err != nil {
+		return err
+	}
+	id, ok := s.Lookup(args[0])
+	if !ok {
+		id, err = normalizeLabelID(args[0])
+		if err != nil {
+			return err
+		}
+	}
+	if !s.Remove(id) {
+		return fmt.Errorf("%w for %q", errNoLabel, args[0])
+	}
+	if err := s.Save(p); err != nil {
+		return err
+	}
+	color.Outf("{{green}}removed the label of %s{{/}}\n", id)
+	return nil
+}
+
+func labelListFunc(cmd *cobra.Command, args []string) error {
+	s, _, err := loadLabels()
+	if err != nil {
+		return err
+	}
+	buf := bytes.NewBuffer(nil)
+	tb := newTxTable(buf, []string{"name", "ID", "tags"})
+	n := 0
+	for _, id := range s.IDs() {
+		l := s.Labels[id]
+		if !hasTags(l.Tags, labelTags) {
+			continue
+		}
+		tb.Append([]string{l.Name, id, strings.Join(l.Tags, ", ")})
+		n++
+	}
+	if n > 0 {
+		tb.Render()
+		fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	}
+	color.Outf("{{magenta}}found %d label(s){{/}}\n", n)
+	return nil
+}
+
+func hasTags(tags []string, want []string) bool {
+	for _, w := range want {
+		if !containsString(tags, w) {
+			return false
+		}
+	}
+	return true
+}
+
+func labelExportFunc(cmd *cobra.Command, args []string) error {
+	s, _, err := loadLabels()
+	if err != nil {
+		return err
+	}
+	if err := s.Save(args[0]); err != nil {
+		return err
+	}
+	color.Outf("{{green}}exported %d label(s) to %q{{/}}\n", len(s.Labels), args[0])
+	return nil
+}
+
+func labelImportFunc(cmd *cobra.Command, args []string) error {
+	s, p, err := loadLabels()
+	if err != nil {
+		return err
+	}
+	shared, err := labels.Load(args[0])
+	if err != nil {
+		return err
+	}
+	added, updated, skipped := s.Merge(shared, labelOverwrite)
+	if err := s.Save(p); err != nil {
+		return err
+	}
+	color.Outf("{{green}}imported %d new and %d updated label(s){{/}}\n", added, updated)
+	if skipped > 0 {
+		color.Outf("{{yellow}}skipped %d conflicting label(s){{/}} {{light-gray}}(see --overwrite){{/}}\n", skipped)
+	}
+	return nil
+}
+
+var (
+	labelStore     *labels.Store
+	labelStoreOnce sync.Once
+)
+
+// labeled returns [id] decorated with its label name, if any
+// (e.g., "24tZh... (prod-subnet)").
+func labeled(id string) string {
+	labelStoreOnce.Do(func() {
+		s, _, err := loadLabels()
+		if err != nil {
+			zap.L().Debug("failed to load the labels", zap.Error(err))
+			s = &labels.Store{}
+		}
+		labelStore = s
+	})
+	if l, ok := labelStore.Get(id); ok {
+		return fmt.Sprintf("%s (%s)", id, l.Name)
+	}
+	return id
+}
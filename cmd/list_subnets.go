@@ -0,0 +1,182 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newListSubnetsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subnets",
+		Short: "Lists the subnets",
+		Long: `
+Lists the subnets of the network, with their control keys and threshold.
+With --mine, only lists the subnets whose control keys include any address
+of the loaded key (or of --addresses), to find the subnets it can manage.
+
+$ subnet-cli list subnets \
+--public-uri=http://localhost:52250 \
+--private-key-path=.insecure.ewoq.key \
+--mine
+
+$ subnet-cli list subnets \
+--public-uri=https://api.avax-test.network \
+--addresses=P-fuji1...,P-fuji1... \
+--mine
+
+`,
+		RunE: listSubnetsFunc,
+	}
+	cmd.PersistentFlags().BoolVar(&listMine, "mine", false, "'true' to only list the subnets controlled by the key or the addresses")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", "", "private key file path (to list the subnets it controls)")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger addresses (to list the subnets they control)")
+	cmd.PersistentFlags().StringSliceVar(&listAddresses, "addresses", nil, "P-Chain addresses to list the subnets they control, without loading any key")
+	return cmd
+}
+
+var errNoAddresses = errors.New("--mine requires --private-key-path, --ledger, or --addresses")
+
+type listedSubnet struct {
+	client.Subnet
+	blockchains int
+	// number of the control keys held
+	held int
+}
+
+func listSubnetsFunc(cmd *cobra.Command, args []string) error {
+	loadKey := privKeyPath != "" || useLedger
+	cli, info, err := InitClient(publicURI, loadKey)
+	if err != nil {
+		return err
+	}
+	if loadKey {
+		defer info.key.Close()
+	}
+
+	mine := make(map[ids.ShortID]struct{})
+	if loadKey {
+		for _, addr := range info.key.Addresses() {
+			mine[addr] = struct{}{}
+		}
+	}
+	for _, a := range listAddresses {
+		addr, err := ParsePAddress(a)
+		if err != nil {
+			return err
+		}
+		mine[addr] = struct{}{}
+	}
+	if listMine && len(mine) == 0 {
+		return errNoAddresses
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	subnets, err := cli.P().GetSubnets(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	bcs, err := cli.P().GetBlockchains(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	blockchains := make(map[ids.ID]int)
+	for _, bc := range bcs {
+		blockchains[bc.SubnetID]++
+	}
+
+	listed := make([]listedSubnet, 0, len(subnets))
+	for _, s := range subnets {
+		// primary network has no control key
+		if s.ID == constants.PrimaryNetworkID {
+			continue
+		}
+		ls := listedSubnet{Subnet: s, blockchains: blockchains[s.ID]}
+		for _, ck := range s.ControlKeys {
+			if _, ok := mine[ck]; ok {
+				ls.held++
+			}
+		}
+		if listMine && ls.held == 0 {
+			continue
+		}
+		listed = append(listed, ls)
+	}
+	sort.Slice(listed, func(i, j int) bool {
+		return listed[i].ID.String() < listed[j].ID.String()
+	})
+
+	if len(listed) == 0 {
+		color.Outf("{{yellow}}no subnet found{{/}}\n")
+		return nil
+	}
+	msg, err := makeListSubnetsTable(cli.NetworkID(), listed, len(mine) > 0)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+	color.Outf("{{magenta}}found %d subnet(s){{/}}\n", len(listed))
+	return nil
+}
+
+func makeListSubnetsTable(networkID uint32, listed []listedSubnet, showHeld bool) (string, error) {
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetRowLine(true)
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+	header := []string{"subnet ID", "control keys", "threshold", "blockchains"}
+	if showHeld {
+		header = append(header, "held keys", "can sign")
+	}
+	tb.SetHeader(header)
+
+	hrp := constants.GetHRP(networkID)
+	for _, ls := range listed {
+		cks := make([]string, len(ls.ControlKeys))
+		for i, ck := range ls.ControlKeys {
+			addr, err := formatting.FormatAddress("P", hrp, ck.Bytes())
+			if err != nil {
+				return "", err
+			}
+			cks[i] = addr
+		}
+		row := []string{
+			labeled(ls.ID.String()),
+			strings.Join(cks, "\n"),
+			fmt.Sprintf("%d", ls.Threshold),
+			fmt.Sprintf("%d", ls.blockchains),
+		}
+		if showHeld {
+			canSign := "no"
+			if ls.held > 0 && uint32(ls.held) >= ls.Threshold {
+				canSign = "yes"
+			}
+			row = append(row, fmt.Sprintf("%d", ls.held), canSign)
+		}
+		tb.Append(row)
+	}
+	tb.Render()
+	return buf.String(), nil
+}
@@ -0,0 +1,22 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// LogsCommand implements "subnet-cli logs" command.
+func LogsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Sub-commands for the node logs",
+	}
+	cmd.AddCommand(
+		newLogsChainCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&nodeURL, "node-url", "http://localhost:9650", "URI of the node")
+	cmd.PersistentFlags().StringVar(&sshTarget, "ssh", "", "SSH destination of the node host (e.g., ubuntu@10.0.0.1), empty for the local host")
+	return cmd
+}
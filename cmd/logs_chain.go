@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/node"
+	"github.com/ava-labs/subnet-cli/internal/nodeconfig"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var (
+	errEmptyChainID        = errors.New("empty --chain-id")
+	errChainNotInitialized = errors.New("chain is not initialized on the node")
+)
+
+func newLogsChainCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chain",
+		Short: "Prints and follows the logs of a chain on a node",
+		Long: `
+Checks the chain is initialized on the node (its logger exists, with the
+admin API), optionally sets its log level, and prints the last lines of
+its log file. With --follow, streams the new lines as the node writes them,
+e.g., to verify the VM initialized right after the wizard.
+
+The node has no API to read its logs, so the log file is read on the node
+host (over SSH with --ssh), from --log-dir ("--log-dir" of the node).
+
+$ subnet-cli logs chain \
+--node-url=http://localhost:9650 \
+--chain-id=2ebCneCbwthjQ1rYT41nhd7M76Hc6YmosMAQrTFhBq8qeqh6tt \
+--follow
+
+`,
+		RunE: logsChainFunc,
+	}
+	cmd.PersistentFlags().StringVar(&blockchainID, "chain-id", "", "blockchain ID or alias of the chain")
+	cmd.PersistentFlags().BoolVar(&followLogs, "follow", false, "'true' to stream the new lines until interrupted")
+	cmd.PersistentFlags().IntVar(&logLines, "lines", 100, "number of the last lines to print first (-1 for all)")
+	cmd.PersistentFlags().StringVar(&nodeLogDir, "log-dir", node.DefaultLogDir, "log directory of the node on the node host")
+	cmd.PersistentFlags().StringVar(&chainLogLevel, "chain-log-level", "", "log level to set for the chain before reading its logs (e.g., 'debug'), empty to keep it")
+	return cmd
+}
+
+func logsChainFunc(cmd *cobra.Command, args []string) error {
+	if blockchainID == "" {
+		return errEmptyChainID
+	}
+	cli := node.New(nodeURL)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	chainID, err := cli.BlockchainID(ctx, blockchainID)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	// the chain logger is registered once the VM is initialized
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	level, err := cli.LoggerLevel(ctx, chainID)
+	cancel()
+	switch {
+	case errors.Is(err, node.ErrAPIDisabled):
+		color.Outf("{{yellow}}admin API is disabled on %s, not checking the chain is initialized{{/}}\n", nodeURL)
+	case err != nil:
+		return fmt.Errorf("%w: %s on %s (%v)", errChainNotInitialized, chainID, nodeURL, err)
+	default:
+		color.Outf("{{green}}chain %s is initialized on %s{{/}} {{light-gray}}(log level %s){{/}}\n", chainID, nodeURL, level)
+	}
+	if chainLogLevel != "" {
+		ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+		err = cli.SetLoggerLevel(ctx, chainID, chainLogLevel)
+		cancel()
+		if err != nil {
+			return err
+		}
+		color.Outf("{{green}}set the log level of %s to %s{{/}}\n", chainID, chainLogLevel)
+	}
+
+	if sshTarget != "" {
+		tail := fmt.Sprintf("tail -n %d", logLines)
+		if logLines < 0 {
+			tail = "tail -n +1"
+		}
+		if followLogs {
+			// follows the file across the rotations
+			tail += " -F"
+		}
+		tail += " " + nodeconfig.ShellPath(nodeLogDir+"/"+chainID+".log")
+		return nodeconfig.SSH(sshTarget).Stream(context.Background(), tail, os.Stdout)
+	}
+	p, err := node.ChainLogPath(nodeLogDir, chainID)
+	if err != nil {
+		return err
+	}
+	return node.Tail(context.Background(), p, logLines, followLogs, pollInterval, os.Stdout)
+}
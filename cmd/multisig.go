@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/multisig"
+)
+
+// MultisigCommand implements "subnet-cli multisig" command.
+func MultisigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "multisig",
+		Short: "Sub-commands for signing subnet transactions with multiple control keys",
+		Long: `
+Collects the signatures of the control key holders of a subnet with
+threshold > 1, through a shareable transaction file:
+
+1. "propose" builds the unsigned transaction (paid by the proposer key),
+   and signs it with the proposer key.
+2. Each control key holder runs "sign" to add their signature.
+3. Anyone runs "commit" to issue the transaction, once all the required
+   signatures are collected.
+
+The proposer must not spend the UTXOs that pay the fee until committed.
+
+The control key holders signing with Core (or the Avalanche Wallet) get
+the transaction from "export", and add their signatures back with "import".
+
+`,
+	}
+	cmd.AddCommand(
+		newMultisigProposeCommand(),
+		newMultisigSignCommand(),
+		newMultisigCommitCommand(),
+		newMultisigExportCommand(),
+		newMultisigImportCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path, or key store URI (e.g., 'keychain://subnet-cli/ops', 'env://OPS_KEY')")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().StringVar(&txFilePath, "tx-file", "multisig-tx.json", "file path of the transaction to sign")
+	return cmd
+}
+
+var errNetworkMismatch = errors.New("transaction file is for another network")
+
+func loadTxFile(networkID uint32) (*multisig.File, error) {
+	f, err := multisig.Load(txFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if f.NetworkID != networkID {
+		return nil, fmt.Errorf("%w (expected %d, got %d)", errNetworkMismatch, networkID, f.NetworkID)
+	}
+	if err := f.CheckChain(constants.PlatformChainID); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func MakeMultisigTable(i *Info, f *multisig.File) (string, error) {
+	buf, tb := BaseTableSetup(i)
+	tb.Append([]string{formatter.F("{{blue}}TX FILE{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", txFilePath)})
+	tb.Append([]string{formatter.F("{{blue}}TX{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", f.Description)})
+	missing := make(map[ids.ShortID]struct{})
+	for _, addr := range f.Missing() {
+		missing[addr] = struct{}{}
+	}
+	hrp := constants.GetHRP(f.NetworkID)
+	for _, addr := range f.Required() {
+		paddr, err := formatting.FormatAddress("P", hrp, addr.Bytes())
+		if err != nil {
+			return "", err
+		}
+		status := formatter.F("{{green}}signed{{/}}")
+		if _, ok := missing[addr]; ok {
+			status = formatter.F("{{yellow}}missing{{/}}")
+		}
+		tb.Append([]string{formatter.F("{{cyan}}{{bold}}%s{{/}}", paddr), status})
+	}
+	tb.Render()
+	return buf.String(), nil
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newMultisigCommitCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commit",
+		Short: "Issues the fully signed transaction",
+		Long: `
+Issues the transaction once all the required signatures are collected.
+No key is required.
+
+$ subnet-cli multisig commit \
+--public-uri=http://localhost:52250 \
+--tx-file=add-validator.json
+
+`,
+		RunE: multisigCommitFunc,
+	}
+	return cmd
+}
+
+func multisigCommitFunc(cmd *cobra.Command, args []string) error {
+	cli, info, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+	f, err := loadTxFile(cli.NetworkID())
+	if err != nil {
+		return err
+	}
+	msg, err := MakeMultisigTable(info, f)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+	pTx, err := f.Tx()
+	if err != nil {
+		return err
+	}
+	if !confirm("{{green}}Yes, let's commit!{{/}}") {
+		return errTxDeclined
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	txID, took, err := cli.P().IssueSignedTx(ctx, pTx, "multisig")
+	cancel()
+	if err != nil {
+		return err
+	}
+	color.Outf("{{magenta}}committed %s{{/}} {{light-gray}}(took %v){{/}}\n", txID, took)
+	return nil
+}
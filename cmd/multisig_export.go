@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+
+	"github.com/ava-labs/subnet-cli/internal/multisig"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newMultisigExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Exports the unsigned transaction for the external wallets",
+		Long: `
+Exports the unsigned transaction of the transaction file in the format of
+the external signing flow of Core and the Avalanche Wallet ("core"), for the
+control key holders signing with a GUI wallet. The (partially) signed
+transaction returned by the wallet is added back with "multisig import".
+
+$ subnet-cli multisig export \
+--tx-file=add-validator.json \
+--format=core \
+--output=add-validator.core.json
+
+`,
+		RunE: multisigExportFunc,
+	}
+	cmd.Flags().StringVar(&txExportFormat, "format", "core", "export format ('core')")
+	cmd.Flags().StringVar(&txExportPath, "output", "", "file path to write to (default to stdout)")
+	return cmd
+}
+
+func multisigExportFunc(cmd *cobra.Command, args []string) error {
+	if txExportFormat != "core" {
+		return fmt.Errorf("%w %q", errInvalidExportFormat, txExportFormat)
+	}
+	f, err := multisig.Load(txFilePath)
+	if err != nil {
+		return err
+	}
+	if err := f.CheckChain(constants.PlatformChainID); err != nil {
+		return err
+	}
+	b, err := f.Core()
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	if txExportPath == "" {
+		_, err := os.Stdout.Write(b)
+		return err
+	}
+	if err := ioutil.WriteFile(txExportPath, b, 0o644); err != nil {
+		return err
+	}
+	color.Outf("{{green}}exported %q to %q{{/}} {{light-gray}}(%s){{/}}\n", txFilePath, txExportPath, f.Description)
+	return nil
+}
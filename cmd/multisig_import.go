@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/multisig"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var (
+	errEmptySignedTx = errors.New("empty --signed-tx")
+	errNoNewSigs     = errors.New("signed transaction has no new signature")
+)
+
+func newMultisigImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Adds the signatures of an externally signed transaction",
+		Long: `
+Adds the signatures of the transaction signed by Core or the Avalanche
+Wallet (exported with "multisig export") to the transaction file. The
+signed transaction is the hex returned by the wallet, or its
+"signedTransactionHex" JSON response. Every signature is verified.
+
+$ subnet-cli multisig import \
+--tx-file=add-validator.json \
+--signed-tx=add-validator.signed.json
+
+`,
+		RunE: multisigImportFunc,
+	}
+	cmd.Flags().StringVar(&signedTxPath, "signed-tx", "", "file of the transaction signed by the wallet")
+	return cmd
+}
+
+func multisigImportFunc(cmd *cobra.Command, args []string) error {
+	if signedTxPath == "" {
+		return errEmptySignedTx
+	}
+	f, err := multisig.Load(txFilePath)
+	if err != nil {
+		return err
+	}
+	b, err := ioutil.ReadFile(signedTxPath)
+	if err != nil {
+		return err
+	}
+	signed, err := multisig.ParseSignedTx(b)
+	if err != nil {
+		return err
+	}
+	added, err := f.AddSigned(signed)
+	if err != nil {
+		return err
+	}
+	if len(added) == 0 {
+		return errNoNewSigs
+	}
+	if err := f.Save(txFilePath); err != nil {
+		return err
+	}
+	hrp := constants.GetHRP(f.NetworkID)
+	for _, signer := range added {
+		addr, err := formatting.FormatAddress("P", hrp, signer.Bytes())
+		if err != nil {
+			return err
+		}
+		color.Outf("{{green}}added the signature of %s{{/}}\n", addr)
+	}
+	if missing := len(f.Missing()); missing > 0 {
+		color.Outf("{{magenta}}imported %q, %d signature(s) still missing{{/}}\n", signedTxPath, missing)
+	} else {
+		color.Outf("{{green}}imported %q, ready to commit{{/}}\n", signedTxPath)
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,291 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/multisig"
+	"github.com/ava-labs/subnet-cli/internal/validate"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newMultisigProposeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose",
+		Short: "Proposes a subnet transaction to sign",
+	}
+	cmd.AddCommand(
+		newMultisigProposeSubnetValidatorCommand(),
+		newMultisigProposeBlockchainCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringSliceVar(&controlKeys, "control-keys", nil, "P-Chain addresses of the control keys to sign with (default to the ones of the key, then the others in order)")
+	addXPubFlags(cmd)
+	return cmd
+}
+
+func newMultisigProposeSubnetValidatorCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subnet-validator",
+		Short: "Proposes to add a subnet validator",
+		Long: `
+Proposes to add a subnet validator. The validation must start after all
+signatures are collected.
+
+$ subnet-cli multisig propose subnet-validator \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--node-id="NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH" \
+--validate-start=+24h \
+--validate-duration=14d \
+--tx-file=add-validator.json
+
+`,
+		RunE: multisigProposeSubnetValidatorFunc,
+	}
+	cmd.PersistentFlags().StringVar(&proposeNodeID, "node-id", "", "node ID (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringVar(&validateStarts, "validate-start", "+24h", "validate start time (RFC3339 timestamp, or relative to now, e.g., '+5m'), must be in the future when committed")
+	addValidateEndFlags(cmd, "", "validate end time, default to the end of the primary network validation")
+	cmd.PersistentFlags().Uint64Var(&validateWeight, "validate-weight", defaultValidateWeight, "validate weight")
+	addStakeDurationFlags(cmd)
+	addOutputFlags(cmd)
+	addMemoFlag(cmd)
+	return cmd
+}
+
+func newMultisigProposeBlockchainCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "blockchain",
+		Short: "Proposes to create a blockchain",
+		Long: `
+Proposes to create a blockchain.
+
+$ subnet-cli multisig propose blockchain \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--chain-name=my-custom-chain \
+--vm-id=tGas3T58KzdjLHhBDMnH2TvrddhqTji5iZAMZ3RXs2NLpSnhH \
+--vm-genesis-path=.my-custom-vm.genesis \
+--tx-file=create-blockchain.json
+
+`,
+		RunE: multisigProposeBlockchainFunc,
+	}
+	cmd.PersistentFlags().StringVar(&chainName, "chain-name", "", "chain name")
+	cmd.PersistentFlags().StringVar(&vmIDs, "vm-id", "", "VM ID (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringVar(&vmGenesisPath, "vm-genesis-path", "", "VM genesis file path")
+	return cmd
+}
+
+func multisigProposeSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
+	cli, info, err := initPropose()
+	if err != nil {
+		return err
+	}
+	defer info.key.Close()
+
+	nodeID, err := ids.ShortFromPrefixedString(proposeNodeID, constants.NodeIDPrefix)
+	if err != nil {
+		return err
+	}
+	info.nodeIDs = []ids.ShortID{nodeID}
+	info.validateStart, err = validate.Time(validateStarts, time.Now())
+	if err != nil {
+		return err
+	}
+	if validateEnds != "" || validateDurations != "" {
+		info.validateEnd, err = parseValidateEnd(info.validateStart)
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		_, info.validateEnd, err = cli.P().GetValidator(ctx, ids.Empty, nodeID)
+		cancel()
+	}
+	if err != nil {
+		return err
+	}
+	if err := CheckClockSkew(publicURI); err != nil {
+		return err
+	}
+	if err := CheckStakeDuration(cli.NetworkID(), time.Now(), info.validateStart, info.validateEnd); err != nil {
+		return err
+	}
+	info.validateWeight = validateWeight
+	if info.validateWeight == 0 {
+		return errZeroValidateWeight
+	}
+	info.txFee = uint64(info.feeData.TxFee)
+	info.requiredBalance = info.txFee
+	if err := info.CheckBalance(); err != nil {
+		return err
+	}
+
+	signers, err := parseControlKeys(cli, info)
+	if err != nil {
+		return err
+	}
+	p := new(client.Proposal)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	_, err = cli.P().AddSubnetValidator(
+		ctx,
+		info.key,
+		info.subnetID,
+		nodeID,
+		info.validateStart,
+		info.validateEnd,
+		info.validateWeight,
+		client.WithSubnetSigners(signers),
+		client.WithProposal(p),
+		client.WithOutputOwners(info.outputOwners),
+		client.WithMemo(info.memo),
+	)
+	cancel()
+	if err != nil {
+		return err
+	}
+	desc := fmt.Sprintf("add %s to subnet %s validator set with weight %d (%s to %s)",
+		nodeID.PrefixedString(constants.NodeIDPrefix),
+		info.subnetID,
+		info.validateWeight,
+		info.validateStart.Format(time.RFC3339),
+		info.validateEnd.Format(time.RFC3339),
+	)
+	return savePropose(cli, info, p, desc)
+}
+
+func multisigProposeBlockchainFunc(cmd *cobra.Command, args []string) error {
+	cli, info, err := initPropose()
+	if err != nil {
+		return err
+	}
+	defer info.key.Close()
+
+	info.vmID, err = ids.FromString(vmIDs)
+	if err != nil {
+		return err
+	}
+	vmGenesisBytes, err := ioutil.ReadFile(vmGenesisPath)
+	if err != nil {
+		return err
+	}
+	info.chainName = chainName
+	info.txFee = uint64(info.feeData.CreateBlockchainTxFee)
+	info.requiredBalance = info.txFee
+	if err := info.CheckBalance(); err != nil {
+		return err
+	}
+
+	signers, err := parseControlKeys(cli, info)
+	if err != nil {
+		return err
+	}
+	p := new(client.Proposal)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	_, _, err = cli.P().CreateBlockchain(
+		ctx,
+		info.key,
+		info.subnetID,
+		info.chainName,
+		info.vmID,
+		vmGenesisBytes,
+		client.WithSubnetSigners(signers),
+		client.WithProposal(p),
+		client.WithOutputOwners(info.outputOwners),
+		client.WithMemo(info.memo),
+	)
+	cancel()
+	if err != nil {
+		return err
+	}
+	desc := fmt.Sprintf("create blockchain %q (VM ID %s, genesis %s) on subnet %s", info.chainName, info.vmID, vmGenesisPath, info.subnetID)
+	return savePropose(cli, info, p, desc)
+}
+
+func initPropose() (client.Client, *Info, error) {
+	cli, info, err := InitClient(publicURI, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	info.subnetID, err = ids.FromString(subnetIDs)
+	if err != nil {
+		info.key.Close()
+		return nil, nil, err
+	}
+	return cli, info, nil
+}
+
+// parseControlKeys returns the control keys to sign with: the ones given,
+// or the ones held by the key first, then the others in order.
+func parseControlKeys(cli client.Client, i *Info) ([]ids.ShortID, error) {
+	if len(controlKeys) > 0 {
+		signers := make([]ids.ShortID, len(controlKeys))
+		for idx, addr := range controlKeys {
+			var err error
+			signers[idx], err = ParsePAddress(addr)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return signers, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	owners, err := cli.P().SubnetOwners(ctx, i.subnetID)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	held := make(map[ids.ShortID]struct{})
+	for _, addr := range i.key.Addresses() {
+		held[addr] = struct{}{}
+	}
+	signers := make([]ids.ShortID, 0, len(owners.Addrs))
+	for _, addr := range owners.Addrs {
+		if _, ok := held[addr]; ok {
+			signers = append(signers, addr)
+		}
+	}
+	for _, addr := range owners.Addrs {
+		if uint32(len(signers)) >= owners.Threshold {
+			break
+		}
+		if _, ok := held[addr]; !ok {
+			signers = append(signers, addr)
+		}
+	}
+	return signers, nil
+}
+
+// savePropose signs the proposal with the proposer key,
+// and writes the transaction file.
+func savePropose(cli client.Client, i *Info, p *client.Proposal, desc string) error {
+	f, err := multisig.New(cli.NetworkID(), desc, p.Tx, p.Signers)
+	if err != nil {
+		return err
+	}
+	if err := signTxFile(i, f); err != nil {
+		return err
+	}
+	if err := f.Save(txFilePath); err != nil {
+		return err
+	}
+	msg, err := MakeMultisigTable(i, f)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+	color.Outf("{{magenta}}saved the transaction to %q, share it with the missing signers{{/}}\n", txFilePath)
+	return nil
+}
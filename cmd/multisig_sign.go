@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/multisig"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newMultisigSignCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign",
+		Short: "Adds the signatures of the key to the transaction file",
+		Long: `
+Adds the signatures of the key to the transaction file.
+
+$ subnet-cli multisig sign \
+--private-key-path=.control-key.pk \
+--public-uri=http://localhost:52250 \
+--tx-file=add-validator.json
+
+`,
+		RunE: multisigSignFunc,
+	}
+	return cmd
+}
+
+var errNothingToSign = errors.New("key holds none of the missing signers")
+
+func multisigSignFunc(cmd *cobra.Command, args []string) error {
+	cli, info, err := InitClient(publicURI, true)
+	if err != nil {
+		return err
+	}
+	defer info.key.Close()
+
+	f, err := loadTxFile(cli.NetworkID())
+	if err != nil {
+		return err
+	}
+	if info.allowlist != nil {
+		utx, err := f.Unsigned()
+		if err != nil {
+			return err
+		}
+		// the change goes to the signers of the inputs
+		if err := checkAllowlist(info.allowlist, utx, cli.NetworkID(), f.Required()); err != nil {
+			return err
+		}
+	}
+	msg, err := MakeMultisigTable(info, f)
+	if err != nil {
+		return err
+	}
+	if enablePrompt {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to sign the transaction, should we continue?{{/}}\n") + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+	if !confirm("{{green}}Yes, let's sign!{{/}}") {
+		return errTxDeclined
+	}
+
+	before := len(f.Missing())
+	if err := signTxFile(info, f); err != nil {
+		return err
+	}
+	if len(f.Missing()) == before {
+		return errNothingToSign
+	}
+	if err := f.Save(txFilePath); err != nil {
+		return err
+	}
+	if missing := len(f.Missing()); missing > 0 {
+		color.Outf("{{magenta}}signed %q, %d signature(s) still missing{{/}}\n", txFilePath, missing)
+	} else {
+		color.Outf("{{green}}signed %q, ready to commit{{/}}\n", txFilePath)
+	}
+	return nil
+}
+
+// signTxFile adds the signatures of the missing signers held by the key.
+func signTxFile(i *Info, f *multisig.File) error {
+	sigs, err := i.key.SignHash(f.Hash(), f.Missing())
+	if err != nil {
+		return err
+	}
+	for signer, sig := range sigs {
+		if err := f.AddSignature(signer, sig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NodeCommand implements "subnet-cli node" command.
+func NodeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "node",
+		Short: "Sub-commands for configuring nodes",
+	}
+	cmd.AddCommand(
+		newNodeTrackSubnetCommand(),
+		newNodeIDCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&nodeURL, "node-url", "http://localhost:9650", "URI of the node (e.g., to check its health after the restart)")
+	cmd.PersistentFlags().StringVar(&sshTarget, "ssh", "", "SSH destination of the node host (e.g., ubuntu@10.0.0.1), empty for the local host")
+	cmd.PersistentFlags().StringVar(&nodeConfigPath, "config-file", "~/.avalanchego/configs/node.json", "path of the node config file on the node host")
+	return cmd
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/node"
+)
+
+func newNodeIDCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "id",
+		Short: "Prints the node ID of a staking certificate",
+		Long: `
+Computes the node ID from the TLS staking certificate of the node
+(e.g., "~/.avalanchego/staking/staker.crt") locally, without any API
+access to the node. The node ID is printed alone, to be passed to
+"--node-ids" (e.g., "add validator"). Without "--cert", the node ID
+is queried from "--node-url".
+
+$ subnet-cli node id --cert=staker.crt
+
+`,
+		RunE: nodeIDFunc,
+	}
+	cmd.PersistentFlags().StringVar(&certPath, "cert", "", "path of the PEM-encoded staking certificate of the node")
+	return cmd
+}
+
+func nodeIDFunc(cmd *cobra.Command, args []string) error {
+	if certPath == "" {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		nodeID, err := node.New(nodeURL).NodeID(ctx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		fmt.Println(nodeID)
+		return nil
+	}
+
+	b, err := ioutil.ReadFile(certPath)
+	if err != nil {
+		return err
+	}
+	nodeID, err := node.NodeIDFromCert(b)
+	if err != nil {
+		return fmt.Errorf("%s: %w", certPath, err)
+	}
+	fmt.Println(nodeID.PrefixedString(constants.NodeIDPrefix))
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/node"
+	"github.com/ava-labs/subnet-cli/internal/nodeconfig"
+	"github.com/ava-labs/subnet-cli/internal/poll"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newNodeTrackSubnetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "track-subnet",
+		Short: "Adds a subnet to the tracked subnets of a node",
+		Long: `
+Adds the subnet to the "whitelisted-subnets" of the node config file, on the
+local host or over SSH, and optionally restarts the node and waits until it
+is healthy. The previous config is kept with the ".bak" suffix. The node
+admin API cannot update the tracked subnets, so the config file is edited.
+
+$ subnet-cli node track-subnet \
+--ssh=ubuntu@10.0.0.1 \
+--config-file=/home/ubuntu/.avalanchego/configs/node.json \
+--subnet-id=[SUBNET ID] \
+--restart-command="sudo systemctl restart avalanchego" \
+--node-url=http://10.0.0.1:9650
+
+`,
+		RunE: nodeTrackSubnetFunc,
+	}
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID to track (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringVar(&restartCommand, "restart-command", "", "command to restart the node on its host (empty to not restart)")
+	return cmd
+}
+
+func nodeTrackSubnetFunc(cmd *cobra.Command, args []string) error {
+	subnetID, err := ids.FromString(subnetIDs)
+	if err != nil {
+		return err
+	}
+	host, path := nodeconfig.Local(), nodeConfigPath
+	if sshTarget != "" {
+		host = nodeconfig.SSH(sshTarget)
+	} else if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		path = filepath.Join(home, path[2:])
+	}
+
+	color.Outf("\n{{blue}}Updating %s on %s...{{/}}\n", path, host)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	prev, err := host.Read(ctx, path)
+	cancel()
+	if err != nil {
+		if sshTarget != "" || !os.IsNotExist(err) {
+			return err
+		}
+		// new config file
+		prev = nil
+	}
+	updated, changed, err := nodeconfig.TrackSubnet(prev, subnetID.String())
+	if err != nil {
+		return err
+	}
+	if !changed {
+		color.Outf("{{magenta}}%s already tracks subnet %s{{/}}\n", host, subnetID)
+		return nil
+	}
+	if !confirm("{{green}}Yes, update the node config!{{/}}") {
+		return errAborted
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	err = host.Write(ctx, path, updated)
+	cancel()
+	if err != nil {
+		return err
+	}
+	color.Outf("{{green}}added subnet %s to %s{{/}}\n", subnetID, path)
+
+	if restartCommand == "" {
+		color.Outf("{{yellow}}restart the node to start tracking the subnet{{/}}\n")
+		return nil
+	}
+	color.Outf("{{blue}}Restarting the node...{{/}}\n")
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	err = host.Run(ctx, restartCommand)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	cli := node.New(nodeURL)
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	took, err := poll.New(pollInterval).Poll(ctx, func() (bool, error) {
+		return cli.Healthy(ctx)
+	})
+	cancel()
+	if err != nil {
+		color.Outf("{{red}}node %s is not healthy after the restart{{/}}\n", nodeURL)
+		return err
+	}
+	color.Outf("{{green}}node %s is healthy{{/}} {{light-gray}}(took %v){{/}}\n", nodeURL, took)
+	return nil
+}
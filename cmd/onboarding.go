@@ -0,0 +1,21 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// OnboardingCommand implements "subnet-cli onboarding" command.
+func OnboardingCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "onboarding",
+		Short: "Sub-commands for onboarding the validators of a subnet",
+	}
+	cmd.AddCommand(
+		newOnboardingBundleCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	return cmd
+}
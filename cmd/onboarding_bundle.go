@@ -0,0 +1,185 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/onboarding"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var errNoSubnetBlockchains = errors.New("subnet has no blockchain")
+
+func newOnboardingBundleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle [options]",
+		Short: "Generates the onboarding bundle of a new subnet validator",
+		Long: `
+Generates a tarball with everything a new validator operator needs to
+validate the subnet, from the on-chain data: the subnet ID and its
+control keys, the blockchains with their VM IDs, genesis and genesis
+hashes, the chain configs (from "--chain-config-dir"), the node config
+snippet to track the subnet, and the verification instructions. If
+"--vm-binary" is set, its SHA-256 is included for the operators to
+verify their VM binary.
+
+$ subnet-cli onboarding bundle \
+--public-uri=http://localhost:52250 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--vm-binary=build/srEXiWaHuhNyGwPUi444Tu47ZEDwxTWrbQiuD7FmgSAQ6X7Dy \
+--chain-config-dir=~/.avalanchego/configs/chains
+
+`,
+		RunE: onboardingBundleFunc,
+	}
+
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringVar(&bundleOutputPath, "output", "", "file path to write the bundle to (default to \"onboarding-<subnet ID>.tar.gz\")")
+	cmd.PersistentFlags().StringVar(&vmBinaryPath, "vm-binary", "", "path of the VM binary to include the SHA-256 of (the binary itself is not bundled)")
+	cmd.PersistentFlags().StringVar(&vmBinarySource, "vm-binary-source", "", "where the operators get the VM binary from (e.g., a release URL)")
+	cmd.PersistentFlags().StringVar(&chainConfigDir, "chain-config-dir", "", "chain configs directory to bundle the \"<blockchain ID>/config.json\" of")
+	return cmd
+}
+
+func onboardingBundleFunc(cmd *cobra.Command, args []string) error {
+	cli, info, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+	subnetID, err := ids.FromString(subnetIDs)
+	if err != nil {
+		return err
+	}
+
+	b, err := makeOnboardingBundle(cli, info, subnetID)
+	if err != nil {
+		return err
+	}
+	if vmBinaryPath != "" {
+		b.VMBinary, err = hashVMBinary(vmBinaryPath)
+		if err != nil {
+			return err
+		}
+		b.VMBinary.Source = vmBinarySource
+	}
+
+	if bundleOutputPath == "" {
+		bundleOutputPath = b.Dir() + ".tar.gz"
+	}
+	f, err := os.Create(bundleOutputPath)
+	if err != nil {
+		return err
+	}
+	if err := b.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	color.Outf("{{green}}wrote the onboarding bundle of %d blockchain(s) to %q{{/}}\n", len(b.Chains), bundleOutputPath)
+	return nil
+}
+
+// makeOnboardingBundle collects the subnet owners and the blockchains
+// of the subnet from the P-Chain.
+func makeOnboardingBundle(cli client.Client, info *Info, subnetID ids.ID) (*onboarding.Bundle, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	owners, err := cli.P().SubnetOwners(ctx, subnetID)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	hrp := constants.GetHRP(info.networkID)
+	b := &onboarding.Bundle{
+		NetworkID:   info.networkID,
+		NetworkName: info.networkName,
+		SubnetID:    subnetID.String(),
+		Threshold:   owners.Threshold,
+		GeneratedAt: time.Now().UTC(),
+	}
+	for _, addr := range owners.Addrs {
+		paddr, err := formatting.FormatAddress("P", hrp, addr.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		b.ControlKeys = append(b.ControlKeys, paddr)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	bcs, err := cli.P().GetBlockchains(ctx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	for _, bc := range bcs {
+		if bc.SubnetID != subnetID {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		tx, err := cli.P().BlockchainTx(ctx, bc.ID)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the blockchain %s: %w", bc.ID, err)
+		}
+		fxIDs := make([]string, 0, len(tx.FxIDs))
+		for _, fxID := range tx.FxIDs {
+			fxIDs = append(fxIDs, fxID.String())
+		}
+		var config []byte
+		if chainConfigDir != "" {
+			config, err = ioutil.ReadFile(filepath.Join(chainConfigDir, bc.ID.String(), "config.json"))
+			if err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+		}
+		b.Chains = append(b.Chains, onboarding.NewChain(
+			bc.ID.String(),
+			tx.ChainName,
+			tx.VMID.String(),
+			fxIDs,
+			tx.GenesisData,
+			config,
+		))
+	}
+	if len(b.Chains) == 0 {
+		return nil, fmt.Errorf("%w %s", errNoSubnetBlockchains, subnetID)
+	}
+	sort.Slice(b.Chains, func(i, j int) bool { return b.Chains[i].Name < b.Chains[j].Name })
+	return b, nil
+}
+
+func hashVMBinary(p string) (*onboarding.VMBinary, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return nil, err
+	}
+	return &onboarding.VMBinary{
+		SHA256: hex.EncodeToString(h.Sum(nil)),
+		Size:   n,
+	}, nil
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/node"
+)
+
+var (
+	errNoNodeURLs    = errors.New("no --node-urls")
+	errNodesNotReady = errors.New("nodes not ready")
+)
+
+// PingCommand implements "subnet-cli ping" command.
+func PingCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ping",
+		Short: "Compares the readiness of the nodes",
+		Long: `
+Concurrently queries the health, the bootstrap status of the primary
+network chains, the version, and the tracked subnets of each node, and
+prints a comparison matrix. Fails if any node is unreachable, unhealthy,
+or not bootstrapped, e.g., to verify the fleet before adding validators.
+
+The tracked subnets are the subnets of the chains with a health check
+on the node.
+
+$ subnet-cli ping \
+--node-urls=http://10.0.0.1:9650,http://10.0.0.2:9650
+
+`,
+		RunE: pingFunc,
+	}
+	cmd.PersistentFlags().StringSliceVar(&nodeURLs, "node-urls", nil, "URIs of the nodes to query")
+	return cmd
+}
+
+type pingResult struct {
+	uri    string
+	status *node.Status
+	err    error
+}
+
+func pingFunc(cmd *cobra.Command, args []string) error {
+	if len(nodeURLs) == 0 {
+		return errNoNodeURLs
+	}
+	results := make([]pingResult, len(nodeURLs))
+	runPool(len(nodeURLs), len(nodeURLs), func(i int) {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		s, err := node.Ping(ctx, node.New(nodeURLs[i]))
+		results[i] = pingResult{uri: nodeURLs[i], status: s, err: err}
+	})
+
+	fmt.Fprint(formatter.ColorableStdOut, makePingTable(results))
+	notReady := 0
+	for _, r := range results {
+		if r.err != nil || !r.status.Healthy || !r.status.Bootstrapped {
+			notReady++
+		}
+	}
+	if notReady > 0 {
+		return fmt.Errorf("%w: %d of %d", errNodesNotReady, notReady, len(results))
+	}
+	return nil
+}
+
+func makePingTable(results []pingResult) string {
+	// highlight the versions and tracked subnets that differ from the most
+	// common ones
+	versions, tracked := make(map[string]int), make(map[string]int)
+	for _, r := range results {
+		if r.err == nil {
+			versions[r.status.Version]++
+			tracked[strings.Join(r.status.TrackedSubnets, ",")]++
+		}
+	}
+	commonVersion, commonTracked := mostCommon(versions), mostCommon(tracked)
+
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+	tb.SetHeader([]string{"node URL", "node ID", "version", "healthy", "bootstrapped", "tracked subnets", "error"})
+	for _, r := range results {
+		if r.err != nil {
+			tb.Append([]string{r.uri, r.status.NodeID, r.status.Version, "", "", "", formatter.F("{{red}}%v{{/}}", r.err)})
+			continue
+		}
+		version := formatter.F("{{green}}%s{{/}}", r.status.Version)
+		if r.status.Version != commonVersion {
+			version = formatter.F("{{red}}%s{{/}}", r.status.Version)
+		}
+		subnets := strings.Join(r.status.TrackedSubnets, "\n")
+		if strings.Join(r.status.TrackedSubnets, ",") != commonTracked {
+			subnets = formatter.F("{{red}}%s{{/}}", subnets)
+		}
+		tb.Append([]string{
+			r.uri,
+			r.status.NodeID,
+			version,
+			formatBool(r.status.Healthy),
+			formatBool(r.status.Bootstrapped),
+			subnets,
+			"",
+		})
+	}
+	tb.Render()
+	return buf.String()
+}
+
+// mostCommon returns the key with the highest count, the smallest on ties.
+func mostCommon(counts map[string]int) string {
+	common, best := "", 0
+	for k, n := range counts {
+		if n > best || (n == best && k < common) {
+			common, best = k, n
+		}
+	}
+	return common
+}
+
+func formatBool(b bool) string {
+	if b {
+		return formatter.F("{{green}}true{{/}}")
+	}
+	return formatter.F("{{red}}false{{/}}")
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/dustin/go-humanize"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/rebalance"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var (
+	errNewNodesMismatch = errors.New("--new-nodes does not match the number of --node-ids")
+	errOutputNoNodeIDs  = errors.New("--output requires --node-ids")
+	errAlreadyValidator = errors.New("node is already a subnet validator")
+)
+
+func newPlanWeightsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "weights [options]",
+		Short: "Suggests the weights of new subnet validators",
+		Long: `
+Analyzes the current subnet validator weights, and suggests the weight of
+the new validators such that no single validator exceeds
+"--max-weight-percent" of the total weight once they are added. The
+suggested weight is the mean of the current weights, raised to keep the
+heaviest current validator under the limit.
+
+With "--node-ids" and "--output", the new validators and their weights are
+written as the target weights file of "rebalance", which adds them in batch.
+
+$ subnet-cli plan weights \
+--public-uri=http://localhost:52250 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--new-nodes=5 \
+--max-weight-percent=20
+
+$ subnet-cli plan weights \
+--public-uri=http://localhost:52250 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--node-ids="NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH,NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET" \
+--output=weights.json
+$ subnet-cli rebalance \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--target-weights=weights.json
+
+`,
+		RunE: planWeightsFunc,
+	}
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
+	cmd.PersistentFlags().IntVar(&newNodes, "new-nodes", 0, "number of new validators (default to the number of --node-ids)")
+	cmd.PersistentFlags().StringSliceVar(&nodeIDs, "node-ids", nil, "a list of the new node IDs")
+	cmd.PersistentFlags().Uint64Var(&maxWeightPercent, "max-weight-percent", 20, "maximum share of a single validator in the total weight, in percent")
+	cmd.PersistentFlags().StringVar(&weightsOutputPath, "output", "", "file path to write the target weights of the new validators to (for 'rebalance --target-weights')")
+	return cmd
+}
+
+func planWeightsFunc(cmd *cobra.Command, args []string) error {
+	switch {
+	case newNodes == 0:
+		newNodes = len(nodeIDs)
+	case len(nodeIDs) > 0 && newNodes != len(nodeIDs):
+		return fmt.Errorf("%w (%d != %d)", errNewNodesMismatch, newNodes, len(nodeIDs))
+	}
+	if weightsOutputPath != "" && len(nodeIDs) == 0 {
+		return errOutputNoNodeIDs
+	}
+	newIDs := make([]ids.ShortID, len(nodeIDs))
+	for i, s := range nodeIDs {
+		var err error
+		newIDs[i], err = ids.ShortFromPrefixedString(s, constants.NodeIDPrefix)
+		if err != nil {
+			return err
+		}
+	}
+
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+	subnetID, err := ids.FromString(subnetIDs)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	vs, err := cli.P().GetValidators(ctx, subnetID)
+	cancel()
+	if err != nil {
+		return err
+	}
+	current := make(map[ids.ShortID]uint64, len(vs))
+	total, heaviest := uint64(0), uint64(0)
+	for _, v := range vs {
+		current[v.NodeID] = v.Weight
+		total += v.Weight
+		if v.Weight > heaviest {
+			heaviest = v.Weight
+		}
+	}
+	for _, nodeID := range newIDs {
+		if _, ok := current[nodeID]; ok {
+			return fmt.Errorf("%w: %s", errAlreadyValidator, nodeID.PrefixedString(constants.NodeIDPrefix))
+		}
+	}
+
+	s, err := rebalance.SuggestWeights(current, newNodes, maxWeightPercent, defaultValidateWeight)
+	if err != nil {
+		return err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	tb := newTxTable(buf, nil)
+	tb.Append([]string{formatter.F("{{blue}}SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", subnetID)})
+	tb.Append([]string{formatter.F("{{cyan}}{{bold}}CURRENT VALIDATORS{{/}}"), formatter.F("{{light-gray}}%d{{/}}", len(vs))})
+	tb.Append([]string{formatter.F("{{cyan}}{{bold}}CURRENT TOTAL WEIGHT{{/}}"), formatter.F("{{light-gray}}%s{{/}}", humanize.Comma(int64(total)))})
+	if total > 0 {
+		tb.Append([]string{formatter.F("{{cyan}}{{bold}}CURRENT MAX SHARE{{/}}"), formatter.F("{{light-gray}}%.2f%%{{/}}", float64(heaviest)/float64(total)*100)})
+	}
+	tb.Append([]string{formatter.F("{{magenta}}NEW VALIDATORS{{/}}"), formatter.F("{{light-gray}}%d{{/}}", newNodes)})
+	tb.Append([]string{formatter.F("{{magenta}}SUGGESTED WEIGHT{{/}}"), formatter.F("{{green}}{{bold}}%s{{/}}", humanize.Comma(int64(s.Weight)))})
+	bounds := fmt.Sprintf("%s - ", humanize.Comma(int64(s.Min)))
+	if s.Max == math.MaxUint64 {
+		bounds += "unbounded"
+	} else {
+		bounds += humanize.Comma(int64(s.Max))
+	}
+	tb.Append([]string{formatter.F("{{magenta}}ACCEPTED WEIGHTS{{/}}"), formatter.F("{{light-gray}}%s{{/}}", bounds)})
+	tb.Append([]string{formatter.F("{{magenta}}NEW TOTAL WEIGHT{{/}}"), formatter.F("{{light-gray}}%s{{/}}", humanize.Comma(int64(s.Total)))})
+	tb.Append([]string{formatter.F("{{magenta}}NEW MAX SHARE{{/}}"), formatter.F("{{light-gray}}%.2f%% (limit %d%%){{/}}", s.MaxPercent, maxWeightPercent)})
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+
+	if weightsOutputPath == "" {
+		return nil
+	}
+	target := make(map[string]uint64, len(newIDs))
+	for _, nodeID := range newIDs {
+		target[nodeID.PrefixedString(constants.NodeIDPrefix)] = s.Weight
+	}
+	b, err := json.MarshalIndent(target, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(weightsOutputPath, append(b, '\n'), 0o644); err != nil {
+		return err
+	}
+	color.Outf("{{green}}wrote the target weights of %d validator(s) to %q{{/}} {{light-gray}}(add them with 'subnet-cli rebalance --target-weights=%s'){{/}}\n",
+		len(newIDs), weightsOutputPath, weightsOutputPath)
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/plugin"
+	pkg_plugin "github.com/ava-labs/subnet-cli/pkg/plugin"
+)
+
+// PluginCommand implements "subnet-cli plugin" command.
+func PluginCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Sub-commands for the plugins",
+	}
+	cmd.AddCommand(
+		newPluginListCommand(),
+	)
+	return cmd
+}
+
+func newPluginListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the plugins on the PATH",
+		Long: `
+Lists the plugins, the executables named "subnet-cli-<name>" on the PATH.
+Each plugin runs as "subnet-cli <name>", unless the name is taken by a
+built-in command.
+
+$ subnet-cli plugin list
+
+`,
+		RunE: pluginListFunc,
+	}
+	return cmd
+}
+
+func pluginListFunc(cmd *cobra.Command, args []string) error {
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+	tb.SetHeader([]string{"name", "path", "status"})
+	for _, p := range plugin.Discover(os.Getenv("PATH")) {
+		status := formatter.F("{{green}}enabled{{/}}")
+		if isBuiltinCommand(p.Name) {
+			status = formatter.F("{{red}}shadowed by the built-in command{{/}}")
+		}
+		tb.Append([]string{p.Name, p.Path, status})
+	}
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	return nil
+}
+
+// addPluginCommands adds a command for each plugin on the PATH,
+// except for the ones named after a built-in command.
+func addPluginCommands(root *cobra.Command) {
+	for _, p := range plugin.Discover(os.Getenv("PATH")) {
+		if isBuiltinCommand(p.Name) {
+			continue
+		}
+		root.AddCommand(newPluginRunCommand(p))
+	}
+}
+
+func isBuiltinCommand(name string) bool {
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	// added by cobra on execution
+	return name == "help" || name == "completion"
+}
+
+func newPluginRunCommand(p plugin.Plugin) *cobra.Command {
+	return &cobra.Command{
+		Use:   p.Name,
+		Short: fmt.Sprintf("Plugin (%s)", p.Path),
+		Long: fmt.Sprintf(`
+Runs the plugin %q with all the arguments. If --public-uri is set,
+the network and the P-Chain addresses of the key (with --private-key-path
+or --ledger) are passed to the plugin in the %s
+JSON handshake.
+
+`, p.Path, pkg_plugin.HandshakeEnvVar),
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPlugin(p, args)
+		},
+	}
+}
+
+func runPlugin(p plugin.Plugin, args []string) error {
+	values, rest := plugin.ExtractFlags(args, []string{"public-uri", "private-key-path"}, []string{"ledger"})
+	h := &pkg_plugin.Handshake{
+		Version:        pkg_plugin.HandshakeVersion,
+		Name:           p.Name,
+		LogLevel:       logLevel,
+		PollInterval:   pollInterval.String(),
+		RequestTimeout: requestTimeout.String(),
+		PublicURI:      values["public-uri"],
+		PrivateKeyPath: values["private-key-path"],
+	}
+	if v, ok := values["ledger"]; ok {
+		var err error
+		h.Ledger, err = strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+	}
+	if h.PublicURI != "" {
+		privKeyPath, useLedger = h.PrivateKeyPath, h.Ledger
+		loadKey := h.PrivateKeyPath != "" || h.Ledger
+		cli, info, err := InitClient(h.PublicURI, loadKey)
+		if err != nil {
+			return err
+		}
+		h.NetworkID, h.NetworkName = cli.NetworkID(), info.networkName
+		if loadKey {
+			h.KeyAddresses = info.key.P()
+			// release the key (e.g., the ledger) for the plugin
+			info.key.Close()
+		}
+	}
+	return p.Run(context.Background(), h, rest, os.Stdin, os.Stdout, os.Stderr)
+}
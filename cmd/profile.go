@@ -0,0 +1,277 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/profile"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var (
+	errProfileExists      = errors.New("profile already exists")
+	errProfileNetwork     = errors.New("--network conflicts with the network of the profile")
+	errInvalidProfileFlag = errors.New("invalid --set (must be 'flag=value' of a subnet-cli flag)")
+)
+
+// ProfileCommand implements "subnet-cli profile" command.
+func ProfileCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Sub-commands for managing the network profiles",
+		Long: `
+Manages the named profiles, each bundling the endpoint, the network, the
+key, and the flag defaults of a network. A profile is selected with
+--profile, or else the one in use ("profile use"). The flags set on the
+command line take precedence over the profile, except --network: the
+endpoint must report the network of the profile to sign any tx, so that
+the networks are never mixed.
+
+$ subnet-cli profile create prod --network=mainnet --public-uri=https://api.avax.network --ledger
+$ subnet-cli profile create fuji-team --network=fuji --private-key-path=fuji-team.pk --set=validate-weight=1000
+$ subnet-cli profile use fuji-team
+$ subnet-cli --profile prod status subnet --subnet-id=[SUBNET ID]
+
+`,
+	}
+	cmd.AddCommand(
+		newProfileListCommand(),
+		newProfileUseCommand(),
+		newProfileCreateCommand(),
+		newProfileRemoveCommand(),
+	)
+	return cmd
+}
+
+func newProfileListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists the profiles",
+		RunE:  profileListFunc,
+	}
+}
+
+func newProfileUseCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use [name]",
+		Short: "Selects the profile of the commands run without --profile (none without a name)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  profileUseFunc,
+	}
+}
+
+func newProfileCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Creates a profile (requires --network)",
+		Args:  cobra.ExactArgs(1),
+		RunE:  profileCreateFunc,
+	}
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "", "URI for avalanche network endpoints of the profile")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", "", "private key file path, or key store URI, of the profile")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "'true' for the profile to sign with the ledger")
+	cmd.PersistentFlags().StringSliceVar(&profileDefaults, "set", nil, "default values of the other flags (e.g., 'validate-weight=1000,max-rps=5')")
+	return cmd
+}
+
+func newProfileRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rm <name>",
+		Short: "Removes a profile",
+		Args:  cobra.ExactArgs(1),
+		RunE:  profileRemoveFunc,
+	}
+}
+
+func loadProfiles() (*profile.Store, string, error) {
+	p, err := profile.DefaultPath()
+	if err != nil {
+		return nil, "", err
+	}
+	s, err := profile.Load(p)
+	if err != nil {
+		return nil, "", err
+	}
+	return s, p, nil
+}
+
+// applyProfile sets the flags of [cmd] to the values of the selected
+// profile, unless set on the command line.
+func applyProfile(cmd *cobra.Command) error {
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		if c.Name() == "profile" && c.Parent() == cmd.Root() {
+			return nil
+		}
+	}
+	s, _, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	name := profileName
+	if !cmd.Flags().Changed("profile") {
+		name = s.Current
+	}
+	if name == "" {
+		return nil
+	}
+	p, err := s.Get(name)
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("network") {
+		want, err := constants.NetworkID(p.Network)
+		if err != nil {
+			return err
+		}
+		got, err := constants.NetworkID(expectedNetwork)
+		if err != nil {
+			return err
+		}
+		if got != want {
+			return fmt.Errorf("%w: %q of %q", errProfileNetwork, p.Network, name)
+		}
+	}
+	for k, v := range p.Flags() {
+		// "--endpoint auto" selects the endpoint
+		if endpointMode != "" && containsString(endpointURIFlags, k) {
+			continue
+		}
+		f := cmd.Flag(k)
+		if f == nil || f.Changed {
+			continue
+		}
+		if err := cmd.Flags().Set(k, v); err != nil {
+			return fmt.Errorf("invalid --%s of profile %q: %w", k, name, err)
+		}
+	}
+	color.Outf("{{light-gray}}using profile %q (%s){{/}}\n", name, p.Network)
+	return nil
+}
+
+func profileListFunc(cmd *cobra.Command, args []string) error {
+	s, _, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	if len(s.Profiles) == 0 {
+		color.Outf("{{magenta}}no profile{{/}} {{light-gray}}(see 'profile create'){{/}}\n")
+		return nil
+	}
+	buf := bytes.NewBuffer(nil)
+	tb := newTxTable(buf, []string{"", "name", "network", "public URI", "key", "defaults"})
+	for _, name := range s.Names() {
+		p := s.Profiles[name]
+		current := ""
+		if name == s.Current {
+			current = "*"
+		}
+		key := p.PrivateKeyPath
+		if p.Ledger {
+			key = "ledger"
+		}
+		defaults := make([]string, 0, len(p.Defaults))
+		for k, v := range p.Defaults {
+			defaults = append(defaults, k+"="+v)
+		}
+		sort.Strings(defaults)
+		tb.Append([]string{current, name, p.Network, p.PublicURI, key, strings.Join(defaults, ", ")})
+	}
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	return nil
+}
+
+func profileUseFunc(cmd *cobra.Command, args []string) error {
+	s, p, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	if err := s.Use(name); err != nil {
+		return err
+	}
+	if err := s.Save(p); err != nil {
+		return err
+	}
+	if name == "" {
+		color.Outf("{{green}}using no profile{{/}}\n")
+		return nil
+	}
+	color.Outf("{{green}}using profile %q (%s){{/}}\n", name, s.Profiles[name].Network)
+	return nil
+}
+
+func profileCreateFunc(cmd *cobra.Command, args []string) error {
+	s, p, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	name := args[0]
+	if _, ok := s.Profiles[name]; ok {
+		return fmt.Errorf("%w: %q (see 'profile rm')", errProfileExists, name)
+	}
+	defaults := make(map[string]string, len(profileDefaults))
+	for _, kv := range profileDefaults {
+		ss := strings.SplitN(kv, "=", 2)
+		if len(ss) != 2 || ss[0] == "profile" || !flagDefined(cmd.Root(), ss[0]) {
+			return fmt.Errorf("%w: %q", errInvalidProfileFlag, kv)
+		}
+		defaults[ss[0]] = ss[1]
+	}
+	if err := s.Set(name, profile.Profile{
+		Network:        expectedNetwork,
+		PublicURI:      publicURI,
+		PrivateKeyPath: privKeyPath,
+		Ledger:         useLedger,
+		Defaults:       defaults,
+	}); err != nil {
+		return err
+	}
+	if err := s.Save(p); err != nil {
+		return err
+	}
+	color.Outf("{{green}}created profile %q (%s){{/}} {{light-gray}}(see 'profile use'){{/}}\n", name, expectedNetwork)
+	return nil
+}
+
+// flagDefined returns true if any command of [cmd] defines the flag [name].
+func flagDefined(cmd *cobra.Command, name string) bool {
+	if cmd.PersistentFlags().Lookup(name) != nil || cmd.LocalNonPersistentFlags().Lookup(name) != nil {
+		return true
+	}
+	for _, c := range cmd.Commands() {
+		if flagDefined(c, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func profileRemoveFunc(cmd *cobra.Command, args []string) error {
+	s, p, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	name := args[0]
+	if !s.Remove(name) {
+		return fmt.Errorf("%w: %q", profile.ErrNotFound, name)
+	}
+	if err := s.Save(p); err != nil {
+		return err
+	}
+	color.Outf("{{green}}removed profile %q{{/}}\n", name)
+	return nil
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/subnet-cli/internal/progress"
+)
+
+var (
+	errInvalidOutputFormat = errors.New("invalid --output (must be 'text' or 'json')")
+	errAborted             = errors.New("aborted by the operator")
+)
+
+// progressEvents emits the progress events of the long operations,
+// nil if neither "--output json" nor "--progress-fd" is set.
+var progressEvents *progress.Emitter
+
+// openProgress sets up the progress events: to "--progress-fd" if set,
+// otherwise to stderr with "--output json".
+func openProgress() error {
+	switch outputFormat {
+	case "text", "json":
+	default:
+		return fmt.Errorf("%w: %q", errInvalidOutputFormat, outputFormat)
+	}
+	switch {
+	case progressFD > 0:
+		progressEvents = progress.New(os.NewFile(uintptr(progressFD), "progress"))
+	case outputFormat == "json":
+		progressEvents = progress.New(os.Stderr)
+	}
+	return nil
+}
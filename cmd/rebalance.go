@@ -0,0 +1,245 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/dustin/go-humanize"
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/manifest"
+	"github.com/ava-labs/subnet-cli/internal/rebalance"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// RebalanceCommand implements "subnet-cli rebalance" command.
+func RebalanceCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rebalance",
+		Short: "Converges the subnet validator weights to the target ones",
+		Long: `
+Computes the difference between the current and the target subnet validator
+weights, and issues the transactions to converge. Nodes missing from the
+target file are left untouched, and a zero weight removes the node.
+
+The current weights include the pending validators. The dropped and the
+reweighted validators are removed first, then the new and the reweighted
+ones are added with their target weight, until the end of their primary
+network validation period.
+
+$ subnet-cli rebalance \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--target-weights=weights.json \
+--dry-run
+
+# weights.json
+{
+  "NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH": 1000,
+  "NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET": 0
+}
+
+`,
+		RunE: rebalanceFunc,
+	}
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path (not required with --dry-run)")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringVar(&targetWeightsPath, "target-weights", "", "JSON file of the target validator weights, keyed by node ID")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "'true' to only show the plan, without loading any key")
+	addStakeDurationFlags(cmd)
+	addOutputFlags(cmd)
+	addMemoFlag(cmd)
+	addFeeKeyFlags(cmd)
+	return cmd
+}
+
+func rebalanceFunc(cmd *cobra.Command, args []string) error {
+	target, err := manifest.LoadWeights(targetWeightsPath)
+	if err != nil {
+		return err
+	}
+	cli, info, err := InitClient(publicURI, !dryRun)
+	if err != nil {
+		return err
+	}
+	if !dryRun {
+		defer info.key.Close()
+	}
+	info.subnetID, err = ids.FromString(subnetIDs)
+	if err != nil {
+		return err
+	}
+
+	current, err := subnetWeights(cli, info.subnetID)
+	if err != nil {
+		return err
+	}
+	actions := rebalance.Plan(current, target)
+
+	msg := makeRebalanceTable(actions)
+	if dryRun {
+		fmt.Fprint(formatter.ColorableStdOut, msg)
+		return nil
+	}
+
+	// a reweight is a removal then an addition
+	adds := rebalance.Count(actions, rebalance.Add)
+	removes := rebalance.Count(actions, rebalance.Remove)
+	reweights := rebalance.Count(actions, rebalance.Reweight)
+	txs := adds + removes + 2*reweights
+	if txs == 0 {
+		fmt.Fprint(formatter.ColorableStdOut, msg)
+		color.Outf("{{magenta}}subnet validator weights already converged{{/}}\n")
+		return nil
+	}
+
+	info.txFee = uint64(info.feeData.TxFee) * uint64(txs)
+	info.requiredBalance = info.txFee
+	if err := info.CheckBalance(); err != nil {
+		return err
+	}
+	if err := info.CheckSubnetAuth(cli); err != nil {
+		return err
+	}
+	if err := CheckClockSkew(publicURI); err != nil {
+		return err
+	}
+	if enablePrompt {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to rebalance subnet validators, should we continue?{{/}}\n") + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+	if !info.confirmSpend() {
+		return errTxDeclined
+	}
+
+	println()
+	println()
+	println()
+	for _, a := range actions {
+		if a.Kind != rebalance.Remove && a.Kind != rebalance.Reweight {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		took, err := cli.P().RemoveSubnetValidator(
+			ctx,
+			info.key,
+			info.subnetID,
+			a.NodeID,
+			client.WithMemo(info.memo),
+			client.WithFeeKey(info.feeKey),
+		)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to remove %s: %w", a.NodeID, err)
+		}
+		color.Outf("{{magenta}}removed %s from subnet %s validator set{{/}} {{light-gray}}(took %v){{/}}\n", a.NodeID, info.subnetID, took)
+	}
+
+	added := make([]ids.ShortID, 0, adds+reweights)
+	for _, a := range actions {
+		if a.Kind != rebalance.Add && a.Kind != rebalance.Reweight {
+			continue
+		}
+		// subnet validation period must be within the primary network one
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		_, end, err := cli.P().GetValidator(ctx, ids.Empty, a.NodeID)
+		cancel()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		start := now.Add(validateStartBuffer)
+		if err := CheckStakeDuration(cli.NetworkID(), now, start, end); err != nil {
+			return fmt.Errorf("%s: %w", a.NodeID, err)
+		}
+		ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+		took, err := cli.P().AddSubnetValidator(
+			ctx,
+			info.key,
+			info.subnetID,
+			a.NodeID,
+			start,
+			end,
+			a.To,
+			client.WithOutputOwners(info.outputOwners),
+			client.WithMemo(info.memo),
+			client.WithFeeKey(info.feeKey),
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+		added = append(added, a.NodeID)
+		color.Outf("{{magenta}}added %s to subnet %s validator set with weight %d{{/}} {{light-gray}}(took %v){{/}}\n\n", a.NodeID, info.subnetID, a.To, took)
+	}
+	WaitValidator(cli, added, info)
+	return nil
+}
+
+// subnetWeights returns the weights of the current and the pending
+// validators of the subnet.
+func subnetWeights(cli client.Client, subnetID ids.ID) (map[ids.ShortID]uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	current, err := cli.P().GetValidators(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := cli.P().GetPendingValidators(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	weights := make(map[ids.ShortID]uint64, len(current)+len(pending))
+	for _, vs := range [][]client.Validator{current, pending} {
+		for _, v := range vs {
+			weights[v.NodeID] = v.Weight
+		}
+	}
+	return weights, nil
+}
+
+func makeRebalanceTable(actions []rebalance.Action) string {
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+	tb.SetHeader([]string{"node ID", "action", "current weight", "target weight", "note"})
+	for _, a := range actions {
+		var action, note string
+		switch a.Kind {
+		case rebalance.Keep:
+			action = formatter.F("{{light-gray}}%s{{/}}", a.Kind)
+		case rebalance.Add:
+			action = formatter.F("{{green}}%s{{/}}", a.Kind)
+		case rebalance.Reweight:
+			action = formatter.F("{{yellow}}%s{{/}}", a.Kind)
+			note = "removed, then added with the target weight"
+		default:
+			action = formatter.F("{{yellow}}%s{{/}}", a.Kind)
+		}
+		tb.Append([]string{
+			labeled(a.NodeID.PrefixedString(constants.NodeIDPrefix)),
+			action,
+			humanize.Comma(int64(a.From)),
+			humanize.Comma(int64(a.To)),
+			note,
+		})
+	}
+	tb.Render()
+	return buf.String()
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/receipt"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// ReceiptCommand implements "subnet-cli receipt" command.
+func ReceiptCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "receipt",
+		Short: "Sub-commands for the tx receipts",
+	}
+	cmd.AddCommand(
+		newReceiptVerifyCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	return cmd
+}
+
+// recordReceipt prints the explorer link of the committed tx, and saves
+// its receipt if "--receipt-dir" is set.
+func recordReceipt(networkID uint32, c client.Committed) {
+	r := receipt.New(networkID, c.TxID.String(), c.TxType, c.Fee)
+	if r.Explorer != "" {
+		color.Outf("{{cyan}}%s{{/}} %s\n", c.TxType, r.Explorer)
+	}
+	if receiptDir == "" {
+		return
+	}
+	p, err := r.Save(receiptDir)
+	if err != nil {
+		color.Outf("{{red}}failed to save the receipt of %s: %v{{/}}\n", c.TxID, err)
+		return
+	}
+	color.Outf("{{light-gray}}saved receipt %q{{/}}\n", p)
+}
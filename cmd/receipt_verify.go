@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	pstatus "github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/amount"
+	"github.com/ava-labs/subnet-cli/internal/receipt"
+)
+
+var (
+	errReceiptNetwork      = errors.New("receipt is for another network")
+	errReceiptNotCommitted = errors.New("receipt tx is not committed")
+)
+
+func newReceiptVerifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify [RECEIPT FILES]",
+		Short: "Re-checks the status of the receipt txs",
+		Long: `
+Re-checks the status of the txs of the receipts saved with "--receipt-dir",
+and fails if any is not committed.
+
+$ subnet-cli receipt verify \
+--public-uri=https://api.avax-test.network \
+receipts/create_subnet-2Q9bbD....json
+
+`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: receiptVerifyFunc,
+	}
+	return cmd
+}
+
+func receiptVerifyFunc(cmd *cobra.Command, args []string) error {
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	tb := newTxTable(buf, []string{"TX ID", "TYPE", "FEE", "TIMESTAMP", "STATUS", "EXPLORER"})
+	failed := 0
+	for _, p := range args {
+		r, err := receipt.Load(p)
+		if err != nil {
+			return err
+		}
+		if r.NetworkID != cli.NetworkID() {
+			return fmt.Errorf("%w: %q is for network %d, expected %d", errReceiptNetwork, p, r.NetworkID, cli.NetworkID())
+		}
+		txID, err := ids.FromString(r.TxID)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		resp, err := cli.P().Client().GetTxStatus(ctx, txID, true)
+		cancel()
+		if err != nil {
+			return err
+		}
+		status := formatter.F("{{green}}%s{{/}}", resp.Status)
+		if resp.Status != pstatus.Committed {
+			failed++
+			status = formatter.F("{{red}}%s %s{{/}}", resp.Status, resp.Reason)
+		}
+		tb.Append([]string{
+			r.TxID,
+			r.Type,
+			amount.Format(r.Fee),
+			r.Timestamp.Format(time.RFC3339),
+			status,
+			r.Explorer,
+		})
+	}
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", errReceiptNotCommitted, failed, len(args))
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/history"
+)
+
+// ReportCommand implements "subnet-cli report" command.
+func ReportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Sub-commands for the reports of the past txs",
+	}
+	cmd.AddCommand(
+		newReportSpendCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	return cmd
+}
+
+// recordHistory records the committed tx in the local history,
+// for the reports.
+func recordHistory(networkID uint32, c client.Committed) {
+	p, err := history.DefaultPath()
+	if err == nil {
+		err = history.Open(p).Record(history.Entry{
+			Time:      time.Now().UTC(),
+			NetworkID: networkID,
+			TxID:      c.TxID.String(),
+			TxType:    c.TxType,
+			Fee:       c.Fee,
+		})
+	}
+	if err != nil {
+		zap.L().Warn("failed to record the tx history", zap.Error(err))
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/history"
+	"github.com/ava-labs/subnet-cli/internal/txs"
+	"github.com/ava-labs/subnet-cli/internal/validate"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var (
+	errReportNoSince = errors.New("--since is required")
+	errReportRange   = errors.New("--until must be after --since")
+)
+
+func newReportSpendCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "spend",
+		Short: "Reports the fees paid and the stake locked over a time range",
+		Long: `
+Reports the fees paid and the stake locked by the txs committed with
+subnet-cli on the network over a time range, in total and per subnet.
+The txs are read from the local history (~/.subnet-cli/history.log),
+and their fee, stake, and subnet from the chain. With --csv, exports
+the txs as CSV for accounting.
+
+$ subnet-cli report spend \
+--public-uri=https://api.avax.network \
+--since=2024-01-01 \
+--until=2024-04-01 \
+--csv=spend-2024-q1.csv
+
+`,
+		RunE: reportSpendFunc,
+	}
+	cmd.PersistentFlags().StringVar(&reportSince, "since", "", "start of the range, as a date (e.g., '2024-01-01'), an RFC3339 timestamp, or relative to now (e.g., '-30d')")
+	cmd.PersistentFlags().StringVar(&reportUntil, "until", "", "end of the range (exclusive) in the same formats as --since, default to now")
+	cmd.PersistentFlags().StringVar(&reportCSVPath, "csv", "", "file path to export the txs of the report as CSV")
+	return cmd
+}
+
+func reportSpendFunc(cmd *cobra.Command, args []string) error {
+	if reportSince == "" {
+		return errReportNoSince
+	}
+	now := time.Now()
+	since, err := parseReportTime(reportSince, now)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	until := time.Time{}
+	if reportUntil != "" {
+		until, err = parseReportTime(reportUntil, now)
+		if err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+		if !until.After(since) {
+			return errReportRange
+		}
+	}
+
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+	p, err := history.DefaultPath()
+	if err != nil {
+		return err
+	}
+	es, err := history.Open(p).Entries(cli.NetworkID(), since, until)
+	if err != nil {
+		return err
+	}
+	rows := make([]history.Row, 0, len(es))
+	for _, e := range es {
+		rows = append(rows, reportRow(cli, e))
+	}
+	r := history.NewReport(rows)
+
+	buf := bytes.NewBuffer(nil)
+	tb := newTxTable(buf, []string{"SUBNET", "TXS", "FEES ($AVAX)", "STAKE ($AVAX)"})
+	for _, s := range r.Subnets {
+		subnet := s.SubnetID
+		if subnet == "" {
+			subnet = "primary network"
+		}
+		tb.Append([]string{subnet, fmt.Sprintf("%d", s.Txs), formatAVAX(s.Fees), formatAVAX(s.Stake)})
+	}
+	tb.Append([]string{
+		formatter.F("{{bold}}TOTAL{{/}}"),
+		formatter.F("{{bold}}%d{{/}}", r.Total.Txs),
+		formatter.F("{{bold}}%s{{/}}", formatAVAX(r.Total.Fees)),
+		formatter.F("{{bold}}%s{{/}}", formatAVAX(r.Total.Stake)),
+	})
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+
+	if reportCSVPath == "" {
+		return nil
+	}
+	f, err := os.Create(reportCSVPath)
+	if err != nil {
+		return err
+	}
+	if err := r.WriteCSV(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	color.Outf("{{green}}exported %d tx(s) to %q{{/}}\n", len(r.Rows), reportCSVPath)
+	return nil
+}
+
+// parseReportTime parses [s] as a date, or as a timestamp of "validate.Time",
+// or as a duration before [now] (e.g., "-30d").
+func parseReportTime(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, nil
+	}
+	if len(s) > 1 && s[0] == '-' {
+		d, err := validate.Duration(s[1:])
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(-d), nil
+	}
+	return validate.Time(s, now)
+}
+
+// reportRow returns the row of the history entry, with the fee, the stake,
+// and the subnet of the tx on chain. It falls back to the recorded fee if
+// the tx cannot be fetched.
+func reportRow(cli client.Client, e history.Entry) history.Row {
+	row := history.Row{Time: e.Time, TxID: e.TxID, TxType: e.TxType, Fee: e.Fee}
+	txID, err := ids.FromString(e.TxID)
+	if err != nil {
+		zap.L().Warn("invalid tx ID in the history", zap.String("txId", e.TxID), zap.Error(err))
+		return row
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	b, err := cli.P().Client().GetTx(ctx, txID)
+	cancel()
+	if err == nil {
+		var s *txs.Summary
+		s, err = txs.Decode(b)
+		if err == nil {
+			applySummary(&row, s, cli.AssetID())
+		}
+	}
+	if err != nil {
+		color.Outf("{{yellow}}failed to fetch %s, reporting its recorded fee only: %v{{/}}\n", e.TxID, err)
+	}
+	return row
+}
+
+// applySummary sets the AVAX fee, the AVAX stake, and the subnet of the tx.
+func applySummary(row *history.Row, s *txs.Summary, avaxAssetID ids.ID) {
+	row.Fee = s.Fee[avaxAssetID]
+	for _, o := range s.Outputs {
+		if o.Kind == "stake" && o.AssetID == avaxAssetID {
+			row.Stake += o.Amount
+		}
+	}
+	if s.Type == "CreateSubnetTx" {
+		row.SubnetID = s.TxID.String()
+		return
+	}
+	for _, f := range s.Fields {
+		if f.Name == "subnet ID" {
+			row.SubnetID = f.Value
+		}
+	}
+}
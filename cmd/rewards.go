@@ -0,0 +1,21 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// RewardsCommand implements "subnet-cli rewards" command.
+func RewardsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rewards",
+		Short: "Sub-commands for the staking rewards",
+	}
+	cmd.AddCommand(
+		newRewardsEstimateCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	return cmd
+}
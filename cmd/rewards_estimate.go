@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/dustin/go-humanize"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/amount"
+	"github.com/ava-labs/subnet-cli/internal/staking"
+)
+
+func newRewardsEstimateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "estimate",
+		Short: "Estimates the staking rewards of a validator",
+		Long: `
+Estimates the rewards of validating the primary network with the stake
+amount for the duration, as the P-Chain would compute them with the current
+supply of the network. With --delegated-amount, also estimates the rewards
+of the delegations, and the fee the validator takes from them.
+
+The rewards are only paid if the validator meets the uptime requirement,
+and the supply grows over time, so the rewards of the validations starting
+later are lower.
+
+$ subnet-cli rewards estimate \
+--public-uri=https://api.avax.network \
+--stake-amount=2000avax \
+--delegated-amount=10000avax \
+--stake-duration=8760h \
+--validate-reward-fee-percent=2
+
+`,
+		RunE: rewardsEstimateFunc,
+	}
+	cmd.PersistentFlags().Var(amount.NewValue(defaultStakeAmount, &stakeAmount), "stake-amount", "stake amount in nano AVAX, or with a denomination (e.g., '2000avax')")
+	cmd.PersistentFlags().Var(amount.NewValue(0, &delegatedAmount), "delegated-amount", "total amount delegated to the validator in nano AVAX, or with a denomination")
+	cmd.PersistentFlags().DurationVar(&stakeDuration, "stake-duration", 365*24*time.Hour, "staking duration")
+	cmd.PersistentFlags().Uint32Var(&validateRewardFeePercent, "validate-reward-fee-percent", defaultValFeePercent, "percentage of fee that the validator will take rewards from its delegators")
+	return cmd
+}
+
+func rewardsEstimateFunc(cmd *cobra.Command, args []string) error {
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if err := staking.DefaultLimits(cli.NetworkID()).Check(now, now.Add(stakeDuration), now, 0); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	supply, err := cli.P().Client().GetCurrentSupply(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	e, err := staking.Rewards(cli.NetworkID(), stakeAmount, delegatedAmount, stakeDuration, float64(validateRewardFeePercent), supply)
+	if err != nil {
+		return err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	tb := newTxTable(buf, []string{"", "stake ($AVAX)", "reward ($AVAX)", "APR"})
+	tb.Append([]string{
+		formatter.F("{{magenta}}validator{{/}}"),
+		formatAVAX(stakeAmount),
+		formatAVAX(e.Reward + e.DelegationFee),
+		fmt.Sprintf("%.4f%%", e.APR),
+	})
+	if delegatedAmount > 0 {
+		tb.Append([]string{
+			formatter.F("{{light-gray}}  from the delegation fee{{/}}"),
+			"",
+			formatAVAX(e.DelegationFee),
+			"",
+		})
+		tb.Append([]string{
+			formatter.F("{{magenta}}delegators{{/}}"),
+			formatAVAX(delegatedAmount),
+			formatAVAX(e.DelegatorReward),
+			fmt.Sprintf("%.4f%%", e.DelegatorAPR),
+		})
+	}
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	fmt.Fprint(formatter.ColorableStdOut, formatter.F("{{light-gray}}current supply %s $AVAX, staking for %v with a %d%% delegation fee{{/}}\n",
+		formatAVAX(supply),
+		stakeDuration,
+		validateRewardFeePercent,
+	))
+	return nil
+}
+
+func formatAVAX(v uint64) string {
+	return humanize.FormatFloat("#,###.#########", float64(v)/float64(units.Avax))
+}
@@ -4,21 +4,93 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/spf13/cobra"
 
+	"github.com/ava-labs/subnet-cli/internal/amount"
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/internal/metrics"
+	"github.com/ava-labs/subnet-cli/internal/proxy"
+	"github.com/ava-labs/subnet-cli/internal/ratelimit"
+	"github.com/ava-labs/subnet-cli/internal/spend"
 	"github.com/ava-labs/subnet-cli/pkg/logutil"
+	"github.com/ava-labs/subnet-cli/pkg/prompt"
 )
 
+// Version is the release version of subnet-cli (e.g., "0.0.3"),
+// set at build time (ref. ".goreleaser.yml").
+var Version = "dev"
+
 var rootCmd = &cobra.Command{
 	Use:        "subnet-cli",
 	Short:      "subnet-cli CLI",
 	SuggestFor: []string{"subnet-cli", "subnetcli", "subnetctl"},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if skipPrompt {
+			enablePrompt = false
+		}
+		if !enablePrompt {
+			SetPrompter(prompt.NewAuto())
+		}
+		if err := applyProfile(cmd); err != nil {
+			return err
+		}
+		// the key of the flag (or the profile) wins over the environment
+		privKeyFromEnv = !cmd.Flags().Changed("private-key-path") && os.Getenv(key.PrivateKeyEnvVar) != ""
+		if err := validateFlags(cmd); err != nil {
+			return err
+		}
+		if err := openProgress(); err != nil {
+			return err
+		}
+		proxyFn, err := proxy.Func(proxyURL, os.Getenv)
+		if err != nil {
+			return err
+		}
+		proxy.Install(proxyFn)
+		cfg, err := loadCLIConfig()
+		if err != nil {
+			return err
+		}
+		spendLimits = cfg.SpendLimits
+		if allowlistPath == "" {
+			allowlistPath = cfg.Allowlist
+		}
+		commandName = strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+		if endpointMode != "" {
+			if err := resolveEndpoint(cmd, cfg); err != nil {
+				return err
+			}
+		}
+		// after the endpoint is selected, to authenticate to it
+		if err := installAuth(cmd, cfg); err != nil {
+			return err
+		}
+		if maxRPS > 0 {
+			ratelimit.Install(maxRPS)
+		}
+		if metricsAddr != "" {
+			stopMetrics, err = metrics.Serve(metricsAddr)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	},
 }
 
+// stopMetrics shuts down the metrics server, if any.
+var stopMetrics = func(context.Context) error { return nil }
+
 var (
 	enablePrompt bool
+	skipPrompt   bool
 	logLevel     string
 
 	privKeyPath string
@@ -29,24 +101,235 @@ var (
 
 	pollInterval   time.Duration
 	requestTimeout time.Duration
+	maxTxRetries   int
+	metricsAddr    string
+	proxyURL       string
+	maxRPS         float64
+	endpointMode   string
+	outputFormat   string
+	progressFD     int
+	cliConfigPath  string
+	authToken      string
+	tlsCert        string
+	tlsKey         string
+	tlsCACert      string
+
+	expectedNetwork string
+	receiptDir      string
+	auditLogPath    string
+
+	feeKeyPath string
+
+	evmChainRPC     string
+	bytecodePath    string
+	constructorArgs string
+	evmGasLimit     uint64
+
+	icmChains            []string
+	messengerTxPath      string
+	messengerAddress     string
+	registryBytecodePath string
+
+	cacheDir string
+	cacheTTL time.Duration
+	noCache  bool
+
+	lockDir string
+	noLock  bool
+
+	feeOverride      string
+	feeOverrideLimit uint64
+
+	passphraseFile string
+	passphraseFD   int
+
+	snapshotOut           string
+	snapshotIgnoreExpired bool
 
 	subnetIDs   string
 	nodeIDs     []string
+	nodesFile   string
 	stakeAmount uint64
+	stakeLocked bool
 
 	validateEnds             string
+	validateDurations        string
+	validateStartBuffer      time.Duration
+	minStakeDuration         time.Duration
+	maxStakeDuration         time.Duration
+	maxClockSkew             time.Duration
 	validateWeight           uint64
+	validateWeightAuto       bool
+	copyFromSubnet           string
 	validateRewardFeePercent uint32
 
 	rewardAddrs string
 	changeAddrs string
 
+	transferTo     string
+	transferAmount uint64
+	memo           string
+	deductFee      bool
+
 	chainName     string
 	vmIDs         string
 	vmGenesisPath string
 
+	genesisTemplatePath string
+	genesisVars         []string
+
+	chainlistPath string
+
+	precompilesPath      string
+	configurePrecompiles bool
+
+	reportSince   string
+	reportUntil   string
+	reportCSVPath string
+
+	doctorOperationName string
+
+	followLogs    bool
+	logLines      int
+	nodeLogDir    string
+	chainLogLevel string
+
+	endpoints []string
+	minUptime float64
+
 	blockchainID      string
 	checkBootstrapped bool
+	checkEVM          bool
+
+	exportFormat     string
+	exportOutputPath string
+
+	pAddress string
+
+	minValidators int
+	timelineWidth int
+
+	drainNodeID   string
+	drainAt       string
+	drainInterval time.Duration
+	drainOnce     bool
+
+	convertTo string
+
+	labelsPath     string
+	labelTags      []string
+	labelOverwrite bool
+
+	profileName     string
+	profileDefaults []string
+
+	simulateAddresses []string
+
+	newControlKeys []string
+	newThreshold   uint32
+
+	dotenvPath          string
+	dotenvKey           string
+	hardhatNetworksPath string
+	hardhatNetwork      string
+
+	targetWeightsPath string
+	dryRun            bool
+
+	newNodes          int
+	maxWeightPercent  uint64
+	weightsOutputPath string
+
+	sourceURI     string
+	sourceChainID string
+
+	faucetURL    string
+	faucetChain  string
+	captchaToken string
+	faucetWait   bool
+
+	txFilePath     string
+	txExportFormat string
+	txExportPath   string
+	signedTxPath   string
+	stateFilePath  string
+	controlKeys    []string
+	proposeNodeID  string
+	validateStarts string
+
+	walletNetworkID uint32
+	keySeed         string
+	insecure        bool
+	gapLimit        uint32
+	saveScanned     bool
+
+	xpub           string
+	watchAddresses int
+	showUnfunded   bool
+
+	listMine      bool
+	listAddresses []string
+
+	nodeURLs   []string
+	chainAlias string
+
+	waitBootstrapped bool
+	bootstrapTimeout time.Duration
+
+	wizardCount       int
+	wizardConcurrency int
+	wizardLocal       bool
+	runnerEndpoint    string
+	localExecPath     string
+	localNodes        uint32
+	localKeep         bool
+	wizardSpecPath    string
+	wizardSpecOutput  string
+	applyPrune        bool
+
+	nodeURL        string
+	sshTarget      string
+	nodeConfigPath string
+	restartCommand string
+	certPath       string
+
+	checkOnly        bool
+	releasePublicKey string
+
+	allowLargeSpend bool
+	// nil for no spend limit
+	spendLimits *spend.Limits
+	// path of the running command (e.g., "add validator")
+	commandName string
+
+	telemetryURL string
+
+	allowlistPath     string
+	overrideAllowlist bool
+
+	utxosFilePath   string
+	deterministic   bool
+	utxoAddresses   []string
+	utxosOutputPath string
+	maxInputs       int
+
+	messageText      string
+	messageFile      string
+	messageAddress   string
+	messageSignature string
+
+	delegatedAmount uint64
+	stakingAssetIDs string
+	stakeDuration   time.Duration
+
+	outputOwnerAddrs []string
+	outputThreshold  uint32
+	outputLocktime   string
+
+	bundleOutputPath string
+	vmBinaryPath     string
+	vmBinarySource   string
+	chainConfigDir   string
 )
 
 func init() {
@@ -56,18 +339,102 @@ func init() {
 		CreateCommand(),
 		AddCommand(),
 		StatusCommand(),
+		ExportCommand(),
+		BalanceCommand(),
+		TimelineCommand(),
+		RebalanceCommand(),
+		CloneCommand(),
+		FaucetCommand(),
+		MultisigCommand(),
+		HealthCommand(),
+		TxCommand(),
+		KeyCommand(),
+		ListCommand(),
+		AliasCommand(),
+		NodeCommand(),
 		WizardCommand(),
+		UTXOsCommand(),
+		ReceiptCommand(),
+		ReportCommand(),
+		PingCommand(),
+		PluginCommand(),
+		AuditCommand(),
+		EVMCommand(),
+		RewardsCommand(),
+		TransferCommand(),
+		UpdateCommand(),
+		OnboardingCommand(),
+		ICMCommand(),
+		PlanCommand(),
+		DecommissionCommand(),
+		DoctorCommand(),
+		LogsCommand(),
+		TelemetryCommand(),
+		ApplyCommand(),
+		DrainCommand(),
+		ConvertCommand(),
+		LabelCommand(),
+		ProfileCommand(),
+		SimulateCommand(),
+		RotateCommand(),
+		RPCURLCommand(),
+		SnapshotCommand(),
 	)
+	rootCmd.Version = Version
+	addPluginCommands(rootCmd)
+	registerCompletions(rootCmd)
+	markUsageErrors(rootCmd)
 
 	rootCmd.PersistentFlags().BoolVar(&enablePrompt, "enable-prompt", true, "'true' to enable prompt mode")
+	rootCmd.PersistentFlags().BoolVarP(&skipPrompt, "yes", "y", false, "'true' to skip all confirmation prompts (same as '--enable-prompt=false')")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", logutil.DefaultLogLevel, "log level")
 	rootCmd.PersistentFlags().DurationVar(&pollInterval, "poll-interval", time.Second, "interval to poll tx/blockchain status")
 	rootCmd.PersistentFlags().DurationVar(&requestTimeout, "request-timeout", 2*time.Minute, "request timeout")
+	rootCmd.PersistentFlags().IntVar(&maxTxRetries, "max-tx-retries", 2, "number of times to retry a tx dropped by the node (re-submitted as is, or rebuilt with the refetched UTXOs), 0 to not retry")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "profile of the endpoint, network, key, and flag defaults to use (default to the one of 'profile use'), empty for none")
+	rootCmd.PersistentFlags().StringVar(&expectedNetwork, "network", "", "expected network name or ID (e.g., 'mainnet', 'fuji', '12345') to refuse to sign txs on any other network, empty to not check")
+	rootCmd.PersistentFlags().StringVar(&auditLogPath, "audit-log", "", "hash-chained log file to record every signing operation in, empty to not record")
+	rootCmd.PersistentFlags().StringVar(&receiptDir, "receipt-dir", "", "directory to save the receipts of the committed txs, empty to not save")
+	rootCmd.PersistentFlags().StringVar(&cacheDir, "cache-dir", "", "directory to cache the subnets, blockchains, validators, and fee config in (default ~/.subnet-cli/cache)")
+	rootCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", time.Minute, "time to cache the network objects for, in the read-only commands")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "'true' to always query the network instead of the cache")
+	rootCmd.PersistentFlags().StringVar(&lockDir, "lock-dir", "", "directory of the wallet locks, held while the UTXOs of a key are spent, for the concurrent subnet-cli processes sharing the key to wait for each other (default ~/.subnet-cli/locks)")
+	rootCmd.PersistentFlags().StringVar(&feeOverride, "fee-override", "", "fees to use instead of the ones of 'info.getTxFee' (e.g., on a custom network): an amount for every fee (e.g., '0.001avax'), or by name (e.g., 'tx=1milliavax,create-subnet=0.1avax,create-blockchain=0.1avax'), empty to use the fees of the network")
+	rootCmd.PersistentFlags().Var(amount.NewValue(units.Avax, &feeOverrideLimit), "fee-override-limit", "fee above which --fee-override is confirmed twice, and refused with the prompts disabled")
+	rootCmd.PersistentFlags().StringVar(&passphraseFile, "passphrase-file", "", "file whose first line is the passphrase of the encrypted secrets (e.g., in CI without a terminal), empty to prompt for it")
+	rootCmd.PersistentFlags().IntVar(&passphraseFD, "passphrase-fd", 0, "file descriptor to read the passphrase of the encrypted secrets from (e.g., 3), 0 to disable")
+	rootCmd.PersistentFlags().BoolVar(&noLock, "no-lock", false, "'true' to spend the UTXOs of the key without locking its wallet")
+	rootCmd.PersistentFlags().StringVar(&utxosFilePath, "utxos-file", "", "file of the UTXOs to spend instead of querying them (see 'utxos export')")
+	rootCmd.PersistentFlags().BoolVar(&deterministic, "deterministic", false, "'true' to build the txs deterministically from --utxos-file (inputs in the order of the UTXO IDs, one change output per owner), and print their canonical digest")
+	rootCmd.PersistentFlags().StringVar(&proxyURL, "proxy", "", "HTTP or SOCKS5 proxy URL for the API calls (e.g., 'socks5://127.0.0.1:9050'), empty to use HTTPS_PROXY/HTTP_PROXY/ALL_PROXY")
+	rootCmd.PersistentFlags().Float64Var(&maxRPS, "max-rps", 0, "maximum number of API calls per second to each endpoint (e.g., '5' not to get throttled by a public API), 0 for no limit")
+	rootCmd.PersistentFlags().StringVar(&endpointMode, "endpoint", "", "'auto' to use the healthy endpoint of the lowest latency among the known ones of --network (default to fuji), instead of --public-uri")
+	rootCmd.PersistentFlags().StringVar(&cliConfigPath, "cli-config", "", "subnet-cli config file with the authentication of the private endpoints (default ~/.subnet-cli/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&authToken, "auth-token", "", "bearer token to send to the endpoints of the command (default to $"+authTokenEnv+")")
+	rootCmd.PersistentFlags().StringVar(&tlsCert, "tls-cert", "", "client certificate PEM file to authenticate to the endpoints of the command (mTLS)")
+	rootCmd.PersistentFlags().StringVar(&tlsKey, "tls-key", "", "client certificate key PEM file (requires --tls-cert)")
+	rootCmd.PersistentFlags().StringVar(&tlsCACert, "tls-ca-cert", "", "CA certificate PEM file to verify the endpoints of the command with, instead of the system roots")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "text", "output format ('text' or 'json'), 'json' to emit the progress events as NDJSON on stderr")
+	rootCmd.PersistentFlags().IntVar(&progressFD, "progress-fd", 0, "file descriptor to emit the progress events as NDJSON on (e.g., 3), 0 to disable")
+	rootCmd.PersistentFlags().BoolVar(&allowLargeSpend, "allow-large-spend", false, "'true' to spend above the soft spend limits of the config file")
+	rootCmd.PersistentFlags().StringVar(&allowlistPath, "allowlist", "", "file of the P-Chain addresses the txs may send funds to, one per line, besides the key addresses (default to the 'allowlist' of the config file)")
+	rootCmd.PersistentFlags().BoolVar(&overrideAllowlist, "override-allowlist", false, "'true' to send funds outside of the allowlist")
+	rootCmd.PersistentFlags().StringVar(&labelsPath, "labels-file", "", "local store of the names of the subnets, blockchains, and nodes to decorate the output with (default ~/.subnet-cli/labels.json)")
+	rootCmd.PersistentFlags().StringVar(&metricsAddr, "metrics-addr", "", "address to expose Prometheus metrics on while the command runs (e.g., ':9090'), empty to disable")
 }
 
 func Execute() error {
 	if err := CreateLogger(); err != nil {
 		return err
 	}
-	return rootCmd.Execute()
+	defer stopMetrics(context.Background())
+	start := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil && strings.HasPrefix(err.Error(), "unknown command") {
+		// not returned via "Args" (ref. cobra "legacyArgs")
+		err = fmt.Errorf("%w: %v", errUsage, err)
+	}
+	printHint(err)
+	reportTelemetry(cmd, time.Since(start), err)
+	return err
 }
@@ -0,0 +1,219 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/rotate"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var errOwnersNotRotated = errors.New("subnet owners are not the new control keys after the transfer")
+
+func newRotateControlKeysCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "control-keys",
+		Short: "Moves the control of a subnet to new control keys",
+		Long: `
+Plans the rotation of the subnet control keys, and executes it if the
+subnet owners can be transferred: one "TransferSubnetOwnershipTx"
+(requires Durango) signed by the current control keys, then a check of
+the new subnet owners. The key must hold enough current control keys.
+
+The owners of an elastic subnet are immutable, as are the ones of any
+subnet before Durango: the control then moves by re-creating the subnet,
+its blockchains, and its validators, which the plan lists as the manual
+steps (with the subnet-cli commands to run) without executing them.
+
+$ subnet-cli rotate control-keys \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--new-control-keys="P-custom1...,P-custom1..." \
+--new-threshold=2 \
+--dry-run
+
+`,
+		RunE: rotateControlKeysFunc,
+	}
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringSliceVar(&newControlKeys, "new-control-keys", nil, "P-Chain addresses of the new control keys")
+	cmd.PersistentFlags().Uint32Var(&newThreshold, "new-threshold", 1, "number of --new-control-keys required to sign the subnet txs")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "'true' to only show the plan, without loading any key")
+	addOutputFlags(cmd)
+	addMemoFlag(cmd)
+	addFeeKeyFlags(cmd)
+	return cmd
+}
+
+func rotateControlKeysFunc(cmd *cobra.Command, args []string) error {
+	addrs := make([]ids.ShortID, len(newControlKeys))
+	for i, addr := range newControlKeys {
+		var err error
+		addrs[i], err = ParsePAddress(addr)
+		if err != nil {
+			return err
+		}
+	}
+	next, err := client.NewOutputOwners(0, newThreshold, addrs)
+	if err != nil {
+		return err
+	}
+	cli, info, err := InitClient(publicURI, !dryRun)
+	if err != nil {
+		return err
+	}
+	if !dryRun {
+		defer info.key.Close()
+	}
+	info.subnetID, err = ids.FromString(subnetIDs)
+	if err != nil {
+		return err
+	}
+
+	in, err := fetchRotateInput(cli, info.subnetID, next)
+	if err != nil {
+		return err
+	}
+	plan, err := rotate.New(*in)
+	if err != nil {
+		return err
+	}
+	msg := makeRotateTable(plan)
+	if dryRun {
+		fmt.Fprint(formatter.ColorableStdOut, msg)
+		return nil
+	}
+	if plan.Method == rotate.Recreate {
+		fmt.Fprint(formatter.ColorableStdOut, msg)
+		color.Outf("{{yellow}}the owners of subnet %s can't be transferred, run the manual steps to re-create it{{/}}\n", info.subnetID)
+		return nil
+	}
+
+	info.txFee = uint64(info.feeData.TxFee)
+	info.requiredBalance = info.txFee
+	if err := info.CheckBalance(); err != nil {
+		return err
+	}
+	if err := info.CheckSubnetAuth(cli); err != nil {
+		return err
+	}
+	if enablePrompt {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to rotate the subnet control keys, should we continue?{{/}}\n") + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+	if !info.confirmSpend() {
+		return errTxDeclined
+	}
+	println()
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	took, err := cli.P().TransferSubnetOwnership(
+		ctx,
+		info.key,
+		info.subnetID,
+		next,
+		client.WithOutputOwners(info.outputOwners),
+		client.WithMemo(info.memo),
+		client.WithFeeKey(info.feeKey),
+	)
+	cancel()
+	if err != nil {
+		return err
+	}
+	color.Outf("{{magenta}}transferred subnet %s to the new control keys{{/}} {{light-gray}}(took %v){{/}}\n", info.subnetID, took)
+
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	owners, err := cli.P().SubnetOwners(ctx, info.subnetID)
+	cancel()
+	if err != nil {
+		return err
+	}
+	// ignoring the locktime of the "CreateSubnetTx" owners
+	if !(&secp256k1fx.OutputOwners{Threshold: owners.Threshold, Addrs: owners.Addrs}).Equals(next) {
+		return fmt.Errorf("%w (%d of %v)", errOwnersNotRotated, owners.Threshold, owners.Addrs)
+	}
+	color.Outf("{{green}}subnet %s is controlled by the new control keys{{/}}\n", info.subnetID)
+	for _, s := range plan.Steps {
+		if s.Manual {
+			color.Outf("{{yellow}}next:{{/}} %s\n", s.Description)
+		}
+	}
+	return nil
+}
+
+// fetchRotateInput fetches the current owners, the blockchains, and the
+// validators of the subnet.
+func fetchRotateInput(cli client.Client, subnetID ids.ID, next *secp256k1fx.OutputOwners) (*rotate.Input, error) {
+	in := &rotate.Input{
+		NetworkID:    cli.NetworkID(),
+		SubnetID:     subnetID,
+		Next:         next,
+		Transferable: checkTxType(cli, "TransferSubnetOwnershipTx") == nil,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	var err error
+	in.Current, err = cli.P().SubnetOwners(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = cli.P().StakingAsset(ctx, subnetID)
+	switch {
+	case err == nil:
+		in.Elastic = true
+	case !errors.Is(err, client.ErrNotElastic):
+		return nil, err
+	}
+	bcs, err := cli.P().GetBlockchains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, bc := range bcs {
+		if bc.SubnetID == subnetID {
+			in.Blockchains = append(in.Blockchains, rotate.Blockchain{ID: bc.ID, Name: bc.Name})
+		}
+	}
+	vs, err := subnetValidators(cli, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	for nodeID := range vs {
+		in.Validators = append(in.Validators, nodeID)
+	}
+	sort.Slice(in.Validators, func(i, j int) bool { return in.Validators[i].String() < in.Validators[j].String() })
+	return in, nil
+}
+
+func makeRotateTable(p *rotate.Plan) string {
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+	tb.SetHeader([]string{"#", "step", "run by"})
+	for i, s := range p.Steps {
+		by := formatter.F("{{green}}subnet-cli{{/}}")
+		if s.Manual {
+			by = formatter.F("{{yellow}}manual{{/}}")
+		}
+		tb.Append([]string{strconv.Itoa(i + 1), s.Description, by})
+	}
+	tb.Render()
+	return formatter.F("{{blue}}{{bold}}rotation by %s{{/}} {{light-gray}}(%d control key(s) added, %d removed){{/}}\n", p.Method, len(p.Added), len(p.Removed)) + buf.String()
+}
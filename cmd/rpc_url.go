@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/evm"
+	"github.com/ava-labs/subnet-cli/internal/node"
+	"github.com/ava-labs/subnet-cli/internal/rpcconfig"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var errChainNotBootstrapped = errors.New("chain is not bootstrapped on the node")
+
+// RPCURLCommand implements "subnet-cli rpc-url" command.
+func RPCURLCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rpc-url [BLOCKCHAIN ID OR ALIAS]",
+		Short: "Prints the JSON-RPC endpoint of a chain",
+		Long: `
+Resolves the chain on the node, prints its JSON-RPC endpoint
+("/ext/bc/[BLOCKCHAIN ID]/rpc"), and verifies that it responds: the EVM
+chain ID with "eth_chainId" (unless --evm=false), or the bootstrap status
+of the chain otherwise. The endpoint is the last line of the output.
+
+With --dotenv, sets the endpoint in a dotenv file (as --dotenv-key). With
+--hardhat-networks, sets it (with the EVM chain ID) as the network
+--hardhat-network of a JSON file of the Hardhat networks, to load in the
+Hardhat config (e.g., "networks: require('./networks.json')").
+
+$ subnet-cli rpc-url \
+--node-url=http://localhost:9650 \
+--chain-id=2ebCneCbwthjQ1rYT41nhd7M76Hc6YmosMAQrTFhBq8qeqh6tt \
+--dotenv=.env
+
+`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: rpcURLFunc,
+	}
+	cmd.PersistentFlags().StringVar(&nodeURL, "node-url", "http://localhost:9650", "URI of the node")
+	cmd.PersistentFlags().StringVar(&blockchainID, "chain-id", "", "blockchain ID or alias of the chain")
+	cmd.PersistentFlags().BoolVar(&checkEVM, "evm", true, "'true' to verify the endpoint with the EVM chain ID")
+	cmd.PersistentFlags().StringVar(&dotenvPath, "dotenv", "", "dotenv file to set the endpoint in (optional)")
+	cmd.PersistentFlags().StringVar(&dotenvKey, "dotenv-key", "RPC_URL", "key of the endpoint in the dotenv file")
+	cmd.PersistentFlags().StringVar(&hardhatNetworksPath, "hardhat-networks", "", "JSON file of the Hardhat networks to set the endpoint in (optional)")
+	cmd.PersistentFlags().StringVar(&hardhatNetwork, "hardhat-network", "subnet", "name of the network in the Hardhat networks file")
+	return cmd
+}
+
+func rpcURLFunc(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		blockchainID = args[0]
+	}
+	if blockchainID == "" {
+		return errEmptyChainID
+	}
+	cli := node.New(nodeURL)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	chainID, err := cli.BlockchainID(ctx, blockchainID)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to resolve chain %q on %s: %w", blockchainID, nodeURL, err)
+	}
+	rpcURL := evm.RPCURL(nodeURL, chainID)
+
+	var evmChainID uint64
+	if checkEVM {
+		ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+		id, err := evm.Dial(rpcURL).ChainID(ctx)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("%s does not respond: %w", rpcURL, err)
+		}
+		evmChainID = id.Uint64()
+		color.Outf("{{green}}%s responds{{/}} {{light-gray}}(EVM chain ID %s){{/}}\n", rpcURL, id)
+	} else {
+		ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+		bootstrapped, err := cli.Bootstrapped(ctx, chainID)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if !bootstrapped {
+			return fmt.Errorf("%w: %s on %s", errChainNotBootstrapped, chainID, nodeURL)
+		}
+		color.Outf("{{green}}chain %s is bootstrapped on %s{{/}}\n", chainID, nodeURL)
+	}
+
+	if dotenvPath != "" {
+		if err := rpcconfig.SetDotenv(dotenvPath, dotenvKey, rpcURL); err != nil {
+			return err
+		}
+		color.Outf("{{green}}set %s in %s{{/}}\n", dotenvKey, dotenvPath)
+	}
+	if hardhatNetworksPath != "" {
+		if err := rpcconfig.SetHardhatNetwork(hardhatNetworksPath, hardhatNetwork, rpcconfig.HardhatNetwork{
+			URL:     rpcURL,
+			ChainID: evmChainID,
+		}); err != nil {
+			return err
+		}
+		color.Outf("{{green}}set network %q in %s{{/}}\n", hardhatNetwork, hardhatNetworksPath)
+	}
+	fmt.Println(rpcURL)
+	return nil
+}
@@ -0,0 +1,204 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/codec"
+	"github.com/ava-labs/subnet-cli/internal/simulate"
+	"github.com/ava-labs/subnet-cli/internal/staking"
+	"github.com/ava-labs/subnet-cli/internal/txs"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// SimulateCommand implements "subnet-cli simulate" command.
+func SimulateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate [HEX OR FILE]",
+		Short: "Checks a P-Chain transaction against the current P-Chain state",
+		Long: `
+Fetches the P-Chain state the transaction depends on (the P-Chain time,
+the validators, the subnet owners, the UTXOs, and the fee), and checks
+the transaction against it locally, to catch the common failures (e.g., a
+duplicate validator, an invalid subnet auth, a spent UTXO) before any
+broadcast. The signatures are verified if the transaction is signed.
+These checks are not the full verification of the node, and a transaction
+that passes them may still be rejected.
+
+The transaction can be hex, CB58, a file of the encoded or raw
+transaction bytes, or a multisig transaction file (see "tx decode").
+The inputs are looked up in the UTXOs of the output owners (i.e., the
+change addresses), and of --addresses.
+
+$ subnet-cli simulate multisig-tx.json
+
+$ subnet-cli simulate 0x0000000000100000... --addresses=P-fuji1...
+
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: simulateFunc,
+	}
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringSliceVar(&simulateAddresses, "addresses", nil, "P-Chain addresses to look up the spent UTXOs in, besides the output owners")
+	cmd.PersistentFlags().DurationVar(&minStakeDuration, "min-stake-duration", 0, "minimum staking duration of the network (0 to use the network default)")
+	cmd.PersistentFlags().DurationVar(&maxStakeDuration, "max-stake-duration", 0, "maximum staking duration of the network (0 to use the network default)")
+	return cmd
+}
+
+func simulateFunc(cmd *cobra.Command, args []string) error {
+	b, err := readTxArg(args[0])
+	if err != nil {
+		return err
+	}
+	tx, signed, err := txs.Unmarshal(b)
+	if err != nil {
+		return err
+	}
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+	st, err := fetchSimulateState(cli, tx.UnsignedTx)
+	if err != nil {
+		return err
+	}
+	if !signed {
+		color.Outf("{{yellow}}unsigned transaction, not verifying the signatures{{/}}\n")
+	}
+	c := simulate.Verify(tx, signed, st)
+	fmt.Fprint(formatter.ColorableStdOut, makeDoctorTable(c))
+	if err := c.Err(); err != nil {
+		return err
+	}
+	color.Outf("{{green}}the transaction would pass the P-Chain verification at %s{{/}}\n", st.Timestamp.Format(time.RFC3339))
+	return nil
+}
+
+// fetchSimulateState fetches the P-Chain state [utx] depends on.
+func fetchSimulateState(cli client.Client, utx platformvm.UnsignedTx) (*simulate.State, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	limits, err := staking.DefaultLimits(cli.NetworkID()).Override(minStakeDuration, maxStakeDuration)
+	if err != nil {
+		return nil, err
+	}
+	ts, err := cli.P().Client().GetTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := cli.Info().TxFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+	st := &simulate.State{
+		NetworkID:   cli.NetworkID(),
+		AVAXAssetID: cli.AssetID(),
+		Timestamp:   ts,
+		Limits:      limits,
+		Validators:  make(map[ids.ID]map[ids.ShortID]simulate.Window),
+		Subnets:     make(map[ids.ID]*secp256k1fx.OutputOwners),
+		UTXOs:       make(map[ids.ID]*avax.UTXO),
+	}
+	var outs []*avax.TransferableOutput
+	switch tx := utx.(type) {
+	case *platformvm.UnsignedAddValidatorTx:
+		// no fee to add a validator (ref. "client.p.addValidator")
+		outs = append(tx.Outs, tx.Stake...)
+	case *platformvm.UnsignedAddSubnetValidatorTx:
+		st.Fee = uint64(fi.TxFee)
+		outs = tx.Outs
+	case *platformvm.UnsignedCreateSubnetTx:
+		st.Fee = uint64(fi.CreateSubnetTxFee)
+		outs = tx.Outs
+	case *platformvm.UnsignedCreateChainTx:
+		st.Fee = uint64(fi.CreateBlockchainTxFee)
+		outs = tx.Outs
+	}
+
+	subnetIDs := []ids.ID{constants.PrimaryNetworkID}
+	if subnetID, ok := simulate.Subnet(utx); ok {
+		subnetIDs = append(subnetIDs, subnetID)
+		owners, err := cli.P().SubnetOwners(ctx, subnetID)
+		if err != nil {
+			// reported as a missing subnet
+			zap.L().Debug("failed to get the subnet owners", zap.Stringer("subnetId", subnetID), zap.Error(err))
+		} else {
+			st.Subnets[subnetID] = owners
+		}
+	}
+	for _, subnetID := range subnetIDs {
+		st.Validators[subnetID], err = validatorWindows(ctx, cli, subnetID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	hrp := constants.GetHRP(cli.NetworkID())
+	addrs := simulateAddresses
+	for _, out := range outs {
+		owned, ok := out.Out.(avax.Addressable)
+		if !ok {
+			continue
+		}
+		for _, a := range owned.Addresses() {
+			addr, err := formatting.FormatAddress("P", hrp, a)
+			if err != nil {
+				return nil, err
+			}
+			if !containsString(addrs, addr) {
+				addrs = append(addrs, addr)
+			}
+		}
+	}
+	if len(addrs) > 0 {
+		ubs, err := cli.P().UTXOs(ctx, addrs)
+		if err != nil {
+			return nil, err
+		}
+		for _, ub := range ubs {
+			utxo := new(avax.UTXO)
+			if _, err := codec.PCodecManager.Unmarshal(ub, utxo); err != nil {
+				return nil, err
+			}
+			st.UTXOs[utxo.InputID()] = utxo
+		}
+	}
+	return st, nil
+}
+
+// validatorWindows returns the validation periods of the current and
+// pending validators of the subnet.
+func validatorWindows(ctx context.Context, cli client.Client, subnetID ids.ID) (map[ids.ShortID]simulate.Window, error) {
+	ws := make(map[ids.ShortID]simulate.Window)
+	current, err := cli.P().GetValidators(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range current {
+		ws[v.NodeID] = simulate.Window{Start: v.Start, End: v.End}
+	}
+	pending, err := cli.P().GetPendingValidators(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range pending {
+		ws[v.NodeID] = simulate.Window{Start: v.Start, End: v.End}
+	}
+	return ws, nil
+}
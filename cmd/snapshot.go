@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/snapshot"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// SnapshotCommand implements "subnet-cli snapshot" command.
+func SnapshotCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Writes a snapshot of the subnet state",
+		Long: `
+Writes the canonical JSON of the subnet owners, staking asset, blockchains,
+and current validators into "snapshot-<subnet ID>-<UTC time>.json" in
+--out, to compare with a later snapshot (see "snapshot diff"). The path of
+the snapshot is printed as the last line.
+
+$ subnet-cli snapshot \
+--public-uri=https://api.avax-test.network \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--out=snapshots/
+
+`,
+		Args: cobra.NoArgs,
+		RunE: snapshotFunc,
+	}
+	cmd.AddCommand(
+		newSnapshotDiffCommand(),
+	)
+	cmd.Flags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.Flags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
+	cmd.Flags().StringVar(&snapshotOut, "out", ".", "directory to write the snapshot to")
+	return cmd
+}
+
+var errSnapshotSubnetID = errors.New("--subnet-id is required")
+
+func snapshotFunc(cmd *cobra.Command, args []string) error {
+	if subnetIDs == "" {
+		return errSnapshotSubnetID
+	}
+	subnetID, err := ids.FromString(subnetIDs)
+	if err != nil {
+		return err
+	}
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+	s, err := takeSnapshot(cli, subnetID)
+	if err != nil {
+		return err
+	}
+	b, err := s.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(snapshotOut, 0o755); err != nil {
+		return err
+	}
+	p := filepath.Join(snapshotOut, s.FileName())
+	if err := ioutil.WriteFile(p, b, 0o644); err != nil {
+		return err
+	}
+	color.Outf("{{green}}snapshot of subnet %s{{/}} {{light-gray}}(%d blockchains, %d validators){{/}}\n", subnetID, len(s.Blockchains), len(s.Validators))
+	color.Outf("%s\n", p)
+	return nil
+}
+
+// takeSnapshot fetches the state of the subnet.
+func takeSnapshot(cli client.Client, subnetID ids.ID) (*snapshot.Snapshot, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	s := &snapshot.Snapshot{
+		Version:   snapshot.Version,
+		NetworkID: cli.NetworkID(),
+		SubnetID:  subnetID,
+		TakenAt:   time.Now(),
+	}
+	owners, err := cli.P().SubnetOwners(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	hrp := constants.GetHRP(cli.NetworkID())
+	s.Owners = snapshot.Owners{Threshold: owners.Threshold, Locktime: owners.Locktime}
+	for _, addr := range owners.Addrs {
+		a, err := formatting.FormatAddress("P", hrp, addr[:])
+		if err != nil {
+			return nil, err
+		}
+		s.Owners.Addrs = append(s.Owners.Addrs, a)
+	}
+
+	asset, err := cli.P().StakingAsset(ctx, subnetID)
+	switch {
+	case err == nil:
+		s.StakingAssetID = asset.ID.String()
+	case !errors.Is(err, client.ErrNotElastic):
+		return nil, err
+	}
+
+	bcs, err := cli.P().GetBlockchains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, bc := range bcs {
+		if bc.SubnetID == subnetID {
+			s.Blockchains = append(s.Blockchains, snapshot.Blockchain{ID: bc.ID, Name: bc.Name, VMID: bc.VMID})
+		}
+	}
+
+	vs, err := cli.P().GetValidators(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range vs {
+		s.Validators = append(s.Validators, snapshot.Validator{
+			NodeID: v.NodeID.PrefixedString(constants.NodeIDPrefix),
+			Weight: v.Weight,
+			Start:  v.Start,
+			End:    v.End,
+		})
+	}
+	return s, nil
+}
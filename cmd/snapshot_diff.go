@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/snapshot"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newSnapshotDiffCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [BEFORE] [AFTER]",
+		Short: "Compares two snapshots of the subnet state",
+		Long: `
+Prints the changes of the subnet state between two snapshots (see
+"snapshot"): the owners, the staking asset, the blockchains, and the
+validators added, removed, or changed. Fails if any change is found, to
+alert in a monitoring pipeline. The validators removed once their end
+time passed are expected, and ignored with --ignore-expired.
+
+$ subnet-cli snapshot diff \
+snapshots/snapshot-24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1-20220501T000000Z.json \
+snapshots/snapshot-24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1-20220502T000000Z.json \
+--ignore-expired
+
+`,
+		Args: cobra.ExactArgs(2),
+		RunE: snapshotDiffFunc,
+	}
+	cmd.PersistentFlags().BoolVar(&snapshotIgnoreExpired, "ignore-expired", false, "'true' to ignore the validators removed once their end time passed")
+	return cmd
+}
+
+var errSnapshotDrift = errors.New("subnet state changed between the snapshots")
+
+func snapshotDiffFunc(cmd *cobra.Command, args []string) error {
+	before, err := snapshot.Load(args[0])
+	if err != nil {
+		return err
+	}
+	after, err := snapshot.Load(args[1])
+	if err != nil {
+		return err
+	}
+	changes, err := snapshot.Diff(before, after, snapshotIgnoreExpired)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		color.Outf("{{green}}no change of subnet %s between %s and %s{{/}}\n", before.SubnetID, before.TakenAt.Format("2006-01-02 15:04:05 MST"), after.TakenAt.Format("2006-01-02 15:04:05 MST"))
+		return nil
+	}
+	fmt.Fprint(formatter.ColorableStdOut, makeSnapshotDiffTable(changes))
+	return fmt.Errorf("%w (%d changes)", errSnapshotDrift, len(changes))
+}
+
+func makeSnapshotDiffTable(changes []snapshot.Change) string {
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+	tb.SetHeader([]string{"change", "object", "id", "field", "before", "after"})
+	for _, c := range changes {
+		kind := string(c.Kind)
+		switch c.Kind {
+		case snapshot.Added:
+			kind = formatter.F("{{green}}%s{{/}}", kind)
+		case snapshot.Removed:
+			kind = formatter.F("{{red}}%s{{/}}", kind)
+		default:
+			kind = formatter.F("{{yellow}}%s{{/}}", kind)
+		}
+		id := c.ID
+		if id != "" {
+			id = labeled(id)
+		}
+		tb.Append([]string{kind, c.Object, id, c.Field, c.Before, c.After})
+	}
+	tb.Render()
+	return buf.String()
+}
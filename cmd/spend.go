@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/internal/spend"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// mainnetPhrase is typed to confirm the spends on mainnet,
+// instead of selecting the confirmation option.
+const mainnetPhrase = "mainnet"
+
+var errMainnetNotExpected = errors.New("spending on mainnet with prompts disabled requires --network=mainnet")
+
+// CheckSpend checks the required balance against the spend limits of the
+// config file, and that the spends on mainnet with prompts disabled are
+// expected with "--network".
+func (i *Info) CheckSpend() error {
+	if i.networkID == constants.MainnetID && !enablePrompt && expectedNetwork == "" {
+		return errMainnetNotExpected
+	}
+	if spendLimits == nil {
+		return nil
+	}
+	spent := uint64(0)
+	if spendLimits.HasSession() {
+		l, err := openLedger()
+		if err != nil {
+			return err
+		}
+		spent, err = l.Spent(i.networkID, spendLimits.SessionStart(time.Now()))
+		if err != nil {
+			return err
+		}
+	}
+	if err := spendLimits.Check(commandName, i.requiredBalance, spent, allowLargeSpend); err != nil {
+		if errors.Is(err, spend.ErrSoftLimit) {
+			color.Outf("{{yellow}}hint: set --allow-large-spend to spend above the soft limit{{/}}\n")
+		}
+		return err
+	}
+	return nil
+}
+
+// confirmSpend asks for the typed phrase on mainnet, the fee of each tx
+// being confirmed once the tx is summarized (ref. "txReviewer"). Once
+// confirmed, the spend counts towards the session limit.
+func (i *Info) confirmSpend() bool {
+	if i.networkID == constants.MainnetID && enablePrompt {
+		color.Outf("{{red}}{{bold}}This spends %s AVAX on MAINNET.{{/}}\n", formatAVAX(i.requiredBalance))
+		s, err := prompter.Input(`Type "` + mainnetPhrase + `" to continue`)
+		if err != nil {
+			zap.L().Warn("prompt failed", zap.Error(err))
+			return false
+		}
+		if strings.TrimSpace(s) != mainnetPhrase {
+			color.Outf("{{red}}confirmation phrase mismatch, aborting{{/}}\n")
+			return false
+		}
+	}
+	if spendLimits != nil && spendLimits.HasSession() && i.requiredBalance > 0 {
+		l, err := openLedger()
+		if err == nil {
+			err = l.Record(spend.Entry{
+				Time:      time.Now(),
+				NetworkID: i.networkID,
+				Command:   commandName,
+				Amount:    i.requiredBalance,
+			})
+		}
+		if err != nil {
+			zap.L().Warn("failed to record the spend", zap.Error(err))
+		}
+	}
+	return true
+}
+
+func openLedger() (*spend.Ledger, error) {
+	p, err := spend.DefaultLedgerPath()
+	if err != nil {
+		return nil, err
+	}
+	return spend.OpenLedger(p), nil
+}
@@ -15,6 +15,8 @@ func StatusCommand() *cobra.Command {
 	}
 	cmd.AddCommand(
 		newStatusBlockchainCommand(),
+		newStatusSubnetCommand(),
+		newStatusChainCommand(),
 	)
 	cmd.PersistentFlags().StringVar(&privateURI, "private-uri", "", "URI for avalanche network endpoints")
 	return cmd
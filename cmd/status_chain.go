@@ -0,0 +1,174 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/dustin/go-humanize"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/evm"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newStatusChainCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chain [BLOCKCHAIN ID]",
+		Short: "Shows the configuration of a blockchain",
+		Long: `
+Shows the name, subnet, and VM of the blockchain. With "--evm", also
+queries the RPC of the (Subnet-EVM) chain to show the last block, the
+current fee config, and the activated precompiles, and verifies that the
+chain config matches the genesis submitted on the P-Chain.
+
+$ subnet-cli status chain \
+--blockchain-id=[BLOCKCHAIN ID] \
+--private-uri=http://localhost:49738 \
+--evm
+
+`,
+		RunE: statusChainFunc,
+	}
+	cmd.PersistentFlags().StringVar(&blockchainID, "blockchain-id", "", "blockchain to show the configuration of")
+	cmd.PersistentFlags().BoolVar(&checkEVM, "evm", false, "'true' to query the Subnet-EVM chain RPC")
+	return cmd
+}
+
+var errGenesisMismatch = errors.New("chain config does not match the genesis")
+
+func statusChainFunc(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		blockchainID = args[0]
+	}
+	cli, info, err := InitClient(privateURI, false)
+	if err != nil {
+		return err
+	}
+	blkChainID, err := ids.FromString(blockchainID)
+	if err != nil {
+		return err
+	}
+
+	color.Outf("\n{{blue}}Checking blockchain...{{/}}\n")
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	tx, err := cli.P().BlockchainTx(ctx, blkChainID)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	buf, tb := BaseTableSetup(info)
+	tb.Append([]string{formatter.F("{{blue}}BLOCKCHAIN ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", blkChainID)})
+	tb.Append([]string{formatter.F("{{blue}}SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", tx.SubnetID)})
+	tb.Append([]string{formatter.F("{{dark-green}}CHAIN NAME{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", tx.ChainName)})
+	tb.Append([]string{formatter.F("{{dark-green}}VM ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", tx.VMID)})
+	if !checkEVM {
+		tb.Render()
+		fmt.Fprint(formatter.ColorableStdOut, buf.String())
+		return nil
+	}
+
+	ec := evm.New(privateURI, blkChainID.String())
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	blk, err := ec.LatestBlock(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	blkTime := time.Unix(int64(blk.Timestamp), 0)
+	tb.Append([]string{formatter.F("{{magenta}}LAST BLOCK{{/}}"), formatter.F("{{light-gray}}{{bold}}%d{{/}} (%s, %s)", blk.Number, blk.Hash, humanize.Time(blkTime))})
+	tb.Append([]string{formatter.F("{{magenta}}GAS USED{{/}}"), formatter.F("{{light-gray}}%s / %s{{/}}", humanize.Comma(int64(blk.GasUsed)), humanize.Comma(int64(blk.GasLimit)))})
+	if blk.BaseFee != nil {
+		tb.Append([]string{formatter.F("{{magenta}}BASE FEE{{/}}"), formatter.F("{{light-gray}}%s wei{{/}}", humanize.BigComma(blk.BaseFee))})
+	}
+
+	// current fee config may differ from the genesis (e.g., fee manager)
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	feeCfg, err := ec.FeeConfig(ctx)
+	cancel()
+	if err != nil {
+		color.Outf("{{yellow}}failed to get the fee config (not a Subnet-EVM chain?): %v{{/}}\n", err)
+	}
+	for _, k := range sortedKeys(feeCfg) {
+		tb.Append([]string{formatter.F("{{cyan}}FEE CONFIG %s{{/}}", k), formatter.F("{{light-gray}}%s{{/}}", feeCfg[k])})
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	chainCfg, err := ec.ChainConfig(ctx)
+	cancel()
+	if err != nil {
+		tb.Render()
+		fmt.Fprint(formatter.ColorableStdOut, buf.String())
+		return err
+	}
+	precompiles := evm.Precompiles(chainCfg)
+	if len(precompiles) == 0 {
+		precompiles = []string{"none"}
+	}
+	tb.Append([]string{formatter.F("{{cyan}}PRECOMPILES{{/}}"), formatter.F("{{light-gray}}%s{{/}}", strings.Join(precompiles, ", "))})
+
+	diff, err := diffGenesis(tx.GenesisData, chainCfg)
+	if err != nil {
+		return err
+	}
+	if len(diff) == 0 {
+		tb.Append([]string{formatter.F("{{cyan}}MATCHES GENESIS{{/}}"), formatter.F("{{green}}{{bold}}yes{{/}}")})
+	} else {
+		tb.Append([]string{formatter.F("{{cyan}}MATCHES GENESIS{{/}}"), formatter.F("{{red}}{{bold}}no{{/}} (%s)", strings.Join(diff, ", "))})
+	}
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	if len(diff) > 0 {
+		return fmt.Errorf("%w: %s", errGenesisMismatch, strings.Join(diff, ", "))
+	}
+	return nil
+}
+
+// diffGenesis returns the genesis config fields that differ in the chain
+// config. The fee config is compared field by field, as the chain fills
+// in the defaults.
+func diffGenesis(genesis []byte, chainCfg map[string]json.RawMessage) ([]string, error) {
+	var g struct {
+		Config map[string]json.RawMessage `json:"config"`
+	}
+	if err := json.Unmarshal(genesis, &g); err != nil {
+		return nil, fmt.Errorf("failed to parse the genesis: %w", err)
+	}
+	var genesisFee, chainFee map[string]json.RawMessage
+	if raw, ok := g.Config["feeConfig"]; ok {
+		if err := json.Unmarshal(raw, &genesisFee); err != nil {
+			return nil, err
+		}
+		delete(g.Config, "feeConfig")
+	}
+	if raw, ok := chainCfg["feeConfig"]; ok {
+		if err := json.Unmarshal(raw, &chainFee); err != nil {
+			return nil, err
+		}
+	}
+
+	diff := evm.Diff(g.Config, chainCfg)
+	for _, k := range evm.Diff(genesisFee, chainFee) {
+		diff = append(diff, "feeConfig."+k)
+	}
+	return diff, nil
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
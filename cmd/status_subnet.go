@@ -0,0 +1,242 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/dustin/go-humanize"
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/amount"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newStatusSubnetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subnet [SUBNET ID]",
+		Short: "subnet commands",
+		Long: `
+Checks the status of the subnet: its control keys, threshold, blockchains,
+and current validators, followed by the pending validators (added, but not
+started yet) with the countdown to their start. No key is required, unless
+the authorization of the key needs to be checked.
+
+$ subnet-cli status subnet \
+--subnet-id=[SUBNET ID] \
+--private-uri=http://localhost:49738
+
+$ subnet-cli status subnet \
+--subnet-id=[SUBNET ID] \
+--private-key-path=.insecure.ewoq.key \
+--private-uri=http://localhost:49738
+
+`,
+		RunE: statusSubnetFunc,
+	}
+
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", "", "private key file path (optional, to check the authorization of the key)")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to check the authorization of the key")
+	cmd.PersistentFlags().StringVar(&pAddress, "address", "", "P-Chain address to check the authorization of, without loading any key")
+	return cmd
+}
+
+type subnetStatus struct {
+	subnetID    ids.ID
+	owners      *secp256k1fx.OutputOwners
+	blockchains []platformvm.APIBlockchain
+	validators  []client.Validator
+	// added, but not validating until their start time
+	pending []client.Validator
+	// nil unless the subnet is elastic
+	stakingAsset *client.Asset
+
+	// only set when a key is loaded or an address is given
+	checkAuth  bool
+	authorized bool
+}
+
+func statusSubnetFunc(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		subnetIDs = args[0]
+	}
+	loadKey := privKeyPath != "" || useLedger
+	cli, info, err := InitClient(privateURI, loadKey)
+	if err != nil {
+		return err
+	}
+	if loadKey {
+		defer info.key.Close()
+	}
+	info.subnetID, err = ids.FromString(subnetIDs)
+	if err != nil {
+		return err
+	}
+
+	color.Outf("\n{{blue}}Checking subnet...{{/}}\n")
+	ss := &subnetStatus{subnetID: info.subnetID}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	ss.owners, err = cli.P().SubnetOwners(ctx, info.subnetID)
+	cancel()
+	if err != nil {
+		return err
+	}
+	now := uint64(time.Now().Unix())
+	switch {
+	case loadKey:
+		ss.checkAuth = true
+		_, _, ss.authorized = info.key.Match(ss.owners, now)
+	case pAddress != "":
+		addr, err := ParsePAddress(pAddress)
+		if err != nil {
+			return err
+		}
+		ss.checkAuth = true
+		if now >= ss.owners.Locktime && ss.owners.Threshold <= 1 {
+			for _, owner := range ss.owners.Addrs {
+				if owner == addr {
+					ss.authorized = true
+					break
+				}
+			}
+		}
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	bcs, err := cli.P().GetBlockchains(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	for _, bc := range bcs {
+		if bc.SubnetID == info.subnetID {
+			ss.blockchains = append(ss.blockchains, bc)
+		}
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	ss.stakingAsset, err = cli.P().StakingAsset(ctx, info.subnetID)
+	cancel()
+	if err != nil && !errors.Is(err, client.ErrNotElastic) {
+		return err
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	ss.validators, err = cli.P().GetValidators(ctx, info.subnetID)
+	cancel()
+	if err != nil {
+		return err
+	}
+	sort.Slice(ss.validators, func(i, j int) bool {
+		return ss.validators[i].End.Before(ss.validators[j].End)
+	})
+
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	ss.pending, err = cli.P().GetPendingValidators(ctx, info.subnetID)
+	cancel()
+	if err != nil {
+		return err
+	}
+	sort.Slice(ss.pending, func(i, j int) bool {
+		return ss.pending[i].Start.Before(ss.pending[j].Start)
+	})
+
+	msg, err := MakeStatusSubnetTable(cli.NetworkID(), info, ss)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+	return nil
+}
+
+func MakeStatusSubnetTable(networkID uint32, i *Info, ss *subnetStatus) (string, error) {
+	buf, tb := BaseTableSetup(i)
+	tb.Append([]string{formatter.F("{{blue}}SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", labeled(ss.subnetID.String()))})
+
+	hrp := constants.GetHRP(networkID)
+	for idx, addr := range ss.owners.Addrs {
+		paddr, err := formatting.FormatAddress("P", hrp, addr.Bytes())
+		if err != nil {
+			return "", err
+		}
+		tb.Append([]string{formatter.F("{{cyan}}{{bold}}CONTROL KEY #%d{{/}}", idx), formatter.F("{{light-gray}}%s{{/}}", paddr)})
+	}
+	tb.Append([]string{formatter.F("{{cyan}}{{bold}}THRESHOLD{{/}}"), formatter.F("{{light-gray}}{{bold}}%d{{/}}", ss.owners.Threshold)})
+	switch {
+	case !ss.checkAuth:
+	case ss.authorized:
+		tb.Append([]string{formatter.F("{{cyan}}{{bold}}KEY AUTHORIZED{{/}}"), formatter.F("{{green}}{{bold}}yes{{/}}")})
+	default:
+		tb.Append([]string{formatter.F("{{cyan}}{{bold}}KEY AUTHORIZED{{/}}"), formatter.F("{{red}}{{bold}}no{{/}}")})
+	}
+
+	if ss.stakingAsset != nil {
+		tb.Append([]string{formatter.F("{{cyan}}{{bold}}STAKING ASSET{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}} (%s, %d decimals)", ss.stakingAsset.Symbol, ss.stakingAsset.ID, ss.stakingAsset.Denomination)})
+	}
+	for _, bc := range ss.blockchains {
+		tb.Append([]string{formatter.F("{{dark-green}}BLOCKCHAIN %q{{/}}", bc.Name), formatter.F("{{light-gray}}{{bold}}%s{{/}} (VM ID %s)", labeled(bc.ID.String()), bc.VMID)})
+	}
+	tb.Render()
+
+	if len(ss.validators) == 0 && len(ss.pending) == 0 {
+		return buf.String() + formatter.F("{{yellow}}no validator found for %s{{/}}\n", ss.subnetID), nil
+	}
+	msg := buf.String() + makeValidatorsTable(ss.validators, ss.pending, ss.stakingAsset)
+	if len(ss.validators) == 0 {
+		msg += formatter.F("{{yellow}}no current validator for %s, %d pending{{/}}\n", ss.subnetID, len(ss.pending))
+	}
+	return msg, nil
+}
+
+// makeValidatorsTable renders the current validators, then the pending
+// ones, with the weights as amounts of the staking asset if not nil
+// (i.e., elastic subnet).
+func makeValidatorsTable(vs []client.Validator, pending []client.Validator, asset *client.Asset) string {
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+	weightHeader := "weight"
+	if asset != nil {
+		weightHeader = fmt.Sprintf("stake (%s)", asset.Symbol)
+	}
+	tb.SetHeader([]string{"node ID", "status", weightHeader, "start", "end"})
+	appendValidator := func(v client.Validator, status string, start string) {
+		weight := humanize.Comma(int64(v.Weight))
+		if asset != nil {
+			weight = amount.FormatDenomination(v.Weight, asset.Denomination)
+		}
+		tb.Append([]string{
+			labeled(v.NodeID.PrefixedString(constants.NodeIDPrefix)),
+			status,
+			weight,
+			start,
+			fmt.Sprintf("%s (%s)", v.End.Format(time.RFC3339), humanize.Time(v.End)),
+		})
+	}
+	for _, v := range vs {
+		appendValidator(v, formatter.F("{{green}}current{{/}}"), v.Start.Format(time.RFC3339))
+	}
+	for _, v := range pending {
+		appendValidator(v, formatter.F("{{yellow}}pending{{/}}"), formatPendingStart(v.Start))
+	}
+	tb.Render()
+	return buf.String()
+}
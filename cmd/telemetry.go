@@ -0,0 +1,169 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/internal/telemetry"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// telemetrySendTimeout bounds the time added to each command
+// by the usage report.
+const telemetrySendTimeout = 2 * time.Second
+
+var errNoTelemetryURL = errors.New("empty --telemetry-url")
+
+// TelemetryCommand implements "subnet-cli telemetry" command.
+func TelemetryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "telemetry",
+		Short: "Sub-commands for the opt-in anonymous usage stats",
+	}
+	cmd.AddCommand(
+		newTelemetryOnCommand(),
+		newTelemetryOffCommand(),
+		newTelemetryStatusCommand(),
+	)
+	return cmd
+}
+
+func newTelemetryOnCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "on",
+		Short: "Opts in to send the anonymous usage stats",
+		Long: `
+Opts in to send the anonymous usage stats of each command to --telemetry-url
+(disabled by default). Each event only holds the command path (e.g.,
+"add validator"), the subnet-cli version, the OS and the architecture,
+the duration, the category of the error (e.g., "insufficient_funds"), and a
+random install ID renewed on each opt-in: never the flags, the arguments,
+the addresses, or the keys.
+
+$ subnet-cli telemetry on --telemetry-url=https://stats.example.com/v1/events
+
+`,
+		RunE: telemetryOnFunc,
+	}
+	cmd.PersistentFlags().StringVar(&telemetryURL, "telemetry-url", "", "URL to POST the usage events to as JSON")
+	return cmd
+}
+
+func telemetryOnFunc(cmd *cobra.Command, args []string) error {
+	if telemetryURL == "" {
+		return errNoTelemetryURL
+	}
+	p, err := telemetry.DefaultPath()
+	if err != nil {
+		return err
+	}
+	s, err := telemetry.Load(p)
+	if err != nil {
+		return err
+	}
+	if err := s.Enable(telemetryURL); err != nil {
+		return err
+	}
+	if err := s.Save(p); err != nil {
+		return err
+	}
+	color.Outf("{{green}}telemetry enabled{{/}} {{light-gray}}(sending to %s, install ID %s){{/}}\n", s.Endpoint, s.InstallID)
+	return nil
+}
+
+func newTelemetryOffCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "off",
+		Short: "Opts out of the anonymous usage stats",
+		Long: `
+Stops sending the usage stats, and forgets the install ID.
+
+$ subnet-cli telemetry off
+
+`,
+		RunE: telemetryOffFunc,
+	}
+}
+
+func telemetryOffFunc(cmd *cobra.Command, args []string) error {
+	p, err := telemetry.DefaultPath()
+	if err != nil {
+		return err
+	}
+	s, err := telemetry.Load(p)
+	if err != nil {
+		return err
+	}
+	s.Disable()
+	if err := s.Save(p); err != nil {
+		return err
+	}
+	color.Outf("{{green}}telemetry disabled{{/}}\n")
+	return nil
+}
+
+func newTelemetryStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Prints whether the anonymous usage stats are sent",
+		Long: `
+Prints whether the usage stats are sent, and where to.
+
+$ subnet-cli telemetry status
+
+`,
+		RunE: telemetryStatusFunc,
+	}
+}
+
+func telemetryStatusFunc(cmd *cobra.Command, args []string) error {
+	p, err := telemetry.DefaultPath()
+	if err != nil {
+		return err
+	}
+	s, err := telemetry.Load(p)
+	if err != nil {
+		return err
+	}
+	if !s.Enabled {
+		color.Outf("{{yellow}}telemetry disabled{{/}} {{light-gray}}(enable with 'subnet-cli telemetry on'){{/}}\n")
+		return nil
+	}
+	color.Outf("{{green}}telemetry enabled{{/}} {{light-gray}}(sending to %s, install ID %s){{/}}\n", s.Endpoint, s.InstallID)
+	return nil
+}
+
+// reportTelemetry sends the usage event of the command, if opted in.
+// Failures are only logged, not to fail the command.
+func reportTelemetry(cmd *cobra.Command, took time.Duration, err error) {
+	if cmd == nil || (cmd.Parent() != nil && cmd.Parent().Name() == "telemetry") {
+		return
+	}
+	p, perr := telemetry.DefaultPath()
+	if perr != nil {
+		return
+	}
+	s, perr := telemetry.Load(p)
+	if perr != nil {
+		zap.L().Debug("failed to load telemetry settings", zap.Error(perr))
+		return
+	}
+	if !s.Enabled {
+		return
+	}
+	name := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	e := s.NewEvent(name, Version, took, errorCategory(err))
+	ctx, cancel := context.WithTimeout(context.Background(), telemetrySendTimeout)
+	defer cancel()
+	if serr := telemetry.Send(ctx, s.Endpoint, e); serr != nil {
+		zap.L().Debug("failed to send telemetry", zap.Error(serr))
+	}
+}
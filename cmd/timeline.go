@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/timeline"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// TimelineCommand implements "subnet-cli timeline" command.
+func TimelineCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "timeline",
+		Short: "Renders the staking timeline of the validators",
+		Long: `
+Renders the validation periods of all current and pending validators of
+a subnet (or the primary network if no subnet ID is given), highlighting
+the gaps in coverage ('X') and the periods below the safety threshold ('!').
+The pending validators are labeled with the countdown to their start.
+
+$ subnet-cli timeline \
+--public-uri=http://localhost:52250 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--min-validators=3
+
+`,
+		RunE: timelineFunc,
+	}
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID, default to primary network)")
+	cmd.PersistentFlags().IntVar(&minValidators, "min-validators", 1, "minimum number of validators to consider the subnet safe")
+	cmd.PersistentFlags().IntVar(&timelineWidth, "width", 80, "number of columns of the timeline")
+	return cmd
+}
+
+func timelineFunc(cmd *cobra.Command, args []string) error {
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+	subnetID := ids.Empty
+	if subnetIDs != "" {
+		subnetID, err = ids.FromString(subnetIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	vs, err := cli.P().GetValidators(ctx, subnetID)
+	cancel()
+	if err != nil {
+		return err
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	pending, err := cli.P().GetPendingValidators(ctx, subnetID)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if len(vs) == 0 && len(pending) == 0 {
+		color.Outf("{{yellow}}no validator found{{/}}\n")
+		return nil
+	}
+
+	now := time.Now()
+	to := now
+	spans := make([]timeline.Span, 0, len(vs)+len(pending))
+	for _, v := range vs {
+		spans = append(spans, timeline.Span{
+			Label: v.NodeID.PrefixedString(constants.NodeIDPrefix),
+			Start: v.Start,
+			End:   v.End,
+		})
+		if v.End.After(to) {
+			to = v.End
+		}
+	}
+	for _, v := range pending {
+		spans = append(spans, timeline.Span{
+			Label: fmt.Sprintf("%s (pending, in %v)", v.NodeID.PrefixedString(constants.NodeIDPrefix), time.Until(v.Start).Round(time.Second)),
+			Start: v.Start,
+			End:   v.End,
+		})
+		if v.End.After(to) {
+			to = v.End
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].End.Before(spans[j].End) })
+
+	fmt.Fprint(formatter.ColorableStdOut, timeline.Render(spans, now, to, timelineWidth, minValidators))
+	println()
+	for _, gap := range timeline.Gaps(spans, now, to) {
+		color.Outf("{{red}}no validator from %s to %s{{/}}\n", gap.Start.UTC().Format(time.RFC3339), gap.End.UTC().Format(time.RFC3339))
+	}
+	// validator set always drops to zero after the last end time
+	below, ok := timeline.Below(spans, now, to, minValidators)
+	if !ok {
+		below = to
+	}
+	color.Outf("{{orange}}validator set drops below %d validator(s) at{{/}} {{bold}}%s{{/}} {{light-gray}}(in %v){{/}}\n", minValidators, below.UTC().Format(time.RFC3339), below.Sub(now).Round(time.Minute))
+	return nil
+}
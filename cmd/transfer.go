@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/amount"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var errZeroTransferAmount = errors.New("--amount must be greater than zero")
+
+// TransferCommand implements "subnet-cli transfer" command.
+func TransferCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer",
+		Short: "Sends AVAX to a P-Chain address",
+		Long: `
+Sends AVAX from the key to a P-Chain address with a "BaseTx" (accepted
+once Durango is activated), returning the change to the key or to
+--change-address. The fee is paid on top of --amount, or out of it with
+--deduct-fee.
+
+$ subnet-cli transfer \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--to=P-custom1... \
+--amount=5avax \
+--memo="invoice 42"
+
+`,
+		RunE: transferFunc,
+	}
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path, or key store URI (e.g., 'keychain://subnet-cli/ops', 'env://OPS_KEY')")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().StringVar(&transferTo, "to", "", "P-Chain address to send the AVAX to")
+	cmd.PersistentFlags().Var(amount.NewValue(0, &transferAmount), "amount", "amount to send in nano AVAX, or with a denomination (e.g., '5avax', '500milliavax')")
+	cmd.PersistentFlags().BoolVar(&deductFee, "deduct-fee", false, "'true' to pay the fee out of --amount, the recipient receiving --amount minus the fee")
+	cmd.PersistentFlags().StringVar(&changeAddrs, "change-address", "", "P-Chain address to send the change to (default to key owner)")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "'true' to only show the planned tx")
+	addMemoFlag(cmd)
+	return cmd
+}
+
+func transferFunc(cmd *cobra.Command, args []string) error {
+	to, err := ParsePAddress(transferTo)
+	if err != nil {
+		return err
+	}
+	if transferAmount == 0 {
+		return errZeroTransferAmount
+	}
+	opts := []client.OpOption{client.WithDeductFee(deductFee)}
+	if changeAddrs != "" {
+		changeAddr, err := ParsePAddress(changeAddrs)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, client.WithChangeAddress(changeAddr))
+	}
+
+	cli, info, err := InitClient(publicURI, true)
+	if err != nil {
+		return err
+	}
+	defer info.key.Close()
+	opts = append(opts, client.WithMemo(info.memo))
+
+	info.txFee = uint64(info.feeData.TxFee)
+	info.requiredBalance = transferAmount
+	if !deductFee {
+		info.requiredBalance += info.txFee
+	}
+	if err := info.CheckBalance(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	planned, err := cli.P().Transfer(ctx, info.key, to, transferAmount, append(opts, client.WithDryMode(true))...)
+	cancel()
+	if err != nil {
+		return err
+	}
+	msg := makeTransferTable(info, planned)
+	if dryRun {
+		fmt.Fprint(formatter.ColorableStdOut, msg)
+		return nil
+	}
+	if enablePrompt {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to transfer, should we continue?{{/}}\n") + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+	if !info.confirmSpend() {
+		return errTxDeclined
+	}
+
+	println()
+	println()
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	t, err := cli.P().Transfer(ctx, info.key, to, transferAmount, opts...)
+	cancel()
+	if err != nil {
+		return err
+	}
+	color.Outf("{{magenta}}transferred %s $AVAX to %s in %s{{/}} {{light-gray}}(took %v){{/}}\n", formatAVAX(t.Amount), transferTo, t.TxID, t.Took)
+	return nil
+}
+
+func makeTransferTable(i *Info, t client.Transfer) string {
+	buf, tb := BaseTableSetup(i)
+	tb.Append([]string{formatter.F("{{cyan}}{{bold}}TO{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", transferTo)})
+	tb.Append([]string{formatter.F("{{magenta}}AMOUNT{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}} $AVAX", formatAVAX(t.Amount))})
+	tb.Append([]string{formatter.F("{{red}}{{bold}}TX FEE{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}} $AVAX", formatAVAX(t.Fee))})
+	tb.Append([]string{formatter.F("{{blue}}TX ID{{/}}"), formatter.F("{{light-gray}}%s{{/}}", t.TxID)})
+	tb.Render()
+	return buf.String()
+}
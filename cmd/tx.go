@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// TxCommand implements "subnet-cli tx" command.
+func TxCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tx",
+		Short: "Sub-commands for inspecting P-Chain transactions",
+	}
+	cmd.AddCommand(
+		newTxDecodeCommand(),
+	)
+	return cmd
+}
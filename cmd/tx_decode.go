@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/amount"
+	"github.com/ava-labs/subnet-cli/internal/multisig"
+	"github.com/ava-labs/subnet-cli/internal/txs"
+)
+
+func newTxDecodeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decode [HEX OR FILE]",
+		Short: "Decodes a signed or unsigned P-Chain transaction",
+		Long: `
+Decodes any signed or unsigned P-Chain transaction, and prints its type,
+inputs, outputs, owners, subnet auth indices, and the burned fee, to audit
+the transactions produced by other tools before signing them. No network
+access is required.
+
+The transaction can be hex (with or without the checksum and "0x" prefix),
+CB58, or a file of the encoded or raw transaction bytes, or a multisig
+transaction file.
+
+$ subnet-cli tx decode 0x0000000000100000...
+
+$ subnet-cli tx decode multisig-tx.json
+
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: txDecodeFunc,
+	}
+	return cmd
+}
+
+func txDecodeFunc(cmd *cobra.Command, args []string) error {
+	b, err := readTxArg(args[0])
+	if err != nil {
+		return err
+	}
+	s, err := txs.Decode(b)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(formatter.ColorableStdOut, makeTxDecodeTable(s))
+	return nil
+}
+
+// readTxArg reads the transaction bytes from the file if it exists,
+// or parses the argument as an encoded transaction.
+func readTxArg(arg string) ([]byte, error) {
+	d, err := os.ReadFile(arg)
+	if os.IsNotExist(err) {
+		return txs.ParseBytes([]byte(arg)), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(d), []byte("{")) && json.Valid(d) {
+		f, err := multisig.Load(arg)
+		if err != nil {
+			return nil, err
+		}
+		return txs.ParseBytes([]byte(f.UnsignedTx)), nil
+	}
+	return txs.ParseBytes(d), nil
+}
+
+func makeTxDecodeTable(s *txs.Summary) string {
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetRowLine(true)
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	tb.Append([]string{formatter.F("{{blue}}{{bold}}TYPE{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", s.Type)})
+	if s.Signed {
+		tb.Append([]string{formatter.F("{{blue}}TX ID{{/}}"), formatter.F("{{light-gray}}%s{{/}}", s.TxID)})
+		tb.Append([]string{formatter.F("{{blue}}CREDENTIALS{{/}}"), formatter.F("{{light-gray}}%d{{/}}", s.Credentials)})
+	} else {
+		tb.Append([]string{formatter.F("{{blue}}SIGNED{{/}}"), formatter.F("{{yellow}}no{{/}}")})
+	}
+	tb.Append([]string{formatter.F("{{blue}}DIGEST{{/}}"), formatter.F("{{light-gray}}%s{{/}}", s.Digest)})
+	tb.Append([]string{formatter.F("{{cyan}}NETWORK ID{{/}}"), formatter.F("{{light-gray}}%d{{/}}", s.NetworkID)})
+	tb.Append([]string{formatter.F("{{cyan}}BLOCKCHAIN ID{{/}}"), formatter.F("{{light-gray}}%s{{/}}", s.BlockchainID)})
+	if len(s.Memo) > 0 {
+		tb.Append([]string{formatter.F("{{cyan}}MEMO{{/}}"), formatter.F("{{light-gray}}%q{{/}}", s.Memo)})
+	}
+	for _, f := range s.Fields {
+		tb.Append([]string{formatter.F("{{magenta}}%s{{/}}", strings.ToUpper(f.Name)), formatter.F("{{light-gray}}%s{{/}}", f.Value)})
+	}
+	for assetID, fee := range s.Fee {
+		tb.Append([]string{formatter.F("{{coral}}{{bold}}FEE{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}} (asset %s)", amount.Format(fee), assetID)})
+	}
+	tb.Render()
+
+	if len(s.Inputs) > 0 {
+		itb := newTxTable(buf, []string{"input UTXO", "asset", "amount", "sig indices", "locktime"})
+		for _, in := range s.Inputs {
+			itb.Append([]string{
+				in.UTXOID,
+				in.AssetID.String(),
+				amount.Format(in.Amount),
+				fmt.Sprintf("%v", in.SigIndices),
+				fmt.Sprintf("%d", in.Locktime),
+			})
+		}
+		itb.Render()
+	}
+	if len(s.Outputs) > 0 {
+		otb := newTxTable(buf, []string{"output", "asset", "amount", "owners", "threshold", "locktime"})
+		for _, out := range s.Outputs {
+			kind := out.Kind
+			if out.Stakeable {
+				kind += fmt.Sprintf(" (stakeable until %d)", out.Locktime)
+			}
+			otb.Append([]string{
+				kind,
+				out.AssetID.String(),
+				amount.Format(out.Amount),
+				strings.Join(out.Owners.Addrs, "\n"),
+				fmt.Sprintf("%d", out.Owners.Threshold),
+				fmt.Sprintf("%d", out.Owners.Locktime),
+			})
+		}
+		otb.Render()
+	}
+	return buf.String()
+}
+
+func newTxTable(buf *bytes.Buffer, header []string) *tablewriter.Table {
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+	tb.SetHeader(header)
+	return tb
+}
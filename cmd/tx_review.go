@@ -0,0 +1,247 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/amount"
+	"github.com/ava-labs/subnet-cli/internal/codec"
+	"github.com/ava-labs/subnet-cli/internal/txs"
+)
+
+var errTxDeclined = errors.New("transaction declined")
+
+// reviewTimeFormat formats the validation periods in the local time zone.
+const reviewTimeFormat = "2006-01-02 15:04:05 MST"
+
+// txReviewer summarizes each tx built by the client (inputs, outputs,
+// stake, fee, validation period, subnet auth signers), and asks to confirm
+// it before it is signed. A tx is confirmed once, e.g., when built by the
+// dry run to compute the subnet ID, then built again to be issued.
+type txReviewer struct {
+	// serializes the prompts of the concurrent txs
+	mu        sync.Mutex
+	confirmed map[string]struct{}
+}
+
+func newTxReviewer() *txReviewer {
+	return &txReviewer{confirmed: make(map[string]struct{})}
+}
+
+// review prints the summary of [utx], whose signing keys hold [self], and
+// returns "errTxDeclined" unless confirmed.
+func (r *txReviewer) review(cli client.Client, utx platformvm.UnsignedTx, self []ids.ShortID) error {
+	s, err := txs.Summarize(utx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.confirmed[s.Digest]; ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	sources := inputSources(ctx, cli, s, self)
+	var signers []ids.ShortID
+	if s.SubnetID != ids.Empty {
+		owners, err := cli.P().SubnetOwners(ctx, s.SubnetID)
+		if err != nil {
+			// the indices are shown instead
+			zap.L().Debug("failed to get the subnet owners", zap.Stringer("subnetId", s.SubnetID), zap.Error(err))
+		} else {
+			for _, i := range s.SubnetAuth {
+				if int(i) < len(owners.Addrs) {
+					signers = append(signers, owners.Addrs[i])
+				}
+			}
+		}
+	}
+	cancel()
+
+	msg := formatter.F("\n{{blue}}{{bold}}Review the transaction to sign:{{/}}\n")
+	msg += makeTxReviewTable(s, cli.AssetID(), sources, signers, self)
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+	if !confirm(feeConfirmation) {
+		return errTxDeclined
+	}
+	r.confirmed[s.Digest] = struct{}{}
+	return nil
+}
+
+// inputSources returns the addresses signing each input of [s], by the
+// UTXOs of the signing keys.
+func inputSources(ctx context.Context, cli client.Client, s *txs.Summary, self []ids.ShortID) map[ids.ID][]ids.ShortID {
+	sources := make(map[ids.ID][]ids.ShortID)
+	if len(s.Inputs) == 0 || len(self) == 0 {
+		return sources
+	}
+	hrp := constants.GetHRP(cli.NetworkID())
+	addrs := make([]string, 0, len(self))
+	for _, addr := range self {
+		a, err := formatting.FormatAddress("P", hrp, addr[:])
+		if err != nil {
+			return sources
+		}
+		addrs = append(addrs, a)
+	}
+	ubs, err := cli.P().UTXOs(ctx, addrs)
+	if err != nil {
+		// the UTXO IDs are shown instead
+		zap.L().Debug("failed to get the UTXOs of the keys", zap.Error(err))
+		return sources
+	}
+	owners := make(map[ids.ID]*secp256k1fx.OutputOwners, len(ubs))
+	for _, ub := range ubs {
+		utxo := new(avax.UTXO)
+		if _, err := codec.PCodecManager.Unmarshal(ub, utxo); err != nil {
+			continue
+		}
+		out := utxo.Out
+		if lout, ok := out.(*platformvm.StakeableLockOut); ok {
+			out = lout.TransferableOut
+		}
+		if tout, ok := out.(*secp256k1fx.TransferOutput); ok {
+			owners[utxo.InputID()] = &tout.OutputOwners
+		}
+	}
+	for _, in := range s.Inputs {
+		oo, ok := owners[in.ID]
+		if !ok {
+			continue
+		}
+		for _, i := range in.SigIndices {
+			if int(i) < len(oo.Addrs) {
+				sources[in.ID] = append(sources[in.ID], oo.Addrs[i])
+			}
+		}
+	}
+	return sources
+}
+
+func makeTxReviewTable(s *txs.Summary, avaxAssetID ids.ID, sources map[ids.ID][]ids.ShortID, signers []ids.ShortID, self []ids.ShortID) string {
+	hrp := constants.GetHRP(s.NetworkID)
+	formatAddrs := func(addrs []ids.ShortID) string {
+		ss := make([]string, len(addrs))
+		for i, addr := range addrs {
+			a, err := formatting.FormatAddress("P", hrp, addr[:])
+			if err != nil {
+				a = addr.String()
+			}
+			for _, k := range self {
+				if k == addr {
+					a += " (this key)"
+					break
+				}
+			}
+			ss[i] = a
+		}
+		return strings.Join(ss, "\n")
+	}
+	formatAmount := func(assetID ids.ID, v uint64) string {
+		if assetID == avaxAssetID {
+			return amount.Format(v)
+		}
+		return fmt.Sprintf("%d (asset %s)", v, assetID)
+	}
+	formatLocktime := func(locktime uint64) string {
+		if locktime == 0 {
+			return "-"
+		}
+		return time.Unix(int64(locktime), 0).Local().Format(reviewTimeFormat)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetRowLine(true)
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	tb.Append([]string{formatter.F("{{blue}}{{bold}}TYPE{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", s.Type)})
+	tb.Append([]string{formatter.F("{{orange}}NETWORK{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", constants.NetworkName(s.NetworkID))})
+	if v := s.Validator; v != nil {
+		tb.Append([]string{formatter.F("{{magenta}}NODE ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", labeled(v.NodeID.PrefixedString(constants.NodeIDPrefix)))})
+		tb.Append([]string{formatter.F("{{magenta}}VALIDATE START{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", v.Start.Local().Format(reviewTimeFormat))})
+		tb.Append([]string{formatter.F("{{magenta}}VALIDATE END{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}} ({{bold}}%v{{/}})", v.End.Local().Format(reviewTimeFormat), v.End.Sub(v.Start))})
+		if s.SubnetID != ids.Empty {
+			tb.Append([]string{formatter.F("{{magenta}}VALIDATE WEIGHT{{/}}"), formatter.F("{{light-gray}}{{bold}}%d{{/}}", v.Weight)})
+		}
+	}
+	if s.SubnetID != ids.Empty {
+		tb.Append([]string{formatter.F("{{blue}}SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", labeled(s.SubnetID.String()))})
+		auth := fmt.Sprintf("indices %v", s.SubnetAuth)
+		if len(signers) > 0 {
+			auth = formatAddrs(signers)
+		}
+		tb.Append([]string{formatter.F("{{blue}}SUBNET AUTH SIGNERS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", auth)})
+	}
+	staked := make(map[ids.ID]uint64)
+	for _, out := range s.Outputs {
+		if out.Kind == "stake" {
+			staked[out.AssetID] += out.Amount
+		}
+	}
+	for assetID, v := range staked {
+		tb.Append([]string{formatter.F("{{red}}{{bold}}STAKE AMOUNT{{/}}"), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}}", formatAmount(assetID, v))})
+	}
+	for assetID, fee := range s.Fee {
+		if fee == 0 {
+			continue
+		}
+		tb.Append([]string{formatter.F("{{red}}{{bold}}TX FEE{{/}}"), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}}", formatAmount(assetID, fee))})
+	}
+	if len(s.Memo) > 0 {
+		tb.Append([]string{formatter.F("{{dark-green}}MEMO{{/}}"), formatter.F("{{light-gray}}%q{{/}}", s.Memo)})
+	}
+	tb.Append([]string{formatter.F("{{cyan}}DIGEST{{/}}"), formatter.F("{{light-gray}}%s{{/}}", s.Digest)})
+	tb.Render()
+
+	if len(s.Inputs) > 0 {
+		itb := newTxTable(buf, []string{"input UTXO", "amount", "source", "locktime"})
+		for _, in := range s.Inputs {
+			source := formatAddrs(sources[in.ID])
+			if source == "" {
+				source = fmt.Sprintf("sig indices %v", in.SigIndices)
+			}
+			itb.Append([]string{in.UTXOID, formatAmount(in.AssetID, in.Amount), source, formatLocktime(in.Locktime)})
+		}
+		itb.Render()
+	}
+	if len(s.Outputs) > 0 {
+		otb := newTxTable(buf, []string{"output", "amount", "destination", "locktime"})
+		for _, out := range s.Outputs {
+			dest := strings.Join(out.Owners.Addrs, "\n")
+			if out.Owners.Threshold > 1 {
+				dest = fmt.Sprintf("%d of\n%s", out.Owners.Threshold, dest)
+			}
+			locktime := out.Owners.Locktime
+			if out.Stakeable {
+				locktime = out.Locktime
+			}
+			otb.Append([]string{out.Kind, formatAmount(out.AssetID, out.Amount), dest, formatLocktime(locktime)})
+		}
+		otb.Render()
+	}
+	return buf.String()
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/node"
+	"github.com/ava-labs/subnet-cli/internal/release"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var (
+	errUpdateAvailable = errors.New("update available")
+	errNoPublicKey     = errors.New("no release public key to verify the update with (development build), set --release-public-key")
+)
+
+// UpdateCommand implements "subnet-cli update" command.
+func UpdateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Updates subnet-cli to the latest release",
+		Long: `
+Checks the latest GitHub release of subnet-cli, verifies the signature of
+its checksums file and the checksum of the archive for the platform, and
+replaces the running binary. With "--check-only", only reports the latest
+release and fails if it is newer than the running one (e.g., in CI).
+
+If "--public-uri" is set, warns if the node is older than the oldest
+avalanchego version the release is compatible with.
+
+$ subnet-cli update --check-only
+$ subnet-cli update --public-uri=https://api.avax-test.network
+
+`,
+		RunE: updateFunc,
+	}
+	cmd.PersistentFlags().BoolVar(&checkOnly, "check-only", false, "'true' to only check for a newer release, failing if any")
+	cmd.PersistentFlags().StringVar(&releasePublicKey, "release-public-key", "", "base64-encoded ed25519 public key to verify the release with (default to the key of the build)")
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "", "URI of the node to check the compatibility of the release with, empty to not check")
+	return cmd
+}
+
+func updateFunc(cmd *cobra.Command, args []string) error {
+	cli := release.New(release.DefaultAPI, release.DefaultRepo)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	rel, err := cli.Latest(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	latest, err := rel.Version()
+	if err != nil {
+		return err
+	}
+	color.Outf("{{blue}}current version:{{/}} %s\n", Version)
+	color.Outf("{{blue}}latest release:{{/}}  %s\n", rel.Tag)
+	if err := checkNodeCompatibility(rel); err != nil {
+		return err
+	}
+
+	current, err := version.NewDefaultParser().Parse("v" + Version)
+	if err != nil {
+		color.Outf("{{yellow}}development build %q, cannot compare with the latest release{{/}}\n", Version)
+		if checkOnly {
+			// only fail on a release known to be newer
+			return nil
+		}
+	} else if latest.Compare(current) <= 0 {
+		color.Outf("{{green}}subnet-cli is up to date{{/}}\n")
+		return nil
+	}
+	if checkOnly {
+		return errUpdateAvailable
+	}
+
+	pub, err := updatePublicKey()
+	if err != nil {
+		return err
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	exe, err = filepath.EvalSymlinks(exe)
+	if err != nil {
+		return err
+	}
+	if !confirm("{{green}}Yes, update " + exe + " to " + rel.Tag + "!{{/}}") {
+		return errAborted
+	}
+
+	color.Outf("{{blue}}Downloading %s...{{/}}\n", release.ArchiveName(rel.Tag, runtime.GOOS, runtime.GOARCH))
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	bin, err := cli.Fetch(ctx, rel, runtime.GOOS, runtime.GOARCH, pub)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if err := release.Replace(exe, bin); err != nil {
+		return err
+	}
+	color.Outf("{{green}}updated %s to %s{{/}}\n", exe, rel.Tag)
+	return nil
+}
+
+// updatePublicKey returns the public key of "--release-public-key",
+// or the one of the build.
+func updatePublicKey() (ed25519.PublicKey, error) {
+	s := releasePublicKey
+	if s == "" {
+		s = release.PublicKey
+	}
+	if s == "" {
+		return nil, errNoPublicKey
+	}
+	return release.ParsePublicKey(s)
+}
+
+// checkNodeCompatibility warns if the node of "--public-uri" is older
+// than the oldest avalanchego version the release is compatible with.
+func checkNodeCompatibility(rel *release.Release) error {
+	if publicURI == "" {
+		return nil
+	}
+	min, ok := rel.MinNodeVersion()
+	if !ok {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	s, err := node.New(publicURI).Version(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	v, err := version.VersionParser.Parse(s)
+	if err != nil {
+		return err
+	}
+	if v.Before(min) {
+		color.Outf("{{yellow}}warning: %s requires %s or later, but %s runs %s{{/}}\n", rel.Tag, min, publicURI, v)
+		return nil
+	}
+	color.Outf("{{green}}%s is compatible with %s{{/}} {{light-gray}}(%s){{/}}\n", rel.Tag, publicURI, v)
+	return nil
+}
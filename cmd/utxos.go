@@ -0,0 +1,22 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// UTXOsCommand implements "subnet-cli utxos" command.
+func UTXOsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "utxos",
+		Short: "Sub-commands for P-Chain UTXOs",
+	}
+	cmd.AddCommand(
+		newUTXOsExportCommand(),
+		newUTXOsConsolidateCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	return cmd
+}
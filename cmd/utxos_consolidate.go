@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/dustin/go-humanize"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newUTXOsConsolidateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "consolidate",
+		Short: "Merges the P-Chain UTXOs of the key",
+		Long: `
+Merges the spendable P-Chain UTXOs of the key into as few UTXOs as possible
+(e.g., after thousands of faucet drips or reward payouts), so that the next
+txs stay small. The UTXOs are split into as many txs as needed to fit the
+max tx size of the node (or --max-inputs per tx), issued one after another.
+
+The P-Chain has no tx to only move funds within the chain, so each tx is an
+export of 1 nAVAX to the X-Chain address of the key, along with the merged
+change on the P-Chain. Each tx pays the base tx fee.
+
+$ subnet-cli utxos consolidate \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--max-inputs=500
+
+`,
+		RunE: utxosConsolidateFunc,
+	}
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path, or key store URI (e.g., 'keychain://subnet-cli/ops', 'env://OPS_KEY')")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().IntVar(&maxInputs, "max-inputs", 0, "max number of UTXOs merged per tx (0 to only bound by the max tx size)")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "'true' to only show the planned txs")
+	addMemoFlag(cmd)
+	return cmd
+}
+
+func utxosConsolidateFunc(cmd *cobra.Command, args []string) error {
+	cli, info, err := InitClient(publicURI, true)
+	if err != nil {
+		return err
+	}
+	defer info.key.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	planned, err := cli.P().Consolidate(ctx, info.key, maxInputs, nil, client.WithDryMode(true), client.WithMemo(info.memo))
+	cancel()
+	if errors.Is(err, client.ErrNothingToConsolidate) {
+		color.Outf("{{magenta}}no UTXOs to consolidate{{/}}\n")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// the fees of the txs, for the spend limits
+	info.requiredBalance = uint64(info.feeData.TxFee) * uint64(len(planned))
+	if err := info.CheckSpend(); err != nil {
+		return err
+	}
+
+	msg := makeConsolidationTable(planned, false)
+	if dryRun {
+		fmt.Fprint(formatter.ColorableStdOut, msg)
+		return nil
+	}
+	if enablePrompt {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to consolidate the UTXOs in %d tx(s), should we continue?{{/}}\n", len(planned)) + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+	if !info.confirmSpend() {
+		return errTxDeclined
+	}
+
+	println()
+	println()
+	println()
+	// each tx is issued once the previous one is committed
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout*time.Duration(len(planned)))
+	done, err := cli.P().Consolidate(ctx, info.key, maxInputs, func(i int, n int, c client.Consolidation) {
+		color.Outf("{{magenta}}[%d/%d] merged %d UTXOs in %s{{/}} {{light-gray}}(took %v){{/}}\n", i, n, c.Inputs, c.TxID, c.Took)
+	}, client.WithMemo(info.memo))
+	cancel()
+	if len(done) > 0 {
+		fmt.Fprint(formatter.ColorableStdOut, makeConsolidationTable(done, true))
+	}
+	return err
+}
+
+func makeConsolidationTable(cs []client.Consolidation, issued bool) string {
+	buf := bytes.NewBuffer(nil)
+	header := []string{"tx", "UTXOs", "size", "merged ($AVAX)"}
+	if issued {
+		header[0] = "tx ID"
+	}
+	tb := newTxTable(buf, header)
+	inputs, amount := 0, uint64(0)
+	for i, c := range cs {
+		tx := fmt.Sprintf("#%d", i+1)
+		if issued {
+			tx = c.TxID.String()
+		}
+		inputs += c.Inputs
+		amount += c.Amount
+		tb.Append([]string{
+			tx,
+			humanize.Comma(int64(c.Inputs)),
+			humanize.Bytes(uint64(c.Size)),
+			humanize.FormatFloat("#,###.#########", float64(c.Amount)/float64(units.Avax)),
+		})
+	}
+	tb.Append([]string{
+		"total",
+		humanize.Comma(int64(inputs)),
+		"",
+		humanize.FormatFloat("#,###.#########", float64(amount)/float64(units.Avax)),
+	})
+	tb.Render()
+	return buf.String()
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/fees"
+	"github.com/ava-labs/subnet-cli/internal/utxofile"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var errEmptyUTXOsOutput = errors.New("empty --output")
+
+func newUTXOsExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Saves the P-Chain UTXO set of the addresses to a file",
+		Long: `
+Saves the full P-Chain UTXO set of the addresses (or of the key, if no
+address is given) to a file. Any tx building command then spends the UTXOs
+of the file with "--utxos-file" instead of querying them, so the tx can be
+built on a machine that does not reach the network. The file also records
+the X-Chain ID, the AVAX asset ID, and the fees, which are then not
+queried. The file must be re-exported once any of its UTXOs is spent.
+
+$ subnet-cli utxos export \
+--public-uri=https://api.avax-test.network \
+--address=P-fuji1...,P-fuji1... \
+--output=utxos.json
+
+$ subnet-cli multisig propose subnet-validator \
+--utxos-file=utxos.json \
+--public-uri=http://localhost:9650 \
+...
+
+`,
+		RunE: utxosExportFunc,
+	}
+	cmd.PersistentFlags().StringSliceVar(&utxoAddresses, "address", nil, "P-Chain addresses to export the UTXOs of (default to the ones of the key)")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path (ignored if --address is set)")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to derive the addresses (ignored if --address is set)")
+	cmd.PersistentFlags().StringVar(&utxosOutputPath, "output", "utxos.json", "file path to save the UTXOs")
+	addXPubFlags(cmd)
+	return cmd
+}
+
+func utxosExportFunc(cmd *cobra.Command, args []string) error {
+	if utxosOutputPath == "" {
+		return errEmptyUTXOsOutput
+	}
+	loadKey := len(utxoAddresses) == 0
+	cli, info, err := InitClient(publicURI, loadKey)
+	if err != nil {
+		return err
+	}
+
+	addrs := utxoAddresses
+	if loadKey {
+		defer info.key.Close()
+		addrs = info.key.P()
+	} else {
+		for _, addr := range addrs {
+			if _, err := ParsePAddress(addr); err != nil {
+				return err
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	utxos, err := cli.P().UTXOs(ctx, addrs)
+	cancel()
+	if err != nil {
+		return err
+	}
+	f := utxofile.New(cli.NetworkID(), addrs, utxos)
+	// for the txs to be built without querying the network
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	xChainID, err := cli.Info().Client().GetBlockchainID(ctx, "X")
+	cancel()
+	if err != nil {
+		return err
+	}
+	assetID := cli.AssetID()
+	f.XChainID, f.AssetID = &xChainID, &assetID
+	f.Fees = fees.Override{
+		fees.Tx:               uint64(info.feeData.TxFee),
+		fees.CreateSubnet:     uint64(info.feeData.CreateSubnetTxFee),
+		fees.CreateBlockchain: uint64(info.feeData.CreateBlockchainTxFee),
+	}
+	if err := f.Save(utxosOutputPath); err != nil {
+		return err
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	resp, err := cli.P().Client().GetBalance(ctx, addrs)
+	cancel()
+	if err != nil {
+		return err
+	}
+	amount := humanize.FormatFloat("#,###.#######", float64(resp.Balance)/float64(units.Avax))
+	color.Outf("{{green}}saved %d UTXOs (%s $AVAX) to %q{{/}}\n", len(utxos), amount, utxosOutputPath)
+	return nil
+}
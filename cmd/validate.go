@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/validate"
+)
+
+// flagChecks lists the checks of the flag values, run on the set values
+// of the flags defined by the command (in order, to report consistently).
+var flagChecks = []struct {
+	name  string
+	check func(string) error
+}{
+	{name: "subnet-id", check: validate.ID},
+	{name: "copy-from-subnet", check: validate.ID},
+	{name: "node-id", check: validate.NodeID},
+	{name: "chain-name", check: validate.ChainName},
+	{name: "vm-id", check: validate.ID},
+	{name: "staking-asset-id", check: validate.ID},
+	{name: "memo", check: func(s string) error {
+		_, err := validate.Memo(s)
+		return err
+	}},
+}
+
+// validateFlags checks the flags of [cmd] and their cross-field constraints
+// before any network call, and returns all the violations at once.
+func validateFlags(cmd *cobra.Command) error {
+	var c validate.Checker
+	for _, fc := range flagChecks {
+		if f := cmd.Flag(fc.name); f != nil && f.Value.String() != "" {
+			c.Check("--"+fc.name, fc.check(f.Value.String()))
+		}
+	}
+	if cmd.Flag("node-ids") != nil {
+		for _, nodeID := range nodeIDs {
+			c.Check("--node-ids", validate.NodeID(nodeID))
+		}
+	}
+
+	// the start time is derived from now when the command does not take it
+	start := time.Now()
+	if cmd.Flag("validate-start-buffer") != nil {
+		start = start.Add(validateStartBuffer)
+	}
+	startOK := true
+	if cmd.Flag("validate-start") != nil && validateStarts != "" {
+		var err error
+		start, err = validate.Time(validateStarts, time.Now())
+		c.Check("--validate-start", err)
+		startOK = err == nil
+	}
+	switch {
+	case cmd.Flag("validate-duration") != nil && validateDurations != "":
+		if cmd.Flags().Changed("validate-end") {
+			c.Check("--validate-duration", validate.ErrEndAndDuration)
+			break
+		}
+		_, err := validate.Duration(validateDurations)
+		c.Check("--validate-duration", err)
+	case cmd.Flag("validate-end") != nil && validateEnds != "":
+		end, err := validate.Time(validateEnds, time.Now())
+		c.Check("--validate-end", err)
+		if err == nil && startOK {
+			c.Check("--validate-end", validate.Window(start, end))
+		}
+	}
+	if cmd.Flag("validate-weight") != nil {
+		c.Check("--validate-weight", validate.Weight(validateWeight))
+	}
+	if cmd.Flag("min-uptime") != nil {
+		c.Check("--min-uptime", validate.Percent(minUptime))
+	}
+	if cmd.Flag("max-weight-percent") != nil {
+		c.Check("--max-weight-percent", validate.Percent(float64(maxWeightPercent)))
+	}
+	if cmd.Flag("output-threshold") != nil && len(outputOwnerAddrs) > 0 {
+		c.Check("--output-threshold", validate.Threshold(outputThreshold, len(outputOwnerAddrs)))
+	}
+	return c.Err()
+}
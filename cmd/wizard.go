@@ -8,17 +8,19 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"os"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/dustin/go-humanize"
-	"github.com/manifoldco/promptui"
 	"github.com/onsi/ginkgo/v2/formatter"
 	"github.com/spf13/cobra"
 
 	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/manifest"
+	"github.com/ava-labs/subnet-cli/internal/progress"
+	"github.com/ava-labs/subnet-cli/internal/runner"
 	"github.com/ava-labs/subnet-cli/pkg/color"
 )
 
@@ -27,36 +29,108 @@ func WizardCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "wizard",
 		Short: "A magical command for creating an entire subnet",
-		RunE:  wizardFunc,
+		Long: `
+Creates a subnet, adds the nodes as validators, and creates a blockchain.
+
+Re-running the wizard with the same parameters skips the steps already
+satisfied on chain (e.g., the blockchain of --chain-name and --vm-id on a
+subnet controlled by the key, the existing validators), and resumes the rest.
+
+With --local, the wizard starts a local network via the
+avalanche-network-runner instead (the VM binary must be in the
+plugins dir of --avalanchego-path), restarts the nodes to track the
+subnet, and verifies the blockchain produces blocks, as a smoke test:
+
+$ avalanche-network-runner server --grpc-gateway-port=:8081 &
+$ subnet-cli wizard \
+--local \
+--avalanchego-path=./build/avalanchego \
+--chain-name=subnetevm \
+--vm-id=srEXiWaHuhNyGwPUi444Tu47ZEDwxTWrbQiuD7FmgSAQ6X7Dy \
+--vm-genesis-path=./genesis.json
+
+With --spec, the wizard reads the subnet parameters, the validators (and
+their node endpoints), and the chains (with their genesis files) from a
+YAML spec, runs without prompts, creates every chain of the spec, and
+writes the spec back with the subnet and blockchain IDs. Re-running it
+provisions whatever is still missing:
+
+$ subnet-cli wizard --spec=subnet.yaml
+
+`,
+		RunE: wizardFunc,
 	}
 
 	// "create subnet"
 	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
-	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path, or key store URI (e.g., 'keychain://subnet-cli/ops', 'env://OPS_KEY')")
 	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "existing subnet to resume the wizard on (default to the subnet of a previous run with the same --chain-name and --vm-id, if any)")
 
 	// "add validator"
 	cmd.PersistentFlags().StringSliceVar(&nodeIDs, "node-ids", nil, "a list of node IDs (must be formatted in ids.ID)")
-	end := time.Now().Add(defaultValDuration)
-	cmd.PersistentFlags().StringVar(&validateEnds, "validate-end", end.Format(time.RFC3339), "validate start timestamp in RFC3339 format")
+	addValidateEndFlags(cmd, fmt.Sprintf("+%dd", defaultValDuration/(24*time.Hour)), "validate end time")
+	cmd.PersistentFlags().BoolVar(&stakeLocked, "stake-locked", false, "'true' to stake the locked stakeable AVAX first (e.g., vested), which cannot pay the fees")
+	addStakeDurationFlags(cmd)
+	addOutputFlags(cmd)
+	addMemoFlag(cmd)
 
 	// "create blockchain"
 	cmd.PersistentFlags().StringVar(&chainName, "chain-name", "", "chain name")
+	cmd.PersistentFlags().StringVar(&chainlistPath, "chainlist", "", "JSON file of the known EVM chains (e.g., https://chainid.network/chains.json) to check the chain ID of the genesis against, besides the built-in ones")
 	cmd.PersistentFlags().StringVar(&vmIDs, "vm-id", "", "VM ID (must be formatted in ids.ID)")
 	cmd.PersistentFlags().StringVar(&vmGenesisPath, "vm-genesis-path", "", "VM genesis file path")
 
+	// spec mode
+	cmd.PersistentFlags().StringVar(&wizardSpecPath, "spec", "", "YAML spec of the subnet, validators, and chains to provision without prompts, instead of the flags")
+	cmd.PersistentFlags().StringVar(&wizardSpecOutput, "spec-output", "", "file path to write the spec with the created IDs to (default to update --spec)")
+
+	// bulk mode
+	cmd.PersistentFlags().IntVar(&wizardCount, "count", 1, "number of subnets (each with a blockchain) to create, with the chain names suffixed by the index")
+	cmd.PersistentFlags().IntVar(&wizardConcurrency, "concurrency", 4, "maximum number of subnets created concurrently with --count")
+
+	// local mode
+	cmd.PersistentFlags().BoolVar(&wizardLocal, "local", false, "'true' to run the wizard against a local network of the avalanche-network-runner, and verify the blockchain produces blocks")
+	cmd.PersistentFlags().StringVar(&runnerEndpoint, "runner-endpoint", runner.DefaultEndpoint, "gRPC gateway endpoint of the avalanche-network-runner (with --local)")
+	cmd.PersistentFlags().StringVar(&localExecPath, "avalanchego-path", "", "avalanchego binary to run the local nodes with, with the VM in its plugins dir (with --local)")
+	cmd.PersistentFlags().Uint32Var(&localNodes, "local-nodes", 5, "number of local nodes (with --local)")
+	cmd.PersistentFlags().BoolVar(&localKeep, "local-keep", false, "'true' to keep the local network running after the wizard (with --local)")
+
 	return cmd
 }
 
 func wizardFunc(cmd *cobra.Command, args []string) error {
+	var spec *manifest.Spec
+	if wizardSpecPath != "" {
+		var err error
+		spec, err = loadWizardSpec()
+		if err != nil {
+			return err
+		}
+	}
+
+	var local *localNetwork
+	if wizardLocal {
+		var err error
+		local, err = startLocalNetwork()
+		if err != nil {
+			return err
+		}
+		defer local.stop()
+	}
+
 	cli, info, err := InitClient(publicURI, true)
 	if err != nil {
 		return err
 	}
+	defer info.key.Close()
 
 	if len(nodeIDs) == 0 {
 		return errors.New("no NodeIDs provided")
 	}
+	if wizardCount < 1 {
+		return errors.New("--count must be at least 1")
+	}
 
 	// Parse Args
 	info.subnetID = ids.Empty
@@ -64,11 +138,14 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	info.stakeAmount = stakeAmount
-	info.validateEnd, err = time.Parse(time.RFC3339, validateEnds)
+	info.validateEnd, err = parseValidateEnd(time.Now().Add(validateStartBuffer))
 	if err != nil {
 		return err
 	}
-	info.validateWeight = defaultValidateWeight
+	info.validateWeight = validateWeight
+	if info.validateWeight == 0 {
+		info.validateWeight = defaultValidateWeight
+	}
 	info.validateRewardFeePercent = defaultValFeePercent
 	info.rewardAddr = info.key.Addresses()[0]
 	info.changeAddr = info.key.Addresses()[0]
@@ -83,9 +160,44 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 	info.chainName = chainName
 	info.vmGenesisPath = vmGenesisPath
 
+	// skip the steps satisfied by a previous run with the same parameters
+	state := &wizardState{}
+	if wizardCount == 1 {
+		state, err = reconcileWizard(cli, info)
+		if err != nil {
+			return err
+		}
+	}
+	if state.blockchainID == ids.Empty {
+		if err := checkChainCollisions(cli, state.subnetID, chainName, vmGenesisBytes); err != nil {
+			return err
+		}
+	}
+
+	// fail fast on the validation periods, before any tx is issued
+	if err := CheckClockSkew(publicURI); err != nil {
+		return err
+	}
+	now, end := time.Now(), info.validateEnd
+	for i, nodeID := range info.nodeIDs {
+		if err := CheckStakeDuration(cli.NetworkID(), now, now.Add(validateStartBuffer), end); err != nil {
+			return fmt.Errorf("%s: %w", nodeID, err)
+		}
+		if i < len(info.nodeIDs)-1 {
+			end = end.Add(defaultStagger)
+		}
+	}
+
 	// Compute dry run cost/actions for approval
 	info.totalStakeAmount = uint64(len(info.nodeIDs)) * info.stakeAmount
-	info.txFee = uint64(info.feeData.CreateSubnetTxFee) + uint64(info.feeData.TxFee)*uint64(len(info.allNodeIDs)) + uint64(info.feeData.CreateBlockchainTxFee)
+	info.txFee = uint64(info.feeData.TxFee) * uint64(len(info.allNodeIDs)-len(state.validators))
+	if state.subnetID == ids.Empty {
+		info.txFee += uint64(info.feeData.CreateSubnetTxFee)
+	}
+	if state.blockchainID == ids.Empty {
+		info.txFee += uint64(info.feeData.CreateBlockchainTxFee)
+	}
+	info.txFee *= uint64(wizardCount)
 	info.requiredBalance = info.stakeAmount + info.txFee
 	if err := info.CheckBalance(); err != nil {
 		return err
@@ -97,28 +209,22 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Fprint(formatter.ColorableStdOut, msg)
 
-	prompt := promptui.Select{
-		Label:  "\n",
-		Stdout: os.Stdout,
-		Items: []string{
-			formatter.F("{{green}}Yes, let's create! {{bold}}{{underline}}I agree to pay the fee{{/}}{{green}}!{{/}}"),
-			formatter.F("{{red}}No, stop it!{{/}}"),
-		},
-	}
-	idx, _, err := prompt.Run()
-	if err != nil {
-		return nil //nolint:nilerr
-	}
-	if idx == 1 {
-		return nil
+	if !info.confirmSpend() {
+		return errTxDeclined
 	}
 	println()
 	println()
 
 	// Ensure all nodes are validators on the primary network
+	for _, nodeID := range info.allNodeIDs {
+		if !containsNodeID(info.nodeIDs, nodeID) {
+			satisfied(progress.Event{Step: "add_validator", NodeID: nodeID.PrefixedString(constants.NodeIDPrefix)}, "already a primary network validator")
+		}
+	}
 	for i, nodeID := range info.nodeIDs {
+		done := progressEvents.Start(progress.Event{Step: "add_validator", NodeID: nodeID.PrefixedString(constants.NodeIDPrefix)})
 		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
-		info.validateStart = time.Now().Add(30 * time.Second)
+		info.validateStart = time.Now().Add(validateStartBuffer)
 		took, err := cli.P().AddValidator(
 			ctx,
 			info.key,
@@ -126,11 +232,15 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 			info.validateStart,
 			info.validateEnd,
 			client.WithStakeAmount(info.stakeAmount),
+			client.WithStakeableLocked(stakeLocked),
 			client.WithRewardShares(info.validateRewardFeePercent*10000),
 			client.WithRewardAddress(info.rewardAddr),
 			client.WithChangeAddress(info.changeAddr),
+			client.WithOutputOwners(info.outputOwners),
+			client.WithMemo(info.memo),
 		)
 		cancel()
+		done(err)
 		if err != nil {
 			return err
 		}
@@ -145,42 +255,73 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 		println()
 	}
 
-	// Create subnet
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
-	subnetID, took, err := cli.P().CreateSubnet(ctx, info.key)
-	cancel()
-	if err != nil {
-		return err
+	if wizardCount > 1 {
+		return runBulkWizard(cli, info, vmGenesisBytes)
 	}
-	info.subnetID = subnetID
-	color.Outf("{{magenta}}created subnet{{/}} %q {{light-gray}}(took %v){{/}}\n", info.subnetID, took)
-
-	// Pause for operator to whitelist subnet on all validators (and to remind
-	// that a binary by the name of [vmIDs] must be in the plugins dir)
-	color.Outf("\n\n\n{{cyan}}Now, time for some config changes on your node(s).\nSet --whitelisted-subnets=%s and move the compiled VM %s to <build-dir>/plugins/%s.\nWhen you're finished, restart your node.{{/}}\n", info.subnetID, info.vmID, info.vmID)
-	prompt = promptui.Select{
-		Label:  "\n",
-		Stdout: os.Stdout,
-		Items: []string{
-			formatter.F("{{green}}Yes, let's continue!{{bold}}{{underline}} I've updated --whitelisted-subnets, built my VM, and restarted my node(s)!{{/}}"),
-			formatter.F("{{red}}No, stop it!{{/}}"),
-		},
-	}
-	idx, _, err = prompt.Run()
-	if err != nil {
-		return nil //nolint:nilerr
+
+	// Create subnet
+	if state.subnetID != ids.Empty {
+		info.subnetID = state.subnetID
+		satisfied(progress.Event{Step: "create_subnet", SubnetID: info.subnetID.String()}, fmt.Sprintf("subnet %s", info.subnetID))
+	} else {
+		done := progressEvents.Start(progress.Event{Step: "create_subnet"})
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		subnetID, took, err := cli.P().CreateSubnet(ctx, info.key, client.WithOutputOwners(info.outputOwners), client.WithMemo(info.memo))
+		cancel()
+		done(err)
+		if err != nil {
+			return err
+		}
+		info.subnetID = subnetID
+		color.Outf("{{magenta}}created subnet{{/}} %q {{light-gray}}(took %v){{/}}\n", info.subnetID, took)
 	}
-	if idx == 1 {
-		return nil
+
+	done := progressEvents.Start(progress.Event{Step: "track_subnet", SubnetID: info.subnetID.String()})
+	if local != nil {
+		err := local.track(info.subnetID)
+		done(err)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Pause for operator to whitelist subnet on all validators (and to remind
+		// that a binary by the name of [vmIDs] must be in the plugins dir)
+		color.Outf("\n\n\n{{cyan}}Now, time for some config changes on your node(s).\nSet --whitelisted-subnets=%s and move the compiled VM %s to <build-dir>/plugins/%s.\nWhen you're finished, restart your node.{{/}}\n", info.subnetID, info.vmID, info.vmID)
+		if !confirm("{{green}}Yes, let's continue!{{bold}}{{underline}} I've updated --whitelisted-subnets, built my VM, and restarted my node(s)!{{/}}") {
+			done(errAborted)
+			return errAborted
+		}
+		done(nil)
 	}
 	println()
 	println()
 
 	// Add validators to subnet
 	for _, nodeID := range info.allNodeIDs { // do all nodes, not parsed
-		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		if _, ok := state.validators[nodeID]; ok {
+			satisfied(progress.Event{
+				Step:     "add_subnet_validator",
+				NodeID:   nodeID.PrefixedString(constants.NodeIDPrefix),
+				SubnetID: info.subnetID.String(),
+			}, fmt.Sprintf("already a validator on %s", info.subnetID))
+			continue
+		}
 		valInfo := info.valInfos[nodeID]
-		start := time.Now().Add(30 * time.Second)
+		weight := info.validateWeight
+		if spec != nil && spec.Weight(nodeID) > 0 {
+			weight = spec.Weight(nodeID)
+		}
+		now := time.Now()
+		start := now.Add(validateStartBuffer)
+		if err := CheckStakeDuration(cli.NetworkID(), now, start, valInfo.end); err != nil {
+			return fmt.Errorf("%s: %w", nodeID, err)
+		}
+		done := progressEvents.Start(progress.Event{
+			Step:     "add_subnet_validator",
+			NodeID:   nodeID.PrefixedString(constants.NodeIDPrefix),
+			SubnetID: info.subnetID.String(),
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 		took, err := cli.P().AddSubnetValidator(
 			ctx,
 			info.key,
@@ -188,9 +329,12 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 			nodeID,
 			start,
 			valInfo.end,
-			validateWeight,
+			weight,
+			client.WithOutputOwners(info.outputOwners),
+			client.WithMemo(info.memo),
 		)
 		cancel()
+		done(err)
 		if err != nil {
 			return err
 		}
@@ -204,34 +348,58 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 	println()
 
 	// Add blockchain to subnet
-	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
-	blockchainID, took, err := cli.P().CreateBlockchain(
-		ctx,
-		info.key,
-		info.subnetID,
-		info.chainName,
-		info.vmID,
-		vmGenesisBytes,
-	)
-	cancel()
-	if err != nil {
-		return err
+	if state.blockchainID != ids.Empty {
+		info.blockchainID = state.blockchainID
+		satisfied(progress.Event{Step: "create_blockchain", SubnetID: info.subnetID.String()}, fmt.Sprintf("blockchain %s", info.blockchainID))
+	} else {
+		done := progressEvents.Start(progress.Event{Step: "create_blockchain", SubnetID: info.subnetID.String()})
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		blockchainID, took, err := cli.P().CreateBlockchain(
+			ctx,
+			info.key,
+			info.subnetID,
+			info.chainName,
+			info.vmID,
+			vmGenesisBytes,
+			client.WithOutputOwners(info.outputOwners),
+			client.WithMemo(info.memo),
+		)
+		cancel()
+		done(err)
+		if err != nil {
+			return err
+		}
+		info.blockchainID = blockchainID
+		color.Outf("{{magenta}}created blockchain{{/}} %q {{light-gray}}(took %v){{/}}\n\n", info.blockchainID, took)
+	}
+	if local != nil {
+		done = progressEvents.Start(progress.Event{Step: "verify_blockchain"})
+		err := local.verify(info.blockchainID)
+		done(err)
+		if err != nil {
+			return err
+		}
+	}
+
+	if spec != nil {
+		if err := createSpecChains(cli, info, spec); err != nil {
+			return err
+		}
 	}
-	info.blockchainID = blockchainID
-	color.Outf("{{magenta}}created blockchain{{/}} %q {{light-gray}}(took %v){{/}}\n\n", info.blockchainID, took)
 
 	// Print out summary of actions (subnetID, chainID, validator periods)
 	info.requiredBalance = 0
 	info.stakeAmount = 0
 	info.totalStakeAmount = 0
 	info.txFee = 0
-	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	info.balance, err = cli.P().Balance(ctx, info.key)
 	cancel()
 	if err != nil {
 		return err
 	}
 	fmt.Fprint(formatter.ColorableStdOut, CreateSpellPostTable(info))
+	color.Outf("{{light-gray}}follow the chain logs on a node with: subnet-cli logs chain --node-url=<NODE-URL> --chain-id=%s --follow{{/}}\n", info.blockchainID)
 	return nil
 }
 
@@ -255,6 +423,9 @@ func CreateSpellPreTable(i *Info) string {
 	tb.Append([]string{formatter.F("{{dark-green}}CHAIN NAME{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.chainName)})
 	tb.Append([]string{formatter.F("{{dark-green}}VM ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.vmID)})
 	tb.Append([]string{formatter.F("{{dark-green}}VM GENESIS PATH{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.vmGenesisPath)})
+	if wizardCount > 1 {
+		tb.Append([]string{formatter.F("{{orange}}SUBNETS TO CREATE{{/}}"), formatter.F("{{light-gray}}{{bold}}%d{{/}} (%d concurrently)", wizardCount, wizardConcurrency)})
+	}
 	tb.Render()
 	return buf.String()
 }
@@ -266,8 +437,8 @@ func CreateSpellPostTable(i *Info) string {
 	}
 
 	tb.Append([]string{formatter.F("{{orange}}SUBNET VALIDATORS{{/}}"), formatter.F("{{light-gray}}{{bold}}%v{{/}}", i.allNodeIDs)})
-	tb.Append([]string{formatter.F("{{blue}}SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.subnetID)})
-	tb.Append([]string{formatter.F("{{blue}}BLOCKCHAIN ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.blockchainID)})
+	tb.Append([]string{formatter.F("{{blue}}SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", labeled(i.subnetID.String()))})
+	tb.Append([]string{formatter.F("{{blue}}BLOCKCHAIN ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", labeled(i.blockchainID.String()))})
 
 	tb.Append([]string{formatter.F("{{dark-green}}CHAIN NAME{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.chainName)})
 	tb.Append([]string{formatter.F("{{dark-green}}VM ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.vmID)})
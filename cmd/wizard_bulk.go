@@ -0,0 +1,193 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+type bulkResult struct {
+	subnetID     ids.ID
+	chainName    string
+	blockchainID ids.ID
+	took         time.Duration
+	err          error
+}
+
+// runBulkWizard creates [wizardCount] subnets, each with the subnet
+// validators and a blockchain, with at most [wizardConcurrency] subnets in
+// flight. The client serializes the txs that need the same UTXOs.
+func runBulkWizard(cli client.Client, info *Info, vmGenesisBytes []byte) error {
+	workers := wizardConcurrency
+	if useLedger {
+		// ledger signs one tx at a time
+		workers = 1
+	}
+	results := make([]bulkResult, wizardCount)
+	for i := range results {
+		// chain names only allow alphanumeric and space characters
+		results[i].chainName = fmt.Sprintf("%s%d", info.chainName, i)
+	}
+
+	color.Outf("{{blue}}Creating %d subnets with %d worker(s)...{{/}}\n", wizardCount, workers)
+	runPool(wizardCount, workers, func(i int) {
+		r := &results[i]
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		subnetID, took, err := cli.P().CreateSubnet(ctx, info.key, client.WithOutputOwners(info.outputOwners), client.WithMemo(info.memo))
+		cancel()
+		r.subnetID, r.took, r.err = subnetID, took, err
+		if err != nil {
+			color.Outf("{{red}}[%d] failed to create subnet: %v{{/}}\n", i, err)
+			return
+		}
+		color.Outf("{{magenta}}[%d] created subnet{{/}} %q {{light-gray}}(took %v){{/}}\n", i, subnetID, took)
+	})
+
+	created := make([]string, 0, len(results))
+	for _, r := range results {
+		if r.err == nil {
+			created = append(created, r.subnetID.String())
+		}
+	}
+	if len(created) == 0 {
+		fmt.Fprint(formatter.ColorableStdOut, makeBulkTable(results))
+		return fmt.Errorf("%w: %d of %d subnet(s) failed", errBatchFailed, len(results), len(results))
+	}
+
+	color.Outf("\n\n\n{{cyan}}Now, time for some config changes on your node(s).\nSet --whitelisted-subnets=%s and move the compiled VM %s to <build-dir>/plugins/%s.\nWhen you're finished, restart your node.{{/}}\n", strings.Join(created, ","), info.vmID, info.vmID)
+	if !confirm("{{green}}Yes, let's continue!{{bold}}{{underline}} I've updated --whitelisted-subnets, built my VM, and restarted my node(s)!{{/}}") {
+		fmt.Fprint(formatter.ColorableStdOut, makeBulkTable(results))
+		return errAborted
+	}
+	println()
+	println()
+
+	runPool(wizardCount, workers, func(i int) {
+		r := &results[i]
+		if r.err != nil {
+			return
+		}
+		for _, nodeID := range info.allNodeIDs {
+			valInfo := info.valInfos[nodeID]
+			now := time.Now()
+			start := now.Add(validateStartBuffer)
+			if r.err = CheckStakeDuration(cli.NetworkID(), now, start, valInfo.end); r.err != nil {
+				r.err = fmt.Errorf("%s: %w", nodeID, r.err)
+				return
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			took, err := cli.P().AddSubnetValidator(
+				ctx,
+				info.key,
+				r.subnetID,
+				nodeID,
+				start,
+				valInfo.end,
+				validateWeight,
+				client.WithOutputOwners(info.outputOwners),
+				client.WithMemo(info.memo),
+			)
+			cancel()
+			r.took += took
+			if err != nil {
+				r.err = fmt.Errorf("failed to add subnet validator %s: %w", nodeID, err)
+				color.Outf("{{red}}[%d] %v{{/}}\n", i, r.err)
+				return
+			}
+			color.Outf("{{magenta}}[%d] added %s to subnet %s validator set{{/}} {{light-gray}}(took %v){{/}}\n", i, nodeID, r.subnetID, took)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		blockchainID, took, err := cli.P().CreateBlockchain(
+			ctx,
+			info.key,
+			r.subnetID,
+			r.chainName,
+			info.vmID,
+			vmGenesisBytes,
+			client.WithOutputOwners(info.outputOwners),
+			client.WithMemo(info.memo),
+		)
+		cancel()
+		r.took += took
+		if err != nil {
+			r.err = fmt.Errorf("failed to create blockchain: %w", err)
+			color.Outf("{{red}}[%d] %v{{/}}\n", i, r.err)
+			return
+		}
+		r.blockchainID = blockchainID
+		color.Outf("{{magenta}}[%d] created blockchain{{/}} %q {{light-gray}}(took %v){{/}}\n", i, blockchainID, took)
+	})
+
+	fmt.Fprint(formatter.ColorableStdOut, makeBulkTable(results))
+	failed := 0
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d subnet(s) failed", errBatchFailed, failed, len(results))
+	}
+	return nil
+}
+
+// runPool calls [f] for 0 to n-1, with at most [workers] calls at a time.
+func runPool(n int, workers int, f func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, workers)
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			f(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func makeBulkTable(results []bulkResult) string {
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+	tb.SetHeader([]string{"#", "subnet ID", "chain name", "blockchain ID", "result"})
+	for i, r := range results {
+		result := formatter.F("{{green}}created{{/}} (took %v)", r.took)
+		if r.err != nil {
+			result = formatter.F("{{red}}failed: %v{{/}}", r.err)
+		}
+		subnetID, blockchainID := "", ""
+		if r.subnetID != ids.Empty {
+			subnetID = r.subnetID.String()
+		}
+		if r.blockchainID != ids.Empty {
+			blockchainID = r.blockchainID.String()
+		}
+		tb.Append([]string{fmt.Sprintf("%d", i), subnetID, r.chainName, blockchainID, result})
+	}
+	tb.Render()
+	return buf.String()
+}
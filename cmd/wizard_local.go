@@ -0,0 +1,176 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+
+	"github.com/ava-labs/subnet-cli/internal/evm"
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/internal/poll"
+	"github.com/ava-labs/subnet-cli/internal/runner"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+	"github.com/ava-labs/subnet-cli/pkg/prompt"
+)
+
+// localTimeout bounds the start and the restarts of the local network,
+// and the verification of the blockchain.
+const localTimeout = 5 * time.Minute
+
+var (
+	errEmptyAvalanchegoPath = errors.New("--local requires --avalanchego-path")
+	errLocalCount           = errors.New("--local does not support --count")
+)
+
+// localNetwork is the network of the avalanche-network-runner,
+// driven by "wizard --local".
+type localNetwork struct {
+	runner runner.Client
+	nodes  []runner.Node
+}
+
+// startLocalNetwork starts the local network via the network runner, and
+// points the wizard at it: the endpoint of the first node, all the nodes
+// as validators, and the pre-funded "ewoq" key, without prompts.
+func startLocalNetwork() (*localNetwork, error) {
+	if localExecPath == "" {
+		return nil, errEmptyAvalanchegoPath
+	}
+	if wizardCount > 1 {
+		return nil, errLocalCount
+	}
+	ln := &localNetwork{runner: runner.New(runnerEndpoint)}
+
+	color.Outf("\n{{blue}}starting a local network of %d nodes via %s...{{/}}\n", localNodes, runnerEndpoint)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	_, err := ln.runner.Start(ctx, runner.StartRequest{
+		ExecPath: localExecPath,
+		NumNodes: localNodes,
+		LogLevel: "INFO",
+	})
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if err := ln.waitHealthy(); err != nil {
+		ln.stop()
+		return nil, err
+	}
+
+	publicURI = ln.nodes[0].URI
+	nodeIDs = make([]string, len(ln.nodes))
+	for i, n := range ln.nodes {
+		nodeIDs[i] = n.ID
+	}
+	useLedger = false
+	if err := os.Setenv(key.PrivateKeyEnvVar, key.EwoqPrivateKey); err != nil {
+		ln.stop()
+		return nil, err
+	}
+	enablePrompt = false
+	SetPrompter(prompt.NewAuto())
+	return ln, nil
+}
+
+func (ln *localNetwork) waitHealthy() error {
+	color.Outf("{{blue}}waiting for the local network to be healthy...{{/}}\n")
+	ctx, cancel := context.WithTimeout(context.Background(), localTimeout)
+	cluster, err := runner.WaitHealthy(ctx, ln.runner, pollInterval)
+	cancel()
+	if err != nil {
+		return err
+	}
+	ln.nodes = cluster.Nodes()
+	for _, n := range ln.nodes {
+		color.Outf("{{light-gray}}%s: %s (%s){{/}}\n", n.Name, n.ID, n.URI)
+	}
+	return nil
+}
+
+// track restarts the nodes to track the subnet [subnetID], instead of
+// pausing for the operator to do it.
+func (ln *localNetwork) track(subnetID ids.ID) error {
+	color.Outf("\n{{blue}}restarting the local nodes to track %s...{{/}}\n", subnetID)
+	for _, n := range ln.nodes {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		_, err := ln.runner.RestartNode(ctx, n.Name, localExecPath, []string{subnetID.String()})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to restart %s: %w", n.Name, err)
+		}
+	}
+	return ln.waitHealthy()
+}
+
+// verify checks that every node bootstrapped the blockchain and, for the
+// EVM chains, that a tx is accepted in a new block seen by every node.
+func (ln *localNetwork) verify(blockchainID ids.ID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), localTimeout)
+	defer cancel()
+
+	color.Outf("\n{{blue}}verifying the blockchain %s on the local nodes...{{/}}\n", blockchainID)
+	pl := poll.New(pollInterval)
+	for _, n := range ln.nodes {
+		ic := info.NewClient(n.URI)
+		took, err := pl.Poll(ctx, func() (bool, error) {
+			return ic.IsBootstrapped(ctx, blockchainID.String())
+		})
+		if err != nil {
+			return fmt.Errorf("%s did not bootstrap %s: %w", n.Name, blockchainID, err)
+		}
+		color.Outf("{{green}}%s bootstrapped the blockchain{{/}} {{light-gray}}(took %v){{/}}\n", n.Name, took)
+	}
+
+	ec := evm.New(ln.nodes[0].URI, blockchainID.String())
+	if _, err := ec.ChainID(ctx); err != nil {
+		color.Outf("{{yellow}}not an EVM chain (%v), skipping the block production check{{/}}\n", err)
+		return nil
+	}
+	k, err := loadSoftKey(constants.LocalID)
+	if err != nil {
+		return err
+	}
+	defer k.Close()
+	privKey := k.Key()
+	rcpt, err := evm.Ping(ctx, ec, &privKey.ToECDSA().PublicKey, privKey.SignHash, pollInterval)
+	if err != nil {
+		return fmt.Errorf("blockchain did not accept a transfer: %w", err)
+	}
+	for _, n := range ln.nodes {
+		nc := evm.New(n.URI, blockchainID.String())
+		if _, err := pl.Poll(ctx, func() (bool, error) {
+			blk, err := nc.LatestBlock(ctx)
+			if err != nil {
+				return false, err
+			}
+			return blk.Number >= rcpt.BlockNumber, nil
+		}); err != nil {
+			return fmt.Errorf("%s did not accept block %d: %w", n.Name, rcpt.BlockNumber, err)
+		}
+	}
+	color.Outf("{{green}}blockchain produced block %d, accepted by all the nodes{{/}} {{light-gray}}(tx %s){{/}}\n", rcpt.BlockNumber, rcpt.TxHash)
+	return nil
+}
+
+// stop stops the local network, unless "--local-keep" is set.
+func (ln *localNetwork) stop() {
+	if localKeep {
+		color.Outf("{{yellow}}keeping the local network running (stop it via the network runner){{/}}\n")
+		return
+	}
+	color.Outf("{{red}}stopping the local network{{/}}\n")
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	if err := ln.runner.Stop(ctx); err != nil {
+		color.Outf("{{red}}failed to stop the local network: %v{{/}}\n", err)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/progress"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var errNotSubnetOwner = errors.New("subnet is not controlled by the key")
+
+// wizardState is the wizard steps already satisfied on chain,
+// by a previous run with the same parameters.
+type wizardState struct {
+	// non-empty if the subnet exists
+	subnetID ids.ID
+	// non-empty if the blockchain exists on the subnet
+	blockchainID ids.ID
+	// current or pending validators of the subnet
+	validators map[ids.ShortID]struct{}
+}
+
+// reconcileWizard finds the subnet and the blockchain created by a previous
+// run: the blockchain of the chain name and the VM ID on a subnet controlled
+// by the key, or on "--subnet-id" if set (e.g., to resume a run interrupted
+// before the blockchain was created).
+func reconcileWizard(cli client.Client, info *Info) (*wizardState, error) {
+	state := &wizardState{validators: make(map[ids.ShortID]struct{})}
+	if subnetIDs != "" {
+		subnetID, err := ids.FromString(subnetIDs)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		err = cli.P().CheckSubnetAuth(ctx, info.key, subnetID)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", errNotSubnetOwner, subnetID, err)
+		}
+		state.subnetID = subnetID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	ss, err := cli.P().GetSubnets(ctx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[ids.ID]bool)
+	for _, s := range ss {
+		// the wizard creates the subnets controlled by the key only
+		owned[s.ID] = s.Threshold == 1 && len(s.ControlKeys) == 1 && s.ControlKeys[0] == info.key.Addresses()[0]
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	bcs, err := cli.P().GetBlockchains(ctx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	for _, bc := range bcs {
+		if bc.Name != info.chainName || bc.VMID != info.vmID {
+			continue
+		}
+		if state.subnetID == ids.Empty && owned[bc.SubnetID] || bc.SubnetID == state.subnetID {
+			state.subnetID, state.blockchainID = bc.SubnetID, bc.ID
+			break
+		}
+	}
+	if state.subnetID == ids.Empty {
+		return state, nil
+	}
+
+	vs, err := subnetValidators(cli, state.subnetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, nodeID := range info.allNodeIDs {
+		if _, ok := vs[nodeID]; ok {
+			state.validators[nodeID] = struct{}{}
+		}
+	}
+	return state, nil
+}
+
+// subnetValidators returns the node IDs of the current and the pending
+// validators of the subnet.
+func subnetValidators(cli client.Client, subnetID ids.ID) (map[ids.ShortID]struct{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	vs := make(map[ids.ShortID]struct{})
+	current, err := cli.P().GetValidators(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range current {
+		vs[v.NodeID] = struct{}{}
+	}
+	pending, err := cli.P().GetPendingValidators(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range pending {
+		vs[v.NodeID] = struct{}{}
+	}
+	return vs, nil
+}
+
+// satisfied reports the wizard step already satisfied on chain.
+func satisfied(step progress.Event, what string) {
+	step.Type = progress.StepSatisfied
+	progressEvents.Emit(step)
+	color.Outf("{{yellow}}%s: already satisfied{{/}} {{light-gray}}(%s){{/}}\n", step.Step, what)
+}
+
+func containsNodeID(nodeIDs []ids.ShortID, nodeID ids.ShortID) bool {
+	for _, id := range nodeIDs {
+		if id == nodeID {
+			return true
+		}
+	}
+	return false
+}
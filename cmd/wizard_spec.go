@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/amount"
+	"github.com/ava-labs/subnet-cli/internal/manifest"
+	"github.com/ava-labs/subnet-cli/internal/node"
+	"github.com/ava-labs/subnet-cli/internal/progress"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+	"github.com/ava-labs/subnet-cli/pkg/prompt"
+)
+
+var errSpecCount = errors.New("--spec creates a single subnet, and can't be used with --count")
+
+// loadWizardSpec loads "--spec" into the wizard flags, and disables the
+// prompts. The first chain is created by the wizard flow, the others by
+// "createSpecChains".
+func loadWizardSpec() (*manifest.Spec, error) {
+	if wizardCount != 1 {
+		return nil, errSpecCount
+	}
+	s, err := manifest.LoadSpec(wizardSpecPath)
+	if err != nil {
+		return nil, err
+	}
+	if s.PublicURI != "" {
+		publicURI = s.PublicURI
+	}
+	if s.PrivateKeyPath != "" {
+		privKeyPath = s.PrivateKeyPath
+	}
+	subnetIDs = s.Subnet.ID
+	if s.Subnet.ValidateEnd != "" {
+		validateEnds, validateDurations = s.Subnet.ValidateEnd, ""
+	}
+	if s.Subnet.StakeAmount != "" {
+		stakeAmount, err = amount.Parse(s.Subnet.StakeAmount)
+		if err != nil {
+			return nil, err
+		}
+	}
+	validateWeight = s.Subnet.Weight
+	nodeIDs = make([]string, len(s.Validators))
+	for i, v := range s.Validators {
+		nodeIDs[i] = v.NodeID
+	}
+	chainName, vmIDs, vmGenesisPath = s.Chains[0].Name, s.Chains[0].VMID, s.Chains[0].GenesisPath(wizardSpecPath)
+
+	enablePrompt = false
+	SetPrompter(prompt.NewAuto())
+	return s, nil
+}
+
+// createSpecChains creates the chains of the spec after the first one,
+// skipping the ones already on the subnet, and writes the spec with
+// the subnet and blockchain IDs.
+func createSpecChains(cli client.Client, info *Info, s *manifest.Spec) error {
+	s.Subnet.ID = info.subnetID.String()
+	s.Chains[0].ID = info.blockchainID.String()
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	bcs, err := cli.P().GetBlockchains(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	for i := 1; i < len(s.Chains); i++ {
+		c := &s.Chains[i]
+		vmID, err := ids.FromString(c.VMID)
+		if err != nil {
+			return err
+		}
+		existing := ids.Empty
+		for _, bc := range bcs {
+			if bc.SubnetID == info.subnetID && bc.Name == c.Name && bc.VMID == vmID {
+				existing = bc.ID
+				break
+			}
+		}
+		if existing != ids.Empty {
+			c.ID = existing.String()
+			satisfied(progress.Event{Step: "create_blockchain", SubnetID: info.subnetID.String()}, fmt.Sprintf("blockchain %s", existing))
+			continue
+		}
+		genesis, err := ioutil.ReadFile(c.GenesisPath(wizardSpecPath))
+		if err != nil {
+			return err
+		}
+		done := progressEvents.Start(progress.Event{Step: "create_blockchain", SubnetID: info.subnetID.String()})
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		blockchainID, took, err := cli.P().CreateBlockchain(
+			ctx,
+			info.key,
+			info.subnetID,
+			c.Name,
+			vmID,
+			genesis,
+			client.WithOutputOwners(info.outputOwners),
+			client.WithMemo(info.memo),
+		)
+		cancel()
+		done(err)
+		if err != nil {
+			return err
+		}
+		c.ID = blockchainID.String()
+		color.Outf("{{magenta}}created blockchain{{/}} %q {{light-gray}}(%s, took %v){{/}}\n\n", blockchainID, c.Name, took)
+	}
+
+	p := wizardSpecOutput
+	if p == "" {
+		p = wizardSpecPath
+	}
+	if err := s.Save(p); err != nil {
+		return err
+	}
+	color.Outf("{{green}}wrote the spec with the subnet and blockchain IDs to %q{{/}}\n", p)
+	checkSpecEndpoints(s, info.subnetID)
+	return nil
+}
+
+// checkSpecEndpoints reports the validator endpoints not running the
+// chains of the subnet yet (e.g., not restarted to track it).
+func checkSpecEndpoints(s *manifest.Spec, subnetID ids.ID) {
+	for _, v := range s.Validators {
+		if v.Endpoint == "" {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		st, err := node.Ping(ctx, node.New(v.Endpoint))
+		cancel()
+		switch {
+		case err != nil:
+			color.Outf("{{yellow}}%s (%s) is unreachable: %v{{/}}\n", v.NodeID, v.Endpoint, err)
+		case !containsString(st.TrackedSubnets, subnetID.String()):
+			color.Outf("{{yellow}}%s (%s) does not track subnet %s yet{{/}} {{light-gray}}(see 'subnet-cli node track-subnet'){{/}}\n", v.NodeID, v.Endpoint, subnetID)
+		default:
+			color.Outf("{{green}}%s (%s) tracks subnet %s{{/}}\n", v.NodeID, v.Endpoint, subnetID)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package allowlist implements the operator-maintained allowlist of the
+// addresses the built transactions may send funds to (e.g., a custody
+// requirement).
+package allowlist
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+
+	"github.com/ava-labs/subnet-cli/internal/txs"
+)
+
+var (
+	ErrNotAllowed     = errors.New("destination addresses not in the allowlist")
+	ErrInvalidAddress = errors.New("invalid allowlist address")
+)
+
+// List is the set of the allowed destination addresses.
+type List struct {
+	addrs map[ids.ShortID]struct{}
+}
+
+// Load loads the allowlist file.
+func Load(p string) (*List, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	l, err := Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", p, err)
+	}
+	return l, nil
+}
+
+// Parse parses one P-Chain address per line (e.g., "P-avax1..."),
+// ignoring the empty lines and the "#" comments.
+func Parse(b []byte) (*List, error) {
+	l := &List{addrs: make(map[ids.ShortID]struct{})}
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; s.Scan(); n++ {
+		line := s.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		chainID, _, addr, err := formatting.ParseAddress(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q at line %d: %v", ErrInvalidAddress, line, n, err)
+		}
+		if chainID != "P" {
+			return nil, fmt.Errorf("%w %q at line %d: not a P-Chain address", ErrInvalidAddress, line, n)
+		}
+		id, err := ids.ToShortID(addr)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q at line %d: %v", ErrInvalidAddress, line, n, err)
+		}
+		l.addrs[id] = struct{}{}
+	}
+	return l, s.Err()
+}
+
+// Len returns the number of allowed addresses.
+func (l *List) Len() int { return len(l.addrs) }
+
+// Check returns an error if the transaction sends funds to any address
+// outside of the allowlist, other than the [self] addresses of the keys
+// signing it (e.g., the change).
+func (l *List) Check(utx platformvm.UnsignedTx, networkID uint32, self []ids.ShortID) error {
+	dests, err := txs.Destinations(utx)
+	if err != nil {
+		return err
+	}
+	allowed := make(map[ids.ShortID]struct{}, len(self))
+	for _, addr := range self {
+		allowed[addr] = struct{}{}
+	}
+	hrp := constants.GetHRP(networkID)
+	var denied []string
+	for _, addr := range dests {
+		if _, ok := l.addrs[addr]; ok {
+			continue
+		}
+		if _, ok := allowed[addr]; ok {
+			continue
+		}
+		a, err := formatting.FormatAddress("P", hrp, addr.Bytes())
+		if err != nil {
+			a = addr.String()
+		}
+		denied = append(denied, a)
+	}
+	if len(denied) > 0 {
+		return fmt.Errorf("%w: %s", ErrNotAllowed, strings.Join(denied, ", "))
+	}
+	return nil
+}
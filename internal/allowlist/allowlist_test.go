@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	self, allowed, other := ids.GenerateTestShortID(), ids.GenerateTestShortID(), ids.GenerateTestShortID()
+	addr, err := formatting.FormatAddress("P", constants.FujiHRP, allowed.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := Parse([]byte(fmt.Sprintf("# treasury\n\n%s # cold wallet\n", addr)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Len() != 1 {
+		t.Fatalf("expected 1 address, got %d", l.Len())
+	}
+
+	out := func(addr ids.ShortID) *avax.TransferableOutput {
+		return &avax.TransferableOutput{Out: &secp256k1fx.TransferOutput{
+			Amt:          1,
+			OutputOwners: secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr}},
+		}}
+	}
+	tt := []struct {
+		utx platformvm.UnsignedTx
+		err error
+	}{
+		{
+			utx: &platformvm.UnsignedExportTx{
+				BaseTx:          platformvm.BaseTx{BaseTx: avax.BaseTx{Outs: []*avax.TransferableOutput{out(self)}}},
+				ExportedOutputs: []*avax.TransferableOutput{out(allowed)},
+			},
+		},
+		{
+			utx: &platformvm.UnsignedExportTx{
+				BaseTx:          platformvm.BaseTx{BaseTx: avax.BaseTx{Outs: []*avax.TransferableOutput{out(self)}}},
+				ExportedOutputs: []*avax.TransferableOutput{out(other)},
+			},
+			err: ErrNotAllowed,
+		},
+		{
+			utx: &platformvm.UnsignedAddValidatorTx{
+				BaseTx:       platformvm.BaseTx{BaseTx: avax.BaseTx{Outs: []*avax.TransferableOutput{out(self)}}},
+				Stake:        []*avax.TransferableOutput{out(self)},
+				RewardsOwner: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{other}},
+			},
+			err: ErrNotAllowed,
+		},
+		{
+			// the subnet owners receive no funds
+			utx: &platformvm.UnsignedCreateSubnetTx{
+				BaseTx: platformvm.BaseTx{BaseTx: avax.BaseTx{Outs: []*avax.TransferableOutput{out(self)}}},
+				Owner:  &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{other}},
+			},
+		},
+	}
+	for i, tv := range tt {
+		err := l.Check(tv.utx, constants.FujiID, []ids.ShortID{self})
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+	}
+
+	if _, err := Parse([]byte("X-fuji1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq\n")); !errors.Is(err, ErrInvalidAddress) {
+		t.Fatalf("expected %v, got %v", ErrInvalidAddress, err)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package amount parses and formats AVAX amounts with denominations
+// (e.g., "2.5avax", "500milliavax", "1000000000navax").
+package amount
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidAmount = errors.New("invalid amount")
+	ErrPrecisionLoss = errors.New("amount is more precise than 1 nAVAX")
+	ErrOverflow      = errors.New("amount overflows uint64 nAVAX")
+)
+
+// denominations by the number of decimals in nAVAX,
+// with the longer suffixes first ("navax" ends with "avax").
+var denominations = []struct {
+	suffix   string
+	decimals int
+}{
+	{"milliavax", 6},
+	{"microavax", 3},
+	{"navax", 0},
+	{"avax", 9},
+}
+
+// Parse parses the amount into nAVAX. An amount without any
+// denomination is in nAVAX.
+func Parse(s string) (uint64, error) {
+	num := strings.ToLower(strings.TrimSpace(s))
+	decimals := 0
+	for _, d := range denominations {
+		if strings.HasSuffix(num, d.suffix) {
+			num, decimals = strings.TrimSpace(strings.TrimSuffix(num, d.suffix)), d.decimals
+			break
+		}
+	}
+
+	intPart, fracPart := num, ""
+	if i := strings.IndexByte(num, '.'); i >= 0 {
+		intPart, fracPart = num[:i], num[i+1:]
+	}
+	if (intPart == "" && fracPart == "") || !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, fmt.Errorf("%w %q", ErrInvalidAmount, s)
+	}
+	if len(fracPart) > decimals {
+		if strings.Trim(fracPart[decimals:], "0") != "" {
+			return 0, fmt.Errorf("%w: %q", ErrPrecisionLoss, s)
+		}
+		fracPart = fracPart[:decimals]
+	}
+	fracPart += strings.Repeat("0", decimals-len(fracPart))
+
+	digits := strings.TrimLeft(intPart+fracPart, "0")
+	if digits == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrOverflow, s)
+	}
+	return v, nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Format formats the nAVAX amount in AVAX, without precision loss
+// (e.g., "2.5avax").
+func Format(v uint64) string {
+	return FormatDenomination(v, 9) + "avax"
+}
+
+// maxDenomination is the max number of decimals of a uint64 amount.
+const maxDenomination = 19
+
+// FormatDenomination formats the amount of an asset with [denomination]
+// decimals (e.g., the staking asset of an elastic subnet), without
+// precision loss (e.g., "2.5").
+func FormatDenomination(v uint64, denomination uint8) string {
+	if denomination == 0 {
+		return strconv.FormatUint(v, 10)
+	}
+	if denomination > maxDenomination {
+		denomination = maxDenomination
+	}
+	s := fmt.Sprintf("%0*d", int(denomination)+1, v)
+	i := len(s) - int(denomination)
+	intPart, fracPart := s[:i], strings.TrimRight(s[i:], "0")
+	if fracPart == "" {
+		return intPart
+	}
+	return intPart + "." + fracPart
+}
+
+// Amount is an amount in nAVAX, which unmarshals from YAML/JSON
+// from either a number in nAVAX or a string with any denomination.
+type Amount uint64
+
+// UnmarshalYAML implements "yaml.Unmarshaler".
+func (a *Amount) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = Amount(v)
+	return nil
+}
+
+// Value is a flag value of an amount in nAVAX, which accepts
+// any denomination.
+type Value struct {
+	p *uint64
+}
+
+// NewValue sets the default [v] to [p], and returns the flag value
+// that parses into [p].
+func NewValue(v uint64, p *uint64) *Value {
+	*p = v
+	return &Value{p: p}
+}
+
+func (v *Value) String() string {
+	if v.p == nil {
+		return Format(0)
+	}
+	return Format(*v.p)
+}
+
+func (v *Value) Set(s string) error {
+	n, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*v.p = n
+	return nil
+}
+
+func (v *Value) Type() string { return "amount" }
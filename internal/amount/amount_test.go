@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package amount
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		s   string
+		v   uint64
+		err error
+	}{
+		{s: "1000000000", v: 1000000000},
+		{s: "1000000000navax", v: 1000000000},
+		{s: "2.5avax", v: 2500000000},
+		{s: "2.5 AVAX", v: 2500000000},
+		{s: ".5avax", v: 500000000},
+		{s: "2000avax", v: 2000000000000},
+		{s: "500milliavax", v: 500000000},
+		{s: "1.5microavax", v: 1500},
+		{s: "0.000000001avax", v: 1},
+		{s: "1.000avax", v: 1000000000},
+		{s: "0avax", v: 0},
+		{s: "0.0000000001avax", err: ErrPrecisionLoss},
+		{s: "1.5navax", err: ErrPrecisionLoss},
+		{s: "18446744073709551615navax", v: 18446744073709551615},
+		{s: "18446744074avax", err: ErrOverflow},
+		{s: "avax", err: ErrInvalidAmount},
+		{s: "-1avax", err: ErrInvalidAmount},
+		{s: "1.2.3avax", err: ErrInvalidAmount},
+		{s: "1e9", err: ErrInvalidAmount},
+		{s: "", err: ErrInvalidAmount},
+	}
+	for i, tv := range tt {
+		v, err := Parse(tv.s)
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: %q unexpected error %v, expected %v", i, tv.s, err, tv.err)
+		}
+		if v != tv.v {
+			t.Fatalf("#%d: %q unexpected amount %d, expected %d", i, tv.s, v, tv.v)
+		}
+	}
+}
+
+func TestFormatDenomination(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		v            uint64
+		denomination uint8
+		expected     string
+	}{
+		{0, 0, "0"},
+		{1500, 0, "1500"},
+		{1500, 3, "1.5"},
+		{1, 6, "0.000001"},
+		{2_000_000, 6, "2"},
+		{math.MaxUint64, 18, "18.446744073709551615"},
+		{math.MaxUint64, 19, "1.8446744073709551615"},
+	}
+	for i, tv := range tt {
+		if s := FormatDenomination(tv.v, tv.denomination); s != tv.expected {
+			t.Fatalf("#%d: expected %q, got %q", i, tv.expected, s)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	for v, expected := range map[uint64]string{
+		0:             "0avax",
+		1:             "0.000000001avax",
+		2500000000:    "2.5avax",
+		2000000000000: "2000avax",
+	} {
+		s := Format(v)
+		if s != expected {
+			t.Fatalf("unexpected format %q, expected %q", s, expected)
+		}
+		parsed, err := Parse(s)
+		if err != nil || parsed != v {
+			t.Fatalf("failed to parse back %q (%d, %v)", s, parsed, err)
+		}
+	}
+}
+
+func TestAmountUnmarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	for raw, expected := range map[string]Amount{
+		"2000000000000": 2000000000000,
+		"2.5avax":       2500000000,
+	} {
+		var a Amount
+		err := a.UnmarshalYAML(func(v interface{}) error {
+			*(v.(*string)) = raw
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a != expected {
+			t.Fatalf("unexpected amount %d, expected %d", a, expected)
+		}
+	}
+}
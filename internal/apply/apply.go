@@ -0,0 +1,179 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package apply computes the changes to converge a subnet on chain
+// to its declarative spec.
+package apply
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/subnet-cli/internal/manifest"
+	"github.com/ava-labs/subnet-cli/internal/rebalance"
+)
+
+type Kind int
+
+const (
+	Keep Kind = iota
+	AddValidator
+	CreateSubnet
+	AddSubnetValidator
+	RemoveSubnetValidator
+	// Reweight is blocked until the end of the current validation,
+	// as the P-Chain has no tx to change a weight.
+	Reweight
+	CreateBlockchain
+	// Unmanaged is a validator or a chain of the subnet missing from the
+	// spec, left untouched.
+	Unmanaged
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Keep:
+		return "keep"
+	case AddValidator:
+		return "add validator"
+	case CreateSubnet:
+		return "create subnet"
+	case AddSubnetValidator:
+		return "add subnet-validator"
+	case RemoveSubnetValidator:
+		return "remove subnet-validator"
+	case Reweight:
+		return "reweight"
+	case CreateBlockchain:
+		return "create blockchain"
+	case Unmanaged:
+		return "unmanaged"
+	default:
+		return "unknown"
+	}
+}
+
+// State is the subnet on chain.
+type State struct {
+	// SubnetID is empty if the subnet of the spec does not exist yet.
+	SubnetID ids.ID
+	// Primary are the current and pending primary network validators.
+	Primary map[ids.ShortID]struct{}
+	// Validators are the weights of the current and pending validators
+	// of the subnet.
+	Validators map[ids.ShortID]uint64
+	// Chains are the blockchains of the subnet, by name.
+	Chains map[string]Chain
+}
+
+type Chain struct {
+	ID   ids.ID
+	VMID ids.ID
+}
+
+// Change is a change of the plan, in the order to issue it.
+type Change struct {
+	Kind   Kind
+	NodeID ids.ShortID
+	// Chain is the chain name of the blockchain changes.
+	Chain string
+	// From and To are the subnet validation weights.
+	From uint64
+	To   uint64
+}
+
+// Issued returns true if the change issues a tx.
+func (c Change) Issued() bool {
+	switch c.Kind {
+	case AddValidator, CreateSubnet, AddSubnetValidator, RemoveSubnetValidator, CreateBlockchain:
+		return true
+	default:
+		return false
+	}
+}
+
+// Plan returns the changes to converge [s] to the [spec], in the order to
+// issue them: the primary network validators, the subnet, the subnet
+// validators, then the blockchains. The validators missing from the spec are
+// removed with [prune], unmanaged otherwise. The validators without a weight
+// in the spec (nor in its subnet) get [defaultWeight].
+func Plan(spec *manifest.Spec, s *State, prune bool, defaultWeight uint64) []Change {
+	var changes []Change
+	target := make(map[ids.ShortID]uint64, len(s.Validators))
+	for _, v := range spec.Validators {
+		if _, ok := s.Primary[v.ID]; !ok {
+			changes = append(changes, Change{Kind: AddValidator, NodeID: v.ID})
+		}
+		target[v.ID] = spec.Weight(v.ID)
+		if target[v.ID] == 0 {
+			target[v.ID] = defaultWeight
+		}
+	}
+	if s.SubnetID == ids.Empty {
+		changes = append(changes, Change{Kind: CreateSubnet})
+	}
+
+	var unmanaged []Change
+	for nodeID, w := range s.Validators {
+		if _, ok := target[nodeID]; ok {
+			continue
+		}
+		if prune {
+			target[nodeID] = 0
+			continue
+		}
+		unmanaged = append(unmanaged, Change{Kind: Unmanaged, NodeID: nodeID, From: w, To: w})
+	}
+	sort.Slice(unmanaged, func(i, j int) bool {
+		return bytes.Compare(unmanaged[i].NodeID[:], unmanaged[j].NodeID[:]) < 0
+	})
+	for _, a := range rebalance.Plan(s.Validators, target) {
+		c := Change{NodeID: a.NodeID, From: a.From, To: a.To}
+		switch a.Kind {
+		case rebalance.Add:
+			c.Kind = AddSubnetValidator
+		case rebalance.Remove:
+			c.Kind = RemoveSubnetValidator
+		case rebalance.Reweight:
+			c.Kind = Reweight
+		default:
+			c.Kind = Keep
+		}
+		changes = append(changes, c)
+	}
+	changes = append(changes, unmanaged...)
+
+	declared := make(map[string]struct{}, len(spec.Chains))
+	for _, c := range spec.Chains {
+		declared[c.Name] = struct{}{}
+		vmID, _ := ids.FromString(c.VMID)
+		if existing, ok := s.Chains[c.Name]; ok && existing.VMID == vmID {
+			changes = append(changes, Change{Kind: Keep, Chain: c.Name})
+			continue
+		}
+		changes = append(changes, Change{Kind: CreateBlockchain, Chain: c.Name})
+	}
+	names := make([]string, 0, len(s.Chains))
+	for name := range s.Chains {
+		if _, ok := declared[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		changes = append(changes, Change{Kind: Unmanaged, Chain: name})
+	}
+	return changes
+}
+
+// Count returns the number of changes of the kind.
+func Count(changes []Change, k Kind) (n int) {
+	for _, c := range changes {
+		if c.Kind == k {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package apply
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/subnet-cli/internal/manifest"
+)
+
+func TestPlan(t *testing.T) {
+	t.Parallel()
+
+	n := func(b byte) ids.ShortID { return ids.ShortID{b} }
+	vmID := ids.ID{1}
+	spec := &manifest.Spec{
+		Subnet: manifest.SpecSubnet{Weight: 100},
+		Validators: []manifest.SpecValidator{
+			{ID: n(1)},              // keep
+			{ID: n(2), Weight: 200}, // reweight
+			{ID: n(5)},              // add, not a primary network validator yet
+		},
+		Chains: []manifest.SpecChain{
+			{Name: "a", VMID: vmID.String()}, // keep
+			{Name: "b", VMID: vmID.String()}, // create
+		},
+	}
+	s := &State{
+		SubnetID: ids.ID{2},
+		Primary: map[ids.ShortID]struct{}{
+			n(1): {},
+			n(2): {},
+			n(3): {},
+			n(4): {},
+		},
+		Validators: map[ids.ShortID]uint64{
+			n(1): 100,
+			n(2): 100,
+			n(4): 100,
+			n(3): 100,
+		},
+		Chains: map[string]Chain{
+			"a": {ID: ids.ID{3}, VMID: vmID},
+			"z": {ID: ids.ID{4}, VMID: vmID},
+		},
+	}
+
+	tt := []struct {
+		prune    bool
+		expected []Change
+	}{
+		{
+			expected: []Change{
+				{Kind: AddValidator, NodeID: n(5)},
+				{Kind: Keep, NodeID: n(1), From: 100, To: 100},
+				{Kind: Reweight, NodeID: n(2), From: 100, To: 200},
+				{Kind: AddSubnetValidator, NodeID: n(5), To: 100},
+				{Kind: Unmanaged, NodeID: n(3), From: 100, To: 100},
+				{Kind: Unmanaged, NodeID: n(4), From: 100, To: 100},
+				{Kind: Keep, Chain: "a"},
+				{Kind: CreateBlockchain, Chain: "b"},
+				{Kind: Unmanaged, Chain: "z"},
+			},
+		},
+		{
+			prune: true,
+			expected: []Change{
+				{Kind: AddValidator, NodeID: n(5)},
+				{Kind: Keep, NodeID: n(1), From: 100, To: 100},
+				{Kind: Reweight, NodeID: n(2), From: 100, To: 200},
+				{Kind: RemoveSubnetValidator, NodeID: n(3), From: 100},
+				{Kind: RemoveSubnetValidator, NodeID: n(4), From: 100},
+				{Kind: AddSubnetValidator, NodeID: n(5), To: 100},
+				{Kind: Keep, Chain: "a"},
+				{Kind: CreateBlockchain, Chain: "b"},
+				{Kind: Unmanaged, Chain: "z"},
+			},
+		},
+	}
+	for i, tv := range tt {
+		changes := Plan(spec, s, tv.prune, 1000)
+		if len(changes) != len(tv.expected) {
+			t.Fatalf("#%d: unexpected changes %+v, expected %+v", i, changes, tv.expected)
+		}
+		for j := range changes {
+			if changes[j] != tv.expected[j] {
+				t.Fatalf("#%d: unexpected change #%d %+v, expected %+v", i, j, changes[j], tv.expected[j])
+			}
+		}
+	}
+
+	// a new subnet gets every validator and chain of the spec
+	changes := Plan(spec, &State{Primary: s.Primary}, false, 1000)
+	if c := Count(changes, CreateSubnet); c != 1 {
+		t.Fatalf("unexpected create subnet count %d", c)
+	}
+	if c := Count(changes, AddSubnetValidator); c != 3 {
+		t.Fatalf("unexpected add subnet-validator count %d", c)
+	}
+	if c := Count(changes, CreateBlockchain); c != 2 {
+		t.Fatalf("unexpected create blockchain count %d", c)
+	}
+}
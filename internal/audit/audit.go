@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package audit implements the append-only, hash-chained log of the
+// signing operations. Each entry commits to the hash of the previous one,
+// so that any modification, insertion, or removal of an entry (but the
+// last ones) breaks the chain.
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+var ErrTampered = errors.New("audit log tampered")
+
+const (
+	OpSignTx   = "sign_tx"
+	OpSignHash = "sign_hash"
+)
+
+// genesisHash is the "Prev" of the first entry.
+var genesisHash = hex.EncodeToString(make([]byte, sha256.Size))
+
+// Entry is a signing operation.
+type Entry struct {
+	Seq  uint64    `json:"seq"`
+	Time time.Time `json:"time"`
+	Op   string    `json:"op"`
+	// TxType is empty for the hash signatures.
+	TxType string `json:"txType,omitempty"`
+	// Digest is the hex-encoded SHA256 of the signed bytes.
+	Digest  string   `json:"digest"`
+	Signers []string `json:"signers"`
+	// Prev is the hash of the previous entry.
+	Prev string `json:"prev"`
+	// Hash is the SHA256 of the entry with an empty hash.
+	Hash string `json:"hash"`
+}
+
+func (e Entry) computeHash() (string, error) {
+	e.Hash = ""
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// Log appends the entries to the log file.
+type Log struct {
+	path string
+
+	mu   sync.Mutex
+	seq  uint64
+	prev string
+}
+
+// Open opens the log at [p], creating it if it does not exist,
+// once verified.
+func Open(p string) (*Log, error) {
+	l := &Log{path: p, prev: genesisHash}
+	last, n, err := Verify(p)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return l, nil
+	case err != nil:
+		return nil, err
+	}
+	if n > 0 {
+		l.seq, l.prev = last.Seq, last.Hash
+	}
+	return l, nil
+}
+
+// Append appends the entry, setting its sequence number, previous hash,
+// and hash, and syncs the file.
+func (l *Log) Append(e Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e.Seq, e.Prev = l.seq+1, l.prev
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	var err error
+	e.Hash, err = e.computeHash()
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	l.seq, l.prev = e.Seq, e.Hash
+	return nil
+}
+
+// Verify checks the hash chain of the log at [p], and returns its last
+// entry and the number of entries.
+func Verify(p string) (last Entry, n int, err error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return Entry{}, 0, err
+	}
+	defer f.Close()
+
+	prev := genesisHash
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		n++
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return last, n, fmt.Errorf("%w: line %d: %v", ErrTampered, n, err)
+		}
+		if e.Seq != uint64(n) {
+			return last, n, fmt.Errorf("%w: line %d: unexpected sequence %d", ErrTampered, n, e.Seq)
+		}
+		if e.Prev != prev {
+			return last, n, fmt.Errorf("%w: line %d: previous hash mismatch", ErrTampered, n)
+		}
+		h, err := e.computeHash()
+		if err != nil {
+			return last, n, err
+		}
+		if e.Hash != h {
+			return last, n, fmt.Errorf("%w: line %d: hash mismatch", ErrTampered, n)
+		}
+		prev, last = e.Hash, e
+	}
+	return last, n, sc.Err()
+}
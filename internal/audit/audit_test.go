@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestLog(t *testing.T) {
+	t.Parallel()
+
+	p := filepath.Join(t.TempDir(), "audit.log")
+	l, err := Open(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Append(Entry{Op: OpSignTx, TxType: "CreateSubnetTx", Digest: "aa", Signers: []string{"P-fuji1a"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// reopened logs continue the chain
+	l, err = Open(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Append(Entry{Op: OpSignHash, Digest: "bb", Signers: []string{"P-fuji1a"}}); err != nil {
+		t.Fatal(err)
+	}
+	last, n, err := Verify(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 || last.Seq != 2 || last.Digest != "bb" {
+		t.Fatalf("unexpected last entry %+v of %d", last, n)
+	}
+
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := bytes.Replace(b, []byte(`"digest":"aa"`), []byte(`"digest":"ab"`), 1)
+	if err := ioutil.WriteFile(p, tampered, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Verify(p); !errors.Is(err, ErrTampered) {
+		t.Fatalf("expected %v, got %v", ErrTampered, err)
+	}
+	if _, err := Open(p); !errors.Is(err, ErrTampered) {
+		t.Fatalf("expected %v, got %v", ErrTampered, err)
+	}
+
+	// removed entries break the chain
+	lines := bytes.SplitN(b, []byte("\n"), 2)
+	if err := ioutil.WriteFile(p, lines[1], 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Verify(p); !errors.Is(err, ErrTampered) {
+		t.Fatalf("expected %v, got %v", ErrTampered, err)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package auth authenticates the API calls to the private endpoints (e.g.,
+// API gateways) with a bearer token and/or a client certificate (mTLS).
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	ErrIncompleteCert = errors.New("client certificate requires both the cert and the key")
+	ErrInvalidCACert  = errors.New("no certificate found in the CA cert file")
+)
+
+// Endpoint is the authentication to the endpoints under the URI.
+type Endpoint struct {
+	// URI is the prefix of the authenticated endpoints
+	// (e.g., "https://avax.corp.example/node1").
+	URI string `yaml:"uri"`
+	// Token is sent as the bearer token of the requests.
+	Token string `yaml:"auth-token,omitempty"`
+	// Cert and Key are the PEM files of the client certificate.
+	Cert string `yaml:"tls-cert,omitempty"`
+	Key  string `yaml:"tls-key,omitempty"`
+	// CACert is the PEM file of the CA to verify the server with,
+	// instead of the system roots.
+	CACert string `yaml:"tls-ca-cert,omitempty"`
+}
+
+// TLSConfig returns the TLS config of the endpoint,
+// or nil if it does not use a client certificate nor a CA.
+func (e Endpoint) TLSConfig() (*tls.Config, error) {
+	if e.Cert == "" && e.Key == "" && e.CACert == "" {
+		return nil, nil
+	}
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	switch {
+	case e.Cert != "" && e.Key != "":
+		cert, err := tls.LoadX509KeyPair(e.Cert, e.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate of %q: %w", e.URI, err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	case e.Cert != "" || e.Key != "":
+		return nil, fmt.Errorf("%w (%q)", ErrIncompleteCert, e.URI)
+	}
+	if e.CACert != "" {
+		pem, err := ioutil.ReadFile(e.CACert)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCACert, e.CACert)
+		}
+		cfg.RootCAs = pool
+	}
+	return cfg, nil
+}
+
+type route struct {
+	Endpoint
+	prefix    *url.URL
+	tlsConfig *tls.Config
+	rt        http.RoundTripper
+}
+
+// matches returns the length of the prefix matching [u], or -1 if none.
+func (r *route) matches(u *url.URL) int {
+	if u.Scheme != r.prefix.Scheme || u.Host != r.prefix.Host {
+		return -1
+	}
+	p := strings.TrimSuffix(r.prefix.Path, "/")
+	if u.Path != p && !strings.HasPrefix(u.Path, p+"/") {
+		return -1
+	}
+	return len(p)
+}
+
+var _ http.RoundTripper = &Transport{}
+
+// Transport authenticates the requests to the endpoints,
+// and sends the others as is.
+type Transport struct {
+	base   http.RoundTripper
+	routes []*route
+}
+
+// NewTransport creates the transport authenticating the requests to [eps]
+// on top of [base]. If several endpoints match a request, the one with the
+// longest URI wins, then the last one.
+func NewTransport(base *http.Transport, eps []Endpoint) (*Transport, error) {
+	t := &Transport{base: base}
+	for _, ep := range eps {
+		u, err := url.Parse(ep.URI)
+		if err != nil {
+			return nil, err
+		}
+		if u.Host == "" {
+			return nil, fmt.Errorf("endpoint %q has no host", ep.URI)
+		}
+		r := &route{Endpoint: ep, prefix: u, rt: base}
+		r.tlsConfig, err = ep.TLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		if r.tlsConfig != nil {
+			tr := base.Clone()
+			tr.TLSClientConfig = r.tlsConfig
+			r.rt = tr
+		}
+		t.routes = append(t.routes, r)
+	}
+	return t, nil
+}
+
+func (t *Transport) route(u *url.URL) *route {
+	var (
+		best    *route
+		bestLen = -1
+	)
+	for _, r := range t.routes {
+		if n := r.matches(u); n >= bestLen && n >= 0 {
+			best, bestLen = r, n
+		}
+	}
+	return best
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := t.route(req.URL)
+	if r == nil {
+		return t.base.RoundTrip(req)
+	}
+	if r.Token != "" {
+		// a round tripper must not modify the request
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+r.Token)
+	}
+	return r.rt.RoundTrip(req)
+}
+
+// Install authenticates the requests of the default HTTP client (used by
+// all the API clients) with [t].
+func Install(t *Transport) {
+	http.DefaultClient.Transport = t
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+
+	tr, err := NewTransport(http.DefaultTransport.(*http.Transport).Clone(), []Endpoint{
+		{URI: srv.URL, Token: "a"},
+		{URI: srv.URL + "/node2", Token: "b"},
+		{URI: "https://other.example", Token: "c"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cli := &http.Client{Transport: tr}
+
+	tt := []struct {
+		path  string
+		token string
+	}{
+		{path: "/ext/info", token: "Bearer a"},
+		{path: "/node2/ext/info", token: "Bearer b"},
+		// not a path prefix
+		{path: "/node20/ext/info", token: "Bearer a"},
+	}
+	for i, tv := range tt {
+		got = ""
+		resp, err := cli.Post(srv.URL+tv.path, "application/json", nil)
+		if err != nil {
+			t.Fatalf("#%d: unexpected error %v", i, err)
+		}
+		resp.Body.Close()
+		if got != tv.token {
+			t.Fatalf("#%d: expected %q, got %q", i, tv.token, got)
+		}
+	}
+}
+
+func TestNewTransportIncompleteCert(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewTransport(http.DefaultTransport.(*http.Transport).Clone(), []Endpoint{
+		{URI: "https://avax.corp.example", Cert: "client.pem"},
+	})
+	if !errors.Is(err, ErrIncompleteCert) {
+		t.Fatalf("expected %v, got %v", ErrIncompleteCert, err)
+	}
+}
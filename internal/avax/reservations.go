@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avax
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// Reservations tracks the UTXOs consumed by the in-flight transactions,
+// so that the concurrent transactions of the same key do not spend the
+// same UTXOs.
+type Reservations struct {
+	mu       sync.Mutex
+	reserved map[ids.ID]struct{}
+	// closed and replaced on every release
+	released chan struct{}
+}
+
+func NewReservations() *Reservations {
+	return &Reservations{
+		reserved: make(map[ids.ID]struct{}),
+		released: make(chan struct{}),
+	}
+}
+
+// Reserved returns true if the UTXO is consumed by an in-flight transaction.
+func (r *Reservations) Reserved(utxoID ids.ID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.reserved[utxoID]
+	return ok
+}
+
+// Reserve reserves all the UTXOs, or none if any is already reserved.
+func (r *Reservations) Reserve(utxoIDs ...ids.ID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range utxoIDs {
+		if _, ok := r.reserved[id]; ok {
+			return false
+		}
+	}
+	for _, id := range utxoIDs {
+		r.reserved[id] = struct{}{}
+	}
+	return true
+}
+
+// Release releases the UTXOs, once the transaction is accepted or failed.
+func (r *Reservations) Release(utxoIDs ...ids.ID) {
+	if len(utxoIDs) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range utxoIDs {
+		delete(r.reserved, id)
+	}
+	close(r.released)
+	r.released = make(chan struct{})
+}
+
+// Released returns a channel closed on the next release.
+func (r *Reservations) Released() <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.released
+}
+
+// Wait waits for the next release.
+func (r *Reservations) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-r.Released():
+		return nil
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avax
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestReservations(t *testing.T) {
+	t.Parallel()
+
+	r := NewReservations()
+	a, b := ids.GenerateTestID(), ids.GenerateTestID()
+	if !r.Reserve(a) {
+		t.Fatal("failed to reserve")
+	}
+	if r.Reserve(a, b) {
+		t.Fatal("reserved twice")
+	}
+	if r.Reserved(b) {
+		t.Fatal("partially reserved")
+	}
+
+	released := r.Released()
+	go r.Release(a)
+	select {
+	case <-released:
+	case <-time.After(5 * time.Second):
+		t.Fatal("release not notified")
+	}
+	if !r.Reserve(a, b) {
+		t.Fatal("failed to reserve after release")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := r.Wait(ctx); err == nil {
+		t.Fatal("expected timeout")
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package cache implements the local file cache of the network objects
+// (e.g., subnets, blockchains, validators), whose entries expire after a TTL.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var ErrInvalidTTL = errors.New("invalid cache TTL")
+
+// DefaultDir returns the default cache directory "~/.subnet-cli/cache".
+func DefaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".subnet-cli", "cache"), nil
+}
+
+// Cache is the file cache in a directory, one file per entry.
+type Cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+type entry struct {
+	Key      string          `json:"key"`
+	StoredAt time.Time       `json:"storedAt"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// New creates the cache in [dir], whose entries expire after [ttl].
+func New(dir string, ttl time.Duration) (*Cache, error) {
+	if ttl <= 0 {
+		return nil, ErrInvalidTTL
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &Cache{dir: dir, ttl: ttl, now: time.Now}, nil
+}
+
+func (c *Cache) path(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(h[:])+".json")
+}
+
+// Get decodes the unexpired entry of [key] into [v], and returns false
+// if the entry is missing, expired, or unreadable.
+func (c *Cache) Get(key string, v interface{}) bool {
+	b, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return false
+	}
+	var e entry
+	if err := json.Unmarshal(b, &e); err != nil || e.Key != key {
+		return false
+	}
+	if c.now().Sub(e.StoredAt) > c.ttl {
+		return false
+	}
+	return json.Unmarshal(e.Value, v) == nil
+}
+
+// Put stores [v] as the entry of [key].
+func (c *Cache) Put(key string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(entry{Key: key, StoredAt: c.now(), Value: value})
+	if err != nil {
+		return err
+	}
+	// write then rename, not to read a partial entry concurrently
+	p := c.path(key)
+	f, err := ioutil.TempFile(c.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), p)
+}
+
+// Clear removes all entries.
+func (c *Cache) Clear() error {
+	matches, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, p := range matches {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
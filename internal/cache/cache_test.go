@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	c, err := New(t.TempDir(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	var v []string
+	if c.Get("api.avax-test.network/subnets", &v) {
+		t.Fatal("unexpected hit on an empty cache")
+	}
+	if err := c.Put("api.avax-test.network/subnets", []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Get("api.avax-test.network/subnets", &v) || len(v) != 2 || v[1] != "b" {
+		t.Fatalf("unexpected cached value %v", v)
+	}
+	if c.Get("api.avax.network/subnets", &v) {
+		t.Fatal("unexpected hit on another key")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if c.Get("api.avax-test.network/subnets", &v) {
+		t.Fatal("unexpected hit on an expired entry")
+	}
+
+	if err := c.Put("k", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	var i int
+	if c.Get("k", &i) {
+		t.Fatal("unexpected hit after clear")
+	}
+
+	if _, err := New(t.TempDir(), 0); err != ErrInvalidTTL {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidTTL)
+	}
+}
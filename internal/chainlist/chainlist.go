@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package chainlist looks up the EVM chain IDs among the chains known to
+// the wallets (e.g., the dataset of https://chainid.network/chains.json),
+// for a new chain not to reuse the chain ID of another.
+package chainlist
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Chain is an EVM chain of the dataset.
+type Chain struct {
+	Name    string
+	ChainID uint64
+}
+
+// Known is the chains looked up without a dataset: the C-Chains, and the
+// most used EVM networks.
+var Known = []Chain{
+	{Name: "Ethereum Mainnet", ChainID: 1},
+	{Name: "Goerli", ChainID: 5},
+	{Name: "BNB Smart Chain Mainnet", ChainID: 56},
+	{Name: "Polygon Mainnet", ChainID: 137},
+	{Name: "Arbitrum One", ChainID: 42161},
+	{Name: "Avalanche Local C-Chain", ChainID: 43112},
+	{Name: "Avalanche Fuji C-Chain", ChainID: 43113},
+	{Name: "Avalanche C-Chain", ChainID: 43114},
+	{Name: "Sepolia", ChainID: 11155111},
+}
+
+// List is the set of the chains by chain ID.
+type List struct {
+	chains map[uint64][]Chain
+}
+
+// New returns the list of "Known" and [chains].
+func New(chains ...Chain) *List {
+	l := &List{chains: make(map[uint64][]Chain)}
+	for _, cs := range [][]Chain{Known, chains} {
+		for _, c := range cs {
+			l.add(c)
+		}
+	}
+	return l
+}
+
+// Load returns the list of "Known" and the chains of the dataset file.
+func Load(p string) (*List, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	chains, err := Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", p, err)
+	}
+	return New(chains...), nil
+}
+
+// Parse parses the JSON array of the chains with their "name" and
+// "chainId" (e.g., "chains.json" of ethereum-lists). The chain IDs that
+// do not fit in 64 bits are skipped.
+func Parse(b []byte) ([]Chain, error) {
+	var raw []struct {
+		Name    string      `json:"name"`
+		ChainID json.Number `json:"chainId"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	chains := make([]Chain, 0, len(raw))
+	for _, r := range raw {
+		id, err := strconv.ParseUint(r.ChainID.String(), 10, 64)
+		if err != nil {
+			continue
+		}
+		chains = append(chains, Chain{Name: r.Name, ChainID: id})
+	}
+	return chains, nil
+}
+
+// Lookup returns the chains of the chain ID [id], if any.
+func (l *List) Lookup(id *big.Int) []Chain {
+	if id == nil || !id.IsUint64() {
+		return nil
+	}
+	return l.chains[id.Uint64()]
+}
+
+// Len returns the number of chains.
+func (l *List) Len() int {
+	n := 0
+	for _, cs := range l.chains {
+		n += len(cs)
+	}
+	return n
+}
+
+// add adds [c], unless a chain of the same chain ID and name (ignoring
+// the case) was added.
+func (l *List) add(c Chain) {
+	for _, o := range l.chains[c.ChainID] {
+		if strings.EqualFold(o.Name, c.Name) {
+			return
+		}
+	}
+	l.chains[c.ChainID] = append(l.chains[c.ChainID], c)
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chainlist
+
+import (
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	p := filepath.Join(t.TempDir(), "chains.json")
+	b := []byte(`[
+  {"name": "Avalanche C-Chain", "chainId": 43114, "shortName": "avax"},
+  {"name": "DFK Chain", "chainId": 53935, "shortName": "DFK"},
+  {"name": "Too Large", "chainId": 123456789012345678901234567890}
+]`)
+	if err := ioutil.WriteFile(p, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	l, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the duplicate of "Known" is not added
+	if expected := len(Known) + 1; l.Len() != expected {
+		t.Fatalf("expected %d chains, got %d", expected, l.Len())
+	}
+
+	tt := []struct {
+		id       *big.Int
+		expected string
+	}{
+		{id: big.NewInt(43114), expected: "Avalanche C-Chain"},
+		{id: big.NewInt(53935), expected: "DFK Chain"},
+		{id: big.NewInt(43214)},
+		{id: new(big.Int).Lsh(big.NewInt(1), 64)},
+		{id: nil},
+	}
+	for i, tv := range tt {
+		cs := l.Lookup(tv.id)
+		switch {
+		case tv.expected == "" && len(cs) != 0:
+			t.Fatalf("#%d: expected no chain, got %+v", i, cs)
+		case tv.expected != "" && (len(cs) != 1 || cs[0].Name != tv.expected):
+			t.Fatalf("#%d: expected %q, got %+v", i, tv.expected, cs)
+		}
+	}
+
+	if _, err := Parse([]byte(`{}`)); err == nil {
+		t.Fatal("expected an error for a non-array dataset")
+	}
+}
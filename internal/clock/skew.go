@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package clock checks the local clock against the remote node.
+package clock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var ErrNoDate = errors.New("no Date header in the node response")
+
+// HealthPath is requested to read the node time, since any response from the
+// node HTTP server carries the "Date" header.
+const HealthPath = "/ext/health"
+
+// Skew returns the difference between the node clock and the local clock,
+// where a positive value means the local clock is behind the node. The node
+// time is read from the "Date" header, so the result is only accurate up to
+// a second.
+func Skew(ctx context.Context, uri string) (time.Duration, error) {
+	return skew(ctx, http.DefaultClient, uri, time.Now)
+}
+
+func skew(ctx context.Context, cli *http.Client, uri string, now func() time.Time) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(uri, "/")+HealthPath, nil)
+	if err != nil {
+		return 0, err
+	}
+	sent := now()
+	resp, err := cli.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	received := now()
+	resp.Body.Close()
+
+	date := resp.Header.Get("Date")
+	if date == "" {
+		return 0, ErrNoDate
+	}
+	remote, err := http.ParseTime(date)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", err, date)
+	}
+	// the node stamped the response somewhere in the round trip,
+	// and the header drops the sub-second part
+	local := sent.Add(received.Sub(sent) / 2).Truncate(time.Second)
+	return remote.Sub(local), nil
+}
+
+// Abs returns the absolute duration.
+func Abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,37 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package clock
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSkew(t *testing.T) {
+	t.Parallel()
+
+	nodeTime := time.Date(2022, 3, 1, 0, 0, 30, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != HealthPath {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		w.Header().Set("Date", nodeTime.Format(http.TimeFormat))
+	}))
+	defer srv.Close()
+
+	local := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	d, err := skew(context.Background(), srv.Client(), srv.URL, func() time.Time { return local })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d != 30*time.Second {
+		t.Fatalf("expected 30s skew, got %v", d)
+	}
+	if Abs(-d) != d {
+		t.Fatalf("unexpected Abs %v", Abs(-d))
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+// BaseTxTypeID is the type ID of the P-Chain "BaseTx" in the codec of
+// the nodes, after the types of Banff and "TransferSubnetOwnershipTx".
+const BaseTxTypeID = 34
+
+var ErrNotExecutable = errors.New("tx is not executable by subnet-cli")
+
+var _ platformvm.UnsignedTx = &BaseTx{}
+
+// BaseTx is the P-Chain "BaseTx" introduced by Durango to transfer AVAX
+// between the P-Chain addresses, unknown to the vendored "platformvm"
+// (ref. "txs.BaseTx").
+type BaseTx struct {
+	platformvm.BaseTx `serialize:"true"`
+}
+
+// SemanticVerify implements "platformvm.UnsignedTx", which is only
+// verified by the node.
+func (*BaseTx) SemanticVerify(*platformvm.VM, platformvm.MutableState, *platformvm.Tx) error {
+	return ErrNotExecutable
+}
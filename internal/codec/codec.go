@@ -4,6 +4,8 @@
 package codec
 
 import (
+	"math"
+
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -11,11 +13,17 @@ import (
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
-var PCodecManager codec.Manager
+var (
+	PCodecManager codec.Manager
+	// UnboundedPCodecManager has no max size, to measure the txs
+	// over the max size of "PCodecManager" (i.e., the node limit).
+	UnboundedPCodecManager codec.Manager
+)
 
 func init() {
 	pc := linearcodec.NewDefault()
 	PCodecManager = codec.NewDefaultManager()
+	UnboundedPCodecManager = codec.NewManager(math.MaxInt32)
 	errs := wrappers.Errs{}
 	errs.Add(
 		pc.RegisterType(&platformvm.ProposalBlock{}),
@@ -41,7 +49,15 @@ func init() {
 		pc.RegisterType(&platformvm.UnsignedRewardValidatorTx{}),
 		pc.RegisterType(&platformvm.StakeableLockIn{}),
 		pc.RegisterType(&platformvm.StakeableLockOut{}),
+	)
+	errs.Add(pc.RegisterType(&RemoveSubnetValidatorTx{}))
+	// skip the other types introduced by the later network upgrades
+	pc.SkipRegistrations(TransferSubnetOwnershipTxTypeID - RemoveSubnetValidatorTxTypeID - 1)
+	errs.Add(
+		pc.RegisterType(&TransferSubnetOwnershipTx{}),
+		pc.RegisterType(&BaseTx{}),
 		PCodecManager.RegisterCodec(0, pc),
+		UnboundedPCodecManager.RegisterCodec(0, pc),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
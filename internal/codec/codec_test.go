@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestBaseTxTypeID(t *testing.T) {
+	t.Parallel()
+
+	var utx platformvm.UnsignedTx = &BaseTx{}
+	b, err := PCodecManager.Marshal(platformvm.CodecVersion, &utx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2-byte codec version, then the 4-byte type ID
+	if typeID := binary.BigEndian.Uint32(b[2:6]); typeID != BaseTxTypeID {
+		t.Fatalf("expected type ID %d, got %d", BaseTxTypeID, typeID)
+	}
+
+	var decoded platformvm.UnsignedTx
+	if _, err := PCodecManager.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := decoded.(*BaseTx); !ok {
+		t.Fatalf("expected *BaseTx, got %T", decoded)
+	}
+}
+
+func TestRemoveSubnetValidatorTxTypeID(t *testing.T) {
+	t.Parallel()
+
+	var utx platformvm.UnsignedTx = &RemoveSubnetValidatorTx{
+		NodeID:     ids.ShortID{1},
+		Subnet:     ids.ID{2},
+		SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
+	}
+	b, err := PCodecManager.Marshal(platformvm.CodecVersion, &utx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typeID := binary.BigEndian.Uint32(b[2:6]); typeID != RemoveSubnetValidatorTxTypeID {
+		t.Fatalf("expected type ID %d, got %d", RemoveSubnetValidatorTxTypeID, typeID)
+	}
+
+	var decoded platformvm.UnsignedTx
+	if _, err := PCodecManager.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	tx, ok := decoded.(*RemoveSubnetValidatorTx)
+	if !ok {
+		t.Fatalf("expected *RemoveSubnetValidatorTx, got %T", decoded)
+	}
+	if tx.NodeID != (ids.ShortID{1}) || tx.Subnet != (ids.ID{2}) {
+		t.Fatalf("unexpected tx %+v", tx)
+	}
+}
+
+func TestTransferSubnetOwnershipTxTypeID(t *testing.T) {
+	t.Parallel()
+
+	owner := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{{3}}}
+	var utx platformvm.UnsignedTx = &TransferSubnetOwnershipTx{
+		Subnet:     ids.ID{2},
+		SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
+		Owner:      owner,
+	}
+	b, err := PCodecManager.Marshal(platformvm.CodecVersion, &utx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typeID := binary.BigEndian.Uint32(b[2:6]); typeID != TransferSubnetOwnershipTxTypeID {
+		t.Fatalf("expected type ID %d, got %d", TransferSubnetOwnershipTxTypeID, typeID)
+	}
+
+	var decoded platformvm.UnsignedTx
+	if _, err := PCodecManager.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	tx, ok := decoded.(*TransferSubnetOwnershipTx)
+	if !ok {
+		t.Fatalf("expected *TransferSubnetOwnershipTx, got %T", decoded)
+	}
+	decodedOwner, ok := tx.Owner.(*secp256k1fx.OutputOwners)
+	if tx.Subnet != (ids.ID{2}) || !ok || !decodedOwner.Equals(owner) {
+		t.Fatalf("unexpected tx %+v", tx)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+// RemoveSubnetValidatorTxTypeID is the type ID of the P-Chain
+// "RemoveSubnetValidatorTx" in the codec of the nodes, the first type
+// introduced by Banff.
+const RemoveSubnetValidatorTxTypeID = 23
+
+var ErrRemovePrimaryNetworkValidator = errors.New("can't remove a primary network validator")
+
+var _ platformvm.UnsignedTx = &RemoveSubnetValidatorTx{}
+
+// RemoveSubnetValidatorTx is the P-Chain "RemoveSubnetValidatorTx"
+// introduced by Banff to remove a validator from a subnet before its end
+// time, unknown to the vendored "platformvm" (ref. "txs.RemoveSubnetValidatorTx").
+type RemoveSubnetValidatorTx struct {
+	platformvm.BaseTx `serialize:"true"`
+	NodeID            ids.ShortID `serialize:"true" json:"nodeID"`
+	Subnet            ids.ID      `serialize:"true" json:"subnetID"`
+	// SubnetAuth proves that the tx is authorized by the subnet owners.
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+func (tx *RemoveSubnetValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	if tx.Subnet == constants.PrimaryNetworkID {
+		return ErrRemovePrimaryNetworkValidator
+	}
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	return tx.SubnetAuth.Verify()
+}
+
+// SemanticVerify implements "platformvm.UnsignedTx", which is only
+// verified by the node.
+func (*RemoveSubnetValidatorTx) SemanticVerify(*platformvm.VM, platformvm.MutableState, *platformvm.Tx) error {
+	return ErrNotExecutable
+}
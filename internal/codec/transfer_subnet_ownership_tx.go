@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+// TransferSubnetOwnershipTxTypeID is the type ID of the P-Chain
+// "TransferSubnetOwnershipTx" in the codec of the nodes, the first type
+// introduced by Durango.
+const TransferSubnetOwnershipTxTypeID = 33
+
+var ErrTransferPrimaryNetwork = errors.New("can't transfer the ownership of the primary network")
+
+var _ platformvm.UnsignedTx = &TransferSubnetOwnershipTx{}
+
+// TransferSubnetOwnershipTx is the P-Chain "TransferSubnetOwnershipTx"
+// introduced by Durango to replace the owners (i.e., the control keys) of a
+// subnet, unknown to the vendored "platformvm" (ref. "txs.TransferSubnetOwnershipTx").
+type TransferSubnetOwnershipTx struct {
+	platformvm.BaseTx `serialize:"true"`
+	Subnet            ids.ID `serialize:"true" json:"subnetID"`
+	// SubnetAuth proves that the tx is authorized by the current subnet owners.
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+	// Owner is the new subnet owners.
+	Owner verify.Verifiable `serialize:"true" json:"newOwner"`
+}
+
+func (tx *TransferSubnetOwnershipTx) SyntacticVerify(ctx *snow.Context) error {
+	if tx.Subnet == constants.PrimaryNetworkID {
+		return ErrTransferPrimaryNetwork
+	}
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+	return tx.Owner.Verify()
+}
+
+// SemanticVerify implements "platformvm.UnsignedTx", which is only
+// verified by the node.
+func (*TransferSubnetOwnershipTx) SemanticVerify(*platformvm.VM, platformvm.MutableState, *platformvm.Tx) error {
+	return ErrNotExecutable
+}
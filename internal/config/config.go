@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package config implements the configuration file of the CLI.
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/ava-labs/subnet-cli/internal/auth"
+	"github.com/ava-labs/subnet-cli/internal/spend"
+)
+
+// Config is the configuration file of the CLI.
+//
+// e.g.,
+//
+//	endpoints:
+//	  - uri: https://avax.corp.example
+//	    auth-token: 7d1f...
+//	  - uri: https://10.0.0.12:9650
+//	    tls-cert: /etc/subnet-cli/client.pem
+//	    tls-key: /etc/subnet-cli/client-key.pem
+//	    tls-ca-cert: /etc/subnet-cli/ca.pem
+//	registry:
+//	  fuji:
+//	    - https://fuji.corp.example
+//	spend-limits:
+//	  command:
+//	    soft: 10avax
+//	allowlist: /etc/subnet-cli/allowlist.txt
+type Config struct {
+	// Endpoints is the authentication to the private endpoints.
+	Endpoints []auth.Endpoint `yaml:"endpoints,omitempty"`
+	// Registry is the endpoints per network name or ID (e.g., "fuji"),
+	// to select from with "--endpoint auto" in addition to the known ones.
+	Registry map[string][]string `yaml:"registry,omitempty"`
+	// SpendLimits is the spend limits of the commands, nil for no limit.
+	SpendLimits *spend.Limits `yaml:"spend-limits,omitempty"`
+	// Allowlist is the path of the allowlist of the destination addresses
+	// of the built txs, empty to not restrict them.
+	Allowlist string `yaml:"allowlist,omitempty"`
+}
+
+// DefaultPath returns the default path of the configuration file.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".subnet-cli", "config.yaml"), nil
+}
+
+// Load loads the configuration file (in YAML or JSON),
+// or returns the empty configuration if it does not exist.
+func Load(p string) (*Config, error) {
+	b, err := ioutil.ReadFile(p)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cfg := new(Config)
+	if err := yaml.UnmarshalStrict(b, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", p, err)
+	}
+	return cfg, nil
+}
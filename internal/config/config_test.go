@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg, err := Load(filepath.Join(dir, "missing.yaml"))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(cfg.Endpoints) != 0 {
+		t.Fatalf("unexpected endpoints %v", cfg.Endpoints)
+	}
+
+	p := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(p, []byte(`endpoints:
+  - uri: https://avax.corp.example
+    auth-token: abc
+  - uri: https://10.0.0.12:9650
+    tls-cert: client.pem
+    tls-key: client-key.pem
+registry:
+  fuji:
+    - https://fuji.corp.example
+spend-limits:
+  command:
+    soft: 10avax
+  commands:
+    add validator:
+      hard: 5000avax
+  window: 24h
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load(p)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(cfg.Endpoints) != 2 || cfg.Endpoints[0].Token != "abc" || cfg.Endpoints[1].Key != "client-key.pem" {
+		t.Fatalf("unexpected endpoints %+v", cfg.Endpoints)
+	}
+	if uris := cfg.Registry["fuji"]; len(uris) != 1 || uris[0] != "https://fuji.corp.example" {
+		t.Fatalf("unexpected registry %v", cfg.Registry)
+	}
+	if l := cfg.SpendLimits; l == nil || uint64(l.Command.Soft) != 10*units.Avax || uint64(l.Commands["add validator"].Hard) != 5000*units.Avax || l.Window != 24*time.Hour {
+		t.Fatalf("unexpected spend limits %+v", cfg.SpendLimits)
+	}
+
+	if err := ioutil.WriteFile(p, []byte("endpoints:\n  - url: https://avax.corp.example\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(p); err == nil {
+		t.Fatal("expected error on unknown field")
+	}
+}
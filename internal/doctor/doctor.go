@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package doctor implements the checklist of the pre-flight checks.
+package doctor
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrFailed = errors.New("pre-flight checks failed")
+
+// Status is the outcome of a check.
+type Status int
+
+const (
+	Pass Status = iota
+	// Warn is a check that passed with a caveat.
+	Warn
+	Fail
+	// Skip is a check that could not run (e.g., the endpoint is unreachable).
+	Skip
+)
+
+func (s Status) String() string {
+	switch s {
+	case Pass:
+		return "pass"
+	case Warn:
+		return "warn"
+	case Fail:
+		return "fail"
+	case Skip:
+		return "skip"
+	default:
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+}
+
+// Result is the outcome of a check, with its detail.
+type Result struct {
+	Check  string
+	Status Status
+	Detail string
+}
+
+// Checklist is the results of the checks, in the order run.
+type Checklist struct {
+	Results []Result
+}
+
+func (c *Checklist) add(check string, s Status, format string, args ...interface{}) {
+	c.Results = append(c.Results, Result{Check: check, Status: s, Detail: fmt.Sprintf(format, args...)})
+}
+
+// Pass records the passed check.
+func (c *Checklist) Pass(check string, format string, args ...interface{}) {
+	c.add(check, Pass, format, args...)
+}
+
+// Warn records the check passed with a caveat.
+func (c *Checklist) Warn(check string, format string, args ...interface{}) {
+	c.add(check, Warn, format, args...)
+}
+
+// Fail records the failed check.
+func (c *Checklist) Fail(check string, format string, args ...interface{}) {
+	c.add(check, Fail, format, args...)
+}
+
+// Skip records the check that could not run.
+func (c *Checklist) Skip(check string, format string, args ...interface{}) {
+	c.add(check, Skip, format, args...)
+}
+
+// Err returns "ErrFailed" with the failed checks, nil if none failed.
+func (c *Checklist) Err() error {
+	var failed []string
+	for _, r := range c.Results {
+		if r.Status == Fail {
+			failed = append(failed, r.Check)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %d of %d (%v)", ErrFailed, len(failed), len(c.Results), failed)
+}
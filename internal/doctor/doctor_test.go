@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package doctor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestChecklist(t *testing.T) {
+	t.Parallel()
+
+	c := new(Checklist)
+	c.Pass("endpoint", "network %d", 5)
+	c.Warn("clock", "skew %s", "2s")
+	c.Skip("key", "no key")
+	if err := c.Err(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	c.Fail("balance", "needs %d", 1)
+	if err := c.Err(); !errors.Is(err, ErrFailed) {
+		t.Fatalf("expected %v, got %v", ErrFailed, err)
+	}
+	expected := []Result{
+		{Check: "endpoint", Status: Pass, Detail: "network 5"},
+		{Check: "clock", Status: Warn, Detail: "skew 2s"},
+		{Check: "key", Status: Skip, Detail: "no key"},
+		{Check: "balance", Status: Fail, Detail: "needs 1"},
+	}
+	for i, r := range c.Results {
+		if r != expected[i] {
+			t.Fatalf("#%d: expected %+v, got %+v", i, expected[i], r)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package endpoint implements the registry of the known public endpoints
+// per network, and the selection of the best one by health and latency.
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// Auto is the "--endpoint" value to select the best endpoint of the network.
+const Auto = "auto"
+
+// ProbeTimeout bounds the probe of an endpoint.
+const ProbeTimeout = 5 * time.Second
+
+var ErrNoHealthyEndpoint = errors.New("no healthy endpoint")
+
+// Registry is the known public endpoints per network ID.
+var Registry = map[uint32][]string{
+	constants.MainnetID: {"https://api.avax.network"},
+	constants.FujiID:    {"https://api.avax-test.network"},
+	// ref. the default ports of the avalanche-network-runner
+	constants.LocalID: {
+		"http://127.0.0.1:9650",
+		"http://127.0.0.1:9652",
+		"http://127.0.0.1:9654",
+		"http://127.0.0.1:9656",
+		"http://127.0.0.1:9658",
+	},
+}
+
+// Candidates returns the known endpoints of [networkID], followed by
+// the [extra] ones (e.g., from the config file), without duplicates.
+func Candidates(networkID uint32, extra []string) []string {
+	seen := map[string]struct{}{}
+	var uris []string
+	for _, uri := range append(append([]string{}, Registry[networkID]...), extra...) {
+		if _, ok := seen[uri]; ok {
+			continue
+		}
+		seen[uri] = struct{}{}
+		uris = append(uris, uri)
+	}
+	return uris
+}
+
+// ProbeFunc checks the endpoint [uri] serves [networkID] and is healthy.
+type ProbeFunc func(ctx context.Context, uri string, networkID uint32) error
+
+// Probe is the result of the probe of an endpoint.
+type Probe struct {
+	URI     string
+	Latency time.Duration
+	// Err is non-nil if the endpoint is unhealthy.
+	Err error
+}
+
+// ProbeAll probes the [uris] concurrently, and returns the results with
+// the healthy endpoints first, by increasing latency.
+func ProbeAll(ctx context.Context, uris []string, networkID uint32, probe ProbeFunc) []Probe {
+	ps := make([]Probe, len(uris))
+	var wg sync.WaitGroup
+	for i, uri := range uris {
+		wg.Add(1)
+		go func(i int, uri string) {
+			defer wg.Done()
+			pctx, cancel := context.WithTimeout(ctx, ProbeTimeout)
+			defer cancel()
+			start := time.Now()
+			err := probe(pctx, uri, networkID)
+			ps[i] = Probe{URI: uri, Latency: time.Since(start), Err: err}
+		}(i, uri)
+	}
+	wg.Wait()
+	sort.SliceStable(ps, func(i, j int) bool {
+		if (ps[i].Err == nil) != (ps[j].Err == nil) {
+			return ps[i].Err == nil
+		}
+		return ps[i].Latency < ps[j].Latency
+	})
+	return ps
+}
+
+// Best returns the healthy endpoint of the lowest latency of [ps],
+// as sorted by "ProbeAll".
+func Best(ps []Probe) (Probe, error) {
+	if len(ps) == 0 || ps[0].Err != nil {
+		return Probe{}, ErrNoHealthyEndpoint
+	}
+	return ps[0], nil
+}
+
+// ProbeInfo probes the endpoint via the info API: it must report
+// [networkID] and have bootstrapped the P-Chain.
+func ProbeInfo(ctx context.Context, uri string, networkID uint32) error {
+	ic := info.NewClient(uri)
+	id, err := ic.GetNetworkID(ctx)
+	if err != nil {
+		return err
+	}
+	if id != networkID {
+		return fmt.Errorf("reports network ID %d, expected %d", id, networkID)
+	}
+	bootstrapped, err := ic.IsBootstrapped(ctx, "P")
+	if err != nil {
+		return err
+	}
+	if !bootstrapped {
+		return errors.New("P-Chain not bootstrapped")
+	}
+	return nil
+}
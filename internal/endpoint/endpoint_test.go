@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestProbeAll(t *testing.T) {
+	t.Parallel()
+
+	latencies := map[string]time.Duration{
+		"http://slow":    30 * time.Millisecond,
+		"http://fast":    time.Millisecond,
+		"http://down":    0,
+		"http://medium":  10 * time.Millisecond,
+		"http://fuji-ep": 0,
+	}
+	probe := func(ctx context.Context, uri string, networkID uint32) error {
+		switch uri {
+		case "http://down":
+			return errors.New("connection refused")
+		case "http://fuji-ep":
+			if networkID != constants.FujiID {
+				return errors.New("wrong network")
+			}
+		}
+		time.Sleep(latencies[uri])
+		return nil
+	}
+
+	ps := ProbeAll(context.Background(), []string{"http://slow", "http://down", "http://fast", "http://medium", "http://fuji-ep"}, constants.MainnetID, probe)
+	expected := []string{"http://fast", "http://medium", "http://slow", "http://down", "http://fuji-ep"}
+	for i, p := range ps {
+		if i < 3 && p.URI != expected[i] {
+			t.Fatalf("#%d: expected %q, got %q", i, expected[i], p.URI)
+		}
+		if i >= 3 && p.Err == nil {
+			t.Fatalf("#%d: expected %q to be unhealthy", i, p.URI)
+		}
+	}
+	best, err := Best(ps)
+	if err != nil || best.URI != "http://fast" {
+		t.Fatalf("unexpected best %+v (%v)", best, err)
+	}
+
+	ps = ProbeAll(context.Background(), []string{"http://down"}, constants.MainnetID, probe)
+	if _, err := Best(ps); !errors.Is(err, ErrNoHealthyEndpoint) {
+		t.Fatalf("expected %v, got %v", ErrNoHealthyEndpoint, err)
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	t.Parallel()
+
+	uris := Candidates(constants.FujiID, []string{"https://fuji.corp.example", "https://api.avax-test.network"})
+	if len(uris) != 2 || uris[0] != "https://api.avax-test.network" || uris[1] != "https://fuji.corp.example" {
+		t.Fatalf("unexpected candidates %v", uris)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// transferGas is the gas of a transfer without data.
+const transferGas = 21000
+
+var (
+	ErrInvalidBytecode = errors.New("invalid bytecode")
+	ErrDeployReverted  = errors.New("contract deployment reverted")
+	ErrTxReverted      = errors.New("tx reverted")
+	ErrAddressMismatch = errors.New("unexpected contract address")
+)
+
+// ParseBytecode parses the contract creation bytecode, either in the
+// ("0x"-prefixed) hex, or in the compiled artifact JSON of Hardhat
+// ("bytecode": "0x...") or Foundry ("bytecode": {"object": "0x..."}).
+func ParseBytecode(b []byte) ([]byte, error) {
+	b = bytes.TrimSpace(b)
+	s := string(b)
+	if strings.HasPrefix(s, "{") {
+		var artifact struct {
+			Bytecode json.RawMessage `json:"bytecode"`
+		}
+		if err := json.Unmarshal(b, &artifact); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBytecode, err)
+		}
+		var obj struct {
+			Object string `json:"object"`
+		}
+		if err := json.Unmarshal(artifact.Bytecode, &s); err != nil {
+			if err := json.Unmarshal(artifact.Bytecode, &obj); err != nil {
+				return nil, fmt.Errorf("%w: no \"bytecode\" in the artifact", ErrInvalidBytecode)
+			}
+			s = obj.Object
+		}
+	}
+	code, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBytecode, err)
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidBytecode)
+	}
+	return code, nil
+}
+
+// Deployment is the result of a contract deployment.
+type Deployment struct {
+	From     []byte
+	Contract []byte
+	Receipt  *Receipt
+}
+
+// Deploy creates the contract of [code] from the key of [pub], whose
+// [sign] returns the signature of a hash in format [r || s || v].
+// If [gas] is zero, the gas limit is estimated. It waits for the tx
+// to be accepted, polling every [interval].
+func Deploy(
+	ctx context.Context,
+	c Client,
+	pub *ecdsa.PublicKey,
+	sign func(hash []byte) ([]byte, error),
+	code []byte,
+	gas uint64,
+	interval time.Duration,
+) (*Deployment, error) {
+	from := Address(pub)
+	nonce, rcpt, err := send(ctx, c, pub, sign, nil, new(big.Int), code, gas, interval)
+	if err != nil {
+		return nil, err
+	}
+	d := &Deployment{From: from, Contract: CreateAddress(from, nonce), Receipt: rcpt}
+	if !rcpt.Success {
+		return d, fmt.Errorf("%w: tx %s", ErrDeployReverted, rcpt.TxHash)
+	}
+	if !strings.EqualFold(rcpt.ContractAddress, EncodeHex(d.Contract)) {
+		return d, fmt.Errorf("%w: %s, expected %s", ErrAddressMismatch, rcpt.ContractAddress, EncodeHex(d.Contract))
+	}
+	return d, nil
+}
+
+// Ping sends a zero-value transfer from the key of [pub] to itself, and
+// waits for it to be accepted, to check that the chain produces blocks.
+func Ping(
+	ctx context.Context,
+	c Client,
+	pub *ecdsa.PublicKey,
+	sign func(hash []byte) ([]byte, error),
+	interval time.Duration,
+) (*Receipt, error) {
+	_, rcpt, err := send(ctx, c, pub, sign, Address(pub), new(big.Int), nil, transferGas, interval)
+	return rcpt, err
+}
+
+// Transfer sends [value] wei from the key of [pub] to [to], and waits
+// for it to be accepted.
+func Transfer(
+	ctx context.Context,
+	c Client,
+	pub *ecdsa.PublicKey,
+	sign func(hash []byte) ([]byte, error),
+	to []byte,
+	value *big.Int,
+	interval time.Duration,
+) (*Receipt, error) {
+	_, rcpt, err := send(ctx, c, pub, sign, to, value, nil, transferGas, interval)
+	if err == nil && !rcpt.Success {
+		err = fmt.Errorf("%w: transfer tx %s", ErrTxReverted, rcpt.TxHash)
+	}
+	return rcpt, err
+}
+
+// send sends the tx of [value] and [data] to [to] (nil to create a contract)
+// from the key of [pub], and waits for it to be accepted. It returns the
+// nonce of the tx.
+func send(
+	ctx context.Context,
+	c Client,
+	pub *ecdsa.PublicKey,
+	sign func(hash []byte) ([]byte, error),
+	to []byte,
+	value *big.Int,
+	data []byte,
+	gas uint64,
+	interval time.Duration,
+) (uint64, *Receipt, error) {
+	from := Address(pub)
+	chainID, err := c.ChainID(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	nonce, err := c.PendingNonce(ctx, from)
+	if err != nil {
+		return 0, nil, err
+	}
+	gasPrice, err := c.GasPrice(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	if gas == 0 {
+		gas, err = c.EstimateGas(ctx, from, to, data)
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+
+	tx := &LegacyTx{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Gas:      gas,
+		To:       to,
+		Value:    value,
+		Data:     data,
+	}
+	sig, err := sign(tx.SigningHash(chainID))
+	if err != nil {
+		return 0, nil, err
+	}
+	raw, hash, err := tx.Signed(chainID, sig)
+	if err != nil {
+		return 0, nil, err
+	}
+	rcpt, err := SendRaw(ctx, c, raw, hash, interval)
+	if err != nil {
+		return 0, nil, err
+	}
+	return nonce, rcpt, nil
+}
+
+// SendRaw issues the raw signed tx of [hash], and waits for it to be accepted.
+func SendRaw(ctx context.Context, c Client, raw []byte, hash []byte, interval time.Duration) (*Receipt, error) {
+	txHash, err := c.SendRawTx(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(txHash, EncodeHex(hash)) {
+		return nil, fmt.Errorf("%w: node returned tx hash %s, expected %s", ErrRPC, txHash, EncodeHex(hash))
+	}
+	return WaitReceipt(ctx, c, txHash, interval)
+}
+
+// WaitReceipt polls the receipt of the tx every [interval] until accepted.
+func WaitReceipt(ctx context.Context, c Client, txHash string, interval time.Duration) (*Receipt, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		rcpt, err := c.Receipt(ctx, txHash)
+		if err != nil {
+			return nil, err
+		}
+		if rcpt != nil {
+			return rcpt, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
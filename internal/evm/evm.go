@@ -0,0 +1,354 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package evm implements a minimal JSON-RPC client for the EVM chains
+// (e.g., Subnet-EVM).
+package evm
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+var ErrRPC = errors.New("rpc error")
+
+type Client interface {
+	// Call calls the JSON-RPC [method], and decodes the result into [result].
+	Call(ctx context.Context, result interface{}, method string, params ...interface{}) error
+	// LatestBlock returns the header of the last accepted block.
+	LatestBlock(ctx context.Context) (*Block, error)
+	// FeeConfig returns the current fee config (Subnet-EVM only).
+	FeeConfig(ctx context.Context) (map[string]json.RawMessage, error)
+	// ChainConfig returns the chain config (Subnet-EVM only).
+	ChainConfig(ctx context.Context) (map[string]json.RawMessage, error)
+	// ChainID returns the EIP-155 chain ID.
+	ChainID(ctx context.Context) (*big.Int, error)
+	// Balance returns the balance of [addr] in wei.
+	Balance(ctx context.Context, addr []byte) (*big.Int, error)
+	// Code returns the code of the contract at [addr], empty if none.
+	Code(ctx context.Context, addr []byte) ([]byte, error)
+	// PendingNonce returns the next nonce of [addr], including the pending txs.
+	PendingNonce(ctx context.Context, addr []byte) (uint64, error)
+	// GasPrice returns the suggested gas price.
+	GasPrice(ctx context.Context) (*big.Int, error)
+	// EstimateGas returns the gas to execute the tx of [data] from [from]
+	// to [to] (nil to create a contract).
+	EstimateGas(ctx context.Context, from []byte, to []byte, data []byte) (uint64, error)
+	// SendRawTx issues the signed tx, and returns its hash.
+	SendRawTx(ctx context.Context, raw []byte) (string, error)
+	// Receipt returns the receipt of the tx, or nil if not yet accepted.
+	Receipt(ctx context.Context, txHash string) (*Receipt, error)
+}
+
+var _ Client = &client{}
+
+type client struct {
+	rpcURL string
+	id     uint64
+}
+
+// New creates a client of the EVM chain at [uri], the base URI of the
+// avalanche node (e.g., "http://localhost:9650").
+func New(uri string, blockchainID string) Client {
+	return &client{rpcURL: RPCURL(uri, blockchainID)}
+}
+
+// Dial creates a client of the EVM chain at the JSON-RPC endpoint [rpcURL]
+// (e.g., "http://localhost:9650/ext/bc/[BLOCKCHAIN ID]/rpc").
+func Dial(rpcURL string) Client {
+	return &client{rpcURL: rpcURL}
+}
+
+// RPCURL returns the JSON-RPC endpoint of the EVM chain.
+func RPCURL(uri string, blockchainID string) string {
+	return fmt.Sprintf("%s/ext/bc/%s/rpc", strings.TrimSuffix(uri, "/"), blockchainID)
+}
+
+func (c *client) Call(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	b, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      atomic.AddUint64(&c.id, 1),
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.rpcURL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var msg struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&msg); err != nil {
+		return fmt.Errorf("%w: unexpected response (status %d): %v", ErrRPC, resp.StatusCode, err)
+	}
+	if msg.Error != nil {
+		return fmt.Errorf("%w: %s: %s (code %d)", ErrRPC, method, msg.Error.Message, msg.Error.Code)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(msg.Result, result)
+}
+
+// Block is the header of a block.
+type Block struct {
+	Number    uint64
+	Hash      string
+	Timestamp uint64
+	GasUsed   uint64
+	GasLimit  uint64
+	// nil before the dynamic fees are activated
+	BaseFee *big.Int
+}
+
+func (c *client) LatestBlock(ctx context.Context) (*Block, error) {
+	var raw struct {
+		Number    string  `json:"number"`
+		Hash      string  `json:"hash"`
+		Timestamp string  `json:"timestamp"`
+		GasUsed   string  `json:"gasUsed"`
+		GasLimit  string  `json:"gasLimit"`
+		BaseFee   *string `json:"baseFeePerGas"`
+	}
+	if err := c.Call(ctx, &raw, "eth_getBlockByNumber", "latest", false); err != nil {
+		return nil, err
+	}
+	blk := &Block{Hash: raw.Hash}
+	for _, f := range []struct {
+		s string
+		v *uint64
+	}{
+		{raw.Number, &blk.Number},
+		{raw.Timestamp, &blk.Timestamp},
+		{raw.GasUsed, &blk.GasUsed},
+		{raw.GasLimit, &blk.GasLimit},
+	} {
+		v, err := ParseHexBig(f.s)
+		if err != nil {
+			return nil, err
+		}
+		*f.v = v.Uint64()
+	}
+	if raw.BaseFee != nil {
+		v, err := ParseHexBig(*raw.BaseFee)
+		if err != nil {
+			return nil, err
+		}
+		blk.BaseFee = v
+	}
+	return blk, nil
+}
+
+func (c *client) FeeConfig(ctx context.Context) (map[string]json.RawMessage, error) {
+	var resp struct {
+		FeeConfig map[string]json.RawMessage `json:"feeConfig"`
+	}
+	if err := c.Call(ctx, &resp, "eth_feeConfig"); err != nil {
+		return nil, err
+	}
+	return resp.FeeConfig, nil
+}
+
+func (c *client) ChainConfig(ctx context.Context) (map[string]json.RawMessage, error) {
+	cfg := make(map[string]json.RawMessage)
+	if err := c.Call(ctx, &cfg, "eth_getChainConfig"); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *client) ChainID(ctx context.Context) (*big.Int, error) {
+	return c.callBig(ctx, "eth_chainId")
+}
+
+func (c *client) Balance(ctx context.Context, addr []byte) (*big.Int, error) {
+	return c.callBig(ctx, "eth_getBalance", EncodeHex(addr), "latest")
+}
+
+func (c *client) Code(ctx context.Context, addr []byte) ([]byte, error) {
+	var s string
+	if err := c.Call(ctx, &s, "eth_getCode", EncodeHex(addr), "latest"); err != nil {
+		return nil, err
+	}
+	code, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid code: %v", ErrRPC, err)
+	}
+	return code, nil
+}
+
+func (c *client) PendingNonce(ctx context.Context, addr []byte) (uint64, error) {
+	v, err := c.callBig(ctx, "eth_getTransactionCount", EncodeHex(addr), "pending")
+	if err != nil {
+		return 0, err
+	}
+	return v.Uint64(), nil
+}
+
+func (c *client) GasPrice(ctx context.Context) (*big.Int, error) {
+	return c.callBig(ctx, "eth_gasPrice")
+}
+
+func (c *client) EstimateGas(ctx context.Context, from []byte, to []byte, data []byte) (uint64, error) {
+	msg := map[string]string{
+		"from": EncodeHex(from),
+		"data": EncodeHex(data),
+	}
+	if to != nil {
+		msg["to"] = EncodeHex(to)
+	}
+	v, err := c.callBig(ctx, "eth_estimateGas", msg)
+	if err != nil {
+		return 0, err
+	}
+	return v.Uint64(), nil
+}
+
+func (c *client) SendRawTx(ctx context.Context, raw []byte) (string, error) {
+	var hash string
+	if err := c.Call(ctx, &hash, "eth_sendRawTransaction", EncodeHex(raw)); err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+// Receipt is the receipt of an accepted tx.
+type Receipt struct {
+	TxHash      string
+	BlockNumber uint64
+	GasUsed     uint64
+	// ContractAddress is empty unless the tx created a contract.
+	ContractAddress string
+	// Success is false if the tx was reverted.
+	Success bool
+}
+
+func (c *client) Receipt(ctx context.Context, txHash string) (*Receipt, error) {
+	var raw *struct {
+		TxHash          string  `json:"transactionHash"`
+		BlockNumber     string  `json:"blockNumber"`
+		GasUsed         string  `json:"gasUsed"`
+		ContractAddress *string `json:"contractAddress"`
+		Status          string  `json:"status"`
+	}
+	if err := c.Call(ctx, &raw, "eth_getTransactionReceipt", txHash); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, nil
+	}
+	rcpt := &Receipt{TxHash: raw.TxHash, Success: raw.Status == "0x1"}
+	if raw.ContractAddress != nil {
+		rcpt.ContractAddress = *raw.ContractAddress
+	}
+	for _, f := range []struct {
+		s string
+		v *uint64
+	}{
+		{raw.BlockNumber, &rcpt.BlockNumber},
+		{raw.GasUsed, &rcpt.GasUsed},
+	} {
+		v, err := ParseHexBig(f.s)
+		if err != nil {
+			return nil, err
+		}
+		*f.v = v.Uint64()
+	}
+	return rcpt, nil
+}
+
+func (c *client) callBig(ctx context.Context, method string, params ...interface{}) (*big.Int, error) {
+	var s string
+	if err := c.Call(ctx, &s, method, params...); err != nil {
+		return nil, err
+	}
+	return ParseHexBig(s)
+}
+
+// EncodeHex encodes [b] in the "0x"-prefixed hex.
+func EncodeHex(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// ParseHexBig parses the "0x"-prefixed hex quantity.
+func ParseHexBig(s string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(strings.TrimPrefix(s, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid quantity %q", ErrRPC, s)
+	}
+	return v, nil
+}
+
+// Precompiles returns the names of the precompile configs in the chain
+// config (e.g., "contractDeployerAllowListConfig"), sorted.
+func Precompiles(cfg map[string]json.RawMessage) []string {
+	names := make([]string, 0)
+	for k, v := range cfg {
+		if !strings.HasSuffix(k, "Config") || k == "feeConfig" {
+			continue
+		}
+		var pc struct {
+			BlockTimestamp *json.Number `json:"blockTimestamp"`
+		}
+		if err := json.Unmarshal(v, &pc); err != nil || pc.BlockTimestamp == nil {
+			continue
+		}
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Diff returns the keys of [expected] whose values differ in [actual],
+// sorted. The values are compared semantically, regardless of formatting.
+func Diff(expected map[string]json.RawMessage, actual map[string]json.RawMessage) []string {
+	diff := make([]string, 0)
+	for k, ev := range expected {
+		av, ok := actual[k]
+		if !ok || !jsonEqual(ev, av) {
+			diff = append(diff, k)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	var va, vb interface{}
+	da := json.NewDecoder(bytes.NewReader(a))
+	da.UseNumber()
+	db := json.NewDecoder(bytes.NewReader(b))
+	db.UseNumber()
+	if da.Decode(&va) != nil || db.Decode(&vb) != nil {
+		return bytes.Equal(a, b)
+	}
+	ba, _ := json.Marshal(va)
+	bb, _ := json.Marshal(vb)
+	return bytes.Equal(ba, bb)
+}
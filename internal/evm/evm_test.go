@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestLatestBlock(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ext/bc/abc/rpc" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"number":"0x10","hash":"0xff","timestamp":"0x5","gasUsed":"0x0","gasLimit":"0x7a1200","baseFeePerGas":"0x5d21dba00"}}`))
+	}))
+	defer srv.Close()
+
+	blk, err := New(srv.URL, "abc").LatestBlock(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blk.Number != 16 || blk.GasLimit != 8000000 || blk.BaseFee.Uint64() != 25000000000 {
+		t.Fatalf("unexpected block %+v", blk)
+	}
+}
+
+func TestPrecompilesAndDiff(t *testing.T) {
+	t.Parallel()
+
+	var genesis, chain map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(`{
+		"chainId": 43214,
+		"feeConfig": {"gasLimit": 8000000, "minBaseFee": 25000000000},
+		"contractDeployerAllowListConfig": {"blockTimestamp": 0, "adminAddresses": ["0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"]}
+	}`), &genesis); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{
+		"chainId": 43214,
+		"feeConfig": {"minBaseFee": 25000000000, "gasLimit": 20000000},
+		"contractDeployerAllowListConfig": {"adminAddresses": ["0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"], "blockTimestamp": 0},
+		"txAllowListConfig": {"blockTimestamp": 10}
+	}`), &chain); err != nil {
+		t.Fatal(err)
+	}
+
+	if p := Precompiles(chain); !reflect.DeepEqual(p, []string{"contractDeployerAllowListConfig", "txAllowListConfig"}) {
+		t.Fatalf("unexpected precompiles %v", p)
+	}
+	if d := Diff(genesis, chain); !reflect.DeepEqual(d, []string{"feeConfig"}) {
+		t.Fatalf("unexpected diff %v", d)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// TeleporterMessengerAddress is the canonical address of the ICM
+// (Teleporter) messenger contract v1.0.0, the same on every chain.
+// ref. https://github.com/ava-labs/teleporter/releases/tag/v1.0.0
+const TeleporterMessengerAddress = "0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf"
+
+// WarpConfig is the chain config key of the Warp precompile, which
+// signs the ICM messages for the relayers.
+const WarpConfig = "warpConfig"
+
+var (
+	ErrNotContractCreation = errors.New("tx does not create a contract")
+	ErrDeployerUsed        = errors.New("keyless deployer already used")
+	ErrInvalidAddress      = errors.New("invalid EVM address")
+)
+
+// KeylessDeployment is a contract creation tx signed by a throwaway key
+// without the replay protection (i.e., "Nakamoto's method"), so the
+// contract is created at the same address on every chain, as the first
+// tx of its deployer.
+type KeylessDeployment struct {
+	Tx       *LegacyTx
+	Raw      []byte
+	Hash     []byte
+	Deployer []byte
+	Contract []byte
+}
+
+// ParseKeylessDeployment parses the hex-encoded raw tx of a keyless
+// deployment (e.g., "TeleporterMessenger_Deployment_Transaction_v1.0.0.txt").
+func ParseKeylessDeployment(b []byte) (*KeylessDeployment, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(string(bytes.TrimSpace(b)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTx, err)
+	}
+	tx, deployer, err := ParseSignedTx(raw)
+	if err != nil {
+		return nil, err
+	}
+	if tx.To != nil {
+		return nil, ErrNotContractCreation
+	}
+	if tx.Nonce != 0 {
+		return nil, fmt.Errorf("%w: nonce %d, expected 0", ErrInvalidTx, tx.Nonce)
+	}
+	return &KeylessDeployment{
+		Tx:       tx,
+		Raw:      raw,
+		Hash:     Keccak256(raw),
+		Deployer: deployer,
+		Contract: CreateAddress(deployer, 0),
+	}, nil
+}
+
+// Cost returns the funds the deployer needs to pay for the tx, in wei.
+func (d *KeylessDeployment) Cost() *big.Int {
+	return new(big.Int).Mul(d.Tx.GasPrice, new(big.Int).SetUint64(d.Tx.Gas))
+}
+
+// DeployKeyless deploys the contract of [d], unless already deployed (in
+// which case the receipt is nil). The deployer is funded from the key of
+// [pub] with the missing cost of the tx.
+func DeployKeyless(
+	ctx context.Context,
+	c Client,
+	pub *ecdsa.PublicKey,
+	sign func(hash []byte) ([]byte, error),
+	d *KeylessDeployment,
+	interval time.Duration,
+) (*Deployment, error) {
+	dep := &Deployment{From: d.Deployer, Contract: d.Contract}
+	code, err := c.Code(ctx, d.Contract)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) > 0 {
+		return dep, nil
+	}
+	nonce, err := c.PendingNonce(ctx, d.Deployer)
+	if err != nil {
+		return nil, err
+	}
+	if nonce != 0 {
+		return nil, fmt.Errorf("%w: %s has nonce %d, but no contract at %s",
+			ErrDeployerUsed, EncodeHex(d.Deployer), nonce, EncodeHex(d.Contract))
+	}
+
+	balance, err := c.Balance(ctx, d.Deployer)
+	if err != nil {
+		return nil, err
+	}
+	if missing := new(big.Int).Sub(d.Cost(), balance); missing.Sign() > 0 {
+		if _, err := Transfer(ctx, c, pub, sign, d.Deployer, missing, interval); err != nil {
+			return nil, fmt.Errorf("failed to fund the deployer: %w", err)
+		}
+	}
+
+	dep.Receipt, err = SendRaw(ctx, c, d.Raw, d.Hash, interval)
+	if err != nil {
+		return nil, err
+	}
+	if !dep.Receipt.Success {
+		return dep, fmt.Errorf("%w: tx %s", ErrDeployReverted, dep.Receipt.TxHash)
+	}
+	if !strings.EqualFold(dep.Receipt.ContractAddress, EncodeHex(d.Contract)) {
+		return dep, fmt.Errorf("%w: %s, expected %s", ErrAddressMismatch, dep.Receipt.ContractAddress, EncodeHex(d.Contract))
+	}
+	return dep, nil
+}
+
+// RegistryArgs returns the ABI-encoded constructor arguments of the
+// "TeleporterRegistry" contract, with the messengers of [addrs] as the
+// initial protocol versions 1, 2, and so on.
+//
+//	constructor(ProtocolRegistryEntry[] memory initialEntries)
+//	struct ProtocolRegistryEntry { uint256 version; address protocolAddress; }
+func RegistryArgs(addrs ...[]byte) []byte {
+	words := [][]byte{
+		abiWord(big.NewInt(32)),
+		abiWord(big.NewInt(int64(len(addrs)))),
+	}
+	for i, addr := range addrs {
+		words = append(words, abiWord(big.NewInt(int64(i+1))), abiWord(new(big.Int).SetBytes(addr)))
+	}
+	return bytes.Join(words, nil)
+}
+
+func abiWord(v *big.Int) []byte {
+	return v.FillBytes(make([]byte, 32))
+}
+
+// ParseAddress parses the "0x"-prefixed hex EVM address.
+func ParseAddress(s string) ([]byte, error) {
+	addr, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(addr) != 20 {
+		return nil, fmt.Errorf("%w %q", ErrInvalidAddress, s)
+	}
+	return addr, nil
+}
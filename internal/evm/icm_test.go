@@ -0,0 +1,166 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/crypto"
+)
+
+// keylessTx returns the hex of a contract creation tx of [code], signed
+// without the replay protection by a new key, and the key address.
+func keylessTx(t *testing.T, code []byte) (string, []byte) {
+	f := &crypto.FactorySECP256K1R{}
+	k, err := f.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := &LegacyTx{GasPrice: big.NewInt(100), Gas: 1000, Value: new(big.Int), Data: code}
+	sig, err := k.SignHash(Keccak256(encodeRLP(tx.fields())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := encodeRLP(append(tx.fields(),
+		uint64(27+sig[sigLen-1]),
+		new(big.Int).SetBytes(sig[:32]),
+		new(big.Int).SetBytes(sig[32:64]),
+	))
+	pub := k.PublicKey().(*crypto.PublicKeySECP256K1R).ToECDSA()
+	return "0x" + hex.EncodeToString(raw) + "\n", Address(pub)
+}
+
+func TestParseKeylessDeployment(t *testing.T) {
+	t.Parallel()
+
+	s, deployer := keylessTx(t, []byte{0x60, 0x80})
+	d, err := ParseKeylessDeployment([]byte(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(d.Deployer, deployer) {
+		t.Fatalf("expected deployer %x, got %x", deployer, d.Deployer)
+	}
+	if !bytes.Equal(d.Contract, CreateAddress(deployer, 0)) {
+		t.Fatalf("unexpected contract %x", d.Contract)
+	}
+	if d.Cost().Uint64() != 100000 {
+		t.Fatalf("unexpected cost %s", d.Cost())
+	}
+
+	if _, err := ParseKeylessDeployment([]byte("zz")); !errors.Is(err, ErrInvalidTx) {
+		t.Fatalf("expected %v, got %v", ErrInvalidTx, err)
+	}
+}
+
+func TestRegistryArgs(t *testing.T) {
+	t.Parallel()
+
+	addr := bytes.Repeat([]byte{0xab}, 20)
+	got := hex.EncodeToString(RegistryArgs(addr))
+	exp := "0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"000000000000000000000000abababababababababababababababababababab"
+	if got != exp {
+		t.Fatalf("expected %s, got %s", exp, got)
+	}
+}
+
+// fakeClient is the chain state of the keyless deployment.
+type fakeClient struct {
+	code    []byte
+	nonce   uint64
+	balance *big.Int
+	// contract created by the txs
+	contract []byte
+	sent     int
+}
+
+func (c *fakeClient) Call(context.Context, interface{}, string, ...interface{}) error { return nil }
+func (c *fakeClient) LatestBlock(context.Context) (*Block, error)                     { return nil, nil }
+func (c *fakeClient) FeeConfig(context.Context) (map[string]json.RawMessage, error)   { return nil, nil }
+func (c *fakeClient) ChainConfig(context.Context) (map[string]json.RawMessage, error) {
+	return nil, nil
+}
+func (c *fakeClient) ChainID(context.Context) (*big.Int, error)            { return big.NewInt(1), nil }
+func (c *fakeClient) Balance(context.Context, []byte) (*big.Int, error)    { return c.balance, nil }
+func (c *fakeClient) Code(context.Context, []byte) ([]byte, error)         { return c.code, nil }
+func (c *fakeClient) PendingNonce(context.Context, []byte) (uint64, error) { return c.nonce, nil }
+func (c *fakeClient) GasPrice(context.Context) (*big.Int, error)           { return big.NewInt(1), nil }
+func (c *fakeClient) EstimateGas(context.Context, []byte, []byte, []byte) (uint64, error) {
+	return transferGas, nil
+}
+
+func (c *fakeClient) SendRawTx(_ context.Context, raw []byte) (string, error) {
+	c.sent++
+	return EncodeHex(Keccak256(raw)), nil
+}
+
+func (c *fakeClient) Receipt(_ context.Context, txHash string) (*Receipt, error) {
+	return &Receipt{TxHash: txHash, Success: true, ContractAddress: EncodeHex(c.contract)}, nil
+}
+
+func TestDeployKeyless(t *testing.T) {
+	t.Parallel()
+
+	s, _ := keylessTx(t, []byte{0x60, 0x80})
+	d, err := ParseKeylessDeployment([]byte(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &crypto.FactorySECP256K1R{}
+	k, err := f.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := k.PublicKey().(*crypto.PublicKeySECP256K1R).ToECDSA()
+
+	// already deployed
+	c := &fakeClient{code: []byte{0x60}}
+	dep, err := DeployKeyless(context.Background(), c, pub, k.SignHash, d, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dep.Receipt != nil || c.sent != 0 {
+		t.Fatalf("unexpected deployment %+v (%d txs)", dep, c.sent)
+	}
+
+	// deployer used for another tx
+	c = &fakeClient{nonce: 1}
+	if _, err := DeployKeyless(context.Background(), c, pub, k.SignHash, d, time.Millisecond); !errors.Is(err, ErrDeployerUsed) {
+		t.Fatalf("expected %v, got %v", ErrDeployerUsed, err)
+	}
+
+	// funded deployer
+	c = &fakeClient{balance: d.Cost(), contract: d.Contract}
+	if _, err := DeployKeyless(context.Background(), c, pub, k.SignHash, d, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if c.sent != 1 {
+		t.Fatalf("expected 1 tx, got %d", c.sent)
+	}
+
+	// unfunded deployer
+	c = &fakeClient{balance: new(big.Int), contract: d.Contract}
+	if _, err := DeployKeyless(context.Background(), c, pub, k.SignHash, d, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if c.sent != 2 {
+		t.Fatalf("expected 2 txs, got %d", c.sent)
+	}
+
+	// unexpected contract address
+	c = &fakeClient{balance: d.Cost()}
+	if _, err := DeployKeyless(context.Background(), c, pub, k.SignHash, d, time.Millisecond); !errors.Is(err, ErrAddressMismatch) {
+		t.Fatalf("expected %v, got %v", ErrAddressMismatch, err)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var ErrInvalidRLP = errors.New("invalid RLP")
+
+// encodeRLP encodes [v] in the recursive length prefix format, where [v]
+// is a []byte, a uint64, a *big.Int, or a []interface{} of those.
+// ref. https://ethereum.org/en/developers/docs/data-structures-and-encoding/rlp/
+func encodeRLP(v interface{}) []byte {
+	switch v := v.(type) {
+	case []byte:
+		if len(v) == 1 && v[0] < 0x80 {
+			return []byte{v[0]}
+		}
+		return append(rlpHeader(0x80, len(v)), v...)
+	case uint64:
+		return encodeRLP(new(big.Int).SetUint64(v))
+	case *big.Int:
+		if v == nil {
+			return encodeRLP([]byte{})
+		}
+		// zero is the empty string, no leading zero byte
+		return encodeRLP(v.Bytes())
+	case []interface{}:
+		payload := make([]byte, 0)
+		for _, item := range v {
+			payload = append(payload, encodeRLP(item)...)
+		}
+		return append(rlpHeader(0xc0, len(payload)), payload...)
+	default:
+		panic(fmt.Sprintf("unsupported RLP type %T", v))
+	}
+}
+
+func rlpHeader(offset byte, n int) []byte {
+	if n < 56 {
+		return []byte{offset + byte(n)}
+	}
+	size := new(big.Int).SetUint64(uint64(n)).Bytes()
+	return append([]byte{offset + 55 + byte(len(size))}, size...)
+}
+
+// decodeRLP decodes the single RLP item of [b] into a []byte, or
+// a []interface{} of those for a list.
+func decodeRLP(b []byte) (interface{}, error) {
+	v, rest, err := decodeRLPItem(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrInvalidRLP, len(rest))
+	}
+	return v, nil
+}
+
+func decodeRLPItem(b []byte) (interface{}, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, fmt.Errorf("%w: unexpected end", ErrInvalidRLP)
+	}
+	prefix := b[0]
+	switch {
+	case prefix < 0x80:
+		return b[:1], b[1:], nil
+	case prefix < 0xc0:
+		payload, rest, err := rlpPayload(b, 0x80)
+		return payload, rest, err
+	}
+	payload, rest, err := rlpPayload(b, 0xc0)
+	if err != nil {
+		return nil, nil, err
+	}
+	items := make([]interface{}, 0)
+	for len(payload) > 0 {
+		var item interface{}
+		item, payload, err = decodeRLPItem(payload)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rest, nil
+}
+
+// rlpPayload splits [b] into the payload of the header of [offset],
+// and the rest.
+func rlpPayload(b []byte, offset byte) ([]byte, []byte, error) {
+	n, start := uint64(b[0]-offset), uint64(1)
+	if n > 55 {
+		size := n - 55
+		if uint64(len(b)) < 1+size || size > 8 {
+			return nil, nil, fmt.Errorf("%w: invalid length", ErrInvalidRLP)
+		}
+		n = new(big.Int).SetBytes(b[1 : 1+size]).Uint64()
+		start += size
+	}
+	if uint64(len(b))-start < n {
+		return nil, nil, fmt.Errorf("%w: unexpected end", ErrInvalidRLP)
+	}
+	return b[start : start+n], b[start+n:], nil
+}
@@ -0,0 +1,146 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrInvalidTx        = errors.New("invalid tx")
+)
+
+// sigLen is the length of the signature of format [r || s || v],
+// where v is the recovery ID (0 or 1).
+const sigLen = 65
+
+// LegacyTx is the legacy (pre EIP-2718) tx, signed with the EIP-155
+// replay protection, which every EVM chain accepts.
+type LegacyTx struct {
+	Nonce    uint64
+	GasPrice *big.Int
+	Gas      uint64
+	// To is nil to create a contract.
+	To    []byte
+	Value *big.Int
+	Data  []byte
+}
+
+func (tx *LegacyTx) fields() []interface{} {
+	to := tx.To
+	if to == nil {
+		to = []byte{}
+	}
+	return []interface{}{tx.Nonce, tx.GasPrice, tx.Gas, to, tx.Value, tx.Data}
+}
+
+// SigningHash returns the hash of the tx to sign on the chain [chainID].
+func (tx *LegacyTx) SigningHash(chainID *big.Int) []byte {
+	return Keccak256(encodeRLP(append(tx.fields(), chainID, uint64(0), uint64(0))))
+}
+
+// Signed returns the raw signed tx, with [sig] of format [r || s || v]
+// (e.g., "crypto.PrivateKeySECP256K1R.SignHash"), and the tx hash.
+func (tx *LegacyTx) Signed(chainID *big.Int, sig []byte) (raw []byte, hash []byte, err error) {
+	if len(sig) != sigLen || sig[sigLen-1] > 1 {
+		return nil, nil, fmt.Errorf("%w: expected %d bytes of [r || s || v]", ErrInvalidSignature, sigLen)
+	}
+	// ref. https://eips.ethereum.org/EIPS/eip-155
+	v := new(big.Int).Mul(chainID, big.NewInt(2))
+	v.Add(v, big.NewInt(35+int64(sig[sigLen-1])))
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	raw = encodeRLP(append(tx.fields(), v, r, s))
+	return raw, Keccak256(raw), nil
+}
+
+// ParseSignedTx parses the raw signed legacy tx, and recovers its sender.
+// The signature is either replay-protected (EIP-155), or not (e.g., the
+// keyless deployment txs, signed for any chain).
+func ParseSignedTx(raw []byte) (*LegacyTx, []byte, error) {
+	v, err := decodeRLP(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidTx, err)
+	}
+	items, ok := v.([]interface{})
+	if !ok || len(items) != 9 {
+		return nil, nil, fmt.Errorf("%w: expected a list of 9 fields", ErrInvalidTx)
+	}
+	fields := make([][]byte, len(items))
+	for i, item := range items {
+		if fields[i], ok = item.([]byte); !ok {
+			return nil, nil, fmt.Errorf("%w: unexpected list in field %d", ErrInvalidTx, i)
+		}
+	}
+	tx := &LegacyTx{
+		Nonce:    new(big.Int).SetBytes(fields[0]).Uint64(),
+		GasPrice: new(big.Int).SetBytes(fields[1]),
+		Gas:      new(big.Int).SetBytes(fields[2]).Uint64(),
+		Value:    new(big.Int).SetBytes(fields[4]),
+		Data:     fields[5],
+	}
+	if len(fields[3]) > 0 {
+		tx.To = fields[3]
+	}
+
+	recID := new(big.Int).SetBytes(fields[6])
+	var hash []byte
+	switch {
+	case recID.Cmp(big.NewInt(27)) == 0 || recID.Cmp(big.NewInt(28)) == 0:
+		hash = Keccak256(encodeRLP(tx.fields()))
+		recID.Sub(recID, big.NewInt(27))
+	case recID.Cmp(big.NewInt(35)) >= 0:
+		// v = chainID * 2 + 35 + recovery ID
+		chainID := new(big.Int).Sub(recID, big.NewInt(35))
+		chainID.Rsh(chainID, 1)
+		hash = tx.SigningHash(chainID)
+		recID.Sub(recID, new(big.Int).Add(new(big.Int).Lsh(chainID, 1), big.NewInt(35)))
+	default:
+		return nil, nil, fmt.Errorf("%w: unexpected v %s", ErrInvalidSignature, recID)
+	}
+	if len(fields[7]) > 32 || len(fields[8]) > 32 {
+		return nil, nil, fmt.Errorf("%w: r or s overflows", ErrInvalidSignature)
+	}
+	sig := make([]byte, sigLen)
+	new(big.Int).SetBytes(fields[7]).FillBytes(sig[:32])
+	new(big.Int).SetBytes(fields[8]).FillBytes(sig[32:64])
+	sig[sigLen-1] = byte(recID.Uint64())
+
+	f := &crypto.FactorySECP256K1R{}
+	pub, err := f.RecoverHashPublicKey(hash, sig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return tx, Address(pub.(*crypto.PublicKeySECP256K1R).ToECDSA()), nil
+}
+
+// Keccak256 returns the legacy Keccak-256 hash of the concatenated [bs].
+func Keccak256(bs ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, b := range bs {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+// Address returns the 20-byte EVM address of the secp256k1 public key.
+func Address(pub *ecdsa.PublicKey) []byte {
+	b := make([]byte, 64)
+	pub.X.FillBytes(b[:32])
+	pub.Y.FillBytes(b[32:])
+	return Keccak256(b)[12:]
+}
+
+// CreateAddress returns the address of the contract created by [from]
+// with the tx of [nonce].
+func CreateAddress(from []byte, nonce uint64) []byte {
+	return Keccak256(encodeRLP([]interface{}{from, nonce}))[12:]
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestEncodeRLP(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		v   interface{}
+		exp string
+	}{
+		{[]byte("dog"), "83646f67"},
+		{[]interface{}{[]byte("cat"), []byte("dog")}, "c88363617483646f67"},
+		{[]byte{}, "80"},
+		{[]interface{}{}, "c0"},
+		{uint64(0), "80"},
+		{[]byte{0x0f}, "0f"},
+		{uint64(1024), "820400"},
+		{[]byte("Lorem ipsum dolor sit amet, consectetur adipisicing elit"), "b838" + hex.EncodeToString([]byte("Lorem ipsum dolor sit amet, consectetur adipisicing elit"))},
+	}
+	for i, tv := range tt {
+		if got := hex.EncodeToString(encodeRLP(tv.v)); got != tv.exp {
+			t.Fatalf("#%d: expected %s, got %s", i, tv.exp, got)
+		}
+	}
+}
+
+// ref. https://eips.ethereum.org/EIPS/eip-155 (example)
+func TestLegacyTxSigned(t *testing.T) {
+	t.Parallel()
+
+	gasPrice, _ := new(big.Int).SetString("20000000000", 10)
+	value, _ := new(big.Int).SetString("1000000000000000000", 10)
+	tx := &LegacyTx{
+		Nonce:    9,
+		GasPrice: gasPrice,
+		Gas:      21000,
+		To:       bytes.Repeat([]byte{0x35}, 20),
+		Value:    value,
+	}
+	chainID := big.NewInt(1)
+	if h := hex.EncodeToString(tx.SigningHash(chainID)); h != "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53" {
+		t.Fatalf("unexpected signing hash %s", h)
+	}
+
+	sig := append(
+		mustHex(t, "28ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276"+
+			"67cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"),
+		0,
+	)
+	raw, _, err := tx.Signed(chainID, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a7640000" +
+		"8025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276" +
+		"a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"
+	if got := hex.EncodeToString(raw); got != exp {
+		t.Fatalf("unexpected signed tx %s", got)
+	}
+
+	if _, _, err := tx.Signed(chainID, sig[:64]); err == nil {
+		t.Fatal("expected an error on a short signature")
+	}
+}
+
+func TestCreateAddress(t *testing.T) {
+	t.Parallel()
+
+	from := mustHex(t, "6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+	for nonce, exp := range []string{
+		"cd234a471b72ba2f1ccf0a70fcaba648a5eecd8d",
+		"343c43a37d37dff08ae8c4a11544c718abb4fcf8",
+	} {
+		if got := hex.EncodeToString(CreateAddress(from, uint64(nonce))); got != exp {
+			t.Fatalf("nonce %d: expected %s, got %s", nonce, exp, got)
+		}
+	}
+}
+
+func TestParseBytecode(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{
+		"0x6080604052\n",
+		"6080604052",
+		`{"contractName":"Multicall","bytecode":"0x6080604052"}`,
+		`{"bytecode":{"object":"0x6080604052"}}`,
+	} {
+		code, err := ParseBytecode([]byte(s))
+		if err != nil {
+			t.Fatalf("%q: %v", s, err)
+		}
+		if hex.EncodeToString(code) != "6080604052" {
+			t.Fatalf("%q: unexpected bytecode %x", s, code)
+		}
+	}
+	for _, s := range []string{"", "0x", "zz", `{"abi":[]}`} {
+		if _, err := ParseBytecode([]byte(s)); err == nil {
+			t.Fatalf("%q: expected an error", s)
+		}
+	}
+}
+
+func TestDecodeRLP(t *testing.T) {
+	t.Parallel()
+
+	long := []byte("Lorem ipsum dolor sit amet, consectetur adipisicing elit")
+	for i, v := range []interface{}{
+		[]byte("dog"),
+		[]byte{0x0f},
+		[]byte{},
+		long,
+		[]interface{}{},
+		[]interface{}{[]byte("cat"), []interface{}{[]byte("dog"), long}},
+	} {
+		b := encodeRLP(v)
+		got, err := decodeRLP(b)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if !bytes.Equal(encodeRLP(got), b) {
+			t.Fatalf("#%d: expected %x, got %x", i, b, encodeRLP(got))
+		}
+	}
+	for i, s := range []string{"", "83646f", "c88363617483646f", "8080"} {
+		if _, err := decodeRLP(mustHex(t, s)); !errors.Is(err, ErrInvalidRLP) {
+			t.Fatalf("#%d: expected %v, got %v", i, ErrInvalidRLP, err)
+		}
+	}
+}
+
+func TestParseSignedTx(t *testing.T) {
+	t.Parallel()
+
+	// ref. https://eips.ethereum.org/EIPS/eip-155 (example)
+	raw := mustHex(t, "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a7640000"+
+		"8025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276"+
+		"a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83")
+	tx, from, err := ParseSignedTx(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Nonce != 9 || tx.Gas != 21000 || tx.GasPrice.Uint64() != 20000000000 {
+		t.Fatalf("unexpected tx %+v", tx)
+	}
+	if got := hex.EncodeToString(from); got != "9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f" {
+		t.Fatalf("unexpected sender %s", got)
+	}
+
+	if _, _, err := ParseSignedTx(raw[:len(raw)-1]); !errors.Is(err, ErrInvalidTx) {
+		t.Fatalf("expected %v, got %v", ErrInvalidTx, err)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package faucet implements the client of the public Fuji faucet.
+package faucet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+const DefaultURL = "https://faucet.avax.network"
+
+var (
+	ErrRequestFailed = errors.New("faucet request failed")
+	ErrRPC           = errors.New("rpc error")
+)
+
+// Request is the body of the faucet "sendToken" request.
+type Request struct {
+	Address string `json:"address"`
+	// Chain is the alias of the chain to fund (e.g., "P", "C").
+	Chain string `json:"chain"`
+	// Token is the captcha response token, if required by the faucet.
+	Token string `json:"token,omitempty"`
+}
+
+type Response struct {
+	Message string `json:"message"`
+	TxHash  string `json:"txHash"`
+}
+
+// Send requests test funds from the faucet at [url].
+func Send(ctx context.Context, url string, req Request) (*Response, error) {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(url, "/")+"/api/sendToken", bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	hreq.Header.Set("Content-Type", "application/json")
+	hresp, err := http.DefaultClient.Do(hreq)
+	if err != nil {
+		return nil, err
+	}
+	defer hresp.Body.Close()
+
+	resp := new(Response)
+	if err := json.NewDecoder(hresp.Body).Decode(resp); err != nil {
+		return nil, fmt.Errorf("%w: unexpected response (status %d): %v", ErrRequestFailed, hresp.StatusCode, err)
+	}
+	if hresp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s (status %d)", ErrRequestFailed, resp.Message, hresp.StatusCode)
+	}
+	return resp, nil
+}
+
+// EVMBalance returns the balance in wei of the hex address,
+// via the "eth_getBalance" JSON-RPC call on [rpcURL].
+func EVMBalance(ctx context.Context, rpcURL string, addr string) (*big.Int, error) {
+	b, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "eth_getBalance",
+		"params":  []string{addr, "latest"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodPost, rpcURL, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	hreq.Header.Set("Content-Type", "application/json")
+	hresp, err := http.DefaultClient.Do(hreq)
+	if err != nil {
+		return nil, err
+	}
+	defer hresp.Body.Close()
+
+	var resp struct {
+		Result string `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(hresp.Body).Decode(&resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRPC, resp.Error.Message)
+	}
+	v, ok := new(big.Int).SetString(strings.TrimPrefix(resp.Result, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid balance %q", ErrRPC, resp.Result)
+	}
+	return v, nil
+}
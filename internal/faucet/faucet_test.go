@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package faucet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSend(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/sendToken" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if req.Token == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Captcha verification failed!"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"message":"Transaction successful","txHash":"0xabc"}`))
+	}))
+	defer srv.Close()
+
+	resp, err := Send(context.Background(), srv.URL, Request{Address: "P-fuji1abc", Chain: "P", Token: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.TxHash != "0xabc" {
+		t.Fatalf("unexpected tx hash %q", resp.TxHash)
+	}
+
+	_, err = Send(context.Background(), srv.URL, Request{Address: "P-fuji1abc", Chain: "P"})
+	if !errors.Is(err, ErrRequestFailed) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrRequestFailed)
+	}
+}
+
+func TestEVMBalance(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0xde0b6b3a7640000"}`))
+	}))
+	defer srv.Close()
+
+	v, err := EVMBalance(context.Background(), srv.URL, "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.String() != "1000000000000000000" {
+		t.Fatalf("unexpected balance %s", v)
+	}
+}
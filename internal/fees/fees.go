@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package fees overrides the fee config of the network (ref.
+// "info.getTxFee"), e.g., for the custom networks whose fees differ from
+// the ones reported by the node.
+package fees
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	api_info "github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/utils/json"
+
+	"github.com/ava-labs/subnet-cli/internal/amount"
+)
+
+var ErrInvalidOverride = errors.New("invalid fee override")
+
+// names of the fees of the override
+const (
+	Tx               = "tx"
+	CreateSubnet     = "create-subnet"
+	CreateBlockchain = "create-blockchain"
+)
+
+// Override is the fees to use instead of the ones of the network,
+// by name (e.g., "create-subnet").
+type Override map[string]uint64
+
+// Parse parses the amount of every fee (e.g., "0.001avax"), or the amount
+// of each fee by name (e.g., "tx=1milliavax,create-subnet=0.1avax").
+func Parse(s string) (Override, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	if !strings.Contains(s, "=") {
+		v, err := amount.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidOverride, err)
+		}
+		return Override{Tx: v, CreateSubnet: v, CreateBlockchain: v}, nil
+	}
+	o := make(Override)
+	for _, kv := range strings.Split(s, ",") {
+		i := strings.Index(kv, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %q is not name=amount", ErrInvalidOverride, kv)
+		}
+		name := strings.TrimSpace(kv[:i])
+		switch name {
+		case Tx, CreateSubnet, CreateBlockchain:
+		default:
+			return nil, fmt.Errorf("%w: unknown fee %q (expected %q, %q, or %q)", ErrInvalidOverride, name, Tx, CreateSubnet, CreateBlockchain)
+		}
+		if _, ok := o[name]; ok {
+			return nil, fmt.Errorf("%w: duplicate fee %q", ErrInvalidOverride, name)
+		}
+		v, err := amount.Parse(kv[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidOverride, err)
+		}
+		o[name] = v
+	}
+	return o, nil
+}
+
+// Complete returns true if every fee is overridden, the fee config of the
+// network being unneeded.
+func (o Override) Complete() bool {
+	_, tx := o[Tx]
+	_, subnet := o[CreateSubnet]
+	_, blockchain := o[CreateBlockchain]
+	return tx && subnet && blockchain
+}
+
+// Apply returns the fee config [fi] of the network with the overridden
+// fees. [fi] is nil if unknown, the other fees being zero.
+func (o Override) Apply(fi *api_info.GetTxFeeResponse) *api_info.GetTxFeeResponse {
+	ret := &api_info.GetTxFeeResponse{}
+	if fi != nil {
+		*ret = *fi
+	}
+	if v, ok := o[Tx]; ok {
+		ret.TxFee = json.Uint64(v)
+	}
+	if v, ok := o[CreateSubnet]; ok {
+		ret.CreateSubnetTxFee = json.Uint64(v)
+	}
+	if v, ok := o[CreateBlockchain]; ok {
+		ret.CreateBlockchainTxFee = json.Uint64(v)
+	}
+	return ret
+}
+
+// Above returns the names of the overridden fees greater than [limit],
+// sorted.
+func (o Override) Above(limit uint64) []string {
+	var names []string
+	for name, v := range o {
+		if v > limit {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	api_info "github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		s        string
+		expected Override
+		err      error
+	}{
+		{s: "", expected: nil},
+		{s: "0.001avax", expected: Override{Tx: units.MilliAvax, CreateSubnet: units.MilliAvax, CreateBlockchain: units.MilliAvax}},
+		{s: "tx=1milliavax, create-subnet=0.1avax", expected: Override{Tx: units.MilliAvax, CreateSubnet: 100 * units.MilliAvax}},
+		{s: "create-blockchain=0", expected: Override{CreateBlockchain: 0}},
+		{s: "fee=1avax", err: ErrInvalidOverride},
+		{s: "tx=1avax,tx=2avax", err: ErrInvalidOverride},
+		{s: "tx=1avax,create-subnet", err: ErrInvalidOverride},
+		{s: "abc", err: ErrInvalidOverride},
+	}
+	for i, tv := range tt {
+		o, err := Parse(tv.s)
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+		if tv.err == nil && !reflect.DeepEqual(o, tv.expected) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.expected, o)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	o := Override{CreateSubnet: 5 * units.Avax}
+	if o.Complete() {
+		t.Fatal("expected incomplete override")
+	}
+	fi := o.Apply(&api_info.GetTxFeeResponse{TxFee: 1000, CreateSubnetTxFee: 2000, CreateBlockchainTxFee: 3000})
+	if fi.TxFee != 1000 || uint64(fi.CreateSubnetTxFee) != 5*units.Avax || fi.CreateBlockchainTxFee != 3000 {
+		t.Fatalf("unexpected fees %+v", fi)
+	}
+	if fi := o.Apply(nil); fi.TxFee != 0 || uint64(fi.CreateSubnetTxFee) != 5*units.Avax {
+		t.Fatalf("unexpected fees %+v", fi)
+	}
+	if names := o.Above(units.Avax); !reflect.DeepEqual(names, []string{CreateSubnet}) {
+		t.Fatalf("unexpected fees above the limit %v", names)
+	}
+	if names := o.Above(5 * units.Avax); len(names) != 0 {
+		t.Fatalf("unexpected fees above the limit %v", names)
+	}
+}
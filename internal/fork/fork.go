@@ -0,0 +1,181 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package fork implements the capability matrix of the network upgrades,
+// to build the tx formats accepted by the node.
+package fork
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var (
+	ErrUnsupportedTx     = errors.New("transaction not supported by the network")
+	ErrUnsupportedFormat = errors.New("transaction format not supported by subnet-cli")
+)
+
+// Upgrade is a network upgrade, activated on each network once its nodes
+// run at least [Version].
+type Upgrade struct {
+	Name string
+	// Version is the first node version to implement the upgrade.
+	Version version.Application
+	// Times is the activation time by network. The other networks
+	// (e.g., local) activate it at genesis.
+	Times map[uint32]time.Time
+}
+
+// ref. https://github.com/ava-labs/avalanchego/blob/master/RELEASES.md
+var (
+	// Banff introduces the permissionless validators and the elastic
+	// subnets (e.g., "AddPermissionlessValidatorTx").
+	Banff = Upgrade{
+		Name:    "Banff",
+		Version: version.NewDefaultApplication(constants.PlatformName, 1, 9, 0),
+		Times: map[uint32]time.Time{
+			constants.MainnetID: time.Date(2022, time.October, 18, 16, 0, 0, 0, time.UTC),
+			constants.FujiID:    time.Date(2022, time.October, 3, 14, 0, 0, 0, time.UTC),
+		},
+	}
+	// Durango disables "AddValidatorTx" and "AddDelegatorTx", the primary
+	// network validators requiring a BLS key.
+	Durango = Upgrade{
+		Name:    "Durango",
+		Version: version.NewDefaultApplication(constants.PlatformName, 1, 11, 0),
+		Times: map[uint32]time.Time{
+			constants.MainnetID: time.Date(2024, time.March, 6, 16, 0, 0, 0, time.UTC),
+			constants.FujiID:    time.Date(2024, time.February, 13, 16, 0, 0, 0, time.UTC),
+		},
+	}
+	// Etna replaces the static P-Chain fees with dynamic ones.
+	Etna = Upgrade{
+		Name:    "Etna",
+		Version: version.NewDefaultApplication(constants.PlatformName, 1, 12, 0),
+		Times: map[uint32]time.Time{
+			constants.MainnetID: time.Date(2024, time.December, 16, 17, 0, 0, 0, time.UTC),
+			constants.FujiID:    time.Date(2024, time.November, 25, 16, 0, 0, 0, time.UTC),
+		},
+	}
+)
+
+// Active returns true if the upgrade is activated on the network at [now],
+// as seen by a node of version [v].
+func (u Upgrade) Active(networkID uint32, v version.Application, now time.Time) bool {
+	if v.Before(u.Version) {
+		return false
+	}
+	t, ok := u.Times[networkID]
+	return !ok || !now.Before(t)
+}
+
+// disabledBy maps the tx types built by subnet-cli to the upgrade that
+// stops accepting them.
+var disabledBy = map[string]Upgrade{
+	"AddValidatorTx": Durango,
+	"AddDelegatorTx": Durango,
+}
+
+// enabledBy maps the tx types built by subnet-cli to the upgrade that
+// starts accepting them.
+var enabledBy = map[string]Upgrade{
+	"BaseTx":                    Durango,
+	"RemoveSubnetValidatorTx":   Banff,
+	"TransferSubnetOwnershipTx": Durango,
+}
+
+// replacedBy maps the disabled tx types to the ones to use instead.
+var replacedBy = map[string]string{
+	"AddValidatorTx": "AddPermissionlessValidatorTx",
+	"AddDelegatorTx": "AddPermissionlessDelegatorTx",
+}
+
+// Capabilities are the tx formats accepted by a node of the network.
+type Capabilities struct {
+	NetworkID   uint32
+	NodeVersion version.Application
+	// Active is the activated upgrades.
+	Active []Upgrade
+
+	// PermissionlessValidators is true once Banff is activated, the
+	// node encoding its txs with the types unknown to subnet-cli.
+	PermissionlessValidators bool
+	// RequiresBLS is true once Durango is activated.
+	RequiresBLS bool
+	// DynamicFees is true once Etna is activated, the fees returned by
+	// "info.getTxFee" being a lower bound.
+	DynamicFees bool
+}
+
+// Detect returns the capabilities of the node of version [nodeVersion]
+// (e.g., "avalanche/1.9.0") on the network at [now].
+func Detect(networkID uint32, nodeVersion string, now time.Time) (*Capabilities, error) {
+	v, err := version.VersionParser.Parse(nodeVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse node version %q: %w", nodeVersion, err)
+	}
+	c := &Capabilities{NetworkID: networkID, NodeVersion: v}
+	for _, u := range []Upgrade{Banff, Durango, Etna} {
+		if u.Active(networkID, v, now) {
+			c.Active = append(c.Active, u)
+		}
+	}
+	c.PermissionlessValidators = c.IsActive(Banff)
+	c.RequiresBLS = c.IsActive(Durango)
+	c.DynamicFees = c.IsActive(Etna)
+	return c, nil
+}
+
+// IsActive returns true if the upgrade is activated.
+func (c *Capabilities) IsActive(u Upgrade) bool {
+	for _, a := range c.Active {
+		if a.Name == u.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns an error if the node does not accept the tx type
+// (e.g., "AddValidatorTx").
+func (c *Capabilities) Check(txType string) error {
+	if u, ok := enabledBy[txType]; ok && !c.IsActive(u) {
+		return fmt.Errorf("%w: %s is enabled by %s (%s), and the node runs %s",
+			ErrUnsupportedTx,
+			txType,
+			u.Name,
+			u.Version,
+			c.NodeVersion,
+		)
+	}
+	u, ok := disabledBy[txType]
+	if !ok || !c.IsActive(u) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s is disabled since %s (%s), and the node runs %s; use %s instead",
+		ErrUnsupportedTx,
+		txType,
+		u.Name,
+		u.Version,
+		c.NodeVersion,
+		replacedBy[txType],
+	)
+}
+
+// WrapDecodeError explains the error of decoding a tx issued by the node,
+// which may use the formats introduced by an upgrade.
+func (c *Capabilities) WrapDecodeError(err error) error {
+	if err == nil || !c.PermissionlessValidators {
+		return err
+	}
+	return fmt.Errorf("%w: the node runs %s, whose txs may use the formats of %s (%v)",
+		ErrUnsupportedFormat,
+		c.NodeVersion,
+		Banff.Name,
+		err,
+	)
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fork
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		networkID   uint32
+		nodeVersion string
+		now         time.Time
+
+		permissionless bool
+		bls            bool
+		dynamicFees    bool
+		addValidator   bool
+	}{
+		{
+			networkID:    constants.FujiID,
+			nodeVersion:  "avalanche/1.7.6",
+			now:          time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			addValidator: true,
+		},
+		{
+			// upgraded node, before the activation
+			networkID:    constants.MainnetID,
+			nodeVersion:  "avalanche/1.9.0",
+			now:          time.Date(2022, time.October, 10, 0, 0, 0, 0, time.UTC),
+			addValidator: true,
+		},
+		{
+			networkID:      constants.MainnetID,
+			nodeVersion:    "avalanche/1.10.17",
+			now:            time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC),
+			permissionless: true,
+			addValidator:   true,
+		},
+		{
+			networkID:      constants.FujiID,
+			nodeVersion:    "avalanche/1.11.2",
+			now:            time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			permissionless: true,
+			bls:            true,
+		},
+		{
+			// local networks activate the upgrades at genesis
+			networkID:      constants.LocalID,
+			nodeVersion:    "avalanche/1.12.1",
+			now:            time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			permissionless: true,
+			bls:            true,
+			dynamicFees:    true,
+		},
+	}
+	for i, tv := range tt {
+		c, err := Detect(tv.networkID, tv.nodeVersion, tv.now)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if c.PermissionlessValidators != tv.permissionless || c.RequiresBLS != tv.bls || c.DynamicFees != tv.dynamicFees {
+			t.Fatalf("#%d: unexpected capabilities %+v", i, c)
+		}
+		err = c.Check("AddValidatorTx")
+		if tv.addValidator && err != nil {
+			t.Fatalf("#%d: unexpected error %v", i, err)
+		}
+		if !tv.addValidator && !errors.Is(err, ErrUnsupportedTx) {
+			t.Fatalf("#%d: expected %v, got %v", i, ErrUnsupportedTx, err)
+		}
+		if err := c.Check("AddSubnetValidatorTx"); err != nil {
+			t.Fatalf("#%d: unexpected error %v", i, err)
+		}
+		// only accepted once AddValidatorTx is disabled
+		err = c.Check("BaseTx")
+		if !tv.addValidator && err != nil {
+			t.Fatalf("#%d: unexpected error %v", i, err)
+		}
+		if tv.addValidator && !errors.Is(err, ErrUnsupportedTx) {
+			t.Fatalf("#%d: expected %v, got %v", i, ErrUnsupportedTx, err)
+		}
+	}
+
+	if _, err := Detect(constants.FujiID, "1.9.0", time.Now()); err == nil {
+		t.Fatal("expected error for the version without application")
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNotEVMGenesis       = errors.New("not a Subnet-EVM genesis (missing config)")
+	ErrUnknownPrecompile   = errors.New("unknown precompile")
+	ErrInvalidAddress      = errors.New("invalid EVM address")
+	ErrNoAdmin             = errors.New("precompile requires at least one admin address")
+	ErrDuplicateAddress    = errors.New("duplicate precompile address")
+	ErrEnabledNotAllowList = errors.New("enabled addresses are only for the allow lists")
+	ErrInvalidRewardConfig = errors.New("invalid initial reward config")
+	ErrNegativeTimestamp   = errors.New("negative activation timestamp")
+)
+
+// Precompile is a Subnet-EVM stateful precompile, named by its key in
+// the genesis "config".
+type Precompile string
+
+const (
+	ContractDeployerAllowList Precompile = "contractDeployerAllowListConfig"
+	TxAllowList               Precompile = "txAllowListConfig"
+	FeeConfigManager          Precompile = "feeManagerConfig"
+	NativeMinter              Precompile = "contractNativeMinterConfig"
+	RewardManager             Precompile = "rewardManagerConfig"
+)
+
+// Precompiles are the configurable precompiles, in the order prompted.
+var Precompiles = []Precompile{
+	ContractDeployerAllowList,
+	TxAllowList,
+	FeeConfigManager,
+	NativeMinter,
+	RewardManager,
+}
+
+var precompileNames = map[Precompile]string{
+	ContractDeployerAllowList: "ContractDeployerAllowList",
+	TxAllowList:               "TxAllowList",
+	FeeConfigManager:          "FeeConfigManager",
+	NativeMinter:              "NativeMinter",
+	RewardManager:             "RewardManager",
+}
+
+// Name returns the name of the precompile (e.g., "TxAllowList").
+func (p Precompile) Name() string {
+	if n, ok := precompileNames[p]; ok {
+		return n
+	}
+	return string(p)
+}
+
+// IsAllowList returns true if the precompile restricts the addresses
+// to its enabled ones (besides its admins).
+func (p Precompile) IsAllowList() bool {
+	return p == ContractDeployerAllowList || p == TxAllowList
+}
+
+// ParsePrecompile parses the precompile of the name or the config key
+// (case-insensitive, e.g., "txallowlist" or "txAllowListConfig").
+func ParsePrecompile(s string) (Precompile, error) {
+	for _, p := range Precompiles {
+		if strings.EqualFold(s, p.Name()) || strings.EqualFold(s, string(p)) {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("%w %q", ErrUnknownPrecompile, s)
+}
+
+// RewardConfig is the initial config of the reward manager: the fees are
+// either sent to the coinbase of the block producers, or to one address.
+// Neither burns the fees.
+type RewardConfig struct {
+	AllowFeeRecipients bool   `json:"allowFeeRecipients,omitempty"`
+	RewardAddress      string `json:"rewardAddress,omitempty"`
+}
+
+// PrecompileConfig is the genesis config of a precompile.
+type PrecompileConfig struct {
+	// BlockTimestamp is the activation time in Unix seconds, 0 to
+	// activate the precompile at genesis.
+	BlockTimestamp   int64    `json:"blockTimestamp"`
+	AdminAddresses   []string `json:"adminAddresses"`
+	EnabledAddresses []string `json:"enabledAddresses,omitempty"`
+	// InitialRewardConfig is only for the reward manager, nil to keep
+	// the default fee burning.
+	InitialRewardConfig *RewardConfig `json:"initialRewardConfig,omitempty"`
+}
+
+// Validate checks the addresses of the precompile config.
+func (c PrecompileConfig) Validate(p Precompile) error {
+	if c.BlockTimestamp < 0 {
+		return fmt.Errorf("%s: %w", p.Name(), ErrNegativeTimestamp)
+	}
+	if len(c.AdminAddresses) == 0 {
+		return fmt.Errorf("%s: %w", p.Name(), ErrNoAdmin)
+	}
+	if len(c.EnabledAddresses) > 0 && !p.IsAllowList() {
+		return fmt.Errorf("%s: %w", p.Name(), ErrEnabledNotAllowList)
+	}
+	seen := make(map[string]struct{})
+	for _, addr := range append(append([]string{}, c.AdminAddresses...), c.EnabledAddresses...) {
+		if !evmAddr.MatchString(addr) {
+			return fmt.Errorf("%s: %w %q", p.Name(), ErrInvalidAddress, addr)
+		}
+		k := strings.ToLower(strings.TrimPrefix(addr, "0x"))
+		if _, ok := seen[k]; ok {
+			return fmt.Errorf("%s: %w %q", p.Name(), ErrDuplicateAddress, addr)
+		}
+		seen[k] = struct{}{}
+	}
+	rc := c.InitialRewardConfig
+	switch {
+	case rc == nil:
+	case p != RewardManager:
+		return fmt.Errorf("%s: %w: only for %s", p.Name(), ErrInvalidRewardConfig, RewardManager.Name())
+	case rc.AllowFeeRecipients == (rc.RewardAddress != ""):
+		return fmt.Errorf("%s: %w: set either allowFeeRecipients or rewardAddress", p.Name(), ErrInvalidRewardConfig)
+	case rc.RewardAddress != "" && !evmAddr.MatchString(rc.RewardAddress):
+		return fmt.Errorf("%s: %w %q", p.Name(), ErrInvalidAddress, rc.RewardAddress)
+	}
+	return nil
+}
+
+// ParseAddresses parses the comma-separated EVM addresses, empty for none.
+func ParseAddresses(s string) ([]string, error) {
+	var addrs []string
+	for _, addr := range strings.Split(s, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		if !evmAddr.MatchString(addr) {
+			return nil, fmt.Errorf("%w %q", ErrInvalidAddress, addr)
+		}
+		if !strings.HasPrefix(addr, "0x") {
+			addr = "0x" + addr
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// LoadPrecompiles parses the precompile configs keyed by the precompile
+// names or config keys (e.g., {"txAllowList": {...}}), and validates them.
+func LoadPrecompiles(b []byte) (map[Precompile]PrecompileConfig, error) {
+	var raw map[string]PrecompileConfig
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+	cfgs := make(map[Precompile]PrecompileConfig, len(raw))
+	for k, c := range raw {
+		p, err := ParsePrecompile(k)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.Validate(p); err != nil {
+			return nil, err
+		}
+		cfgs[p] = c
+	}
+	return cfgs, nil
+}
+
+// SetPrecompiles sets the precompile configs in the "config" of the
+// Subnet-EVM genesis, replacing the existing ones of the same precompiles
+// and keeping the rest of the genesis as is.
+func SetPrecompiles(b []byte, cfgs map[Precompile]PrecompileConfig) ([]byte, error) {
+	var g map[string]json.RawMessage
+	if err := json.Unmarshal(b, &g); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGenesis, err)
+	}
+	raw, ok := g["config"]
+	if !ok {
+		return nil, ErrNotEVMGenesis
+	}
+	var cfg map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("%w: config: %v", ErrInvalidGenesis, err)
+	}
+	for p, c := range cfgs {
+		if err := c.Validate(p); err != nil {
+			return nil, err
+		}
+		pb, err := json.Marshal(c)
+		if err != nil {
+			return nil, err
+		}
+		cfg[string(p)] = pb
+	}
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	g["config"] = raw
+	out, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := Validate(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+const (
+	admin   = "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"
+	enabled = "0x0Fa8EA536Be85F32724D57A37758761B86416123"
+)
+
+func TestSetPrecompiles(t *testing.T) {
+	t.Parallel()
+
+	cfgs, err := LoadPrecompiles([]byte(`{
+  "TxAllowList": {"adminAddresses": ["` + admin + `"], "enabledAddresses": ["` + enabled + `"]},
+  "rewardManagerConfig": {"blockTimestamp": 1651363200, "adminAddresses": ["` + admin + `"], "initialRewardConfig": {"allowFeeRecipients": true}}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := SetPrecompiles([]byte(`{"config": {"chainId": 43214, "txAllowListConfig": {"blockTimestamp": 0, "adminAddresses": []}}, "gasLimit": "0x7A1200"}`), cfgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var g struct {
+		Config struct {
+			ChainID       uint64            `json:"chainId"`
+			TxAllowList   *PrecompileConfig `json:"txAllowListConfig"`
+			RewardManager *PrecompileConfig `json:"rewardManagerConfig"`
+			NativeMinter  *PrecompileConfig `json:"contractNativeMinterConfig"`
+		} `json:"config"`
+		GasLimit string `json:"gasLimit"`
+	}
+	if err := json.Unmarshal(b, &g); err != nil {
+		t.Fatal(err)
+	}
+	if g.Config.ChainID != 43214 || g.GasLimit != "0x7A1200" {
+		t.Fatalf("unexpected genesis %s", b)
+	}
+	if tl := g.Config.TxAllowList; tl == nil || len(tl.AdminAddresses) != 1 || len(tl.EnabledAddresses) != 1 {
+		t.Fatalf("unexpected tx allow list %+v", tl)
+	}
+	if rm := g.Config.RewardManager; rm == nil || rm.BlockTimestamp != 1651363200 || !rm.InitialRewardConfig.AllowFeeRecipients {
+		t.Fatalf("unexpected reward manager %+v", rm)
+	}
+	if g.Config.NativeMinter != nil {
+		t.Fatalf("unexpected native minter %+v", g.Config.NativeMinter)
+	}
+
+	if _, err := SetPrecompiles([]byte(`{"alloc": {}}`), cfgs); !errors.Is(err, ErrNotEVMGenesis) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrNotEVMGenesis)
+	}
+}
+
+func TestPrecompileConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		p   Precompile
+		cfg PrecompileConfig
+		err error
+	}{
+		{
+			p:   NativeMinter,
+			cfg: PrecompileConfig{AdminAddresses: []string{admin}},
+		},
+		{
+			p:   FeeConfigManager,
+			cfg: PrecompileConfig{},
+			err: ErrNoAdmin,
+		},
+		{
+			p:   TxAllowList,
+			cfg: PrecompileConfig{AdminAddresses: []string{"0x1234"}},
+			err: ErrInvalidAddress,
+		},
+		{
+			p:   ContractDeployerAllowList,
+			cfg: PrecompileConfig{AdminAddresses: []string{admin}, EnabledAddresses: []string{"0x8DB97C7CECE249C2B98BDC0226CC4C2A57BF52FC"}},
+			err: ErrDuplicateAddress,
+		},
+		{
+			p:   NativeMinter,
+			cfg: PrecompileConfig{AdminAddresses: []string{admin}, EnabledAddresses: []string{enabled}},
+			err: ErrEnabledNotAllowList,
+		},
+		{
+			p:   RewardManager,
+			cfg: PrecompileConfig{AdminAddresses: []string{admin}, InitialRewardConfig: &RewardConfig{AllowFeeRecipients: true, RewardAddress: enabled}},
+			err: ErrInvalidRewardConfig,
+		},
+		{
+			p:   TxAllowList,
+			cfg: PrecompileConfig{AdminAddresses: []string{admin}, InitialRewardConfig: &RewardConfig{RewardAddress: enabled}},
+			err: ErrInvalidRewardConfig,
+		},
+		{
+			p:   TxAllowList,
+			cfg: PrecompileConfig{BlockTimestamp: -1, AdminAddresses: []string{admin}},
+			err: ErrNegativeTimestamp,
+		},
+	}
+	for i, tv := range tt {
+		if err := tv.cfg.Validate(tv.p); !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+	}
+
+	if _, err := ParsePrecompile("minter"); !errors.Is(err, ErrUnknownPrecompile) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrUnknownPrecompile)
+	}
+	addrs, err := ParseAddresses(" " + admin + ",," + enabled[2:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addrs) != 2 || addrs[1] != enabled {
+		t.Fatalf("unexpected addresses %v", addrs)
+	}
+}
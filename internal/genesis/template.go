@@ -0,0 +1,125 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package genesis renders and validates VM genesis files.
+package genesis
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+var (
+	ErrInvalidVar     = errors.New("invalid template variable (expected 'key=value')")
+	ErrDuplicateVar   = errors.New("duplicate template variable")
+	ErrInvalidGenesis = errors.New("invalid genesis")
+)
+
+// ParseVars parses the "key=value" template variables.
+func ParseVars(kvs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(kvs))
+	for _, kv := range kvs {
+		idx := strings.IndexByte(kv, '=')
+		if idx <= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidVar, kv)
+		}
+		k, v := kv[:idx], kv[idx+1:]
+		if _, ok := vars[k]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateVar, k)
+		}
+		vars[k] = v
+	}
+	return vars, nil
+}
+
+// RenderFile renders the Go template at [p] with [vars], and validates
+// the result. Referencing an undefined variable fails the rendering.
+func RenderFile(p string, vars map[string]string) ([]byte, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	return Render(filepath.Base(p), string(b), vars)
+}
+
+// Render renders the Go template [text] with [vars], and validates
+// the result.
+func Render(name string, text string, vars map[string]string) ([]byte, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	buf := bytes.NewBuffer(nil)
+	if err := tmpl.Execute(buf, vars); err != nil {
+		return nil, err
+	}
+	if err := Validate(buf.Bytes()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var evmAddr = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{40}$`)
+
+// Validate checks that the genesis is a JSON object. If it is an EVM
+// genesis (i.e., has a "config"), it also checks the chain ID and the
+// allocation addresses.
+func Validate(b []byte) error {
+	var g map[string]json.RawMessage
+	if err := json.Unmarshal(b, &g); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidGenesis, err)
+	}
+	chainID, err := ChainID(b)
+	if err != nil || chainID == nil {
+		return err
+	}
+
+	if raw, ok := g["alloc"]; ok {
+		var alloc map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &alloc); err != nil {
+			return fmt.Errorf("%w: alloc: %v", ErrInvalidGenesis, err)
+		}
+		for addr := range alloc {
+			if !evmAddr.MatchString(addr) {
+				return fmt.Errorf("%w: alloc address %q", ErrInvalidGenesis, addr)
+			}
+		}
+	}
+	return nil
+}
+
+// ChainID returns the "config.chainId" of the EVM genesis, or nil if the
+// genesis is not an EVM one (i.e., has no "config").
+func ChainID(b []byte) (*big.Int, error) {
+	var g map[string]json.RawMessage
+	if err := json.Unmarshal(b, &g); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGenesis, err)
+	}
+	raw, ok := g["config"]
+	if !ok {
+		return nil, nil
+	}
+
+	var cfg struct {
+		ChainID *json.Number `json:"chainId"`
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("%w: config: %v", ErrInvalidGenesis, err)
+	}
+	if cfg.ChainID == nil {
+		return nil, fmt.Errorf("%w: missing config.chainId", ErrInvalidGenesis)
+	}
+	chainID, ok := new(big.Int).SetString(cfg.ChainID.String(), 10)
+	if !ok || chainID.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: config.chainId %q must be a positive integer", ErrInvalidGenesis, cfg.ChainID.String())
+	}
+	return chainID, nil
+}
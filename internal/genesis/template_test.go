@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const tmpl = `{
+  "config": {"chainId": {{.chainId}}},
+  "alloc": {"{{.airdropAddr}}": {"balance": "0x52B7D2DCC80CD2E4000000"}}
+}`
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	vars, err := ParseVars([]string{"chainId=43214", "airdropAddr=0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Render("genesis", tmpl, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var g struct {
+		Config struct {
+			ChainID uint64 `json:"chainId"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(b, &g); err != nil {
+		t.Fatal(err)
+	}
+	if g.Config.ChainID != 43214 {
+		t.Fatalf("unexpected chain ID %d", g.Config.ChainID)
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Render("genesis", tmpl, map[string]string{"chainId": "1"}); err == nil || !strings.Contains(err.Error(), "airdropAddr") {
+		t.Fatalf("unexpected error %v for a missing variable", err)
+	}
+	if _, err := Render("genesis", tmpl, map[string]string{"chainId": "0", "airdropAddr": "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"}); !errors.Is(err, ErrInvalidGenesis) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidGenesis)
+	}
+	if _, err := Render("genesis", tmpl, map[string]string{"chainId": "1", "airdropAddr": "0xinvalid"}); !errors.Is(err, ErrInvalidGenesis) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidGenesis)
+	}
+	if _, err := Render("genesis", `{"a": {{.a}}`, map[string]string{"a": "1"}); !errors.Is(err, ErrInvalidGenesis) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidGenesis)
+	}
+	for _, kv := range []string{"chainId", "=1"} {
+		if _, err := ParseVars([]string{kv}); !errors.Is(err, ErrInvalidVar) {
+			t.Fatalf("unexpected error %v for %q, expected %v", err, kv, ErrInvalidVar)
+		}
+	}
+	if _, err := ParseVars([]string{"a=1", "a=2"}); !errors.Is(err, ErrDuplicateVar) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrDuplicateVar)
+	}
+}
+
+func TestChainID(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		genesis  string
+		expected int64
+		err      error
+	}{
+		{genesis: `{"config": {"chainId": 43214}}`, expected: 43214},
+		{genesis: `{"a": 1}`},
+		{genesis: `{"config": {}}`, err: ErrInvalidGenesis},
+		{genesis: `{"config": {"chainId": -1}}`, err: ErrInvalidGenesis},
+		{genesis: `[]`, err: ErrInvalidGenesis},
+	}
+	for i, tv := range tt {
+		chainID, err := ChainID([]byte(tv.genesis))
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+		switch {
+		case tv.expected == 0 && chainID != nil:
+			t.Fatalf("#%d: expected no chain ID, got %v", i, chainID)
+		case tv.expected != 0 && (chainID == nil || chainID.Int64() != tv.expected):
+			t.Fatalf("#%d: expected %d, got %v", i, tv.expected, chainID)
+		}
+	}
+}
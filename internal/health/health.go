@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package health evaluates the validator health from the observations
+// of multiple endpoints.
+package health
+
+import "sort"
+
+// Observation is the uptime and connectivity of a validator,
+// as reported by an endpoint.
+type Observation struct {
+	NodeID    string
+	Endpoint  string
+	Uptime    float64
+	Connected bool
+}
+
+// Status is the aggregated health of a validator.
+type Status struct {
+	NodeID string
+	// Uptime is the mean of the reported uptimes.
+	Uptime float64
+	// Connected is the number of endpoints reporting the node as connected.
+	Connected int
+	// Reported is the number of endpoints reporting the node.
+	Reported int
+	Healthy  bool
+}
+
+// Evaluate aggregates the observations by node, sorted by node ID. A node
+// is healthy if its mean uptime is at least [minUptime], and if it is
+// reported as connected by a majority of the endpoints reporting it.
+func Evaluate(obs []Observation, minUptime float64) []Status {
+	byNode := make(map[string]*Status)
+	for _, o := range obs {
+		s, ok := byNode[o.NodeID]
+		if !ok {
+			s = &Status{NodeID: o.NodeID}
+			byNode[o.NodeID] = s
+		}
+		s.Uptime += o.Uptime
+		s.Reported++
+		if o.Connected {
+			s.Connected++
+		}
+	}
+
+	statuses := make([]Status, 0, len(byNode))
+	for _, s := range byNode {
+		s.Uptime /= float64(s.Reported)
+		s.Healthy = s.Uptime >= minUptime && 2*s.Connected > s.Reported
+		statuses = append(statuses, *s)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].NodeID < statuses[j].NodeID
+	})
+	return statuses
+}
+
+// Unhealthy returns the number of unhealthy nodes.
+func Unhealthy(statuses []Status) (n int) {
+	for _, s := range statuses {
+		if !s.Healthy {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import "testing"
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	obs := []Observation{
+		{NodeID: "a", Endpoint: "1", Uptime: 0.9, Connected: true},
+		{NodeID: "a", Endpoint: "2", Uptime: 0.8, Connected: true},
+		{NodeID: "b", Endpoint: "1", Uptime: 0.7, Connected: true},
+		{NodeID: "b", Endpoint: "2", Uptime: 0.8, Connected: true},
+		{NodeID: "c", Endpoint: "1", Uptime: 0.99, Connected: true},
+		{NodeID: "c", Endpoint: "2", Uptime: 0.99, Connected: false},
+	}
+	statuses := Evaluate(obs, 0.8)
+	expected := []struct {
+		nodeID  string
+		healthy bool
+	}{
+		{"a", true},
+		{"b", false}, // mean uptime 0.75
+		{"c", false}, // not connected by a majority
+	}
+	if len(statuses) != len(expected) {
+		t.Fatalf("unexpected statuses %+v", statuses)
+	}
+	for i, s := range statuses {
+		if s.NodeID != expected[i].nodeID || s.Healthy != expected[i].healthy {
+			t.Fatalf("#%d: unexpected status %+v, expected %+v", i, s, expected[i])
+		}
+	}
+	if n := Unhealthy(statuses); n != 2 {
+		t.Fatalf("unexpected unhealthy count %d", n)
+	}
+}
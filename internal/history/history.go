@@ -0,0 +1,177 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package history implements the local history of the committed txs,
+// and the spend report aggregated from it.
+package history
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Entry is a committed tx recorded in the history.
+type Entry struct {
+	Time      time.Time `json:"time"`
+	NetworkID uint32    `json:"networkID"`
+	TxID      string    `json:"txID"`
+	// TxType is the tx type as recorded by the metrics (e.g., "create_subnet").
+	TxType string `json:"txType"`
+	// Fee is the burned AVAX in nAVAX.
+	Fee uint64 `json:"fee"`
+}
+
+// Log records the committed txs in a file, one JSON entry per line.
+type Log struct {
+	path string
+}
+
+// DefaultPath returns the default path of the history file.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".subnet-cli", "history.log"), nil
+}
+
+// Open opens the history of [path], created on the first record.
+func Open(path string) *Log {
+	return &Log{path: path}
+}
+
+// Record appends the entry to the history.
+func (l *Log) Record(e Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Entries returns the entries of the network within [since, until),
+// in the recorded order. A zero [until] has no upper bound.
+func (l *Log) Entries(networkID uint32, since time.Time, until time.Time) ([]Entry, error) {
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var es []Entry
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var e Entry
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("failed to parse %q: %w", l.path, err)
+		}
+		if e.NetworkID != networkID || e.Time.Before(since) {
+			continue
+		}
+		if !until.IsZero() && !e.Time.Before(until) {
+			continue
+		}
+		es = append(es, e)
+	}
+	return es, s.Err()
+}
+
+// Row is a tx of the spend report, with the on-chain data.
+type Row struct {
+	Time   time.Time
+	TxID   string
+	TxType string
+	// SubnetID is empty for the primary network txs.
+	SubnetID string
+	// Fee is the burned AVAX in nAVAX.
+	Fee uint64
+	// Stake is the AVAX locked by the tx in nAVAX.
+	Stake uint64
+}
+
+// Spend is the aggregated expenditure of a subnet.
+type Spend struct {
+	SubnetID string
+	Txs      int
+	Fees     uint64
+	Stake    uint64
+}
+
+// Report is the expenditure over a time range.
+type Report struct {
+	Rows []Row
+	// Total is the expenditure of all the rows, with an empty subnet ID.
+	Total Spend
+	// Subnets is the expenditure per subnet, sorted by subnet ID,
+	// the primary network (empty subnet ID) first.
+	Subnets []Spend
+}
+
+// NewReport aggregates the rows, sorted by time.
+func NewReport(rows []Row) *Report {
+	r := &Report{Rows: rows}
+	sort.SliceStable(r.Rows, func(i, j int) bool { return r.Rows[i].Time.Before(r.Rows[j].Time) })
+
+	subnets := make(map[string]*Spend)
+	for _, row := range r.Rows {
+		s, ok := subnets[row.SubnetID]
+		if !ok {
+			s = &Spend{SubnetID: row.SubnetID}
+			subnets[row.SubnetID] = s
+		}
+		for _, sp := range []*Spend{s, &r.Total} {
+			sp.Txs++
+			sp.Fees += row.Fee
+			sp.Stake += row.Stake
+		}
+	}
+	for _, s := range subnets {
+		r.Subnets = append(r.Subnets, *s)
+	}
+	sort.Slice(r.Subnets, func(i, j int) bool { return r.Subnets[i].SubnetID < r.Subnets[j].SubnetID })
+	return r
+}
+
+// WriteCSV writes the rows of the report, the amounts in nAVAX.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"time", "tx_id", "tx_type", "subnet_id", "fee_navax", "stake_navax"}); err != nil {
+		return err
+	}
+	for _, row := range r.Rows {
+		if err := cw.Write([]string{
+			row.Time.UTC().Format(time.RFC3339),
+			row.TxID,
+			row.TxType,
+			row.SubnetID,
+			strconv.FormatUint(row.Fee, 10),
+			strconv.FormatUint(row.Stake, 10),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
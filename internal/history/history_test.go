@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package history
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEntries(t *testing.T) {
+	t.Parallel()
+
+	l := Open(filepath.Join(t.TempDir(), "history.log"))
+	now := time.Now().UTC().Truncate(time.Second)
+	es, err := l.Entries(1, now.Add(-time.Hour), time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(es) != 0 {
+		t.Fatalf("expected no entry, got %d", len(es))
+	}
+	for _, e := range []Entry{
+		{Time: now.Add(-2 * time.Hour), NetworkID: 1, TxID: "a"},
+		{Time: now.Add(-time.Minute), NetworkID: 1, TxID: "b"},
+		{Time: now, NetworkID: 1, TxID: "c"},
+		{Time: now, NetworkID: 5, TxID: "d"},
+	} {
+		if err := l.Record(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tt := []struct {
+		since time.Time
+		until time.Time
+		txIDs []string
+	}{
+		{since: now.Add(-time.Hour), txIDs: []string{"b", "c"}},
+		{since: now.Add(-3 * time.Hour), until: now, txIDs: []string{"a", "b"}},
+		{since: now.Add(time.Second)},
+	}
+	for i, tv := range tt {
+		es, err := l.Entries(1, tv.since, tv.until)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(es) != len(tv.txIDs) {
+			t.Fatalf("#%d: expected %d entries, got %d", i, len(tv.txIDs), len(es))
+		}
+		for j, e := range es {
+			if e.TxID != tv.txIDs[j] {
+				t.Fatalf("#%d: expected %q, got %q", i, tv.txIDs[j], e.TxID)
+			}
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	r := NewReport([]Row{
+		{Time: now.Add(time.Hour), TxID: "b", TxType: "add_validator", Fee: 0, Stake: 2000},
+		{Time: now, TxID: "a", TxType: "create_subnet", SubnetID: "s", Fee: 100},
+		{Time: now.Add(2 * time.Hour), TxID: "c", TxType: "create_blockchain", SubnetID: "s", Fee: 100},
+	})
+	if r.Total.Txs != 3 || r.Total.Fees != 200 || r.Total.Stake != 2000 {
+		t.Fatalf("unexpected total %+v", r.Total)
+	}
+	expected := []Spend{
+		{Txs: 1, Stake: 2000},
+		{SubnetID: "s", Txs: 2, Fees: 200},
+	}
+	if len(r.Subnets) != len(expected) {
+		t.Fatalf("expected %d subnets, got %d", len(expected), len(r.Subnets))
+	}
+	for i, s := range r.Subnets {
+		if s != expected[i] {
+			t.Fatalf("#%d: expected %+v, got %+v", i, expected[i], s)
+		}
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := r.WriteCSV(buf); err != nil {
+		t.Fatal(err)
+	}
+	csv := `time,tx_id,tx_type,subnet_id,fee_navax,stake_navax
+2024-01-02T00:00:00Z,a,create_subnet,s,100,0
+2024-01-02T01:00:00Z,b,add_validator,,0,2000
+2024-01-02T02:00:00Z,c,create_blockchain,s,100,0
+`
+	if buf.String() != csv {
+		t.Fatalf("expected %q, got %q", csv, buf.String())
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package idconv converts the avalanche IDs between their CB58 and hex
+// formats, and detects the kind of an ID string.
+package idconv
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/mr-tron/base58"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown ID format")
+	ErrBadChecksum   = errors.New("invalid checksum")
+	ErrInvalidLength = errors.New("invalid ID length")
+)
+
+const checksumLen = 4
+
+type Kind string
+
+const (
+	// ID is a 32-byte ID: a subnet, blockchain, tx, asset, or VM ID.
+	ID Kind = "id"
+	// NodeID is a 20-byte ID with the "NodeID-" prefix.
+	NodeID Kind = "node-id"
+	// ShortID is a 20-byte ID without a prefix: a node ID, or the payload
+	// of an address.
+	ShortID Kind = "short-id"
+	// Address is a 20-byte ID of a chain address (e.g., "P-fuji1...").
+	Address Kind = "address"
+)
+
+type Encoding string
+
+const (
+	CB58 Encoding = "cb58"
+	// Hex is the hex of the ID bytes (e.g., a bytes32 of the EVM), with
+	// or without the checksum of the avalanche hex encoding.
+	Hex    Encoding = "hex"
+	Bech32 Encoding = "bech32"
+)
+
+// Parsed is an ID string, decoded.
+type Parsed struct {
+	Kind     Kind
+	Encoding Encoding
+	// Checksum is true if the string had a checksum (e.g., CB58).
+	Checksum bool
+	Bytes    []byte
+	// Chain and HRP are the chain alias and the human readable part of
+	// the addresses (e.g., "P", "fuji").
+	Chain string
+	HRP   string
+}
+
+// Parse detects the kind and the encoding of [s], and decodes it:
+// a "NodeID-" prefixed ID, a chain address, a "0x" prefixed (or 40 and
+// 64 chars long) hex with or without the checksum, or else a CB58 with its
+// checksum. The IDs are 32 bytes long, except the node IDs and the
+// addresses of 20 bytes.
+func Parse(s string) (*Parsed, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, constants.NodeIDPrefix):
+		p, err := parseCB58(strings.TrimPrefix(s, constants.NodeIDPrefix))
+		if err != nil {
+			return nil, err
+		}
+		if len(p.Bytes) != hashing.AddrLen {
+			return nil, fmt.Errorf("%w: node ID of %d bytes", ErrInvalidLength, len(p.Bytes))
+		}
+		p.Kind = NodeID
+		return p, nil
+
+	case strings.Contains(s, "-"):
+		chain, hrp, b, err := formatting.ParseAddress(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnknownFormat, err)
+		}
+		if len(b) != hashing.AddrLen {
+			return nil, fmt.Errorf("%w: address of %d bytes", ErrInvalidLength, len(b))
+		}
+		return &Parsed{Kind: Address, Encoding: Bech32, Bytes: b, Chain: chain, HRP: hrp}, nil
+
+	case strings.HasPrefix(s, "0x") || isHex(s) && (len(s) == 2*hashing.AddrLen || len(s) == 2*hashing.HashLen):
+		b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnknownFormat, err)
+		}
+		p := &Parsed{Encoding: Hex, Bytes: b}
+		switch len(b) {
+		case hashing.HashLen + checksumLen, hashing.AddrLen + checksumLen:
+			p.Bytes, err = verifyChecksum(b)
+			if err != nil {
+				return nil, err
+			}
+			p.Checksum = true
+		}
+		return p, p.setKind()
+
+	default:
+		p, err := parseCB58(s)
+		if err != nil {
+			return nil, err
+		}
+		return p, p.setKind()
+	}
+}
+
+func parseCB58(s string) (*Parsed, error) {
+	b, err := base58.Decode(s)
+	if err != nil || len(s) == 0 {
+		return nil, fmt.Errorf("%w: %q is neither CB58 nor hex", ErrUnknownFormat, s)
+	}
+	b, err = verifyChecksum(b)
+	if err != nil {
+		return nil, err
+	}
+	return &Parsed{Encoding: CB58, Checksum: true, Bytes: b}, nil
+}
+
+func verifyChecksum(b []byte) ([]byte, error) {
+	if len(b) < checksumLen {
+		return nil, fmt.Errorf("%w: missing", ErrBadChecksum)
+	}
+	payload := b[:len(b)-checksumLen]
+	if !bytes.Equal(b[len(payload):], hashing.Checksum(payload, checksumLen)) {
+		return nil, ErrBadChecksum
+	}
+	return payload, nil
+}
+
+func (p *Parsed) setKind() error {
+	switch len(p.Bytes) {
+	case hashing.HashLen:
+		p.Kind = ID
+	case hashing.AddrLen:
+		p.Kind = ShortID
+	default:
+		return fmt.Errorf("%w: %d bytes, expected %d or %d", ErrInvalidLength, len(p.Bytes), hashing.HashLen, hashing.AddrLen)
+	}
+	return nil
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// CB58 returns the ID in CB58 (with its checksum), without any prefix.
+func (p *Parsed) CB58() string {
+	s, _ := formatting.EncodeWithChecksum(formatting.CB58, p.Bytes)
+	return s
+}
+
+// Hex returns the "0x" prefixed hex of the ID bytes, without any checksum
+// (e.g., a bytes32 of the EVM).
+func (p *Parsed) Hex() string {
+	return "0x" + hex.EncodeToString(p.Bytes)
+}
+
+// NodeID returns the ID with the "NodeID-" prefix, or an empty string if
+// not 20 bytes long.
+func (p *Parsed) NodeID() string {
+	if len(p.Bytes) != hashing.AddrLen {
+		return ""
+	}
+	return constants.NodeIDPrefix + p.CB58()
+}
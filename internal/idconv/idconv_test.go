@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package idconv
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	subnetID, err := ids.FromString("24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodeID, err := ids.ShortFromPrefixedString("NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH", constants.NodeIDPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := formatting.FormatAddress("P", constants.FujiHRP, nodeID[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksummed, err := formatting.EncodeWithChecksum(formatting.Hex, subnetID[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	subnetHex := "0x" + strings.ToLower(strings.TrimPrefix(checksummed, "0x")[:64])
+
+	tt := []struct {
+		s        string
+		kind     Kind
+		encoding Encoding
+		cb58     string
+		hex      string
+		err      error
+	}{
+		{s: subnetID.String(), kind: ID, encoding: CB58, cb58: subnetID.String(), hex: subnetHex},
+		{s: subnetHex, kind: ID, encoding: Hex, cb58: subnetID.String(), hex: subnetHex},
+		// without the "0x" prefix
+		{s: subnetHex[2:], kind: ID, encoding: Hex, cb58: subnetID.String(), hex: subnetHex},
+		// with the checksum of the avalanche hex encoding
+		{s: checksummed, kind: ID, encoding: Hex, cb58: subnetID.String(), hex: subnetHex},
+		{s: nodeID.PrefixedString(constants.NodeIDPrefix), kind: NodeID, encoding: CB58, cb58: nodeID.String()},
+		{s: nodeID.String(), kind: ShortID, encoding: CB58, cb58: nodeID.String()},
+		{s: addr, kind: Address, encoding: Bech32, cb58: nodeID.String()},
+		{s: subnetID.String()[:len(subnetID.String())-1] + "2", err: ErrBadChecksum},
+		{s: checksummed[:len(checksummed)-1] + "0", err: ErrBadChecksum},
+		{s: "0x1234", err: ErrInvalidLength},
+		{s: "not-an-id", err: ErrUnknownFormat},
+		{s: "0OIl", err: ErrUnknownFormat},
+	}
+	for i, tv := range tt {
+		p, err := Parse(tv.s)
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+		if tv.err != nil {
+			continue
+		}
+		if p.Kind != tv.kind || p.Encoding != tv.encoding {
+			t.Fatalf("#%d: expected %s in %s, got %s in %s", i, tv.kind, tv.encoding, p.Kind, p.Encoding)
+		}
+		if p.CB58() != tv.cb58 {
+			t.Fatalf("#%d: expected %s, got %s", i, tv.cb58, p.CB58())
+		}
+		if tv.hex != "" && p.Hex() != tv.hex {
+			t.Fatalf("#%d: expected %s, got %s", i, tv.hex, p.Hex())
+		}
+	}
+
+	p, err := Parse(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.NodeID() != nodeID.PrefixedString(constants.NodeIDPrefix) || p.Chain != "P" || p.HRP != constants.FujiHRP {
+		t.Fatalf("unexpected %+v", p)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+
+	"github.com/ava-labs/subnet-cli/internal/audit"
+	"github.com/ava-labs/subnet-cli/internal/codec"
+	"github.com/ava-labs/subnet-cli/internal/txs"
+)
+
+var _ Key = &auditedKey{}
+
+// auditedKey records every signing operation of the key in the audit log.
+type auditedKey struct {
+	Key
+	log *audit.Log
+}
+
+// WithAudit returns the key that records its signing operations in [log].
+// If recording fails, the signing operation returns the error.
+func WithAudit(k Key, log *audit.Log) Key {
+	return &auditedKey{Key: k, log: log}
+}
+
+func (k *auditedKey) Sign(pTx *platformvm.Tx, signers [][]ids.ShortID) error {
+	if err := k.Key.Sign(pTx, signers); err != nil {
+		return err
+	}
+	unsignedBytes, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, &pTx.UnsignedTx)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(unsignedBytes)
+	return k.record(audit.Entry{
+		Op:     audit.OpSignTx,
+		TxType: txs.TypeName(pTx.UnsignedTx),
+		Digest: hex.EncodeToString(digest[:]),
+	}, flatten(signers))
+}
+
+func (k *auditedKey) SignHash(hash []byte, addrs []ids.ShortID) (map[ids.ShortID][]byte, error) {
+	sigs, err := k.Key.SignHash(hash, addrs)
+	if err != nil || len(sigs) == 0 {
+		return sigs, err
+	}
+	signers := make([]ids.ShortID, 0, len(sigs))
+	for addr := range sigs {
+		signers = append(signers, addr)
+	}
+	digest := sha256.Sum256(hash)
+	if err := k.record(audit.Entry{
+		Op:     audit.OpSignHash,
+		Digest: hex.EncodeToString(digest[:]),
+	}, signers); err != nil {
+		return nil, err
+	}
+	return sigs, nil
+}
+
+// record appends the entry with the formatted addresses of [signers]
+// held by the key.
+func (k *auditedKey) record(e audit.Entry, signers []ids.ShortID) error {
+	formatted := make(map[ids.ShortID]string)
+	for i, addr := range k.Key.Addresses() {
+		formatted[addr] = k.Key.P()[i]
+	}
+	seen := make(map[ids.ShortID]struct{})
+	for _, signer := range signers {
+		paddr, ok := formatted[signer]
+		if _, dup := seen[signer]; !ok || dup {
+			continue
+		}
+		seen[signer] = struct{}{}
+		e.Signers = append(e.Signers, paddr)
+	}
+	if err := k.log.Append(e); err != nil {
+		return fmt.Errorf("failed to record the signature in the audit log: %w", err)
+	}
+	return nil
+}
+
+func flatten(signers [][]ids.ShortID) []ids.ShortID {
+	flat := make([]ids.ShortID, 0, len(signers))
+	for _, inputSigners := range signers {
+		flat = append(flat, inputSigners...)
+	}
+	return flat
+}
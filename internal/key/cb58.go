@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// The CB58 encoding of the private keys, on byte slices instead of the
+// strings of "utils/formatting", so that every copy of the key can be
+// zeroed out.
+
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	checksumLen    = 4
+)
+
+var (
+	errInvalidBase58 = errors.New("invalid base58 character")
+	errBadChecksum   = errors.New("invalid input checksum")
+)
+
+// encodeCB58 returns the base58 encoding of [b] with its checksum.
+func encodeCB58(b []byte) []byte {
+	in := make([]byte, 0, len(b)+checksumLen)
+	in = append(in, b...)
+	in = append(in, hashing.Checksum(b, checksumLen)...)
+	defer zero(in)
+
+	zeros := 0
+	for zeros < len(in) && in[zeros] == 0 {
+		zeros++
+	}
+	// log(256) / log(58) < 138 / 100
+	digits := make([]byte, (len(in)-zeros)*138/100+1)
+	defer zero(digits)
+	for _, c := range in[zeros:] {
+		carry := int(c)
+		for j := len(digits) - 1; j >= 0; j-- {
+			carry += int(digits[j]) << 8
+			digits[j] = byte(carry % 58)
+			carry /= 58
+		}
+	}
+	i := 0
+	for i < len(digits) && digits[i] == 0 {
+		i++
+	}
+	out := make([]byte, zeros+len(digits)-i)
+	for j := 0; j < zeros; j++ {
+		out[j] = base58Alphabet[0]
+	}
+	for j, d := range digits[i:] {
+		out[zeros+j] = base58Alphabet[d]
+	}
+	return out
+}
+
+// decodeCB58 returns the bytes of the base58 encoding [s], after checking
+// their checksum.
+func decodeCB58(s []byte) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	// log(58) / log(256) < 733 / 1000
+	b256 := make([]byte, (len(s)-zeros)*733/1000+1)
+	defer zero(b256)
+	for _, c := range s[zeros:] {
+		carry := bytes.IndexByte([]byte(base58Alphabet), c)
+		if carry < 0 {
+			return nil, errInvalidBase58
+		}
+		for j := len(b256) - 1; j >= 0; j-- {
+			carry += int(b256[j]) * 58
+			b256[j] = byte(carry)
+			carry >>= 8
+		}
+	}
+	i := 0
+	for i < len(b256) && b256[i] == 0 {
+		i++
+	}
+	out := make([]byte, zeros+len(b256)-i)
+	copy(out[zeros:], b256[i:])
+	if len(out) < checksumLen {
+		zero(out)
+		return nil, errBadChecksum
+	}
+	b, checksum := out[:len(out)-checksumLen], out[len(out)-checksumLen:]
+	if !bytes.Equal(checksum, hashing.Checksum(b, checksumLen)) {
+		zero(out)
+		return nil, errBadChecksum
+	}
+	zero(checksum)
+	return b, nil
+}
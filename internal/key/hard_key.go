@@ -5,11 +5,11 @@ package key
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/ava-labs/subnet-cli/internal/codec"
 	"github.com/ava-labs/subnet-cli/pkg/color"
+	"github.com/ava-labs/subnet-cli/pkg/prompt"
 
 	ledger "github.com/ava-labs/avalanche-ledger-go"
 	"github.com/ava-labs/avalanchego/ids"
@@ -20,7 +20,6 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
-	"github.com/manifoldco/promptui"
 	"github.com/onsi/ginkgo/v2/formatter"
 	"go.uber.org/zap"
 )
@@ -33,6 +32,8 @@ var _ Key = &HardKey{}
 
 type HardKey struct {
 	l *ledger.Ledger
+	// confirms the retries of the failed ledger actions, nil to not retry
+	prompter prompt.Prompter
 
 	pAddrs       []string
 	shortAddrs   []ids.ShortID
@@ -54,35 +55,38 @@ func parseLedgerErr(err error, fallback string) {
 }
 
 // retriableLedgerAction wraps all Ledger calls to allow the user to try and
-// recover instead of exiting (in case their Ledger locks).
-func retriableLegerAction(f func() error, fallback string) error {
+// recover instead of exiting (in case their Ledger locks). The retries are
+// confirmed with [p], and the first error is returned if [p] is nil (e.g.,
+// with the prompts disabled).
+func retriableLedgerAction(p prompt.Prompter, f func() error, fallback string) error {
 	for {
 		rerr := f()
 		if rerr == nil {
 			return nil
 		}
 		parseLedgerErr(rerr, fallback)
+		if p == nil {
+			return rerr
+		}
 
 		color.Outf("\n{{cyan}}ledger action failed...what now?{{/}}\n")
-		prompt := promptui.Select{
-			Label:  "\n",
-			Stdout: os.Stdout,
-			Items: []string{
-				formatter.F("{{green}}retry{{/}}"),
-				formatter.F("{{red}}exit{{/}}"),
-			},
-		}
-		idx, _, err := prompt.Run()
-		if err != nil || idx == 1 {
+		retry, err := p.Confirm(
+			formatter.F("{{green}}retry{{/}}"),
+			formatter.F("{{red}}exit{{/}}"),
+		)
+		if err != nil || !retry {
 			return rerr
 		}
 	}
 }
 
-func NewHard(networkID uint32) (*HardKey, error) {
-	k := &HardKey{}
+// NewHard connects to the ledger, and derives its addresses on [networkID].
+// The retries of the failed ledger actions are confirmed with [p], if not
+// nil.
+func NewHard(networkID uint32, p prompt.Prompter) (*HardKey, error) {
+	k := &HardKey{prompter: p}
 	color.Outf("{{yellow}}connecting to ledger...{{/}}\n")
-	if err := retriableLegerAction(func() error {
+	if err := retriableLedgerAction(k.prompter, func() error {
 		l, err := ledger.Connect()
 		if err != nil {
 			return err
@@ -95,7 +99,7 @@ func NewHard(networkID uint32) (*HardKey, error) {
 
 	color.Outf("{{yellow}}deriving address from ledger...{{/}}\n")
 	hrp := getHRP(networkID)
-	if err := retriableLegerAction(func() error {
+	if err := retriableLedgerAction(k.prompter, func() error {
 		addrs, err := k.l.Addresses(hrp, numAddresses)
 		if err != nil {
 			return err
@@ -125,6 +129,12 @@ func (h *HardKey) Disconnect() error {
 	return h.l.Disconnect()
 }
 
+// Close disconnects from the ledger. The private key never
+// leaves the device, so there is nothing to zero out.
+func (h *HardKey) Close() error {
+	return h.Disconnect()
+}
+
 func (h *HardKey) P() []string { return h.pAddrs }
 
 func (h *HardKey) Addresses() []ids.ShortID {
@@ -140,7 +150,14 @@ func (h *HardKey) Spends(outputs []*avax.UTXO, opts ...OpOption) (
 	ret.applyOpts(opts)
 
 	for _, out := range outputs {
-		input, txsigners, err := h.spend(out, ret.time)
+		if ret.skip(out) {
+			continue
+		}
+		o, locktime := unlockStakeable(out.Out, ret.time)
+		if locktime > 0 && !ret.stakeableLocked {
+			continue
+		}
+		input, txsigners, err := h.spend(o, ret.time)
 		if err != nil {
 			zap.L().Warn("cannot spend with current key", zap.Error(err))
 			continue
@@ -149,7 +166,7 @@ func (h *HardKey) Spends(outputs []*avax.UTXO, opts ...OpOption) (
 		inputs = append(inputs, &avax.TransferableInput{
 			UTXOID: out.UTXOID,
 			Asset:  out.Asset,
-			In:     input,
+			In:     lockStakeable(input, locktime),
 		})
 		signers = append(signers, txsigners)
 		if ret.targetAmount > 0 &&
@@ -161,14 +178,14 @@ func (h *HardKey) Spends(outputs []*avax.UTXO, opts ...OpOption) (
 	return totalBalanceToSpend, inputs, signers
 }
 
-func (h *HardKey) spend(output *avax.UTXO, time uint64) (
+func (h *HardKey) spend(output verify.Verifiable, time uint64) (
 	input avax.TransferableIn,
 	signers []ids.ShortID,
 	err error,
 ) {
 	// "time" is used to check whether the key owner
 	// is still within the lock time (thus can't spend).
-	inputf, signers, err := h.lspend(output.Out, time)
+	inputf, signers, err := h.lspend(output, time)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -218,6 +235,38 @@ func (h *HardKey) Match(owners *secp256k1fx.OutputOwners, time uint64) ([]uint32
 	return sigs, signers, uint32(len(sigs)) == owners.Threshold
 }
 
+func (h *HardKey) SignHash(hash []byte, addrs []ids.ShortID) (map[ids.ShortID][]byte, error) {
+	indices := make([]uint32, 0, len(addrs))
+	seen := make(map[uint32]struct{}, len(addrs))
+	for _, addr := range addrs {
+		idx, ok := h.shortAddrMap[addr]
+		if !ok {
+			continue
+		}
+		if _, ok := seen[idx]; ok {
+			continue
+		}
+		seen[idx] = struct{}{}
+		indices = append(indices, idx)
+	}
+	sigMap := make(map[ids.ShortID][]byte, len(indices))
+	if len(indices) == 0 {
+		return sigMap, nil
+	}
+
+	var sigs [][]byte
+	if err := retriableLedgerAction(h.prompter, func() (err error) {
+		sigs, err = h.l.SignHash(hash, indices)
+		return err
+	}, "failed to sign hash"); err != nil {
+		return nil, fmt.Errorf("problem generating signatures: %w", err)
+	}
+	for i, idx := range indices {
+		sigMap[h.shortAddrs[idx]] = sigs[i]
+	}
+	return sigMap, nil
+}
+
 // Sign transaction with the Ledger private key
 //
 // This is a slightly modified version of *platformvm.Tx.Sign().
@@ -246,7 +295,7 @@ func (h *HardKey) Sign(pTx *platformvm.Tx, signers [][]ids.ShortID) error {
 	}
 
 	var sigs [][]byte
-	if err := retriableLegerAction(func() error {
+	if err := retriableLedgerAction(h.prompter, func() error {
 		sigs, err = h.l.SignHash(hash, indices)
 		if err != nil {
 			return err
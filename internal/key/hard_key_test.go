@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/subnet-cli/pkg/prompt"
+)
+
+// retryPrompter confirms the first [retries] retries.
+type retryPrompter struct {
+	prompt.Prompter
+	retries int
+	asked   int
+}
+
+func (p *retryPrompter) Confirm(string, string) (bool, error) {
+	p.asked++
+	return p.asked <= p.retries, nil
+}
+
+func TestRetriableLedgerAction(t *testing.T) {
+	t.Parallel()
+
+	errLocked := errors.New("6b0c")
+	tt := []struct {
+		prompter *retryPrompter
+		failures int
+		calls    int
+		err      error
+	}{
+		{prompter: nil, failures: 1, calls: 1, err: errLocked},
+		{prompter: &retryPrompter{retries: 2}, failures: 2, calls: 3},
+		{prompter: &retryPrompter{retries: 1}, failures: 5, calls: 2, err: errLocked},
+	}
+	for i, tv := range tt {
+		var p prompt.Prompter
+		if tv.prompter != nil {
+			p = tv.prompter
+		}
+		calls := 0
+		err := retriableLedgerAction(p, func() error {
+			calls++
+			if calls <= tv.failures {
+				return errLocked
+			}
+			return nil
+		}, "failed")
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+		if calls != tv.calls {
+			t.Fatalf("#%d: expected %d calls, got %d", i, tv.calls, calls)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	ErrInvalidMnemonic       = errors.New("invalid mnemonic")
+	ErrInvalidDerivationPath = errors.New("invalid derivation path")
+	ErrInvalidChildKey       = errors.New("invalid child key")
+)
+
+const (
+	// AvalancheCoinType is the SLIP-44 coin type of AVAX.
+	AvalancheCoinType = 9000
+
+	hardenedOffset = 0x80000000
+)
+
+// secp256k1 curve order
+var curveOrder, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+
+// AvalanchePath returns the BIP-44 derivation path of the external address
+// at [index] in the [account], as used by the Avalanche wallet
+// (i.e., "m/44'/9000'/account'/0/index").
+func AvalanchePath(account uint32, index uint32) []uint32 {
+	return []uint32{
+		44 + hardenedOffset,
+		AvalancheCoinType + hardenedOffset,
+		account + hardenedOffset,
+		0,
+		index,
+	}
+}
+
+// ParseDerivationPath parses the BIP-32 path (e.g., "m/44'/9000'/0'/0/0").
+func ParseDerivationPath(p string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(p), "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("%w %q", ErrInvalidDerivationPath, p)
+	}
+	path := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		offset := uint32(0)
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") {
+			offset = hardenedOffset
+			part = part[:len(part)-1]
+		}
+		i, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || i >= hardenedOffset {
+			return nil, fmt.Errorf("%w %q", ErrInvalidDerivationPath, p)
+		}
+		path = append(path, uint32(i)+offset)
+	}
+	return path, nil
+}
+
+// SeedFromMnemonic returns the BIP-39 seed of the mnemonic.
+// The mnemonic checksum is not verified against the wordlist.
+func SeedFromMnemonic(mnemonic string, passphrase string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, fmt.Errorf("%w: expected 12 to 24 words, got %d", ErrInvalidMnemonic, len(words))
+	}
+	normalized := strings.ToLower(strings.Join(words, " "))
+	return pbkdf2.Key([]byte(normalized), []byte("mnemonic"+passphrase), 2048, 64, sha512.New), nil
+}
+
+// DeriveKey derives the BIP-32 private key at [path] from the [seed].
+func DeriveKey(seed []byte, path []uint32) (*crypto.PrivateKeySECP256K1R, error) {
+	k, _, err := deriveExtended(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return toPrivateKey(k)
+}
+
+// deriveExtended derives the BIP-32 private key and chain code at [path]
+// from the [seed].
+func deriveExtended(seed []byte, path []uint32) (*big.Int, []byte, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	k, c := new(big.Int).SetBytes(sum[:32]), sum[32:]
+	if k.Sign() == 0 || k.Cmp(curveOrder) >= 0 {
+		return nil, nil, ErrInvalidChildKey
+	}
+
+	for _, i := range path {
+		data := make([]byte, 0, 37)
+		if i >= hardenedOffset {
+			data = append(data, 0)
+			data = append(data, serialize(k)...)
+		} else {
+			pk, err := toPrivateKey(k)
+			if err != nil {
+				return nil, nil, err
+			}
+			data = append(data, pk.PublicKey().Bytes()...)
+		}
+		var ser [4]byte
+		binary.BigEndian.PutUint32(ser[:], i)
+		data = append(data, ser[:]...)
+
+		mac := hmac.New(sha512.New, c)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+		il := new(big.Int).SetBytes(sum[:32])
+		if il.Cmp(curveOrder) >= 0 {
+			return nil, nil, ErrInvalidChildKey
+		}
+		k = il.Add(il, k).Mod(il, curveOrder)
+		if k.Sign() == 0 {
+			return nil, nil, ErrInvalidChildKey
+		}
+		c = sum[32:]
+	}
+	return k, c, nil
+}
+
+func serialize(k *big.Int) []byte {
+	b := make([]byte, 32)
+	return k.FillBytes(b)
+}
+
+func toPrivateKey(k *big.Int) (*crypto.PrivateKeySECP256K1R, error) {
+	rpk, err := keyFactory.ToPrivateKey(serialize(k))
+	if err != nil {
+		return nil, err
+	}
+	privKey, ok := rpk.(*crypto.PrivateKeySECP256K1R)
+	if !ok {
+		return nil, ErrInvalidType
+	}
+	return privKey, nil
+}
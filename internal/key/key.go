@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
@@ -34,7 +35,8 @@ type Key interface {
 	//
 	// If target amount is specified, it only uses the
 	// outputs until the total spending is below the target
-	// amount.
+	// amount. If asset ID is specified, it skips the outputs
+	// of the other assets.
 	Spends(outputs []*avax.UTXO, opts ...OpOption) (
 		totalBalanceToSpend uint64,
 		inputs []*avax.TransferableInput,
@@ -42,12 +44,22 @@ type Key interface {
 	)
 	// Sign generates [numSigs] signatures and attaches them to [pTx].
 	Sign(pTx *platformvm.Tx, signers [][]ids.ShortID) error
+	// SignHash signs [hash] with each of [addrs] held by the key,
+	// ignoring the others, and returns the signatures by address.
+	SignHash(hash []byte, addrs []ids.ShortID) (map[ids.ShortID][]byte, error)
+	// Close releases the key, zeroing out the private key bytes it holds.
+	// The parsed key of the underlying libraries cannot be zeroed out, and
+	// is only left to the garbage collector. The key must not be used after
+	// Close.
+	Close() error
 }
 
 type Op struct {
-	time         uint64
-	targetAmount uint64
-	feeDeduct    uint64
+	time            uint64
+	targetAmount    uint64
+	feeDeduct       uint64
+	stakeableLocked bool
+	assetID         ids.ID
 }
 
 type OpOption func(*Op)
@@ -78,6 +90,51 @@ func WithFeeDeduct(fee uint64) OpOption {
 	}
 }
 
+// WithStakeableLocked spends the stakeable outputs still locked at the
+// time (e.g., vested AVAX) with "StakeableLockIn" inputs, only valid to
+// stake. Otherwise, they are skipped.
+func WithStakeableLocked() OpOption {
+	return func(op *Op) {
+		op.stakeableLocked = true
+	}
+}
+
+// WithAssetID only spends the outputs of [assetID] (e.g., AVAX to pay
+// the fees), so the target amount never adds up different assets.
+func WithAssetID(assetID ids.ID) OpOption {
+	return func(op *Op) {
+		op.assetID = assetID
+	}
+}
+
+// skip returns true if [out] is not of the asset to spend.
+func (op *Op) skip(out *avax.UTXO) bool {
+	return op.assetID != ids.Empty && out.AssetID() != op.assetID
+}
+
+// unlockStakeable returns the output locked by the stakeable [out], and
+// its locktime if still locked at [time] (zero otherwise). Other outputs
+// are returned as is.
+func unlockStakeable(out verify.State, time uint64) (verify.State, uint64) {
+	locked, ok := out.(*platformvm.StakeableLockOut)
+	if !ok {
+		return out, 0
+	}
+	if locked.Locktime <= time {
+		return locked.TransferableOut, 0
+	}
+	return locked.TransferableOut, locked.Locktime
+}
+
+// lockStakeable wraps [in] to spend a stakeable output locked until
+// [locktime], if set.
+func lockStakeable(in avax.TransferableIn, locktime uint64) avax.TransferableIn {
+	if locktime == 0 {
+		return in
+	}
+	return &platformvm.StakeableLockIn{Locktime: locktime, TransferableIn: in}
+}
+
 func getHRP(networkID uint32) string {
 	switch networkID {
 	case constants.LocalID:
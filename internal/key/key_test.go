@@ -5,12 +5,20 @@ package key
 
 import (
 	"bytes"
+	"crypto/rand"
 	"errors"
+	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 const (
@@ -116,3 +124,259 @@ func TestNewKey(t *testing.T) {
 		}
 	}
 }
+
+func TestSoftKeyClose(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(
+		fallbackNetworkID,
+		WithPrivateKeyEncoded(EwoqPrivateKey),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Encode() != EwoqPrivateKey {
+		t.Fatalf("unexpected encoded key %q, expected %q", m.Encode(), EwoqPrivateKey)
+	}
+
+	raw := m.Raw()
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw, make([]byte, len(raw))) {
+		t.Fatalf("private key not zeroed out %v", raw)
+	}
+	if m.Encode() != "" {
+		t.Fatalf("encoded private key not cleared %q", m.Encode())
+	}
+	if m.Key() != nil || m.keyChain != nil {
+		t.Fatal("private key not cleared")
+	}
+	if _, err := m.SignHash(make([]byte, 32), m.Addresses()); !errors.Is(err, ErrClosed) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrClosed)
+	}
+}
+
+func TestCB58(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 100; i++ {
+		// with up to 2 leading zeros
+		b := make([]byte, 2+i%40)
+		if _, err := rand.Read(b[i%3:]); err != nil {
+			t.Fatal(err)
+		}
+		expected, err := formatting.EncodeWithChecksum(formatting.CB58, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if enc := encodeCB58(b); string(enc) != expected {
+			t.Fatalf("#%d: expected %q, got %q", i, expected, enc)
+		}
+		dec, err := decodeCB58([]byte(expected))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(dec, b) {
+			t.Fatalf("#%d: expected %x, got %x", i, b, dec)
+		}
+	}
+	if _, err := decodeCB58([]byte(rawEwoqPk + "1")); !errors.Is(err, errBadChecksum) {
+		t.Fatalf("unexpected error %v, expected %v", err, errBadChecksum)
+	}
+	if _, err := decodeCB58([]byte("0OIl")); !errors.Is(err, errInvalidBase58) {
+		t.Fatalf("unexpected error %v, expected %v", err, errInvalidBase58)
+	}
+}
+
+func TestLoadSoftEnv(t *testing.T) {
+	t.Setenv(PrivateKeyEnvVar, EwoqPrivateKey+"\n")
+
+	m, err := LoadSoftEnv(fallbackNetworkID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.P()[0] != ewoqPChainAddr {
+		t.Fatalf("unexpected P-Chain address %q, expected %q", m.P(), ewoqPChainAddr)
+	}
+
+	t.Setenv(PrivateKeyEnvVar, "PrivateKey-invalid-secret")
+	_, err = LoadSoftEnv(fallbackNetworkID)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if strings.Contains(err.Error(), "invalid-secret") {
+		t.Fatalf("error %q leaks the key", err)
+	}
+}
+
+func TestNewWithSeed(t *testing.T) {
+	t.Parallel()
+
+	k1, err := NewWithSeed(fallbackNetworkID, []byte("test-seed"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := NewWithSeed(fallbackNetworkID, []byte("test-seed"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	k3, err := NewWithSeed(fallbackNetworkID, []byte("other-seed"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(k1.Raw(), k2.Raw()) {
+		t.Fatal("same seed produced different keys")
+	}
+	if bytes.Equal(k1.Raw(), k3.Raw()) {
+		t.Fatal("different seeds produced the same key")
+	}
+	if _, err := NewWithSeed(fallbackNetworkID, nil); !errors.Is(err, ErrEmptySeed) {
+		t.Fatalf("expected %v, got %v", ErrEmptySeed, err)
+	}
+}
+
+func TestSpendsStakeableLocked(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(fallbackNetworkID, WithPrivateKeyEncoded(EwoqPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	owners := secp256k1fx.OutputOwners{Threshold: 1, Addrs: m.Addresses()}
+	stakeable := func(idx uint32, locktime uint64) *avax.UTXO {
+		return &avax.UTXO{
+			UTXOID: avax.UTXOID{OutputIndex: idx},
+			Out: &platformvm.StakeableLockOut{
+				Locktime:        locktime,
+				TransferableOut: &secp256k1fx.TransferOutput{Amt: 10, OutputOwners: owners},
+			},
+		}
+	}
+	utxos := []*avax.UTXO{
+		{UTXOID: avax.UTXOID{OutputIndex: 0}, Out: &secp256k1fx.TransferOutput{Amt: 1, OutputOwners: owners}},
+		stakeable(1, 50),  // unlocked at 100
+		stakeable(2, 200), // still locked at 100
+	}
+
+	tt := []struct {
+		opts    []OpOption
+		total   uint64
+		indexes []uint32
+		locked  []bool
+	}{
+		{
+			opts:    []OpOption{WithTime(100)},
+			total:   11,
+			indexes: []uint32{0, 1},
+			locked:  []bool{false, false},
+		},
+		{
+			opts:    []OpOption{WithTime(100), WithStakeableLocked()},
+			total:   21,
+			indexes: []uint32{0, 1, 2},
+			locked:  []bool{false, false, true},
+		},
+		{
+			opts:    []OpOption{WithTime(300)},
+			total:   21,
+			indexes: []uint32{0, 1, 2},
+			locked:  []bool{false, false, false},
+		},
+	}
+	for i, tv := range tt {
+		total, ins, signers := m.Spends(utxos, tv.opts...)
+		if total != tv.total {
+			t.Fatalf("#%d: expected total %d, got %d", i, tv.total, total)
+		}
+		if len(ins) != len(tv.indexes) || len(signers) != len(tv.indexes) {
+			t.Fatalf("#%d: expected %d inputs, got %d", i, len(tv.indexes), len(ins))
+		}
+		for j, in := range ins {
+			if in.OutputIndex != tv.indexes[j] {
+				t.Fatalf("#%d: expected output index %d, got %d", i, tv.indexes[j], in.OutputIndex)
+			}
+			lin, locked := in.In.(*platformvm.StakeableLockIn)
+			if locked != tv.locked[j] {
+				t.Fatalf("#%d: expected locked input %v, got %v", i, tv.locked[j], locked)
+			}
+			if locked && lin.Locktime != 200 {
+				t.Fatalf("#%d: expected locktime 200, got %d", i, lin.Locktime)
+			}
+			if _, ok := in.In.(*secp256k1fx.TransferInput); !ok && !locked {
+				t.Fatalf("#%d: unexpected input type %T", i, in.In)
+			}
+		}
+	}
+}
+
+func TestSpendsAssetID(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(fallbackNetworkID, WithPrivateKeyEncoded(EwoqPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	owners := secp256k1fx.OutputOwners{Threshold: 1, Addrs: m.Addresses()}
+	avaxID, otherID := ids.GenerateTestID(), ids.GenerateTestID()
+	utxos := []*avax.UTXO{
+		{UTXOID: avax.UTXOID{OutputIndex: 0}, Asset: avax.Asset{ID: otherID}, Out: &secp256k1fx.TransferOutput{Amt: 100, OutputOwners: owners}},
+		{UTXOID: avax.UTXOID{OutputIndex: 1}, Asset: avax.Asset{ID: avaxID}, Out: &secp256k1fx.TransferOutput{Amt: 1, OutputOwners: owners}},
+	}
+
+	tt := []struct {
+		assetID ids.ID
+		total   uint64
+		inputs  int
+	}{
+		{assetID: ids.Empty, total: 101, inputs: 2},
+		{assetID: avaxID, total: 1, inputs: 1},
+		{assetID: otherID, total: 100, inputs: 1},
+	}
+	for i, tv := range tt {
+		total, ins, _ := m.Spends(utxos, WithAssetID(tv.assetID))
+		if total != tv.total {
+			t.Fatalf("#%d: expected total %d, got %d", i, tv.total, total)
+		}
+		if len(ins) != tv.inputs {
+			t.Fatalf("#%d: expected %d inputs, got %d", i, tv.inputs, len(ins))
+		}
+		for _, in := range ins {
+			if tv.assetID != ids.Empty && in.AssetID() != tv.assetID {
+				t.Fatalf("#%d: expected asset %s, got %s", i, tv.assetID, in.AssetID())
+			}
+		}
+	}
+}
+
+func TestSeededKeyFile(t *testing.T) {
+	t.Parallel()
+
+	seeded, err := NewWithSeed(fallbackNetworkID, []byte("test-seed"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := filepath.Join(t.TempDir(), "seeded.pk")
+	if err := seeded.Save(p); err != nil {
+		t.Fatal(err)
+	}
+	m, err := LoadSoft(constants.LocalID, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.insecure || !bytes.Equal(m.Raw(), seeded.Raw()) {
+		t.Fatal("seeded key not loaded as insecure")
+	}
+	if _, err := LoadSoft(constants.MainnetID, p); !errors.Is(err, ErrInsecureMainnet) {
+		t.Fatalf("expected %v, got %v", ErrInsecureMainnet, err)
+	}
+
+	p = filepath.Join(t.TempDir(), "invalid.pk")
+	b := append(bytes.Repeat([]byte("a"), privKeySize), "\n# not the marker\n"...)
+	if err := ioutil.WriteFile(p, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSoft(constants.LocalID, p); !errors.Is(err, ErrInvalidPrivateKeyEnding) {
+		t.Fatalf("expected %v, got %v", ErrInvalidPrivateKeyEnding, err)
+	}
+}
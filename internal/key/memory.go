@@ -0,0 +1,35 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"go.uber.org/zap"
+)
+
+// zero overwrites the secret material in [b] in place.
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// lock prevents the pages holding [b] from being swapped to disk, where the
+// platform permits. Failures (e.g., RLIMIT_MEMLOCK exceeded) are not fatal.
+func lock(b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	if err := mlock(b); err != nil {
+		zap.L().Debug("failed to lock key material in memory", zap.Error(err))
+	}
+}
+
+func unlock(b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	if err := munlock(b); err != nil {
+		zap.L().Debug("failed to unlock key material in memory", zap.Error(err))
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly
+
+package key
+
+// locked memory is not supported on this platform
+func mlock([]byte) error   { return nil }
+func munlock([]byte) error { return nil }
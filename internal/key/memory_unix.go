@@ -0,0 +1,14 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+// +build linux darwin freebsd netbsd openbsd dragonfly
+
+package key
+
+import (
+	"syscall"
+)
+
+func mlock(b []byte) error   { return syscall.Mlock(b) }
+func munlock(b []byte) error { return syscall.Munlock(b) }
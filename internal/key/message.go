@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// messagePrefix is prepended to the signed messages, not to be mistaken
+// for a tx (ref. "digestMessage" of the Avalanche wallet).
+const messagePrefix = "\x1AAvalanche Signed Message:\n"
+
+var (
+	ErrInvalidMessageSignature = errors.New("invalid message signature")
+	ErrMessageSignerMismatch   = errors.New("message not signed by the address")
+)
+
+// MessageHash returns the hash signed for [msg]: the SHA-256 of the prefix,
+// the big-endian uint32 length of the message, and the message.
+func MessageHash(msg []byte) []byte {
+	b := make([]byte, 0, len(messagePrefix)+4+len(msg))
+	b = append(b, messagePrefix...)
+	size := make([]byte, 4)
+	binary.BigEndian.PutUint32(size, uint32(len(msg)))
+	b = append(b, size...)
+	b = append(b, msg...)
+	return hashing.ComputeHash256(b)
+}
+
+// SignMessage signs [msg] with the address [addr] held by the key, and
+// returns the recoverable signature ([r || s || v]).
+func SignMessage(k Key, addr ids.ShortID, msg []byte) ([]byte, error) {
+	sigs, err := k.SignHash(MessageHash(msg), []ids.ShortID{addr})
+	if err != nil {
+		return nil, err
+	}
+	sig, ok := sigs[addr]
+	if !ok {
+		return nil, fmt.Errorf("%w: key does not hold %s", ErrCantSpend, addr)
+	}
+	return sig, nil
+}
+
+// RecoverMessageSigner returns the address that signed [msg] with [sig].
+func RecoverMessageSigner(msg []byte, sig []byte) (ids.ShortID, error) {
+	if len(sig) != crypto.SECP256K1RSigLen {
+		return ids.ShortEmpty, fmt.Errorf("%w: %d bytes, expected %d", ErrInvalidMessageSignature, len(sig), crypto.SECP256K1RSigLen)
+	}
+	pub, err := keyFactory.RecoverHashPublicKey(MessageHash(msg), sig)
+	if err != nil {
+		return ids.ShortEmpty, fmt.Errorf("%w: %v", ErrInvalidMessageSignature, err)
+	}
+	return pub.Address(), nil
+}
+
+// VerifyMessage returns an error if [msg] was not signed by [addr].
+func VerifyMessage(addr ids.ShortID, msg []byte, sig []byte) error {
+	signer, err := RecoverMessageSigner(msg, sig)
+	if err != nil {
+		return err
+	}
+	if signer != addr {
+		return fmt.Errorf("%w: signed by %s", ErrMessageSignerMismatch, signer)
+	}
+	return nil
+}
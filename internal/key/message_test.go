@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestSignMessage(t *testing.T) {
+	t.Parallel()
+
+	k, err := NewSoft(constants.FujiID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer k.Close()
+	addr := k.Addresses()[0]
+
+	msg := []byte("I control this subnet")
+	sig, err := SignMessage(k, addr, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyMessage(addr, msg, sig); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyMessage(addr, []byte("I control this subnet!"), sig); !errors.Is(err, ErrMessageSignerMismatch) {
+		t.Fatalf("expected %v, got %v", ErrMessageSignerMismatch, err)
+	}
+	if err := VerifyMessage(addr, msg, sig[:64]); !errors.Is(err, ErrInvalidMessageSignature) {
+		t.Fatalf("expected %v, got %v", ErrInvalidMessageSignature, err)
+	}
+	if _, err := SignMessage(k, ids.GenerateTestShortID(), msg); !errors.Is(err, ErrCantSpend) {
+		t.Fatalf("expected %v, got %v", ErrCantSpend, err)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var ErrInvalidGapLimit = errors.New("invalid gap limit")
+
+// DefaultGapLimit is the BIP-44 number of consecutive unused addresses
+// after which the scan stops.
+const DefaultGapLimit = 20
+
+// ScanResult is an active address found by [Scan].
+type ScanResult struct {
+	Account uint32
+	Index   uint32
+	Address ids.ShortID
+	// Balance is as reported by the activity function.
+	Balance uint64
+}
+
+// Path returns the derivation path of the address.
+func (r ScanResult) Path() string {
+	return fmt.Sprintf("m/44'/%d'/%d'/0/%d", AvalancheCoinType, r.Account, r.Index)
+}
+
+// Activity returns the balances of the active addresses among [addrs].
+// The addresses without activity must be omitted.
+type Activity func(addrs []ids.ShortID) (map[ids.ShortID]uint64, error)
+
+// Scan derives the external addresses of the successive accounts of [seed]
+// (i.e., "m/44'/9000'/account'/0/index"), and returns the active ones.
+// Within an account, the scan stops after [gapLimit] consecutive inactive
+// addresses. As per BIP-44, the account discovery stops at the first
+// account without any active address. The addresses are checked in
+// batches of [gapLimit].
+func Scan(seed []byte, gapLimit uint32, activity Activity) ([]ScanResult, error) {
+	if gapLimit == 0 {
+		return nil, ErrInvalidGapLimit
+	}
+	results := make([]ScanResult, 0)
+	for account := uint32(0); account < hardenedOffset; account++ {
+		found, err := scanAccount(seed, account, gapLimit, activity)
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 {
+			return results, nil
+		}
+		results = append(results, found...)
+	}
+	return results, nil
+}
+
+func scanAccount(seed []byte, account uint32, gapLimit uint32, activity Activity) ([]ScanResult, error) {
+	found := make([]ScanResult, 0)
+	// the next index to derive, and the index after the last active one
+	next, end := uint32(0), gapLimit
+	for next < end {
+		addrs := make([]ids.ShortID, 0, end-next)
+		for index := next; index < end; index++ {
+			privKey, err := DeriveKey(seed, AvalanchePath(account, index))
+			if err != nil {
+				return nil, err
+			}
+			addrs = append(addrs, privKey.PublicKey().Address())
+		}
+		balances, err := activity(addrs)
+		if err != nil {
+			return nil, err
+		}
+		for i, addr := range addrs {
+			balance, ok := balances[addr]
+			if !ok {
+				continue
+			}
+			index := next + uint32(i)
+			found = append(found, ScanResult{
+				Account: account,
+				Index:   index,
+				Address: addr,
+				Balance: balance,
+			})
+			if index+1+gapLimit > end {
+				end = index + 1 + gapLimit
+			}
+		}
+		next += uint32(len(addrs))
+	}
+	return found, nil
+}
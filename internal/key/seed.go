@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+var ErrEmptySeed = errors.New("empty seed")
+
+const (
+	// seedDomain separates the seeded keys from any other use of the seed.
+	seedDomain = "subnet-cli insecure test key"
+	// insecureMarker is the line saved after a seeded key, for the key to
+	// be refused on Mainnet once loaded.
+	insecureMarker = "# INSECURE: derived from a seed, never use on Mainnet"
+)
+
+// NewWithSeed creates the SoftKey deterministically derived from [seed],
+// for reproducible keys in tests and local networks. Anyone who knows or
+// guesses the seed can spend the funds, so it must never be used to hold
+// real funds, and is refused on Mainnet once saved and loaded.
+func NewWithSeed(networkID uint32, seed []byte) (*SoftKey, error) {
+	if len(seed) == 0 {
+		return nil, ErrEmptySeed
+	}
+	// re-hash with a counter in the unlikely case of an invalid scalar
+	for counter := uint32(0); ; counter++ {
+		h := sha256.New()
+		h.Write([]byte(seedDomain))
+		h.Write(seed)
+		var c [4]byte
+		binary.BigEndian.PutUint32(c[:], counter)
+		h.Write(c[:])
+		sum := h.Sum(nil)
+
+		k := new(big.Int).SetBytes(sum)
+		if k.Sign() == 0 || k.Cmp(curveOrder) >= 0 {
+			continue
+		}
+		privKey, err := toPrivateKey(k)
+		zero(sum)
+		if err != nil {
+			return nil, err
+		}
+		m, err := NewSoft(networkID, WithPrivateKey(privKey))
+		if err != nil {
+			return nil, err
+		}
+		m.insecure = true
+		return m, nil
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// signWorkers is the max number of signatures computed concurrently.
+var signWorkers = runtime.NumCPU()
+
+// signHash computes the signature of [hash] by each of [privKeys],
+// with at most [workers] signatures computed concurrently.
+func signHash(hash []byte, privKeys map[ids.ShortID]*crypto.PrivateKeySECP256K1R, workers int) (map[ids.ShortID][]byte, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	type job struct {
+		addr    ids.ShortID
+		privKey *crypto.PrivateKeySECP256K1R
+	}
+	jobs := make(chan job)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		sigs     = make(map[ids.ShortID][]byte, len(privKeys))
+		firstErr error
+	)
+	for i := 0; i < workers && i < len(privKeys); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				sig, err := j.privKey.SignHash(hash)
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				sigs[j.addr] = sig
+				mu.Unlock()
+			}
+		}()
+	}
+	for addr, privKey := range privKeys {
+		jobs <- job{addr: addr, privKey: privKey}
+	}
+	close(jobs)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return sigs, nil
+}
+
+// signTx signs [pTx] with [privKeys], which must hold all [signers].
+// Unlike "platformvm.Tx.Sign", which computes a signature per input signer
+// serially, each unique signer signs once, concurrently with the others.
+func signTx(pTx *platformvm.Tx, signers [][]ids.ShortID, privKeys map[ids.ShortID]*crypto.PrivateKeySECP256K1R) error {
+	unsignedBytes, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, &pTx.UnsignedTx)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
+	}
+	used := make(map[ids.ShortID]*crypto.PrivateKeySECP256K1R)
+	for _, inputSigners := range signers {
+		for _, signer := range inputSigners {
+			privKey, ok := privKeys[signer]
+			if !ok {
+				// Should never happen
+				return ErrCantSpend
+			}
+			used[signer] = privKey
+		}
+	}
+	sigs, err := signHash(hashing.ComputeHash256(unsignedBytes), used, signWorkers)
+	if err != nil {
+		return err
+	}
+
+	for _, inputSigners := range signers {
+		cred := &secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(inputSigners)),
+		}
+		for i, signer := range inputSigners {
+			copy(cred.Sigs[i][:], sigs[signer])
+		}
+		pTx.Creds = append(pTx.Creds, cred)
+	}
+	signedBytes, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, pTx)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal signed tx: %w", err)
+	}
+	pTx.Initialize(unsignedBytes, signedBytes)
+	return nil
+}
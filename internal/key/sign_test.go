@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// newTestTx returns an unsigned tx spending [n] inputs, each signed by
+// [addr], along with the matching signers.
+func newTestTx(n int, addr ids.ShortID) (*platformvm.Tx, [][]ids.ShortID) {
+	ins := make([]*avax.TransferableInput, n)
+	signers := make([][]ids.ShortID, n)
+	for i := range ins {
+		ins[i] = &avax.TransferableInput{
+			UTXOID: avax.UTXOID{TxID: ids.Empty.Prefix(uint64(i))},
+			Asset:  avax.Asset{ID: ids.Empty},
+			In: &secp256k1fx.TransferInput{
+				Amt:   1,
+				Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+		}
+		signers[i] = []ids.ShortID{addr}
+	}
+	utx := &platformvm.UnsignedCreateSubnetTx{
+		BaseTx: platformvm.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID: fallbackNetworkID,
+			Ins:       ins,
+		}},
+		Owner: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr}},
+	}
+	return &platformvm.Tx{UnsignedTx: utx}, signers
+}
+
+func newTestPrivKeys(t testing.TB, n int) map[ids.ShortID]*crypto.PrivateKeySECP256K1R {
+	factory := &crypto.FactorySECP256K1R{}
+	keys := make(map[ids.ShortID]*crypto.PrivateKeySECP256K1R, n)
+	for i := 0; i < n; i++ {
+		rpk, err := factory.NewPrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		privKey := rpk.(*crypto.PrivateKeySECP256K1R)
+		keys[privKey.PublicKey().Address()] = privKey
+	}
+	return keys
+}
+
+func TestSoftKeySignMatchesSerial(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(fallbackNetworkID, WithPrivateKeyEncoded(EwoqPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := m.Addresses()[0]
+
+	pTx, signers := newTestTx(64, addr)
+	if err := m.Sign(pTx, signers); err != nil {
+		t.Fatal(err)
+	}
+
+	serialTx, _ := newTestTx(64, addr)
+	privsigners := make([][]*crypto.PrivateKeySECP256K1R, len(signers))
+	for i := range signers {
+		privsigners[i] = []*crypto.PrivateKeySECP256K1R{m.Key()}
+	}
+	if err := serialTx.Sign(codec.PCodecManager, privsigners); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(pTx.Bytes(), serialTx.Bytes()) {
+		t.Fatal("concurrently signed tx differs from serially signed tx")
+	}
+	if pTx.ID() != serialTx.ID() {
+		t.Fatalf("unexpected tx ID %s, expected %s", pTx.ID(), serialTx.ID())
+	}
+}
+
+func TestSoftKeySignWrongSigner(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(fallbackNetworkID, WithPrivateKeyEncoded(EwoqPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pTx, signers := newTestTx(2, ids.ShortEmpty)
+	if err := m.Sign(pTx, signers); err != ErrCantSpend {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrCantSpend)
+	}
+}
+
+func TestSignHash(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestPrivKeys(t, 16)
+	hash := hashing.ComputeHash256([]byte("hello"))
+	for _, workers := range []int{0, 1, 4, 32} {
+		sigs, err := signHash(hash, keys, workers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sigs) != len(keys) {
+			t.Fatalf("workers %d: unexpected %d signatures, expected %d", workers, len(sigs), len(keys))
+		}
+		for addr, privKey := range keys {
+			if !privKey.PublicKey().VerifyHash(hash, sigs[addr]) {
+				t.Fatalf("workers %d: invalid signature for %s", workers, addr)
+			}
+		}
+	}
+}
+
+func BenchmarkSoftKeySign(b *testing.B) {
+	m, err := NewSoft(fallbackNetworkID, WithPrivateKeyEncoded(EwoqPrivateKey))
+	if err != nil {
+		b.Fatal(err)
+	}
+	addr := m.Addresses()[0]
+	for _, n := range []int{1, 100, 1000} {
+		b.Run(fmt.Sprintf("inputs=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				pTx, signers := newTestTx(n, addr)
+				b.StartTimer()
+				if err := m.Sign(pTx, signers); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSignHash(b *testing.B) {
+	keys := newTestPrivKeys(b, 64)
+	hash := hashing.ComputeHash256([]byte("hello"))
+	for _, workers := range []int{1, signWorkers} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := signHash(hash, keys, workers); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -10,17 +10,20 @@ import (
 	"errors"
 	"io"
 	"io/ioutil"
+	"os"
 	"strings"
 
-	"github.com/ava-labs/subnet-cli/internal/codec"
-
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/internal/keystore"
 )
 
 var (
@@ -28,18 +31,25 @@ var (
 	ErrInvalidPrivateKeyLen      = errors.New("invalid private key length (expect 64 bytes in hex)")
 	ErrInvalidPrivateKeyEnding   = errors.New("invalid private key ending")
 	ErrInvalidPrivateKeyEncoding = errors.New("invalid private key encoding")
+	ErrClosed                    = errors.New("private key is closed")
+	ErrInsecureMainnet           = errors.New("refusing the insecure seeded key on Mainnet")
 )
 
 var _ Key = &SoftKey{}
 
 type SoftKey struct {
-	privKey        *crypto.PrivateKeySECP256K1R
-	privKeyRaw     []byte
-	privKeyEncoded string
+	privKey    *crypto.PrivateKeySECP256K1R
+	privKeyRaw []byte
+	// kept in bytes (not string) so that it can be zeroed out on Close
+	privKeyEncoded []byte
 
 	pAddr string
+	addr  ids.ShortID
 
 	keyChain *secp256k1fx.Keychain
+
+	// true if derived from a seed (ref. "NewWithSeed")
+	insecure bool
 }
 
 const (
@@ -111,14 +121,12 @@ func NewSoft(networkID uint32, opts ...SOpOption) (*SoftKey, error) {
 	}
 
 	privKey := ret.privKey
-	privKeyEncoded, err := encodePrivateKey(ret.privKey)
-	if err != nil {
-		return nil, err
-	}
+	privKeyEncoded := encodePrivateKey(ret.privKey)
 
 	// double-check encoding is consistent
 	if ret.privKeyEncoded != "" &&
-		ret.privKeyEncoded != privKeyEncoded {
+		ret.privKeyEncoded != string(privKeyEncoded) {
+		zero(privKeyEncoded)
 		return nil, ErrInvalidPrivateKeyEncoding
 	}
 
@@ -132,10 +140,14 @@ func NewSoft(networkID uint32, opts ...SOpOption) (*SoftKey, error) {
 
 		keyChain: keyChain,
 	}
+	lock(m.privKeyRaw)
+	lock(m.privKeyEncoded)
 
 	// Parse HRP to create valid address
 	hrp := getHRP(networkID)
-	m.pAddr, err = formatting.FormatAddress("P", hrp, m.privKey.PublicKey().Address().Bytes())
+	var err error
+	m.addr = m.privKey.PublicKey().Address()
+	m.pAddr, err = formatting.FormatAddress("P", hrp, m.addr.Bytes())
 	if err != nil {
 		return nil, err
 	}
@@ -143,21 +155,74 @@ func NewSoft(networkID uint32, opts ...SOpOption) (*SoftKey, error) {
 	return m, nil
 }
 
-// LoadSoft loads the private key from disk and creates the corresponding SoftKey.
+const (
+	// PrivateKeyEnvVar is the environment variable to load the private key
+	// from, without writing it to disk (e.g., CI secrets).
+	PrivateKeyEnvVar = "SUBNET_CLI_PRIVATE_KEY"
+	// StdinPath is the key path to read the private key from stdin.
+	StdinPath = "-"
+)
+
+// LoadSoft loads the private key and creates the corresponding SoftKey.
+// The key is read from stdin if [keyPath] is "-", or else from the
+// key store of [keyPath] (e.g., a file, or "keychain://subnet-cli/ops").
 func LoadSoft(networkID uint32, keyPath string) (*SoftKey, error) {
-	kb, err := ioutil.ReadFile(keyPath)
+	var (
+		kb  []byte
+		err error
+	)
+	if keyPath == StdinPath {
+		kb, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		var b keystore.Backend
+		b, err = keystore.Open(keyPath)
+		if err == nil {
+			kb, err = b.Load()
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
+	return loadSoftBytes(networkID, kb)
+}
+
+// LoadSoftEnv loads the private key of the [PrivateKeyEnvVar] environment
+// variable.
+func LoadSoftEnv(networkID uint32) (*SoftKey, error) {
+	zap.L().Info("loading private key from the environment", zap.String("name", PrivateKeyEnvVar))
+	return loadSoftBytes(networkID, []byte(os.Getenv(PrivateKeyEnvVar)))
+}
+
+// loadSoftBytes parses the key of [kb], and zeroes [kb] out. The seeded
+// keys are refused on Mainnet.
+func loadSoftBytes(networkID uint32, kb []byte) (*SoftKey, error) {
+	defer zero(kb)
+
+	k, err := loadSoft(networkID, kb)
+	if err != nil {
+		return nil, scrub(err, kb)
+	}
+	if k.insecure && networkID == constants.MainnetID {
+		k.Close()
+		return nil, ErrInsecureMainnet
+	}
+	return k, nil
+}
 
-	// in case, it's already encoded
-	k, err := NewSoft(networkID, WithPrivateKeyEncoded(string(kb)))
-	if err == nil {
-		return k, nil
+func loadSoft(networkID uint32, kb []byte) (*SoftKey, error) {
+	// in case, it's already encoded (parsed from the bytes, as a string
+	// could not be zeroed out)
+	if enc := bytes.TrimSpace(kb); bytes.HasPrefix(enc, []byte(privKeyEncPfx)) {
+		privKey, err := decodePrivateKeyBytes(enc)
+		if err != nil {
+			return nil, err
+		}
+		return NewSoft(networkID, WithPrivateKey(privKey))
 	}
 
 	r := bufio.NewReader(bytes.NewBuffer(kb))
 	buf := make([]byte, privKeySize)
+	defer zero(buf)
 	n, err := readASCII(buf, r)
 	if err != nil {
 		return nil, err
@@ -165,13 +230,17 @@ func LoadSoft(networkID uint32, keyPath string) (*SoftKey, error) {
 	if n != len(buf) {
 		return nil, ErrInvalidPrivateKeyLen
 	}
-	if err := checkKeyFileEnd(r); err != nil {
+	insecure, err := checkKeyFileEnd(r)
+	if err != nil {
 		return nil, err
 	}
 
-	skBytes, err := hex.DecodeString(string(buf))
-	if err != nil {
-		return nil, err
+	// held by the private key, and zeroed out on "Close"
+	skBytes := make([]byte, hex.DecodedLen(len(buf)))
+	if _, err := hex.Decode(skBytes, buf); err != nil {
+		zero(skBytes)
+		// the error may quote a part of the key
+		return nil, ErrInvalidPrivateKeyEncoding
 	}
 	rpk, err := keyFactory.ToPrivateKey(skBytes)
 	if err != nil {
@@ -182,7 +251,24 @@ func LoadSoft(networkID uint32, keyPath string) (*SoftKey, error) {
 		return nil, ErrInvalidType
 	}
 
-	return NewSoft(networkID, WithPrivateKey(privKey))
+	k, err := NewSoft(networkID, WithPrivateKey(privKey))
+	if err != nil {
+		return nil, err
+	}
+	k.insecure = insecure
+	return k, nil
+}
+
+// scrub drops the error message if it contains any part of the key,
+// so that the key never ends up in the error/log outputs.
+func scrub(err error, kb []byte) error {
+	msg := []byte(err.Error())
+	for _, field := range bytes.Fields(kb) {
+		if len(field) >= 8 && bytes.Contains(msg, field) {
+			return ErrInvalidPrivateKey
+		}
+	}
+	return err
 }
 
 // readASCII reads into 'buf', stopping when the buffer is full or
@@ -202,35 +288,47 @@ func readASCII(buf []byte, r io.ByteReader) (n int, err error) {
 
 const fileEndLimit = 1
 
-// checkKeyFileEnd skips over additional newlines at the end of a key file.
-func checkKeyFileEnd(r io.ByteReader) error {
+// checkKeyFileEnd skips over additional newlines at the end of a key file,
+// and reports the line of the seeded keys (ref. "insecureMarker").
+func checkKeyFileEnd(r *bufio.Reader) (insecure bool, err error) {
 	for idx := 0; ; idx++ {
 		b, err := r.ReadByte()
 		switch {
 		case errors.Is(err, io.EOF):
-			return nil
+			return insecure, nil
 		case err != nil:
-			return err
+			return false, err
+		case b == insecureMarker[0] && !insecure:
+			line, err := r.ReadString('\n')
+			if err != nil && !errors.Is(err, io.EOF) {
+				return false, err
+			}
+			if string(b)+strings.TrimRight(line, "\r\n") != insecureMarker {
+				return false, ErrInvalidPrivateKeyEnding
+			}
+			// the newlines after the marker
+			insecure, idx = true, -1
 		case b != '\n' && b != '\r':
-			return ErrInvalidPrivateKeyEnding
+			return false, ErrInvalidPrivateKeyEnding
 		case idx > fileEndLimit:
-			return ErrInvalidPrivateKeyLen
+			return false, ErrInvalidPrivateKeyLen
 		}
 	}
 }
 
-func encodePrivateKey(pk *crypto.PrivateKeySECP256K1R) (string, error) {
-	privKeyRaw := pk.Bytes()
-	enc, err := formatting.EncodeWithChecksum(formatting.CB58, privKeyRaw)
-	if err != nil {
-		return "", err
-	}
-	return privKeyEncPfx + enc, nil
+func encodePrivateKey(pk *crypto.PrivateKeySECP256K1R) []byte {
+	enc := encodeCB58(pk.Bytes())
+	defer zero(enc)
+	return append([]byte(privKeyEncPfx), enc...)
 }
 
 func decodePrivateKey(enc string) (*crypto.PrivateKeySECP256K1R, error) {
-	rawPk := strings.Replace(enc, privKeyEncPfx, "", 1)
-	skBytes, err := formatting.Decode(formatting.CB58, rawPk)
+	return decodePrivateKeyBytes([]byte(enc))
+}
+
+func decodePrivateKeyBytes(enc []byte) (*crypto.PrivateKeySECP256K1R, error) {
+	// held by the private key, and zeroed out on "Close"
+	skBytes, err := decodeCB58(bytes.TrimPrefix(enc, []byte(privKeyEncPfx)))
 	if err != nil {
 		return nil, err
 	}
@@ -257,13 +355,42 @@ func (m *SoftKey) Raw() []byte {
 
 // Returns the private key encoded in CB58 and "PrivateKey-" prefix.
 func (m *SoftKey) Encode() string {
-	return m.privKeyEncoded
+	return string(m.privKeyEncoded)
 }
 
-// Saves the private key to disk with hex encoding.
+// Saves the private key with hex encoding to the key store of [p]
+// (e.g., a file, or "keychain://subnet-cli/ops").
 func (m *SoftKey) Save(p string) error {
-	k := hex.EncodeToString(m.privKeyRaw)
-	return ioutil.WriteFile(p, []byte(k), fsModeWrite)
+	n := hex.EncodedLen(len(m.privKeyRaw))
+	// no reallocation by the marker, for all the bytes to be zeroed out
+	k := make([]byte, n, n+len(insecureMarker)+2)
+	defer zero(k)
+	hex.Encode(k, m.privKeyRaw)
+	if m.insecure {
+		k = append(k, "\n"+insecureMarker+"\n"...)
+	}
+	b, err := keystore.Open(p)
+	if err != nil {
+		return err
+	}
+	return b.Save(k)
+}
+
+// Close zeroes out the private key bytes: [privKeyRaw] (the same buffer
+// cached by the underlying private key) and [privKeyEncoded]. The scalar
+// of the underlying secp256k1 key is unexported by avalanchego, so it is
+// not zeroed out but only dropped.
+func (m *SoftKey) Close() error {
+	zero(m.privKeyRaw)
+	zero(m.privKeyEncoded)
+	unlock(m.privKeyRaw)
+	unlock(m.privKeyEncoded)
+	m.privKeyRaw = nil
+	m.privKeyEncoded = nil
+	// the key is unusable once closed
+	m.privKey = nil
+	m.keyChain = nil
+	return nil
 }
 
 func (m *SoftKey) P() []string { return []string{m.pAddr} }
@@ -277,7 +404,14 @@ func (m *SoftKey) Spends(outputs []*avax.UTXO, opts ...OpOption) (
 	ret.applyOpts(opts)
 
 	for _, out := range outputs {
-		input, psigners, err := m.spend(out, ret.time)
+		if ret.skip(out) {
+			continue
+		}
+		o, locktime := unlockStakeable(out.Out, ret.time)
+		if locktime > 0 && !ret.stakeableLocked {
+			continue
+		}
+		input, psigners, err := m.spend(o, ret.time)
 		if err != nil {
 			zap.L().Warn("cannot spend with current key", zap.Error(err))
 			continue
@@ -286,7 +420,7 @@ func (m *SoftKey) Spends(outputs []*avax.UTXO, opts ...OpOption) (
 		inputs = append(inputs, &avax.TransferableInput{
 			UTXOID: out.UTXOID,
 			Asset:  out.Asset,
-			In:     input,
+			In:     lockStakeable(input, locktime),
 		})
 		// Convert to ids.ShortID to adhere with interface
 		pksigners := make([]ids.ShortID, len(psigners))
@@ -303,14 +437,17 @@ func (m *SoftKey) Spends(outputs []*avax.UTXO, opts ...OpOption) (
 	return totalBalanceToSpend, inputs, signers
 }
 
-func (m *SoftKey) spend(output *avax.UTXO, time uint64) (
+func (m *SoftKey) spend(output verify.Verifiable, time uint64) (
 	input avax.TransferableIn,
 	signers []*crypto.PrivateKeySECP256K1R,
 	err error,
 ) {
+	if m.keyChain == nil {
+		return nil, nil, ErrClosed
+	}
 	// "time" is used to check whether the key owner
 	// is still within the lock time (thus can't spend).
-	inputf, psigners, err := m.keyChain.Spend(output.Out, time)
+	inputf, psigners, err := m.keyChain.Spend(output, time)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -322,29 +459,43 @@ func (m *SoftKey) spend(output *avax.UTXO, time uint64) (
 	return input, psigners, nil
 }
 
-const fsModeWrite = 0o600
-
 func (m *SoftKey) Addresses() []ids.ShortID {
-	return []ids.ShortID{m.privKey.PublicKey().Address()}
+	return []ids.ShortID{m.addr}
 }
 
 func (m *SoftKey) Sign(pTx *platformvm.Tx, signers [][]ids.ShortID) error {
-	privsigners := make([][]*crypto.PrivateKeySECP256K1R, len(signers))
-	for i, inputSigners := range signers {
-		privsigners[i] = make([]*crypto.PrivateKeySECP256K1R, len(inputSigners))
-		for j, signer := range inputSigners {
-			if signer != m.privKey.PublicKey().Address() {
-				// Should never happen
-				return ErrCantSpend
-			}
-			privsigners[i][j] = m.privKey
-		}
+	if m.privKey == nil {
+		return ErrClosed
 	}
+	return signTx(pTx, signers, map[ids.ShortID]*crypto.PrivateKeySECP256K1R{
+		m.addr: m.privKey,
+	})
+}
 
-	return pTx.Sign(codec.PCodecManager, privsigners)
+func (m *SoftKey) SignHash(hash []byte, addrs []ids.ShortID) (map[ids.ShortID][]byte, error) {
+	if m.privKey == nil {
+		return nil, ErrClosed
+	}
+	sigs := make(map[ids.ShortID][]byte)
+	own := m.addr
+	for _, addr := range addrs {
+		if addr != own {
+			continue
+		}
+		sig, err := m.privKey.SignHash(hash)
+		if err != nil {
+			return nil, err
+		}
+		sigs[own] = sig
+		break
+	}
+	return sigs, nil
 }
 
 func (m *SoftKey) Match(owners *secp256k1fx.OutputOwners, time uint64) ([]uint32, []ids.ShortID, bool) {
+	if m.keyChain == nil {
+		return nil, nil, false
+	}
 	indices, privs, ok := m.keyChain.Match(owners, time)
 	pks := make([]ids.ShortID, len(privs))
 	for i, priv := range privs {
@@ -0,0 +1,162 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	ErrUnsupportedWalletVersion = errors.New("unsupported wallet export version")
+	ErrWrongPassphrase          = errors.New("wrong wallet passphrase")
+	ErrUnsupportedWalletKey     = errors.New("unsupported wallet key type")
+)
+
+const (
+	// WalletKeyMnemonic is the HD wallet key type, imported as its first
+	// external address key ("m/44'/9000'/0'/0/0").
+	WalletKeyMnemonic = "mnemonic"
+	// WalletKeySingleton is the single private key type.
+	WalletKeySingleton = "singleton"
+
+	walletKeyIterations = 100000
+	walletKeySize       = 32
+)
+
+// walletExport is the encrypted JSON export ("Export Wallet") of
+// the Avalanche web wallet.
+type walletExport struct {
+	Version  string `json:"version"`
+	Salt     string `json:"salt"`
+	PassHash string `json:"pass_hash"`
+	Keys     []struct {
+		Key  string `json:"key"`
+		IV   string `json:"iv"`
+		Type string `json:"type"`
+	} `json:"keys"`
+}
+
+// WalletKey is a key imported from the Avalanche web wallet export.
+type WalletKey struct {
+	Type    string
+	PrivKey *crypto.PrivateKeySECP256K1R
+}
+
+// DecryptWallet decrypts the keys of the Avalanche web wallet export.
+// The keys are derived from the [passphrase] with PBKDF2 and encrypted
+// with AES-GCM, as done by the wallet.
+func DecryptWallet(b []byte, passphrase string) ([]WalletKey, error) {
+	var w walletExport
+	if err := json.Unmarshal(b, &w); err != nil {
+		return nil, err
+	}
+	switch w.Version {
+	case "5.0", "6.0":
+	default:
+		return nil, fmt.Errorf("%w %q (re-export with the latest wallet)", ErrUnsupportedWalletVersion, w.Version)
+	}
+
+	salt, err := formatting.Decode(formatting.CB58, w.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %w", err)
+	}
+	passHash, err := formatting.Decode(formatting.CB58, w.PassHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pass_hash: %w", err)
+	}
+	pwKey := walletPasswordHash(passphrase, salt)
+	defer zero(pwKey)
+	if subtle.ConstantTimeCompare(walletPasswordHash(passphrase, pwKey), passHash) != 1 {
+		return nil, ErrWrongPassphrase
+	}
+
+	aesKey := pbkdf2.Key(pwKey, salt, walletKeyIterations, walletKeySize, sha256.New)
+	defer zero(aesKey)
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]WalletKey, 0, len(w.Keys))
+	for i, k := range w.Keys {
+		ciphertext, err := formatting.Decode(formatting.CB58, k.Key)
+		if err != nil {
+			return nil, fmt.Errorf("key #%d: invalid key: %w", i, err)
+		}
+		iv, err := formatting.Decode(formatting.CB58, k.IV)
+		if err != nil {
+			return nil, fmt.Errorf("key #%d: invalid iv: %w", i, err)
+		}
+		if len(iv) != gcm.NonceSize() {
+			return nil, fmt.Errorf("key #%d: invalid iv length %d", i, len(iv))
+		}
+		plaintext, err := gcm.Open(nil, iv, ciphertext, salt)
+		if err != nil {
+			return nil, fmt.Errorf("key #%d: %w", i, err)
+		}
+		privKey, err := walletPrivateKey(k.Type, plaintext)
+		zero(plaintext)
+		if err != nil {
+			return nil, fmt.Errorf("key #%d: %w", i, err)
+		}
+		keys = append(keys, WalletKey{Type: k.Type, PrivKey: privKey})
+	}
+	return keys, nil
+}
+
+// walletPasswordHash is the SHA-256 of the passphrase and the salt.
+func walletPasswordHash(passphrase string, salt []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(passphrase))
+	h.Write(salt)
+	return h.Sum(nil)
+}
+
+func walletPrivateKey(typ string, plaintext []byte) (*crypto.PrivateKeySECP256K1R, error) {
+	switch typ {
+	case WalletKeyMnemonic:
+		seed, err := SeedFromMnemonic(string(plaintext), "")
+		if err != nil {
+			return nil, err
+		}
+		defer zero(seed)
+		return DeriveKey(seed, AvalanchePath(0, 0))
+	case WalletKeySingleton:
+		s := strings.TrimSpace(string(plaintext))
+		if strings.HasPrefix(s, privKeyEncPfx) {
+			return decodePrivateKey(s)
+		}
+		raw, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+		if err != nil {
+			raw = plaintext
+		}
+		rpk, err := keyFactory.ToPrivateKey(raw)
+		if err != nil {
+			return nil, ErrInvalidPrivateKey
+		}
+		privKey, ok := rpk.(*crypto.PrivateKeySECP256K1R)
+		if !ok {
+			return nil, ErrInvalidType
+		}
+		return privKey, nil
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedWalletKey, typ)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// encryptWallet encrypts the keys the same way the Avalanche web wallet does.
+func encryptWallet(t *testing.T, passphrase string, keys map[string][]byte) []byte {
+	salt := []byte("0123456789abcdef")
+	pwKey := walletPasswordHash(passphrase, salt)
+	block, err := aes.NewCipher(pbkdf2.Key(pwKey, salt, walletKeyIterations, walletKeySize, sha256.New))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cb58 := func(b []byte) string {
+		s, err := formatting.EncodeWithChecksum(formatting.CB58, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	w := map[string]interface{}{
+		"version":   "6.0",
+		"salt":      cb58(salt),
+		"pass_hash": cb58(walletPasswordHash(passphrase, pwKey)),
+	}
+	ks := []map[string]string{}
+	for typ, plaintext := range keys {
+		iv := bytes.Repeat([]byte{1}, gcm.NonceSize())
+		ks = append(ks, map[string]string{
+			"type": typ,
+			"iv":   cb58(iv),
+			"key":  cb58(gcm.Seal(nil, iv, plaintext, salt)),
+		})
+	}
+	w["keys"] = ks
+	b, err := json.Marshal(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestDecryptWallet(t *testing.T) {
+	t.Parallel()
+
+	b := encryptWallet(t, "passw0rd", map[string][]byte{
+		WalletKeySingleton: []byte(EwoqPrivateKey),
+	})
+	if _, err := DecryptWallet(b, "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("expected %v, got %v", ErrWrongPassphrase, err)
+	}
+	keys, err := DecryptWallet(b, "passw0rd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("expected 1 key, got %d", len(keys))
+	}
+	m, err := NewSoft(fallbackNetworkID, WithPrivateKey(keys[0].PrivKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.P()[0] != ewoqPChainAddr {
+		t.Fatalf("unexpected P-Chain address %q, expected %q", m.P()[0], ewoqPChainAddr)
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	t.Parallel()
+
+	// BIP-32 test vector 1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	tt := []struct {
+		path string
+		key  string
+	}{
+		{"m", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{"m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0'/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0'/1/2'", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+	}
+	for _, tv := range tt {
+		path, err := ParseDerivationPath(tv.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pk, err := DeriveKey(seed, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(pk.Bytes()); got != tv.key {
+			t.Fatalf("%s: expected %s, got %s", tv.path, tv.key, got)
+		}
+	}
+
+	if _, err := ParseDerivationPath("44'/9000'"); !errors.Is(err, ErrInvalidDerivationPath) {
+		t.Fatalf("expected %v, got %v", ErrInvalidDerivationPath, err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	addr := func(account, index uint32) ids.ShortID {
+		pk, err := DeriveKey(seed, AvalanchePath(account, index))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pk.PublicKey().Address()
+	}
+	// the 2nd active address of account 0 is beyond the first batch
+	active := map[ids.ShortID]uint64{
+		addr(0, 0): 1,
+		addr(0, 3): 2,
+		addr(0, 7): 3,
+		addr(1, 2): 4,
+		// unreachable, account 2 has no activity
+		addr(3, 0): 5,
+	}
+	queried := 0
+	results, err := Scan(seed, 5, func(addrs []ids.ShortID) (map[ids.ShortID]uint64, error) {
+		queried += len(addrs)
+		balances := make(map[ids.ShortID]uint64)
+		for _, a := range addrs {
+			if b, ok := active[a]; ok {
+				balances[a] = b
+			}
+		}
+		return balances, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"m/44'/9000'/0'/0/0",
+		"m/44'/9000'/0'/0/3",
+		"m/44'/9000'/0'/0/7",
+		"m/44'/9000'/1'/0/2",
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d results, got %+v", len(expected), results)
+	}
+	for i, r := range results {
+		if r.Path() != expected[i] || r.Address != addr(r.Account, r.Index) || r.Balance != uint64(i+1) {
+			t.Fatalf("#%d: unexpected result %+v (%s)", i, r, r.Path())
+		}
+	}
+	// account 0: 0..12, account 1: 0..7, account 2: 0..4
+	if queried != 13+8+5 {
+		t.Fatalf("unexpected %d derived addresses", queried)
+	}
+
+	if _, err := Scan(seed, 0, nil); !errors.Is(err, ErrInvalidGapLimit) {
+		t.Fatalf("expected %v, got %v", ErrInvalidGapLimit, err)
+	}
+}
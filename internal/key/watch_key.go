@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+var ErrWatchOnly = errors.New("watch-only key can't sign")
+
+// accountDepth is the depth of the account key "m/44'/9000'/account'".
+const accountDepth = 3
+
+var _ Key = &WatchKey{}
+
+// WatchKey holds the external addresses derived from the account extended
+// public key, without any private key: it matches and spends the UTXOs to
+// build the unsigned txs, but can't sign them. The addresses are the ones
+// of the ledger, derived from the same account key.
+type WatchKey struct {
+	*HardKey
+	xpub *ExtendedPublicKey
+}
+
+// NewWatch derives the first [n] external addresses ("0/index") of the
+// account extended public key [xpub].
+func NewWatch(networkID uint32, xpub string, n int) (*WatchKey, error) {
+	x, err := ParseExtendedPublicKey(xpub)
+	if err != nil {
+		return nil, err
+	}
+	if x.Depth != accountDepth || x.ChildNumber < hardenedOffset {
+		return nil, fmt.Errorf("%w: expected an account key (depth %d, e.g., \"m/44'/9000'/0'\"), got depth %d", ErrInvalidXPub, accountDepth, x.Depth)
+	}
+	external, err := x.Child(0)
+	if err != nil {
+		return nil, err
+	}
+	hrp := getHRP(networkID)
+	k := &HardKey{
+		pAddrs:       make([]string, n),
+		shortAddrs:   make([]ids.ShortID, n),
+		shortAddrMap: make(map[ids.ShortID]uint32, n),
+	}
+	for i := 0; i < n; i++ {
+		child, err := external.Child(uint32(i))
+		if err != nil {
+			return nil, err
+		}
+		addr, err := child.Address()
+		if err != nil {
+			return nil, err
+		}
+		k.pAddrs[i], err = formatting.FormatAddress("P", hrp, addr[:])
+		if err != nil {
+			return nil, err
+		}
+		k.shortAddrs[i] = addr
+		k.shortAddrMap[addr] = uint32(i)
+	}
+	return &WatchKey{HardKey: k, xpub: x}, nil
+}
+
+// Account returns the hardened account index of the key.
+func (w *WatchKey) Account() uint32 {
+	return w.xpub.ChildNumber - hardenedOffset
+}
+
+// Path returns the derivation path of the address at [index].
+func (w *WatchKey) Path(index uint32) string {
+	return fmt.Sprintf("m/44'/%d'/%d'/0/%d", AvalancheCoinType, w.Account(), index)
+}
+
+// Close is a no-op, as the key holds no private material.
+func (w *WatchKey) Close() error { return nil }
+
+// Sign always fails, as the key holds no private material.
+func (w *WatchKey) Sign(*platformvm.Tx, [][]ids.ShortID) error {
+	return ErrWatchOnly
+}
+
+// SignHash signs with none of [addrs], so the signatures are all
+// left to collect (e.g., for a multisig proposal).
+func (w *WatchKey) SignHash([]byte, []ids.ShortID) (map[ids.ShortID][]byte, error) {
+	return map[ids.ShortID][]byte{}, nil
+}
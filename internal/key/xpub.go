@@ -0,0 +1,196 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/decred/dcrd/dcrec/secp256k1/v3"
+	"github.com/mr-tron/base58/base58"
+)
+
+var (
+	ErrInvalidXPub      = errors.New("invalid extended public key")
+	ErrHardenedFromXPub = errors.New("hardened child of an extended public key")
+)
+
+const (
+	// xpubLen is the length of a serialized extended key, without the checksum.
+	xpubLen = 78
+
+	xpubVersion = 0x0488B21E
+	tpubVersion = 0x043587CF
+	xprvVersion = 0x0488ADE4
+	tprvVersion = 0x04358394
+)
+
+// ExtendedPublicKey is a BIP-32 extended public key (e.g., "xpub..."),
+// deriving the non-hardened child public keys without any private key.
+type ExtendedPublicKey struct {
+	version uint32
+	// Depth is the number of derivations from the master key
+	// (e.g., 3 for the account key "m/44'/9000'/account'").
+	Depth uint8
+	// ChildNumber is the index of the key in its parent
+	// (e.g., the hardened account index).
+	ChildNumber       uint32
+	parentFingerprint [4]byte
+	chainCode         []byte
+	// compressed public key
+	pubKey []byte
+}
+
+// ParseExtendedPublicKey parses the base58check "xpub..." (or "tpub...").
+// The extended private keys are rejected, not to hold private material.
+func ParseExtendedPublicKey(s string) (*ExtendedPublicKey, error) {
+	b, err := base58.Decode(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidXPub, err)
+	}
+	if len(b) != xpubLen+4 {
+		return nil, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidXPub, xpubLen+4, len(b))
+	}
+	payload, checksum := b[:xpubLen], b[xpubLen:]
+	if !bytes.Equal(doubleSHA256(payload)[:4], checksum) {
+		return nil, fmt.Errorf("%w: bad checksum", ErrInvalidXPub)
+	}
+	x := &ExtendedPublicKey{
+		version:     binary.BigEndian.Uint32(payload[:4]),
+		Depth:       payload[4],
+		ChildNumber: binary.BigEndian.Uint32(payload[9:13]),
+		chainCode:   append([]byte{}, payload[13:45]...),
+		pubKey:      append([]byte{}, payload[45:]...),
+	}
+	copy(x.parentFingerprint[:], payload[5:9])
+	switch x.version {
+	case xpubVersion, tpubVersion:
+	case xprvVersion, tprvVersion:
+		return nil, fmt.Errorf("%w: got an extended private key, expected its public key", ErrInvalidXPub)
+	default:
+		return nil, fmt.Errorf("%w: unknown version %#x", ErrInvalidXPub, x.version)
+	}
+	if _, err := secp256k1.ParsePubKey(x.pubKey); err != nil || len(x.pubKey) != 33 {
+		return nil, fmt.Errorf("%w: bad public key", ErrInvalidXPub)
+	}
+	return x, nil
+}
+
+// DeriveExtendedPublicKey derives the extended public key at [path] from
+// the [seed] (e.g., the account key "m/44'/9000'/0'" to watch).
+func DeriveExtendedPublicKey(seed []byte, path []uint32) (*ExtendedPublicKey, error) {
+	k, c, err := deriveExtended(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	privKey, err := toPrivateKey(k)
+	if err != nil {
+		return nil, err
+	}
+	x := &ExtendedPublicKey{
+		version:   xpubVersion,
+		Depth:     uint8(len(path)),
+		chainCode: c,
+		pubKey:    privKey.PublicKey().Bytes(),
+	}
+	if len(path) > 0 {
+		x.ChildNumber = path[len(path)-1]
+		pk, _, err := deriveExtended(seed, path[:len(path)-1])
+		if err != nil {
+			return nil, err
+		}
+		parent, err := toPrivateKey(pk)
+		if err != nil {
+			return nil, err
+		}
+		copy(x.parentFingerprint[:], hashing.PubkeyBytesToAddress(parent.PublicKey().Bytes()))
+	}
+	return x, nil
+}
+
+// String returns the base58check encoding of the key.
+func (x *ExtendedPublicKey) String() string {
+	b := make([]byte, 0, xpubLen+4)
+	b = append(b, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(b, x.version)
+	b = append(b, x.Depth)
+	b = append(b, x.parentFingerprint[:]...)
+	b = append(b, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(b[9:], x.ChildNumber)
+	b = append(b, x.chainCode...)
+	b = append(b, x.pubKey...)
+	return base58.Encode(append(b, doubleSHA256(b)[:4]...))
+}
+
+// Child derives the non-hardened child at [index].
+func (x *ExtendedPublicKey) Child(index uint32) (*ExtendedPublicKey, error) {
+	if index >= hardenedOffset {
+		return nil, fmt.Errorf("%w: %d", ErrHardenedFromXPub, index-hardenedOffset)
+	}
+	data := make([]byte, 0, 37)
+	data = append(data, x.pubKey...)
+	var ser [4]byte
+	binary.BigEndian.PutUint32(ser[:], index)
+	data = append(data, ser[:]...)
+	mac := hmac.New(sha512.New, x.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	var il secp256k1.ModNScalar
+	if overflow := il.SetByteSlice(sum[:32]); overflow {
+		return nil, ErrInvalidChildKey
+	}
+	parent, err := secp256k1.ParsePubKey(x.pubKey)
+	if err != nil {
+		return nil, err
+	}
+	var tweak, parentPoint, childPoint secp256k1.JacobianPoint
+	secp256k1.ScalarBaseMultNonConst(&il, &tweak)
+	parent.AsJacobian(&parentPoint)
+	secp256k1.AddNonConst(&tweak, &parentPoint, &childPoint)
+	childPoint.ToAffine()
+	if childPoint.X.IsZero() && childPoint.Y.IsZero() {
+		return nil, ErrInvalidChildKey
+	}
+	child := &ExtendedPublicKey{
+		version:     x.version,
+		Depth:       x.Depth + 1,
+		ChildNumber: index,
+		chainCode:   sum[32:],
+		pubKey:      secp256k1.NewPublicKey(&childPoint.X, &childPoint.Y).SerializeCompressed(),
+	}
+	copy(child.parentFingerprint[:], hashing.PubkeyBytesToAddress(x.pubKey))
+	return child, nil
+}
+
+// Derive derives the non-hardened descendant at the relative [path].
+func (x *ExtendedPublicKey) Derive(path ...uint32) (*ExtendedPublicKey, error) {
+	var err error
+	for _, i := range path {
+		x, err = x.Child(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+// Address returns the address of the public key.
+func (x *ExtendedPublicKey) Address() (ids.ShortID, error) {
+	return ids.ToShortID(hashing.PubkeyBytesToAddress(x.pubKey))
+}
+
+func doubleSHA256(b []byte) []byte {
+	h := sha256.Sum256(b)
+	h = sha256.Sum256(h[:])
+	return h[:]
+}
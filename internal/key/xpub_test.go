@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestExtendedPublicKey(t *testing.T) {
+	t.Parallel()
+
+	// BIP-32 test vector 1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	tt := []struct {
+		path string
+		xpub string
+	}{
+		{"m", "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8"},
+		{"m/0'", "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw"},
+		{"m/0'/1", "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ"},
+		{"m/0'/1/2'", "xpub6D4BDPcP2GT577Vvch3R8wDkScZWzQzMMUm3PWbmWvVJrZwQY4VUNgqFJPMM3No2dFDFGTsxxpG5uJh7n7epu4trkrX7x7DogT5Uv6fcLW5"},
+		{"m/0'/1/2'/2", "xpub6FHa3pjLCk84BayeJxFW2SP4XRrFd1JYnxeLeU8EqN3vDfZmbqBqaGJAyiLjTAwm6ZLRQUMv1ZACTj37sR62cfN7fe5JnJ7dh8zL4fiyLHV"},
+	}
+	for _, tv := range tt {
+		path, err := ParseDerivationPath(tv.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, err := DeriveExtendedPublicKey(seed, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := x.String(); got != tv.xpub {
+			t.Fatalf("%s: expected %s, got %s", tv.path, tv.xpub, got)
+		}
+		parsed, err := ParseExtendedPublicKey(tv.xpub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := parsed.String(); got != tv.xpub {
+			t.Fatalf("%s: expected %s, got %s", tv.path, tv.xpub, got)
+		}
+	}
+
+	// the non-hardened children are derived without the private key
+	parent, err := ParseExtendedPublicKey(tt[3].xpub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err := parent.Derive(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.String() != tt[4].xpub {
+		t.Fatalf("expected %s, got %s", tt[4].xpub, child)
+	}
+	if _, err := parent.Child(hardenedOffset); !errors.Is(err, ErrHardenedFromXPub) {
+		t.Fatalf("expected %v, got %v", ErrHardenedFromXPub, err)
+	}
+
+	for i, s := range []string{
+		"",
+		"xpub6FHa3pjLCk84BayeJxFW2SP4XRrFd1JYnxeLeU8EqN3vDfZmbqBqaGJAyiLjTAwm6ZLRQUMv1ZACTj37sR62cfN7fe5JnJ7dh8zL4fiyLHW",
+		// the extended private key of "m"
+		"xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+	} {
+		if _, err := ParseExtendedPublicKey(s); !errors.Is(err, ErrInvalidXPub) {
+			t.Fatalf("#%d: expected %v, got %v", i, ErrInvalidXPub, err)
+		}
+	}
+}
+
+func TestNewWatch(t *testing.T) {
+	t.Parallel()
+
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	account, err := DeriveExtendedPublicKey(seed, AvalanchePath(1, 0)[:3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWatch(fallbackNetworkID, account.String(), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(w.Addresses()) != 4 {
+		t.Fatalf("expected 4 addresses, got %d", len(w.Addresses()))
+	}
+	for i, addr := range w.Addresses() {
+		pk, err := DeriveKey(seed, AvalanchePath(1, uint32(i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := pk.PublicKey().Address(); addr != expected {
+			t.Fatalf("#%d: expected %s, got %s", i, expected, addr)
+		}
+	}
+	if p := w.Path(2); p != "m/44'/9000'/1'/0/2" {
+		t.Fatalf("unexpected path %q", p)
+	}
+	if err := w.Sign(nil, nil); !errors.Is(err, ErrWatchOnly) {
+		t.Fatalf("expected %v, got %v", ErrWatchOnly, err)
+	}
+	sigs, err := w.SignHash(make([]byte, 32), w.Addresses())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sigs) != 0 {
+		t.Fatalf("expected no signature, got %d", len(sigs))
+	}
+
+	// not an account key
+	if _, err := NewWatch(fallbackNetworkID, "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ", 1); !errors.Is(err, ErrInvalidXPub) {
+		t.Fatalf("expected %v, got %v", ErrInvalidXPub, err)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build darwin
+// +build darwin
+
+package keystore
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// errSecItemNotFound is the exit code of "security" for a missing item.
+const errSecItemNotFound = 44
+
+// keychainLoad reads the generic password of the macOS Keychain.
+func keychainLoad(service string, account string) ([]byte, error) {
+	b, err := run(nil, nil, "security", "find-generic-password", "-s", service, "-a", account, "-w")
+	if exitCode(err) == errSecItemNotFound {
+		return nil, fmt.Errorf("%w: %s/%s", ErrNotFound, service, account)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(b), nil
+}
+
+// keychainSave adds (or updates) the generic password of the macOS
+// Keychain, with the command on stdin not to pass the key as an argument.
+func keychainSave(service string, account string, b []byte) error {
+	cmd := fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+		strconv.Quote(service), strconv.Quote(account), strconv.Quote(string(b)))
+	_, err := run([]byte(cmd), nil, "security", "-i")
+	return err
+}
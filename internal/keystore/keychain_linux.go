@@ -0,0 +1,32 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build linux || freebsd || netbsd || openbsd || dragonfly
+// +build linux freebsd netbsd openbsd dragonfly
+
+package keystore
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// keychainLoad looks up the secret of the libsecret keyring (e.g., GNOME
+// Keyring), with "secret-tool" (ref. "libsecret-tools").
+func keychainLoad(service string, account string) ([]byte, error) {
+	b, err := run(nil, nil, "secret-tool", "lookup", "service", service, "account", account)
+	// "secret-tool" exits with 1 and no output for a missing secret
+	if exitCode(err) == 1 || (err == nil && len(b) == 0) {
+		return nil, fmt.Errorf("%w: %s/%s", ErrNotFound, service, account)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(b), nil
+}
+
+// keychainSave stores the secret in the libsecret keyring, read from stdin.
+func keychainSave(service string, account string, b []byte) error {
+	_, err := run(b, nil, "secret-tool", "store", "--label=subnet-cli "+service+"/"+account, "service", service, "account", account)
+	return err
+}
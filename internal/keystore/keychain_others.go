@@ -0,0 +1,15 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build !darwin && !linux && !freebsd && !netbsd && !openbsd && !dragonfly && !windows
+// +build !darwin,!linux,!freebsd,!netbsd,!openbsd,!dragonfly,!windows
+
+package keystore
+
+func keychainLoad(string, string) ([]byte, error) {
+	return nil, ErrUnsupported
+}
+
+func keychainSave(string, string, []byte) error {
+	return ErrUnsupported
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build windows
+// +build windows
+
+package keystore
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// keychainPathEnv passes the key file path to PowerShell, not to quote it.
+const keychainPathEnv = "SUBNET_CLI_KEYCHAIN_PATH"
+
+// keychainPath returns the file of the DPAPI-encrypted key, only
+// decryptable by the current Windows user.
+func keychainPath(service string, account string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "subnet-cli", "keychain", service, account+".dpapi"), nil
+}
+
+// keychainLoad decrypts the key with DPAPI ("ConvertTo-SecureString").
+func keychainLoad(service string, account string) ([]byte, error) {
+	p, err := keychainPath(service, account)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(p); os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s/%s", ErrNotFound, service, account)
+	}
+	b, err := run(nil, []string{keychainPathEnv + "=" + p}, "powershell", "-NoProfile", "-NonInteractive", "-Command",
+		`$s = Get-Content -LiteralPath $env:SUBNET_CLI_KEYCHAIN_PATH | ConvertTo-SecureString; `+
+			`[Runtime.InteropServices.Marshal]::PtrToStringBSTR([Runtime.InteropServices.Marshal]::SecureStringToBSTR($s))`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(b), nil
+}
+
+// keychainSave encrypts the key read from stdin with DPAPI
+// ("ConvertFrom-SecureString").
+func keychainSave(service string, account string, b []byte) error {
+	p, err := keychainPath(service, account)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return err
+	}
+	_, err = run(b, []string{keychainPathEnv + "=" + p}, "powershell", "-NoProfile", "-NonInteractive", "-Command",
+		`[Console]::In.ReadToEnd() | ConvertTo-SecureString -AsPlainText -Force | ConvertFrom-SecureString | Set-Content -LiteralPath $env:SUBNET_CLI_KEYCHAIN_PATH`,
+	)
+	return err
+}
@@ -0,0 +1,215 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package keystore implements the backends storing the private keys,
+// selected by the URI of the key path (e.g., "keychain://subnet-cli/ops").
+package keystore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+var (
+	ErrUnknownScheme = errors.New("unknown key store scheme")
+	ErrInvalidURI    = errors.New("invalid key store URI")
+	ErrNotFound      = errors.New("key not found in the key store")
+	ErrReadOnly      = errors.New("key store is read-only")
+	ErrUnsupported   = errors.New("key store not supported on this platform")
+)
+
+// fsModeWrite is the mode of the key files.
+const fsModeWrite = 0o600
+
+// Backend stores the private key bytes (e.g., hex-encoded).
+type Backend interface {
+	// Load returns the stored key bytes, or an error matching "ErrNotFound".
+	Load() ([]byte, error)
+	// Save stores the key bytes, replacing the existing ones.
+	Save(b []byte) error
+	// Exists returns true if a key is stored.
+	Exists() (bool, error)
+	// String describes the backend without any secret (e.g., in the errors).
+	String() string
+}
+
+// IsURI returns true if [s] is a key store URI rather than a file path.
+func IsURI(s string) bool {
+	return strings.Contains(s, "://")
+}
+
+// Open returns the backend of [uri]:
+//
+//   - a path, or "file:///path/to.pk": the local file
+//   - "keychain://<service>/<account>": the OS keychain (macOS Keychain,
+//     libsecret via "secret-tool", or Windows DPAPI)
+//   - "env://<NAME>": the environment variable, read-only
+func Open(uri string) (Backend, error) {
+	if !IsURI(uri) {
+		return &file{path: uri}, nil
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %v", ErrInvalidURI, uri, err)
+	}
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" {
+			return nil, fmt.Errorf("%w %q: empty path", ErrInvalidURI, uri)
+		}
+		return &file{path: u.Path}, nil
+	case "keychain":
+		account := strings.Trim(u.Path, "/")
+		if u.Host == "" || account == "" {
+			return nil, fmt.Errorf("%w %q: expected keychain://<service>/<account>", ErrInvalidURI, uri)
+		}
+		return &keychain{service: u.Host, account: account}, nil
+	case "env":
+		if u.Host == "" {
+			return nil, fmt.Errorf("%w %q: expected env://<NAME>", ErrInvalidURI, uri)
+		}
+		return &env{name: u.Host}, nil
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownScheme, u.Scheme)
+	}
+}
+
+var _ Backend = &file{}
+
+type file struct {
+	path string
+}
+
+func (f *file) Load() ([]byte, error) {
+	b, err := ioutil.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return nil, &notFoundError{err: err}
+	}
+	return b, err
+}
+
+func (f *file) Save(b []byte) error {
+	return ioutil.WriteFile(f.path, b, fsModeWrite)
+}
+
+func (f *file) Exists() (bool, error) {
+	_, err := os.Stat(f.path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (f *file) String() string { return f.path }
+
+// notFoundError matches both "ErrNotFound" and the error of the file
+// system (e.g., "os.ErrNotExist").
+type notFoundError struct {
+	err error
+}
+
+func (e *notFoundError) Error() string        { return e.err.Error() }
+func (e *notFoundError) Is(target error) bool { return target == ErrNotFound }
+func (e *notFoundError) Unwrap() error        { return e.err }
+
+var _ Backend = &env{}
+
+type env struct {
+	name string
+}
+
+func (e *env) Load() ([]byte, error) {
+	v, ok := os.LookupEnv(e.name)
+	if !ok || v == "" {
+		return nil, fmt.Errorf("%w: $%s is not set", ErrNotFound, e.name)
+	}
+	return []byte(v), nil
+}
+
+func (e *env) Save([]byte) error {
+	return fmt.Errorf("%w: %s", ErrReadOnly, e)
+}
+
+func (e *env) Exists() (bool, error) {
+	v, ok := os.LookupEnv(e.name)
+	return ok && v != "", nil
+}
+
+func (e *env) String() string { return "env://" + e.name }
+
+var _ Backend = &keychain{}
+
+// keychain stores the key in the OS keychain, implemented per platform
+// by "keychainLoad" and "keychainSave".
+type keychain struct {
+	service string
+	account string
+}
+
+func (k *keychain) Load() ([]byte, error) {
+	return keychainLoad(k.service, k.account)
+}
+
+func (k *keychain) Save(b []byte) error {
+	return keychainSave(k.service, k.account, b)
+}
+
+func (k *keychain) Exists() (bool, error) {
+	b, err := keychainLoad(k.service, k.account)
+	for i := range b {
+		b[i] = 0
+	}
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (k *keychain) String() string { return "keychain://" + k.service + "/" + k.account }
+
+// run runs the keychain tool with the secrets on stdin, never in the
+// arguments (visible to the other processes), and with the [env]
+// variables (e.g., "NAME=value") added to the environment.
+func run(stdin []byte, env []string, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, &runError{name: name, err: err, stderr: strings.TrimSpace(stderr.String())}
+	}
+	return stdout.Bytes(), nil
+}
+
+type runError struct {
+	name   string
+	err    error
+	stderr string
+}
+
+func (e *runError) Error() string {
+	if e.stderr == "" {
+		return fmt.Sprintf("%s failed: %v", e.name, e.err)
+	}
+	return fmt.Sprintf("%s failed: %v (%s)", e.name, e.err, e.stderr)
+}
+
+func (e *runError) Unwrap() error { return e.err }
+
+// exitCode returns the exit code of the failed tool, -1 if it did not run.
+func exitCode(err error) int {
+	var ee *exec.ExitError
+	if errors.As(err, &ee) {
+		return ee.ExitCode()
+	}
+	return -1
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpen(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		uri      string
+		expected string
+		err      error
+	}{
+		{uri: ".subnet-cli.pk", expected: ".subnet-cli.pk"},
+		{uri: "file:///etc/subnet-cli/ops.pk", expected: "/etc/subnet-cli/ops.pk"},
+		{uri: "keychain://subnet-cli/ops", expected: "keychain://subnet-cli/ops"},
+		{uri: "env://OPS_KEY", expected: "env://OPS_KEY"},
+		{uri: "keychain://subnet-cli", err: ErrInvalidURI},
+		{uri: "env://", err: ErrInvalidURI},
+		{uri: "vault://secret/ops", err: ErrUnknownScheme},
+	}
+	for i, tv := range tt {
+		b, err := Open(tv.uri)
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+		if err == nil && b.String() != tv.expected {
+			t.Fatalf("#%d: expected %q, got %q", i, tv.expected, b.String())
+		}
+	}
+}
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	b, err := Open("file://" + filepath.Join(t.TempDir(), "key.pk"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := b.Exists(); err != nil || ok {
+		t.Fatalf("expected no key, got %v (%v)", ok, err)
+	}
+	if _, err := b.Load(); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected %v, got %v", ErrNotFound, err)
+	}
+	if err := b.Save([]byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := b.Exists(); err != nil || !ok {
+		t.Fatalf("expected the key, got %v (%v)", ok, err)
+	}
+	v, err := b.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(v, []byte("secret")) {
+		t.Fatalf("expected %q, got %q", "secret", v)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("SUBNET_CLI_TEST_KEY", "secret")
+
+	b, err := Open("env://SUBNET_CLI_TEST_KEY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := b.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(v, []byte("secret")) {
+		t.Fatalf("expected %q, got %q", "secret", v)
+	}
+	if err := b.Save(v); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected %v, got %v", ErrReadOnly, err)
+	}
+
+	b, err = Open("env://SUBNET_CLI_TEST_MISSING")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Load(); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected %v, got %v", ErrNotFound, err)
+	}
+}
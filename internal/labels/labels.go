@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package labels implements the local store of the names and the tags of
+// the subnets, blockchains, and nodes, as the P-Chain has no human names
+// for them. The store is shared within a team by exporting and importing
+// its file.
+package labels
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrEmptyName     = errors.New("empty label name")
+	ErrDuplicateName = errors.New("label name already used")
+)
+
+// DefaultPath returns the default store file "~/.subnet-cli/labels.json".
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".subnet-cli", "labels.json"), nil
+}
+
+type Label struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+// Store is the labels keyed by the IDs as printed (e.g., "NodeID-..."
+// for the nodes).
+type Store struct {
+	Labels map[string]Label `json:"labels"`
+}
+
+// Load loads the store file, or returns the empty store if missing.
+func Load(p string) (*Store, error) {
+	s := &Store{Labels: make(map[string]Label)}
+	b, err := ioutil.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", p, err)
+	}
+	if s.Labels == nil {
+		s.Labels = make(map[string]Label)
+	}
+	return s, nil
+}
+
+// Save writes the store file, creating its directory if missing.
+func (s *Store) Save(p string) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p, b, 0o600)
+}
+
+// Set labels [id], replacing its previous label. The names are unique,
+// to refer to a single ID.
+func (s *Store) Set(id string, l Label) error {
+	l.Name = strings.TrimSpace(l.Name)
+	if l.Name == "" {
+		return ErrEmptyName
+	}
+	if other, ok := s.Lookup(l.Name); ok && other != id {
+		return fmt.Errorf("%w: %q labels %s", ErrDuplicateName, l.Name, other)
+	}
+	s.Labels[id] = l
+	return nil
+}
+
+// Remove removes the label of [id], and returns false if none.
+func (s *Store) Remove(id string) bool {
+	if _, ok := s.Labels[id]; !ok {
+		return false
+	}
+	delete(s.Labels, id)
+	return true
+}
+
+// Get returns the label of [id], if any.
+func (s *Store) Get(id string) (Label, bool) {
+	l, ok := s.Labels[id]
+	return l, ok
+}
+
+// Lookup returns the ID labeled [name], if any.
+func (s *Store) Lookup(name string) (string, bool) {
+	for id, l := range s.Labels {
+		if l.Name == name {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// IDs returns the labeled IDs, sorted by their label names.
+func (s *Store) IDs() []string {
+	ids := make([]string, 0, len(s.Labels))
+	for id := range s.Labels {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return s.Labels[ids[i]].Name < s.Labels[ids[j]].Name })
+	return ids
+}
+
+// Merge imports the labels of [o] (e.g., shared by a team). The labels of
+// the IDs already labeled differently are kept unless [overwrite], and
+// the ones of a name already used are skipped.
+func (s *Store) Merge(o *Store, overwrite bool) (added int, updated int, skipped int) {
+	for _, id := range o.IDs() {
+		l := o.Labels[id]
+		prev, ok := s.Labels[id]
+		switch {
+		case ok && prev.Name == l.Name && strings.Join(prev.Tags, ",") == strings.Join(l.Tags, ","):
+			continue
+		case ok && !overwrite:
+			skipped++
+			continue
+		}
+		if err := s.Set(id, l); err != nil {
+			skipped++
+			continue
+		}
+		if ok {
+			updated++
+		} else {
+			added++
+		}
+	}
+	return added, updated, skipped
+}
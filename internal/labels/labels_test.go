@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package labels
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	p := filepath.Join(t.TempDir(), "sub", "labels.json")
+	s, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("subnet-a", Label{Name: "prod", Tags: []string{"team=infra"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("node-a", Label{Name: "validator-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("subnet-b", Label{Name: "prod"}); !errors.Is(err, ErrDuplicateName) {
+		t.Fatalf("expected %v, got %v", ErrDuplicateName, err)
+	}
+	if err := s.Set("subnet-b", Label{Name: " "}); !errors.Is(err, ErrEmptyName) {
+		t.Fatalf("expected %v, got %v", ErrEmptyName, err)
+	}
+	// relabeled
+	if err := s.Set("subnet-a", Label{Name: "prod", Tags: []string{"team=ops"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(p); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l, ok := s.Get("subnet-a"); !ok || l.Name != "prod" || len(l.Tags) != 1 || l.Tags[0] != "team=ops" {
+		t.Fatalf("unexpected label %+v", l)
+	}
+	if id, ok := s.Lookup("validator-1"); !ok || id != "node-a" {
+		t.Fatalf("unexpected ID %q", id)
+	}
+	if ids := s.IDs(); len(ids) != 2 || ids[0] != "subnet-a" {
+		t.Fatalf("unexpected IDs %v", ids)
+	}
+
+	shared := &Store{Labels: map[string]Label{
+		"subnet-a": {Name: "production"},  // conflict
+		"node-a":   {Name: "validator-1"}, // same
+		"node-b":   {Name: "validator-2"}, // new
+		"node-c":   {Name: "prod"},        // name already used
+	}}
+	tt := []struct {
+		overwrite bool
+		added     int
+		updated   int
+		skipped   int
+	}{
+		{overwrite: false, added: 1, skipped: 2},
+		{overwrite: true, updated: 1, skipped: 1},
+	}
+	for i, tv := range tt {
+		added, updated, skipped := s.Merge(shared, tv.overwrite)
+		if added != tv.added || updated != tv.updated || skipped != tv.skipped {
+			t.Fatalf("#%d: expected %d/%d/%d, got %d/%d/%d", i, tv.added, tv.updated, tv.skipped, added, updated, skipped)
+		}
+	}
+	if !s.Remove("node-b") || s.Remove("node-b") {
+		t.Fatal("unexpected remove")
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package manifest implements the file formats to declare resources in bulk.
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"gopkg.in/yaml.v2"
+
+	"github.com/ava-labs/subnet-cli/internal/amount"
+)
+
+var (
+	ErrEmptyNodes    = errors.New("empty nodes")
+	ErrDuplicateNode = errors.New("duplicate node")
+)
+
+// Nodes is the list of nodes to add as validators.
+//
+// e.g.,
+//
+//	nodes:
+//	  - node-id: NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH
+//	    weight: 2000000000000
+//	    duration: 336h
+type Nodes struct {
+	Nodes []Node `yaml:"nodes"`
+}
+
+type Node struct {
+	NodeID string `yaml:"node-id"`
+	// Weight is the stake amount for the primary network validators
+	// (in nAVAX, or with a denomination such as "2000avax"), or the
+	// validation weight for the subnet validators.
+	// Zero to use the command default.
+	Weight amount.Amount `yaml:"weight"`
+	// Duration is the validation period from the start time
+	// (e.g., "336h"). Empty to use the command default.
+	Duration string `yaml:"duration"`
+
+	ID             ids.ShortID   `yaml:"-"`
+	ParsedDuration time.Duration `yaml:"-"`
+}
+
+// LoadNodes loads and validates the nodes file (in YAML or JSON).
+func LoadNodes(p string) (*Nodes, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	ns := new(Nodes)
+	if err := yaml.UnmarshalStrict(b, ns); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", p, err)
+	}
+	if len(ns.Nodes) == 0 {
+		return nil, ErrEmptyNodes
+	}
+
+	seen := make(map[ids.ShortID]struct{}, len(ns.Nodes))
+	for i := range ns.Nodes {
+		n := &ns.Nodes[i]
+		n.ID, err = ids.ShortFromPrefixedString(n.NodeID, constants.NodeIDPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node ID %q: %w", n.NodeID, err)
+		}
+		if _, ok := seen[n.ID]; ok {
+			return nil, fmt.Errorf("%w %q", ErrDuplicateNode, n.NodeID)
+		}
+		seen[n.ID] = struct{}{}
+		if n.Duration != "" {
+			n.ParsedDuration, err = time.ParseDuration(n.Duration)
+			if err != nil {
+				return nil, fmt.Errorf("invalid duration %q for %q: %w", n.Duration, n.NodeID, err)
+			}
+		}
+	}
+	return ns, nil
+}
@@ -0,0 +1,182 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"gopkg.in/yaml.v2"
+
+	"github.com/ava-labs/subnet-cli/internal/amount"
+)
+
+var (
+	ErrEmptyChains     = errors.New("empty chains")
+	ErrDuplicateChain  = errors.New("duplicate chain")
+	ErrInvalidChain    = errors.New("invalid chain")
+	ErrInvalidSubnetID = errors.New("invalid subnet ID")
+)
+
+// Spec declares a subnet with its validators and chains, to provision
+// with "wizard --spec" or to converge with "apply". The IDs are written
+// back once created.
+//
+// e.g.,
+//
+//	public-uri: https://api.avax-test.network
+//	private-key-path: .subnet-cli.pk
+//	subnet:
+//	  validate-end: +30d
+//	  stake-amount: 2000avax
+//	validators:
+//	  - node-id: NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH
+//	    endpoint: http://10.0.0.1:9650
+//	chains:
+//	  - name: subnetevm
+//	    vm-id: srEXiWaHuhNyGwPUi444Tu47ZEDwxTWrbQiuD7FmgSAQ6X7Dy
+//	    genesis: ./genesis.json
+type Spec struct {
+	// PublicURI and PrivateKeyPath override the flags, if set.
+	PublicURI      string          `yaml:"public-uri,omitempty"`
+	PrivateKeyPath string          `yaml:"private-key-path,omitempty"`
+	Subnet         SpecSubnet      `yaml:"subnet"`
+	Validators     []SpecValidator `yaml:"validators"`
+	Chains         []SpecChain     `yaml:"chains"`
+}
+
+type SpecSubnet struct {
+	// ID is the subnet ID, empty until created.
+	ID string `yaml:"id,omitempty"`
+	// ValidateEnd is the end of the validations (e.g., "+30d", or an
+	// RFC3339 timestamp). Empty to use the wizard default.
+	ValidateEnd string `yaml:"validate-end,omitempty"`
+	// StakeAmount is the stake of the new primary network validators
+	// (in nAVAX, or with a denomination such as "2000avax").
+	// Empty to use the wizard default.
+	StakeAmount string `yaml:"stake-amount,omitempty"`
+	// Weight is the subnet validation weight, zero for the wizard default.
+	Weight uint64 `yaml:"weight,omitempty"`
+}
+
+type SpecValidator struct {
+	NodeID string `yaml:"node-id"`
+	// Endpoint is the API of the node, to wait until it tracks the subnet
+	// instead of prompting. Empty if not reachable.
+	Endpoint string `yaml:"endpoint,omitempty"`
+	// Weight is the subnet validation weight, zero for the one of the subnet.
+	Weight uint64 `yaml:"weight,omitempty"`
+
+	ID ids.ShortID `yaml:"-"`
+}
+
+type SpecChain struct {
+	Name string `yaml:"name"`
+	VMID string `yaml:"vm-id"`
+	// Genesis is the path of the VM genesis file,
+	// relative to the spec file.
+	Genesis string `yaml:"genesis"`
+	// ID is the blockchain ID, empty until created.
+	ID string `yaml:"id,omitempty"`
+}
+
+// LoadSpec loads and validates the spec file (in YAML or JSON).
+func LoadSpec(p string) (*Spec, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	s := new(Spec)
+	if err := yaml.UnmarshalStrict(b, s); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", p, err)
+	}
+	if err := s.validate(); err != nil {
+		return nil, fmt.Errorf("invalid spec %q: %w", p, err)
+	}
+	return s, nil
+}
+
+func (s *Spec) validate() error {
+	if s.Subnet.ID != "" {
+		if _, err := ids.FromString(s.Subnet.ID); err != nil {
+			return fmt.Errorf("%w %q: %v", ErrInvalidSubnetID, s.Subnet.ID, err)
+		}
+	}
+	if s.Subnet.StakeAmount != "" {
+		if _, err := amount.Parse(s.Subnet.StakeAmount); err != nil {
+			return err
+		}
+	}
+	if len(s.Validators) == 0 {
+		return ErrEmptyNodes
+	}
+	seen := make(map[ids.ShortID]struct{}, len(s.Validators))
+	for i := range s.Validators {
+		v := &s.Validators[i]
+		var err error
+		v.ID, err = ids.ShortFromPrefixedString(v.NodeID, constants.NodeIDPrefix)
+		if err != nil {
+			return fmt.Errorf("invalid node ID %q: %w", v.NodeID, err)
+		}
+		if _, ok := seen[v.ID]; ok {
+			return fmt.Errorf("%w %q", ErrDuplicateNode, v.NodeID)
+		}
+		seen[v.ID] = struct{}{}
+	}
+	if len(s.Chains) == 0 {
+		return ErrEmptyChains
+	}
+	names := make(map[string]struct{}, len(s.Chains))
+	for _, c := range s.Chains {
+		if c.Name == "" || c.Genesis == "" {
+			return fmt.Errorf("%w %q: empty name or genesis", ErrInvalidChain, c.Name)
+		}
+		if _, err := ids.FromString(c.VMID); err != nil {
+			return fmt.Errorf("%w %q: invalid VM ID %q: %v", ErrInvalidChain, c.Name, c.VMID, err)
+		}
+		if c.ID != "" {
+			if _, err := ids.FromString(c.ID); err != nil {
+				return fmt.Errorf("%w %q: invalid blockchain ID %q: %v", ErrInvalidChain, c.Name, c.ID, err)
+			}
+		}
+		if _, ok := names[c.Name]; ok {
+			return fmt.Errorf("%w %q", ErrDuplicateChain, c.Name)
+		}
+		names[c.Name] = struct{}{}
+	}
+	return nil
+}
+
+// Weight returns the subnet validation weight of the node, zero if
+// neither the node nor the subnet sets it.
+func (s *Spec) Weight(nodeID ids.ShortID) uint64 {
+	for _, v := range s.Validators {
+		if v.ID == nodeID && v.Weight > 0 {
+			return v.Weight
+		}
+	}
+	return s.Subnet.Weight
+}
+
+// GenesisPath returns the path of the chain genesis, relative to
+// the spec file [p] unless absolute.
+func (c SpecChain) GenesisPath(p string) string {
+	if filepath.IsAbs(c.Genesis) {
+		return c.Genesis
+	}
+	return filepath.Join(filepath.Dir(p), c.Genesis)
+}
+
+// Save writes the spec, with the IDs set.
+func (s *Spec) Save(p string) error {
+	b, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p, b, 0o644)
+}
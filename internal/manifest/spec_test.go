@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package manifest
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+const testSpec = `
+public-uri: https://api.avax-test.network
+subnet:
+  validate-end: +30d
+  stake-amount: 2000avax
+validators:
+  - node-id: NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH
+    endpoint: http://10.0.0.1:9650
+chains:
+  - name: subnetevm
+    vm-id: srEXiWaHuhNyGwPUi444Tu47ZEDwxTWrbQiuD7FmgSAQ6X7Dy
+    genesis: genesis.json
+`
+
+func TestLoadSpec(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	p := filepath.Join(dir, "subnet.yaml")
+	if err := ioutil.WriteFile(p, []byte(testSpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := LoadSpec(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g := s.Chains[0].GenesisPath(p); g != filepath.Join(dir, "genesis.json") {
+		t.Fatalf("unexpected genesis path %q", g)
+	}
+
+	// the IDs written back are loaded as is
+	s.Subnet.ID = "24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1"
+	s.Chains[0].ID = "2XDnKyAEr1RhhWpTpMXqrjeejN23vETmDykVzkb4PrU1fQjewh"
+	if err := s.Save(p); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadSpec(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Subnet.ID != s.Subnet.ID || loaded.Chains[0].ID != s.Chains[0].ID {
+		t.Fatalf("unexpected IDs %q, %q", loaded.Subnet.ID, loaded.Chains[0].ID)
+	}
+
+	tt := []struct {
+		spec string
+		err  error
+	}{
+		{
+			spec: "chains: []\nvalidators: []\n",
+			err:  ErrEmptyNodes,
+		},
+		{
+			spec: "validators:\n  - node-id: NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH\n",
+			err:  ErrEmptyChains,
+		},
+		{
+			spec: "validators:\n  - node-id: NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH\n  - node-id: NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH\n",
+			err:  ErrDuplicateNode,
+		},
+		{
+			spec: testSpec + "  - name: subnetevm\n    vm-id: srEXiWaHuhNyGwPUi444Tu47ZEDwxTWrbQiuD7FmgSAQ6X7Dy\n    genesis: other.json\n",
+			err:  ErrDuplicateChain,
+		},
+		{
+			spec: testSpec + "  - name: other\n    vm-id: srEXiWaHuhNyGwPUi444Tu47ZEDwxTWrbQiuD7FmgSAQ6X7Dy\n",
+			err:  ErrInvalidChain,
+		},
+	}
+	for i, tv := range tt {
+		p := filepath.Join(dir, "invalid.yaml")
+		if err := ioutil.WriteFile(p, []byte(tv.spec), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadSpec(p); !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+	}
+}
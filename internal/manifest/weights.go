@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// LoadWeights loads the target validator weights, keyed by node ID.
+// A zero weight marks the node for removal.
+//
+// e.g.,
+//
+//	{
+//	  "NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH": 1000,
+//	  "NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET": 0
+//	}
+func LoadWeights(p string) (map[ids.ShortID]uint64, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	raw := make(map[string]uint64)
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", p, err)
+	}
+	if len(raw) == 0 {
+		return nil, ErrEmptyNodes
+	}
+	weights := make(map[ids.ShortID]uint64, len(raw))
+	for s, w := range raw {
+		nodeID, err := ids.ShortFromPrefixedString(s, constants.NodeIDPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node ID %q: %w", s, err)
+		}
+		if _, ok := weights[nodeID]; ok {
+			return nil, fmt.Errorf("%w %q", ErrDuplicateNode, s)
+		}
+		weights[nodeID] = w
+	}
+	return weights, nil
+}
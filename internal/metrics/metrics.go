@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package metrics defines the Prometheus metrics of subnet operations.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+const namespace = "subnet_cli"
+
+var (
+	APILatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "api_call_duration_seconds",
+			Help:      "Latency of the avalanche API calls",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"method"},
+	)
+	APIFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "api_call_failures_total",
+			Help:      "Number of failed avalanche API calls",
+		},
+		[]string{"method"},
+	)
+	TxIssued = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tx_issued_total",
+			Help:      "Number of issued transactions",
+		},
+		[]string{"type"},
+	)
+	TxFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tx_failures_total",
+			Help:      "Number of transactions that failed to issue",
+		},
+		[]string{"type"},
+	)
+	Retries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retries_total",
+			Help:      "Number of retried checks",
+		},
+		[]string{"op"},
+	)
+	RateLimitWait = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rate_limit_wait_seconds_total",
+			Help:      "Time the API calls waited for the client-side rate limit",
+		},
+		[]string{"host"},
+	)
+	Balance = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "balance_navax",
+			Help:      "Last observed P-Chain balance in nano-AVAX",
+		},
+		[]string{"address"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		APILatency,
+		APIFailures,
+		TxIssued,
+		TxFailures,
+		Retries,
+		RateLimitWait,
+		Balance,
+	)
+}
+
+// ObserveAPI records the latency of the API call started at [start],
+// and counts it as failed if [err] is not nil.
+func ObserveAPI(method string, start time.Time, err error) {
+	APILatency.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		APIFailures.WithLabelValues(method).Inc()
+	}
+}
+
+// Serve exposes the metrics on "[addr]/metrics" in the background.
+// It fails fast if [addr] cannot be listened on.
+func Serve(addr string) (stop func(ctx context.Context) error, err error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	srv := &http.Server{Handler: mux}
+	go func() {
+		zap.L().Info("serving metrics", zap.String("addr", ln.Addr().String()))
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zap.L().Warn("metrics server failed", zap.Error(err))
+		}
+	}()
+	return srv.Shutdown, nil
+}
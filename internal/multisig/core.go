@@ -0,0 +1,140 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package multisig
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+)
+
+var (
+	ErrTxMismatch       = errors.New("signed transaction does not match the transaction file")
+	ErrInvalidSignedTx  = errors.New("invalid signed transaction")
+	ErrCredsMismatch    = errors.New("unexpected number of credentials")
+	ErrUnexpectedSigner = errors.New("unexpected signer")
+)
+
+// CoreRequest is the unsigned transaction in the format of the external
+// signing flow of Core and the Avalanche Wallet (i.e., the parameters of
+// "avalanche_signTransaction"). The UTXOs of the inputs are fetched by
+// the wallet.
+type CoreRequest struct {
+	// TransactionHex is the "0x"-prefixed hex of the unsigned transaction
+	// with its codec version.
+	TransactionHex string `json:"transactionHex"`
+	ChainAlias     string `json:"chainAlias"`
+}
+
+// Core returns the unsigned transaction in the Core format.
+func (f *File) Core() ([]byte, error) {
+	return json.MarshalIndent(CoreRequest{
+		TransactionHex: "0x" + hex.EncodeToString(f.unsignedBytes),
+		ChainAlias:     "P",
+	}, "", "  ")
+}
+
+// ParseSignedTx parses the (partially) signed transaction returned by Core
+// or the Avalanche Wallet: the "0x"-prefixed hex of the transaction with
+// its credentials, as is, as a JSON string, or as the "signedTransactionHex"
+// of a JSON object.
+func ParseSignedTx(b []byte) ([]byte, error) {
+	b = bytes.TrimSpace(b)
+	s := string(b)
+	switch {
+	case strings.HasPrefix(s, "{"):
+		var resp struct {
+			SignedTransactionHex string `json:"signedTransactionHex"`
+		}
+		if err := json.Unmarshal(b, &resp); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSignedTx, err)
+		}
+		s = resp.SignedTransactionHex
+	case strings.HasPrefix(s, `"`):
+		if err := json.Unmarshal(b, &s); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSignedTx, err)
+		}
+	}
+	signed, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignedTx, err)
+	}
+	if len(signed) == 0 {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidSignedTx)
+	}
+	return signed, nil
+}
+
+// AddSigned verifies and adds the signatures of the [signed] transaction
+// (e.g., co-signed in Core), skipping the empty ones. It returns the
+// signers whose signature was added.
+func (f *File) AddSigned(signed []byte) ([]ids.ShortID, error) {
+	pTx := new(platformvm.Tx)
+	if _, err := codec.PCodecManager.Unmarshal(signed, pTx); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignedTx, err)
+	}
+	unsignedBytes, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, &pTx.UnsignedTx)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
+	}
+	if !bytes.Equal(unsignedBytes, f.unsignedBytes) {
+		return nil, ErrTxMismatch
+	}
+	if len(pTx.Creds) != len(f.signers) {
+		return nil, fmt.Errorf("%w: expected %d, got %d", ErrCredsMismatch, len(f.signers), len(pTx.Creds))
+	}
+
+	var empty [crypto.SECP256K1RSigLen]byte
+	added := make([]ids.ShortID, 0)
+	for i, v := range pTx.Creds {
+		cred, ok := v.(*secp256k1fx.Credential)
+		if !ok {
+			return added, fmt.Errorf("%w: credential %d is %T", ErrInvalidSignedTx, i, v)
+		}
+		for _, sig := range cred.Sigs {
+			if sig == empty {
+				continue
+			}
+			pk, err := (&crypto.FactorySECP256K1R{}).RecoverHashPublicKey(f.Hash(), sig[:])
+			if err != nil {
+				return added, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+			}
+			signer := pk.Address()
+			if !containsSigner(f.signers[i], signer) {
+				return added, fmt.Errorf("%w %s in credential %d", ErrUnexpectedSigner, signer, i)
+			}
+			addr, err := f.format(signer)
+			if err != nil {
+				return added, err
+			}
+			if _, ok := f.Signatures[addr]; ok {
+				continue
+			}
+			if err := f.AddSignature(signer, sig[:]); err != nil {
+				return added, err
+			}
+			added = append(added, signer)
+		}
+	}
+	return added, nil
+}
+
+func containsSigner(signers []ids.ShortID, signer ids.ShortID) bool {
+	for _, s := range signers {
+		if s == signer {
+			return true
+		}
+	}
+	return false
+}
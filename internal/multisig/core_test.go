@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package multisig
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+)
+
+func TestCore(t *testing.T) {
+	t.Parallel()
+
+	f := &crypto.FactorySECP256K1R{}
+	keys := make([]*crypto.PrivateKeySECP256K1R, 3)
+	signers := make([]ids.ShortID, 3)
+	for i := range keys {
+		k, err := f.NewPrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = k.(*crypto.PrivateKeySECP256K1R)
+		signers[i] = keys[i].PublicKey().Address()
+	}
+	pTx := &platformvm.Tx{UnsignedTx: &platformvm.UnsignedCreateSubnetTx{
+		BaseTx: platformvm.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.FujiID,
+			BlockchainID: constants.PlatformChainID,
+		}},
+		Owner: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{signers[0]}},
+	}}
+	file, err := New(constants.FujiID, "create subnet", pTx, [][]ids.ShortID{signers[:2], signers[2:]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := file.Core()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var req CoreRequest
+	if err := json.Unmarshal(b, &req); err != nil {
+		t.Fatal(err)
+	}
+	if req.ChainAlias != "P" || req.TransactionHex != "0x"+file.UnsignedTx {
+		t.Fatalf("unexpected request %+v", req)
+	}
+
+	// co-signed by the second key only, the other signatures are empty
+	sig, err := keys[1].SignHash(file.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cosigned := *pTx
+	cred0, cred1 := &secp256k1fx.Credential{Sigs: make([][crypto.SECP256K1RSigLen]byte, 2)}, &secp256k1fx.Credential{Sigs: make([][crypto.SECP256K1RSigLen]byte, 1)}
+	copy(cred0.Sigs[1][:], sig)
+	cosigned.Creds = []verify.Verifiable{cred0, cred1}
+	signed, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, &cosigned)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, s := range []string{
+		"0x" + hex.EncodeToString(signed) + "\n",
+		fmt.Sprintf("%q", "0x"+hex.EncodeToString(signed)),
+		fmt.Sprintf(`{"signedTransactionHex": "0x%x"}`, signed),
+	} {
+		b, err := ParseSignedTx([]byte(s))
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		added, err := file.AddSigned(b)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		// added once
+		exp := []ids.ShortID{}
+		if i == 0 {
+			exp = []ids.ShortID{signers[1]}
+		}
+		if !reflect.DeepEqual(added, exp) {
+			t.Fatalf("#%d: expected %v, got %v", i, exp, added)
+		}
+	}
+	if missing := file.Missing(); len(missing) != 2 {
+		t.Fatalf("expected 2 missing signatures, got %d", len(missing))
+	}
+
+	// signed by a key that is not a signer of the credential
+	sig, err = keys[2].SignHash(file.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy(cred0.Sigs[0][:], sig)
+	signed, err = codec.PCodecManager.Marshal(platformvm.CodecVersion, &cosigned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.AddSigned(signed); !errors.Is(err, ErrUnexpectedSigner) {
+		t.Fatalf("expected %v, got %v", ErrUnexpectedSigner, err)
+	}
+
+	if _, err := ParseSignedTx([]byte(`{"transactionHex": "0x00"}`)); !errors.Is(err, ErrInvalidSignedTx) {
+		t.Fatalf("expected %v, got %v", ErrInvalidSignedTx, err)
+	}
+}
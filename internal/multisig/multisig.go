@@ -0,0 +1,274 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package multisig implements the shareable file format to collect
+// the signatures of a transaction from multiple key holders.
+package multisig
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+	"github.com/ava-labs/subnet-cli/internal/txs"
+)
+
+var (
+	ErrNotSigner          = errors.New("not a signer of the transaction")
+	ErrInvalidSignature   = errors.New("invalid signature")
+	ErrMissingSignatures  = errors.New("missing signatures")
+	ErrNetworkIDMismatch  = errors.New("network ID mismatch")
+	ErrEmptyUnsignedBytes = errors.New("empty unsigned transaction")
+)
+
+const fsModeWrite = 0o600
+
+// File is the unsigned transaction, with the signatures collected so far.
+type File struct {
+	NetworkID uint32 `json:"networkId"`
+	// Description is a human-readable summary of the transaction.
+	Description string `json:"description"`
+	// UnsignedTx is the hex-encoded unsigned transaction.
+	UnsignedTx string `json:"unsignedTx"`
+	// Signers are the P-Chain addresses required to sign each credential.
+	Signers [][]string `json:"signers"`
+	// Signatures are the hex-encoded signatures, by P-Chain address.
+	Signatures map[string]string `json:"signatures"`
+
+	unsignedBytes []byte
+	signers       [][]ids.ShortID
+}
+
+// New creates the file of the unsigned [pTx].
+func New(networkID uint32, description string, pTx *platformvm.Tx, signers [][]ids.ShortID) (*File, error) {
+	unsignedBytes, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, &pTx.UnsignedTx)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
+	}
+	f := &File{
+		NetworkID:     networkID,
+		Description:   description,
+		UnsignedTx:    hex.EncodeToString(unsignedBytes),
+		Signers:       make([][]string, len(signers)),
+		Signatures:    make(map[string]string),
+		unsignedBytes: unsignedBytes,
+		signers:       signers,
+	}
+	for i, inputSigners := range signers {
+		f.Signers[i] = make([]string, len(inputSigners))
+		for j, signer := range inputSigners {
+			f.Signers[i][j], err = f.format(signer)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return f, nil
+}
+
+// Load loads the file, and verifies all of its signatures.
+func Load(p string) (*File, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	f := new(File)
+	if err := json.Unmarshal(b, f); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", p, err)
+	}
+	f.unsignedBytes, err = hex.DecodeString(f.UnsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	if len(f.unsignedBytes) == 0 {
+		return nil, ErrEmptyUnsignedBytes
+	}
+	f.signers = make([][]ids.ShortID, len(f.Signers))
+	for i, inputSigners := range f.Signers {
+		f.signers[i] = make([]ids.ShortID, len(inputSigners))
+		for j, signer := range inputSigners {
+			f.signers[i][j], err = f.parse(signer)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if f.Signatures == nil {
+		f.Signatures = make(map[string]string)
+	}
+	for addr, sig := range f.Signatures {
+		signer, err := f.parse(addr)
+		if err != nil {
+			return nil, err
+		}
+		b, err := hex.DecodeString(sig)
+		if err != nil {
+			return nil, err
+		}
+		if err := f.verify(signer, b); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// CheckChain returns an error if the unsigned transaction does not embed
+// the network ID of the file and [blockchainID].
+func (f *File) CheckChain(blockchainID ids.ID) error {
+	s, err := txs.Decode(f.unsignedBytes)
+	if err != nil {
+		return err
+	}
+	return s.CheckChain(f.NetworkID, blockchainID)
+}
+
+// Save writes the file to [p].
+func (f *File) Save(p string) error {
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p, b, fsModeWrite)
+}
+
+// Hash returns the hash of the unsigned transaction to sign.
+func (f *File) Hash() []byte {
+	return hashing.ComputeHash256(f.unsignedBytes)
+}
+
+// Required returns all the unique signers, sorted.
+func (f *File) Required() []ids.ShortID {
+	set := ids.ShortSet{}
+	for _, inputSigners := range f.signers {
+		set.Add(inputSigners...)
+	}
+	required := set.List()
+	sort.Slice(required, func(i, j int) bool {
+		return required[i].String() < required[j].String()
+	})
+	return required
+}
+
+// Missing returns the signers whose signature is yet to be collected.
+func (f *File) Missing() []ids.ShortID {
+	missing := make([]ids.ShortID, 0)
+	for _, signer := range f.Required() {
+		addr, err := f.format(signer)
+		if err != nil {
+			continue
+		}
+		if _, ok := f.Signatures[addr]; !ok {
+			missing = append(missing, signer)
+		}
+	}
+	return missing
+}
+
+// AddSignature verifies and adds the signature of [signer].
+func (f *File) AddSignature(signer ids.ShortID, sig []byte) error {
+	if err := f.verify(signer, sig); err != nil {
+		return err
+	}
+	addr, err := f.format(signer)
+	if err != nil {
+		return err
+	}
+	f.Signatures[addr] = hex.EncodeToString(sig)
+	return nil
+}
+
+// Unsigned decodes the unsigned transaction.
+func (f *File) Unsigned() (platformvm.UnsignedTx, error) {
+	var utx platformvm.UnsignedTx
+	if _, err := codec.PCodecManager.Unmarshal(f.unsignedBytes, &utx); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal UnsignedTx: %w", err)
+	}
+	return utx, nil
+}
+
+// Tx returns the signed transaction, once all signatures are collected.
+func (f *File) Tx() (*platformvm.Tx, error) {
+	if missing := f.Missing(); len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %d of %d", ErrMissingSignatures, len(missing), len(f.Required()))
+	}
+	pTx := new(platformvm.Tx)
+	if _, err := codec.PCodecManager.Unmarshal(f.unsignedBytes, &pTx.UnsignedTx); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal UnsignedTx: %w", err)
+	}
+	for _, inputSigners := range f.signers {
+		cred := &secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(inputSigners)),
+		}
+		for i, signer := range inputSigners {
+			addr, err := f.format(signer)
+			if err != nil {
+				return nil, err
+			}
+			sig, err := hex.DecodeString(f.Signatures[addr])
+			if err != nil {
+				return nil, err
+			}
+			copy(cred.Sigs[i][:], sig)
+		}
+		pTx.Creds = append(pTx.Creds, cred)
+	}
+	signedBytes, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, pTx)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal signed tx: %w", err)
+	}
+	pTx.Initialize(f.unsignedBytes, signedBytes)
+	return pTx, nil
+}
+
+// verify checks that [sig] is the signature of the unsigned transaction
+// by [signer], which must be one of the required signers.
+func (f *File) verify(signer ids.ShortID, sig []byte) error {
+	required := false
+	for _, s := range f.Required() {
+		if s == signer {
+			required = true
+			break
+		}
+	}
+	if !required {
+		return fmt.Errorf("%w: %s", ErrNotSigner, signer)
+	}
+	if len(sig) != crypto.SECP256K1RSigLen {
+		return fmt.Errorf("%w: unexpected length %d", ErrInvalidSignature, len(sig))
+	}
+	pk, err := (&crypto.FactorySECP256K1R{}).RecoverHashPublicKey(f.Hash(), sig)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	if pk.Address() != signer {
+		return fmt.Errorf("%w: not signed by %s", ErrInvalidSignature, signer)
+	}
+	return nil
+}
+
+func (f *File) format(addr ids.ShortID) (string, error) {
+	return formatting.FormatAddress("P", constants.GetHRP(f.NetworkID), addr.Bytes())
+}
+
+func (f *File) parse(addr string) (ids.ShortID, error) {
+	_, hrp, b, err := formatting.ParseAddress(addr)
+	if err != nil {
+		return ids.ShortEmpty, err
+	}
+	if hrp != constants.GetHRP(f.NetworkID) {
+		return ids.ShortEmpty, fmt.Errorf("%w: address %q", ErrNetworkIDMismatch, addr)
+	}
+	return ids.ToShortID(b)
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var ErrNotBootstrapped = errors.New("chain not bootstrapped")
+
+// WaitBootstrapped polls the nodes of [cs] every [interval] until all
+// bootstrapped the [chain]. The nodes that do not run the chain yet
+// (e.g., not yet restarted to track its subnet) are polled as not
+// bootstrapped. [progress] is called once per node when it bootstraps,
+// with the number of nodes bootstrapped so far. On the context expiry,
+// it returns the indexes of the nodes not bootstrapped.
+func WaitBootstrapped(
+	ctx context.Context,
+	cs []Client,
+	chain string,
+	interval time.Duration,
+	progress func(i int, done int, took time.Duration),
+) ([]int, error) {
+	start := time.Now()
+	pending := make(map[int]struct{}, len(cs))
+	for i := range cs {
+		pending[i] = struct{}{}
+	}
+
+	// poll first with no wait
+	tc := time.NewTicker(1)
+	defer tc.Stop()
+	for len(pending) > 0 {
+		select {
+		case <-ctx.Done():
+			left := make([]int, 0, len(pending))
+			for i := range cs {
+				if _, ok := pending[i]; ok {
+					left = append(left, i)
+				}
+			}
+			return left, fmt.Errorf("%w on %d of %d node(s): %v", ErrNotBootstrapped, len(left), len(cs), ctx.Err())
+		case <-tc.C:
+			tc.Reset(interval)
+		}
+
+		for i := range cs {
+			if _, ok := pending[i]; !ok {
+				continue
+			}
+			bootstrapped, err := cs[i].Bootstrapped(ctx, chain)
+			if err != nil {
+				zap.L().Debug("failed to check bootstrapped", zap.Int("node", i), zap.Error(err))
+				continue
+			}
+			if !bootstrapped {
+				continue
+			}
+			delete(pending, i)
+			if progress != nil {
+				progress(i, len(cs)-len(pending), time.Since(start))
+			}
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// bootstrapServer is bootstrapped from the [after]th call, and does not
+// run the chain before the [unknown]th call.
+func bootstrapServer(after int64, unknown int64) *httptest.Server {
+	var calls int64
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&calls, 1)
+		if n < unknown {
+			w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"there is no chain with alias/ID"}}`))
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"isBootstrapped":%t}}`, after > 0 && n >= after)
+	}))
+}
+
+func TestWaitBootstrapped(t *testing.T) {
+	t.Parallel()
+
+	srvs := []*httptest.Server{
+		bootstrapServer(1, 0),
+		bootstrapServer(3, 2),
+		bootstrapServer(0, 0),
+	}
+	cs := make([]Client, len(srvs))
+	for i, srv := range srvs {
+		defer srv.Close()
+		cs[i] = New(srv.URL)
+	}
+
+	var got []int
+	progress := func(i int, done int, _ time.Duration) {
+		if done != len(got)+1 {
+			t.Errorf("expected %d done, got %d", len(got)+1, done)
+		}
+		got = append(got, i)
+	}
+	left, err := WaitBootstrapped(context.Background(), cs[:2], "abc", time.Millisecond, progress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) != 0 || !reflect.DeepEqual(got, []int{0, 1}) {
+		t.Fatalf("unexpected left %v, bootstrapped %v", left, got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	left, err = WaitBootstrapped(ctx, cs, "abc", time.Millisecond, nil)
+	if !errors.Is(err, ErrNotBootstrapped) {
+		t.Fatalf("expected %v, got %v", ErrNotBootstrapped, err)
+	}
+	if !reflect.DeepEqual(left, []int{2}) {
+		t.Fatalf("unexpected left %v", left)
+	}
+}
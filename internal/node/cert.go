@@ -0,0 +1,33 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+var ErrInvalidCert = errors.New("invalid staking certificate")
+
+// NodeIDFromCert computes the node ID of the PEM-encoded TLS staking
+// certificate (e.g., "~/.avalanchego/staking/staker.crt"), as the node
+// derives it on start (ref. "node.Node.initNodeID").
+func NodeIDFromCert(pemBytes []byte) (ids.ShortID, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return ids.ShortEmpty, fmt.Errorf("%w: no PEM block found", ErrInvalidCert)
+	}
+	if block.Type != "CERTIFICATE" {
+		return ids.ShortEmpty, fmt.Errorf("%w: unexpected PEM block %q (must be the certificate, not the key)", ErrInvalidCert, block.Type)
+	}
+	if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+		return ids.ShortEmpty, fmt.Errorf("%w: %v", ErrInvalidCert, err)
+	}
+	return ids.ToShortID(hashing.PubkeyBytesToAddress(block.Bytes))
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+func TestNodeIDFromCert(t *testing.T) {
+	t.Parallel()
+
+	certBytes, keyBytes, err := staking.NewCertAndKeyBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := staking.LoadTLSCertFromBytes(keyBytes, certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// as the node computes its ID from the loaded certificate
+	expected, err := ids.ToShortID(hashing.PubkeyBytesToAddress(cert.Leaf.Raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tt := []struct {
+		pem      []byte
+		expected ids.ShortID
+		err      error
+	}{
+		{pem: certBytes, expected: expected},
+		{pem: keyBytes, err: ErrInvalidCert},
+		{pem: []byte("not a certificate"), err: ErrInvalidCert},
+		{pem: []byte("-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n"), err: ErrInvalidCert},
+	}
+	for i, tv := range tt {
+		nodeID, err := NodeIDFromCert(tv.pem)
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+		if nodeID != tv.expected {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.expected, nodeID)
+		}
+	}
+}
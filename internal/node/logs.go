@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultLogDir is the default log directory of the node
+// (ref. "utils/logging.DefaultLogDirectory").
+const DefaultLogDir = "~/.avalanchego/logs"
+
+// ChainLogPath returns the log file of the chain in the log directory
+// of the node, "~" being the home directory.
+func ChainLogPath(logDir string, chainID string) (string, error) {
+	if strings.HasPrefix(logDir, "~") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		logDir = filepath.Join(home, strings.TrimPrefix(logDir, "~"))
+	}
+	return filepath.Join(logDir, chainID+".log"), nil
+}
+
+// Tail writes the last [lines] lines of the file to [w] (all of them if
+// negative). With [follow], it then polls the file every [interval] and
+// writes the appended lines, until the context is done. The node rotates
+// the file by renaming it, so the file is reopened once it shrinks or is
+// replaced.
+func Tail(ctx context.Context, path string, lines int, follow bool, interval time.Duration, w io.Writer) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { f.Close() }()
+
+	r := bufio.NewReader(f)
+	var last []string
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF {
+			// a partial line is written on the next read
+			if _, err := f.Seek(-int64(len(line)), io.SeekCurrent); err != nil {
+				return err
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+		last = append(last, line)
+		if lines >= 0 && len(last) > lines {
+			last = last[1:]
+		}
+	}
+	for _, line := range last {
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	if !follow {
+		return nil
+	}
+
+	r.Reset(f)
+	tc := time.NewTicker(interval)
+	defer tc.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-tc.C:
+		}
+		for {
+			line, err := r.ReadString('\n')
+			if err == io.EOF {
+				if _, err := f.Seek(-int64(len(line)), io.SeekCurrent); err != nil {
+					return err
+				}
+				r.Reset(f)
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if _, err := io.WriteString(w, line); err != nil {
+				return err
+			}
+		}
+		rotated, err := rotated(f, path)
+		if err != nil {
+			return err
+		}
+		if rotated {
+			f.Close()
+			if f, err = os.Open(path); err != nil {
+				return err
+			}
+			r.Reset(f)
+		}
+	}
+}
+
+// rotated returns true if [path] no longer is the open file, or shrank
+// below the read offset.
+func rotated(f *os.File, path string) (bool, error) {
+	fi, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		// not yet recreated
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	cur, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+	if !os.SameFile(fi, cur) {
+		return true, nil
+	}
+	off, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return false, err
+	}
+	return fi.Size() < off, nil
+}
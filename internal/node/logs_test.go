@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a buffer written by "Tail" while read by the test.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestTail(t *testing.T) {
+	t.Parallel()
+
+	p := filepath.Join(t.TempDir(), "chain.log")
+	if err := ioutil.WriteFile(p, []byte("a\nb\nc\npartial"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tt := []struct {
+		lines    int
+		expected string
+	}{
+		{lines: 2, expected: "b\nc\n"},
+		{lines: 0, expected: ""},
+		{lines: -1, expected: "a\nb\nc\n"},
+	}
+	for i, tv := range tt {
+		buf := bytes.NewBuffer(nil)
+		if err := Tail(context.Background(), p, tv.lines, false, time.Millisecond, buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tv.expected {
+			t.Fatalf("#%d: expected %q, got %q", i, tv.expected, buf.String())
+		}
+	}
+}
+
+func TestTailFollow(t *testing.T) {
+	t.Parallel()
+
+	p := filepath.Join(t.TempDir(), "chain.log")
+	if err := ioutil.WriteFile(p, []byte("a\npart"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	buf := new(syncBuffer)
+	done := make(chan error)
+	go func() {
+		done <- Tail(ctx, p, 10, true, 5*time.Millisecond, buf)
+	}()
+	waitFor := func(s string) {
+		for !strings.HasSuffix(buf.String(), s) {
+			select {
+			case <-ctx.Done():
+				t.Fatalf("expected %q, got %q", s, buf.String())
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+	}
+	waitFor("a\n")
+
+	f, err := os.OpenFile(p, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("ial\nb\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	waitFor("a\npartial\nb\n")
+
+	// rotated by renaming, as the node does
+	if err := os.Rename(p, p+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(p, []byte("c\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("a\npartial\nb\nc\n")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
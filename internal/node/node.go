@@ -0,0 +1,237 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package node implements a minimal client of the avalanche node
+// "admin" and "info" APIs.
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	ErrRPC           = errors.New("rpc error")
+	ErrAPIDisabled   = errors.New("API is not enabled on the node")
+	ErrAliasMismatch = errors.New("alias resolves to another chain")
+)
+
+const (
+	AdminEndpoint  = "/ext/admin"
+	InfoEndpoint   = "/ext/info"
+	HealthEndpoint = "/ext/health"
+	PEndpoint      = "/ext/P"
+)
+
+type Client interface {
+	// Call calls the JSON-RPC [method] at [endpoint] (e.g., "/ext/admin"),
+	// and decodes the result into [result].
+	Call(ctx context.Context, endpoint string, method string, params interface{}, result interface{}) error
+	// AliasChain sets [alias] for the chain (requires "--api-admin-enabled").
+	AliasChain(ctx context.Context, chain string, alias string) error
+	// BlockchainID resolves the blockchain ID of [alias].
+	BlockchainID(ctx context.Context, alias string) (string, error)
+	// Healthy returns true if all the node health checks pass.
+	Healthy(ctx context.Context) (bool, error)
+	// HealthChecks returns the names of the node health checks, which
+	// include the IDs of the chains the node runs.
+	HealthChecks(ctx context.Context) (healthy bool, checks []string, err error)
+	// NodeID returns the node ID (e.g., "NodeID-...").
+	NodeID(ctx context.Context) (string, error)
+	// Version returns the node version (e.g., "avalanche/1.7.6").
+	Version(ctx context.Context) (string, error)
+	// Bootstrapped returns true if the node bootstrapped [chain].
+	Bootstrapped(ctx context.Context, chain string) (bool, error)
+	// Blockchains returns the subnet IDs of all blockchains, by blockchain ID.
+	Blockchains(ctx context.Context) (map[string]string, error)
+	// LoggerLevel returns the log level of the [logger] (e.g., a chain ID),
+	// which only exists once the chain is initialized (requires
+	// "--api-admin-enabled").
+	LoggerLevel(ctx context.Context, logger string) (string, error)
+	// SetLoggerLevel sets the log level of the [logger] (requires
+	// "--api-admin-enabled").
+	SetLoggerLevel(ctx context.Context, logger string, level string) error
+}
+
+var _ Client = &client{}
+
+type client struct {
+	uri string
+	id  uint64
+}
+
+// New creates a client of the node at [uri] (e.g., "http://localhost:9650").
+func New(uri string) Client {
+	return &client{uri: strings.TrimSuffix(uri, "/")}
+}
+
+func (c *client) Call(ctx context.Context, endpoint string, method string, params interface{}, result interface{}) error {
+	if params == nil {
+		params = struct{}{}
+	}
+	b, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      atomic.AddUint64(&c.id, 1),
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.uri+endpoint, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s%s", ErrAPIDisabled, c.uri, endpoint)
+	}
+
+	var msg struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&msg); err != nil {
+		return fmt.Errorf("%w: unexpected response (status %d): %v", ErrRPC, resp.StatusCode, err)
+	}
+	if msg.Error != nil {
+		return fmt.Errorf("%w: %s: %s (code %d)", ErrRPC, method, msg.Error.Message, msg.Error.Code)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(msg.Result, result)
+}
+
+func (c *client) AliasChain(ctx context.Context, chain string, alias string) error {
+	return c.Call(ctx, AdminEndpoint, "admin.aliasChain", map[string]string{
+		"chain": chain,
+		"alias": alias,
+	}, nil)
+}
+
+func (c *client) BlockchainID(ctx context.Context, alias string) (string, error) {
+	var res struct {
+		BlockchainID string `json:"blockchainID"`
+	}
+	err := c.Call(ctx, InfoEndpoint, "info.getBlockchainID", map[string]string{
+		"alias": alias,
+	}, &res)
+	return res.BlockchainID, err
+}
+
+func (c *client) Healthy(ctx context.Context) (bool, error) {
+	healthy, _, err := c.HealthChecks(ctx)
+	return healthy, err
+}
+
+func (c *client) HealthChecks(ctx context.Context) (bool, []string, error) {
+	var res struct {
+		Healthy bool                       `json:"healthy"`
+		Checks  map[string]json.RawMessage `json:"checks"`
+	}
+	if err := c.Call(ctx, HealthEndpoint, "health.health", nil, &res); err != nil {
+		return false, nil, err
+	}
+	checks := make([]string, 0, len(res.Checks))
+	for name := range res.Checks {
+		checks = append(checks, name)
+	}
+	sort.Strings(checks)
+	return res.Healthy, checks, nil
+}
+
+func (c *client) NodeID(ctx context.Context) (string, error) {
+	var res struct {
+		NodeID string `json:"nodeID"`
+	}
+	err := c.Call(ctx, InfoEndpoint, "info.getNodeID", nil, &res)
+	return res.NodeID, err
+}
+
+func (c *client) Version(ctx context.Context) (string, error) {
+	var res struct {
+		Version string `json:"version"`
+	}
+	err := c.Call(ctx, InfoEndpoint, "info.getNodeVersion", nil, &res)
+	return res.Version, err
+}
+
+func (c *client) Bootstrapped(ctx context.Context, chain string) (bool, error) {
+	var res struct {
+		IsBootstrapped bool `json:"isBootstrapped"`
+	}
+	err := c.Call(ctx, InfoEndpoint, "info.isBootstrapped", map[string]string{
+		"chain": chain,
+	}, &res)
+	return res.IsBootstrapped, err
+}
+
+func (c *client) Blockchains(ctx context.Context) (map[string]string, error) {
+	var res struct {
+		Blockchains []struct {
+			ID       string `json:"id"`
+			SubnetID string `json:"subnetID"`
+		} `json:"blockchains"`
+	}
+	if err := c.Call(ctx, PEndpoint, "platform.getBlockchains", nil, &res); err != nil {
+		return nil, err
+	}
+	chains := make(map[string]string, len(res.Blockchains))
+	for _, b := range res.Blockchains {
+		chains[b.ID] = b.SubnetID
+	}
+	return chains, nil
+}
+
+func (c *client) LoggerLevel(ctx context.Context, logger string) (string, error) {
+	var res struct {
+		LoggerLevels map[string]struct {
+			LogLevel string `json:"logLevel"`
+		} `json:"loggerLevels"`
+	}
+	if err := c.Call(ctx, AdminEndpoint, "admin.getLoggerLevel", map[string]string{
+		"loggerName": logger,
+	}, &res); err != nil {
+		return "", err
+	}
+	l, ok := res.LoggerLevels[logger]
+	if !ok {
+		return "", fmt.Errorf("%w: no logger %q", ErrRPC, logger)
+	}
+	return l.LogLevel, nil
+}
+
+func (c *client) SetLoggerLevel(ctx context.Context, logger string, level string) error {
+	return c.Call(ctx, AdminEndpoint, "admin.setLoggerLevel", map[string]string{
+		"loggerName": logger,
+		"logLevel":   level,
+	}, nil)
+}
+
+// VerifyAlias checks that [alias] resolves to [chainID].
+func VerifyAlias(ctx context.Context, c Client, chainID string, alias string) error {
+	resolved, err := c.BlockchainID(ctx, alias)
+	if err != nil {
+		return err
+	}
+	if resolved != chainID {
+		return fmt.Errorf("%w: %q resolves to %s, expected %s", ErrAliasMismatch, alias, resolved, chainID)
+	}
+	return nil
+}
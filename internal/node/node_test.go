@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAliasChain(t *testing.T) {
+	t.Parallel()
+
+	aliases := make(map[string]string)
+	mux := http.NewServeMux()
+	mux.HandleFunc(AdminEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params map[string]string `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		aliases[req.Params["alias"]] = req.Params["chain"]
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"success":true}}`))
+	})
+	mux.HandleFunc(InfoEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params map[string]string `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		id, ok := aliases[req.Params["alias"]]
+		if !ok {
+			w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"not found"}}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  map[string]string{"blockchainID": id},
+		})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cli := New(srv.URL + "/")
+	ctx := context.Background()
+	if err := VerifyAlias(ctx, cli, "chain1", "myevm"); !errors.Is(err, ErrRPC) {
+		t.Fatalf("expected %v, got %v", ErrRPC, err)
+	}
+	if err := cli.AliasChain(ctx, "chain1", "myevm"); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyAlias(ctx, cli, "chain1", "myevm"); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyAlias(ctx, cli, "chain2", "myevm"); !errors.Is(err, ErrAliasMismatch) {
+		t.Fatalf("expected %v, got %v", ErrAliasMismatch, err)
+	}
+}
+
+func TestAPIDisabled(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	if err := New(srv.URL).AliasChain(context.Background(), "chain1", "myevm"); !errors.Is(err, ErrAPIDisabled) {
+		t.Fatalf("expected %v, got %v", ErrAPIDisabled, err)
+	}
+}
+
+func TestPing(t *testing.T) {
+	t.Parallel()
+
+	results := map[string]string{
+		"info.getNodeID":          `{"nodeID":"NodeID-1"}`,
+		"info.getNodeVersion":     `{"version":"avalanche/1.7.6"}`,
+		"info.isBootstrapped":     `{"isBootstrapped":true}`,
+		"health.health":           `{"healthy":true,"checks":{"network":{},"chainP":{},"chainA":{},"chainB":{}}}`,
+		"platform.getBlockchains": `{"blockchains":[{"id":"chainP","subnetID":"` + PrimaryNetworkID + `"},{"id":"chainA","subnetID":"subnetA"},{"id":"chainB","subnetID":"subnetA"},{"id":"chainC","subnetID":"subnetC"}]}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + results[req.Method] + `}`))
+	}))
+	defer srv.Close()
+
+	s, err := Ping(context.Background(), New(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.NodeID != "NodeID-1" || s.Version != "avalanche/1.7.6" || !s.Healthy || !s.Bootstrapped {
+		t.Fatalf("unexpected status %+v", s)
+	}
+	if len(s.TrackedSubnets) != 1 || s.TrackedSubnets[0] != "subnetA" {
+		t.Fatalf("unexpected tracked subnets %v", s.TrackedSubnets)
+	}
+}
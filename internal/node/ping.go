@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"sort"
+)
+
+// PrimaryNetworkID is the subnet ID of the primary network.
+const PrimaryNetworkID = "11111111111111111111111111111111LpoYY"
+
+// primaryChains are the aliases of the primary network chains.
+var primaryChains = []string{"P", "X", "C"}
+
+// Status is the readiness of a node.
+type Status struct {
+	NodeID  string
+	Version string
+	Healthy bool
+	// Bootstrapped is true once the node bootstrapped the P, X, and C chains.
+	Bootstrapped bool
+	// TrackedSubnets are the sorted IDs of the subnets with at least one
+	// chain run by the node, excluding the primary network.
+	TrackedSubnets []string
+}
+
+// Ping collects the status of the node, and returns the first error.
+func Ping(ctx context.Context, c Client) (*Status, error) {
+	s := new(Status)
+	var err error
+	s.NodeID, err = c.NodeID(ctx)
+	if err != nil {
+		return s, err
+	}
+	s.Version, err = c.Version(ctx)
+	if err != nil {
+		return s, err
+	}
+	s.Bootstrapped = true
+	for _, chain := range primaryChains {
+		bootstrapped, err := c.Bootstrapped(ctx, chain)
+		if err != nil {
+			return s, err
+		}
+		s.Bootstrapped = s.Bootstrapped && bootstrapped
+	}
+
+	var checks []string
+	s.Healthy, checks, err = c.HealthChecks(ctx)
+	if err != nil {
+		return s, err
+	}
+	chains, err := c.Blockchains(ctx)
+	if err != nil {
+		return s, err
+	}
+	tracked := make(map[string]struct{})
+	for _, check := range checks {
+		// chain health checks are named by chain ID
+		subnetID, ok := chains[check]
+		if !ok || subnetID == PrimaryNetworkID {
+			continue
+		}
+		tracked[subnetID] = struct{}{}
+	}
+	for subnetID := range tracked {
+		s.TrackedSubnets = append(s.TrackedSubnets, subnetID)
+	}
+	sort.Strings(s.TrackedSubnets)
+	return s, nil
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nodeconfig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Host is where the node runs.
+type Host interface {
+	// Read reads the file at [path].
+	Read(ctx context.Context, path string) ([]byte, error)
+	// Write replaces the file at [path], keeping a ".bak" copy of the
+	// previous content.
+	Write(ctx context.Context, path string, b []byte) error
+	// Run runs the shell command (e.g., to restart the node).
+	Run(ctx context.Context, command string) error
+	// Stream runs the shell command, writing its output to [w] as it runs
+	// (e.g., to follow a log file).
+	Stream(ctx context.Context, command string, w io.Writer) error
+	String() string
+}
+
+var _ Host = &local{}
+
+type local struct{}
+
+// Local returns the host of the current machine.
+func Local() Host { return &local{} }
+
+func (l *local) Read(_ context.Context, path string) ([]byte, error) {
+	return ioutil.ReadFile(path)
+}
+
+func (l *local) Write(_ context.Context, path string, b []byte) error {
+	mode := os.FileMode(0o644)
+	if fi, err := os.Stat(path); err == nil {
+		mode = fi.Mode()
+		prev, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path+".bak", prev, mode); err != nil {
+			return err
+		}
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (l *local) Run(ctx context.Context, command string) error {
+	return run(exec.CommandContext(ctx, "sh", "-c", command), nil)
+}
+
+func (l *local) Stream(ctx context.Context, command string, w io.Writer) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdout = w
+	return run(cmd, nil)
+}
+
+func (l *local) String() string { return "localhost" }
+
+var _ Host = &sshHost{}
+
+type sshHost struct {
+	target string
+	args   []string
+}
+
+// SSH returns the remote host reached with the "ssh" binary, so that the
+// user's SSH config and agent apply (e.g., target "ubuntu@10.0.0.1").
+func SSH(target string, args ...string) Host {
+	return &sshHost{target: target, args: args}
+}
+
+func (s *sshHost) Read(ctx context.Context, path string) ([]byte, error) {
+	var out bytes.Buffer
+	cmd := s.command(ctx, "cat "+ShellPath(path))
+	cmd.Stdout = &out
+	if err := run(cmd, nil); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func (s *sshHost) Write(ctx context.Context, path string, b []byte) error {
+	p, bak, tmp := ShellPath(path), ShellPath(path+".bak"), ShellPath(path+".tmp")
+	script := fmt.Sprintf("if [ -f %s ]; then cp -p %s %s; fi && cat > %s && mv %s %s", p, p, bak, tmp, tmp, p)
+	return run(s.command(ctx, script), b)
+}
+
+func (s *sshHost) Run(ctx context.Context, command string) error {
+	return run(s.command(ctx, command), nil)
+}
+
+func (s *sshHost) Stream(ctx context.Context, command string, w io.Writer) error {
+	cmd := s.command(ctx, command)
+	cmd.Stdout = w
+	return run(cmd, nil)
+}
+
+func (s *sshHost) String() string { return s.target }
+
+func (s *sshHost) command(ctx context.Context, remote string) *exec.Cmd {
+	args := append(append([]string{}, s.args...), s.target, remote)
+	return exec.CommandContext(ctx, "ssh", args...)
+}
+
+func run(cmd *exec.Cmd, stdin []byte) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%q failed: %w (%s)", strings.Join(cmd.Args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// ShellPath quotes the path for the commands of "Host.Run" and
+// "Host.Stream", expanding the leading "~/" on the host.
+func ShellPath(p string) string {
+	if strings.HasPrefix(p, "~/") {
+		return `"$HOME"/` + quote(p[2:])
+	}
+	return quote(p)
+}
+
+// quote quotes the shell word.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package nodeconfig updates the avalanche node config file.
+package nodeconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var ErrInvalidConfig = errors.New("invalid node config")
+
+const (
+	// WhitelistedSubnetsKey is the config key of the tracked subnets.
+	WhitelistedSubnetsKey = "whitelisted-subnets"
+	// TrackSubnetsKey is the newer name of [WhitelistedSubnetsKey],
+	// used if already present in the config.
+	TrackSubnetsKey = "track-subnets"
+)
+
+// TrackSubnet adds the subnet to the comma-separated tracked subnets of
+// the JSON node config, and returns the updated config. It returns false
+// if the subnet is already tracked. The other config keys are kept.
+func TrackSubnet(config []byte, subnetID string) ([]byte, bool, error) {
+	cfg := make(map[string]json.RawMessage)
+	if len(bytes.TrimSpace(config)) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, false, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+	}
+	key := WhitelistedSubnetsKey
+	if _, ok := cfg[TrackSubnetsKey]; ok {
+		key = TrackSubnetsKey
+	}
+
+	subnets, err := Tracked(cfg[key])
+	if err != nil {
+		return nil, false, err
+	}
+	for _, s := range subnets {
+		if s == subnetID {
+			return config, false, nil
+		}
+	}
+	subnets = append(subnets, subnetID)
+	sort.Strings(subnets)
+	v, err := json.Marshal(strings.Join(subnets, ","))
+	if err != nil {
+		return nil, false, err
+	}
+	cfg[key] = v
+
+	updated, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return nil, false, err
+	}
+	return append(updated, '\n'), true, nil
+}
+
+// Tracked parses the comma-separated subnet IDs of the config value.
+func Tracked(v json.RawMessage) ([]string, error) {
+	if len(v) == 0 {
+		return nil, nil
+	}
+	var s string
+	if err := json.Unmarshal(v, &s); err != nil {
+		return nil, fmt.Errorf("%w: tracked subnets must be a comma-separated string: %v", ErrInvalidConfig, err)
+	}
+	var subnets []string
+	for _, id := range strings.Split(s, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			subnets = append(subnets, id)
+		}
+	}
+	return subnets, nil
+}
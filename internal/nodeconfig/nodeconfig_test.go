@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nodeconfig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestTrackSubnet(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		config  string
+		key     string
+		tracked string
+		changed bool
+		err     error
+	}{
+		{``, WhitelistedSubnetsKey, "s2", true, nil},
+		{`{"network-id":"fuji"}`, WhitelistedSubnetsKey, "s2", true, nil},
+		{`{"whitelisted-subnets":"s3, s1"}`, WhitelistedSubnetsKey, "s1,s2,s3", true, nil},
+		{`{"track-subnets":"s1"}`, TrackSubnetsKey, "s1,s2", true, nil},
+		{`{"whitelisted-subnets":"s1,s2"}`, WhitelistedSubnetsKey, "s1,s2", false, nil},
+		{`{"whitelisted-subnets":["s1"]}`, "", "", false, ErrInvalidConfig},
+		{`{`, "", "", false, ErrInvalidConfig},
+	}
+	for i, tv := range tt {
+		updated, changed, err := TrackSubnet([]byte(tv.config), "s2")
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if changed != tv.changed {
+			t.Fatalf("#%d: expected changed %v, got %v", i, tv.changed, changed)
+		}
+		cfg := make(map[string]interface{})
+		if err := json.Unmarshal(updated, &cfg); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if cfg[tv.key] != tv.tracked && changed {
+			t.Fatalf("#%d: expected %q, got %v", i, tv.tracked, cfg[tv.key])
+		}
+		if tv.config == `{"network-id":"fuji"}` && cfg["network-id"] != "fuji" {
+			t.Fatalf("#%d: lost the other keys %v", i, cfg)
+		}
+	}
+}
+
+func TestLocalWrite(t *testing.T) {
+	t.Parallel()
+
+	p := filepath.Join(t.TempDir(), "config.json")
+	h := Local()
+	ctx := context.Background()
+	if err := h.Write(ctx, p, []byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Write(ctx, p, []byte("b")); err != nil {
+		t.Fatal(err)
+	}
+	b, err := h.Read(ctx, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bak, err := ioutil.ReadFile(p + ".bak")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "b" || string(bak) != "a" {
+		t.Fatalf("unexpected content %q, backup %q", b, bak)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package onboarding generates the bundle of a new validator operator of
+// a subnet: everything needed to run the subnet chains, and to verify
+// them against the on-chain data.
+package onboarding
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"path"
+	"text/template"
+	"time"
+
+	"github.com/ava-labs/subnet-cli/internal/nodeconfig"
+)
+
+// Chain is a blockchain of the subnet, as created on the P-Chain.
+type Chain struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	VMID  string   `json:"vmId"`
+	FxIDs []string `json:"fxIds,omitempty"`
+	// GenesisSHA256 is the hex-encoded SHA-256 of the genesis bytes.
+	GenesisSHA256 string `json:"genesisSha256"`
+
+	genesis []byte
+	config  []byte
+}
+
+// NewChain creates the chain of the [genesis] bytes, with the chain
+// [config] of the node (nil if none).
+func NewChain(id string, name string, vmID string, fxIDs []string, genesis []byte, config []byte) Chain {
+	h := sha256.Sum256(genesis)
+	return Chain{
+		ID:            id,
+		Name:          name,
+		VMID:          vmID,
+		FxIDs:         fxIDs,
+		GenesisSHA256: hex.EncodeToString(h[:]),
+		genesis:       genesis,
+		config:        config,
+	}
+}
+
+// VMBinary describes the VM binary the operators should run.
+type VMBinary struct {
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+	// Source is where to get the binary (e.g., a release URL).
+	Source string `json:"source,omitempty"`
+}
+
+// Bundle is the "manifest.json" of the onboarding bundle.
+type Bundle struct {
+	NetworkID   uint32    `json:"networkId"`
+	NetworkName string    `json:"networkName"`
+	SubnetID    string    `json:"subnetId"`
+	ControlKeys []string  `json:"controlKeys"`
+	Threshold   uint32    `json:"threshold"`
+	Chains      []Chain   `json:"chains"`
+	VMBinary    *VMBinary `json:"vmBinary,omitempty"`
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// Dir returns the top directory of the bundle archive.
+func (b *Bundle) Dir() string {
+	return "onboarding-" + b.SubnetID
+}
+
+// Write writes the bundle as a gzipped tarball:
+//
+//	onboarding-<subnet ID>/
+//	  manifest.json
+//	  README.md
+//	  node-config-snippet.json
+//	  chains/<blockchain ID>/genesis.json
+//	  chains/<blockchain ID>/config.json (if any)
+func (b *Bundle) Write(w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	manifest, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	snippet, _, err := nodeconfig.TrackSubnet(nil, b.SubnetID)
+	if err != nil {
+		return err
+	}
+	readme := new(bytes.Buffer)
+	if err := readmeTemplate.Execute(readme, b); err != nil {
+		return err
+	}
+	files := []struct {
+		name string
+		b    []byte
+	}{
+		{"manifest.json", append(manifest, '\n')},
+		{"README.md", readme.Bytes()},
+		{"node-config-snippet.json", snippet},
+	}
+	for _, c := range b.Chains {
+		files = append(files, struct {
+			name string
+			b    []byte
+		}{path.Join("chains", c.ID, "genesis.json"), c.genesis})
+		if c.config != nil {
+			files = append(files, struct {
+				name string
+				b    []byte
+			}{path.Join("chains", c.ID, "config.json"), c.config})
+		}
+	}
+	for _, f := range files {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:     path.Join(b.Dir(), f.name),
+			Mode:     0o644,
+			Size:     int64(len(f.b)),
+			ModTime:  b.GeneratedAt,
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(f.b); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+var readmeTemplate = template.Must(template.New("README.md").Parse(`# Validating subnet {{.SubnetID}}
+
+Network: {{.NetworkName}} (ID {{.NetworkID}}), generated at {{.GeneratedAt.Format "2006-01-02T15:04:05Z07:00"}}
+from the P-Chain. The subnet is controlled by {{.Threshold}} of {{len .ControlKeys}} control key(s).
+
+## Chains
+{{range .Chains}}
+- {{.Name}} ({{.ID}})
+  - VM ID: {{.VMID}}
+  - genesis SHA-256: {{.GenesisSHA256}}
+{{- end}}
+
+## 1. Install the VM
+{{with .VMBinary}}
+The VM binary has the SHA-256 {{.SHA256}} ({{.Size}} bytes){{if .Source}}, from {{.Source}}{{end}}.
+Verify it before installing it:
+
+    sha256sum <VM binary>
+{{end}}
+Copy the VM binary to the plugins directory of the node, named after the VM ID:
+{{range .Chains}}
+    cp <VM binary> ~/.avalanchego/plugins/{{.VMID}}
+{{- end}}
+
+## 2. Configure the chains
+
+Copy the genesis and the chain configs, and check that the genesis matches the
+hash above (as created on chain):
+{{range .Chains}}
+    mkdir -p ~/.avalanchego/configs/chains/{{.ID}}
+    sha256sum chains/{{.ID}}/genesis.json
+    cp chains/{{.ID}}/config.json ~/.avalanchego/configs/chains/{{.ID}}/ # if any
+{{- end}}
+
+## 3. Track the subnet
+
+Merge node-config-snippet.json into the node config file, or run:
+
+    subnet-cli node track-subnet --subnet-id={{.SubnetID}} --restart-command="<restart the node>"
+
+## 4. Verify
+
+Once the node restarted, check that each chain bootstrapped:
+{{range .Chains}}
+    curl -X POST --data '{"jsonrpc":"2.0","id":1,"method":"info.isBootstrapped","params":{"chain":"{{.ID}}"}}' -H 'content-type:application/json;' 127.0.0.1:9650/ext/info
+{{- end}}
+
+Then send the node ID to the subnet owners, to be added as a subnet validator:
+
+    subnet-cli node id --cert=~/.avalanchego/staking/staker.crt
+`))
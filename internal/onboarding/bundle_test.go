@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package onboarding
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBundleWrite(t *testing.T) {
+	t.Parallel()
+
+	b := &Bundle{
+		NetworkID:   5,
+		NetworkName: "fuji",
+		SubnetID:    "24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1",
+		ControlKeys: []string{"P-fuji1rvwmfkl8yyhu79tnng6hjqgzaqhga3ntgjn3ng"},
+		Threshold:   1,
+		Chains: []Chain{
+			NewChain("2cBxQdH4nVDhwqfaJ5FnPTG9r4W8XXGCcd9HNhEmSwfuDa3ZuJ", "subnetevm", "srEXiWaHuhNyGwPUi444Tu47ZEDwxTWrbQiuD7FmgSAQ6X7Dy", nil, []byte("{}"), []byte(`{"pruning-enabled":false}`)),
+			NewChain("2FsnMRmk5VpKeYm7TEA7j6dp7pBXyDqRwv3YPBHiQNhRjVbfEs", "timestampvm", "tGas3T58KzdjcJ2iKSyiYsWiqYctRXaPTqBCA11BcEkNKJ5x1", nil, []byte("genesis"), nil),
+		},
+		VMBinary:    &VMBinary{SHA256: "abcd", Size: 4},
+		GeneratedAt: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	buf := new(bytes.Buffer)
+	if err := b.Write(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	gr, err := gzip.NewReader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[hdr.Name], err = ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dir := "onboarding-" + b.SubnetID + "/"
+	expected := []string{
+		"manifest.json",
+		"README.md",
+		"node-config-snippet.json",
+		"chains/2cBxQdH4nVDhwqfaJ5FnPTG9r4W8XXGCcd9HNhEmSwfuDa3ZuJ/genesis.json",
+		"chains/2cBxQdH4nVDhwqfaJ5FnPTG9r4W8XXGCcd9HNhEmSwfuDa3ZuJ/config.json",
+		"chains/2FsnMRmk5VpKeYm7TEA7j6dp7pBXyDqRwv3YPBHiQNhRjVbfEs/genesis.json",
+	}
+	if len(files) != len(expected) {
+		t.Fatalf("expected %d files, got %d", len(expected), len(files))
+	}
+	for _, name := range expected {
+		if _, ok := files[dir+name]; !ok {
+			t.Fatalf("missing %q", name)
+		}
+	}
+
+	var m Bundle
+	if err := json.Unmarshal(files[dir+"manifest.json"], &m); err != nil {
+		t.Fatal(err)
+	}
+	// sha256("genesis")
+	if h := m.Chains[1].GenesisSHA256; h != "aeebad4a796fcc2e15dc4c6061b45ed9b373f26adfc798ca7d2d8cc58182718e" {
+		t.Fatalf("unexpected genesis hash %q", h)
+	}
+	if s := string(files[dir+"node-config-snippet.json"]); !strings.Contains(s, b.SubnetID) {
+		t.Fatalf("unexpected node config snippet %q", s)
+	}
+	readme := string(files[dir+"README.md"])
+	for _, s := range []string{b.SubnetID, "plugins/srEXiWaHuhNyGwPUi444Tu47ZEDwxTWrbQiuD7FmgSAQ6X7Dy", "SHA-256 abcd"} {
+		if !strings.Contains(readme, s) {
+			t.Fatalf("README.md does not contain %q", s)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package passphrase reads the passphrases of the encrypted secrets (e.g.,
+// the wallet exports), from a file, a file descriptor, or the terminal
+// without echoing them.
+package passphrase
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// DefaultAttempts is the number of prompts for a passphrase, by default.
+const DefaultAttempts = 3
+
+var (
+	ErrEmpty           = errors.New("empty passphrase")
+	ErrMismatch        = errors.New("passphrases do not match")
+	ErrTooManyAttempts = errors.New("too many passphrase attempts")
+	ErrNoTerminal      = errors.New("no terminal to prompt for the passphrase")
+	// ErrWrong is wrapped by the errors of the "Read" checks, for the
+	// passphrase to be prompted for again.
+	ErrWrong = errors.New("wrong passphrase")
+)
+
+// Reader reads the passphrase from "File", or "FD", if set. Otherwise, it
+// prompts for it on the terminal.
+type Reader struct {
+	// File is the file whose first line is the passphrase (e.g., in CI).
+	File string
+	// FD is the file descriptor to read the passphrase from (e.g., 3),
+	// 0 to not read any.
+	FD int
+	// Attempts is the number of prompts until "ErrTooManyAttempts".
+	Attempts int
+	// Interactive is false to fail with "ErrNoTerminal" instead of
+	// prompting.
+	Interactive bool
+	// Out is where the prompts are written (e.g., os.Stderr).
+	Out io.Writer
+
+	// read once from "File" or "FD"
+	cached *string
+	// true if the standard input is a terminal
+	isTerminal func() bool
+	// reads a line from the terminal without echoing it
+	readTerminal func() ([]byte, error)
+}
+
+// New returns the reader of the passphrase file [file] or descriptor [fd],
+// prompting on the terminal of the standard input if neither is set.
+func New(file string, fd int, interactive bool) *Reader {
+	return &Reader{
+		File:        file,
+		FD:          fd,
+		Attempts:    DefaultAttempts,
+		Interactive: interactive,
+		Out:         os.Stderr,
+		// supports the consoles of Windows, and the TTYs of the others
+		isTerminal:   func() bool { return term.IsTerminal(int(os.Stdin.Fd())) },
+		readTerminal: func() ([]byte, error) { return term.ReadPassword(int(os.Stdin.Fd())) },
+	}
+}
+
+// Read reads the passphrase of an existing secret, labeled [label]. If
+// [check] is not nil, the passphrase is prompted for again while [check]
+// fails with "ErrWrong", up to "Attempts" times. The other errors are
+// returned as is.
+func (r *Reader) Read(label string, check func(passphrase string) error) (string, error) {
+	if r.fromFile() {
+		p, err := r.readFile()
+		if err != nil {
+			return "", err
+		}
+		if check != nil {
+			if err := check(p); err != nil {
+				return "", err
+			}
+		}
+		return p, nil
+	}
+	for i := 0; i < r.attempts(); i++ {
+		p, err := r.prompt(label)
+		if err != nil {
+			return "", err
+		}
+		if p == "" {
+			fmt.Fprintf(r.Out, "%v, try again\n", ErrEmpty)
+			continue
+		}
+		if check == nil {
+			return p, nil
+		}
+		err = check(p)
+		if err == nil {
+			return p, nil
+		}
+		if !errors.Is(err, ErrWrong) {
+			return "", err
+		}
+		fmt.Fprintf(r.Out, "%v, try again\n", err)
+	}
+	return "", fmt.Errorf("%w (%d)", ErrTooManyAttempts, r.attempts())
+}
+
+// ReadNew reads the passphrase of a new secret, labeled [label]. When
+// prompted for, it is confirmed by typing it again.
+func (r *Reader) ReadNew(label string) (string, error) {
+	if r.fromFile() {
+		return r.readFile()
+	}
+	for i := 0; i < r.attempts(); i++ {
+		p, err := r.prompt(label)
+		if err != nil {
+			return "", err
+		}
+		if p == "" {
+			fmt.Fprintf(r.Out, "%v, try again\n", ErrEmpty)
+			continue
+		}
+		confirmed, err := r.prompt("Confirm " + strings.ToLower(label[:1]) + label[1:])
+		if err != nil {
+			return "", err
+		}
+		if confirmed == p {
+			return p, nil
+		}
+		fmt.Fprintf(r.Out, "%v, try again\n", ErrMismatch)
+	}
+	return "", fmt.Errorf("%w (%d)", ErrTooManyAttempts, r.attempts())
+}
+
+func (r *Reader) fromFile() bool {
+	return r.File != "" || r.FD > 0
+}
+
+func (r *Reader) attempts() int {
+	if r.Attempts <= 0 {
+		return DefaultAttempts
+	}
+	return r.Attempts
+}
+
+// readFile returns the first line of "File" or "FD", without the line
+// ending (e.g., "\r\n" on Windows).
+func (r *Reader) readFile() (string, error) {
+	if r.cached != nil {
+		return *r.cached, nil
+	}
+	var (
+		line string
+		err  error
+	)
+	switch {
+	case r.File != "":
+		var b []byte
+		b, err = ioutil.ReadFile(r.File)
+		line = strings.SplitN(string(b), "\n", 2)[0]
+	default:
+		f := os.NewFile(uintptr(r.FD), fmt.Sprintf("fd %d", r.FD))
+		if f == nil {
+			return "", fmt.Errorf("invalid passphrase file descriptor %d", r.FD)
+		}
+		line, err = bufio.NewReader(f).ReadString('\n')
+		f.Close()
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read the passphrase: %w", err)
+	}
+	p := strings.TrimRight(line, "\r\n")
+	if p == "" {
+		return "", ErrEmpty
+	}
+	r.cached = &p
+	return p, nil
+}
+
+// prompt reads a passphrase from the terminal, without echoing it.
+func (r *Reader) prompt(label string) (string, error) {
+	if !r.Interactive {
+		return "", fmt.Errorf("%w (prompts are disabled)", ErrNoTerminal)
+	}
+	if !r.isTerminal() {
+		return "", ErrNoTerminal
+	}
+	fmt.Fprintf(r.Out, "%s: ", label)
+	b, err := r.readTerminal()
+	// the newline typed is not echoed either
+	fmt.Fprintln(r.Out)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
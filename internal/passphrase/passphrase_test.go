@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package passphrase
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestReader returns a reader prompting with the [typed] lines.
+func newTestReader(typed ...string) *Reader {
+	r := New("", 0, true)
+	r.Out = ioutil.Discard
+	r.isTerminal = func() bool { return true }
+	r.readTerminal = func() ([]byte, error) {
+		if len(typed) == 0 {
+			return nil, errors.New("no more input")
+		}
+		line := typed[0]
+		typed = typed[1:]
+		return []byte(line), nil
+	}
+	return r
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	errOther := errors.New("other")
+	check := func(p string) error {
+		switch p {
+		case "right":
+			return nil
+		case "other":
+			return errOther
+		}
+		return fmt.Errorf("%w: %q", ErrWrong, p)
+	}
+	tt := []struct {
+		typed    []string
+		expected string
+		err      error
+	}{
+		{typed: []string{"right"}, expected: "right"},
+		{typed: []string{"", "bad", "right"}, expected: "right"},
+		{typed: []string{"bad", "bad", "bad", "right"}, err: ErrTooManyAttempts},
+		{typed: []string{"bad", "other"}, err: errOther},
+	}
+	for i, tv := range tt {
+		p, err := newTestReader(tv.typed...).Read("Passphrase", check)
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+		if p != tv.expected {
+			t.Fatalf("#%d: expected %q, got %q", i, tv.expected, p)
+		}
+	}
+
+	r := newTestReader("right")
+	r.Interactive = false
+	if _, err := r.Read("Passphrase", check); !errors.Is(err, ErrNoTerminal) {
+		t.Fatalf("expected %v, got %v", ErrNoTerminal, err)
+	}
+	r = newTestReader("right")
+	r.isTerminal = func() bool { return false }
+	if _, err := r.Read("Passphrase", check); !errors.Is(err, ErrNoTerminal) {
+		t.Fatalf("expected %v, got %v", ErrNoTerminal, err)
+	}
+}
+
+func TestReadNew(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		typed    []string
+		expected string
+		err      error
+	}{
+		{typed: []string{"a", "a"}, expected: "a"},
+		{typed: []string{"a", "b", "c", "c"}, expected: "c"},
+		{typed: []string{"", "a", "b", "a", "b"}, err: ErrTooManyAttempts},
+	}
+	for i, tv := range tt {
+		p, err := newTestReader(tv.typed...).ReadNew("Passphrase")
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+		if p != tv.expected {
+			t.Fatalf("#%d: expected %q, got %q", i, tv.expected, p)
+		}
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	p := filepath.Join(dir, "passphrase")
+	if err := ioutil.WriteFile(p, []byte("secret\r\nignored\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	r := New(p, 0, false)
+	for i := 0; i < 2; i++ {
+		s, err := r.ReadNew("Passphrase")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != "secret" {
+			t.Fatalf("expected %q, got %q", "secret", s)
+		}
+	}
+	// not prompted for again
+	if _, err := r.Read("Passphrase", func(string) error { return ErrWrong }); !errors.Is(err, ErrWrong) {
+		t.Fatalf("expected %v, got %v", ErrWrong, err)
+	}
+
+	empty := filepath.Join(dir, "empty")
+	if err := ioutil.WriteFile(empty, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(empty, 0, false).Read("Passphrase", nil); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("expected %v, got %v", ErrEmpty, err)
+	}
+
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pw.WriteString("from fd"); err != nil {
+		t.Fatal(err)
+	}
+	pw.Close()
+	s, err := New("", int(pr.Fd()), false).Read("Passphrase", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "from fd" {
+		t.Fatalf("expected %q, got %q", "from fd", s)
+	}
+}
@@ -49,21 +49,36 @@ func (c *checker) PollTx(ctx context.Context, txID ids.ID, s pstatus.Status) (ti
 		zap.String("txId", txID.String()),
 		zap.String("expectedStatus", s.String()),
 	)
-	return c.poller.Poll(ctx, func() (done bool, err error) {
-		status, err := c.cli.GetTxStatus(ctx, txID, true)
-		if err != nil {
-			return false, err
-		}
-		zap.L().Debug("tx",
-			zap.String("status", status.Status.String()),
-			zap.String("reason", status.Reason),
-		)
-		if s == pstatus.Committed &&
-			(status.Status == pstatus.Aborted || status.Status == pstatus.Dropped) {
-			return true, ErrAbortedDropped
+	// the poller retries on any error, so the failed tx is returned separately
+	var failed error
+	took, err := c.poller.Poll(ctx, func() (done bool, err error) {
+		done, err = c.checkTx(ctx, txID, s)
+		if errors.Is(err, ErrAbortedDropped) {
+			failed = err
+			return true, nil
 		}
-		return status.Status == s, nil
+		return done, err
 	})
+	if failed != nil {
+		return took, failed
+	}
+	return took, err
+}
+
+func (c *checker) checkTx(ctx context.Context, txID ids.ID, s pstatus.Status) (done bool, err error) {
+	status, err := c.cli.GetTxStatus(ctx, txID, true)
+	if err != nil {
+		return false, err
+	}
+	zap.L().Debug("tx",
+		zap.String("status", status.Status.String()),
+		zap.String("reason", status.Reason),
+	)
+	if s == pstatus.Committed &&
+		(status.Status == pstatus.Aborted || status.Status == pstatus.Dropped) {
+		return true, &TxError{TxID: txID, Status: status.Status, Reason: status.Reason}
+	}
+	return status.Status == s, nil
 }
 
 func (c *checker) PollSubnet(ctx context.Context, subnetID ids.ID) (took time.Duration, err error) {
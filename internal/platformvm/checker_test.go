@@ -7,6 +7,13 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	pstatus "github.com/ava-labs/avalanchego/vms/platformvm/status"
+
+	"github.com/ava-labs/subnet-cli/internal/poll"
 )
 
 func TestChecker(t *testing.T) {
@@ -18,3 +25,65 @@ func TestChecker(t *testing.T) {
 		t.Fatalf("unexpected error %v, expected %v", err, ErrEmptyID)
 	}
 }
+
+// statusClient returns the [statuses] in order, then the last one.
+type statusClient struct {
+	platformvm.Client
+	statuses []pstatus.Status
+	errs     []error
+	calls    int
+}
+
+func (c *statusClient) GetTxStatus(context.Context, ids.ID, bool) (*platformvm.GetTxStatusResponse, error) {
+	i := c.calls
+	if i >= len(c.statuses) {
+		i = len(c.statuses) - 1
+	}
+	c.calls++
+	if c.errs[i] != nil {
+		return nil, c.errs[i]
+	}
+	return &platformvm.GetTxStatusResponse{Status: c.statuses[i]}, nil
+}
+
+func TestPollTx(t *testing.T) {
+	t.Parallel()
+
+	errUnreachable := errors.New("connection refused")
+	tt := []struct {
+		statuses []pstatus.Status
+		errs     []error
+		calls    int
+		err      error
+	}{
+		// keeps polling after the errors
+		{
+			statuses: []pstatus.Status{pstatus.Unknown, pstatus.Processing, pstatus.Committed},
+			errs:     []error{errUnreachable, nil, nil},
+			calls:    3,
+		},
+		{
+			statuses: []pstatus.Status{pstatus.Processing, pstatus.Dropped},
+			errs:     []error{nil, nil},
+			calls:    2,
+			err:      ErrAbortedDropped,
+		},
+		{
+			statuses: []pstatus.Status{pstatus.Unknown},
+			errs:     []error{errUnreachable},
+			err:      context.DeadlineExceeded,
+		},
+	}
+	for i, tv := range tt {
+		cli := &statusClient{statuses: tv.statuses, errs: tv.errs}
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		_, err := NewChecker(poll.New(time.Millisecond), cli).PollTx(ctx, ids.GenerateTestID(), pstatus.Committed)
+		cancel()
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+		if tv.calls != 0 && cli.calls != tv.calls {
+			t.Fatalf("#%d: expected %d calls, got %d", i, tv.calls, cli.calls)
+		}
+	}
+}
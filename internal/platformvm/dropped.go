@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	pstatus "github.com/ava-labs/avalanchego/vms/platformvm/status"
+)
+
+// Retry is how a dropped tx may still be committed.
+type Retry int
+
+const (
+	// RetryNone is the tx that is invalid as built.
+	RetryNone Retry = iota
+	// RetryResubmit is the tx to issue again as is, once the chain
+	// caught up (e.g., the start time too far ahead of the chain time).
+	RetryResubmit
+	// RetryRebuild is the tx to build again, with the UTXOs fetched again
+	// (e.g., consumed by another tx) and a later start time (e.g., dropped
+	// from the mempool once the start time passed).
+	RetryRebuild
+)
+
+func (r Retry) String() string {
+	switch r {
+	case RetryResubmit:
+		return "resubmit"
+	case RetryRebuild:
+		return "rebuild"
+	default:
+		return "none"
+	}
+}
+
+// droppedReasons maps the substrings of the reasons the tx was dropped
+// (ref. "vms.platformvm") to the retry of the tx.
+var droppedReasons = []struct {
+	substr string
+	retry  Retry
+}{
+	{substr: "failed to read consumed utxo", retry: RetryRebuild},
+	{substr: "synchrony bound", retry: RetryRebuild},
+	{substr: "ahead of the current chain time", retry: RetryResubmit},
+}
+
+// TxError is the tx aborted or dropped by the node. It matches
+// "ErrAbortedDropped" with "errors.Is".
+type TxError struct {
+	TxID   ids.ID
+	Status pstatus.Status
+	// Reason is the reason reported by the node, if dropped.
+	Reason string
+}
+
+func (e *TxError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("%v: tx %s %s", ErrAbortedDropped, e.TxID, e.Status)
+	}
+	return fmt.Sprintf("%v: tx %s %s (%s)", ErrAbortedDropped, e.TxID, e.Status, e.Reason)
+}
+
+func (e *TxError) Unwrap() error { return ErrAbortedDropped }
+
+// Retry returns how the tx may be retried, based on the drop reason.
+// The aborted txs are never retried.
+func (e *TxError) Retry() Retry {
+	if e.Status != pstatus.Dropped {
+		return RetryNone
+	}
+	lower := strings.ToLower(e.Reason)
+	for _, r := range droppedReasons {
+		if strings.Contains(lower, r.substr) {
+			return r.retry
+		}
+	}
+	return RetryNone
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"testing"
+
+	pstatus "github.com/ava-labs/avalanchego/vms/platformvm/status"
+)
+
+func TestTxErrorRetry(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		status pstatus.Status
+		reason string
+		retry  Retry
+	}{
+		{
+			status: pstatus.Dropped,
+			reason: "failed to read consumed UTXO 2Xh5Kk7vW4pYe3dA8T9ygVFBnJ6RKR7x1ChqZMJBQPCPN7jvAw:0 due to: not found",
+			retry:  RetryRebuild,
+		},
+		{
+			status: pstatus.Dropped,
+			reason: "synchrony bound (2022-05-01 00:00:30 +0000 UTC) is later than staker start time (2022-05-01 00:00:10 +0000 UTC)",
+			retry:  RetryRebuild,
+		},
+		{
+			status: pstatus.Dropped,
+			reason: "staker is attempting to start staking more than 336h0m0s ahead of the current chain time",
+			retry:  RetryResubmit,
+		},
+		{
+			status: pstatus.Dropped,
+			reason: "validator's weight is larger than the maximum weight",
+			retry:  RetryNone,
+		},
+		{
+			status: pstatus.Dropped,
+			retry:  RetryNone,
+		},
+		{
+			status: pstatus.Aborted,
+			reason: "failed to read consumed UTXO",
+			retry:  RetryNone,
+		},
+	}
+	for i, tv := range tt {
+		err := &TxError{Status: tv.status, Reason: tv.reason}
+		if !errors.Is(err, ErrAbortedDropped) {
+			t.Fatalf("#%d: expected %v, got %v", i, ErrAbortedDropped, err)
+		}
+		if r := err.Retry(); r != tv.retry {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.retry, r)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package plugin implements the discovery and the execution of the
+// plugins, the "subnet-cli-<name>" executables on the PATH.
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	pkg_plugin "github.com/ava-labs/subnet-cli/pkg/plugin"
+)
+
+// Plugin is a discovered plugin executable.
+type Plugin struct {
+	Name string
+	Path string
+}
+
+// Discover returns the plugins in the directories of [pathList] (e.g.,
+// "$PATH"), sorted by name. On name collisions, the first one wins.
+func Discover(pathList string) []Plugin {
+	found := make(map[string]string)
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			// unreadable directories are skipped, like the shell does
+			continue
+		}
+		for _, e := range entries {
+			name := strings.TrimSuffix(e.Name(), filepath.Ext(e.Name()))
+			if !strings.HasPrefix(name, pkg_plugin.Prefix) || len(name) == len(pkg_plugin.Prefix) {
+				continue
+			}
+			if e.IsDir() || e.Mode()&0o111 == 0 {
+				continue
+			}
+			name = strings.TrimPrefix(name, pkg_plugin.Prefix)
+			if _, ok := found[name]; !ok {
+				found[name] = filepath.Join(dir, e.Name())
+			}
+		}
+	}
+	plugins := make([]Plugin, 0, len(found))
+	for name, p := range found {
+		plugins = append(plugins, Plugin{Name: name, Path: p})
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// Run runs the plugin with [args], passing [h] in its environment.
+func (p Plugin) Run(ctx context.Context, h *pkg_plugin.Handshake, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	b, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, p.Path, args...)
+	cmd.Env = append(os.Environ(), pkg_plugin.HandshakeEnvVar+"="+string(b))
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, stdout, stderr
+	return cmd.Run()
+}
+
+// ExtractFlags removes the [known] flags from [args], and returns their
+// values. The flags in [boolFlags] take no value unless set with "=".
+// Parsing stops at "--", which is kept in the returned args.
+func ExtractFlags(args []string, known []string, boolFlags []string) (map[string]string, []string) {
+	isKnown, isBool := make(map[string]bool), make(map[string]bool)
+	for _, k := range known {
+		isKnown[k] = true
+	}
+	for _, k := range boolFlags {
+		isKnown[k], isBool[k] = true, true
+	}
+	values := make(map[string]string)
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "--") {
+			rest = append(rest, arg)
+			continue
+		}
+		name, value := strings.TrimPrefix(arg, "--"), ""
+		hasValue := false
+		if idx := strings.Index(name, "="); idx >= 0 {
+			name, value, hasValue = name[:idx], name[idx+1:], true
+		}
+		if !isKnown[name] {
+			rest = append(rest, arg)
+			continue
+		}
+		switch {
+		case hasValue:
+		case isBool[name]:
+			value = "true"
+		case i+1 < len(args):
+			i++
+			value = args[i]
+		}
+		values[name] = value
+	}
+	return values, rest
+}
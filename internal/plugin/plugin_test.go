@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	dir1, dir2 := t.TempDir(), t.TempDir()
+	for _, f := range []struct {
+		dir  string
+		name string
+		mode os.FileMode
+	}{
+		{dir1, "subnet-cli-genesis", 0o755},
+		{dir1, "subnet-cli-noexec", 0o644},
+		{dir1, "other", 0o755},
+		{dir2, "subnet-cli-genesis", 0o755},
+		{dir2, "subnet-cli-deploy", 0o755},
+	} {
+		if err := ioutil.WriteFile(filepath.Join(f.dir, f.name), []byte("#!/bin/sh\n"), f.mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	plugins := Discover(dir1 + string(os.PathListSeparator) + dir2)
+	expected := []Plugin{
+		{Name: "deploy", Path: filepath.Join(dir2, "subnet-cli-deploy")},
+		{Name: "genesis", Path: filepath.Join(dir1, "subnet-cli-genesis")},
+	}
+	if !reflect.DeepEqual(plugins, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, plugins)
+	}
+}
+
+func TestExtractFlags(t *testing.T) {
+	t.Parallel()
+
+	values, rest := ExtractFlags(
+		[]string{"--public-uri", "http://localhost:9650", "build", "--ledger", "--chain-id=1", "--private-key-path=.pk", "--", "--public-uri"},
+		[]string{"public-uri", "private-key-path"},
+		[]string{"ledger"},
+	)
+	expected := map[string]string{
+		"public-uri":       "http://localhost:9650",
+		"private-key-path": ".pk",
+		"ledger":           "true",
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Fatalf("expected %v, got %v", expected, values)
+	}
+	if !reflect.DeepEqual(rest, []string{"build", "--chain-id=1", "--", "--public-uri"}) {
+		t.Fatalf("unexpected rest %v", rest)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/internal/metrics"
 )
 
 var ErrAborted = errors.New("aborted")
@@ -56,6 +58,7 @@ func (pl *poller) Poll(ctx context.Context, check func() (done bool, err error))
 		done, err := check()
 		if err != nil {
 			zap.L().Warn("poll check failed", zap.Error(err))
+			metrics.Retries.WithLabelValues("poll").Inc()
 			continue
 		}
 		if !done {
@@ -0,0 +1,170 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package profile implements the named profiles of the CLI, each bundling
+// the endpoint, the network, the key, and the flag defaults of a network
+// (e.g., "prod" for mainnet, "fuji-team" for a shared testnet key), so that
+// the commands of different networks are not mixed.
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+var (
+	ErrEmptyName = errors.New("empty profile name")
+	ErrNoNetwork = errors.New("profile has no network")
+	ErrNotFound  = errors.New("profile not found")
+)
+
+// DefaultPath returns the default store file "~/.subnet-cli/profiles.json".
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".subnet-cli", "profiles.json"), nil
+}
+
+type Profile struct {
+	// Network is the network name or ID (e.g., "mainnet", "12345"), that
+	// the endpoint must report to sign any tx.
+	Network        string `json:"network"`
+	PublicURI      string `json:"publicURI,omitempty"`
+	PrivateKeyPath string `json:"privateKeyPath,omitempty"`
+	Ledger         bool   `json:"ledger,omitempty"`
+	// Defaults is the default values of the other flags, keyed by the flag
+	// names (e.g., "validate-weight").
+	Defaults map[string]string `json:"defaults,omitempty"`
+}
+
+// Flags returns the flag values of the profile, keyed by the flag names.
+// The endpoint is set to both "--public-uri" and "--private-uri" (of the
+// "status" commands).
+func (p Profile) Flags() map[string]string {
+	fs := make(map[string]string, len(p.Defaults)+4)
+	for k, v := range p.Defaults {
+		fs[k] = v
+	}
+	fs["network"] = p.Network
+	if p.PublicURI != "" {
+		fs["public-uri"] = p.PublicURI
+		fs["private-uri"] = p.PublicURI
+	}
+	if p.PrivateKeyPath != "" {
+		fs["private-key-path"] = p.PrivateKeyPath
+	}
+	if p.Ledger {
+		fs["ledger"] = strconv.FormatBool(p.Ledger)
+	}
+	return fs
+}
+
+// Store is the profiles keyed by their names, and the profile in use
+// when none is selected.
+type Store struct {
+	Current  string             `json:"current,omitempty"`
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+// Load loads the store file, or returns the empty store if missing.
+func Load(p string) (*Store, error) {
+	s := &Store{Profiles: make(map[string]Profile)}
+	b, err := ioutil.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", p, err)
+	}
+	if s.Profiles == nil {
+		s.Profiles = make(map[string]Profile)
+	}
+	return s, nil
+}
+
+// Save writes the store file, creating its directory if missing.
+func (s *Store) Save(p string) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p, b, 0o600)
+}
+
+// Set creates or replaces the profile [name]. The network is required,
+// as the profiles exist to keep the networks apart.
+func (s *Store) Set(name string, p Profile) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyName
+	}
+	if p.Network == "" {
+		return fmt.Errorf("%w: %q", ErrNoNetwork, name)
+	}
+	if _, err := constants.NetworkID(p.Network); err != nil {
+		return fmt.Errorf("invalid network %q of profile %q: %w", p.Network, name, err)
+	}
+	s.Profiles[name] = p
+	return nil
+}
+
+// Get returns the profile [name].
+func (s *Store) Get(name string) (Profile, error) {
+	p, ok := s.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("%w: %q", ErrNotFound, name)
+	}
+	return p, nil
+}
+
+// Use selects the profile [name] for the commands run without a profile,
+// or none if empty.
+func (s *Store) Use(name string) error {
+	if name != "" {
+		if _, err := s.Get(name); err != nil {
+			return err
+		}
+	}
+	s.Current = name
+	return nil
+}
+
+// Remove removes the profile [name], no longer in use, and returns false
+// if none.
+func (s *Store) Remove(name string) bool {
+	if _, ok := s.Profiles[name]; !ok {
+		return false
+	}
+	delete(s.Profiles, name)
+	if s.Current == name {
+		s.Current = ""
+	}
+	return true
+}
+
+// Names returns the sorted profile names.
+func (s *Store) Names() []string {
+	names := make([]string, 0, len(s.Profiles))
+	for name := range s.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package profile
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	p := filepath.Join(t.TempDir(), "sub", "profiles.json")
+	s, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("prod", Profile{
+		Network:   "mainnet",
+		PublicURI: "https://api.avax.network",
+		Ledger:    true,
+		Defaults:  map[string]string{"validate-weight": "1000"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("fuji-team", Profile{Network: "5", PrivateKeyPath: "fuji.pk"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("no-network", Profile{PublicURI: "http://localhost:9650"}); !errors.Is(err, ErrNoNetwork) {
+		t.Fatalf("expected %v, got %v", ErrNoNetwork, err)
+	}
+	if err := s.Set(" ", Profile{Network: "fuji"}); !errors.Is(err, ErrEmptyName) {
+		t.Fatalf("expected %v, got %v", ErrEmptyName, err)
+	}
+	if err := s.Set("bad", Profile{Network: "not-a-network"}); err == nil {
+		t.Fatal("expected error on unknown network")
+	}
+	if err := s.Use("staging"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected %v, got %v", ErrNotFound, err)
+	}
+	if err := s.Use("prod"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(p); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Current != "prod" {
+		t.Fatalf("expected current profile %q, got %q", "prod", s.Current)
+	}
+	if names := s.Names(); len(names) != 2 || names[0] != "fuji-team" || names[1] != "prod" {
+		t.Fatalf("unexpected names %v", names)
+	}
+	prod, err := s.Get("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := prod.Flags()
+	for k, v := range map[string]string{
+		"network":         "mainnet",
+		"public-uri":      "https://api.avax.network",
+		"private-uri":     "https://api.avax.network",
+		"ledger":          "true",
+		"validate-weight": "1000",
+	} {
+		if fs[k] != v {
+			t.Fatalf("expected %s=%q, got %q", k, v, fs[k])
+		}
+	}
+	if _, ok := fs["private-key-path"]; ok {
+		t.Fatalf("unexpected private key path in %v", fs)
+	}
+	if !s.Remove("prod") || s.Remove("prod") {
+		t.Fatal("expected to remove the profile once")
+	}
+	if s.Current != "" {
+		t.Fatalf("expected no current profile, got %q", s.Current)
+	}
+}
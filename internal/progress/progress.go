@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package progress emits the progress of the long operations as
+// newline-delimited JSON (NDJSON) events, for the wrappers and the UIs
+// to render their own progress.
+package progress
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// EventType is the type of a progress event.
+type EventType string
+
+const (
+	StepStarted        EventType = "step_started"
+	StepCompleted      EventType = "step_completed"
+	TxIssued           EventType = "tx_issued"
+	TxAccepted         EventType = "tx_accepted"
+	ValidatorConfirmed EventType = "validator_confirmed"
+	// StepSatisfied is emitted instead of the start and the completion
+	// of a step already satisfied (e.g., by a previous run).
+	StepSatisfied EventType = "step_satisfied"
+)
+
+// Event is a progress event, one JSON object per line.
+//
+// e.g.,
+//
+//	{"time":"2022-03-01T00:00:00Z","type":"tx_issued","txType":"create_subnet","txID":"..."}
+type Event struct {
+	Time time.Time `json:"time"`
+	Type EventType `json:"type"`
+	// Step is the name of the step (e.g., "create_subnet").
+	Step   string `json:"step,omitempty"`
+	TxType string `json:"txType,omitempty"`
+	TxID   string `json:"txID,omitempty"`
+	// Fee is the AVAX burned by the accepted tx, in nAVAX.
+	Fee      uint64 `json:"fee,omitempty"`
+	NodeID   string `json:"nodeID,omitempty"`
+	SubnetID string `json:"subnetID,omitempty"`
+	// TookMs is the duration of the step or of the tx, in milliseconds.
+	TookMs int64 `json:"tookMs,omitempty"`
+	// Error is set on the failed steps.
+	Error string `json:"error,omitempty"`
+}
+
+// Emitter writes the events to the writer. A nil emitter discards them.
+type Emitter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	now func() time.Time
+}
+
+// New creates an emitter of the events to [w].
+func New(w io.Writer) *Emitter {
+	return &Emitter{enc: json.NewEncoder(w), now: time.Now}
+}
+
+// Emit writes [ev], stamped with the current time if not set.
+// It is safe for concurrent use.
+func (e *Emitter) Emit(ev Event) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if ev.Time.IsZero() {
+		ev.Time = e.now().UTC()
+	}
+	// best effort, not to fail the operation on a closed reader
+	_ = e.enc.Encode(ev)
+}
+
+// Start emits the start of the [step] (e.g., with its node ID), and
+// returns the function to emit its completion with its error, if any.
+func (e *Emitter) Start(step Event) func(err error) {
+	if e == nil {
+		return func(error) {}
+	}
+	step.Type = StepStarted
+	e.Emit(step)
+	start := e.now()
+	return func(err error) {
+		ev := step
+		ev.Time, ev.Type, ev.TookMs = time.Time{}, StepCompleted, e.now().Sub(start).Milliseconds()
+		if err != nil {
+			ev.Error = err.Error()
+		}
+		e.Emit(ev)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package progress
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEmitter(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.NewBuffer(nil)
+	e := New(buf)
+	now := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+	e.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	done := e.Start(Event{Step: "create_subnet"})
+	e.Emit(Event{Type: TxIssued, TxType: "create_subnet", TxID: "abc"})
+	done(errors.New("boom"))
+
+	expected := `{"time":"2022-03-01T00:00:01Z","type":"step_started","step":"create_subnet"}
+{"time":"2022-03-01T00:00:03Z","type":"tx_issued","txType":"create_subnet","txID":"abc"}
+{"time":"2022-03-01T00:00:05Z","type":"step_completed","step":"create_subnet","tookMs":2000,"error":"boom"}
+`
+	if buf.String() != expected {
+		t.Fatalf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	// nil emitter discards the events
+	var ne *Emitter
+	ne.Emit(Event{Type: TxIssued})
+	ne.Start(Event{Step: "noop"})(nil)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package proxy routes the API calls through an HTTP or SOCKS5 proxy
+// (e.g., a corporate proxy or Tor).
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+var ErrUnsupportedScheme = errors.New("unsupported proxy scheme (must be http, https, or socks5)")
+
+// Parse parses the proxy URL [raw] (e.g., "socks5://127.0.0.1:9050").
+func Parse(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedScheme, raw)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy %q has no host", raw)
+	}
+	return u, nil
+}
+
+// Func returns the proxy of the requests: [raw] for all the requests if set,
+// otherwise the one of the environment ("HTTPS_PROXY", "HTTP_PROXY", then
+// "ALL_PROXY", excluding "NO_PROXY" and the local hosts) read with [getenv].
+func Func(raw string, getenv func(string) string) (func(*http.Request) (*url.URL, error), error) {
+	if raw != "" {
+		u, err := Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		return http.ProxyURL(u), nil
+	}
+
+	cfg := &httpproxy.Config{
+		HTTPProxy:  env(getenv, "HTTP_PROXY", "http_proxy"),
+		HTTPSProxy: env(getenv, "HTTPS_PROXY", "https_proxy"),
+		NoProxy:    env(getenv, "NO_PROXY", "no_proxy"),
+	}
+	if all := env(getenv, "ALL_PROXY", "all_proxy"); all != "" {
+		if _, err := Parse(all); err != nil {
+			return nil, err
+		}
+		if cfg.HTTPProxy == "" {
+			cfg.HTTPProxy = all
+		}
+		if cfg.HTTPSProxy == "" {
+			cfg.HTTPSProxy = all
+		}
+	}
+	fn := cfg.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return fn(req.URL)
+	}, nil
+}
+
+// Install routes the requests of the default HTTP transport (used by all
+// the API clients) through the proxy of [fn].
+func Install(fn func(*http.Request) (*url.URL, error)) {
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		t.Proxy = fn
+	}
+}
+
+func env(getenv func(string) string, names ...string) string {
+	for _, name := range names {
+		if v := getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proxy
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestFunc(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		raw  string
+		env  map[string]string
+		uri  string
+		used string
+		err  error
+	}{
+		{
+			uri: "https://api.avax-test.network/ext/P",
+		},
+		{
+			raw:  "socks5://127.0.0.1:9050",
+			uri:  "https://api.avax-test.network/ext/P",
+			used: "socks5://127.0.0.1:9050",
+		},
+		{
+			raw: "ftp://127.0.0.1:21",
+			err: ErrUnsupportedScheme,
+		},
+		{
+			env:  map[string]string{"HTTPS_PROXY": "http://proxy.corp:3128"},
+			uri:  "https://api.avax-test.network/ext/P",
+			used: "http://proxy.corp:3128",
+		},
+		{
+			env:  map[string]string{"all_proxy": "socks5://127.0.0.1:1080"},
+			uri:  "http://api.avax-test.network/ext/P",
+			used: "socks5://127.0.0.1:1080",
+		},
+		{
+			// the explicit proxy of the scheme takes precedence
+			env: map[string]string{
+				"ALL_PROXY":   "socks5://127.0.0.1:1080",
+				"HTTPS_PROXY": "http://proxy.corp:3128",
+			},
+			uri:  "https://api.avax-test.network/ext/P",
+			used: "http://proxy.corp:3128",
+		},
+		{
+			env: map[string]string{
+				"ALL_PROXY": "socks5://127.0.0.1:1080",
+				"NO_PROXY":  "avax-test.network",
+			},
+			uri: "https://api.avax-test.network/ext/P",
+		},
+		{
+			env: map[string]string{"ALL_PROXY": "gopher://127.0.0.1:70"},
+			err: ErrUnsupportedScheme,
+		},
+	}
+	for i, tv := range tt {
+		env := tv.env
+		fn, err := Func(tv.raw, func(k string) string { return env[k] })
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		req, err := http.NewRequest(http.MethodPost, tv.uri, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, err := fn(req)
+		if err != nil {
+			t.Fatalf("#%d: unexpected error %v", i, err)
+		}
+		used := ""
+		if u != nil {
+			used = u.String()
+		}
+		if used != tv.used {
+			t.Fatalf("#%d: expected proxy %q, got %q", i, tv.used, used)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package ratelimit limits the rate of the API calls to each endpoint, not
+// to get throttled (or banned) by the public API providers on the bulk
+// operations.
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/subnet-cli/internal/metrics"
+)
+
+var _ http.RoundTripper = &Transport{}
+
+// Transport limits the requests to each host with a token bucket,
+// and sends them with the base transport.
+type Transport struct {
+	base  http.RoundTripper
+	limit rate.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewTransport creates the transport limiting the requests to [rps] per
+// second for each host, on top of [base]. The bursts are of up to one
+// second worth of requests.
+func NewTransport(base http.RoundTripper, rps float64) *Transport {
+	burst := int(math.Ceil(rps))
+	if burst < 1 {
+		burst = 1
+	}
+	return &Transport{
+		base:     base,
+		limit:    rate.Limit(rps),
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+func (t *Transport) limiter(host string) *rate.Limiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	l, ok := t.limiters[host]
+	if !ok {
+		l = rate.NewLimiter(t.limit, t.burst)
+		t.limiters[host] = l
+	}
+	return l
+}
+
+// RoundTrip waits for a token of the request host, or for the request
+// context to be done.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	if err := t.limiter(req.URL.Host).Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	if waited := time.Since(start); waited > time.Millisecond {
+		zap.L().Debug("rate limited", zap.String("host", req.URL.Host), zap.Duration("waited", waited))
+		metrics.RateLimitWait.WithLabelValues(req.URL.Host).Add(waited.Seconds())
+	}
+	return t.base.RoundTrip(req)
+}
+
+// installed is the transport of the default HTTP client, if any.
+var installed *Transport
+
+// Install limits the requests of the default HTTP client (used by all the
+// API clients) to [rps] per second for each host, on top of its current
+// transport (e.g., authenticating the requests). Installing it again
+// replaces the limit.
+func Install(rps float64) {
+	base := http.DefaultClient.Transport
+	switch {
+	case base == nil:
+		base = http.DefaultTransport
+	case base == installed:
+		base = installed.base
+	}
+	installed = NewTransport(base, rps)
+	http.DefaultClient.Transport = installed
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer other.Close()
+
+	cli := &http.Client{Transport: NewTransport(http.DefaultTransport, 10)}
+	get := func(ctx context.Context, u string) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := cli.Do(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	// the burst of 10 requests, then 5 more at 10 per second
+	start := time.Now()
+	for i := 0; i < 15; i++ {
+		if err := get(context.Background(), srv.URL); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if took := time.Since(start); took < 400*time.Millisecond {
+		t.Fatalf("expected the requests to be limited, took %v", took)
+	}
+
+	// the other host has its own bucket
+	start = time.Now()
+	if err := get(context.Background(), other.URL); err != nil {
+		t.Fatal(err)
+	}
+	if took := time.Since(start); took > 50*time.Millisecond {
+		t.Fatalf("expected the other host not to be limited, took %v", took)
+	}
+
+	// the wait is bound by the request context
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := get(ctx, srv.URL); err == nil {
+		t.Fatal("expected the request to fail before its deadline")
+	}
+}
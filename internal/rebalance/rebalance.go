@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package rebalance computes the validator set changes to converge
+// the subnet validator weights to the desired ones.
+package rebalance
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+type Kind int
+
+const (
+	Keep Kind = iota
+	Add
+	Remove
+	Reweight
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Keep:
+		return "keep"
+	case Add:
+		return "add"
+	case Remove:
+		return "remove"
+	case Reweight:
+		return "reweight"
+	default:
+		return "unknown"
+	}
+}
+
+// Action is a single validator change.
+type Action struct {
+	NodeID ids.ShortID
+	Kind   Kind
+	From   uint64
+	To     uint64
+}
+
+// Plan returns the minimal set of actions to converge the current weights
+// to the target ones, sorted by node ID. Nodes that are not in the target
+// are left untouched, and a zero target weight removes the node.
+func Plan(current map[ids.ShortID]uint64, target map[ids.ShortID]uint64) []Action {
+	actions := make([]Action, 0, len(target))
+	for nodeID, to := range target {
+		from, ok := current[nodeID]
+		a := Action{NodeID: nodeID, From: from, To: to}
+		switch {
+		case !ok && to == 0:
+			continue
+		case !ok:
+			a.Kind = Add
+		case to == 0:
+			a.Kind = Remove
+		case from != to:
+			a.Kind = Reweight
+		default:
+			a.Kind = Keep
+		}
+		actions = append(actions, a)
+	}
+	sort.Slice(actions, func(i, j int) bool {
+		return bytes.Compare(actions[i].NodeID[:], actions[j].NodeID[:]) < 0
+	})
+	return actions
+}
+
+// Count returns the number of actions of the kind.
+func Count(actions []Action, k Kind) (n int) {
+	for _, a := range actions {
+		if a.Kind == k {
+			n++
+		}
+	}
+	return n
+}
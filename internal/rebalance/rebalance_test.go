@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rebalance
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestPlan(t *testing.T) {
+	t.Parallel()
+
+	n := func(b byte) ids.ShortID { return ids.ShortID{b} }
+	current := map[ids.ShortID]uint64{
+		n(1): 100,
+		n(2): 100,
+		n(3): 100,
+		n(4): 100,
+	}
+	target := map[ids.ShortID]uint64{
+		n(1): 100, // keep
+		n(2): 200, // reweight
+		n(3): 0,   // remove
+		n(5): 50,  // add
+		n(6): 0,   // not a validator, nothing to remove
+	}
+
+	actions := Plan(current, target)
+	expected := []Action{
+		{NodeID: n(1), Kind: Keep, From: 100, To: 100},
+		{NodeID: n(2), Kind: Reweight, From: 100, To: 200},
+		{NodeID: n(3), Kind: Remove, From: 100, To: 0},
+		{NodeID: n(5), Kind: Add, From: 0, To: 50},
+	}
+	if len(actions) != len(expected) {
+		t.Fatalf("unexpected actions %+v, expected %+v", actions, expected)
+	}
+	for i := range actions {
+		if actions[i] != expected[i] {
+			t.Fatalf("#%d: unexpected action %+v, expected %+v", i, actions[i], expected[i])
+		}
+	}
+	if c := Count(actions, Keep); c != 1 {
+		t.Fatalf("unexpected keep count %d", c)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rebalance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+var (
+	ErrInvalidMaxPercent = errors.New("max weight percent must be in (0, 100]")
+	ErrNoNewNodes        = errors.New("no new nodes")
+	ErrWeightsInfeasible = errors.New("no new validator weight satisfies the max weight percent")
+	ErrNoStake           = errors.New("no primary network stake")
+)
+
+// Suggestion is the weight of the new validators.
+type Suggestion struct {
+	// Weight is the suggested weight of each new validator: the mean
+	// of the current weights (or the default weight if none) within
+	// [Min, Max].
+	Weight uint64
+	// Min is the least weight for the current validators not to exceed
+	// the max weight percent.
+	Min uint64
+	// Max is the most weight for the new validators not to exceed
+	// the max weight percent.
+	Max uint64
+	// Total is the total weight once the new validators are added.
+	Total uint64
+	// MaxPercent is the largest share of a single validator, once the
+	// new validators are added.
+	MaxPercent float64
+}
+
+// SuggestWeights returns the weight of [n] new validators such that no
+// validator exceeds [maxPercent] of the total weight, once added to the
+// [current] ones. [def] is the suggested weight without current validators.
+func SuggestWeights(current map[ids.ShortID]uint64, n int, maxPercent uint64, def uint64) (*Suggestion, error) {
+	switch {
+	case maxPercent == 0 || maxPercent > 100:
+		return nil, ErrInvalidMaxPercent
+	case n <= 0:
+		return nil, ErrNoNewNodes
+	}
+	sum, max := new(big.Int), uint64(0)
+	for _, w := range current {
+		sum.Add(sum, new(big.Int).SetUint64(w))
+		if w > max {
+			max = w
+		}
+	}
+	p, bn := new(big.Int).SetUint64(maxPercent), big.NewInt(int64(n))
+
+	// for each new node: 100 * w <= p * (sum + n * w)
+	// i.e., w * (100 - p * n) <= p * sum
+	hi := new(big.Int).SetUint64(math.MaxUint64)
+	if d := new(big.Int).Sub(big.NewInt(100), new(big.Int).Mul(p, bn)); d.Sign() > 0 {
+		hi.Div(new(big.Int).Mul(p, sum), d)
+	}
+	// for the heaviest current node: 100 * max <= p * (sum + n * w)
+	// i.e., w >= (100 * max - p * sum) / (p * n)
+	lo := big.NewInt(1)
+	if num := new(big.Int).Sub(new(big.Int).Mul(big.NewInt(100), new(big.Int).SetUint64(max)), new(big.Int).Mul(p, sum)); num.Sign() > 0 {
+		den := new(big.Int).Mul(p, bn)
+		// ceil
+		lo.Add(num, new(big.Int).Sub(den, big.NewInt(1)))
+		lo.Div(lo, den)
+	}
+	if lo.Cmp(hi) > 0 {
+		return nil, fmt.Errorf("%w %d%% (at least %s, at most %s)", ErrWeightsInfeasible, maxPercent, lo, hi)
+	}
+	if !lo.IsUint64() {
+		return nil, fmt.Errorf("%w %d%% (at least %s overflows)", ErrWeightsInfeasible, maxPercent, lo)
+	}
+
+	s := &Suggestion{Min: lo.Uint64(), Max: hi.Uint64(), Weight: def}
+	if len(current) > 0 {
+		s.Weight = new(big.Int).Div(sum, big.NewInt(int64(len(current)))).Uint64()
+	}
+	if s.Weight < s.Min {
+		s.Weight = s.Min
+	}
+	if s.Weight > s.Max {
+		s.Weight = s.Max
+	}
+
+	total := new(big.Int).Add(sum, new(big.Int).Mul(new(big.Int).SetUint64(s.Weight), bn))
+	if !total.IsUint64() {
+		return nil, fmt.Errorf("%w: total weight %s overflows", ErrWeightsInfeasible, total)
+	}
+	s.Total = total.Uint64()
+	heaviest := max
+	if s.Weight > heaviest {
+		heaviest = s.Weight
+	}
+	s.MaxPercent = float64(heaviest) / float64(s.Total) * 100
+	return s, nil
+}
+
+// StakeWeights returns the weights of the [nodes] proportional to their
+// primary network [stakes], for the subnet voting power to follow the
+// primary network stake distribution. The weight per staked nAVAX is the
+// one of the [current] subnet validators (their total weight over their
+// total stake), or one per [unit] (e.g., one per AVAX) without current
+// validators. The weights are at least 1.
+func StakeWeights(stakes map[ids.ShortID]uint64, current map[ids.ShortID]uint64, nodes []ids.ShortID, unit uint64) (map[ids.ShortID]uint64, error) {
+	weightSum, stakeSum := new(big.Int), new(big.Int)
+	for nodeID, w := range current {
+		stake, ok := stakes[nodeID]
+		if !ok || stake == 0 {
+			continue
+		}
+		weightSum.Add(weightSum, new(big.Int).SetUint64(w))
+		stakeSum.Add(stakeSum, new(big.Int).SetUint64(stake))
+	}
+	if stakeSum.Sign() == 0 {
+		weightSum.SetUint64(1)
+		stakeSum.SetUint64(unit)
+	}
+
+	ws := make(map[ids.ShortID]uint64, len(nodes))
+	for _, nodeID := range nodes {
+		stake, ok := stakes[nodeID]
+		if !ok || stake == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNoStake, nodeID.PrefixedString(constants.NodeIDPrefix))
+		}
+		w := new(big.Int).Mul(new(big.Int).SetUint64(stake), weightSum)
+		w.Div(w, stakeSum)
+		switch {
+		case !w.IsUint64():
+			return nil, fmt.Errorf("%w: weight %s of %s overflows", ErrWeightsInfeasible, w, nodeID.PrefixedString(constants.NodeIDPrefix))
+		case w.Sign() == 0:
+			w.SetUint64(1)
+		}
+		ws[nodeID] = w.Uint64()
+	}
+	return ws, nil
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rebalance
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestSuggestWeights(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		current    []uint64
+		n          int
+		maxPercent uint64
+		exp        Suggestion
+		expErr     error
+	}{
+		// mean of the current weights, within the bounds
+		{
+			current:    []uint64{1000, 1000, 1000, 1000, 1000},
+			n:          5,
+			maxPercent: 20,
+			exp:        Suggestion{Weight: 1000, Min: 1, Max: math.MaxUint64, Total: 10000, MaxPercent: 10},
+		},
+		// the heaviest node requires w >= 6500, but the new nodes
+		// cannot exceed w <= 25 * 7000 / 50
+		{
+			current:    []uint64{5000, 1000, 1000},
+			n:          2,
+			maxPercent: 25,
+			expErr:     ErrWeightsInfeasible,
+		},
+		// the heaviest node forces a higher weight
+		{
+			current:    []uint64{5000, 1000, 1000},
+			n:          3,
+			maxPercent: 25,
+			// w >= (500000 - 175000) / 75, w <= 175000 / 25
+			exp: Suggestion{Weight: 4334, Min: 4334, Max: 7000, Total: 20002, MaxPercent: 5000.0 / 20002 * 100},
+		},
+		{
+			current:    []uint64{1000, 1000, 1000, 1000, 100000},
+			n:          1,
+			maxPercent: 50,
+			exp:        Suggestion{Weight: 96000, Min: 96000, Max: 104000, Total: 200000, MaxPercent: 50},
+		},
+		// the new nodes cannot be heavier than the cap
+		{
+			current:    []uint64{10, 10, 10, 10},
+			n:          1,
+			maxPercent: 30,
+			// w <= 30 * 40 / 70
+			exp: Suggestion{Weight: 10, Min: 1, Max: 17, Total: 50, MaxPercent: 20},
+		},
+		// no current validators
+		{
+			n:          5,
+			maxPercent: 20,
+			exp:        Suggestion{Weight: 1000, Min: 1, Max: math.MaxUint64, Total: 5000, MaxPercent: 20},
+		},
+		{
+			n:          4,
+			maxPercent: 20,
+			expErr:     ErrWeightsInfeasible,
+		},
+		{
+			n:          1,
+			maxPercent: 0,
+			expErr:     ErrInvalidMaxPercent,
+		},
+		{
+			n:          0,
+			maxPercent: 20,
+			expErr:     ErrNoNewNodes,
+		},
+	}
+	for i, tv := range tt {
+		current := make(map[ids.ShortID]uint64, len(tv.current))
+		for j, w := range tv.current {
+			current[ids.ShortID{byte(j + 1)}] = w
+		}
+		s, err := SuggestWeights(current, tv.n, tv.maxPercent, 1000)
+		if !errors.Is(err, tv.expErr) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.expErr, err)
+		}
+		if tv.expErr != nil {
+			continue
+		}
+		if *s != tv.exp {
+			t.Fatalf("#%d: expected %+v, got %+v", i, tv.exp, *s)
+		}
+	}
+}
+
+func TestStakeWeights(t *testing.T) {
+	t.Parallel()
+
+	a, b, c, d := ids.ShortID{1}, ids.ShortID{2}, ids.ShortID{3}, ids.ShortID{4}
+	const avax = 1_000_000_000
+	stakes := map[ids.ShortID]uint64{
+		a: 2000 * avax,
+		b: 4000 * avax,
+		c: 3000 * avax,
+		d: 1,
+	}
+
+	tt := []struct {
+		current map[ids.ShortID]uint64
+		nodes   []ids.ShortID
+		exp     map[ids.ShortID]uint64
+		expErr  error
+	}{
+		// one per AVAX without current validators
+		{
+			nodes: []ids.ShortID{a, b},
+			exp:   map[ids.ShortID]uint64{a: 2000, b: 4000},
+		},
+		// the weight per stake of the current validators
+		{
+			current: map[ids.ShortID]uint64{a: 100, b: 200},
+			nodes:   []ids.ShortID{c},
+			exp:     map[ids.ShortID]uint64{c: 150},
+		},
+		// at least 1
+		{
+			nodes: []ids.ShortID{d},
+			exp:   map[ids.ShortID]uint64{d: 1},
+		},
+		{
+			nodes:  []ids.ShortID{{5}},
+			expErr: ErrNoStake,
+		},
+	}
+	for i, tv := range tt {
+		ws, err := StakeWeights(stakes, tv.current, tv.nodes, avax)
+		if !errors.Is(err, tv.expErr) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.expErr, err)
+		}
+		if tv.expErr != nil {
+			continue
+		}
+		if len(ws) != len(tv.exp) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.exp, ws)
+		}
+		for nodeID, w := range tv.exp {
+			if ws[nodeID] != w {
+				t.Fatalf("#%d: expected %v, got %v", i, tv.exp, ws)
+			}
+		}
+	}
+}
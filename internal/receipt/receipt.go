@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package receipt implements the receipt files of the committed txs.
+package receipt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ref. "avalanchego/utils/constants".
+const (
+	mainnetID = 1
+	fujiID    = 5
+)
+
+// Receipt is the record of a committed P-Chain tx.
+type Receipt struct {
+	TxID      string `json:"txId"`
+	Type      string `json:"type"`
+	NetworkID uint32 `json:"networkId"`
+	// Fee is the burned AVAX, in nAVAX.
+	Fee       uint64    `json:"fee"`
+	Timestamp time.Time `json:"timestamp"`
+	// Explorer is empty for the networks without an explorer.
+	Explorer string `json:"explorer,omitempty"`
+}
+
+// New creates the receipt of the tx committed now.
+func New(networkID uint32, txID string, txType string, fee uint64) *Receipt {
+	return &Receipt{
+		TxID:      txID,
+		Type:      txType,
+		NetworkID: networkID,
+		Fee:       fee,
+		Timestamp: time.Now().UTC(),
+		Explorer:  ExplorerURL(networkID, txID),
+	}
+}
+
+// ExplorerURL returns the explorer page of the P-Chain tx,
+// or an empty string if the network has no explorer.
+func ExplorerURL(networkID uint32, txID string) string {
+	switch networkID {
+	case mainnetID:
+		return "https://subnets.avax.network/p-chain/tx/" + txID
+	case fujiID:
+		return "https://subnets-test.avax.network/p-chain/tx/" + txID
+	default:
+		return ""
+	}
+}
+
+// Save writes the receipt in [dir], and returns its path.
+func (r *Receipt) Save(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	p := filepath.Join(dir, fmt.Sprintf("%s-%s.json", r.Type, r.TxID))
+	return p, ioutil.WriteFile(p, append(b, '\n'), 0o644)
+}
+
+// Load loads the receipt file at [p].
+func Load(p string) (*Receipt, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	r := new(Receipt)
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, fmt.Errorf("failed to parse receipt %q: %w", p, err)
+	}
+	return r, nil
+}
@@ -0,0 +1,37 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package receipt
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestReceipt(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "receipts")
+	r := New(fujiID, "2Q9bbD", "create_subnet", 1_000_000)
+	if r.Explorer != "https://subnets-test.avax.network/p-chain/tx/2Q9bbD" {
+		t.Fatalf("unexpected explorer URL %q", r.Explorer)
+	}
+	p, err := r.Save(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(p) != "create_subnet-2Q9bbD.json" {
+		t.Fatalf("unexpected receipt path %q", p)
+	}
+	loaded, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *loaded != *r {
+		t.Fatalf("expected %+v, got %+v", r, loaded)
+	}
+
+	if u := ExplorerURL(12345, "2Q9bbD"); u != "" {
+		t.Fatalf("unexpected explorer URL %q for a local network", u)
+	}
+}
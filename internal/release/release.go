@@ -0,0 +1,320 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package release implements the self-update of subnet-cli from its
+// GitHub releases, as published by goreleaser (ref. ".goreleaser.yml").
+package release
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const (
+	DefaultAPI  = "https://api.github.com"
+	DefaultRepo = "ava-labs/subnet-cli"
+
+	binaryName = "subnet-cli"
+)
+
+// PublicKey is the base64-encoded ed25519 public key of the release
+// signing key, set at build time. The checksums file of each release
+// is signed by the key (ref. ".goreleaser.yml").
+var PublicKey = ""
+
+var (
+	ErrAssetNotFound     = errors.New("release asset not found")
+	ErrChecksumMismatch  = errors.New("checksum mismatch")
+	ErrInvalidSignature  = errors.New("invalid checksums signature")
+	ErrInvalidPublicKey  = errors.New("invalid release public key")
+	ErrBinaryNotFound    = errors.New("binary not found in the release archive")
+	ErrUnexpectedStatus  = errors.New("unexpected HTTP status")
+	ErrInvalidReleaseTag = errors.New("invalid release tag")
+)
+
+// Asset is a file attached to the release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Release is a GitHub release of subnet-cli.
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	Body   string  `json:"body"`
+	Assets []Asset `json:"assets"`
+}
+
+// Version parses the release tag (e.g., "v0.0.3").
+func (r *Release) Version() (version.Version, error) {
+	v, err := version.NewDefaultParser().Parse(r.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %v", ErrInvalidReleaseTag, r.Tag, err)
+	}
+	return v, nil
+}
+
+// Asset returns the asset of [name].
+func (r *Release) Asset(name string) (Asset, error) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, nil
+		}
+	}
+	return Asset{}, fmt.Errorf("%w: %q in %s", ErrAssetNotFound, name, r.Tag)
+}
+
+var minNodeVersionRe = regexp.MustCompile(`(?m)^\s*min-avalanchego:\s*v?(\d+\.\d+\.\d+)\s*$`)
+
+// MinNodeVersion returns the oldest node version the release is compatible
+// with, declared by the "min-avalanchego: v1.11.0" line of the release
+// notes. It returns false if the release notes declare none.
+func (r *Release) MinNodeVersion() (version.Application, bool) {
+	m := minNodeVersionRe.FindStringSubmatch(r.Body)
+	if m == nil {
+		return nil, false
+	}
+	v, err := version.NewDefaultParser().Parse("v" + m[1])
+	if err != nil {
+		return nil, false
+	}
+	return version.NewDefaultApplication(constants.PlatformName, v.Major(), v.Minor(), v.Patch()), true
+}
+
+// ArchiveName returns the name of the release archive for the platform,
+// as named by goreleaser (e.g., "subnet-cli_0.0.3_linux_amd64.tar.gz").
+func ArchiveName(ver string, goos string, goarch string) string {
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return fmt.Sprintf("%s_%s_%s_%s%s", binaryName, strings.TrimPrefix(ver, "v"), goos, goarch, ext)
+}
+
+// ChecksumsName returns the name of the release checksums file.
+func ChecksumsName(ver string) string {
+	return fmt.Sprintf("%s_%s_checksums.txt", binaryName, strings.TrimPrefix(ver, "v"))
+}
+
+// ParsePublicKey decodes the base64-encoded ed25519 public key.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPublicKey, err)
+	}
+	if len(b) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidPublicKey, ed25519.PublicKeySize, len(b))
+	}
+	return ed25519.PublicKey(b), nil
+}
+
+// VerifySignature verifies the ed25519 signature of the checksums file.
+func VerifySignature(checksums []byte, sig []byte, pub ed25519.PublicKey) error {
+	if !ed25519.Verify(pub, checksums, sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyChecksum verifies the SHA-256 checksum of the file [name] against
+// its line of the checksums file ("<hex digest>  <name>").
+func VerifyChecksum(checksums []byte, name string, b []byte) error {
+	digest := sha256.Sum256(b)
+	s := bufio.NewScanner(bytes.NewReader(checksums))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) != 2 || fields[1] != name {
+			continue
+		}
+		if fields[0] != hex.EncodeToString(digest[:]) {
+			return fmt.Errorf("%w: %s", ErrChecksumMismatch, name)
+		}
+		return nil
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: no checksum for %s", ErrAssetNotFound, name)
+}
+
+// ExtractBinary returns the subnet-cli binary of the release archive.
+func ExtractBinary(archive []byte, goos string) ([]byte, error) {
+	name := binaryName
+	if goos == "windows" {
+		name += ".exe"
+		zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range zr.File {
+			if filepath.Base(f.Name) != name {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			return ioutil.ReadAll(rc)
+		}
+		return nil, ErrBinaryNotFound
+	}
+
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrBinaryNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag == tar.TypeReg && filepath.Base(hdr.Name) == name {
+			return ioutil.ReadAll(tr)
+		}
+	}
+}
+
+// Replace replaces the executable at [exe] with [bin]. The new binary is
+// written next to it and renamed over it, not to leave a partial binary.
+func Replace(exe string, bin []byte) error {
+	info, err := os.Stat(exe)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(exe), "."+filepath.Base(exe)+".new-")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+	if _, err := f.Write(bin); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp, info.Mode()); err != nil {
+		return err
+	}
+	// the running executable cannot be overwritten on Windows, only renamed
+	old := exe + ".old"
+	_ = os.Remove(old)
+	if err := os.Rename(exe, old); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, exe); err != nil {
+		_ = os.Rename(old, exe)
+		return err
+	}
+	_ = os.Remove(old)
+	return nil
+}
+
+// Client fetches the releases of [repo] from the GitHub API.
+type Client struct {
+	api  string
+	repo string
+}
+
+// New creates a client of the releases of [repo] (e.g., "ava-labs/subnet-cli").
+func New(api string, repo string) *Client {
+	return &Client{api: strings.TrimSuffix(api, "/"), repo: repo}
+}
+
+// Latest returns the latest release.
+func (c *Client) Latest(ctx context.Context) (*Release, error) {
+	b, err := c.get(ctx, fmt.Sprintf("%s/repos/%s/releases/latest", c.api, c.repo), "application/vnd.github.v3+json")
+	if err != nil {
+		return nil, err
+	}
+	r := new(Release)
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Fetch downloads the binary of the release for the platform, after
+// verifying the signature of the checksums file with [pub], and the
+// checksum of the archive.
+func (c *Client) Fetch(ctx context.Context, r *Release, goos string, goarch string, pub ed25519.PublicKey) ([]byte, error) {
+	checksumsAsset, err := r.Asset(ChecksumsName(r.Tag))
+	if err != nil {
+		return nil, err
+	}
+	sigAsset, err := r.Asset(ChecksumsName(r.Tag) + ".sig")
+	if err != nil {
+		return nil, err
+	}
+	archiveAsset, err := r.Asset(ArchiveName(r.Tag, goos, goarch))
+	if err != nil {
+		return nil, err
+	}
+
+	checksums, err := c.get(ctx, checksumsAsset.URL, "")
+	if err != nil {
+		return nil, err
+	}
+	sig, err := c.get(ctx, sigAsset.URL, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifySignature(checksums, sig, pub); err != nil {
+		return nil, err
+	}
+	archive, err := c.get(ctx, archiveAsset.URL, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyChecksum(checksums, archiveAsset.Name, archive); err != nil {
+		return nil, err
+	}
+	return ExtractBinary(archive, goos)
+}
+
+func (c *Client) get(ctx context.Context, url string, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w %d from %s", ErrUnexpectedStatus, resp.StatusCode, url)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package release
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newArchive(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, b := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(b)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherPriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin := []byte("new binary")
+	archive := newArchive(t, map[string][]byte{"README.md": []byte("readme"), "subnet-cli": bin})
+	archiveName := ArchiveName("v0.0.4", "linux", "amd64")
+	digest := sha256.Sum256(archive)
+
+	tt := []struct {
+		checksums string
+		signer    ed25519.PrivateKey
+		err       error
+	}{
+		{
+			checksums: fmt.Sprintf("%s  %s\n", hex.EncodeToString(digest[:]), archiveName),
+			signer:    priv,
+		},
+		{
+			checksums: fmt.Sprintf("%s  %s\n", hex.EncodeToString(digest[:]), archiveName),
+			signer:    otherPriv,
+			err:       ErrInvalidSignature,
+		},
+		{
+			checksums: fmt.Sprintf("%s  %s\n", hex.EncodeToString(make([]byte, 32)), archiveName),
+			signer:    priv,
+			err:       ErrChecksumMismatch,
+		},
+		{
+			checksums: fmt.Sprintf("%s  other.tar.gz\n", hex.EncodeToString(digest[:])),
+			signer:    priv,
+			err:       ErrAssetNotFound,
+		},
+	}
+	for i, tv := range tt {
+		files := map[string][]byte{
+			"/" + archiveName:                      archive,
+			"/" + ChecksumsName("v0.0.4"):          []byte(tv.checksums),
+			"/" + ChecksumsName("v0.0.4") + ".sig": ed25519.Sign(tv.signer, []byte(tv.checksums)),
+		}
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/repos/"+DefaultRepo+"/releases/latest" {
+				rel := Release{Tag: "v0.0.4"}
+				for name := range files {
+					rel.Assets = append(rel.Assets, Asset{Name: name[1:], URL: "http://" + r.Host + name})
+				}
+				_ = json.NewEncoder(w).Encode(rel)
+				return
+			}
+			b, ok := files[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(b)
+		}))
+
+		c := New(srv.URL, DefaultRepo)
+		rel, err := c.Latest(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := c.Fetch(context.Background(), rel, "linux", "amd64", pub)
+		srv.Close()
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+		if tv.err == nil && !bytes.Equal(b, bin) {
+			t.Fatalf("#%d: expected %q, got %q", i, bin, b)
+		}
+	}
+}
+
+func TestMinNodeVersion(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		body     string
+		expected string
+	}{
+		{body: "Fixes.\n\nmin-avalanchego: v1.11.0\n", expected: "avalanche/1.11.0"},
+		{body: "min-avalanchego: 1.9.3", expected: "avalanche/1.9.3"},
+		{body: "Requires a recent avalanchego."},
+	}
+	for i, tv := range tt {
+		v, ok := (&Release{Body: tv.body}).MinNodeVersion()
+		if ok != (tv.expected != "") {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.expected != "", ok)
+		}
+		if ok && v.String() != tv.expected {
+			t.Fatalf("#%d: expected %q, got %q", i, tv.expected, v)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package rotate plans the rotation of the control keys of a permissioned
+// subnet, moving its control to the new keys with an ownership transfer,
+// or by re-creating the subnet if its owners can't be transferred (e.g.,
+// before Durango, or once the subnet is elastic).
+package rotate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	ErrSameOwners = errors.New("new control keys and threshold are the current ones")
+	ErrNoOwners   = errors.New("missing current or new subnet owners")
+)
+
+type Method string
+
+const (
+	// Transfer replaces the subnet owners with a "TransferSubnetOwnershipTx".
+	Transfer Method = "transfer"
+	// Recreate creates a subnet owned by the new keys, and moves the
+	// blockchains and the validators to it.
+	Recreate Method = "recreate"
+)
+
+// Step is a step of the rotation, executed by subnet-cli unless Manual.
+type Step struct {
+	Description string
+	Manual      bool
+}
+
+// Blockchain is a blockchain of the subnet, to re-create.
+type Blockchain struct {
+	ID   ids.ID
+	Name string
+}
+
+// Input is the subnet to rotate the control keys of.
+type Input struct {
+	NetworkID uint32
+	SubnetID  ids.ID
+	Current   *secp256k1fx.OutputOwners
+	Next      *secp256k1fx.OutputOwners
+	// Transferable is true if the network accepts the ownership transfers.
+	Transferable bool
+	// Elastic is true if the subnet was transformed, its owners being
+	// immutable since.
+	Elastic     bool
+	Blockchains []Blockchain
+	Validators  []ids.ShortID
+}
+
+// Plan is the rotation of the control keys.
+type Plan struct {
+	Method Method
+	// Added and Removed are the control keys gained and lost.
+	Added   []ids.ShortID
+	Removed []ids.ShortID
+	Steps   []Step
+}
+
+// New returns the plan to rotate the control keys of the subnet.
+func New(in Input) (*Plan, error) {
+	if in.Current == nil || in.Next == nil {
+		return nil, ErrNoOwners
+	}
+	p := &Plan{
+		Added:   diff(in.Next.Addrs, in.Current.Addrs),
+		Removed: diff(in.Current.Addrs, in.Next.Addrs),
+	}
+	if len(p.Added) == 0 && len(p.Removed) == 0 && in.Current.Threshold == in.Next.Threshold {
+		return nil, ErrSameOwners
+	}
+	hrp := constants.GetHRP(in.NetworkID)
+	current, next := owners(hrp, in.Current), owners(hrp, in.Next)
+
+	if in.Transferable && !in.Elastic {
+		p.Method = Transfer
+		p.Steps = []Step{
+			{Description: fmt.Sprintf("verify that the key holds the current control keys (%s)", current)},
+			{Description: fmt.Sprintf("issue a TransferSubnetOwnershipTx of subnet %s to %s", in.SubnetID, next)},
+			{Description: "verify that the subnet owners are the new control keys"},
+			{Description: "update the keys of the profiles and the control keys of the multisig proposals to the new ones", Manual: true},
+		}
+		if len(p.Removed) > 0 {
+			p.Steps = append(p.Steps, Step{
+				Description: fmt.Sprintf("retire the removed control keys (%s), which no longer control the subnet", addresses(hrp, p.Removed)),
+				Manual:      true,
+			})
+		}
+		return p, nil
+	}
+
+	p.Method = Recreate
+	create := "create a subnet owned by a new control key (\"subnet-cli create subnet\" with the new key)"
+	if len(in.Next.Addrs) > 1 || in.Next.Threshold > 1 {
+		create += fmt.Sprintf(", then transfer it to %s once the network accepts the ownership transfers", next)
+	}
+	p.Steps = append(p.Steps, Step{Description: create, Manual: true})
+	for _, bc := range in.Blockchains {
+		p.Steps = append(p.Steps, Step{
+			Description: fmt.Sprintf("create blockchain %q on the new subnet (\"subnet-cli clone blockchain --source-chain-id=%s\")", bc.Name, bc.ID),
+			Manual:      true,
+		})
+	}
+	if len(in.Validators) > 0 {
+		nodeIDs := make([]string, len(in.Validators))
+		for i, nodeID := range in.Validators {
+			nodeIDs[i] = nodeID.PrefixedString(constants.NodeIDPrefix)
+		}
+		p.Steps = append(p.Steps, Step{
+			Description: fmt.Sprintf("track the new subnet on the validators (\"subnet-cli node track-subnet\"), and add them to it (\"subnet-cli add subnet-validator --node-ids=%s\")", strings.Join(nodeIDs, ",")),
+			Manual:      true,
+		})
+	}
+	if in.Elastic {
+		p.Steps = append(p.Steps, Step{Description: "transform the new subnet into an elastic subnet, with the staking parameters of the current one", Manual: true})
+	}
+	p.Steps = append(p.Steps, Step{
+		Description: fmt.Sprintf("move the clients to the new blockchain IDs, then retire subnet %s (\"subnet-cli decommission subnet\")", in.SubnetID),
+		Manual:      true,
+	})
+	return p, nil
+}
+
+// diff returns the addresses of [a] not in [b].
+func diff(a, b []ids.ShortID) []ids.ShortID {
+	in := make(map[ids.ShortID]struct{}, len(b))
+	for _, addr := range b {
+		in[addr] = struct{}{}
+	}
+	var d []ids.ShortID
+	for _, addr := range a {
+		if _, ok := in[addr]; !ok {
+			d = append(d, addr)
+		}
+	}
+	return d
+}
+
+func owners(hrp string, o *secp256k1fx.OutputOwners) string {
+	return fmt.Sprintf("%d of %s", o.Threshold, addresses(hrp, o.Addrs))
+}
+
+func addresses(hrp string, addrs []ids.ShortID) string {
+	ss := make([]string, len(addrs))
+	for i, addr := range addrs {
+		s, err := formatting.FormatAddress("P", hrp, addr[:])
+		if err != nil {
+			s = addr.String()
+		}
+		ss[i] = s
+	}
+	return strings.Join(ss, ", ")
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rotate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := ids.ShortID{1}, ids.ShortID{2}, ids.ShortID{3}
+	current := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{a, b}}
+	chains := []Blockchain{{ID: ids.GenerateTestID(), Name: "spacesvm"}}
+	nodes := []ids.ShortID{ids.GenerateTestShortID()}
+
+	tt := []struct {
+		in      Input
+		method  Method
+		added   int
+		removed int
+		manual  int
+		steps   int
+		err     error
+	}{
+		{
+			in:     Input{Next: &secp256k1fx.OutputOwners{Threshold: 2, Addrs: []ids.ShortID{b, c}}, Transferable: true},
+			method: Transfer, added: 1, removed: 1, manual: 2, steps: 5,
+		},
+		{
+			in:     Input{Next: &secp256k1fx.OutputOwners{Threshold: 2, Addrs: []ids.ShortID{a, b}}, Transferable: true},
+			method: Transfer, manual: 1, steps: 4,
+		},
+		{
+			// create, blockchain, validators, transform, retire
+			in:     Input{Next: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{c}}, Transferable: true, Elastic: true, Blockchains: chains, Validators: nodes},
+			method: Recreate, added: 1, removed: 2, manual: 5, steps: 5,
+		},
+		{
+			in:     Input{Next: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{c}}},
+			method: Recreate, added: 1, removed: 2, manual: 2, steps: 2,
+		},
+		{
+			in:  Input{Next: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{a, b}}, Transferable: true},
+			err: ErrSameOwners,
+		},
+		{
+			in:  Input{Transferable: true},
+			err: ErrNoOwners,
+		},
+	}
+	for i, tv := range tt {
+		tv.in.NetworkID = constants.FujiID
+		tv.in.SubnetID = ids.GenerateTestID()
+		if tv.in.Next != nil {
+			tv.in.Current = current
+		}
+		p, err := New(tv.in)
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+		if tv.err != nil {
+			continue
+		}
+		manual := 0
+		for _, s := range p.Steps {
+			if s.Manual {
+				manual++
+			}
+		}
+		if p.Method != tv.method || len(p.Added) != tv.added || len(p.Removed) != tv.removed || manual != tv.manual || len(p.Steps) != tv.steps {
+			t.Fatalf("#%d: unexpected plan %+v", i, p)
+		}
+	}
+}
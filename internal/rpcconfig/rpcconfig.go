@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package rpcconfig writes the JSON-RPC endpoint of a chain into the config
+// files of the dapp tooling: a dotenv file, or a JSON file of the Hardhat
+// networks (e.g., "networks: require('./networks.json')" in
+// "hardhat.config.js").
+package rpcconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidKey  = errors.New("invalid dotenv key")
+	ErrInvalidFile = errors.New("invalid Hardhat networks file")
+)
+
+var dotenvKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SetDotenv sets [key] to [value] in the dotenv file at [p], replacing the
+// existing assignment if any and keeping the other lines, or creates the
+// file if missing.
+func SetDotenv(p string, key string, value string) error {
+	if !dotenvKey.MatchString(key) {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	b, mode, err := read(p)
+	if err != nil {
+		return err
+	}
+	line := fmt.Sprintf("%s=%q", key, value)
+	var lines []string
+	if len(b) > 0 {
+		lines = strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	}
+	found := false
+	for i, l := range lines {
+		k := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(l), "export "))
+		if j := strings.Index(k, "="); j > 0 && strings.TrimSpace(k[:j]) == key {
+			lines[i], found = line, true
+		}
+	}
+	if !found {
+		lines = append(lines, line)
+	}
+	return ioutil.WriteFile(p, []byte(strings.Join(lines, "\n")+"\n"), mode)
+}
+
+// HardhatNetwork is a network of the Hardhat config.
+type HardhatNetwork struct {
+	URL string `json:"url"`
+	// ChainID is the EIP-155 chain ID, omitted if unknown.
+	ChainID uint64 `json:"chainId,omitempty"`
+}
+
+// SetHardhatNetwork sets the network [name] in the JSON file of the
+// Hardhat networks at [p], keeping the other networks as is, or creates the
+// file if missing.
+func SetHardhatNetwork(p string, name string, n HardhatNetwork) error {
+	b, mode, err := read(p)
+	if err != nil {
+		return err
+	}
+	networks := make(map[string]json.RawMessage)
+	if len(strings.TrimSpace(string(b))) > 0 {
+		if err := json.Unmarshal(b, &networks); err != nil {
+			return fmt.Errorf("%w %q: %v", ErrInvalidFile, p, err)
+		}
+	}
+	raw, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	networks[name] = raw
+	b, err = json.MarshalIndent(networks, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p, append(b, '\n'), mode)
+}
+
+// read returns the content and the mode of the file at [p], or nothing
+// and the mode of a new file if missing.
+func read(p string) ([]byte, os.FileMode, error) {
+	fi, err := os.Stat(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0o644, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	b, err := ioutil.ReadFile(p)
+	return b, fi.Mode().Perm(), err
+}
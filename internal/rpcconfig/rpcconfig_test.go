@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetDotenv(t *testing.T) {
+	t.Parallel()
+
+	p := filepath.Join(t.TempDir(), ".env")
+	if err := ioutil.WriteFile(p, []byte("# subnet\nPRIVATE_KEY=abc\nexport RPC_URL=http://old\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetDotenv(p, "RPC_URL", "http://localhost:9650/ext/bc/x/rpc"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetDotenv(p, "CHAIN_RPC", "http://new"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetDotenv(p, "1BAD", "x"); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("expected %v, got %v", ErrInvalidKey, err)
+	}
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "# subnet\nPRIVATE_KEY=abc\nRPC_URL=\"http://localhost:9650/ext/bc/x/rpc\"\nCHAIN_RPC=\"http://new\"\n"
+	if string(b) != expected {
+		t.Fatalf("expected %q, got %q", expected, b)
+	}
+}
+
+func TestSetHardhatNetwork(t *testing.T) {
+	t.Parallel()
+
+	p := filepath.Join(t.TempDir(), "networks.json")
+	if err := SetHardhatNetwork(p, "fuji", HardhatNetwork{URL: "https://api.avax-test.network/ext/bc/C/rpc", ChainID: 43113}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetHardhatNetwork(p, "subnet", HardhatNetwork{URL: "http://old"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetHardhatNetwork(p, "subnet", HardhatNetwork{URL: "http://new", ChainID: 99999}); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var networks map[string]HardhatNetwork
+	if err := json.Unmarshal(b, &networks); err != nil {
+		t.Fatal(err)
+	}
+	if len(networks) != 2 || networks["fuji"].ChainID != 43113 || networks["subnet"] != (HardhatNetwork{URL: "http://new", ChainID: 99999}) {
+		t.Fatalf("unexpected networks %+v", networks)
+	}
+
+	if err := ioutil.WriteFile(p, []byte("module.exports = {}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetHardhatNetwork(p, "subnet", HardhatNetwork{URL: "http://new"}); !errors.Is(err, ErrInvalidFile) {
+		t.Fatalf("expected %v, got %v", ErrInvalidFile, err)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package runner implements the client of the avalanche-network-runner
+// control API, via its gRPC gateway (e.g., "http://127.0.0.1:8081").
+package runner
+
+// ref. https://github.com/ava-labs/avalanche-network-runner/blob/main/rpcpb/rpc.proto
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const DefaultEndpoint = "http://127.0.0.1:8081"
+
+var (
+	ErrRequestFailed = errors.New("network runner request failed")
+	ErrNoNodes       = errors.New("network runner has no nodes")
+)
+
+// Node is a node of the local network.
+type Node struct {
+	Name string `json:"name"`
+	URI  string `json:"uri"`
+	// ID is the node ID (e.g., "NodeID-...").
+	ID                 string `json:"id"`
+	ExecPath           string `json:"execPath"`
+	WhitelistedSubnets string `json:"whitelistedSubnets"`
+}
+
+// Cluster is the state of the local network.
+type Cluster struct {
+	NodeNames []string        `json:"nodeNames"`
+	NodeInfos map[string]Node `json:"nodeInfos"`
+	Healthy   bool            `json:"healthy"`
+}
+
+// Nodes returns the nodes of the network, sorted by name.
+func (c *Cluster) Nodes() []Node {
+	ns := make([]Node, 0, len(c.NodeInfos))
+	for _, n := range c.NodeInfos {
+		ns = append(ns, n)
+	}
+	sort.Slice(ns, func(i, j int) bool { return ns[i].Name < ns[j].Name })
+	return ns
+}
+
+// StartRequest is the configuration of the local network.
+type StartRequest struct {
+	ExecPath  string `json:"execPath"`
+	NumNodes  uint32 `json:"numNodes,omitempty"`
+	LogLevel  string `json:"logLevel,omitempty"`
+	PluginDir string `json:"pluginDir,omitempty"`
+	// WhitelistedSubnets is the comma-separated list of the subnet IDs
+	// to track.
+	WhitelistedSubnets string `json:"whitelistedSubnets,omitempty"`
+}
+
+type Client interface {
+	// Start starts the local network, and returns before it is healthy.
+	Start(ctx context.Context, req StartRequest) (*Cluster, error)
+	// Health waits for the local network to be healthy.
+	Health(ctx context.Context) (*Cluster, error)
+	// Status returns the state of the local network.
+	Status(ctx context.Context) (*Cluster, error)
+	// RestartNode restarts the node [name] to track [subnetIDs].
+	RestartNode(ctx context.Context, name string, execPath string, subnetIDs []string) (*Cluster, error)
+	// Stop stops the local network.
+	Stop(ctx context.Context) error
+}
+
+type client struct {
+	endpoint string
+}
+
+// New creates a client of the network runner gRPC gateway at [endpoint].
+func New(endpoint string) Client {
+	return &client{endpoint: strings.TrimSuffix(endpoint, "/")}
+}
+
+type clusterResponse struct {
+	ClusterInfo *Cluster `json:"clusterInfo"`
+}
+
+func (c *client) Start(ctx context.Context, req StartRequest) (*Cluster, error) {
+	return c.cluster(ctx, "start", req)
+}
+
+func (c *client) Health(ctx context.Context) (*Cluster, error) {
+	return c.cluster(ctx, "health", struct{}{})
+}
+
+func (c *client) Status(ctx context.Context) (*Cluster, error) {
+	return c.cluster(ctx, "status", struct{}{})
+}
+
+func (c *client) RestartNode(ctx context.Context, name string, execPath string, subnetIDs []string) (*Cluster, error) {
+	return c.cluster(ctx, "restartnode", map[string]string{
+		"name":               name,
+		"execPath":           execPath,
+		"whitelistedSubnets": strings.Join(subnetIDs, ","),
+	})
+}
+
+func (c *client) Stop(ctx context.Context) error {
+	_, err := c.cluster(ctx, "stop", struct{}{})
+	return err
+}
+
+func (c *client) cluster(ctx context.Context, method string, req interface{}) (*Cluster, error) {
+	resp := new(clusterResponse)
+	if err := c.call(ctx, method, req, resp); err != nil {
+		return nil, err
+	}
+	if resp.ClusterInfo == nil {
+		resp.ClusterInfo = &Cluster{}
+	}
+	return resp.ClusterInfo, nil
+}
+
+func (c *client) call(ctx context.Context, method string, req interface{}, resp interface{}) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/v1/control/"+method, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	hreq.Header.Set("Content-Type", "application/json")
+	hresp, err := http.DefaultClient.Do(hreq)
+	if err != nil {
+		return err
+	}
+	defer hresp.Body.Close()
+	rb, err := ioutil.ReadAll(hresp.Body)
+	if err != nil {
+		return err
+	}
+	if hresp.StatusCode != http.StatusOK {
+		// ref. "grpc-gateway" error body
+		var e struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(rb, &e) != nil || e.Message == "" {
+			e.Message = strings.TrimSpace(string(rb))
+		}
+		return fmt.Errorf("%w: %s: %s (status %d)", ErrRequestFailed, method, e.Message, hresp.StatusCode)
+	}
+	return json.Unmarshal(rb, resp)
+}
+
+// WaitHealthy polls the health of the network every [interval] until
+// healthy, as the runner returns before the nodes are started.
+func WaitHealthy(ctx context.Context, c Client, interval time.Duration) (*Cluster, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		cluster, err := c.Health(ctx)
+		if err == nil && cluster.Healthy {
+			if len(cluster.NodeInfos) == 0 {
+				return nil, ErrNoNodes
+			}
+			return cluster, nil
+		}
+		select {
+		case <-ctx.Done():
+			if err == nil {
+				err = ctx.Err()
+			}
+			return nil, fmt.Errorf("network not healthy: %w", err)
+		case <-ticker.C:
+		}
+	}
+}
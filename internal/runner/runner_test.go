@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	healthChecks := 0
+	var restarted map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/control/start":
+			var req StartRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ExecPath != "/bin/avalanchego" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":3,"message":"invalid exec path"}`))
+				return
+			}
+			w.Write([]byte(`{"clusterInfo":{"nodeNames":["node1"]}}`))
+		case "/v1/control/health":
+			healthChecks++
+			if healthChecks < 2 {
+				w.Write([]byte(`{"clusterInfo":{"healthy":false}}`))
+				return
+			}
+			w.Write([]byte(`{"clusterInfo":{"healthy":true,"nodeInfos":{
+				"node2":{"name":"node2","uri":"http://127.0.0.1:9652","id":"NodeID-2"},
+				"node1":{"name":"node1","uri":"http://127.0.0.1:9650","id":"NodeID-1"}}}}`))
+		case "/v1/control/restartnode":
+			if err := json.NewDecoder(r.Body).Decode(&restarted); err != nil {
+				t.Error(err)
+			}
+			w.Write([]byte(`{"clusterInfo":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	cli := New(srv.URL + "/")
+	if _, err := cli.Start(ctx, StartRequest{ExecPath: "/tmp/avalanchego"}); !errors.Is(err, ErrRequestFailed) {
+		t.Fatalf("expected %v, got %v", ErrRequestFailed, err)
+	}
+	cluster, err := cli.Start(ctx, StartRequest{ExecPath: "/bin/avalanchego", NumNodes: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cluster.NodeNames) != 1 {
+		t.Fatalf("unexpected cluster %+v", cluster)
+	}
+
+	cluster, err = WaitHealthy(ctx, cli, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes := cluster.Nodes()
+	if len(nodes) != 2 || nodes[0].ID != "NodeID-1" || nodes[1].URI != "http://127.0.0.1:9652" {
+		t.Fatalf("unexpected nodes %+v", nodes)
+	}
+
+	if _, err := cli.RestartNode(ctx, "node1", "/bin/avalanchego", []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if restarted["name"] != "node1" || restarted["whitelistedSubnets"] != "a,b" {
+		t.Fatalf("unexpected restart request %v", restarted)
+	}
+}
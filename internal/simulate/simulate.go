@@ -0,0 +1,304 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulate checks a P-Chain transaction against the P-Chain state
+// it depends on, fetched beforehand, to catch the common failures of the
+// semantic verification of the platformvm (e.g., a duplicate validator, an
+// invalid subnet auth) before the transaction is broadcast. The checks are
+// reimplemented here after the platformvm ones, and cover the validation
+// period, the validators, the credentials and the fee, not the whole
+// verification of the node.
+package simulate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	"github.com/ava-labs/subnet-cli/internal/doctor"
+	"github.com/ava-labs/subnet-cli/internal/staking"
+)
+
+// MaxFutureStartTime is the furthest a validator may start after the
+// P-Chain time (ref. "platformvm.maxFutureStartTime").
+const MaxFutureStartTime = 2 * 7 * 24 * time.Hour
+
+// Window is the validation period of a current or pending validator.
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+// State is the P-Chain state the transaction depends on.
+type State struct {
+	NetworkID   uint32
+	AVAXAssetID ids.ID
+	// Timestamp is the P-Chain time the transaction is verified at.
+	Timestamp time.Time
+	// Fee is the fee the transaction must burn.
+	Fee    uint64
+	Limits staking.Limits
+	// Validators is the current and pending validators by subnet
+	// ("constants.PrimaryNetworkID" for the primary network).
+	Validators map[ids.ID]map[ids.ShortID]Window
+	// Subnets is the owners of the subnets, missing if no such subnet.
+	Subnets map[ids.ID]*secp256k1fx.OutputOwners
+	// UTXOs is the unspent UTXOs by their input IDs, missing if spent.
+	UTXOs map[ids.ID]*avax.UTXO
+}
+
+// Subnet returns the subnet the transaction depends on, if any.
+func Subnet(utx platformvm.UnsignedTx) (ids.ID, bool) {
+	switch tx := utx.(type) {
+	case *platformvm.UnsignedAddSubnetValidatorTx:
+		return tx.Validator.Subnet, true
+	case *platformvm.UnsignedCreateChainTx:
+		return tx.SubnetID, true
+	default:
+		return ids.Empty, false
+	}
+}
+
+// Verify runs the checks on [tx] against [st], and returns their results. The signatures of the inputs and the subnet auth
+// are only verified if [signed], the other checks run on the unsigned
+// transactions too.
+func Verify(tx *platformvm.Tx, signed bool, st *State) *doctor.Checklist {
+	c := new(doctor.Checklist)
+	ctx := &snow.Context{
+		NetworkID:   st.NetworkID,
+		ChainID:     constants.PlatformChainID,
+		AVAXAssetID: st.AVAXAssetID,
+	}
+	if err := tx.UnsignedTx.SyntacticVerify(ctx); err != nil {
+		c.Fail("syntax", "%v", err)
+		return c
+	}
+	c.Pass("syntax", "well-formed for network %d", st.NetworkID)
+
+	fx, err := newFx(st.Timestamp, signed)
+	if err != nil {
+		c.Fail("credentials", "%v", err)
+		return c
+	}
+	v := &verifier{c: c, tx: tx, signed: signed, st: st, fx: fx}
+	switch utx := tx.UnsignedTx.(type) {
+	case *platformvm.UnsignedAddValidatorTx:
+		v.checkPeriod(utx.Validator.StartTime(), utx.Validator.EndTime())
+		v.checkPrimaryValidator(utx.Validator.NodeID)
+		v.checkSpend(utx.Ins, append(utx.Outs, utx.Stake...), tx.Creds)
+	case *platformvm.UnsignedAddSubnetValidatorTx:
+		v.checkPeriod(utx.Validator.StartTime(), utx.Validator.EndTime())
+		v.checkSubnetValidator(utx.Validator.Subnet, utx.Validator.NodeID, utx.Validator.StartTime(), utx.Validator.EndTime())
+		creds := v.checkSubnetAuth(utx.Validator.Subnet, utx.SubnetAuth)
+		v.checkSpend(utx.Ins, utx.Outs, creds)
+	case *platformvm.UnsignedCreateChainTx:
+		creds := v.checkSubnetAuth(utx.SubnetID, utx.SubnetAuth)
+		v.checkSpend(utx.Ins, utx.Outs, creds)
+	case *platformvm.UnsignedCreateSubnetTx:
+		v.checkSpend(utx.Ins, utx.Outs, tx.Creds)
+	default:
+		c.Skip("state", "%T is not simulated", utx)
+	}
+	return c
+}
+
+var _ secp256k1fx.VM = &fxVM{}
+
+// fxVM is the VM the secp256k1fx runs in, with the clock at the P-Chain
+// time, so that the locktimes are checked as the platformvm would.
+type fxVM struct {
+	clk   mockable.Clock
+	codec codec.Registry
+}
+
+func (vm *fxVM) Clock() *mockable.Clock        { return &vm.clk }
+func (vm *fxVM) CodecRegistry() codec.Registry { return vm.codec }
+func (vm *fxVM) Logger() logging.Logger        { return logging.NoLog{} }
+
+// newFx returns the secp256k1fx of the platformvm, with its clock at the
+// P-Chain time. The signatures are only verified once bootstrapped.
+func newFx(now time.Time, bootstrapped bool) (*secp256k1fx.Fx, error) {
+	vm := &fxVM{codec: linearcodec.NewDefault()}
+	vm.clk.Set(now)
+	fx := new(secp256k1fx.Fx)
+	if err := fx.Initialize(vm); err != nil {
+		return nil, err
+	}
+	if bootstrapped {
+		if err := fx.Bootstrapped(); err != nil {
+			return nil, err
+		}
+	}
+	return fx, nil
+}
+
+type verifier struct {
+	c      *doctor.Checklist
+	tx     *platformvm.Tx
+	signed bool
+	st     *State
+	fx     *secp256k1fx.Fx
+}
+
+// ref. "platformvm.UnsignedAddSubnetValidatorTx.Execute".
+func (v *verifier) checkPeriod(start, end time.Time) {
+	// the start time must be after the P-Chain time, in seconds
+	if err := v.st.Limits.Check(start, end, v.st.Timestamp, time.Second); err != nil {
+		v.c.Fail("period", "%v (P-Chain time %s)", err, v.st.Timestamp.Format(time.RFC3339))
+		return
+	}
+	if latest := v.st.Timestamp.Add(MaxFutureStartTime); start.After(latest) {
+		v.c.Fail("period", "start %s is more than %v after the P-Chain time %s",
+			start.Format(time.RFC3339), MaxFutureStartTime, v.st.Timestamp.Format(time.RFC3339))
+		return
+	}
+	v.c.Pass("period", "%s to %s", start.Format(time.RFC3339), end.Format(time.RFC3339))
+}
+
+func (v *verifier) checkPrimaryValidator(nodeID ids.ShortID) {
+	id := nodeID.PrefixedString(constants.NodeIDPrefix)
+	if w, ok := v.st.Validators[constants.PrimaryNetworkID][nodeID]; ok {
+		v.c.Fail("validator", "%s already validates the primary network until %s", id, w.End.Format(time.RFC3339))
+		return
+	}
+	v.c.Pass("validator", "%s is not a primary network validator yet", id)
+}
+
+func (v *verifier) checkSubnetValidator(subnetID ids.ID, nodeID ids.ShortID, start, end time.Time) {
+	id := nodeID.PrefixedString(constants.NodeIDPrefix)
+	if w, ok := v.st.Validators[subnetID][nodeID]; ok {
+		v.c.Fail("validator", "%s already validates subnet %s until %s", id, subnetID, w.End.Format(time.RFC3339))
+		return
+	}
+	p, ok := v.st.Validators[constants.PrimaryNetworkID][nodeID]
+	switch {
+	case !ok:
+		v.c.Fail("validator", "%s does not validate the primary network", id)
+	case start.Before(p.Start) || end.After(p.End):
+		v.c.Fail("validator", "%s validates the primary network from %s to %s, not the whole period",
+			id, p.Start.Format(time.RFC3339), p.End.Format(time.RFC3339))
+	default:
+		v.c.Pass("validator", "%s validates the primary network until %s", id, p.End.Format(time.RFC3339))
+	}
+}
+
+// checkSubnetAuth verifies the subnet auth against the subnet owners, and
+// returns the credentials of the inputs.
+//
+// ref. "platformvm.VM.verifySubnetAuthorization".
+func (v *verifier) checkSubnetAuth(subnetID ids.ID, auth verify.Verifiable) []verify.Verifiable {
+	creds := v.tx.Creds
+	owners, ok := v.st.Subnets[subnetID]
+	if !ok {
+		v.c.Fail("subnet auth", "subnet %s not found", subnetID)
+		return creds
+	}
+	in, ok := auth.(*secp256k1fx.Input)
+	if !ok {
+		v.c.Fail("subnet auth", "unknown subnet auth %T", auth)
+		return creds
+	}
+	var cred verify.Verifiable = &secp256k1fx.Credential{Sigs: make([][crypto.SECP256K1RSigLen]byte, len(in.SigIndices))}
+	if v.signed {
+		if len(creds) == 0 {
+			v.c.Fail("subnet auth", "no subnet auth credential")
+			return creds
+		}
+		cred, creds = creds[len(creds)-1], creds[:len(creds)-1]
+	}
+	if err := v.verifyCredentials(in, cred, owners); err != nil {
+		v.c.Fail("subnet auth", "%v (threshold %d of %d control keys)", err, owners.Threshold, len(owners.Addrs))
+		return creds
+	}
+	v.c.Pass("subnet auth", "%d of %d control keys of subnet %s", len(in.SigIndices), len(owners.Addrs), subnetID)
+	return creds
+}
+
+// checkSpend verifies the inputs are unspent and can be spent, and burn
+// the fee.
+//
+// ref. "platformvm.VM.semanticVerifySpendUTXOs".
+func (v *verifier) checkSpend(ins []*avax.TransferableInput, outs []*avax.TransferableOutput, creds []verify.Verifiable) {
+	if v.signed && len(creds) != len(ins) {
+		v.c.Fail("inputs", "%d inputs but %d credentials", len(ins), len(creds))
+		return
+	}
+	var consumed, produced uint64
+	for i, in := range ins {
+		utxo, ok := v.st.UTXOs[in.InputID()]
+		if !ok {
+			v.c.Fail("inputs", "UTXO %s not found (spent, or not owned by the looked up addresses)", &in.UTXOID)
+			return
+		}
+		if in.AssetID() != v.st.AVAXAssetID || utxo.AssetID() != v.st.AVAXAssetID {
+			v.c.Fail("inputs", "UTXO %s is not AVAX", &in.UTXOID)
+			return
+		}
+		out, input := utxo.Out, in.In
+		if lock, ok := out.(*platformvm.StakeableLockOut); ok {
+			out = lock.TransferableOut
+		}
+		if lock, ok := input.(*platformvm.StakeableLockIn); ok {
+			input = lock.TransferableIn
+		}
+		to, ok := out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			v.c.Fail("inputs", "UTXO %s of unknown output %T", &in.UTXOID, out)
+			return
+		}
+		ti, ok := input.(*secp256k1fx.TransferInput)
+		if !ok {
+			v.c.Fail("inputs", "input %s of unknown type %T", &in.UTXOID, input)
+			return
+		}
+		if ti.Amt != to.Amt {
+			v.c.Fail("inputs", "input %s spends %d of the UTXO of %d", &in.UTXOID, ti.Amt, to.Amt)
+			return
+		}
+		var cred verify.Verifiable = &secp256k1fx.Credential{Sigs: make([][crypto.SECP256K1RSigLen]byte, len(ti.SigIndices))}
+		if v.signed {
+			cred = creds[i]
+		}
+		if err := v.verifyCredentials(&ti.Input, cred, &to.OutputOwners); err != nil {
+			v.c.Fail("inputs", "UTXO %s: %v", &in.UTXOID, err)
+			return
+		}
+		consumed += ti.Amt
+	}
+	v.c.Pass("inputs", "%d unspent UTXO(s)", len(ins))
+
+	for _, out := range outs {
+		produced += out.Output().Amount()
+	}
+	if consumed < produced+v.st.Fee {
+		v.c.Fail("fee", "consumes %d, produces %d with the fee of %d", consumed, produced, v.st.Fee)
+		return
+	}
+	v.c.Pass("fee", "burns %d (fee %d)", consumed-produced, v.st.Fee)
+}
+
+func (v *verifier) verifyCredentials(in *secp256k1fx.Input, credIntf verify.Verifiable, owners *secp256k1fx.OutputOwners) error {
+	cred, ok := credIntf.(*secp256k1fx.Credential)
+	if !ok {
+		return fmt.Errorf("unknown credential %T", credIntf)
+	}
+	// the fx skips the index checks without the signatures
+	for _, index := range in.SigIndices {
+		if index >= uint32(len(owners.Addrs)) {
+			return fmt.Errorf("signature index %d out of %d addresses", index, len(owners.Addrs))
+		}
+	}
+	return v.fx.VerifyCredentials(v.tx.UnsignedTx, in, cred, owners)
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+	"github.com/ava-labs/subnet-cli/internal/doctor"
+	"github.com/ava-labs/subnet-cli/internal/staking"
+)
+
+func newKey(t *testing.T) *crypto.PrivateKeySECP256K1R {
+	k, err := new(crypto.FactorySECP256K1R).NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k.(*crypto.PrivateKeySECP256K1R)
+}
+
+func TestVerifyAddSubnetValidator(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	assetID := ids.GenerateTestID()
+	subnetID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestShortID()
+	k, other := newKey(t), newKey(t)
+	owners := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{k.PublicKey().Address()}}
+
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: assetID},
+		Out:    &secp256k1fx.TransferOutput{Amt: 5000, OutputOwners: *owners},
+	}
+	start, end := now.Add(time.Hour), now.Add(48*time.Hour)
+	newTx := func(signers ...*crypto.PrivateKeySECP256K1R) *platformvm.Tx {
+		tx := &platformvm.Tx{UnsignedTx: &platformvm.UnsignedAddSubnetValidatorTx{
+			BaseTx: platformvm.BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    constants.FujiID,
+				BlockchainID: constants.PlatformChainID,
+				Ins: []*avax.TransferableInput{{
+					UTXOID: utxo.UTXOID,
+					Asset:  utxo.Asset,
+					In:     &secp256k1fx.TransferInput{Amt: 5000, Input: secp256k1fx.Input{SigIndices: []uint32{0}}},
+				}},
+				Outs: []*avax.TransferableOutput{{
+					Asset: utxo.Asset,
+					Out:   &secp256k1fx.TransferOutput{Amt: 4000, OutputOwners: *owners},
+				}},
+			}},
+			Validator: platformvm.SubnetValidator{
+				Validator: platformvm.Validator{
+					NodeID: nodeID,
+					Start:  uint64(start.Unix()),
+					End:    uint64(end.Unix()),
+					Wght:   1000,
+				},
+				Subnet: subnetID,
+			},
+			SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
+		}}
+		signer := k
+		if len(signers) > 0 {
+			signer = signers[0]
+		}
+		if err := tx.Sign(codec.PCodecManager, [][]*crypto.PrivateKeySECP256K1R{{k}, {signer}}); err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+	newState := func() *State {
+		return &State{
+			NetworkID:   constants.FujiID,
+			AVAXAssetID: assetID,
+			Timestamp:   now,
+			Fee:         1000,
+			Limits:      staking.DefaultLimits(constants.FujiID),
+			Validators: map[ids.ID]map[ids.ShortID]Window{
+				constants.PrimaryNetworkID: {nodeID: {Start: now.Add(-time.Hour), End: now.Add(30 * 24 * time.Hour)}},
+			},
+			Subnets: map[ids.ID]*secp256k1fx.OutputOwners{subnetID: owners},
+			UTXOs:   map[ids.ID]*avax.UTXO{utxo.InputID(): utxo},
+		}
+	}
+
+	tt := []struct {
+		tx     *platformvm.Tx
+		signed bool
+		state  func(st *State)
+		failed string
+	}{
+		{tx: newTx(), signed: true},
+		{tx: newTx(), signed: false},
+		{
+			tx: newTx(), signed: true,
+			state: func(st *State) {
+				st.Validators[subnetID] = map[ids.ShortID]Window{nodeID: {Start: now, End: end}}
+			},
+			failed: "validator",
+		},
+		{
+			tx: newTx(), signed: true,
+			state:  func(st *State) { delete(st.Validators, constants.PrimaryNetworkID) },
+			failed: "validator",
+		},
+		{
+			tx: newTx(), signed: true,
+			state: func(st *State) {
+				st.Validators[constants.PrimaryNetworkID][nodeID] = Window{Start: now, End: now.Add(24 * time.Hour)}
+			},
+			failed: "validator",
+		},
+		{tx: newTx(other), signed: true, failed: "subnet auth"},
+		{
+			tx: newTx(), signed: true,
+			state:  func(st *State) { delete(st.Subnets, subnetID) },
+			failed: "subnet auth",
+		},
+		{
+			tx: newTx(), signed: false,
+			state: func(st *State) {
+				st.Subnets[subnetID] = &secp256k1fx.OutputOwners{Threshold: 2, Addrs: []ids.ShortID{k.PublicKey().Address(), other.PublicKey().Address()}}
+			},
+			failed: "subnet auth",
+		},
+		{
+			tx: newTx(), signed: true,
+			state:  func(st *State) { delete(st.UTXOs, utxo.InputID()) },
+			failed: "inputs",
+		},
+		{
+			tx: newTx(), signed: true,
+			state:  func(st *State) { st.Fee = 2000 },
+			failed: "fee",
+		},
+		{
+			tx: newTx(), signed: true,
+			state:  func(st *State) { st.Timestamp = start },
+			failed: "period",
+		},
+		{
+			tx: newTx(), signed: true,
+			state:  func(st *State) { st.NetworkID = constants.MainnetID },
+			failed: "syntax",
+		},
+	}
+	for i, tv := range tt {
+		st := newState()
+		if tv.state != nil {
+			tv.state(st)
+		}
+		c := Verify(tv.tx, tv.signed, st)
+		failed := ""
+		for _, r := range c.Results {
+			if r.Status == doctor.Fail {
+				failed = r.Check
+				break
+			}
+		}
+		if failed != tv.failed {
+			t.Fatalf("#%d: expected %q to fail, got %q (%+v)", i, tv.failed, failed, c.Results)
+		}
+	}
+}
+
+func TestVerifyAddValidator(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	nodeID := ids.GenerateTestShortID()
+	st := &State{
+		NetworkID: constants.FujiID,
+		Timestamp: now,
+		Limits:    staking.DefaultLimits(constants.FujiID),
+		Validators: map[ids.ID]map[ids.ShortID]Window{
+			constants.PrimaryNetworkID: {nodeID: {Start: now, End: now.Add(24 * time.Hour)}},
+		},
+	}
+	c := new(doctor.Checklist)
+	v := &verifier{c: c, st: st}
+	v.checkPrimaryValidator(nodeID)
+	v.checkPrimaryValidator(ids.GenerateTestShortID())
+	v.checkPeriod(now.Add(MaxFutureStartTime+time.Hour), now.Add(MaxFutureStartTime+48*time.Hour))
+	if len(c.Results) != 3 || c.Results[0].Status != doctor.Fail || c.Results[1].Status != doctor.Pass || c.Results[2].Status != doctor.Fail {
+		t.Fatalf("unexpected results %+v", c.Results)
+	}
+}
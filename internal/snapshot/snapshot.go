@@ -0,0 +1,203 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package snapshot implements the canonical snapshots of the state of a
+// subnet (its owners, blockchains, and validators), and their diff to
+// detect the drifts between runs (e.g., in a nightly monitoring job).
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// Version is the version of the snapshot format.
+const Version = 1
+
+var (
+	ErrInvalidSnapshot = errors.New("invalid snapshot")
+	ErrDifferentSubnet = errors.New("snapshots of different subnets")
+)
+
+// Owners are the control keys of the subnet.
+type Owners struct {
+	Threshold uint32   `json:"threshold"`
+	Locktime  uint64   `json:"locktime"`
+	Addrs     []string `json:"addresses"`
+}
+
+type Blockchain struct {
+	ID   ids.ID `json:"id"`
+	Name string `json:"name"`
+	VMID ids.ID `json:"vmId"`
+}
+
+type Validator struct {
+	NodeID string    `json:"nodeId"`
+	Weight uint64    `json:"weight"`
+	Start  time.Time `json:"startTime"`
+	End    time.Time `json:"endTime"`
+}
+
+// Snapshot is the state of a subnet at [TakenAt].
+type Snapshot struct {
+	Version   int       `json:"version"`
+	NetworkID uint32    `json:"networkId"`
+	SubnetID  ids.ID    `json:"subnetId"`
+	TakenAt   time.Time `json:"takenAt"`
+	Owners    Owners    `json:"owners"`
+	// StakingAssetID is the staking asset of an elastic subnet, empty if
+	// permissioned.
+	StakingAssetID string       `json:"stakingAssetId,omitempty"`
+	Blockchains    []Blockchain `json:"blockchains"`
+	Validators     []Validator  `json:"validators"`
+}
+
+// canonicalize sorts the lists, and sets the times in UTC to the second,
+// for the same state to be encoded in the same bytes.
+func (s *Snapshot) canonicalize() {
+	s.TakenAt = s.TakenAt.UTC().Truncate(time.Second)
+	sort.Strings(s.Owners.Addrs)
+	if s.Blockchains == nil {
+		s.Blockchains = []Blockchain{}
+	}
+	sort.Slice(s.Blockchains, func(i, j int) bool {
+		return s.Blockchains[i].ID.String() < s.Blockchains[j].ID.String()
+	})
+	if s.Validators == nil {
+		s.Validators = []Validator{}
+	}
+	for i := range s.Validators {
+		s.Validators[i].Start = s.Validators[i].Start.UTC().Truncate(time.Second)
+		s.Validators[i].End = s.Validators[i].End.UTC().Truncate(time.Second)
+	}
+	sort.Slice(s.Validators, func(i, j int) bool {
+		return s.Validators[i].NodeID < s.Validators[j].NodeID
+	})
+}
+
+// Marshal encodes the canonical JSON of the snapshot.
+func (s *Snapshot) Marshal() ([]byte, error) {
+	s.canonicalize()
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// FileName returns the file name of the snapshot
+// (e.g., "snapshot-<subnet ID>-20220501T000000Z.json").
+func (s *Snapshot) FileName() string {
+	return fmt.Sprintf("snapshot-%s-%s.json", s.SubnetID, s.TakenAt.UTC().Format("20060102T150405Z"))
+}
+
+// Load loads the snapshot file.
+func Load(p string) (*Snapshot, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	s := new(Snapshot)
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("%w %q: %v", ErrInvalidSnapshot, p, err)
+	}
+	if s.Version != Version {
+		return nil, fmt.Errorf("%w %q: unsupported version %d", ErrInvalidSnapshot, p, s.Version)
+	}
+	s.canonicalize()
+	return s, nil
+}
+
+type Kind string
+
+const (
+	Added   Kind = "added"
+	Removed Kind = "removed"
+	Changed Kind = "changed"
+)
+
+// Change is a difference between two snapshots.
+type Change struct {
+	Kind Kind
+	// Object is "owners", "staking asset", "blockchain", or "validator".
+	Object string
+	// ID is the ID of the blockchain or the validator.
+	ID string
+	// Field is the changed field, empty if added or removed.
+	Field  string
+	Before string
+	After  string
+}
+
+// Diff returns the changes from [a] to [b]. The validators of [a] removed
+// once their end time passed are ignored if [ignoreExpired], as expected.
+func Diff(a, b *Snapshot, ignoreExpired bool) ([]Change, error) {
+	if a.NetworkID != b.NetworkID || a.SubnetID != b.SubnetID {
+		return nil, fmt.Errorf("%w: %s (network %d) and %s (network %d)", ErrDifferentSubnet, a.SubnetID, a.NetworkID, b.SubnetID, b.NetworkID)
+	}
+	var changes []Change
+	changed := func(object, id, field string, before, after interface{}) {
+		bs, as := fmt.Sprint(before), fmt.Sprint(after)
+		if bs != as {
+			changes = append(changes, Change{Kind: Changed, Object: object, ID: id, Field: field, Before: bs, After: as})
+		}
+	}
+
+	changed("owners", "", "threshold", a.Owners.Threshold, b.Owners.Threshold)
+	changed("owners", "", "locktime", a.Owners.Locktime, b.Owners.Locktime)
+	changed("owners", "", "addresses", strings.Join(a.Owners.Addrs, ", "), strings.Join(b.Owners.Addrs, ", "))
+	changed("staking asset", "", "asset ID", a.StakingAssetID, b.StakingAssetID)
+
+	bcs := make(map[ids.ID]Blockchain, len(b.Blockchains))
+	for _, bc := range b.Blockchains {
+		bcs[bc.ID] = bc
+	}
+	for _, before := range a.Blockchains {
+		after, ok := bcs[before.ID]
+		if !ok {
+			changes = append(changes, Change{Kind: Removed, Object: "blockchain", ID: before.ID.String(), Before: before.Name})
+			continue
+		}
+		delete(bcs, before.ID)
+		changed("blockchain", before.ID.String(), "name", before.Name, after.Name)
+		changed("blockchain", before.ID.String(), "VM ID", before.VMID, after.VMID)
+	}
+	for _, bc := range b.Blockchains {
+		if _, ok := bcs[bc.ID]; ok {
+			changes = append(changes, Change{Kind: Added, Object: "blockchain", ID: bc.ID.String(), After: bc.Name})
+		}
+	}
+
+	vs := make(map[string]Validator, len(b.Validators))
+	for _, v := range b.Validators {
+		vs[v.NodeID] = v
+	}
+	for _, before := range a.Validators {
+		after, ok := vs[before.NodeID]
+		if !ok {
+			if ignoreExpired && !before.End.After(b.TakenAt) {
+				continue
+			}
+			changes = append(changes, Change{Kind: Removed, Object: "validator", ID: before.NodeID, Before: fmt.Sprintf("weight %d", before.Weight)})
+			continue
+		}
+		delete(vs, before.NodeID)
+		changed("validator", before.NodeID, "weight", before.Weight, after.Weight)
+		changed("validator", before.NodeID, "start", before.Start.Format(time.RFC3339), after.Start.Format(time.RFC3339))
+		changed("validator", before.NodeID, "end", before.End.Format(time.RFC3339), after.End.Format(time.RFC3339))
+	}
+	for _, v := range b.Validators {
+		if _, ok := vs[v.NodeID]; ok {
+			changes = append(changes, Change{Kind: Added, Object: "validator", ID: v.NodeID, After: fmt.Sprintf("weight %d", v.Weight)})
+		}
+	}
+	return changes, nil
+}
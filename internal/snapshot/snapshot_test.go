@@ -0,0 +1,137 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestMarshalLoad(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.May, 1, 0, 0, 0, 0, time.UTC)
+	mk := func(order []int) *Snapshot {
+		s := &Snapshot{
+			Version:   Version,
+			NetworkID: 5,
+			SubnetID:  ids.ID{1},
+			TakenAt:   now.In(time.FixedZone("x", 3600)),
+			Owners:    Owners{Threshold: 1, Addrs: []string{"P-fuji1b", "P-fuji1a"}},
+		}
+		vs := []Validator{
+			{NodeID: "NodeID-A", Weight: 1, Start: now, End: now.Add(time.Hour)},
+			{NodeID: "NodeID-B", Weight: 2, Start: now, End: now.Add(time.Hour)},
+		}
+		for _, i := range order {
+			s.Validators = append(s.Validators, vs[i])
+		}
+		return s
+	}
+	a, err := mk([]int{0, 1}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := mk([]int{1, 0}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(a, b) {
+		t.Fatalf("expected the same bytes, got\n%s\n%s", a, b)
+	}
+
+	subnetID := ids.ID{1}
+	p := filepath.Join(t.TempDir(), mk(nil).FileName())
+	if filepath.Base(p) != "snapshot-"+subnetID.String()+"-20220501T000000Z.json" {
+		t.Fatalf("unexpected file name %q", filepath.Base(p))
+	}
+	if err := ioutil.WriteFile(p, a, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := s.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(loaded, a) {
+		t.Fatalf("expected\n%s\ngot\n%s", a, loaded)
+	}
+	if err := ioutil.WriteFile(p, []byte(`{"version":2}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(p); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Fatalf("expected %v, got %v", ErrInvalidSnapshot, err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.May, 1, 0, 0, 0, 0, time.UTC)
+	a := &Snapshot{
+		NetworkID:   5,
+		SubnetID:    ids.ID{1},
+		TakenAt:     now,
+		Owners:      Owners{Threshold: 1, Addrs: []string{"P-fuji1a"}},
+		Blockchains: []Blockchain{{ID: ids.ID{2}, Name: "a", VMID: ids.ID{3}}},
+		Validators: []Validator{
+			{NodeID: "NodeID-A", Weight: 1, Start: now, End: now.Add(time.Hour)},
+			{NodeID: "NodeID-B", Weight: 2, Start: now, End: now.Add(48 * time.Hour)},
+			{NodeID: "NodeID-C", Weight: 3, Start: now, End: now.Add(48 * time.Hour)},
+		},
+	}
+	b := &Snapshot{
+		NetworkID:   5,
+		SubnetID:    ids.ID{1},
+		TakenAt:     now.Add(24 * time.Hour),
+		Owners:      Owners{Threshold: 2, Addrs: []string{"P-fuji1a"}},
+		Blockchains: []Blockchain{{ID: ids.ID{2}, Name: "a", VMID: ids.ID{3}}, {ID: ids.ID{4}, Name: "b"}},
+		Validators: []Validator{
+			{NodeID: "NodeID-B", Weight: 5, Start: now, End: now.Add(48 * time.Hour)},
+			{NodeID: "NodeID-D", Weight: 1, Start: now, End: now.Add(48 * time.Hour)},
+		},
+	}
+
+	changes, err := Diff(a, b, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Change{
+		{Kind: Changed, Object: "owners", Field: "threshold", Before: "1", After: "2"},
+		{Kind: Added, Object: "blockchain", ID: ids.ID{4}.String(), After: "b"},
+		{Kind: Changed, Object: "validator", ID: "NodeID-B", Field: "weight", Before: "2", After: "5"},
+		{Kind: Removed, Object: "validator", ID: "NodeID-C", Before: "weight 3"},
+		{Kind: Added, Object: "validator", ID: "NodeID-D", After: "weight 1"},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, changes)
+	}
+
+	// the expired validator A is a change too
+	changes, err = Diff(a, b, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != len(expected)+1 {
+		t.Fatalf("expected %d changes, got %+v", len(expected)+1, changes)
+	}
+	if changes, err := Diff(a, a, false); err != nil || len(changes) != 0 {
+		t.Fatalf("expected no change, got %+v (%v)", changes, err)
+	}
+
+	b.SubnetID = ids.ID{9}
+	if _, err := Diff(a, b, false); !errors.Is(err, ErrDifferentSubnet) {
+		t.Fatalf("expected %v, got %v", ErrDifferentSubnet, err)
+	}
+}
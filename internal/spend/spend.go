@@ -0,0 +1,170 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package spend implements the spend limits of the commands, to reduce
+// the blast radius of the scripting mistakes.
+package spend
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/subnet-cli/internal/amount"
+)
+
+// DefaultWindow is the default duration of a session.
+const DefaultWindow = 12 * time.Hour
+
+var (
+	ErrSoftLimit = errors.New("spend exceeds the soft limit")
+	ErrHardLimit = errors.New("spend exceeds the hard limit")
+)
+
+// Limit is a spend limit in nAVAX, zero for no limit. Exceeding the soft
+// limit requires an explicit opt-in, while the hard limit is never exceeded.
+type Limit struct {
+	Soft amount.Amount `yaml:"soft,omitempty"`
+	Hard amount.Amount `yaml:"hard,omitempty"`
+}
+
+// check checks [v] against the limit of [what].
+func (l Limit) check(what string, v uint64, allowLarge bool) error {
+	if l.Hard > 0 && v > uint64(l.Hard) {
+		return fmt.Errorf("%w: %s of %s > %s", ErrHardLimit, amount.Format(v), what, amount.Format(uint64(l.Hard)))
+	}
+	if l.Soft > 0 && v > uint64(l.Soft) && !allowLarge {
+		return fmt.Errorf("%w: %s of %s > %s", ErrSoftLimit, amount.Format(v), what, amount.Format(uint64(l.Soft)))
+	}
+	return nil
+}
+
+// Limits is the spend limits of the config file.
+//
+// e.g.,
+//
+//	spend-limits:
+//	  command:
+//	    soft: 10avax
+//	  commands:
+//	    add validator:
+//	      soft: 2000avax
+//	      hard: 5000avax
+//	  session:
+//	    hard: 10000avax
+//	  window: 24h
+type Limits struct {
+	// Command is the limit of each command, unless overridden by [Commands].
+	Command Limit `yaml:"command,omitempty"`
+	// Commands is the limit by command (e.g., "add validator").
+	Commands map[string]Limit `yaml:"commands,omitempty"`
+	// Session is the limit of all the spends on a network within [Window].
+	Session Limit `yaml:"session,omitempty"`
+	// Window is the duration of a session, "DefaultWindow" if zero.
+	Window time.Duration `yaml:"window,omitempty"`
+}
+
+// Check checks the spend of [v] by [command], [spent] being the amount
+// already spent in the session. The soft limits are only exceeded with
+// [allowLarge].
+func (l *Limits) Check(command string, v uint64, spent uint64, allowLarge bool) error {
+	limit, ok := l.Commands[command]
+	if !ok {
+		limit = l.Command
+	}
+	if err := limit.check(fmt.Sprintf("%q", command), v, allowLarge); err != nil {
+		return err
+	}
+	return l.Session.check("the session", spent+v, allowLarge)
+}
+
+// HasSession returns true if the spends of the session are limited.
+func (l *Limits) HasSession() bool {
+	return l.Session.Soft > 0 || l.Session.Hard > 0
+}
+
+// SessionStart returns the start of the session ending at [now].
+func (l *Limits) SessionStart(now time.Time) time.Time {
+	w := l.Window
+	if w <= 0 {
+		w = DefaultWindow
+	}
+	return now.Add(-w)
+}
+
+// Entry is a spend recorded in the ledger.
+type Entry struct {
+	Time      time.Time `json:"time"`
+	NetworkID uint32    `json:"networkID"`
+	Command   string    `json:"command"`
+	// Amount is the spent amount in nAVAX.
+	Amount uint64 `json:"amount"`
+}
+
+// Ledger records the spends in a file, one JSON entry per line.
+type Ledger struct {
+	path string
+}
+
+// DefaultLedgerPath returns the default path of the ledger file.
+func DefaultLedgerPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".subnet-cli", "spend.log"), nil
+}
+
+// OpenLedger opens the ledger of [path], created on the first record.
+func OpenLedger(path string) *Ledger {
+	return &Ledger{path: path}
+}
+
+// Spent returns the amount spent on the network since [since].
+func (l *Ledger) Spent(networkID uint32, since time.Time) (uint64, error) {
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	spent := uint64(0)
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var e Entry
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			return 0, fmt.Errorf("failed to parse %q: %w", l.path, err)
+		}
+		if e.NetworkID == networkID && !e.Time.Before(since) {
+			spent += e.Amount
+		}
+	}
+	return spent, s.Err()
+}
+
+// Record appends the spend to the ledger.
+func (l *Ledger) Record(e Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
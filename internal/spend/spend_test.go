@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package spend
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	"github.com/ava-labs/subnet-cli/internal/amount"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	l := &Limits{
+		Command: Limit{Soft: amount.Amount(10 * units.Avax)},
+		Commands: map[string]Limit{
+			"add validator": {Soft: amount.Amount(2000 * units.Avax), Hard: amount.Amount(5000 * units.Avax)},
+		},
+		Session: Limit{Hard: amount.Amount(6000 * units.Avax)},
+	}
+	tt := []struct {
+		command    string
+		amount     uint64
+		spent      uint64
+		allowLarge bool
+		err        error
+	}{
+		{command: "create subnet", amount: units.Avax},
+		{command: "create subnet", amount: 11 * units.Avax, err: ErrSoftLimit},
+		{command: "create subnet", amount: 11 * units.Avax, allowLarge: true},
+		{command: "add validator", amount: 2000 * units.Avax},
+		{command: "add validator", amount: 2500 * units.Avax, err: ErrSoftLimit},
+		{command: "add validator", amount: 2500 * units.Avax, allowLarge: true},
+		{command: "add validator", amount: 5001 * units.Avax, allowLarge: true, err: ErrHardLimit},
+		{command: "add validator", amount: 2000 * units.Avax, spent: 4001 * units.Avax, allowLarge: true, err: ErrHardLimit},
+	}
+	for i, tv := range tt {
+		err := l.Check(tv.command, tv.amount, tv.spent, tv.allowLarge)
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+	}
+}
+
+func TestLedger(t *testing.T) {
+	t.Parallel()
+
+	l := OpenLedger(filepath.Join(t.TempDir(), "spend.log"))
+	now := time.Now()
+	spent, err := l.Spent(1, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spent != 0 {
+		t.Fatalf("expected 0, got %d", spent)
+	}
+	for _, e := range []Entry{
+		{Time: now.Add(-2 * time.Hour), NetworkID: 1, Amount: 1},
+		{Time: now.Add(-time.Minute), NetworkID: 1, Amount: 2},
+		{Time: now, NetworkID: 1, Amount: 4},
+		{Time: now, NetworkID: 5, Amount: 8},
+	} {
+		if err := l.Record(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	spent, err = l.Spent(1, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spent != 6 {
+		t.Fatalf("expected 6, got %d", spent)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package staking implements the client-side checks of the staking
+// parameters, to fail early instead of getting the tx rejected by the node.
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+var (
+	ErrStartTooSoon     = errors.New("validate start time is too soon")
+	ErrEndBeforeStart   = errors.New("validate end time is not after start time")
+	ErrDurationTooShort = errors.New("staking duration is shorter than the network minimum")
+	ErrDurationTooLong  = errors.New("staking duration is longer than the network maximum")
+	ErrInvalidLimits    = errors.New("invalid staking duration limits")
+	ErrNegativeBuffer   = errors.New("negative validate start buffer")
+)
+
+// Limits is the network staking duration bounds.
+type Limits struct {
+	MinDuration time.Duration
+	MaxDuration time.Duration
+}
+
+// DefaultLimits returns the staking duration bounds of the network,
+// as defined in the avalanchego genesis parameters. The custom networks
+// default to the local network parameters.
+func DefaultLimits(networkID uint32) Limits {
+	switch networkID {
+	case constants.MainnetID:
+		return Limits{MinDuration: 2 * 7 * 24 * time.Hour, MaxDuration: 365 * 24 * time.Hour}
+	case constants.FujiID:
+		return Limits{MinDuration: 24 * time.Hour, MaxDuration: 365 * 24 * time.Hour}
+	default:
+		return Limits{MinDuration: 24 * time.Hour, MaxDuration: 365 * 24 * time.Hour}
+	}
+}
+
+// Override returns the limits with the non-zero bounds replaced.
+func (l Limits) Override(min, max time.Duration) (Limits, error) {
+	if min > 0 {
+		l.MinDuration = min
+	}
+	if max > 0 {
+		l.MaxDuration = max
+	}
+	if l.MinDuration > l.MaxDuration {
+		return Limits{}, fmt.Errorf("%w: min %v > max %v", ErrInvalidLimits, l.MinDuration, l.MaxDuration)
+	}
+	return l, nil
+}
+
+// Check verifies the validation period [start, end] is within the limits,
+// and that the start time is at least [buffer] after [now].
+func (l Limits) Check(start, end, now time.Time, buffer time.Duration) error {
+	if buffer < 0 {
+		return ErrNegativeBuffer
+	}
+	if earliest := now.Add(buffer); start.Before(earliest) {
+		return fmt.Errorf("%w: %s must be at or after %s (%v from now)",
+			ErrStartTooSoon,
+			start.Format(time.RFC3339),
+			earliest.Format(time.RFC3339),
+			buffer,
+		)
+	}
+	if !end.After(start) {
+		return fmt.Errorf("%w: start %s, end %s",
+			ErrEndBeforeStart,
+			start.Format(time.RFC3339),
+			end.Format(time.RFC3339),
+		)
+	}
+	d := end.Sub(start)
+	if d < l.MinDuration {
+		return fmt.Errorf("%w: %v < %v (end must be at or after %s)",
+			ErrDurationTooShort,
+			d.Round(time.Second),
+			l.MinDuration,
+			start.Add(l.MinDuration).Format(time.RFC3339),
+		)
+	}
+	if d > l.MaxDuration {
+		return fmt.Errorf("%w: %v > %v (end must be at or before %s)",
+			ErrDurationTooLong,
+			d.Round(time.Second),
+			l.MaxDuration,
+			start.Add(l.MaxDuration).Format(time.RFC3339),
+		)
+	}
+	return nil
+}
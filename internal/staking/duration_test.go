@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package staking
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	l := DefaultLimits(constants.MainnetID)
+	tt := []struct {
+		start  time.Time
+		end    time.Time
+		buffer time.Duration
+		err    error
+	}{
+		{now.Add(time.Minute), now.Add(30 * 24 * time.Hour), 30 * time.Second, nil},
+		{now.Add(10 * time.Second), now.Add(30 * 24 * time.Hour), 30 * time.Second, ErrStartTooSoon},
+		{now.Add(-time.Hour), now.Add(30 * 24 * time.Hour), 0, ErrStartTooSoon},
+		{now.Add(time.Minute), now.Add(time.Minute), 0, ErrEndBeforeStart},
+		{now.Add(time.Minute), now.Add(24 * time.Hour), 0, ErrDurationTooShort},
+		{now.Add(time.Minute), now.Add(400 * 24 * time.Hour), 0, ErrDurationTooLong},
+		{now.Add(time.Minute), now.Add(30 * 24 * time.Hour), -time.Second, ErrNegativeBuffer},
+	}
+	for i, tv := range tt {
+		err := l.Check(tv.start, tv.end, now, tv.buffer)
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+	}
+}
+
+func TestOverride(t *testing.T) {
+	t.Parallel()
+
+	l := DefaultLimits(constants.FujiID)
+	o, err := l.Override(time.Hour, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.MinDuration != time.Hour || o.MaxDuration != l.MaxDuration {
+		t.Fatalf("unexpected limits %+v", o)
+	}
+	if _, err := l.Override(0, time.Hour); !errors.Is(err, ErrInvalidLimits) {
+		t.Fatalf("expected %v, got %v", ErrInvalidLimits, err)
+	}
+}
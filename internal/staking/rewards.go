@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+)
+
+// PercentDenominator is the denominator of the delegation fee rates
+// (ref. "reward.PercentDenominator").
+const PercentDenominator = reward.PercentDenominator
+
+var (
+	ErrZeroStake         = errors.New("zero stake amount")
+	ErrZeroSupply        = errors.New("zero current supply")
+	ErrInvalidFeePercent = errors.New("invalid delegation fee")
+)
+
+// year is the period of the annual percentage rates.
+const year = 365 * 24 * time.Hour
+
+// Estimate is the projected rewards of a validation, all amounts in nAVAX.
+type Estimate struct {
+	// Reward is the reward of the validator stake.
+	Reward uint64
+	// DelegationFee is the fee taken by the validator from the rewards
+	// of the delegations.
+	DelegationFee uint64
+	// DelegatorReward is the reward of the delegations, net of the fee.
+	DelegatorReward uint64
+	// APR is the annual percentage rate of the validator stake,
+	// including the delegation fee.
+	APR float64
+	// DelegatorAPR is the annual percentage rate of the delegations.
+	DelegatorAPR float64
+}
+
+// Rewards estimates the rewards of staking [stake] and being delegated
+// [delegated] for [duration], with the delegation fee [feePercent] (in
+// percent), as the network would compute them with the [supply] at the
+// start of the validation. The reward parameters are the ones of the
+// avalanchego genesis, the custom networks using the local ones.
+func Rewards(networkID uint32, stake uint64, delegated uint64, duration time.Duration, feePercent float64, supply uint64) (*Estimate, error) {
+	if stake == 0 {
+		return nil, ErrZeroStake
+	}
+	if supply == 0 {
+		return nil, ErrZeroSupply
+	}
+	if feePercent < 0 || feePercent > 100 {
+		return nil, fmt.Errorf("%w: %.4f%% must be within [0, 100]", ErrInvalidFeePercent, feePercent)
+	}
+	calc := reward.NewCalculator(genesis.GetStakingConfig(networkID).RewardConfig)
+
+	e := &Estimate{Reward: calc.Calculate(duration, stake, supply)}
+	if delegated > 0 {
+		// ref. "platformvm.UnsignedRewardValidatorTx.Execute", each delegation being
+		// rewarded with the supply at its start
+		gross := calc.Calculate(duration, delegated, supply)
+		delegatorShares := PercentDenominator - uint64(feePercent*PercentDenominator/100)
+		e.DelegatorReward = delegatorShares * (gross / PercentDenominator)
+		if optimistic, err := math.Mul64(delegatorShares, gross); err == nil {
+			e.DelegatorReward = optimistic / PercentDenominator
+		}
+		e.DelegationFee = gross - e.DelegatorReward
+		e.DelegatorAPR = apr(e.DelegatorReward, delegated, duration)
+	}
+	e.APR = apr(e.Reward+e.DelegationFee, stake, duration)
+	return e, nil
+}
+
+func apr(reward uint64, amount uint64, duration time.Duration) float64 {
+	if duration <= 0 {
+		return 0
+	}
+	return float64(reward) / float64(amount) * float64(year) / float64(duration) * 100
+}
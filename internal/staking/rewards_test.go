@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package staking
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+func TestRewards(t *testing.T) {
+	t.Parallel()
+
+	supply := 400 * units.MegaAvax
+	stake := 2000 * units.Avax
+	e, err := Rewards(constants.MainnetID, stake, 0, year, 2, supply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// mainnet mints between 10% and 12% of the remaining supply per year
+	if e.APR < 5 || e.APR > 12 {
+		t.Fatalf("unexpected APR %.4f%%", e.APR)
+	}
+	if e.DelegationFee != 0 || e.DelegatorReward != 0 {
+		t.Fatalf("unexpected delegation rewards %+v", e)
+	}
+
+	// a shorter stake earns a lower rate
+	short, err := Rewards(constants.MainnetID, stake, 0, 14*24*time.Hour, 2, supply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if short.APR >= e.APR {
+		t.Fatalf("expected APR %.4f%% < %.4f%%", short.APR, e.APR)
+	}
+
+	d, err := Rewards(constants.MainnetID, stake, stake, year, 2, supply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Reward != e.Reward {
+		t.Fatalf("unexpected validator reward %d, expected %d", d.Reward, e.Reward)
+	}
+	if d.DelegationFee+d.DelegatorReward != e.Reward {
+		t.Fatalf("unexpected delegation rewards %+v", d)
+	}
+	if fee := float64(d.DelegationFee) / float64(e.Reward) * 100; fee < 1.99 || fee > 2.01 {
+		t.Fatalf("unexpected delegation fee %.4f%%", fee)
+	}
+
+	if _, err := Rewards(constants.MainnetID, 0, 0, year, 2, supply); !errors.Is(err, ErrZeroStake) {
+		t.Fatalf("expected %v, got %v", ErrZeroStake, err)
+	}
+	if _, err := Rewards(constants.MainnetID, stake, 0, year, 101, supply); !errors.Is(err, ErrInvalidFeePercent) {
+		t.Fatalf("expected %v, got %v", ErrInvalidFeePercent, err)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package state implements the local state of subnet-cli, the records of
+// the network objects that the network itself does not track (e.g., the
+// decommissioned subnets, the scheduled validator removals).
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// DefaultPath returns the default state file "~/.subnet-cli/state.json".
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".subnet-cli", "state.json"), nil
+}
+
+// RetiredSubnet is a subnet decommissioned by subnet-cli. The P-Chain has
+// no tx to delete a subnet: it remains, without validators.
+type RetiredSubnet struct {
+	NetworkID uint32    `json:"networkId"`
+	SubnetID  ids.ID    `json:"subnetId"`
+	RetiredAt time.Time `json:"retiredAt"`
+	// Removed are the validators removed by the decommission.
+	Removed []ids.ShortID `json:"removed,omitempty"`
+}
+
+// Drain is a subnet validator removal scheduled by "drain validator", and
+// issued by "drain run" once the P-Chain timestamp reaches [At].
+type Drain struct {
+	NetworkID uint32      `json:"networkId"`
+	SubnetID  ids.ID      `json:"subnetId"`
+	NodeID    ids.ShortID `json:"nodeId"`
+	At        time.Time   `json:"at"`
+	// MinValidators is the number of current validators to keep on the
+	// subnet: the removal is postponed while it would drop below.
+	MinValidators int `json:"minValidators"`
+}
+
+// State is the local state of subnet-cli.
+type State struct {
+	RetiredSubnets []RetiredSubnet `json:"retiredSubnets,omitempty"`
+	Drains         []Drain         `json:"drains,omitempty"`
+}
+
+// Load loads the state file, or returns the empty state if missing.
+func Load(p string) (*State, error) {
+	b, err := ioutil.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return &State{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s := new(State)
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Save writes the state file, creating its directory if missing.
+func (s *State) Save(p string) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p, b, 0o600)
+}
+
+// Retire records the decommissioned subnet, replacing the previous record
+// of the subnet, if any (e.g., a decommission run again).
+func (s *State) Retire(r RetiredSubnet) {
+	for i, prev := range s.RetiredSubnets {
+		if prev.NetworkID == r.NetworkID && prev.SubnetID == r.SubnetID {
+			s.RetiredSubnets[i] = r
+			return
+		}
+	}
+	s.RetiredSubnets = append(s.RetiredSubnets, r)
+}
+
+// Retired returns the record of the subnet, if decommissioned.
+func (s *State) Retired(networkID uint32, subnetID ids.ID) (RetiredSubnet, bool) {
+	for _, r := range s.RetiredSubnets {
+		if r.NetworkID == networkID && r.SubnetID == subnetID {
+			return r, true
+		}
+	}
+	return RetiredSubnet{}, false
+}
+
+// Schedule records the drain, replacing the previous one of the validator,
+// if any (e.g., rescheduled).
+func (s *State) Schedule(d Drain) {
+	for i, prev := range s.Drains {
+		if prev.NetworkID == d.NetworkID && prev.SubnetID == d.SubnetID && prev.NodeID == d.NodeID {
+			s.Drains[i] = d
+			return
+		}
+	}
+	s.Drains = append(s.Drains, d)
+}
+
+// Unschedule removes the drain of the validator, and returns false if
+// none was scheduled.
+func (s *State) Unschedule(networkID uint32, subnetID ids.ID, nodeID ids.ShortID) bool {
+	for i, d := range s.Drains {
+		if d.NetworkID == networkID && d.SubnetID == subnetID && d.NodeID == nodeID {
+			s.Drains = append(s.Drains[:i], s.Drains[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Scheduled returns the drains of the network, in the order of their time.
+func (s *State) Scheduled(networkID uint32) []Drain {
+	var ds []Drain
+	for _, d := range s.Drains {
+		if d.NetworkID == networkID {
+			ds = append(ds, d)
+		}
+	}
+	sort.SliceStable(ds, func(i, j int) bool { return ds[i].At.Before(ds[j].At) })
+	return ds
+}
+
+// Due returns the drains of the network due at the P-Chain time [now],
+// in the order of their time.
+func (s *State) Due(networkID uint32, now time.Time) []Drain {
+	var ds []Drain
+	for _, d := range s.Scheduled(networkID) {
+		if !d.At.After(now) {
+			ds = append(ds, d)
+		}
+	}
+	return ds
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestState(t *testing.T) {
+	t.Parallel()
+
+	p := filepath.Join(t.TempDir(), "sub", "state.json")
+	s, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.RetiredSubnets) != 0 {
+		t.Fatalf("expected empty state, got %+v", s)
+	}
+
+	now := time.Date(2022, time.May, 1, 0, 0, 0, 0, time.UTC)
+	s.Retire(RetiredSubnet{NetworkID: 5, SubnetID: ids.ID{1}, RetiredAt: now})
+	s.Retire(RetiredSubnet{NetworkID: 1, SubnetID: ids.ID{1}, RetiredAt: now})
+	// run again
+	s.Retire(RetiredSubnet{NetworkID: 5, SubnetID: ids.ID{1}, RetiredAt: now.Add(time.Hour), Removed: []ids.ShortID{{2}}})
+	if err := s.Save(p); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.RetiredSubnets) != 2 {
+		t.Fatalf("expected 2 retired subnets, got %d", len(s.RetiredSubnets))
+	}
+	r, ok := s.Retired(5, ids.ID{1})
+	if !ok || !r.RetiredAt.Equal(now.Add(time.Hour)) || len(r.Removed) != 1 {
+		t.Fatalf("unexpected record %+v", r)
+	}
+	if _, ok := s.Retired(5, ids.ID{2}); ok {
+		t.Fatal("unexpected retired subnet")
+	}
+}
+
+func TestDrains(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.May, 1, 0, 0, 0, 0, time.UTC)
+	s := &State{}
+	s.Schedule(Drain{NetworkID: 5, SubnetID: ids.ID{1}, NodeID: ids.ShortID{1}, At: now.Add(2 * time.Hour)})
+	s.Schedule(Drain{NetworkID: 5, SubnetID: ids.ID{1}, NodeID: ids.ShortID{2}, At: now.Add(time.Hour)})
+	s.Schedule(Drain{NetworkID: 1, SubnetID: ids.ID{1}, NodeID: ids.ShortID{1}, At: now})
+	// rescheduled
+	s.Schedule(Drain{NetworkID: 5, SubnetID: ids.ID{1}, NodeID: ids.ShortID{1}, At: now.Add(3 * time.Hour)})
+
+	ds := s.Scheduled(5)
+	if len(ds) != 2 || ds[0].NodeID != (ids.ShortID{2}) || !ds[1].At.Equal(now.Add(3*time.Hour)) {
+		t.Fatalf("unexpected drains %+v", ds)
+	}
+
+	tt := []struct {
+		now time.Time
+		due int
+	}{
+		{now: now, due: 0},
+		{now: now.Add(time.Hour), due: 1},
+		{now: now.Add(3 * time.Hour), due: 2},
+	}
+	for i, tv := range tt {
+		if due := s.Due(5, tv.now); len(due) != tv.due {
+			t.Fatalf("#%d: expected %d due drain(s), got %d", i, tv.due, len(due))
+		}
+	}
+
+	if !s.Unschedule(5, ids.ID{1}, ids.ShortID{2}) {
+		t.Fatal("expected the drain to be unscheduled")
+	}
+	if s.Unschedule(5, ids.ID{1}, ids.ShortID{2}) {
+		t.Fatal("unexpected unscheduled drain")
+	}
+	if len(s.Drains) != 2 {
+		t.Fatalf("expected 2 drains, got %d", len(s.Drains))
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package telemetry implements the opt-in anonymous usage stats of the
+// commands. An event only holds the command path, the build, the duration,
+// and the category of the error: never the flags, the arguments, the
+// addresses, or the keys.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+var (
+	ErrInvalidEndpoint = errors.New("invalid telemetry endpoint")
+	ErrRequestFailed   = errors.New("telemetry request failed")
+)
+
+// DefaultPath returns the default settings file "~/.subnet-cli/telemetry.json".
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".subnet-cli", "telemetry.json"), nil
+}
+
+// Settings is the telemetry opt-in, disabled unless set by the user.
+type Settings struct {
+	Enabled  bool   `json:"enabled"`
+	Endpoint string `json:"endpoint,omitempty"`
+	// InstallID is a random ID, not derived from the host or the user,
+	// to count the distinct installs. Renewed on each opt-in.
+	InstallID string `json:"installId,omitempty"`
+}
+
+// Load loads the settings file, or returns the disabled settings if missing.
+func Load(p string) (*Settings, error) {
+	b, err := ioutil.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Settings{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s := new(Settings)
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", p, err)
+	}
+	return s, nil
+}
+
+// Save writes the settings file, creating its directory if missing.
+func (s *Settings) Save(p string) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p, b, 0o600)
+}
+
+// Enable opts in to send the events to [endpoint], with a new install ID.
+func (s *Settings) Enable(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w %q (expected an http(s) URL)", ErrInvalidEndpoint, endpoint)
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+	s.Enabled, s.Endpoint, s.InstallID = true, endpoint, hex.EncodeToString(id)
+	return nil
+}
+
+// Disable opts out, and forgets the install ID.
+func (s *Settings) Disable() {
+	s.Enabled, s.InstallID = false, ""
+}
+
+// Event is the usage of a command.
+type Event struct {
+	InstallID string `json:"installId"`
+	// Command is the command path without the binary name
+	// (e.g., "add validator").
+	Command string `json:"command"`
+	Version string `json:"version"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+	// DurationMs is the run time of the command in milliseconds.
+	DurationMs int64 `json:"durationMs"`
+	// Error is the category of the error (e.g., "insufficient_funds"),
+	// empty if the command succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// NewEvent returns the event of the command, with the install ID
+// of the settings and the platform of the build.
+func (s *Settings) NewEvent(command string, version string, took time.Duration, errCategory string) Event {
+	return Event{
+		InstallID:  s.InstallID,
+		Command:    command,
+		Version:    version,
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		DurationMs: took.Milliseconds(),
+		Error:      errCategory,
+	}
+}
+
+// Send posts the event to the endpoint as JSON.
+func Send(ctx context.Context, endpoint string, e Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%w (status %d)", ErrRequestFailed, resp.StatusCode)
+	}
+	return nil
+}
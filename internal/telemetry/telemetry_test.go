@@ -0,0 +1,99 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSettings(t *testing.T) {
+	t.Parallel()
+
+	p := filepath.Join(t.TempDir(), "telemetry.json")
+	s, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Enabled {
+		t.Fatal("expected telemetry disabled by default")
+	}
+	for i, endpoint := range []string{"", "ftp://stats.example", "stats.example/v1"} {
+		if err := s.Enable(endpoint); !errors.Is(err, ErrInvalidEndpoint) {
+			t.Fatalf("#%d: expected %v, got %v", i, ErrInvalidEndpoint, err)
+		}
+	}
+	if err := s.Enable("https://stats.example/v1/events"); err != nil {
+		t.Fatal(err)
+	}
+	id := s.InstallID
+	if len(id) != 32 {
+		t.Fatalf("unexpected install ID %q", id)
+	}
+	if err := s.Save(p); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *loaded != *s {
+		t.Fatalf("expected %+v, got %+v", s, loaded)
+	}
+
+	s.Disable()
+	if s.Enabled || s.InstallID != "" {
+		t.Fatalf("unexpected disabled settings %+v", s)
+	}
+	if err := s.Enable(s.Endpoint); err != nil {
+		t.Fatal(err)
+	}
+	if s.InstallID == id {
+		t.Fatal("expected a new install ID")
+	}
+}
+
+func TestSend(t *testing.T) {
+	t.Parallel()
+
+	events := make(chan Event, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var e Event
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		events <- e
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := &Settings{Enabled: true, Endpoint: srv.URL, InstallID: "abc"}
+	e := s.NewEvent("add validator", "0.0.3", 1500*time.Millisecond, "insufficient_funds")
+	if err := Send(context.Background(), srv.URL, e); err != nil {
+		t.Fatal(err)
+	}
+	got := <-events
+	if got != e {
+		t.Fatalf("expected %+v, got %+v", e, got)
+	}
+	if got.DurationMs != 1500 {
+		t.Fatalf("unexpected duration %d", got.DurationMs)
+	}
+
+	err := Send(context.Background(), srv.URL+"/missing", Event{})
+	if !errors.Is(err, ErrRequestFailed) {
+		t.Fatalf("expected %v, got %v", ErrRequestFailed, err)
+	}
+}
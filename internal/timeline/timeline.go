@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package timeline implements the coverage analysis and ASCII rendering
+// of the validation periods.
+package timeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Span is a labeled period (e.g., a validation period of a node).
+type Span struct {
+	Label string
+	Start time.Time
+	End   time.Time
+}
+
+// Segment is a period with a constant number of active spans.
+type Segment struct {
+	Start time.Time
+	End   time.Time
+	Count int
+}
+
+// Coverage splits [from, to) into the segments with the number of spans
+// active during each segment. Adjacent segments always differ in count.
+func Coverage(spans []Span, from time.Time, to time.Time) []Segment {
+	if !from.Before(to) {
+		return nil
+	}
+	bounds := []time.Time{from, to}
+	for _, s := range spans {
+		for _, t := range []time.Time{s.Start, s.End} {
+			if t.After(from) && t.Before(to) {
+				bounds = append(bounds, t)
+			}
+		}
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i].Before(bounds[j]) })
+
+	segs := make([]Segment, 0, len(bounds))
+	for i := 0; i < len(bounds)-1; i++ {
+		start, end := bounds[i], bounds[i+1]
+		if !start.Before(end) {
+			continue
+		}
+		cnt := active(spans, start)
+		if n := len(segs); n > 0 && segs[n-1].Count == cnt {
+			segs[n-1].End = end
+			continue
+		}
+		segs = append(segs, Segment{Start: start, End: end, Count: cnt})
+	}
+	return segs
+}
+
+// active returns the number of spans active at [t].
+func active(spans []Span, t time.Time) (cnt int) {
+	for _, s := range spans {
+		if !t.Before(s.Start) && t.Before(s.End) {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// Gaps returns the segments in [from, to) not covered by any span.
+func Gaps(spans []Span, from time.Time, to time.Time) []Segment {
+	gaps := make([]Segment, 0)
+	for _, seg := range Coverage(spans, from, to) {
+		if seg.Count == 0 {
+			gaps = append(gaps, seg)
+		}
+	}
+	return gaps
+}
+
+// Below returns the earliest time in [from, to) at which fewer than [min]
+// spans are active. It returns false if the threshold always holds.
+func Below(spans []Span, from time.Time, to time.Time, min int) (time.Time, bool) {
+	for _, seg := range Coverage(spans, from, to) {
+		if seg.Count < min {
+			return seg.Start, true
+		}
+	}
+	return time.Time{}, false
+}
+
+const (
+	barChar   = '='
+	gapChar   = 'X'
+	belowChar = '!'
+)
+
+// Render draws each span as a bar of [width] columns over [from, to), and
+// a last "coverage" row marking the gaps ('X') and the periods below the
+// [min] threshold ('!').
+func Render(spans []Span, from time.Time, to time.Time, width int, min int) string {
+	if width <= 0 || !from.Before(to) {
+		return ""
+	}
+	labelWidth := len("coverage")
+	for _, s := range spans {
+		if len(s.Label) > labelWidth {
+			labelWidth = len(s.Label)
+		}
+	}
+
+	step := to.Sub(from) / time.Duration(width)
+	// sample at the middle of each column
+	at := func(col int) time.Time {
+		return from.Add(step*time.Duration(col) + step/2)
+	}
+
+	sb := new(strings.Builder)
+	fmt.Fprintf(sb, "%-*s  %s ... %s\n", labelWidth, "", from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))
+	for _, s := range spans {
+		row := make([]byte, width)
+		for col := range row {
+			t := at(col)
+			row[col] = ' '
+			if !t.Before(s.Start) && t.Before(s.End) {
+				row[col] = barChar
+			}
+		}
+		fmt.Fprintf(sb, "%-*s |%s|\n", labelWidth, s.Label, row)
+	}
+
+	row := make([]byte, width)
+	for col := range row {
+		cnt := active(spans, at(col))
+		switch {
+		case cnt == 0:
+			row[col] = gapChar
+		case cnt < min:
+			row[col] = belowChar
+		default:
+			row[col] = ' '
+		}
+	}
+	fmt.Fprintf(sb, "%-*s |%s|\n", labelWidth, "coverage", row)
+	return sb.String()
+}
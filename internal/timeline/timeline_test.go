@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package timeline
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoverage(t *testing.T) {
+	t.Parallel()
+
+	from := time.Unix(0, 0)
+	hr := func(h int) time.Time { return from.Add(time.Duration(h) * time.Hour) }
+	spans := []Span{
+		{Label: "a", Start: hr(0), End: hr(4)},
+		{Label: "b", Start: hr(2), End: hr(6)},
+		{Label: "c", Start: hr(8), End: hr(10)},
+	}
+
+	segs := Coverage(spans, from, hr(10))
+	expected := []Segment{
+		{Start: hr(0), End: hr(2), Count: 1},
+		{Start: hr(2), End: hr(4), Count: 2},
+		{Start: hr(4), End: hr(6), Count: 1},
+		{Start: hr(6), End: hr(8), Count: 0},
+		{Start: hr(8), End: hr(10), Count: 1},
+	}
+	if len(segs) != len(expected) {
+		t.Fatalf("unexpected segments %+v, expected %+v", segs, expected)
+	}
+	for i := range segs {
+		if !segs[i].Start.Equal(expected[i].Start) ||
+			!segs[i].End.Equal(expected[i].End) ||
+			segs[i].Count != expected[i].Count {
+			t.Fatalf("#%d: unexpected segment %+v, expected %+v", i, segs[i], expected[i])
+		}
+	}
+
+	gaps := Gaps(spans, from, hr(10))
+	if len(gaps) != 1 || !gaps[0].Start.Equal(hr(6)) || !gaps[0].End.Equal(hr(8)) {
+		t.Fatalf("unexpected gaps %+v", gaps)
+	}
+
+	below, ok := Below(spans, hr(2), hr(10), 2)
+	if !ok || !below.Equal(hr(4)) {
+		t.Fatalf("unexpected below threshold %v (%v), expected %v", below, ok, hr(4))
+	}
+	if _, ok := Below(spans, from, hr(6), 1); ok {
+		t.Fatal("unexpected below threshold")
+	}
+}
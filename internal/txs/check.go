@@ -0,0 +1,40 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+var (
+	ErrWrongNetworkID    = errors.New("transaction is for another network")
+	ErrWrongBlockchainID = errors.New("transaction is for another blockchain")
+)
+
+// CheckChain returns an error if the transaction does not embed the
+// expected network and blockchain IDs, so that it cannot be replayed
+// on another network.
+func CheckChain(utx platformvm.UnsignedTx, networkID uint32, blockchainID ids.ID) error {
+	s, err := Summarize(utx)
+	if err != nil {
+		return err
+	}
+	return s.CheckChain(networkID, blockchainID)
+}
+
+// CheckChain returns an error if the summarized transaction does not
+// embed the expected network and blockchain IDs.
+func (s *Summary) CheckChain(networkID uint32, blockchainID ids.ID) error {
+	if s.NetworkID != networkID {
+		return fmt.Errorf("%w: %s embeds network ID %d, expected %d", ErrWrongNetworkID, s.Type, s.NetworkID, networkID)
+	}
+	if s.BlockchainID != blockchainID {
+		return fmt.Errorf("%w: %s embeds blockchain ID %s, expected %s", ErrWrongBlockchainID, s.Type, s.BlockchainID, blockchainID)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestCheckChain(t *testing.T) {
+	t.Parallel()
+
+	utx := &platformvm.UnsignedCreateSubnetTx{
+		BaseTx: platformvm.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.FujiID,
+			BlockchainID: constants.PlatformChainID,
+		}},
+		Owner: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{ids.GenerateTestShortID()}},
+	}
+	if err := CheckChain(utx, constants.FujiID, constants.PlatformChainID); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckChain(utx, constants.MainnetID, constants.PlatformChainID); !errors.Is(err, ErrWrongNetworkID) {
+		t.Fatalf("expected %v, got %v", ErrWrongNetworkID, err)
+	}
+	if err := CheckChain(utx, constants.FujiID, ids.GenerateTestID()); !errors.Is(err, ErrWrongBlockchainID) {
+		t.Fatalf("expected %v, got %v", ErrWrongBlockchainID, err)
+	}
+}
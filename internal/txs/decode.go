@@ -0,0 +1,347 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package txs decodes and summarizes the P-Chain transactions.
+package txs
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+)
+
+var (
+	ErrUndecodable   = errors.New("not a P-Chain transaction")
+	ErrUnknownTxType = errors.New("unknown transaction type")
+)
+
+// Input is a consumed UTXO.
+type Input struct {
+	// ID is the input ID of the UTXO (ref. "avax.UTXOID.InputID").
+	ID         ids.ID
+	UTXOID     string
+	AssetID    ids.ID
+	Amount     uint64
+	SigIndices []uint32
+	// non-zero for the stakeable locked inputs
+	Locktime uint64
+}
+
+// Output is a produced UTXO.
+type Output struct {
+	AssetID ids.ID
+	Amount  uint64
+	// Kind is "change", "stake", or "export".
+	Kind      string
+	Owners    Owners
+	Locktime  uint64
+	Stakeable bool
+}
+
+// Owners is a human-readable "secp256k1fx.OutputOwners".
+type Owners struct {
+	Locktime  uint64
+	Threshold uint32
+	Addrs     []string
+}
+
+// Validator is the validator added by the transaction.
+type Validator struct {
+	NodeID ids.ShortID
+	Start  time.Time
+	End    time.Time
+	Weight uint64
+}
+
+// Field is a transaction-specific field.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Summary is the human-readable content of the transaction.
+type Summary struct {
+	Type   string
+	Signed bool
+	// only set for the signed transactions
+	TxID         ids.ID
+	Credentials  int
+	NetworkID    uint32
+	BlockchainID ids.ID
+	Memo         []byte
+	Inputs       []Input
+	Outputs      []Output
+	Fields       []Field
+	// Validator is the added validator, nil for the other transactions.
+	Validator *Validator
+	// SubnetID is the subnet of the subnet operations, and SubnetAuth the
+	// indices of the subnet owners authorizing them.
+	SubnetID   ids.ID
+	SubnetAuth []uint32
+	// Fee is the burned amount of each asset (inputs minus outputs).
+	Fee map[ids.ID]uint64
+	// Digest is the canonical digest of the unsigned tx (ref. "Digest").
+	Digest string
+}
+
+// Decode decodes the signed or unsigned transaction bytes.
+func Decode(b []byte) (*Summary, error) {
+	pTx, signed, err := Unmarshal(b)
+	if err != nil {
+		return nil, err
+	}
+	s, err := Summarize(pTx.UnsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	if signed {
+		s.Signed = true
+		s.TxID = pTx.ID()
+		s.Credentials = len(pTx.Creds)
+	}
+	return s, nil
+}
+
+// Unmarshal decodes the signed or unsigned transaction bytes, the unsigned
+// one returned without credentials.
+func Unmarshal(b []byte) (tx *platformvm.Tx, signed bool, err error) {
+	pTx := new(platformvm.Tx)
+	if _, err := codec.PCodecManager.Unmarshal(b, pTx); err == nil {
+		signedBytes := b
+		unsignedBytes, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, &pTx.UnsignedTx)
+		if err != nil {
+			return nil, false, err
+		}
+		pTx.Initialize(unsignedBytes, signedBytes)
+		return pTx, true, nil
+	}
+
+	var utx platformvm.UnsignedTx
+	if _, err := codec.PCodecManager.Unmarshal(b, &utx); err != nil {
+		return nil, false, fmt.Errorf("%w: %v", ErrUndecodable, err)
+	}
+	utx.Initialize(b, b)
+	return &platformvm.Tx{UnsignedTx: utx}, false, nil
+}
+
+// ParseBytes parses the hex (with or without checksum, "0x"-prefixed or not)
+// or CB58 encoded transaction, falling back to the raw bytes.
+func ParseBytes(b []byte) []byte {
+	s := strings.TrimSpace(string(b))
+	if d, err := formatting.Decode(formatting.Hex, s); err == nil {
+		return d
+	}
+	if d, err := hex.DecodeString(strings.TrimPrefix(s, "0x")); err == nil {
+		return d
+	}
+	if d, err := formatting.Decode(formatting.CB58, s); err == nil {
+		return d
+	}
+	return b
+}
+
+// Summarize returns the human-readable content of the unsigned transaction.
+func Summarize(utx platformvm.UnsignedTx) (*Summary, error) {
+	var (
+		s      = &Summary{Fee: make(map[ids.ID]uint64)}
+		base   *avax.BaseTx
+		extraI []*avax.TransferableInput
+		extraO []*avax.TransferableOutput
+		kind   string
+	)
+	switch tx := utx.(type) {
+	case *platformvm.UnsignedAddValidatorTx:
+		s.Type, base, extraO, kind = "AddValidatorTx", &tx.BaseTx.BaseTx, tx.Stake, "stake"
+		s.Validator = newValidator(tx.Validator)
+		s.Fields = append(s.Fields, validatorFields(tx.Validator)...)
+		s.Fields = append(s.Fields,
+			Field{"reward shares", fmt.Sprintf("%.4f%%", float64(tx.Shares)/10000)},
+		)
+		s.Fields = append(s.Fields, ownerFields("rewards owner", tx.RewardsOwner)...)
+	case *platformvm.UnsignedAddDelegatorTx:
+		s.Type, base, extraO, kind = "AddDelegatorTx", &tx.BaseTx.BaseTx, tx.Stake, "stake"
+		s.Validator = newValidator(tx.Validator)
+		s.Fields = append(s.Fields, validatorFields(tx.Validator)...)
+		s.Fields = append(s.Fields, ownerFields("rewards owner", tx.RewardsOwner)...)
+	case *platformvm.UnsignedAddSubnetValidatorTx:
+		s.Type, base = "AddSubnetValidatorTx", &tx.BaseTx.BaseTx
+		s.Validator = newValidator(tx.Validator.Validator)
+		s.SubnetID, s.SubnetAuth = tx.Validator.Subnet, sigIndices(tx.SubnetAuth)
+		s.Fields = append(s.Fields, Field{"subnet ID", tx.Validator.Subnet.String()})
+		s.Fields = append(s.Fields, validatorFields(tx.Validator.Validator)...)
+		s.Fields = append(s.Fields, subnetAuthField(tx.SubnetAuth))
+	case *platformvm.UnsignedCreateSubnetTx:
+		s.Type, base = "CreateSubnetTx", &tx.BaseTx.BaseTx
+		s.Fields = append(s.Fields, ownerFields("subnet owner", tx.Owner)...)
+	case *platformvm.UnsignedCreateChainTx:
+		s.Type, base = "CreateChainTx", &tx.BaseTx.BaseTx
+		s.SubnetID, s.SubnetAuth = tx.SubnetID, sigIndices(tx.SubnetAuth)
+		fxIDs := make([]string, len(tx.FxIDs))
+		for i, fxID := range tx.FxIDs {
+			fxIDs[i] = fxID.String()
+		}
+		s.Fields = append(s.Fields,
+			Field{"subnet ID", tx.SubnetID.String()},
+			Field{"chain name", tx.ChainName},
+			Field{"VM ID", tx.VMID.String()},
+			Field{"fx IDs", strings.Join(fxIDs, ", ")},
+			Field{"genesis", fmt.Sprintf("%d bytes", len(tx.GenesisData))},
+			subnetAuthField(tx.SubnetAuth),
+		)
+	case *platformvm.UnsignedImportTx:
+		s.Type, base, extraI = "ImportTx", &tx.BaseTx.BaseTx, tx.ImportedInputs
+		s.Fields = append(s.Fields, Field{"source chain", tx.SourceChain.String()})
+	case *platformvm.UnsignedExportTx:
+		s.Type, base, extraO, kind = "ExportTx", &tx.BaseTx.BaseTx, tx.ExportedOutputs, "export"
+		s.Fields = append(s.Fields, Field{"destination chain", tx.DestinationChain.String()})
+	case *codec.BaseTx:
+		s.Type, base = "BaseTx", &tx.BaseTx.BaseTx
+	case *codec.RemoveSubnetValidatorTx:
+		s.Type, base = "RemoveSubnetValidatorTx", &tx.BaseTx.BaseTx
+		s.SubnetID, s.SubnetAuth = tx.Subnet, sigIndices(tx.SubnetAuth)
+		s.Fields = append(s.Fields,
+			Field{"subnet ID", tx.Subnet.String()},
+			Field{"node ID", tx.NodeID.PrefixedString(constants.NodeIDPrefix)},
+			subnetAuthField(tx.SubnetAuth),
+		)
+	case *codec.TransferSubnetOwnershipTx:
+		s.Type, base = "TransferSubnetOwnershipTx", &tx.BaseTx.BaseTx
+		s.SubnetID, s.SubnetAuth = tx.Subnet, sigIndices(tx.SubnetAuth)
+		s.Fields = append(s.Fields, Field{"subnet ID", tx.Subnet.String()})
+		s.Fields = append(s.Fields, ownerFields("new subnet owner", tx.Owner)...)
+		s.Fields = append(s.Fields, subnetAuthField(tx.SubnetAuth))
+	default:
+		return nil, fmt.Errorf("%w %T", ErrUnknownTxType, utx)
+	}
+	s.NetworkID = base.NetworkID
+	s.BlockchainID = base.BlockchainID
+	s.Memo = base.Memo
+	var err error
+	s.Digest, err = Digest(utx)
+	if err != nil {
+		return nil, err
+	}
+
+	hrp := constants.GetHRP(s.NetworkID)
+	for _, in := range append(append([]*avax.TransferableInput{}, base.Ins...), extraI...) {
+		i := Input{
+			ID:      in.InputID(),
+			UTXOID:  fmt.Sprintf("%s:%d", in.TxID, in.OutputIndex),
+			AssetID: in.AssetID(),
+			Amount:  in.Input().Amount(),
+		}
+		ti := in.In
+		if lin, ok := ti.(*platformvm.StakeableLockIn); ok {
+			i.Locktime = lin.Locktime
+			ti = lin.TransferableIn
+		}
+		if sin, ok := ti.(*secp256k1fx.TransferInput); ok {
+			i.SigIndices = sin.SigIndices
+		}
+		s.Inputs = append(s.Inputs, i)
+		s.Fee[i.AssetID] += i.Amount
+	}
+	for _, outs := range []struct {
+		kind string
+		outs []*avax.TransferableOutput
+	}{
+		{"change", base.Outs},
+		{kind, extraO},
+	} {
+		for _, out := range outs.outs {
+			o := Output{
+				AssetID: out.AssetID(),
+				Amount:  out.Output().Amount(),
+				Kind:    outs.kind,
+			}
+			to := out.Out
+			if lout, ok := to.(*platformvm.StakeableLockOut); ok {
+				o.Locktime, o.Stakeable = lout.Locktime, true
+				to = lout.TransferableOut
+			}
+			if sout, ok := to.(*secp256k1fx.TransferOutput); ok {
+				o.Owners = formatOwners(hrp, &sout.OutputOwners)
+			}
+			s.Outputs = append(s.Outputs, o)
+			// burned amount cannot be negative for a valid tx
+			if s.Fee[o.AssetID] >= o.Amount {
+				s.Fee[o.AssetID] -= o.Amount
+			} else {
+				s.Fee[o.AssetID] = 0
+			}
+		}
+	}
+	return s, nil
+}
+
+func validatorFields(v platformvm.Validator) []Field {
+	return []Field{
+		{"node ID", v.NodeID.PrefixedString(constants.NodeIDPrefix)},
+		{"start", time.Unix(int64(v.Start), 0).UTC().Format(time.RFC3339)},
+		{"end", time.Unix(int64(v.End), 0).UTC().Format(time.RFC3339)},
+		{"weight", fmt.Sprintf("%d", v.Wght)},
+	}
+}
+
+func newValidator(v platformvm.Validator) *Validator {
+	return &Validator{
+		NodeID: v.NodeID,
+		Start:  time.Unix(int64(v.Start), 0),
+		End:    time.Unix(int64(v.End), 0),
+		Weight: v.Wght,
+	}
+}
+
+// sigIndices returns the indices of the subnet owners signing [auth], nil
+// if not a "secp256k1fx.Input".
+func sigIndices(auth verify.Verifiable) []uint32 {
+	if in, ok := auth.(*secp256k1fx.Input); ok {
+		return in.SigIndices
+	}
+	return nil
+}
+
+func subnetAuthField(auth verify.Verifiable) Field {
+	if in, ok := auth.(*secp256k1fx.Input); ok {
+		return Field{"subnet auth indices", fmt.Sprintf("%v", in.SigIndices)}
+	}
+	return Field{"subnet auth", fmt.Sprintf("%T", auth)}
+}
+
+func ownerFields(name string, owner interface{}) []Field {
+	oo, ok := owner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return []Field{{name, fmt.Sprintf("%T", owner)}}
+	}
+	// addresses are formatted regardless of the network
+	fo := formatOwners(constants.FallbackHRP, oo)
+	return []Field{
+		{name + " addresses", strings.Join(fo.Addrs, ", ")},
+		{name + " threshold", fmt.Sprintf("%d", fo.Threshold)},
+		{name + " locktime", fmt.Sprintf("%d", fo.Locktime)},
+	}
+}
+
+func formatOwners(hrp string, oo *secp256k1fx.OutputOwners) Owners {
+	o := Owners{Locktime: oo.Locktime, Threshold: oo.Threshold}
+	for _, addr := range oo.Addrs {
+		a, err := formatting.FormatAddress("P", hrp, addr.Bytes())
+		if err != nil {
+			a = addr.String()
+		}
+		o.Addrs = append(o.Addrs, a)
+	}
+	return o
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+)
+
+func TestDecodeCreateSubnet(t *testing.T) {
+	t.Parallel()
+
+	assetID := ids.GenerateTestID()
+	owner := ids.GenerateTestShortID()
+	utx := &platformvm.UnsignedCreateSubnetTx{
+		BaseTx: platformvm.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.FujiID,
+			BlockchainID: ids.Empty,
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+				Asset:  avax.Asset{ID: assetID},
+				In: &secp256k1fx.TransferInput{
+					Amt:   3000,
+					Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+				},
+			}},
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          1000,
+					OutputOwners: secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{owner}},
+				},
+			}},
+		}},
+		Owner: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{owner}},
+	}
+	var u platformvm.UnsignedTx = utx
+	b, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, &u)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Decode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Type != "CreateSubnetTx" || s.Signed {
+		t.Fatalf("unexpected type %q (signed %v)", s.Type, s.Signed)
+	}
+	if len(s.Inputs) != 1 || len(s.Outputs) != 1 {
+		t.Fatalf("unexpected ins/outs %d/%d", len(s.Inputs), len(s.Outputs))
+	}
+	if s.Fee[assetID] != 2000 {
+		t.Fatalf("expected fee 2000, got %d", s.Fee[assetID])
+	}
+
+	// signed
+	pTx := &platformvm.Tx{UnsignedTx: utx}
+	sb, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, pTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err = Decode(sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Signed || s.TxID == ids.Empty {
+		t.Fatalf("expected signed tx with ID, got %v %s", s.Signed, s.TxID)
+	}
+}
+
+func TestSummarizeAddSubnetValidator(t *testing.T) {
+	t.Parallel()
+
+	assetID, subnetID := ids.GenerateTestID(), ids.GenerateTestID()
+	nodeID := ids.GenerateTestShortID()
+	in := &avax.TransferableInput{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID(), OutputIndex: 1},
+		Asset:  avax.Asset{ID: assetID},
+		In: &secp256k1fx.TransferInput{
+			Amt:   1000,
+			Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+		},
+	}
+	utx := &platformvm.UnsignedAddSubnetValidatorTx{
+		BaseTx: platformvm.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID: constants.FujiID,
+			Ins:       []*avax.TransferableInput{in},
+		}},
+		Validator: platformvm.SubnetValidator{
+			Validator: platformvm.Validator{NodeID: nodeID, Start: 1000, End: 2000, Wght: 20},
+			Subnet:    subnetID,
+		},
+		SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{1, 0}},
+	}
+	s, err := Summarize(utx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Validator == nil || s.Validator.NodeID != nodeID || s.Validator.Start.Unix() != 1000 || s.Validator.End.Unix() != 2000 || s.Validator.Weight != 20 {
+		t.Fatalf("unexpected validator %+v", s.Validator)
+	}
+	if s.SubnetID != subnetID || len(s.SubnetAuth) != 2 || s.SubnetAuth[0] != 1 {
+		t.Fatalf("unexpected subnet auth %s %v", s.SubnetID, s.SubnetAuth)
+	}
+	if len(s.Inputs) != 1 || s.Inputs[0].ID != in.InputID() {
+		t.Fatalf("unexpected inputs %+v", s.Inputs)
+	}
+	if s.Fee[assetID] != 1000 {
+		t.Fatalf("expected fee 1000, got %d", s.Fee[assetID])
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Decode([]byte{0x01, 0x02}); !errors.Is(err, ErrUndecodable) {
+		t.Fatalf("expected %v, got %v", ErrUndecodable, err)
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	t.Parallel()
+
+	raw := []byte{0x00, 0x00, 0x00, 0x10, 0xab}
+	withChecksum, err := formatting.EncodeWithChecksum(formatting.Hex, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		hex.EncodeToString(raw),
+		"0x" + hex.EncodeToString(raw),
+		withChecksum + "\n",
+	} {
+		if got := ParseBytes([]byte(s)); hex.EncodeToString(got) != hex.EncodeToString(raw) {
+			t.Fatalf("%q: expected %x, got %x", s, raw, got)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+)
+
+// Destinations returns the addresses the transaction sends funds to: the
+// owners of the produced UTXOs (change, stake, and export), and of the
+// validation rewards. The subnet owners are not destinations.
+func Destinations(utx platformvm.UnsignedTx) ([]ids.ShortID, error) {
+	var (
+		base   *avax.BaseTx
+		extraO []*avax.TransferableOutput
+		owners []interface{}
+	)
+	switch tx := utx.(type) {
+	case *platformvm.UnsignedAddValidatorTx:
+		base, extraO, owners = &tx.BaseTx.BaseTx, tx.Stake, []interface{}{tx.RewardsOwner}
+	case *platformvm.UnsignedAddDelegatorTx:
+		base, extraO, owners = &tx.BaseTx.BaseTx, tx.Stake, []interface{}{tx.RewardsOwner}
+	case *platformvm.UnsignedAddSubnetValidatorTx:
+		base = &tx.BaseTx.BaseTx
+	case *platformvm.UnsignedCreateSubnetTx:
+		base = &tx.BaseTx.BaseTx
+	case *platformvm.UnsignedCreateChainTx:
+		base = &tx.BaseTx.BaseTx
+	case *platformvm.UnsignedImportTx:
+		base = &tx.BaseTx.BaseTx
+	case *platformvm.UnsignedExportTx:
+		base, extraO = &tx.BaseTx.BaseTx, tx.ExportedOutputs
+	case *codec.BaseTx:
+		base = &tx.BaseTx.BaseTx
+	case *codec.RemoveSubnetValidatorTx:
+		base = &tx.BaseTx.BaseTx
+	case *codec.TransferSubnetOwnershipTx:
+		base, owners = &tx.BaseTx.BaseTx, []interface{}{tx.Owner}
+	default:
+		return nil, fmt.Errorf("%w %T", ErrUnknownTxType, utx)
+	}
+
+	var (
+		addrs []ids.ShortID
+		seen  = make(map[ids.ShortID]struct{})
+	)
+	add := func(oo *secp256k1fx.OutputOwners) {
+		for _, addr := range oo.Addrs {
+			if _, ok := seen[addr]; ok {
+				continue
+			}
+			seen[addr] = struct{}{}
+			addrs = append(addrs, addr)
+		}
+	}
+	for _, out := range append(append([]*avax.TransferableOutput{}, base.Outs...), extraO...) {
+		to := out.Out
+		if lout, ok := to.(*platformvm.StakeableLockOut); ok {
+			to = lout.TransferableOut
+		}
+		if sout, ok := to.(*secp256k1fx.TransferOutput); ok {
+			add(&sout.OutputOwners)
+		}
+	}
+	for _, owner := range owners {
+		if oo, ok := owner.(*secp256k1fx.OutputOwners); ok {
+			add(oo)
+		}
+	}
+	return addrs, nil
+}
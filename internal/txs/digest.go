@@ -0,0 +1,26 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+)
+
+// Digest returns the canonical digest of the tx: the hex-encoded SHA256 of
+// its unsigned bytes, which the signatures commit to (as recorded by the
+// audit log). The parties signing the same tx compute the same digest,
+// regardless of the credentials already attached.
+func Digest(utx platformvm.UnsignedTx) (string, error) {
+	b, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, &utx)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:]), nil
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+)
+
+func TestDigest(t *testing.T) {
+	t.Parallel()
+
+	owner := ids.GenerateTestShortID()
+	var utx platformvm.UnsignedTx = &platformvm.UnsignedCreateSubnetTx{
+		BaseTx: platformvm.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID: constants.FujiID,
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+				Asset:  avax.Asset{ID: ids.GenerateTestID()},
+				In: &secp256k1fx.TransferInput{
+					Amt:   3000,
+					Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+				},
+			}},
+		}},
+		Owner: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{owner}},
+	}
+	unsigned, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, &utx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := sha256.Sum256(unsigned)
+	expected := hex.EncodeToString(h[:])
+
+	signed, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, &platformvm.Tx{
+		UnsignedTx: utx,
+		Creds:      []verify.Verifiable{&secp256k1fx.Credential{Sigs: [][65]byte{{1}}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, b := range [][]byte{unsigned, signed} {
+		s, err := Decode(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Digest != expected {
+			t.Fatalf("#%d: expected %s, got %s", i, expected, s.Digest)
+		}
+	}
+}
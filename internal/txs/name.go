@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+)
+
+// TypeName returns the name of the tx type (e.g., "CreateSubnetTx"), as
+// recorded by the audit log, including the types of the later network
+// upgrades registered in "codec".
+func TypeName(utx platformvm.UnsignedTx) string {
+	switch utx.(type) {
+	case *platformvm.UnsignedAddValidatorTx:
+		return "AddValidatorTx"
+	case *platformvm.UnsignedAddDelegatorTx:
+		return "AddDelegatorTx"
+	case *platformvm.UnsignedAddSubnetValidatorTx:
+		return "AddSubnetValidatorTx"
+	case *platformvm.UnsignedCreateSubnetTx:
+		return "CreateSubnetTx"
+	case *platformvm.UnsignedCreateChainTx:
+		return "CreateChainTx"
+	case *platformvm.UnsignedImportTx:
+		return "ImportTx"
+	case *platformvm.UnsignedExportTx:
+		return "ExportTx"
+	case *platformvm.UnsignedAdvanceTimeTx:
+		return "AdvanceTimeTx"
+	case *platformvm.UnsignedRewardValidatorTx:
+		return "RewardValidatorTx"
+	case *codec.BaseTx:
+		return "BaseTx"
+	case *codec.RemoveSubnetValidatorTx:
+		return "RemoveSubnetValidatorTx"
+	case *codec.TransferSubnetOwnershipTx:
+		return "TransferSubnetOwnershipTx"
+	default:
+		return fmt.Sprintf("%T", utx)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+)
+
+func TestTypeName(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		utx      platformvm.UnsignedTx
+		expected string
+	}{
+		{utx: &platformvm.UnsignedCreateSubnetTx{}, expected: "CreateSubnetTx"},
+		{utx: &platformvm.UnsignedAddSubnetValidatorTx{}, expected: "AddSubnetValidatorTx"},
+		{utx: &codec.BaseTx{}, expected: "BaseTx"},
+		{utx: &codec.RemoveSubnetValidatorTx{}, expected: "RemoveSubnetValidatorTx"},
+		{utx: &codec.TransferSubnetOwnershipTx{}, expected: "TransferSubnetOwnershipTx"},
+	}
+	for i, tv := range tt {
+		if got := TypeName(tv.utx); got != tv.expected {
+			t.Fatalf("#%d: expected %q, got %q", i, tv.expected, got)
+		}
+	}
+}
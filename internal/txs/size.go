@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	"github.com/ava-labs/subnet-cli/internal/codec"
+)
+
+// MaxSize is the max size of a P-Chain transaction accepted by the node,
+// as bounded by the platformvm codec (ref. "codec.NewDefaultManager").
+const MaxSize = 256 * units.KiB
+
+const (
+	// ref. "avax.TransferableInput" of a "secp256k1fx.TransferInput":
+	// tx ID, output index, asset ID, type ID, amount, sig indices length
+	inputSize    = 32 + 4 + 32 + 4 + 8 + 4
+	sigIndexSize = 4
+	// ref. "secp256k1fx.Credential": type ID, sigs length
+	credentialSize = 4 + 4
+)
+
+var ErrTooLarge = errors.New("transaction is too large")
+
+// Complexity is the size of a signed transaction, and the number of
+// its components.
+type Complexity struct {
+	Size       int
+	Inputs     int
+	Outputs    int
+	Signatures int
+}
+
+// Measure returns the complexity of the transaction once signed by
+// [signers] (one credential per input), without signing it.
+func Measure(utx platformvm.UnsignedTx, signers [][]ids.ShortID) (*Complexity, error) {
+	s, err := Summarize(utx)
+	if err != nil {
+		return nil, err
+	}
+	pTx := &platformvm.Tx{
+		UnsignedTx: utx,
+		Creds:      make([]verify.Verifiable, len(signers)),
+	}
+	c := &Complexity{Inputs: len(s.Inputs), Outputs: len(s.Outputs)}
+	for i, inputSigners := range signers {
+		pTx.Creds[i] = &secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(inputSigners)),
+		}
+		c.Signatures += len(inputSigners)
+	}
+	b, err := codec.UnboundedPCodecManager.Marshal(platformvm.CodecVersion, pTx)
+	if err != nil {
+		return nil, err
+	}
+	c.Size = len(b)
+	return c, nil
+}
+
+// Check returns an error if the transaction exceeds the node limit.
+func (c *Complexity) Check() error {
+	if c.Size <= MaxSize {
+		return nil
+	}
+	return fmt.Errorf("%w: %d bytes > %d bytes (%d inputs, %d outputs, %d signatures)",
+		ErrTooLarge,
+		c.Size,
+		MaxSize,
+		c.Inputs,
+		c.Outputs,
+		c.Signatures,
+	)
+}
+
+// InputSize returns the size that an input of [sigs] signatures adds
+// to a signed transaction, including its credential.
+func InputSize(sigs int) int {
+	return inputSize + sigs*sigIndexSize + credentialSize + sigs*crypto.SECP256K1RSigLen
+}
+
+// Batches splits the inputs, given their number of signatures [sigs],
+// into consecutive batches of at most [maxInputs] inputs (unlimited if
+// zero), each fitting the max tx size along with [overhead] bytes of the
+// rest of the tx. It returns the number of inputs of each batch.
+func Batches(sigs []int, overhead int, maxInputs int) []int {
+	batches := make([]int, 0)
+	n, size := 0, overhead
+	for _, s := range sigs {
+		inSize := InputSize(s)
+		if n > 0 && (size+inSize > MaxSize || (maxInputs > 0 && n >= maxInputs)) {
+			batches = append(batches, n)
+			n, size = 0, overhead
+		}
+		n++
+		size += inSize
+	}
+	if n > 0 {
+		batches = append(batches, n)
+	}
+	return batches
+}
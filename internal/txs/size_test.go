@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newSizeTestTx(n int) (platformvm.UnsignedTx, [][]ids.ShortID) {
+	owner := ids.GenerateTestShortID()
+	ins := make([]*avax.TransferableInput, n)
+	signers := make([][]ids.ShortID, n)
+	for i := range ins {
+		ins[i] = &avax.TransferableInput{
+			UTXOID: avax.UTXOID{TxID: ids.Empty.Prefix(uint64(i))},
+			Asset:  avax.Asset{ID: ids.Empty},
+			In: &secp256k1fx.TransferInput{
+				Amt:   1,
+				Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+		}
+		signers[i] = []ids.ShortID{owner}
+	}
+	utx := &platformvm.UnsignedCreateSubnetTx{
+		BaseTx: platformvm.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.FujiID,
+			BlockchainID: constants.PlatformChainID,
+			Ins:          ins,
+		}},
+		Owner: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{owner}},
+	}
+	return utx, signers
+}
+
+func TestMeasure(t *testing.T) {
+	t.Parallel()
+
+	utx, signers := newSizeTestTx(1)
+	one, err := Measure(utx, signers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	utx, signers = newSizeTestTx(2)
+	two, err := Measure(utx, signers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if two.Inputs != 2 || two.Signatures != 2 {
+		t.Fatalf("unexpected complexity %+v", two)
+	}
+	if d := two.Size - one.Size; d != InputSize(1) {
+		t.Fatalf("expected %d bytes per input, got %d", InputSize(1), d)
+	}
+	if err := two.Check(); err != nil {
+		t.Fatal(err)
+	}
+
+	n := MaxSize/InputSize(1) + 1
+	utx, signers = newSizeTestTx(n)
+	c, err := Measure(utx, signers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Check(); !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("expected %v, got %v", ErrTooLarge, err)
+	}
+}
+
+func TestBatches(t *testing.T) {
+	t.Parallel()
+
+	perTx := (MaxSize - 1024) / InputSize(1)
+	tt := []struct {
+		sigs      int
+		n         int
+		maxInputs int
+		expected  []int
+	}{
+		{sigs: 1, n: 0, expected: []int{}},
+		{sigs: 1, n: 3, expected: []int{3}},
+		{sigs: 1, n: 5, maxInputs: 2, expected: []int{2, 2, 1}},
+		{sigs: 1, n: perTx + 1, expected: []int{perTx, 1}},
+		{sigs: 1, n: 2*perTx + 3, maxInputs: perTx + 5, expected: []int{perTx, perTx, 3}},
+	}
+	for i, tv := range tt {
+		sigs := make([]int, tv.n)
+		for j := range sigs {
+			sigs[j] = tv.sigs
+		}
+		batches := Batches(sigs, 1024, tv.maxInputs)
+		if len(batches) != len(tv.expected) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.expected, batches)
+		}
+		for j := range batches {
+			if batches[j] != tv.expected[j] {
+				t.Fatalf("#%d: expected %v, got %v", i, tv.expected, batches)
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package utxofile implements the UTXO set file, to build transactions
+// without querying the network for the UTXOs.
+package utxofile
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/subnet-cli/internal/fees"
+)
+
+var (
+	ErrNetworkMismatch = errors.New("UTXO file is for another network")
+	ErrInvalidUTXO     = errors.New("invalid UTXO encoding")
+)
+
+// File is the P-Chain UTXO set of the addresses.
+type File struct {
+	NetworkID uint32    `json:"networkId"`
+	Addresses []string  `json:"addresses"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	// UTXOs are the hex-encoded UTXO bytes.
+	UTXOs []string `json:"utxos"`
+
+	// the network information, for the txs to be built without querying
+	// the network (nil in the files of the earlier versions)
+	XChainID *ids.ID       `json:"xChainId,omitempty"`
+	AssetID  *ids.ID       `json:"assetId,omitempty"`
+	Fees     fees.Override `json:"fees,omitempty"`
+}
+
+// New creates the file of the raw UTXOs.
+func New(networkID uint32, addrs []string, utxos [][]byte) *File {
+	f := &File{
+		NetworkID: networkID,
+		Addresses: addrs,
+		FetchedAt: time.Now().UTC(),
+		UTXOs:     make([]string, len(utxos)),
+	}
+	for i, u := range utxos {
+		f.UTXOs[i] = hex.EncodeToString(u)
+	}
+	return f
+}
+
+// Offline returns true if the file has the network information to build
+// the txs without querying the network.
+func (f *File) Offline() bool {
+	return f.XChainID != nil && f.AssetID != nil && f.Fees.Complete()
+}
+
+// Load loads the file at [p].
+func Load(p string) (*File, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	f := new(File)
+	if err := json.Unmarshal(b, f); err != nil {
+		return nil, fmt.Errorf("failed to parse UTXO file %q: %w", p, err)
+	}
+	return f, nil
+}
+
+// CheckNetwork returns an error if the UTXOs are not of the network.
+func (f *File) CheckNetwork(networkID uint32) error {
+	if f.NetworkID != networkID {
+		return fmt.Errorf("%w: file network %d, expected %d", ErrNetworkMismatch, f.NetworkID, networkID)
+	}
+	return nil
+}
+
+// Save writes the file at [p].
+func (f *File) Save(p string) error {
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p, append(b, '\n'), 0o644)
+}
+
+// Bytes returns the raw UTXOs.
+func (f *File) Bytes() ([][]byte, error) {
+	utxos := make([][]byte, len(f.UTXOs))
+	for i, u := range f.UTXOs {
+		b, err := hex.DecodeString(u)
+		if err != nil {
+			return nil, fmt.Errorf("%w: UTXO #%d: %v", ErrInvalidUTXO, i, err)
+		}
+		utxos[i] = b
+	}
+	return utxos, nil
+}
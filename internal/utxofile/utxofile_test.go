@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utxofile
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/subnet-cli/internal/fees"
+)
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	p := filepath.Join(t.TempDir(), "utxos.json")
+	utxos := [][]byte{{0x00, 0x01}, {0xff}}
+	if err := New(5, []string{"P-fuji1abc"}, utxos).Save(p); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.CheckNetwork(1); !errors.Is(err, ErrNetworkMismatch) {
+		t.Fatalf("expected %v, got %v", ErrNetworkMismatch, err)
+	}
+	if err := f.CheckNetwork(5); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := f.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != len(utxos) || !bytes.Equal(loaded[0], utxos[0]) || !bytes.Equal(loaded[1], utxos[1]) {
+		t.Fatalf("unexpected UTXOs %x, expected %x", loaded, utxos)
+	}
+
+	if f.Offline() {
+		t.Fatal("unexpected network information")
+	}
+
+	// the network information of the later versions
+	xChainID, assetID := ids.GenerateTestID(), ids.GenerateTestID()
+	f.XChainID, f.AssetID = &xChainID, &assetID
+	f.Fees = fees.Override{fees.Tx: 1, fees.CreateSubnet: 2, fees.CreateBlockchain: 3}
+	if err := f.Save(p); err != nil {
+		t.Fatal(err)
+	}
+	f, err = Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.Offline() || *f.XChainID != xChainID || *f.AssetID != assetID || f.Fees[fees.CreateBlockchain] != 3 {
+		t.Fatalf("unexpected network information %v %v %v", f.XChainID, f.AssetID, f.Fees)
+	}
+
+	f.UTXOs = append(f.UTXOs, "zz")
+	if _, err := f.Bytes(); !errors.Is(err, ErrInvalidUTXO) {
+		t.Fatalf("expected %v, got %v", ErrInvalidUTXO, err)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package validate checks the inputs of the txs before any network call,
+// collecting all the violations instead of failing on the first one.
+package validate
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+// MaxChainNameLen is the maximum length of a chain name
+// (ref. "vms.platformvm.UnsignedCreateChainTx").
+const MaxChainNameLen = 128
+
+// MaxMemoLen is the maximum size of a tx memo in bytes.
+const MaxMemoLen = avax.MaxMemoSize
+
+var (
+	ErrInvalid = errors.New("invalid inputs")
+
+	ErrChainNameTooLong     = fmt.Errorf("chain name must be at most %d characters", MaxChainNameLen)
+	ErrChainNameCharset     = errors.New("chain name must only contain ASCII letters, digits, and spaces")
+	ErrInvalidID            = errors.New("must be a CB58-encoded 32-byte ID")
+	ErrInvalidNodeID        = errors.New("must be a node ID (e.g., NodeID-...)")
+	ErrInvalidTime          = errors.New("must be a timestamp in RFC3339 format, or relative to now (e.g., +5m)")
+	ErrInvalidDuration      = errors.New("must be a duration (e.g., 336h, 14d)")
+	ErrEndAndDuration       = errors.New("end time and duration are mutually exclusive")
+	ErrEndNotAfterStart     = errors.New("end time must be after the start time")
+	ErrZeroWeight           = errors.New("weight must be greater than 0")
+	ErrZeroThreshold        = errors.New("threshold must be greater than 0")
+	ErrInvalidPercent       = errors.New("must be a percentage in [0, 100]")
+	ErrThresholdExceedsKeys = errors.New("threshold must not exceed the number of keys")
+	ErrMemoTooLong          = fmt.Errorf("memo must be at most %d bytes", MaxMemoLen)
+	ErrInvalidMemo          = errors.New("memo must be UTF-8, or hex-encoded with the 0x prefix")
+)
+
+// Violation is an input that does not satisfy a constraint.
+type Violation struct {
+	// Field is the name of the input (e.g., the flag).
+	Field string
+	Err   error
+}
+
+// Errors is the list of violations, and matches "ErrInvalid"
+// with "errors.Is".
+type Errors []Violation
+
+func (es Errors) Error() string {
+	lines := make([]string, 0, len(es)+1)
+	s := "s"
+	if len(es) == 1 {
+		s = ""
+	}
+	lines = append(lines, fmt.Sprintf("%v (%d violation%s):", ErrInvalid, len(es), s))
+	for _, v := range es {
+		lines = append(lines, fmt.Sprintf("  %s: %v", v.Field, v.Err))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (es Errors) Is(target error) bool { return target == ErrInvalid }
+
+// Checker collects the violations of the checked inputs.
+type Checker struct {
+	errs Errors
+}
+
+// Check records [err] as a violation of [field], if not nil.
+func (c *Checker) Check(field string, err error) {
+	if err != nil {
+		c.errs = append(c.errs, Violation{Field: field, Err: err})
+	}
+}
+
+// Err returns the collected violations as "Errors", or nil if none.
+func (c *Checker) Err() error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return c.errs
+}
+
+// ChainName checks [name] against the rules of the P-Chain.
+func ChainName(name string) error {
+	if len(name) > MaxChainNameLen {
+		return ErrChainNameTooLong
+	}
+	for _, r := range name {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsNumber(r) || r == ' ') {
+			return fmt.Errorf("%w (found %q)", ErrChainNameCharset, r)
+		}
+	}
+	return nil
+}
+
+// ID checks that [s] is a CB58-encoded ID (e.g., a subnet or a VM ID).
+func ID(s string) error {
+	if _, err := ids.FromString(s); err != nil {
+		return fmt.Errorf("%w (%v)", ErrInvalidID, err)
+	}
+	return nil
+}
+
+// NodeID checks that [s] is a prefixed node ID.
+func NodeID(s string) error {
+	if _, err := ids.ShortFromPrefixedString(s, constants.NodeIDPrefix); err != nil {
+		return fmt.Errorf("%w (%v)", ErrInvalidNodeID, err)
+	}
+	return nil
+}
+
+// timeLayouts are the accepted timestamp layouts, in order. The ones
+// without a UTC offset are in the time zone of "now".
+var timeLayouts = []struct {
+	layout string
+	zoned  bool
+}{
+	{time.RFC3339, true},
+	{"2006-01-02T15:04Z07:00", true},
+	{"2006-01-02T15:04:05", false},
+	{"2006-01-02T15:04", false},
+	{"2006-01-02 15:04:05", false},
+	{"2006-01-02 15:04", false},
+}
+
+// Time parses [s] as a timestamp in RFC3339 format (the seconds and the UTC
+// offset are optional, e.g., "2024-12-31T00:00Z", "2024-12-31T09:00"), or
+// as a duration relative to [now] (e.g., "+5m", "+14d"). The timestamps
+// without a UTC offset are in the time zone of [now].
+func Time(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "now" {
+		return now, nil
+	}
+	if strings.HasPrefix(s, "+") {
+		d, err := Duration(s[1:])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w (%v)", ErrInvalidTime, err)
+		}
+		return now.Add(d), nil
+	}
+	var err error
+	for _, l := range timeLayouts {
+		var t time.Time
+		if l.zoned {
+			t, err = time.Parse(l.layout, s)
+		} else {
+			t, err = time.ParseInLocation(l.layout, s, now.Location())
+		}
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w (%q)", ErrInvalidTime, s)
+}
+
+// Duration parses [s] as a Go duration (e.g., "336h", "1h30m"), or as
+// a number of days (e.g., "14d").
+func Duration(s string) (time.Duration, error) {
+	var (
+		d   time.Duration
+		err error
+	)
+	if days := strings.TrimSuffix(s, "d"); days != s {
+		var n uint64
+		n, err = strconv.ParseUint(days, 10, 16)
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%w (%q)", ErrInvalidDuration, s)
+	}
+	return d, nil
+}
+
+// Window checks that [end] is after [start].
+func Window(start time.Time, end time.Time) error {
+	if !end.After(start) {
+		return fmt.Errorf("%w (%s <= %s)", ErrEndNotAfterStart, end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Weight checks that the validation [weight] is set.
+func Weight(weight uint64) error {
+	if weight == 0 {
+		return ErrZeroWeight
+	}
+	return nil
+}
+
+// Percent checks that [p] is a percentage.
+func Percent(p float64) error {
+	// also rejects NaN
+	if !(p >= 0 && p <= 100) {
+		return fmt.Errorf("%w (%v)", ErrInvalidPercent, p)
+	}
+	return nil
+}
+
+// Threshold checks that [threshold] signatures out of [keys] can be met.
+func Threshold(threshold uint32, keys int) error {
+	switch {
+	case threshold == 0:
+		return ErrZeroThreshold
+	case int(threshold) > keys:
+		return fmt.Errorf("%w (%d > %d)", ErrThresholdExceedsKeys, threshold, keys)
+	}
+	return nil
+}
+
+// Memo decodes the tx memo [s], hex-encoded if prefixed by "0x" (e.g.,
+// "0xdeadbeef"), UTF-8 otherwise, and checks it fits in a tx.
+func Memo(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+	b := []byte(s)
+	if strings.HasPrefix(s, "0x") {
+		var err error
+		b, err = hex.DecodeString(s[2:])
+		if err != nil {
+			return nil, fmt.Errorf("%w (%v)", ErrInvalidMemo, err)
+		}
+	} else if !utf8.ValidString(s) {
+		return nil, ErrInvalidMemo
+	}
+	if len(b) > MaxMemoLen {
+		return nil, fmt.Errorf("%w (%d bytes)", ErrMemoTooLong, len(b))
+	}
+	return b, nil
+}
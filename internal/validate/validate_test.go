@@ -0,0 +1,186 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validate
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChainName(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		err  error
+	}{
+		{name: "subnetevm"},
+		{name: "my chain 2"},
+		{name: ""},
+		{name: "my-chain", err: ErrChainNameCharset},
+		{name: "chaîne", err: ErrChainNameCharset},
+		{name: strings.Repeat("a", MaxChainNameLen)},
+		{name: strings.Repeat("a", MaxChainNameLen+1), err: ErrChainNameTooLong},
+	}
+	for i, tv := range tt {
+		if err := ChainName(tv.name); !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+	}
+}
+
+func TestChecker(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	var c Checker
+	c.Check("--chain-name", ChainName("subnetevm"))
+	c.Check("--weight", Weight(1))
+	c.Check("--threshold", Threshold(2, 2))
+	if err := c.Err(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	c.Check("--vm-id", ID("abc"))
+	c.Check("--node-id", NodeID("abc"))
+	c.Check("--validate-end", Window(start, start))
+	c.Check("--validate-weight", Weight(0))
+	c.Check("--output-threshold", Threshold(3, 2))
+	c.Check("--output-threshold", Threshold(0, 2))
+	err := c.Err()
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected %v, got %v", ErrInvalid, err)
+	}
+	var es Errors
+	if !errors.As(err, &es) {
+		t.Fatalf("expected Errors, got %T", err)
+	}
+	expected := []error{ErrInvalidID, ErrInvalidNodeID, ErrEndNotAfterStart, ErrZeroWeight, ErrThresholdExceedsKeys, ErrZeroThreshold}
+	if len(es) != len(expected) {
+		t.Fatalf("expected %d violations, got %d", len(expected), len(es))
+	}
+	for i, v := range es {
+		if !errors.Is(v.Err, expected[i]) {
+			t.Fatalf("#%d: expected %v, got %v", i, expected[i], v.Err)
+		}
+	}
+	if !strings.HasPrefix(err.Error(), "invalid inputs (6 violations):\n  --vm-id: ") {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+}
+
+func TestTime(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Time("2022-03-01T00:00:00Z", time.Now()); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := Time("2022-03-01", time.Now()); !errors.Is(err, ErrInvalidTime) {
+		t.Fatalf("expected %v, got %v", ErrInvalidTime, err)
+	}
+
+	est := time.FixedZone("EST", -5*3600)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, est)
+	tt := []struct {
+		s   string
+		exp time.Time
+	}{
+		{"now", now},
+		{"+5m", now.Add(5 * time.Minute)},
+		{"+14d", now.Add(14 * 24 * time.Hour)},
+		{"2024-12-31T00:00Z", time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{"2024-12-31T00:00:00+09:00", time.Date(2024, 12, 30, 15, 0, 0, 0, time.UTC)},
+		{"2024-12-31T09:30", time.Date(2024, 12, 31, 9, 30, 0, 0, est)},
+		{"2024-12-31 09:30:15", time.Date(2024, 12, 31, 9, 30, 15, 0, est)},
+	}
+	for i, tv := range tt {
+		got, err := Time(tv.s, now)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if !got.Equal(tv.exp) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.exp, got)
+		}
+	}
+	for _, s := range []string{"+", "+-5m", "+5x", "tomorrow", "-5m"} {
+		if _, err := Time(s, now); !errors.Is(err, ErrInvalidTime) {
+			t.Fatalf("%q: expected %v, got %v", s, ErrInvalidTime, err)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		s   string
+		exp time.Duration
+	}{
+		{"336h", 336 * time.Hour},
+		{"1h30m", 90 * time.Minute},
+		{"14d", 14 * 24 * time.Hour},
+	}
+	for i, tv := range tt {
+		got, err := Duration(tv.s)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if got != tv.exp {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.exp, got)
+		}
+	}
+	for _, s := range []string{"", "0", "0d", "-1h", "d", "1.5d"} {
+		if _, err := Duration(s); !errors.Is(err, ErrInvalidDuration) {
+			t.Fatalf("%q: expected %v, got %v", s, ErrInvalidDuration, err)
+		}
+	}
+}
+
+func TestPercent(t *testing.T) {
+	t.Parallel()
+
+	for _, p := range []float64{0, 0.8, 80, 100} {
+		if err := Percent(p); err != nil {
+			t.Fatalf("%v: %v", p, err)
+		}
+	}
+	for _, p := range []float64{-1, 100.5, 8000, math.NaN()} {
+		if err := Percent(p); !errors.Is(err, ErrInvalidPercent) {
+			t.Fatalf("%v: expected %v, got %v", p, ErrInvalidPercent, err)
+		}
+	}
+}
+
+func TestMemo(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		memo     string
+		expected []byte
+		err      error
+	}{
+		{memo: ""},
+		{memo: "invoice 42", expected: []byte("invoice 42")},
+		{memo: "0xdeadbeef", expected: []byte{0xde, 0xad, 0xbe, 0xef}},
+		{memo: "0xzz", err: ErrInvalidMemo},
+		{memo: "\xff", err: ErrInvalidMemo},
+		{memo: strings.Repeat("a", MaxMemoLen), expected: []byte(strings.Repeat("a", MaxMemoLen))},
+		{memo: strings.Repeat("a", MaxMemoLen+1), err: ErrMemoTooLong},
+		{memo: "0x" + strings.Repeat("ab", MaxMemoLen+1), err: ErrMemoTooLong},
+	}
+	for i, tv := range tt {
+		b, err := Memo(tv.memo)
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: expected %v, got %v", i, tv.err, err)
+		}
+		if err == nil && !bytes.Equal(b, tv.expected) {
+			t.Fatalf("#%d: expected %x, got %x", i, tv.expected, b)
+		}
+	}
+}
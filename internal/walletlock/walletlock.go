@@ -0,0 +1,117 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package walletlock implements the advisory file locks of the wallets,
+// held while the UTXOs of a wallet are spent by in-flight txs, so that the
+// subnet-cli processes sharing a key (e.g., CI jobs, parallel scripts)
+// spend its UTXOs one after the other instead of building conflicting txs.
+//
+// The lock file records the txs issued by its holder, for the next holder
+// to wait for the txs still processing (e.g., issued by a killed process)
+// before selecting the UTXOs.
+package walletlock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var ErrLocked = errors.New("wallet is locked by another process")
+
+// DefaultDir returns the default lock directory "~/.subnet-cli/locks".
+func DefaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".subnet-cli", "locks"), nil
+}
+
+// Lock is the held lock of a wallet.
+type Lock struct {
+	f *os.File
+}
+
+type record struct {
+	Pending []ids.ID `json:"pending"`
+}
+
+// Acquire acquires the lock of the wallet [name] (e.g., its P-Chain
+// address) in [dir], polling every [interval] while held by another
+// process, until [ctx] is done.
+func Acquire(ctx context.Context, dir string, name string, interval time.Duration) (*Lock, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	p := filepath.Join(dir, name+".lock")
+	f, err := os.OpenFile(p, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		ok, err := tryLock(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if ok {
+			return &Lock{f: f}, nil
+		}
+		select {
+		case <-ctx.Done():
+			f.Close()
+			return nil, fmt.Errorf("%w (%s): %v", ErrLocked, p, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Pending returns the txs recorded as issued by the holders.
+func (l *Lock) Pending() ([]ids.ID, error) {
+	if _, err := l.f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(l.f)
+	if err != nil || len(b) == 0 {
+		return nil, err
+	}
+	var r record
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", l.f.Name(), err)
+	}
+	return r.Pending, nil
+}
+
+// SetPending records the txs issued by the holders, not yet known to be
+// committed or dropped.
+func (l *Lock) SetPending(txIDs []ids.ID) error {
+	b, err := json.Marshal(record{Pending: txIDs})
+	if err != nil {
+		return err
+	}
+	if err := l.f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := l.f.WriteAt(b, 0); err != nil {
+		return err
+	}
+	return l.f.Sync()
+}
+
+// Release releases the lock.
+func (l *Lock) Release() error {
+	if err := unlock(l.f); err != nil {
+		l.f.Close()
+		return err
+	}
+	return l.f.Close()
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package walletlock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestAcquire(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	l, err := Acquire(context.Background(), dir, "P-fuji1abc", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+	if err := l.SetPending(txIDs); err != nil {
+		t.Fatal(err)
+	}
+
+	// the lock is held per open file, as by another process
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	_, err = Acquire(ctx, dir, "P-fuji1abc", time.Millisecond)
+	cancel()
+	if !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected %v, got %v", ErrLocked, err)
+	}
+	other, err := Acquire(context.Background(), dir, "P-fuji1def", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Release(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.Release(); err != nil {
+		t.Fatal(err)
+	}
+	l, err = Acquire(context.Background(), dir, "P-fuji1abc", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending, err := l.Pending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 || pending[0] != txIDs[0] || pending[1] != txIDs[1] {
+		t.Fatalf("expected %v, got %v", txIDs, pending)
+	}
+	if err := l.SetPending(nil); err != nil {
+		t.Fatal(err)
+	}
+	if pending, err := l.Pending(); err != nil || len(pending) != 0 {
+		t.Fatalf("expected no pending tx, got %v (%v)", pending, err)
+	}
+	if err := l.Release(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package plugin implements the JSON handshake passed by subnet-cli to
+// the plugins, the "subnet-cli-<name>" executables on the PATH.
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	// Prefix is the prefix of the plugin executable names.
+	Prefix = "subnet-cli-"
+	// HandshakeEnvVar is the environment variable holding the JSON
+	// handshake in the plugin process.
+	HandshakeEnvVar = "SUBNET_CLI_PLUGIN_HANDSHAKE"
+	// HandshakeVersion is incremented on any breaking change to "Handshake".
+	HandshakeVersion = 1
+)
+
+var (
+	ErrNoHandshake          = errors.New("not run by subnet-cli (no " + HandshakeEnvVar + ")")
+	ErrUnsupportedHandshake = errors.New("unsupported handshake version")
+)
+
+// Handshake is the configuration of the CLI passed to the plugins.
+// The fields are only added, never removed or renamed, within a version.
+type Handshake struct {
+	Version int `json:"version"`
+	// Name is the plugin name (e.g., "genesis" for "subnet-cli-genesis").
+	Name string `json:"name"`
+
+	LogLevel       string `json:"logLevel"`
+	PollInterval   string `json:"pollInterval"`
+	RequestTimeout string `json:"requestTimeout"`
+
+	// empty if "--public-uri" is not set
+	PublicURI   string `json:"publicUri,omitempty"`
+	NetworkID   uint32 `json:"networkId,omitempty"`
+	NetworkName string `json:"networkName,omitempty"`
+
+	// PrivateKeyPath is the key to sign with, unless "Ledger" is set.
+	PrivateKeyPath string `json:"privateKeyPath,omitempty"`
+	Ledger         bool   `json:"ledger,omitempty"`
+	// KeyAddresses are the P-Chain addresses of the key, if loaded.
+	KeyAddresses []string `json:"keyAddresses,omitempty"`
+}
+
+// ReadHandshake reads the handshake in the plugin process.
+func ReadHandshake() (*Handshake, error) {
+	s, ok := os.LookupEnv(HandshakeEnvVar)
+	if !ok {
+		return nil, ErrNoHandshake
+	}
+	h := new(Handshake)
+	if err := json.Unmarshal([]byte(s), h); err != nil {
+		return nil, err
+	}
+	if h.Version != HandshakeVersion {
+		return nil, fmt.Errorf("%w %d, expected %d", ErrUnsupportedHandshake, h.Version, HandshakeVersion)
+	}
+	return h, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package prompt implements interactive confirmation prompts.
+package prompt
+
+import (
+	"errors"
+	"os"
+
+	"github.com/manifoldco/promptui"
+)
+
+// Prompter defines the confirmation prompt interface.
+type Prompter interface {
+	// Confirm displays the [yes] and [no] options and returns true
+	// if the [yes] option is selected.
+	Confirm(yes string, no string) (bool, error)
+	// Password reads a secret without echoing it.
+	Password(label string) (string, error)
+	// Input reads a line of text (e.g., a confirmation phrase).
+	Input(label string) (string, error)
+}
+
+// ErrNonInteractive is returned when a secret or an input is requested
+// with prompts disabled.
+var ErrNonInteractive = errors.New("cannot prompt for an input in non-interactive mode")
+
+var _ Prompter = &selectPrompter{}
+
+type selectPrompter struct{}
+
+// New creates a new interactive prompter reading from the terminal.
+func New() Prompter {
+	return &selectPrompter{}
+}
+
+func (sp *selectPrompter) Confirm(yes string, no string) (bool, error) {
+	prompt := promptui.Select{
+		Label:  "\n",
+		Stdout: os.Stdout,
+		Items:  []string{yes, no},
+	}
+	idx, _, err := prompt.Run()
+	if err != nil {
+		return false, err
+	}
+	return idx == 0, nil
+}
+
+func (sp *selectPrompter) Password(label string) (string, error) {
+	prompt := promptui.Prompt{
+		Label:  label,
+		Stdout: os.Stdout,
+		Mask:   '*',
+	}
+	return prompt.Run()
+}
+
+func (sp *selectPrompter) Input(label string) (string, error) {
+	prompt := promptui.Prompt{
+		Label:  label,
+		Stdout: os.Stdout,
+	}
+	return prompt.Run()
+}
+
+var _ Prompter = &autoPrompter{}
+
+type autoPrompter struct{}
+
+// NewAuto creates a new non-interactive prompter that confirms
+// every prompt (e.g., "--yes" in CI).
+func NewAuto() Prompter {
+	return &autoPrompter{}
+}
+
+func (ap *autoPrompter) Confirm(string, string) (bool, error) {
+	return true, nil
+}
+
+func (ap *autoPrompter) Password(string) (string, error) {
+	return "", ErrNonInteractive
+}
+
+func (ap *autoPrompter) Input(string) (string, error) {
+	return "", ErrNonInteractive
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/subnet-cli/client"
+)
+
+// CreateSubnet creates a subnet controlled by "Options.Owners" (the key,
+// if nil), and returns its ID.
+func CreateSubnet(ctx context.Context, cli client.Client, k Key, opts Options) (ids.ID, error) {
+	subnetID, _, err := cli.P().CreateSubnet(ctx, k, opts.ops()...)
+	return subnetID, err
+}
+
+// Blockchain is the blockchain to create on a subnet.
+type Blockchain struct {
+	SubnetID ids.ID
+	Name     string
+	VMID     ids.ID
+	// FxIDs are the feature extensions of the VM, if any.
+	FxIDs   []ids.ID
+	Genesis []byte
+}
+
+// CreateBlockchain creates the blockchain, and returns its ID. The key
+// must hold enough control keys of the subnet.
+func CreateBlockchain(ctx context.Context, cli client.Client, k Key, bc Blockchain, opts Options) (ids.ID, error) {
+	if bc.Name == "" {
+		return ids.Empty, ErrEmptyChainName
+	}
+	ops := opts.ops()
+	if len(bc.FxIDs) > 0 {
+		ops = append(ops, client.WithFxIDs(bc.FxIDs))
+	}
+	blockchainID, _, err := cli.P().CreateBlockchain(ctx, k, bc.SubnetID, bc.Name, bc.VMID, bc.Genesis, ops...)
+	return blockchainID, err
+}
+
+// Validator is the primary network validator to add.
+type Validator struct {
+	NodeID ids.ShortID
+	Period Period
+	// StakeAmount is the staked nAVAX. Zero to default to the minimum
+	// stake of the network.
+	StakeAmount uint64
+	// StakeableLocked stakes the locked stakeable AVAX first.
+	StakeableLocked bool
+	// RewardShares is the delegation fee, in millionths (e.g., 20000 for 2%).
+	RewardShares uint32
+	// RewardAddress receives the rewards. Empty to default to the key.
+	RewardAddress ids.ShortID
+	// ChangeAddress receives the change. Empty to default to the key.
+	ChangeAddress ids.ShortID
+}
+
+// AddValidator adds the node to the primary network validators, and
+// returns its validation period.
+func AddValidator(ctx context.Context, cli client.Client, k Key, v Validator, opts Options) (time.Time, time.Time, error) {
+	start, end, err := v.Period.resolve(cli.NetworkID(), time.Now())
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%s: %w", v.NodeID, err)
+	}
+	_, err = cli.P().AddValidator(ctx, k, v.NodeID, start, end,
+		append(opts.ops(),
+			client.WithStakeAmount(v.StakeAmount),
+			client.WithStakeableLocked(v.StakeableLocked),
+			client.WithRewardShares(v.RewardShares),
+			client.WithRewardAddress(v.RewardAddress),
+			client.WithChangeAddress(v.ChangeAddress),
+		)...,
+	)
+	return start, end, err
+}
+
+// SubnetValidator is the subnet validator to add.
+type SubnetValidator struct {
+	SubnetID ids.ID
+	NodeID   ids.ShortID
+	// Period must be within the primary network validation period.
+	// A zero end time defaults to the primary network validation end.
+	Period Period
+	// Weight is zero to default to "DefaultWeight".
+	Weight uint64
+}
+
+// AddSubnetValidator adds the primary network validator to the subnet
+// validators, and returns its validation period. The key must hold enough
+// control keys of the subnet.
+func AddSubnetValidator(ctx context.Context, cli client.Client, k Key, v SubnetValidator, opts Options) (time.Time, time.Time, error) {
+	if v.Period.End.IsZero() {
+		_, end, err := cli.P().GetValidator(ctx, ids.Empty, v.NodeID)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%s: %w", v.NodeID, err)
+		}
+		v.Period.End = end
+	}
+	start, end, err := v.Period.resolve(cli.NetworkID(), time.Now())
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%s: %w", v.NodeID, err)
+	}
+	if v.Weight == 0 {
+		v.Weight = DefaultWeight
+	}
+	_, err = cli.P().AddSubnetValidator(ctx, k, v.SubnetID, v.NodeID, start, end, v.Weight, opts.ops()...)
+	return start, end, err
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/key"
+)
+
+func TestProvision(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cli := client.NewFake(constants.LocalID)
+	k, err := key.NewSoft(constants.LocalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := key.NewSoft(constants.LocalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cli.Fund(k.Addresses()[0], units.Avax)
+
+	subnetID, err := CreateSubnet(ctx, cli, k, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CreateBlockchain(ctx, cli, k, Blockchain{SubnetID: subnetID}, Options{}); !errors.Is(err, ErrEmptyChainName) {
+		t.Fatalf("expected %v, got %v", ErrEmptyChainName, err)
+	}
+	if _, err := CreateBlockchain(ctx, cli, other, Blockchain{SubnetID: subnetID, Name: "x"}, Options{}); !errors.Is(err, client.ErrCantSign) {
+		t.Fatalf("expected %v, got %v", client.ErrCantSign, err)
+	}
+	blockchainID, err := CreateBlockchain(ctx, cli, k, Blockchain{SubnetID: subnetID, Name: "x", Genesis: []byte("{}")}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := cli.P().BlockchainTx(ctx, blockchainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.SubnetID != subnetID || tx.ChainName != "x" {
+		t.Fatalf("unexpected blockchain %+v", tx)
+	}
+
+	nodeID := ids.ShortID{1}
+	now := time.Now()
+	v := SubnetValidator{SubnetID: subnetID, NodeID: nodeID}
+	if _, _, err := AddSubnetValidator(ctx, cli, k, v, Options{}); !errors.Is(err, client.ErrValidatorNotFound) {
+		t.Fatalf("expected %v, got %v", client.ErrValidatorNotFound, err)
+	}
+	cli.SetValidator(ids.Empty, client.Validator{NodeID: nodeID, Start: now, End: now.Add(30 * 24 * time.Hour)})
+	start, end, err := AddSubnetValidator(ctx, cli, k, v, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// pending until [start]
+	if _, _, err := cli.P().GetValidator(ctx, subnetID, nodeID); !errors.Is(err, client.ErrValidatorNotFound) {
+		t.Fatalf("expected %v, got %v", client.ErrValidatorNotFound, err)
+	}
+	pending, err := cli.P().GetPendingValidators(ctx, subnetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || !pending[0].Start.Equal(start) || !pending[0].End.Equal(end) {
+		t.Fatalf("expected a pending validator [%v, %v], got %+v", start, end, pending)
+	}
+	wctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	_, _, err = WaitValidator(wctx, cli, subnetID, nodeID, time.Millisecond)
+	cancel()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if _, _, err := AddSubnetValidator(ctx, cli, k, v, Options{}); !errors.Is(err, client.ErrAlreadySubnetValidator) {
+		t.Fatalf("expected %v, got %v", client.ErrAlreadySubnetValidator, err)
+	}
+
+	// 1 AVAX - 100 mAVAX (subnet) - 100 mAVAX (blockchain) - 1 mAVAX (subnet validator)
+	b, err := cli.P().Balance(ctx, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := units.Avax - 201*units.MilliAvax; b != expected {
+		t.Fatalf("expected balance %d, got %d", expected, b)
+	}
+	if _, err := CreateSubnet(ctx, cli, other, Options{}); !errors.Is(err, client.ErrInsufficientBalanceForGasFee) {
+		t.Fatalf("expected %v, got %v", client.ErrInsufficientBalanceForGasFee, err)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package subnet implements the subnet provisioning of subnet-cli as a
+// library, for the Go programs embedding it instead of running the CLI.
+// The functions do not prompt nor print, and return once the txs are
+// committed. The API is only extended, never broken, within a major version.
+package subnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/internal/staking"
+)
+
+const (
+	// DefaultStartBuffer is the time between now and the validation start
+	// time, when not set, for the tx to be accepted by the node.
+	DefaultStartBuffer = 30 * time.Second
+	// DefaultWeight is the subnet validator weight, when not set.
+	DefaultWeight = 1000
+	// DefaultPollInterval is the interval to poll the validator status.
+	DefaultPollInterval = 10 * time.Second
+)
+
+var (
+	ErrEmptyChainName = errors.New("empty chain name")
+	ErrNoNodeIDs      = errors.New("no node IDs")
+)
+
+// Key is the key signing (and paying for) the txs. It is implemented by
+// the keys returned by "LoadKey", or by any custom signer.
+type Key = key.Key
+
+// LoadKey loads the hex-encoded private key file (e.g., ".subnet-cli.pk"),
+// as created by "subnet-cli create key". The caller must close the key.
+func LoadKey(networkID uint32, path string) (Key, error) {
+	return key.LoadSoft(networkID, path)
+}
+
+// Options are the options common to all txs.
+type Options struct {
+	// Owners is the owner of the change and of the created subnet.
+	// Nil to default to the key's first address.
+	Owners *secp256k1fx.OutputOwners
+	// FeeKey pays the fee instead of the signing key, if not nil.
+	FeeKey Key
+	Memo   []byte
+}
+
+func (o Options) ops() []client.OpOption {
+	ops := []client.OpOption{
+		client.WithOutputOwners(o.Owners),
+		client.WithMemo(o.Memo),
+	}
+	if o.FeeKey != nil {
+		ops = append(ops, client.WithFeeKey(o.FeeKey))
+	}
+	return ops
+}
+
+// Period is the validation period of a validator.
+type Period struct {
+	// Start is the validation start time. Zero to start [StartBuffer]
+	// (or "DefaultStartBuffer") from now.
+	Start       time.Time
+	End         time.Time
+	StartBuffer time.Duration
+	// MinDuration and MaxDuration override the staking duration bounds
+	// of the network, if non-zero (e.g., for the custom networks).
+	MinDuration time.Duration
+	MaxDuration time.Duration
+}
+
+// resolve returns the validation period, checked against the staking
+// duration bounds of the network.
+func (p Period) resolve(networkID uint32, now time.Time) (time.Time, time.Time, error) {
+	buffer := p.StartBuffer
+	if buffer == 0 {
+		buffer = DefaultStartBuffer
+	}
+	start := p.Start
+	if start.IsZero() {
+		start = now.Add(buffer)
+	}
+	limits, err := staking.DefaultLimits(networkID).Override(p.MinDuration, p.MaxDuration)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if err := limits.Check(start, p.End, now, buffer); err != nil {
+		if errors.Is(err, staking.ErrStartTooSoon) {
+			return time.Time{}, time.Time{}, client.NewStartTimeTooSoon(start, now.Add(buffer))
+		}
+		return time.Time{}, time.Time{}, err
+	}
+	return start, p.End, nil
+}
+
+// WaitValidator polls every [interval] until [nodeID] validates the subnet
+// (the primary network, if [subnetID] is empty), and returns its validation
+// period. The query errors are retried, as the node may be restarting
+// (e.g., to track the subnet). It returns the context error once [ctx]
+// is done.
+func WaitValidator(
+	ctx context.Context,
+	cli client.Client,
+	subnetID ids.ID,
+	nodeID ids.ShortID,
+	interval time.Duration,
+) (start time.Time, end time.Time, err error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	for {
+		start, end, err = cli.P().GetValidator(ctx, subnetID, nodeID)
+		if err == nil {
+			return start, end, nil
+		}
+		if !errors.Is(err, client.ErrValidatorNotFound) {
+			zap.L().Debug("failed to get validator", zap.Stringer("nodeId", nodeID), zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return time.Time{}, time.Time{}, fmt.Errorf("%s: %w", nodeID, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
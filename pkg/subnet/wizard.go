@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnet
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/subnet-cli/client"
+)
+
+// Step is a step of the wizard.
+type Step string
+
+const (
+	StepAddValidator       Step = "add_validator"
+	StepCreateSubnet       Step = "create_subnet"
+	StepTrackSubnet        Step = "track_subnet"
+	StepAddSubnetValidator Step = "add_subnet_validator"
+	StepCreateBlockchain   Step = "create_blockchain"
+)
+
+// WizardConfig is the subnet to provision with "Wizard".
+type WizardConfig struct {
+	// NodeIDs are the subnet validators. The nodes not yet validating the
+	// primary network are added with "Validator" as a template.
+	NodeIDs   []ids.ShortID
+	Validator Validator
+	// Weight is the subnet validator weight, zero for "DefaultWeight".
+	Weight     uint64
+	Blockchain Blockchain
+	Options    Options
+
+	// TrackSubnet is called once the subnet is created, for the nodes to
+	// track it (e.g., add it to "--whitelisted-subnets" and restart) before
+	// they are added as subnet validators. Nil to skip.
+	TrackSubnet func(ctx context.Context, subnetID ids.ID) error
+	// OnStep is called once each step is done for a node (empty for the
+	// subnet-wide steps). Nil to skip.
+	OnStep func(step Step, nodeID ids.ShortID)
+	// PollInterval is the interval to poll the validator status, zero for
+	// "DefaultPollInterval".
+	PollInterval time.Duration
+}
+
+// WizardResult is the provisioned subnet.
+type WizardResult struct {
+	SubnetID     ids.ID
+	BlockchainID ids.ID
+	// Added are the nodes added to the primary network validators.
+	Added []ids.ShortID
+}
+
+// Wizard provisions a subnet in one go: it adds the nodes to the primary
+// network validators (if not yet), creates the subnet, adds the nodes to
+// the subnet validators, and creates the blockchain. "Blockchain.SubnetID"
+// is ignored. On error, the result holds the steps done so far.
+func Wizard(ctx context.Context, cli client.Client, k Key, cfg WizardConfig) (*WizardResult, error) {
+	if len(cfg.NodeIDs) == 0 {
+		return nil, ErrNoNodeIDs
+	}
+	if cfg.Blockchain.Name == "" {
+		return nil, ErrEmptyChainName
+	}
+	onStep := cfg.OnStep
+	if onStep == nil {
+		onStep = func(Step, ids.ShortID) {}
+	}
+	ret := &WizardResult{}
+
+	for _, nodeID := range cfg.NodeIDs {
+		_, _, err := cli.P().GetValidator(ctx, ids.Empty, nodeID)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, client.ErrValidatorNotFound) {
+			return ret, err
+		}
+		v := cfg.Validator
+		v.NodeID = nodeID
+		if _, _, err := AddValidator(ctx, cli, k, v, cfg.Options); err != nil {
+			return ret, err
+		}
+		ret.Added = append(ret.Added, nodeID)
+		onStep(StepAddValidator, nodeID)
+	}
+	// the subnet validation starts after the primary network one
+	for _, nodeID := range ret.Added {
+		if _, _, err := WaitValidator(ctx, cli, ids.Empty, nodeID, cfg.PollInterval); err != nil {
+			return ret, err
+		}
+	}
+
+	subnetID, err := CreateSubnet(ctx, cli, k, cfg.Options)
+	if err != nil {
+		return ret, err
+	}
+	ret.SubnetID = subnetID
+	onStep(StepCreateSubnet, ids.ShortEmpty)
+
+	if cfg.TrackSubnet != nil {
+		if err := cfg.TrackSubnet(ctx, subnetID); err != nil {
+			return ret, err
+		}
+		onStep(StepTrackSubnet, ids.ShortEmpty)
+	}
+
+	for _, nodeID := range cfg.NodeIDs {
+		if _, _, err := AddSubnetValidator(ctx, cli, k, SubnetValidator{
+			SubnetID: subnetID,
+			NodeID:   nodeID,
+			Period: Period{
+				StartBuffer: cfg.Validator.Period.StartBuffer,
+				MinDuration: cfg.Validator.Period.MinDuration,
+				MaxDuration: cfg.Validator.Period.MaxDuration,
+			},
+			Weight: cfg.Weight,
+		}, cfg.Options); err != nil {
+			return ret, err
+		}
+		onStep(StepAddSubnetValidator, nodeID)
+	}
+
+	bc := cfg.Blockchain
+	bc.SubnetID = subnetID
+	ret.BlockchainID, err = CreateBlockchain(ctx, cli, k, bc, cfg.Options)
+	if err != nil {
+		return ret, err
+	}
+	onStep(StepCreateBlockchain, ids.ShortEmpty)
+	return ret, nil
+}